@@ -73,7 +73,10 @@ import (
 	"github.com/uber/cadence/service/matching"
 	"github.com/uber/cadence/service/worker"
 	diagnosticsInvariant "github.com/uber/cadence/service/worker/diagnostics/invariant"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/child"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/decision"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/failure"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/historygrowth"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/retry"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/timeout"
 )
@@ -318,7 +321,25 @@ func (s *server) startService() common.Daemon {
 	}
 
 	params.KafkaConfig = s.cfg.Kafka
-	params.DiagnosticsInvariants = []diagnosticsInvariant.Invariant{timeout.NewInvariant(timeout.Params{Client: params.PublicClient}), failure.NewInvariant(), retry.NewInvariant()}
+	params.DiagnosticsInvariants = []diagnosticsInvariant.Invariant{
+		timeout.NewInvariant(timeout.Params{Client: params.PublicClient}),
+		failure.NewInvariant(),
+		retry.NewInvariant(),
+		decision.NewInvariant(decision.Params{
+			Client:                           params.PublicClient,
+			NonDeterministicFailureThreshold: dc.GetIntPropertyFilteredByDomain(dynamicproperties.DiagnosticsNonDeterministicFailureThreshold),
+			PendingDecisionAgeThreshold:      dc.GetDurationPropertyFilteredByDomain(dynamicproperties.DiagnosticsPendingDecisionAgeThreshold),
+			Logger:                           params.Logger,
+		}),
+		historygrowth.NewInvariant(historygrowth.Params{
+			HistoryCountLimitWarn:  dc.GetIntPropertyFilteredByDomain(dynamicproperties.HistoryCountLimitWarn),
+			HistoryCountLimitError: dc.GetIntPropertyFilteredByDomain(dynamicproperties.HistoryCountLimitError),
+			HistorySizeLimitWarn:   dc.GetIntPropertyFilteredByDomain(dynamicproperties.HistorySizeLimitWarn),
+			HistorySizeLimitError:  dc.GetIntPropertyFilteredByDomain(dynamicproperties.HistorySizeLimitError),
+			SignalStormThreshold:   dc.GetIntPropertyFilteredByDomain(dynamicproperties.DiagnosticsSignalStormThreshold),
+		}),
+		child.NewInvariant(),
+	}
 	params.ShardDistributorMatchingConfig = s.cfg.ShardDistributorMatchingConfig
 
 	params.Logger.Info("Starting service " + s.name)
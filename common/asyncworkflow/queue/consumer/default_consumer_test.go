@@ -121,6 +121,10 @@ func (m *fakeMessage) Nack() error {
 	return nil
 }
 
+func (m *fakeMessage) NackWithReason(string) error {
+	return m.Nack()
+}
+
 func TestDefaultConsumer(t *testing.T) {
 	tests := []struct {
 		name                         string
@@ -66,6 +66,11 @@ type (
 		// Reservation.Used(true/false) called as soon as possible to have the
 		// best chance of returning its unused token.
 		Reserve() Reservation
+		// ReserveN is Reserve for an event that costs n tokens.
+		//
+		// n is capped at Burst, so costly events are still allowed once the
+		// bucket is full rather than being rejected forever.
+		ReserveN(n int) Reservation
 		// SetBurst sets the Burst value
 		SetBurst(newBurst int)
 		// SetLimit sets the Limit value
@@ -275,9 +280,13 @@ func (r *ratelimiter) Allow() bool {
 }
 
 func (r *ratelimiter) Reserve() Reservation {
+	return r.ReserveN(1)
+}
+
+func (r *ratelimiter) ReserveN(n int) Reservation {
 	now, unlock := r.lockNow()
 	defer unlock()
-	res := r.limiter.ReserveN(now, 1)
+	res := r.limiter.ReserveN(now, max(1, min(n, r.limiter.Burst())))
 	if res.OK() && res.DelayFrom(now) == 0 {
 		// usable token, return a cancel-able handler in case it's not used
 		return &allowedReservation{
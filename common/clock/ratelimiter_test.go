@@ -464,6 +464,25 @@ func TestRatelimiterCoverage(t *testing.T) {
 		elapsed := time.Since(started)
 		assert.Less(t, elapsed, time.Second/10, "Wait should have returned almost immediately on impossible waits")
 	})
+	t.Run("reserve n", func(t *testing.T) {
+		ts := NewMockedTimeSource()
+		rl := NewRateLimiterWithTimeSource(ts, 10, 10)
+		r := rl.ReserveN(4)
+		require.True(t, r.Allow())
+		r.Used(true)
+		assert.InDelta(t, 6, rl.Tokens(), 0.001, "all of the cost should be consumed")
+
+		r = rl.ReserveN(7)
+		assert.False(t, r.Allow(), "cost above the available tokens should be rejected")
+		r.Used(false)
+		assert.InDelta(t, 6, rl.Tokens(), 0.001, "rejected reservations should not consume tokens")
+
+		ts.Advance(time.Second)
+		r = rl.ReserveN(100)
+		require.True(t, r.Allow(), "cost above the burst should be allowed with a full bucket")
+		r.Used(true)
+		assert.InDelta(t, 0, rl.Tokens(), 0.001, "cost above the burst should consume the whole burst")
+	})
 	t.Run("mock limiter constructor", func(t *testing.T) {
 		// covered by fuzz testing, but this gets it to 100% without fuzz.
 		_ = NewRateLimiterWithTimeSource(NewMockedTimeSource(), 1, 1)
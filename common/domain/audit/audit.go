@@ -1 +1,191 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package audit answers "who changed this domain and when" on top of the
+// before/after snapshots stored by persistence.DomainAuditManager.
 package audit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/uber/cadence/common/persistence"
+)
+
+const defaultQueryPageSize = 100
+
+// AllOperationTypes lists every operation type recorded in the domain audit log
+var AllOperationTypes = []persistence.DomainAuditOperationType{
+	persistence.DomainAuditOperationTypeCreate,
+	persistence.DomainAuditOperationTypeUpdate,
+	persistence.DomainAuditOperationTypeFailover,
+	persistence.DomainAuditOperationTypeDeprecate,
+	persistence.DomainAuditOperationTypeDelete,
+}
+
+type (
+	// Filter narrows down a domain audit log query
+	Filter struct {
+		DomainID string
+		// OperationTypes to include, all operation types are included when empty
+		OperationTypes []persistence.DomainAuditOperationType
+		// Identity only includes entries made by this actor when set
+		Identity string
+		// StartTime and EndTime bound the creation time of the entries, zero values are unbounded
+		StartTime time.Time
+		EndTime   time.Time
+		// Limit caps the number of returned entries, zero or negative means no limit
+		Limit    int
+		PageSize int
+	}
+
+	// Entry is a single domain audit log entry along with the fields it changed
+	Entry struct {
+		EventID       string        `json:"eventID"`
+		DomainID      string        `json:"domainID"`
+		DomainName    string        `json:"domainName,omitempty"`
+		OperationType string        `json:"operationType"`
+		CreatedTime   time.Time     `json:"createdTime"`
+		Identity      string        `json:"identity,omitempty"`
+		IdentityType  string        `json:"identityType,omitempty"`
+		Comment       string        `json:"comment,omitempty"`
+		Changes       []FieldChange `json:"changes"`
+	}
+)
+
+// ParseOperationType maps a case-insensitive operation name such as "failover" to its audit operation type
+func ParseOperationType(name string) (persistence.DomainAuditOperationType, error) {
+	for _, op := range AllOperationTypes {
+		if strings.EqualFold(op.String(), strings.TrimSpace(name)) {
+			return op, nil
+		}
+	}
+	return persistence.DomainAuditOperationTypeInvalid, fmt.Errorf("unknown domain audit operation type %q", name)
+}
+
+// Query returns the audit log entries of a domain matching the filter, newest first.
+// The audit log is partitioned by operation type, so each requested type is read
+// separately and the results are merged.
+func Query(
+	ctx context.Context,
+	manager persistence.DomainAuditManager,
+	filter Filter,
+) ([]*persistence.DomainAuditLog, error) {
+	if filter.DomainID == "" {
+		return nil, fmt.Errorf("domain ID is required")
+	}
+	if !filter.StartTime.IsZero() && !filter.EndTime.IsZero() && filter.EndTime.Before(filter.StartTime) {
+		return nil, fmt.Errorf("end time %v is before start time %v", filter.EndTime, filter.StartTime)
+	}
+
+	operationTypes := filter.OperationTypes
+	if len(operationTypes) == 0 {
+		operationTypes = AllOperationTypes
+	}
+	pageSize := filter.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultQueryPageSize
+	}
+
+	var result []*persistence.DomainAuditLog
+	for _, op := range operationTypes {
+		logs, err := queryOperationType(ctx, manager, filter, op, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, logs...)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].CreatedTime.Equal(result[j].CreatedTime) {
+			return result[i].EventID > result[j].EventID
+		}
+		return result[i].CreatedTime.After(result[j].CreatedTime)
+	})
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+	return result, nil
+}
+
+func queryOperationType(
+	ctx context.Context,
+	manager persistence.DomainAuditManager,
+	filter Filter,
+	op persistence.DomainAuditOperationType,
+	pageSize int,
+) ([]*persistence.DomainAuditLog, error) {
+	request := &persistence.GetDomainAuditLogsRequest{
+		DomainID:      filter.DomainID,
+		OperationType: op,
+		PageSize:      pageSize,
+	}
+	if !filter.StartTime.IsZero() {
+		request.MinCreatedTime = &filter.StartTime
+	}
+	if !filter.EndTime.IsZero() {
+		request.MaxCreatedTime = &filter.EndTime
+	}
+
+	var result []*persistence.DomainAuditLog
+	for {
+		resp, err := manager.GetDomainAuditLogs(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %v audit logs: %w", op, err)
+		}
+		for _, log := range resp.AuditLogs {
+			if filter.Identity != "" && log.Identity != filter.Identity {
+				continue
+			}
+			result = append(result, log)
+			// entries come back newest first, so anything past the limit would be cut after merging anyway
+			if filter.Limit > 0 && len(result) >= filter.Limit {
+				return result, nil
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			return result, nil
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+}
+
+// NewEntry converts an audit log into an entry with its field level diff
+func NewEntry(log *persistence.DomainAuditLog) *Entry {
+	entry := &Entry{
+		EventID:       log.EventID,
+		DomainID:      log.DomainID,
+		OperationType: log.OperationType.String(),
+		CreatedTime:   log.CreatedTime,
+		Identity:      log.Identity,
+		IdentityType:  log.IdentityType,
+		Comment:       log.Comment,
+		Changes:       Diff(log.StateBefore, log.StateAfter),
+	}
+	if info := log.StateAfter.GetInfo(); info != nil {
+		entry.DomainName = info.Name
+	} else if info := log.StateBefore.GetInfo(); info != nil {
+		entry.DomainName = info.Name
+	}
+	return entry
+}
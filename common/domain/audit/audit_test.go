@@ -0,0 +1,181 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/persistence"
+)
+
+func TestQuery(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	start := now.Add(-time.Hour)
+
+	ctrl := gomock.NewController(t)
+	manager := persistence.NewMockDomainAuditManager(ctrl)
+
+	manager.EXPECT().GetDomainAuditLogs(gomock.Any(), &persistence.GetDomainAuditLogsRequest{
+		DomainID:       "domain-id",
+		OperationType:  persistence.DomainAuditOperationTypeUpdate,
+		MinCreatedTime: &start,
+		PageSize:       2,
+	}).Return(&persistence.GetDomainAuditLogsResponse{
+		AuditLogs: []*persistence.DomainAuditLog{
+			{EventID: "update-3", CreatedTime: now.Add(-time.Minute), Identity: "alice"},
+			{EventID: "update-2", CreatedTime: now.Add(-3 * time.Minute), Identity: "bob"},
+		},
+		NextPageToken: []byte("next"),
+	}, nil)
+	manager.EXPECT().GetDomainAuditLogs(gomock.Any(), &persistence.GetDomainAuditLogsRequest{
+		DomainID:       "domain-id",
+		OperationType:  persistence.DomainAuditOperationTypeUpdate,
+		MinCreatedTime: &start,
+		PageSize:       2,
+		NextPageToken:  []byte("next"),
+	}).Return(&persistence.GetDomainAuditLogsResponse{
+		AuditLogs: []*persistence.DomainAuditLog{
+			{EventID: "update-1", CreatedTime: now.Add(-5 * time.Minute), Identity: "alice"},
+		},
+	}, nil)
+	manager.EXPECT().GetDomainAuditLogs(gomock.Any(), &persistence.GetDomainAuditLogsRequest{
+		DomainID:       "domain-id",
+		OperationType:  persistence.DomainAuditOperationTypeFailover,
+		MinCreatedTime: &start,
+		PageSize:       2,
+	}).Return(&persistence.GetDomainAuditLogsResponse{
+		AuditLogs: []*persistence.DomainAuditLog{
+			{EventID: "failover-1", CreatedTime: now.Add(-2 * time.Minute), Identity: "alice"},
+		},
+	}, nil)
+
+	logs, err := Query(context.Background(), manager, Filter{
+		DomainID: "domain-id",
+		OperationTypes: []persistence.DomainAuditOperationType{
+			persistence.DomainAuditOperationTypeUpdate,
+			persistence.DomainAuditOperationTypeFailover,
+		},
+		Identity:  "alice",
+		StartTime: start,
+		PageSize:  2,
+	})
+	require.NoError(t, err)
+
+	var ids []string
+	for _, log := range logs {
+		ids = append(ids, log.EventID)
+	}
+	assert.Equal(t, []string{"update-3", "failover-1", "update-1"}, ids)
+}
+
+func TestQuery_Limit(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	ctrl := gomock.NewController(t)
+	manager := persistence.NewMockDomainAuditManager(ctrl)
+
+	for _, op := range AllOperationTypes {
+		op := op
+		manager.EXPECT().GetDomainAuditLogs(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, request *persistence.GetDomainAuditLogsRequest) (*persistence.GetDomainAuditLogsResponse, error) {
+				assert.Equal(t, op, request.OperationType)
+				assert.Equal(t, defaultQueryPageSize, request.PageSize)
+				return &persistence.GetDomainAuditLogsResponse{
+					AuditLogs: []*persistence.DomainAuditLog{
+						{EventID: op.String() + "-2", CreatedTime: now.Add(-time.Duration(op) * time.Minute)},
+						{EventID: op.String() + "-1", CreatedTime: now.Add(-time.Duration(op) * time.Hour)},
+					},
+					// the second page is never read as the limit is already reached
+					NextPageToken: []byte("next"),
+				}, nil
+			})
+	}
+
+	logs, err := Query(context.Background(), manager, Filter{DomainID: "domain-id", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	assert.Equal(t, "Create-2", logs[0].EventID)
+	assert.Equal(t, "Update-2", logs[1].EventID)
+}
+
+func TestQuery_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	manager := persistence.NewMockDomainAuditManager(ctrl)
+
+	_, err := Query(context.Background(), manager, Filter{})
+	assert.ErrorContains(t, err, "domain ID is required")
+
+	now := time.Now()
+	_, err = Query(context.Background(), manager, Filter{DomainID: "domain-id", StartTime: now, EndTime: now.Add(-time.Hour)})
+	assert.ErrorContains(t, err, "is before start time")
+
+	manager.EXPECT().GetDomainAuditLogs(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+	_, err = Query(context.Background(), manager, Filter{DomainID: "domain-id"})
+	assert.ErrorContains(t, err, "failed to read Create audit logs: db down")
+}
+
+func TestParseOperationType(t *testing.T) {
+	op, err := ParseOperationType(" failover")
+	require.NoError(t, err)
+	assert.Equal(t, persistence.DomainAuditOperationTypeFailover, op)
+
+	_, err = ParseOperationType("rename")
+	assert.Error(t, err)
+}
+
+func TestNewEntry(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	entry := NewEntry(&persistence.DomainAuditLog{
+		EventID:       "event-id",
+		DomainID:      "domain-id",
+		OperationType: persistence.DomainAuditOperationTypeUpdate,
+		CreatedTime:   now,
+		Identity:      "alice",
+		IdentityType:  "user",
+		Comment:       "bump retention",
+		StateBefore: &persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{Name: "domain"},
+			Config: &persistence.DomainConfig{Retention: 3},
+		},
+		StateAfter: &persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{Name: "domain"},
+			Config: &persistence.DomainConfig{Retention: 5},
+		},
+	})
+
+	assert.Equal(t, &Entry{
+		EventID:       "event-id",
+		DomainID:      "domain-id",
+		DomainName:    "domain",
+		OperationType: "Update",
+		CreatedTime:   now,
+		Identity:      "alice",
+		IdentityType:  "user",
+		Comment:       "bump retention",
+		Changes:       []FieldChange{{Field: "config.retentionDays", Before: "3", After: "5"}},
+	}, entry)
+}
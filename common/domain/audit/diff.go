@@ -0,0 +1,155 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+// FieldChange is a single domain field whose value differs between two snapshots.
+// An empty Before means the field was introduced, an empty After means it was removed.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// Diff returns the field level changes between two domain snapshots, in a stable order.
+// Either snapshot may be nil, e.g. for the creation or deletion of a domain.
+func Diff(before, after *persistence.GetDomainResponse) []FieldChange {
+	b, a := flatten(before), flatten(after)
+
+	fields := make([]string, 0, len(b)+len(a))
+	for field := range b {
+		fields = append(fields, field)
+	}
+	for field := range a {
+		if _, ok := b[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	var changes []FieldChange
+	for _, field := range fields {
+		if b[field] != a[field] {
+			changes = append(changes, FieldChange{Field: field, Before: b[field], After: a[field]})
+		}
+	}
+	return changes
+}
+
+// flatten renders the audited parts of a domain as a map of field path to value
+func flatten(d *persistence.GetDomainResponse) map[string]string {
+	fields := map[string]string{}
+	if d == nil {
+		return fields
+	}
+
+	fields["isGlobalDomain"] = strconv.FormatBool(d.IsGlobalDomain)
+	fields["failoverVersion"] = strconv.FormatInt(d.FailoverVersion, 10)
+	fields["previousFailoverVersion"] = strconv.FormatInt(d.PreviousFailoverVersion, 10)
+	if d.FailoverEndTime != nil {
+		fields["failoverEndTime"] = strconv.FormatInt(*d.FailoverEndTime, 10)
+	}
+
+	if info := d.Info; info != nil {
+		fields["info.status"] = domainStatusString(info.Status)
+		setIfNotEmpty(fields, "info.description", info.Description)
+		setIfNotEmpty(fields, "info.ownerEmail", info.OwnerEmail)
+		for k, v := range info.Data {
+			fields["info.data."+k] = v
+		}
+	}
+
+	if config := d.Config; config != nil {
+		fields["config.retentionDays"] = strconv.Itoa(int(config.Retention))
+		fields["config.emitMetric"] = strconv.FormatBool(config.EmitMetric)
+		fields["config.historyArchivalStatus"] = config.HistoryArchivalStatus.String()
+		setIfNotEmpty(fields, "config.historyArchivalURI", config.HistoryArchivalURI)
+		fields["config.visibilityArchivalStatus"] = config.VisibilityArchivalStatus.String()
+		setIfNotEmpty(fields, "config.visibilityArchivalURI", config.VisibilityArchivalURI)
+		for name, partition := range config.IsolationGroups {
+			fields["config.isolationGroups."+name] = isolationGroupStateString(partition.State)
+		}
+		for checksum, info := range config.BadBinaries.Binaries {
+			if info != nil {
+				fields["config.badBinaries."+checksum] = info.Reason
+			}
+		}
+	}
+
+	if replication := d.ReplicationConfig; replication != nil {
+		setIfNotEmpty(fields, "replication.activeClusterName", replication.ActiveClusterName)
+		clusters := make([]string, 0, len(replication.Clusters))
+		for _, cluster := range replication.Clusters {
+			if cluster != nil {
+				clusters = append(clusters, cluster.ClusterName)
+			}
+		}
+		sort.Strings(clusters)
+		setIfNotEmpty(fields, "replication.clusters", strings.Join(clusters, ","))
+		if replication.ActiveClusters != nil {
+			for scope, attributes := range replication.ActiveClusters.AttributeScopes {
+				for name, info := range attributes.ClusterAttributes {
+					fields["replication.activeClusters."+scope+"."+name] = fmt.Sprintf("%s (failover version %d)", info.ActiveClusterName, info.FailoverVersion)
+				}
+			}
+		}
+	}
+	return fields
+}
+
+func setIfNotEmpty(fields map[string]string, field, value string) {
+	if value != "" {
+		fields[field] = value
+	}
+}
+
+func domainStatusString(status int) string {
+	switch status {
+	case persistence.DomainStatusRegistered:
+		return types.DomainStatusRegistered.String()
+	case persistence.DomainStatusDeprecated:
+		return types.DomainStatusDeprecated.String()
+	case persistence.DomainStatusDeleted:
+		return types.DomainStatusDeleted.String()
+	default:
+		return strconv.Itoa(status)
+	}
+}
+
+func isolationGroupStateString(state types.IsolationGroupState) string {
+	switch state {
+	case types.IsolationGroupStateHealthy:
+		return "Healthy"
+	case types.IsolationGroupStateDrained:
+		return "Drained"
+	default:
+		return "Invalid"
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestDiff(t *testing.T) {
+	base := func() *persistence.GetDomainResponse {
+		return &persistence.GetDomainResponse{
+			Info: &persistence.DomainInfo{
+				ID:     "domain-id",
+				Name:   "domain",
+				Status: persistence.DomainStatusRegistered,
+				Data:   map[string]string{"owner": "team-a"},
+			},
+			Config: &persistence.DomainConfig{
+				Retention:                3,
+				HistoryArchivalStatus:    types.ArchivalStatusDisabled,
+				VisibilityArchivalStatus: types.ArchivalStatusDisabled,
+				IsolationGroups: types.IsolationGroupConfiguration{
+					"zone-1": {Name: "zone-1", State: types.IsolationGroupStateHealthy},
+				},
+			},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: "cluster-a",
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: "cluster-b"},
+					{ClusterName: "cluster-a"},
+				},
+			},
+			IsGlobalDomain:  true,
+			FailoverVersion: 1,
+		}
+	}
+
+	tests := map[string]struct {
+		before   *persistence.GetDomainResponse
+		after    func() *persistence.GetDomainResponse
+		expected []FieldChange
+	}{
+		"no changes": {
+			before:   base(),
+			after:    base,
+			expected: nil,
+		},
+		"retention and archival": {
+			before: base(),
+			after: func() *persistence.GetDomainResponse {
+				d := base()
+				d.Config.Retention = 7
+				d.Config.HistoryArchivalStatus = types.ArchivalStatusEnabled
+				d.Config.HistoryArchivalURI = "file:///tmp/history"
+				return d
+			},
+			expected: []FieldChange{
+				{Field: "config.historyArchivalStatus", Before: "DISABLED", After: "ENABLED"},
+				{Field: "config.historyArchivalURI", After: "file:///tmp/history"},
+				{Field: "config.retentionDays", Before: "3", After: "7"},
+			},
+		},
+		"isolation groups and domain data": {
+			before: base(),
+			after: func() *persistence.GetDomainResponse {
+				d := base()
+				d.Config.IsolationGroups = types.IsolationGroupConfiguration{
+					"zone-1": {Name: "zone-1", State: types.IsolationGroupStateDrained},
+					"zone-2": {Name: "zone-2", State: types.IsolationGroupStateHealthy},
+				}
+				d.Info.Data = nil
+				return d
+			},
+			expected: []FieldChange{
+				{Field: "config.isolationGroups.zone-1", Before: "Healthy", After: "Drained"},
+				{Field: "config.isolationGroups.zone-2", After: "Healthy"},
+				{Field: "info.data.owner", Before: "team-a"},
+			},
+		},
+		"failover and cluster list": {
+			before: base(),
+			after: func() *persistence.GetDomainResponse {
+				d := base()
+				d.ReplicationConfig.ActiveClusterName = "cluster-b"
+				d.ReplicationConfig.Clusters = append(d.ReplicationConfig.Clusters, &persistence.ClusterReplicationConfig{ClusterName: "cluster-c"})
+				d.ReplicationConfig.ActiveClusters = &types.ActiveClusters{
+					AttributeScopes: map[string]types.ClusterAttributeScope{
+						"region": {ClusterAttributes: map[string]types.ActiveClusterInfo{
+							"us-west": {ActiveClusterName: "cluster-b", FailoverVersion: 11},
+						}},
+					},
+				}
+				d.PreviousFailoverVersion = 1
+				d.FailoverVersion = 11
+				return d
+			},
+			expected: []FieldChange{
+				{Field: "failoverVersion", Before: "1", After: "11"},
+				{Field: "previousFailoverVersion", Before: "0", After: "1"},
+				{Field: "replication.activeClusterName", Before: "cluster-a", After: "cluster-b"},
+				{Field: "replication.activeClusters.region.us-west", After: "cluster-b (failover version 11)"},
+				{Field: "replication.clusters", Before: "cluster-a,cluster-b", After: "cluster-a,cluster-b,cluster-c"},
+			},
+		},
+		"deprecation": {
+			before: base(),
+			after: func() *persistence.GetDomainResponse {
+				d := base()
+				d.Info.Status = persistence.DomainStatusDeprecated
+				return d
+			},
+			expected: []FieldChange{
+				{Field: "info.status", Before: "REGISTERED", After: "DEPRECATED"},
+			},
+		},
+		"creation": {
+			before: nil,
+			after: func() *persistence.GetDomainResponse {
+				return &persistence.GetDomainResponse{
+					Info:   &persistence.DomainInfo{Status: persistence.DomainStatusRegistered},
+					Config: &persistence.DomainConfig{Retention: 1},
+				}
+			},
+			expected: []FieldChange{
+				{Field: "config.emitMetric", After: "false"},
+				{Field: "config.historyArchivalStatus", After: "DISABLED"},
+				{Field: "config.retentionDays", After: "1"},
+				{Field: "config.visibilityArchivalStatus", After: "DISABLED"},
+				{Field: "failoverVersion", After: "0"},
+				{Field: "info.status", After: "REGISTERED"},
+				{Field: "isGlobalDomain", After: "false"},
+				{Field: "previousFailoverVersion", After: "0"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Diff(tc.before, tc.after()))
+		})
+	}
+}
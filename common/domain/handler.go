@@ -25,6 +25,7 @@ package domain
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
 	"time"
@@ -37,6 +38,7 @@ import (
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/domain/audit"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
@@ -89,6 +91,11 @@ type (
 			ctx context.Context,
 			updateRequest types.UpdateDomainAsyncWorkflowConfiguratonRequest,
 		) error
+		ListDomainAuditLogs(
+			ctx context.Context,
+			domainName string,
+			filter audit.Filter,
+		) ([]*audit.Entry, error)
 	}
 
 	// handlerImpl is the domain operation handler implementation
@@ -1355,6 +1362,43 @@ func (d *handlerImpl) UpdateAsyncWorkflowConfiguraton(
 	return nil
 }
 
+// ListDomainAuditLogs returns the audit log entries of a domain matching the filter, newest first,
+// along with the fields each entry changed. The audit log is keyed on the domain ID, so the name is
+// only resolved when the filter has no domain ID; deleted or renamed domains are audited by their ID.
+func (d *handlerImpl) ListDomainAuditLogs(
+	ctx context.Context,
+	domainName string,
+	filter audit.Filter,
+) ([]*audit.Entry, error) {
+
+	if d.domainAuditManager == nil {
+		return nil, &types.InternalServiceError{Message: "Domain audit log is not available."}
+	}
+	if filter.DomainID == "" {
+		resp, err := d.domainManager.GetDomain(ctx, &persistence.GetDomainRequest{Name: domainName})
+		if err != nil {
+			var notExists *types.EntityNotExistsError
+			if errors.As(err, &notExists) {
+				return nil, &types.EntityNotExistsError{
+					Message: fmt.Sprintf("Domain %v does not exist, the audit log of a deleted or renamed domain is listed by its domain ID.", domainName),
+				}
+			}
+			return nil, err
+		}
+		filter.DomainID = resp.Info.ID
+	}
+
+	logs, err := audit.Query(ctx, d.domainAuditManager, filter)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*audit.Entry, 0, len(logs))
+	for _, log := range logs {
+		entries = append(entries, audit.NewEntry(log))
+	}
+	return entries, nil
+}
+
 func (d *handlerImpl) createResponse(
 	info *persistence.DomainInfo,
 	config *persistence.DomainConfig,
@@ -15,6 +15,7 @@ import (
 
 	gomock "go.uber.org/mock/gomock"
 
+	audit "github.com/uber/cadence/common/domain/audit"
 	types "github.com/uber/cadence/common/types"
 )
 
@@ -100,6 +101,21 @@ func (mr *MockHandlerMockRecorder) FailoverDomain(ctx, failoverRequest any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailoverDomain", reflect.TypeOf((*MockHandler)(nil).FailoverDomain), ctx, failoverRequest)
 }
 
+// ListDomainAuditLogs mocks base method.
+func (m *MockHandler) ListDomainAuditLogs(ctx context.Context, domainName string, filter audit.Filter) ([]*audit.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDomainAuditLogs", ctx, domainName, filter)
+	ret0, _ := ret[0].([]*audit.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDomainAuditLogs indicates an expected call of ListDomainAuditLogs.
+func (mr *MockHandlerMockRecorder) ListDomainAuditLogs(ctx, domainName, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDomainAuditLogs", reflect.TypeOf((*MockHandler)(nil).ListDomainAuditLogs), ctx, domainName, filter)
+}
+
 // ListDomains mocks base method.
 func (m *MockHandler) ListDomains(ctx context.Context, listRequest *types.ListDomainsRequest) (*types.ListDomainsResponse, error) {
 	m.ctrl.T.Helper()
@@ -41,6 +41,7 @@ import (
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/config"
 	commonconstants "github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/domain/audit"
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
@@ -702,6 +703,109 @@ func TestHandler_DescribeDomain(t *testing.T) {
 	assert.IsType(t, types.EntityNotExistsError{}, err)
 }
 
+func TestHandler_ListDomainAuditLogs(t *testing.T) {
+	domainName := "test-domain"
+	domainID := "test-domain-id"
+	auditLog := &persistence.DomainAuditLog{
+		EventID:       "event-id",
+		DomainID:      domainID,
+		OperationType: persistence.DomainAuditOperationTypeUpdate,
+		Identity:      "alice",
+		StateBefore:   &persistence.GetDomainResponse{Config: &persistence.DomainConfig{Retention: 3}},
+		StateAfter:    &persistence.GetDomainResponse{Config: &persistence.DomainConfig{Retention: 7}},
+	}
+
+	tests := []struct {
+		name        string
+		noAuditLog  bool
+		domainID    string
+		setupMocks  func(*persistence.MockDomainManager, *persistence.MockDomainAuditManager)
+		wantEntries []*audit.Entry
+		wantErr     error
+	}{
+		{
+			name:       "audit log not available",
+			noAuditLog: true,
+			setupMocks: func(*persistence.MockDomainManager, *persistence.MockDomainAuditManager) {},
+			wantErr:    &types.InternalServiceError{Message: "Domain audit log is not available."},
+		},
+		{
+			name: "domain not found",
+			setupMocks: func(domainManager *persistence.MockDomainManager, _ *persistence.MockDomainAuditManager) {
+				domainManager.EXPECT().GetDomain(gomock.Any(), &persistence.GetDomainRequest{Name: domainName}).
+					Return(nil, &types.EntityNotExistsError{Message: "not found"})
+			},
+			wantErr: &types.EntityNotExistsError{
+				Message: "Domain test-domain does not exist, the audit log of a deleted or renamed domain is listed by its domain ID.",
+			},
+		},
+		{
+			name: "domain lookup fails",
+			setupMocks: func(domainManager *persistence.MockDomainManager, _ *persistence.MockDomainAuditManager) {
+				domainManager.EXPECT().GetDomain(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			wantErr: errors.New("db down"),
+		},
+		{
+			name:     "deleted domain by its ID",
+			domainID: domainID,
+			setupMocks: func(_ *persistence.MockDomainManager, auditManager *persistence.MockDomainAuditManager) {
+				auditManager.EXPECT().GetDomainAuditLogs(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, request *persistence.GetDomainAuditLogsRequest) (*persistence.GetDomainAuditLogsResponse, error) {
+						assert.Equal(t, domainID, request.DomainID)
+						return &persistence.GetDomainAuditLogsResponse{AuditLogs: []*persistence.DomainAuditLog{auditLog}}, nil
+					})
+			},
+			wantEntries: []*audit.Entry{audit.NewEntry(auditLog)},
+		},
+		{
+			name: "entries of the domain with their changes",
+			setupMocks: func(domainManager *persistence.MockDomainManager, auditManager *persistence.MockDomainAuditManager) {
+				domainManager.EXPECT().GetDomain(gomock.Any(), &persistence.GetDomainRequest{Name: domainName}).
+					Return(&persistence.GetDomainResponse{Info: &persistence.DomainInfo{ID: domainID, Name: domainName}}, nil)
+				auditManager.EXPECT().GetDomainAuditLogs(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, request *persistence.GetDomainAuditLogsRequest) (*persistence.GetDomainAuditLogsResponse, error) {
+						assert.Equal(t, domainID, request.DomainID)
+						assert.Equal(t, persistence.DomainAuditOperationTypeUpdate, request.OperationType)
+						return &persistence.GetDomainAuditLogsResponse{AuditLogs: []*persistence.DomainAuditLog{auditLog}}, nil
+					})
+			},
+			wantEntries: []*audit.Entry{audit.NewEntry(auditLog)},
+		},
+		{
+			name: "query fails",
+			setupMocks: func(domainManager *persistence.MockDomainManager, auditManager *persistence.MockDomainAuditManager) {
+				domainManager.EXPECT().GetDomain(gomock.Any(), gomock.Any()).
+					Return(&persistence.GetDomainResponse{Info: &persistence.DomainInfo{ID: domainID, Name: domainName}}, nil)
+				auditManager.EXPECT().GetDomainAuditLogs(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			wantErr: fmt.Errorf("failed to read Update audit logs: %w", errors.New("db down")),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			mockDomainManager := persistence.NewMockDomainManager(controller)
+			mockDomainAuditManager := persistence.NewMockDomainAuditManager(controller)
+			tc.setupMocks(mockDomainManager, mockDomainAuditManager)
+
+			handler := newTestHandler(t, controller, mockDomainManager, true, NewMockReplicator(controller)).(*handlerImpl)
+			handler.domainAuditManager = mockDomainAuditManager
+			if tc.noAuditLog {
+				handler.domainAuditManager = nil
+			}
+
+			entries, err := handler.ListDomainAuditLogs(context.Background(), domainName, audit.Filter{
+				DomainID:       tc.domainID,
+				OperationTypes: []persistence.DomainAuditOperationType{persistence.DomainAuditOperationTypeUpdate},
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantEntries, entries)
+		})
+	}
+}
+
 func TestHandler_DeprecateDomain(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -36,8 +36,7 @@ const (
 	NopClient         = "nop"
 )
 
-// Client allows fetching values from a dynamic configuration system NOTE: This does not have async
-// options right now. In the interest of keeping it minimal, we can add when requirement arises.
+// Client allows fetching values from a dynamic configuration system and subscribing to their changes
 type Client interface {
 	GetValue(name dynamicproperties.Key) (interface{}, error)
 	GetValueWithFilters(name dynamicproperties.Key, filters map[dynamicproperties.Filter]interface{}) (interface{}, error)
@@ -53,6 +52,9 @@ type Client interface {
 	UpdateValue(name dynamicproperties.Key, value interface{}) error
 	RestoreValue(name dynamicproperties.Key, filters map[dynamicproperties.Filter]interface{}) error
 	ListValue(name dynamicproperties.Key) ([]*types.DynamicConfigEntry, error)
+	// Subscribe calls onChange with the new value of name for filters, as returned by GetValueWithFilters,
+	// each time the client observes that it changed. The returned function cancels the subscription.
+	Subscribe(name dynamicproperties.Key, filters map[dynamicproperties.Filter]interface{}, onChange func(value interface{})) func()
 }
 
 var NotFoundError = &types.EntityNotExistsError{
@@ -564,6 +564,14 @@ func (c *Collection) GetStringPropertyFilteredByRatelimitKey(key dynamicproperti
 	}
 }
 
+// Subscribe calls onChange whenever the value of key for the filters of opts changes, the new value can then be read
+// with the property functions of the collection. The returned function cancels the subscription.
+func (c *Collection) Subscribe(key dynamicproperties.Key, onChange func(), opts ...dynamicproperties.FilterOption) func() {
+	return c.client.Subscribe(key, c.toFilterMap(opts...), func(interface{}) {
+		onChange()
+	})
+}
+
 func (c *Collection) toFilterMap(opts ...dynamicproperties.FilterOption) map[dynamicproperties.Filter]interface{} {
 	l := len(opts)
 	m := make(map[dynamicproperties.Filter]interface{}, l)
@@ -31,6 +31,7 @@ import (
 	"time"
 
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/config"
 	dc "github.com/uber/cadence/common/dynamicconfig"
 	csc "github.com/uber/cadence/common/dynamicconfig/configstore/config"
@@ -76,12 +77,24 @@ type configStoreClient struct {
 	doneCh             chan struct{}
 	wg                 sync.WaitGroup
 	logger             log.Logger
+	timeSource         clock.TimeSource
+	subscriptions      *dc.Subscriptions
 }
 
 type cacheEntry struct {
 	cacheVersion  int64
 	schemaVersion int64
 	dcEntries     map[string]*types.DynamicConfigEntry
+	// parsedValues are the values of dcEntries with their override policy decoded once per snapshot
+	parsedValues map[string][]parsedValue
+}
+
+// parsedValue is a config store value split into its override policy and the filters used for lookups
+type parsedValue struct {
+	value         *types.DynamicConfigValue
+	override      dynamicproperties.Override
+	lookupFilters []*types.DynamicConfigFilter
+	err           error
 }
 
 // NewConfigStoreClient creates a config store client
@@ -153,6 +166,8 @@ func newConfigStoreClient(
 		configStoreManager: persistence.NewConfigStoreManagerImpl(store, logger),
 		logger:             logger,
 		configStoreType:    configType,
+		timeSource:         clock.NewRealTimeSource(),
+		subscriptions:      dc.NewSubscriptions(),
 	}
 
 	return client, nil
@@ -301,7 +316,18 @@ func (csc *configStoreClient) UpdateValue(name dynamicproperties.Key, value inte
 	if !ok && value != nil {
 		return errors.New("invalid value")
 	}
-	return csc.updateValue(name, dcValues, csc.config.UpdateRetryAttempts)
+	dcValues, expectedDigest, hasPrecondition, err := dynamicproperties.SplitPrecondition(dcValues)
+	if err != nil {
+		return &types.BadRequestError{Message: err.Error()}
+	}
+	if hasPrecondition {
+		// the precondition is checked against the latest snapshot rather than the cached one
+		if err := csc.update(); err != nil {
+			return err
+		}
+		return csc.updateValue(name, dcValues, &expectedDigest, csc.config.UpdateRetryAttempts)
+	}
+	return csc.updateValue(name, dcValues, nil, csc.config.UpdateRetryAttempts)
 }
 
 func (csc *configStoreClient) RestoreValue(name dynamicproperties.Key, filters map[dynamicproperties.Filter]interface{}) error {
@@ -325,19 +351,19 @@ func (csc *configStoreClient) RestoreValue(name dynamicproperties.Key, filters m
 	newValues := make([]*types.DynamicConfigValue, 0, len(val.Values))
 	if filters == nil {
 		for _, dcValue := range val.Values {
-			if dcValue.Filters != nil || len(dcValue.Filters) != 0 {
+			if hasLookupFilters(dcValue) {
 				newValues = append(newValues, dcValue.Copy())
 			}
 		}
 	} else {
 		for _, dcValue := range val.Values {
-			if !matchFilters(dcValue, filters) || dcValue.Filters == nil || len(dcValue.Filters) == 0 {
+			if !matchFilters(dcValue, filters) || !hasLookupFilters(dcValue) {
 				newValues = append(newValues, dcValue.Copy())
 			}
 		}
 	}
 
-	return csc.updateValue(name, newValues, csc.config.UpdateRetryAttempts)
+	return csc.updateValue(name, newValues, nil, csc.config.UpdateRetryAttempts)
 }
 
 func (csc *configStoreClient) ListValue(name dynamicproperties.Key) ([]*types.DynamicConfigEntry, error) {
@@ -375,6 +401,12 @@ func (csc *configStoreClient) ListValue(name dynamicproperties.Key) ([]*types.Dy
 	return resList, nil
 }
 
+// Subscribe notifies onChange of changes observed when polling the config store. Subscribers are also notified
+// when values expire or their rollout widens, as values are evaluated again after each poll.
+func (csc *configStoreClient) Subscribe(name dynamicproperties.Key, filters map[dynamicproperties.Filter]interface{}, onChange func(value interface{})) func() {
+	return csc.subscriptions.Subscribe(csc.GetValueWithFilters, name, filters, onChange)
+}
+
 func (csc *configStoreClient) Stop() {
 	if !atomic.CompareAndSwapInt32(&csc.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
 		return
@@ -394,14 +426,14 @@ func (csc *configStoreClient) Start() {
 	}
 }
 
-func (csc *configStoreClient) updateValue(name dynamicproperties.Key, dcValues []*types.DynamicConfigValue, retryAttempts int) error {
+// updateValue replaces the values of name. If expectedDigest is set, the values currently stored for name must have
+// this digest, the snapshot version then guarantees they did not change until the update is written.
+func (csc *configStoreClient) updateValue(name dynamicproperties.Key, dcValues []*types.DynamicConfigValue, expectedDigest *string, retryAttempts int) error {
 	// since values are not unique, no way to know if you are trying to update a specific value
 	// or if you want to add another of the same value with different filters.
 	// UpdateValue will replace everything associated with dc key.
-	for _, dcValue := range dcValues {
-		if err := validateKeyDataBlobPair(name, dcValue.Value); err != nil {
-			return err
-		}
+	if err := dynamicproperties.ValidateConfigValues(name, dcValues); err != nil {
+		return err
 	}
 	loaded := csc.values.Load()
 	var currentCached cacheEntry
@@ -419,6 +451,19 @@ func (csc *configStoreClient) updateValue(name dynamicproperties.Key, dcValues [
 	var newEntries []*types.DynamicConfigEntry
 
 	existingEntry, entryExists := currentCached.dcEntries[keyName]
+	if expectedDigest != nil {
+		var existingValues []*types.DynamicConfigValue
+		if entryExists && existingEntry != nil {
+			existingValues = existingEntry.Values
+		}
+		digest, err := dynamicproperties.ValuesDigest(existingValues)
+		if err != nil {
+			return err
+		}
+		if digest != *expectedDigest {
+			return &types.BadRequestError{Message: fmt.Sprintf("values of %s were changed since they were read, read them again and retry", keyName)}
+		}
+	}
 
 	if len(dcValues) == 0 {
 		newEntries = make([]*types.DynamicConfigEntry, 0, len(currentCached.dcEntries))
@@ -485,7 +530,7 @@ func (csc *configStoreClient) updateValue(name dynamicproperties.Key, dcValues [
 				if err != nil {
 					return err
 				}
-				return csc.updateValue(name, dcValues, retryAttempts-1)
+				return csc.updateValue(name, dcValues, expectedDigest, retryAttempts-1)
 			}
 
 			if retryAttempts == 0 {
@@ -525,12 +570,15 @@ func (csc *configStoreClient) update() error {
 func (csc *configStoreClient) storeValues(snapshot *persistence.DynamicConfigSnapshot) error {
 	// Converting the list of dynamic config entries into a map for better lookup performance
 	var dcEntryMap map[string]*types.DynamicConfigEntry
+	var parsedValueMap map[string][]parsedValue
 	if snapshot.Values.Entries == nil {
 		dcEntryMap = nil
 	} else {
 		dcEntryMap = make(map[string]*types.DynamicConfigEntry)
+		parsedValueMap = make(map[string][]parsedValue)
 		for _, entry := range snapshot.Values.Entries {
 			dcEntryMap[entry.Name] = entry
+			parsedValueMap[entry.Name] = parseValues(entry.Values)
 		}
 	}
 
@@ -538,8 +586,10 @@ func (csc *configStoreClient) storeValues(snapshot *persistence.DynamicConfigSna
 		cacheVersion:  snapshot.Version,
 		schemaVersion: snapshot.Values.SchemaVersion,
 		dcEntries:     dcEntryMap,
+		parsedValues:  parsedValueMap,
 	})
 	csc.logger.Debug("Updated dynamic config")
+	csc.subscriptions.Notify(csc.GetValueWithFilters)
 	return nil
 }
 
@@ -550,37 +600,90 @@ func (csc *configStoreClient) getValueWithFilters(key dynamicproperties.Key, fil
 		return defaultValue, nil
 	}
 	cached := loaded.(cacheEntry)
-	found := false
 
-	if entry, ok := cached.dcEntries[keyName]; ok && entry != nil {
-		for _, dcValue := range entry.Values {
-			if len(dcValue.Filters) == 0 {
-				parsedVal, err := convertFromDataBlob(dcValue.Value)
+	if values, ok := cached.parsedValues[keyName]; ok {
+		value, found, err := effectiveValue(values, filters, csc.timeSource.Now())
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return value, nil
+		}
+	}
+	return defaultValue, dc.NotFoundError
+}
 
-				if err == nil {
-					defaultValue = parsedVal
-					found = true
-				}
-				continue
+// EffectiveValue resolves values of a key the way the config store does for a lookup with filters at now:
+// the first value whose filters are all matched wins, otherwise the last value without filters is used.
+// Values that expired or whose rollout does not cover the lookup yet are skipped.
+// found is false if no value applies.
+func EffectiveValue(values []*types.DynamicConfigValue, filters map[dynamicproperties.Filter]interface{}, now time.Time) (interface{}, bool, error) {
+	return effectiveValue(parseValues(values), filters, now)
+}
+
+func parseValues(values []*types.DynamicConfigValue) []parsedValue {
+	parsed := make([]parsedValue, 0, len(values))
+	for _, dcValue := range values {
+		override, lookupFilters, err := dynamicproperties.ParseOverride(dcValue.Filters)
+		parsed = append(parsed, parsedValue{
+			value:         dcValue,
+			override:      override,
+			lookupFilters: lookupFilters,
+			err:           err,
+		})
+	}
+	return parsed
+}
+
+func effectiveValue(values []parsedValue, filters map[dynamicproperties.Filter]interface{}, now time.Time) (interface{}, bool, error) {
+	var fallback interface{}
+	found := false
+	for _, parsed := range values {
+		if parsed.err != nil || !parsed.override.Applies(now, filters) {
+			continue
+		}
+		dcValue, lookupFilters := parsed.value, parsed.lookupFilters
+		if len(lookupFilters) == 0 {
+			parsedVal, err := convertFromDataBlob(dcValue.Value)
+			if err == nil {
+				fallback = parsedVal
+				found = true
 			}
+			continue
+		}
 
-			if matchFilters(dcValue, filters) {
-				return convertFromDataBlob(dcValue.Value)
+		if matchLookupFilters(lookupFilters, filters) {
+			parsedVal, err := convertFromDataBlob(dcValue.Value)
+			if err != nil {
+				return nil, false, err
 			}
+			return parsedVal, true, nil
 		}
 	}
-	if found {
-		return defaultValue, nil
-	}
-	return defaultValue, dc.NotFoundError
+	return fallback, found, nil
 }
 
+// matchFilters returns true if the filters of dcValue, except those of its override policy, are all matched
 func matchFilters(dcValue *types.DynamicConfigValue, filters map[dynamicproperties.Filter]interface{}) bool {
-	if len(dcValue.Filters) > len(filters) {
+	_, lookupFilters, err := dynamicproperties.ParseOverride(dcValue.Filters)
+	if err != nil {
 		return false
 	}
+	return matchLookupFilters(lookupFilters, filters)
+}
 
-	for _, valueFilter := range dcValue.Filters {
+// hasLookupFilters returns false for fallback values, whatever their override policy
+func hasLookupFilters(dcValue *types.DynamicConfigValue) bool {
+	_, lookupFilters, err := dynamicproperties.ParseOverride(dcValue.Filters)
+	return err != nil || len(lookupFilters) > 0
+}
+
+func matchLookupFilters(valueFilters []*types.DynamicConfigFilter, filters map[dynamicproperties.Filter]interface{}) bool {
+	if len(valueFilters) > len(filters) {
+		return false
+	}
+
+	for _, valueFilter := range valueFilters {
 		filterKey := dynamicproperties.ParseFilter(valueFilter.Name)
 		if filters[filterKey] == nil {
 			return false
@@ -623,56 +726,3 @@ func convertFromDataBlob(blob *types.DataBlob) (interface{}, error) {
 		return nil, errors.New("unsupported blob encoding")
 	}
 }
-
-func validateKeyDataBlobPair(key dynamicproperties.Key, blob *types.DataBlob) error {
-	value, err := convertFromDataBlob(blob)
-	if err != nil {
-		return err
-	}
-	err = fmt.Errorf("key value pair mismatch, key type: %T, value type: %T", key, value)
-	switch key.(type) {
-	case dynamicproperties.IntKey:
-		if _, ok := value.(int); !ok {
-			floatVal, ok := value.(float64)
-			if !ok { // int can be decoded as float64
-				return err
-			}
-			if floatVal != math.Trunc(floatVal) {
-				return errors.New("value type is not int")
-			}
-		}
-	case dynamicproperties.BoolKey:
-		if _, ok := value.(bool); !ok {
-			return err
-		}
-	case dynamicproperties.FloatKey:
-		if _, ok := value.(float64); !ok {
-			return err
-		}
-	case dynamicproperties.StringKey:
-		if _, ok := value.(string); !ok {
-			return err
-		}
-	case dynamicproperties.DurationKey:
-		if _, ok := value.(time.Duration); !ok {
-			durationStr, ok := value.(string)
-			if !ok {
-				return err
-			}
-			if _, err = time.ParseDuration(durationStr); err != nil {
-				return errors.New("value string encoding cannot be parsed into duration")
-			}
-		}
-	case dynamicproperties.MapKey:
-		if _, ok := value.(map[string]interface{}); !ok {
-			return err
-		}
-	case dynamicproperties.ListKey:
-		if _, ok := value.([]interface{}); !ok {
-			return err
-		}
-	default:
-		return fmt.Errorf("unknown key type: %T", key)
-	}
-	return nil
-}
@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 
+	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/config"
 	c "github.com/uber/cadence/common/dynamicconfig/configstore/config"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
@@ -344,6 +345,41 @@ func (s *configStoreClientSuite) TestGetValue() {
 	s.Equal(false, v)
 }
 
+func (s *configStoreClientSuite) TestGetValue_ExpiringValue() {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeSource := clock.NewMockedTimeSourceAt(now)
+	s.client.timeSource = timeSource
+	expiresAt, err := dynamicproperties.OverrideFilters(dynamicproperties.Override{ExpiresAt: now.Add(time.Hour)})
+	s.NoError(err)
+	s.NoError(s.client.storeValues(&p.DynamicConfigSnapshot{
+		Version: 1,
+		Values: &types.DynamicConfigBlob{
+			SchemaVersion: 1,
+			Entries: []*types.DynamicConfigEntry{{
+				Name: dynamicproperties.TestGetIntPropertyKey.String(),
+				Values: []*types.DynamicConfigValue{
+					{Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: jsonMarshalHelper(10)}, Filters: expiresAt},
+				},
+			}},
+		},
+	}))
+
+	// the override policy is decoded when the snapshot is stored, not on every lookup
+	parsed := s.client.values.Load().(cacheEntry).parsedValues[dynamicproperties.TestGetIntPropertyKey.String()]
+	s.Len(parsed, 1)
+	s.Equal(now.Add(time.Hour), parsed[0].override.ExpiresAt)
+	s.Empty(parsed[0].lookupFilters)
+
+	v, err := s.client.GetIntValue(dynamicproperties.TestGetIntPropertyKey, nil)
+	s.NoError(err)
+	s.Equal(10, v)
+
+	timeSource.Advance(time.Hour)
+	v, err = s.client.GetIntValue(dynamicproperties.TestGetIntPropertyKey, nil)
+	s.Equal(dynamicproperties.TestGetIntPropertyKey.DefaultInt(), v)
+	s.Error(err)
+}
+
 func (s *configStoreClientSuite) TestGetValue_NonExistKey() {
 	defaultTestSetup(s)
 	v, err := s.client.GetValue(dynamicproperties.MaxRetentionDays)
@@ -780,6 +816,37 @@ func (s *configStoreClientSuite) TestUpdateValue_SuccessNewKey() {
 	s.NoError(err)
 }
 
+func (s *configStoreClientSuite) TestSubscribe() {
+	snapshot := func(version int64, value bool) *p.FetchDynamicConfigResponse {
+		return &p.FetchDynamicConfigResponse{Snapshot: &p.DynamicConfigSnapshot{
+			Version: version,
+			Values: &types.DynamicConfigBlob{
+				SchemaVersion: 1,
+				Entries: []*types.DynamicConfigEntry{{
+					Name: dynamicproperties.TestGetBoolPropertyKey.String(),
+					Values: []*types.DynamicConfigValue{{
+						Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: jsonMarshalHelper(value)},
+					}},
+				}},
+			},
+		}}
+	}
+	gomock.InOrder(
+		s.mockManager.EXPECT().FetchDynamicConfig(gomock.Any(), p.DynamicConfig).Return(snapshot(1, false), nil),
+		s.mockManager.EXPECT().FetchDynamicConfig(gomock.Any(), p.DynamicConfig).Return(snapshot(2, true), nil),
+	)
+	s.NoError(s.client.update())
+
+	var changes []interface{}
+	cancel := s.client.Subscribe(dynamicproperties.TestGetBoolPropertyKey, nil, func(value interface{}) {
+		changes = append(changes, value)
+	})
+	defer cancel()
+
+	s.NoError(s.client.update())
+	s.Equal([]interface{}{true}, changes)
+}
+
 func (s *configStoreClientSuite) TestUpdateValue_RetrySuccess() {
 	s.mockManager.EXPECT().
 		UpdateDynamicConfig(gomock.Any(), EqSnapshotVersion(2), p.DynamicConfig).
@@ -813,6 +880,37 @@ func (s *configStoreClientSuite) TestUpdateValue_RetryFailure() {
 	s.Error(err)
 }
 
+func (s *configStoreClientSuite) TestUpdateValue_Precondition() {
+	defaultTestSetup(s)
+
+	values := []*types.DynamicConfigValue{{
+		Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: jsonMarshalHelper(true)},
+	}}
+	current := snapshot1.Values.Entries[0].Values
+	s.Equal(dynamicproperties.TestGetBoolPropertyKey.String(), snapshot1.Values.Entries[0].Name)
+
+	stale, err := dynamicproperties.WithExpectedValues(values, values)
+	s.NoError(err)
+	err = s.client.UpdateValue(dynamicproperties.TestGetBoolPropertyKey, stale)
+	var badRequest *types.BadRequestError
+	s.ErrorAs(err, &badRequest)
+	s.Contains(badRequest.Message, "changed since they were read")
+
+	s.mockManager.EXPECT().
+		UpdateDynamicConfig(gomock.Any(), EqSnapshotVersion(2), p.DynamicConfig).
+		DoAndReturn(func(_ context.Context, request *p.UpdateDynamicConfigRequest, cfgType p.ConfigType) error {
+			for _, entry := range request.Snapshot.Values.Entries {
+				if entry.Name == dynamicproperties.TestGetBoolPropertyKey.String() {
+					s.Equal(values, entry.Values, "the precondition must not be stored")
+				}
+			}
+			return nil
+		}).Times(1)
+	upToDate, err := dynamicproperties.WithExpectedValues(values, current)
+	s.NoError(err)
+	s.NoError(s.client.UpdateValue(dynamicproperties.TestGetBoolPropertyKey, upToDate))
+}
+
 func (s *configStoreClientSuite) TestUpdateValue_Timeout() {
 	defaultTestSetup(s)
 	s.mockManager.EXPECT().
@@ -974,7 +1072,7 @@ func (s *configStoreClientSuite) TestValidateKeyDataBlobPair() {
 
 	for _, tc := range tests {
 		s.Run(tc.name, func() {
-			err := validateKeyDataBlobPair(tc.key, tc.blob)
+			err := dynamicproperties.ValidateConfigValues(tc.key, []*types.DynamicConfigValue{{Value: tc.blob}})
 			if tc.wantErr {
 				s.Require().Error(err, "Expected an error for case: %s", tc.name)
 			} else {
@@ -1038,3 +1136,77 @@ func (e eqSnapshotVersionMatcher) String() string {
 func EqSnapshotVersion(version int64) gomock.Matcher {
 	return eqSnapshotVersionMatcher{version}
 }
+
+func TestEffectiveValue(t *testing.T) {
+	blob := func(v interface{}) *types.DataBlob {
+		return &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: jsonMarshalHelper(v)}
+	}
+	now := time.Now()
+	values := []*types.DynamicConfigValue{
+		{Value: blob(1)},
+		{
+			Value:   blob(2),
+			Filters: []*types.DynamicConfigFilter{{Name: dynamicproperties.DomainName.String(), Value: blob("domain")}},
+		},
+		{
+			Value: blob(3),
+			Filters: []*types.DynamicConfigFilter{
+				{Name: dynamicproperties.DomainName.String(), Value: blob("domain")},
+				{Name: dynamicproperties.TaskListName.String(), Value: blob("tasklist")},
+			},
+		},
+	}
+
+	value, found, err := EffectiveValue(values, nil, now)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, float64(1), value)
+
+	// the first matching value wins, even if a later one is more specific
+	value, found, err = EffectiveValue(values, map[dynamicproperties.Filter]interface{}{
+		dynamicproperties.DomainName:   "domain",
+		dynamicproperties.TaskListName: "tasklist",
+	}, now)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, float64(2), value)
+
+	value, found, err = EffectiveValue(values, map[dynamicproperties.Filter]interface{}{
+		dynamicproperties.DomainName: "other-domain",
+	}, now)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, float64(1), value)
+
+	_, found, err = EffectiveValue(values[1:], map[dynamicproperties.Filter]interface{}{
+		dynamicproperties.DomainName: "other-domain",
+	}, now)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// expired values and values whose rollout does not cover the lookup fall back to the next applicable value
+	expired, err := dynamicproperties.OverrideFilters(dynamicproperties.Override{ExpiresAt: now})
+	require.NoError(t, err)
+	rollout, err := dynamicproperties.OverrideFilters(dynamicproperties.Override{
+		Rollout: []dynamicproperties.RolloutStage{{StartTime: now.Add(-time.Minute), ShardIDs: []int{1}}},
+	})
+	require.NoError(t, err)
+	overridden := []*types.DynamicConfigValue{
+		{Value: blob(1)},
+		{Value: blob(4), Filters: expired},
+		{Value: blob(5), Filters: rollout},
+	}
+	value, found, err = EffectiveValue(overridden, map[dynamicproperties.Filter]interface{}{dynamicproperties.ShardID: 1}, now)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, float64(5), value)
+
+	value, found, err = EffectiveValue(overridden, map[dynamicproperties.Filter]interface{}{dynamicproperties.ShardID: 2}, now)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, float64(1), value)
+
+	_, found, err = EffectiveValue(overridden[1:2], nil, now)
+	require.NoError(t, err)
+	require.False(t, found)
+}
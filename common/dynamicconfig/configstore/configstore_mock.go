@@ -231,6 +231,20 @@ func (mr *MockClientMockRecorder) Stop() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockClient)(nil).Stop))
 }
 
+// Subscribe mocks base method.
+func (m *MockClient) Subscribe(name dynamicproperties.Key, filters map[dynamicproperties.Filter]any, onChange func(any)) func() {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", name, filters, onChange)
+	ret0, _ := ret[0].(func())
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockClientMockRecorder) Subscribe(name, filters, onChange any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockClient)(nil).Subscribe), name, filters, onChange)
+}
+
 // UpdateValue mocks base method.
 func (m *MockClient) UpdateValue(name dynamicproperties.Key, value any) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package configstore
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+var historyPageSize = 100
+
+// ValueChange is a change of the values of a key in the config store
+type ValueChange struct {
+	Key string
+	// Version and Timestamp are the version of the snapshot the change was written in and the time it took effect
+	Version   int64
+	Timestamp time.Time
+	// Values are nil if the key was removed, PreviousValues are nil if the key was added
+	Values         []*types.DynamicConfigValue
+	PreviousValues []*types.DynamicConfigValue
+}
+
+// ChangeHistory returns the changes of the values of key in the config store, latest first, by comparing consecutive snapshots.
+// Changes of all keys are returned if key is nil. At most limit changes are returned, all of them if limit is 0.
+func ChangeHistory(
+	ctx context.Context,
+	manager persistence.ConfigStoreManager,
+	configType persistence.ConfigType,
+	key dynamicproperties.Key,
+	limit int,
+) ([]*ValueChange, error) {
+	var changes []*ValueChange
+	var newer *persistence.DynamicConfigSnapshot
+	maxVersion := int64(0)
+	for {
+		resp, err := manager.FetchDynamicConfigHistory(ctx, &persistence.FetchDynamicConfigHistoryRequest{
+			MaxVersion: maxVersion,
+			PageSize:   historyPageSize,
+		}, configType)
+		if err != nil {
+			return nil, err
+		}
+		for _, older := range resp.Snapshots {
+			if newer != nil {
+				changes = append(changes, diffSnapshots(newer, older, key)...)
+				if limit > 0 && len(changes) >= limit {
+					return changes[:limit], nil
+				}
+			}
+			newer = older
+		}
+		if len(resp.Snapshots) < historyPageSize || newer == nil || newer.Version <= 1 {
+			break
+		}
+		maxVersion = newer.Version - 1
+	}
+	if newer != nil {
+		// the values of the oldest snapshot were all added by it
+		changes = append(changes, diffSnapshots(newer, nil, key)...)
+	}
+	if limit > 0 && len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes, nil
+}
+
+// diffSnapshots returns the changes made by newer on top of older, ordered by key
+func diffSnapshots(newer, older *persistence.DynamicConfigSnapshot, key dynamicproperties.Key) []*ValueChange {
+	newerValues := snapshotValues(newer)
+	olderValues := snapshotValues(older)
+	names := make(map[string]bool, len(newerValues)+len(olderValues))
+	for name := range newerValues {
+		names[name] = true
+	}
+	for name := range olderValues {
+		names[name] = true
+	}
+
+	var changes []*ValueChange
+	for name := range names {
+		if key != nil && name != key.String() {
+			continue
+		}
+		if reflect.DeepEqual(newerValues[name], olderValues[name]) {
+			continue
+		}
+		changes = append(changes, &ValueChange{
+			Key:            name,
+			Version:        newer.Version,
+			Timestamp:      newer.Timestamp,
+			Values:         newerValues[name],
+			PreviousValues: olderValues[name],
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+func snapshotValues(snapshot *persistence.DynamicConfigSnapshot) map[string][]*types.DynamicConfigValue {
+	values := make(map[string][]*types.DynamicConfigValue)
+	if snapshot == nil || snapshot.Values == nil {
+		return values
+	}
+	for _, entry := range snapshot.Values.Entries {
+		if entry != nil && len(entry.Values) > 0 {
+			values[entry.Name] = entry.Values
+		}
+	}
+	return values
+}
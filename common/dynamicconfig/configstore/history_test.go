@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package configstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestChangeHistory(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	value := func(v string) []*types.DynamicConfigValue {
+		return []*types.DynamicConfigValue{{Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: []byte(v)}}}
+	}
+	snapshot := func(version int64, entries map[string]string) *persistence.DynamicConfigSnapshot {
+		blob := &types.DynamicConfigBlob{}
+		for name, v := range entries {
+			blob.Entries = append(blob.Entries, &types.DynamicConfigEntry{Name: name, Values: value(v)})
+		}
+		return &persistence.DynamicConfigSnapshot{
+			Version:   version,
+			Timestamp: now.Add(time.Duration(version) * time.Minute),
+			Values:    blob,
+		}
+	}
+	keyA := dynamicproperties.TestGetIntPropertyKey
+	keyB := dynamicproperties.TestGetBoolPropertyKey
+	snapshots := []*persistence.DynamicConfigSnapshot{
+		snapshot(4, map[string]string{keyB.String(): "true"}),
+		snapshot(3, map[string]string{keyA.String(): "2", keyB.String(): "true"}),
+		snapshot(2, map[string]string{keyA.String(): "1", keyB.String(): "true"}),
+		snapshot(1, map[string]string{keyA.String(): "1"}),
+	}
+	newManager := func(t *testing.T) *persistence.MockConfigStoreManager {
+		manager := persistence.NewMockConfigStoreManager(gomock.NewController(t))
+		manager.EXPECT().FetchDynamicConfigHistory(gomock.Any(), gomock.Any(), persistence.DynamicConfig).DoAndReturn(
+			func(_ context.Context, request *persistence.FetchDynamicConfigHistoryRequest, _ persistence.ConfigType) (*persistence.FetchDynamicConfigHistoryResponse, error) {
+				var page []*persistence.DynamicConfigSnapshot
+				for _, s := range snapshots {
+					if (request.MaxVersion == 0 || s.Version <= request.MaxVersion) && len(page) < request.PageSize {
+						page = append(page, s)
+					}
+				}
+				return &persistence.FetchDynamicConfigHistoryResponse{Snapshots: page}, nil
+			}).AnyTimes()
+		return manager
+	}
+
+	t.Run("all keys in pages", func(t *testing.T) {
+		pageSize := historyPageSize
+		historyPageSize = 2
+		defer func() { historyPageSize = pageSize }()
+
+		changes, err := ChangeHistory(context.Background(), newManager(t), persistence.DynamicConfig, nil, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []*ValueChange{
+			{Key: keyA.String(), Version: 4, Timestamp: now.Add(4 * time.Minute), PreviousValues: value("2")},
+			{Key: keyA.String(), Version: 3, Timestamp: now.Add(3 * time.Minute), Values: value("2"), PreviousValues: value("1")},
+			{Key: keyB.String(), Version: 2, Timestamp: now.Add(2 * time.Minute), Values: value("true")},
+			{Key: keyA.String(), Version: 1, Timestamp: now.Add(1 * time.Minute), Values: value("1")},
+		}, changes)
+	})
+
+	t.Run("single key with limit", func(t *testing.T) {
+		changes, err := ChangeHistory(context.Background(), newManager(t), persistence.DynamicConfig, keyB, 0)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, int64(2), changes[0].Version)
+
+		changes, err = ChangeHistory(context.Background(), newManager(t), persistence.DynamicConfig, keyA, 1)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, int64(4), changes[0].Version)
+	})
+
+	t.Run("persistence error", func(t *testing.T) {
+		manager := persistence.NewMockConfigStoreManager(gomock.NewController(t))
+		manager.EXPECT().FetchDynamicConfigHistory(gomock.Any(), gomock.Any(), persistence.DynamicConfig).Return(nil, errors.New("unavailable"))
+		_, err := ChangeHistory(context.Background(), manager, persistence.DynamicConfig, nil, 0)
+		assert.ErrorContains(t, err, "unavailable")
+	})
+}
@@ -120,3 +120,8 @@ func GetStringPropertyFnFilteredByDomain(value string) func(domain string) strin
 func GetMapPropertyFn(value map[string]interface{}) func(opts ...FilterOption) map[string]interface{} {
 	return func(...FilterOption) map[string]interface{} { return value }
 }
+
+// GetMapPropertyFnFilteredByDomain returns value as MapPropertyFnWithDomainFilter
+func GetMapPropertyFnFilteredByDomain(value map[string]interface{}) func(domain string) map[string]interface{} {
+	return func(domain string) map[string]interface{} { return value }
+}
@@ -538,6 +538,13 @@ const (
 	// Default value: 1000 (see common.GetHistoryMaxPageSize)
 	// Allowed filters: DomainName
 	FrontendHistoryMaxPageSize
+	// FrontendRequestCostUnitSize is the number of page items or history events that cost one ratelimiter token
+	// when FrontendEnableRequestCost is enabled. e.g. with the default of 100, listing a page of 1000 workflows costs 10 tokens.
+	// KeyName: frontend.requestCostUnitSize
+	// Value type: Int
+	// Default value: 100
+	// Allowed filters: DomainName
+	FrontendRequestCostUnitSize
 	// FrontendUserRPS is used to limit "user" requests (StartWorkflow, Signal, etc)
 	// per frontend instance (across all domains, or for non-domain-related requests),
 	// and is mostly intended to protect against excessive single-host load.
@@ -944,6 +951,19 @@ const (
 	// Default value: 0
 	// Allowed filters: N/A
 	HistoryPersistenceGlobalMaxQPS
+	// HistoryPersistenceDomainMaxQPS is the max qps a single domain can query DB through one history shard
+	// KeyName: history.persistenceDomainMaxQPS
+	// Value type: Int
+	// Default value: 0 (disabled)
+	// Allowed filters: DomainName
+	HistoryPersistenceDomainMaxQPS
+	// HistoryPersistenceShardMaxQPS is the max qps all domains together can query DB through one history shard,
+	// domains with pending requests share it fairly once it is exhausted
+	// KeyName: history.persistenceShardMaxQPS
+	// Value type: Int
+	// Default value: 0 (disabled)
+	// Allowed filters: N/A
+	HistoryPersistenceShardMaxQPS
 	// HistoryVisibilityOpenMaxQPS is max qps one history host can write visibility open_executions
 	// KeyName: history.historyVisibilityOpenMaxQPS
 	// Value type: Int
@@ -1214,6 +1234,13 @@ const (
 	// Default value: 0
 	// Allowed filters: N/A
 	ReplicatorCacheCapacity
+	// ReplicationStatusMaxScanTasks is the max number of pending replication tasks read per shard and remote cluster
+	// when estimating per-domain replication lag
+	// KeyName: history.replicationStatusMaxScanTasks
+	// Value type: Int
+	// Default value: 1000
+	// Allowed filters: N/A
+	ReplicationStatusMaxScanTasks
 	// ReplicatorCacheMaxSize is the max size of the replication cache in bytes
 	// KeyName: history.replicatorCacheMaxSize
 	// Value type: Int
@@ -1427,6 +1454,13 @@ const (
 	// Default value: 2<<24 // 16MB
 	// Allowed filters: N/A
 	WorkerESProcessorBulkSize
+	// WorkerESProcessorMaxItemRetries is how many times esProcessor retries a request ES keeps rejecting with a retryable status
+	// before sending its message to the DLQ. Values <= 0 retry indefinitely
+	// KeyName: worker.ESProcessorMaxItemRetries
+	// Value type: Int
+	// Default value: 100
+	// Allowed filters: N/A
+	WorkerESProcessorMaxItemRetries
 	// WorkerArchiverConcurrency is controls the number of coroutines handling archival work per archival workflow
 	// KeyName: worker.ArchiverConcurrency
 	// Value type: Int
@@ -1553,6 +1587,30 @@ const (
 	// Default value: 3
 	// Allowed filters: N/A
 	TimersScannerPeriodEnd
+	// VisibilityScannerConcurrency is the concurrency of visibility scanner
+	// KeyName: worker.visibilityScannerConcurrency
+	// Value type: Int
+	// Default value: 5
+	// Allowed filters: N/A
+	VisibilityScannerConcurrency
+	// VisibilityScannerPersistencePageSize is the page size of execution persistence fetches in visibility scanner
+	// KeyName: worker.visibilityScannerPersistencePageSize
+	// Value type: Int
+	// Default value: 1000
+	// Allowed filters: N/A
+	VisibilityScannerPersistencePageSize
+	// VisibilityScannerBlobstoreFlushThreshold is threshold to flush blob store
+	// KeyName: worker.visibilityScannerBlobstoreFlushThreshold
+	// Value type: Int
+	// Default value: 100
+	// Allowed filters: N/A
+	VisibilityScannerBlobstoreFlushThreshold
+	// VisibilityScannerActivityBatchSize is the number of shards scanned by one visibility scanner activity
+	// KeyName: worker.visibilityScannerActivityBatchSize
+	// Value type: Int
+	// Default value: 25
+	// Allowed filters: N/A
+	VisibilityScannerActivityBatchSize
 	// ESAnalyzerMaxNumDomains defines how many domains to check
 	// KeyName: worker.ESAnalyzerMaxNumDomains
 	// Value type: int
@@ -1573,6 +1631,18 @@ const (
 	// Value type: Int
 	// Default value: 100
 	ESAnalyzerMinNumWorkflowsForAvg
+	// DiagnosticsSignalStormThreshold is the number of signals received within a minute that the diagnostics workflow reports as a signal storm
+	// KeyName: worker.diagnosticsSignalStormThreshold
+	// Value type: Int
+	// Default value: 100
+	// Allowed filters: DomainName
+	DiagnosticsSignalStormThreshold
+	// DiagnosticsNonDeterministicFailureThreshold is the number of consecutive non-deterministic decision task failures that the diagnostics workflow reports as non-determinism
+	// KeyName: worker.diagnosticsNonDeterministicFailureThreshold
+	// Value type: Int
+	// Default value: 2
+	// Allowed filters: DomainName
+	DiagnosticsNonDeterministicFailureThreshold
 
 	// VisibilityArchivalQueryMaxRangeInDays is the maximum number of days for a visibility archival query
 	// KeyName: frontend.visibilityArchivalQueryMaxRangeInDays
@@ -1808,6 +1878,14 @@ const (
 	// Default value: true
 	// Allowed filters: N/A
 	EnableQueryAttributeValidation
+	// FrontendEnableRequestCost makes frontend ratelimiters charge requests by the amount of data they read instead of
+	// one token per call: list and scan requests by page size and query complexity, and history reads by the number
+	// of events they can return. Consumed tokens are emitted as request_cost_units per domain.
+	// KeyName: frontend.enableRequestCost
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: DomainName
+	FrontendEnableRequestCost
 
 	// key for matching
 
@@ -1941,6 +2019,13 @@ const (
 	// Default value: true
 	// Allowed filters: DomainName
 	EnableParentClosePolicy
+	// EnableChildSearchAttributeValidation is whether the search attributes of child workflows started by decisions
+	// are validated against the search attributes and limits of the child's domain
+	// KeyName: history.enableChildSearchAttributeValidation
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: DomainName
+	EnableChildSearchAttributeValidation
 	// EnableDropStuckTaskByDomainID is whether stuck timer/transfer task should be dropped for a domain
 	// KeyName: history.DropStuckTaskByDomain
 	// Value type: Bool
@@ -2081,6 +2166,30 @@ const (
 	// Default value: false
 	// Allowed filters: N/A
 	ConcreteExecutionsScannerInvariantCollectionStale
+	// ConcreteExecutionsScannerInvariantCollectionChildParent indicates if the parent child linkage invariant should be run
+	// KeyName: worker.executionsScannerInvariantCollectionChildParent
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	ConcreteExecutionsScannerInvariantCollectionChildParent
+	// ConcreteExecutionsFixerInvariantCollectionChildParent indicates if the parent child linkage invariant should be run
+	// KeyName: worker.executionsFixerInvariantCollectionChildParent
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	ConcreteExecutionsFixerInvariantCollectionChildParent
+	// ConcreteExecutionsScannerInvariantCollectionTimerTask indicates if the dangling timer invariant should be run
+	// KeyName: worker.executionsScannerInvariantCollectionTimerTask
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	ConcreteExecutionsScannerInvariantCollectionTimerTask
+	// ConcreteExecutionsFixerInvariantCollectionTimerTask indicates if the dangling timer invariant should be run
+	// KeyName: worker.executionsFixerInvariantCollectionTimerTask
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	ConcreteExecutionsFixerInvariantCollectionTimerTask
 	// CurrentExecutionsScannerEnabled indicates if current executions scanner should be started as part of worker.Scanner
 	// KeyName: worker.currentExecutionsScannerEnabled
 	// Value type: Bool
@@ -2173,6 +2282,24 @@ const (
 	// Default value: false
 	// Allowed filters: DomainName
 	TimersFixerDomainAllow
+	// VisibilityScannerEnabled is if visibility scanner should be started as part of worker.Scanner
+	// KeyName: worker.visibilityScannerEnabled
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	VisibilityScannerEnabled
+	// VisibilityFixerEnabled is if visibility fixer should be started as part of worker.Scanner
+	// KeyName: worker.visibilityFixerEnabled
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	VisibilityFixerEnabled
+	// VisibilityFixerDomainAllow is which domains are allowed to be fixed by visibility fixer workflow
+	// KeyName: worker.visibilityFixerDomainAllow
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: DomainName
+	VisibilityFixerDomainAllow
 	// ConcreteExecutionFixerEnabled is if concrete execution fixer workflow is enabled
 	// KeyName: worker.concreteExecutionFixerEnabled
 	// Value type: Bool
@@ -2255,6 +2382,14 @@ const (
 	// Allowed filters: DomainName,TasklistName,TaskType
 	EnablePartitionIsolationGroupAssignment
 
+	// EnableIsolationGroupDrainMigration proactively moves work away from drained isolation groups: sticky task lists
+	// whose pollers are all in drained groups stop accepting decisions, and drained groups are unassigned from task list partitions
+	// KeyName: matching.enableIsolationGroupDrainMigration
+	// Value type: bool
+	// Default value: false
+	// Allowed filters: DomainName
+	EnableIsolationGroupDrainMigration
+
 	// EnableShardIDMetrics turns on or off shardId metrics
 	// KeyName: system.enableShardIDMetrics
 	// Value type: Bool
@@ -2605,6 +2740,12 @@ const (
 	// TODO: https://github.com/uber/cadence/issues/3861
 	// Note: not currently used in open-source
 	WorkerBlobIntegrityCheckProbability
+	// VisibilityScannerSampleRate is the fraction of executions whose visibility records are checked by visibility scanner
+	// KeyName: worker.visibilityScannerSampleRate
+	// Value type: Float64
+	// Default value: 0.01
+	// Allowed filters: N/A
+	VisibilityScannerSampleRate
 
 	// HistoryGlobalRatelimiterNewDataWeight defines how much weight to give each host's newest data, per update.  Must be between 0 and 1, higher values match new values more closely after a single update.
 	// KeyName: history.globalRatelimiterNewDataWeight
@@ -2712,6 +2853,17 @@ const (
 	// Default value: "disabled"
 	// Allowed filters: RatelimitKey (on global key, e.g. prefixed by collection name)
 	FrontendGlobalRatelimiterMode
+	// MatchingGlobalRatelimiterMode controls what task lists share their dispatch rate limit with all matching hosts,
+	// with the same modes as FrontendGlobalRatelimiterMode. Keys are prefixed by "taskListDispatch:" and identify
+	// a task list by domain name, task list name and task type, e.g. "taskListDispatch:samples-domain/orders/activity".
+	//
+	// In "disabled" mode, each task list partition keeps dispatching at the configured rate divided by the number of partitions.
+	//
+	// KeyName: matching.globalRatelimiterMode
+	// Value type: string enum: "disabled", "local", "global", "local-shadow-global", or "global-shadow-local"
+	// Default value: "disabled"
+	// Allowed filters: RatelimitKey (on global key, e.g. prefixed by collection name)
+	MatchingGlobalRatelimiterMode
 	// EnableAuthorizationV2 is the key to enable authorization v2 for a domain, only for extension binary:
 	// KeyName: system.enableAuthorizationV2
 	// Value type: string ["disabled","shadow","enabled"]
@@ -2757,6 +2909,14 @@ const (
 	// Allowed filters: ShardID
 	TimerProcessorCachedQueueReaderMode
 
+	// DiagnosticsHistoryEventLinkTemplate is the link to a history event in the reports of the diagnostics workflow,
+	// {domain}, {workflowID}, {runID} and {eventID} are replaced by the values of the event. Events are not linked when it is empty.
+	// KeyName: worker.diagnosticsHistoryEventLinkTemplate
+	// Value type: String
+	// Default value: ""
+	// Allowed filters: DomainName
+	DiagnosticsHistoryEventLinkTemplate
+
 	// LastStringKey must be the last one in this const group
 	LastStringKey
 )
@@ -2922,6 +3082,12 @@ const (
 	// Default value: 0
 	// Allowed filters: N/A
 	HistoryShutdownDrainDuration
+	// HistoryPersistenceDomainMaxWait is how long a persistence request may queue for its domain's per-shard budget before being rejected
+	// KeyName: history.persistenceDomainMaxWait
+	// Value type: Duration
+	// Default value: 100ms
+	// Allowed filters: DomainName
+	HistoryPersistenceDomainMaxWait
 	// EventsCacheTTL is TTL of events cache
 	// KeyName: history.eventsCacheTTL
 	// Value type: Duration
@@ -3120,6 +3286,13 @@ const (
 	// Default value: 40s (40 * time.Second)
 	// Allowed filters: N/A
 	ReplicatorUpperLatency
+	// ReplicationLagSLOThreshold is the replication lag above which a domain is reported as breaching its replication SLO.
+	// Zero disables the check for a domain, the check is skipped when no domain has a non-zero value.
+	// KeyName: history.replicationLagSLOThreshold
+	// Value type: Duration
+	// Default value: 0
+	// Allowed filters: DomainName
+	ReplicationLagSLOThreshold
 	// ShardUpdateMinInterval is the minimal time interval which the shard info can be updated
 	// KeyName: history.shardUpdateMinInterval
 	// Value type: Duration
@@ -3263,6 +3436,12 @@ const (
 	// Value type: Duration
 	// Default value: 30 minutes
 	ESAnalyzerBufferWaitTime
+	// DiagnosticsPendingDecisionAgeThreshold is how long a decision task stays scheduled before the diagnostics workflow reports it as pending
+	// KeyName: worker.diagnosticsPendingDecisionAgeThreshold
+	// Value type: Duration
+	// Default value: 1 minute
+	// Allowed filters: DomainName
+	DiagnosticsPendingDecisionAgeThreshold
 	// IsolationGroupStateRefreshInterval
 	// KeyName: system.isolationGroupStateRefreshInterval
 	// Value type: Duration
@@ -3405,6 +3584,24 @@ const (
 	// Default value: the default attributes of this release version, see definition.GetDefaultIndexedKeys()
 	// Allowed filters: N/A
 	ValidSearchAttributes
+	// DeprecatedSearchAttributes is the set of removed search attributes, which are rejected in upserts and hidden from GetSearchAttributes. Values are ignored
+	// KeyName: frontend.deprecatedSearchAttributes
+	// Value type: Map
+	// Default value: empty map
+	// Allowed filters: N/A
+	DeprecatedSearchAttributes
+	// SearchAttributeAliases maps the new names of renamed search attributes to the valid search attributes they resolve to in queries
+	// KeyName: frontend.searchAttributeAliases
+	// Value type: Map
+	// Default value: empty map
+	// Allowed filters: N/A
+	SearchAttributeAliases
+	// DomainSearchAttributes is the custom search attributes registered for a domain with their types, in addition to ValidSearchAttributes
+	// KeyName: frontend.domainSearchAttributes
+	// Value type: Map
+	// Default value: empty map
+	// Allowed filters: DomainName
+	DomainSearchAttributes
 
 	// key for history
 
@@ -3469,6 +3666,15 @@ const (
 	// Allowed filters: N/A
 	RateLimiterBypassCallerTypes
 
+	// IsolationGroupDrainSchedules is the list of planned isolation-group drain windows. A scheduled
+	// drain is applied when its start time is reached and reverted automatically at its end time.
+	// Entries are managed with `cadence admin isolation-groups schedule`.
+	// KeyName: system.isolationGroupDrainSchedules
+	// Value type: []isolationgroup.DrainSchedule
+	// Default value: empty list
+	// Allowed filters: N/A
+	IsolationGroupDrainSchedules
+
 	// HeaderForwardingRules defines which headers are forwarded from inbound calls to outbound.
 	// This value is only loaded at startup.
 	//
@@ -3698,6 +3904,12 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "FrontendHistoryMaxPageSize is default max size for GetWorkflowExecutionHistory in one page",
 		DefaultValue: 1000,
 	},
+	FrontendRequestCostUnitSize: {
+		KeyName:      "frontend.requestCostUnitSize",
+		Filters:      []Filter{DomainName},
+		Description:  "FrontendRequestCostUnitSize is the number of page items or history events that cost one ratelimiter token",
+		DefaultValue: 100,
+	},
 	FrontendUserRPS: {
 		KeyName:      "frontend.rps",
 		Description:  "FrontendUserRPS is workflow rate limit per second",
@@ -3998,6 +4210,17 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "HistoryPersistenceGlobalMaxQPS is the max qps history cluster can query DB",
 		DefaultValue: 0,
 	},
+	HistoryPersistenceDomainMaxQPS: {
+		KeyName:      "history.persistenceDomainMaxQPS",
+		Filters:      []Filter{DomainName},
+		Description:  "HistoryPersistenceDomainMaxQPS is the max qps a single domain can query DB through one history shard, 0 disables the per-domain budget",
+		DefaultValue: 0,
+	},
+	HistoryPersistenceShardMaxQPS: {
+		KeyName:      "history.persistenceShardMaxQPS",
+		Description:  "HistoryPersistenceShardMaxQPS is the max qps all domains together can query DB through one history shard, domains with pending requests share it fairly once it is exhausted, 0 disables it",
+		DefaultValue: 0,
+	},
 	HistoryVisibilityOpenMaxQPS: {
 		KeyName:      "history.historyVisibilityOpenMaxQPS",
 		Filters:      []Filter{DomainName},
@@ -4225,6 +4448,11 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "ReplicatorCacheCapacity is the capacity of replication cache in number of tasks",
 		DefaultValue: 0,
 	},
+	ReplicationStatusMaxScanTasks: {
+		KeyName:      "history.replicationStatusMaxScanTasks",
+		Description:  "ReplicationStatusMaxScanTasks is the max number of pending replication tasks read per shard and remote cluster when estimating per-domain replication lag",
+		DefaultValue: 1000,
+	},
 	ReplicatorCacheMaxSize: {
 		KeyName:      "history.replicatorCacheMaxSize",
 		Description:  "ReplicatorCacheMaxSize is the max size of the replication cache in bytes",
@@ -4406,6 +4634,11 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "WorkerESProcessorBulkSize is max total size of bulk in bytes for esProcessor",
 		DefaultValue: 2 << 24, // 16MB
 	},
+	WorkerESProcessorMaxItemRetries: {
+		KeyName:      "worker.ESProcessorMaxItemRetries",
+		Description:  "WorkerESProcessorMaxItemRetries is how many times esProcessor retries a request ES keeps rejecting with a retryable status before sending its message to the DLQ. Values <= 0 retry indefinitely",
+		DefaultValue: 100,
+	},
 	WorkerArchiverConcurrency: {
 		KeyName:      "worker.ArchiverConcurrency",
 		Description:  "WorkerArchiverConcurrency is controls the number of coroutines handling archival work per archival workflow",
@@ -4511,6 +4744,26 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "TimersScannerPeriodEnd is interval end for fetching scheduled timers",
 		DefaultValue: 3,
 	},
+	VisibilityScannerConcurrency: {
+		KeyName:      "worker.visibilityScannerConcurrency",
+		Description:  "VisibilityScannerConcurrency is the concurrency of visibility scanner",
+		DefaultValue: 5,
+	},
+	VisibilityScannerPersistencePageSize: {
+		KeyName:      "worker.visibilityScannerPersistencePageSize",
+		Description:  "VisibilityScannerPersistencePageSize is the page size of execution persistence fetches in visibility scanner",
+		DefaultValue: 1000,
+	},
+	VisibilityScannerBlobstoreFlushThreshold: {
+		KeyName:      "worker.visibilityScannerBlobstoreFlushThreshold",
+		Description:  "VisibilityScannerBlobstoreFlushThreshold is threshold to flush blob store",
+		DefaultValue: 100,
+	},
+	VisibilityScannerActivityBatchSize: {
+		KeyName:      "worker.visibilityScannerActivityBatchSize",
+		Description:  "VisibilityScannerActivityBatchSize is the number of shards scanned by one visibility scanner activity",
+		DefaultValue: 25,
+	},
 	ESAnalyzerMaxNumDomains: {
 		KeyName:      "worker.ESAnalyzerMaxNumDomains",
 		Description:  "ESAnalyzerMaxNumDomains defines how many domains to check",
@@ -4531,6 +4784,18 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "ESAnalyzerMinNumWorkflowsForAvg controls how many workflows to have at least to rely on workflow run time avg per type",
 		DefaultValue: 100,
 	},
+	DiagnosticsSignalStormThreshold: {
+		KeyName:      "worker.diagnosticsSignalStormThreshold",
+		Filters:      []Filter{DomainName},
+		Description:  "DiagnosticsSignalStormThreshold is the number of signals received within a minute that the diagnostics workflow reports as a signal storm, zero or less disables the check",
+		DefaultValue: 100,
+	},
+	DiagnosticsNonDeterministicFailureThreshold: {
+		KeyName:      "worker.diagnosticsNonDeterministicFailureThreshold",
+		Filters:      []Filter{DomainName},
+		Description:  "DiagnosticsNonDeterministicFailureThreshold is the number of consecutive non-deterministic decision task failures that the diagnostics workflow reports as non-determinism",
+		DefaultValue: 2,
+	},
 	VisibilityArchivalQueryMaxRangeInDays: {
 		KeyName:      "frontend.visibilityArchivalQueryMaxRangeInDays",
 		Description:  "VisibilityArchivalQueryMaxRangeInDays is the maximum number of days for a visibility archival query",
@@ -4751,6 +5016,12 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "EnableQueryAttributeValidation enables validation of queries' search attributes against the dynamic config whitelist",
 		DefaultValue: true,
 	},
+	FrontendEnableRequestCost: {
+		KeyName:      "frontend.enableRequestCost",
+		Filters:      []Filter{DomainName},
+		Description:  "FrontendEnableRequestCost makes frontend ratelimiters charge requests by the amount of data they read instead of one token per call",
+		DefaultValue: false,
+	},
 	MatchingEnableSyncMatch: {
 		KeyName:      "matching.enableSyncMatch",
 		Filters:      []Filter{DomainName, TaskListName, TaskType},
@@ -4867,6 +5138,12 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "EnableParentClosePolicy is whether to  ParentClosePolicy",
 		DefaultValue: true,
 	},
+	EnableChildSearchAttributeValidation: {
+		KeyName:      "history.enableChildSearchAttributeValidation",
+		Filters:      []Filter{DomainName},
+		Description:  "EnableChildSearchAttributeValidation is whether the search attributes of child workflows started by decisions are validated against the search attributes and limits of the child's domain",
+		DefaultValue: false,
+	},
 	EnableDropStuckTaskByDomainID: {
 		KeyName:      "history.DropStuckTaskByDomain",
 		Filters:      []Filter{DomainID},
@@ -4980,6 +5257,26 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "ConcreteExecutionsFixerInvariantCollectionStale indicates if the stale-workflow invariant should be run",
 		DefaultValue: false, // may be enabled after further verification, but for now it's a bit too risky to enable by default
 	},
+	ConcreteExecutionsScannerInvariantCollectionChildParent: {
+		KeyName:      "worker.executionsScannerInvariantCollectionChildParent",
+		Description:  "ConcreteExecutionsScannerInvariantCollectionChildParent indicates if the parent child linkage invariant should be run",
+		DefaultValue: false,
+	},
+	ConcreteExecutionsFixerInvariantCollectionChildParent: {
+		KeyName:      "worker.executionsFixerInvariantCollectionChildParent",
+		Description:  "ConcreteExecutionsFixerInvariantCollectionChildParent indicates if the parent child linkage invariant should be run",
+		DefaultValue: false,
+	},
+	ConcreteExecutionsScannerInvariantCollectionTimerTask: {
+		KeyName:      "worker.executionsScannerInvariantCollectionTimerTask",
+		Description:  "ConcreteExecutionsScannerInvariantCollectionTimerTask indicates if the dangling timer invariant should be run",
+		DefaultValue: false,
+	},
+	ConcreteExecutionsFixerInvariantCollectionTimerTask: {
+		KeyName:      "worker.executionsFixerInvariantCollectionTimerTask",
+		Description:  "ConcreteExecutionsFixerInvariantCollectionTimerTask indicates if the dangling timer invariant should be run",
+		DefaultValue: false,
+	},
 	CurrentExecutionsScannerEnabled: {
 		KeyName:      "worker.currentExecutionsScannerEnabled",
 		Description:  "CurrentExecutionsScannerEnabled indicates if current executions scanner should be started as part of worker.Scanner",
@@ -5060,6 +5357,22 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "TimersFixerDomainAllow is which domains are allowed to be fixed by timer fixer workflow",
 		DefaultValue: false,
 	},
+	VisibilityScannerEnabled: {
+		KeyName:      "worker.visibilityScannerEnabled",
+		Description:  "VisibilityScannerEnabled is if visibility scanner should be started as part of worker.Scanner",
+		DefaultValue: false,
+	},
+	VisibilityFixerEnabled: {
+		KeyName:      "worker.visibilityFixerEnabled",
+		Description:  "VisibilityFixerEnabled is if visibility fixer should be started as part of worker.Scanner",
+		DefaultValue: false,
+	},
+	VisibilityFixerDomainAllow: {
+		KeyName:      "worker.visibilityFixerDomainAllow",
+		Filters:      []Filter{DomainName},
+		Description:  "VisibilityFixerDomainAllow is which domains are allowed to be fixed by visibility fixer workflow",
+		DefaultValue: false,
+	},
 	ConcreteExecutionFixerEnabled: {
 		KeyName:      "worker.concreteExecutionFixerEnabled",
 		Description:  "ConcreteExecutionFixerEnabled is if concrete execution fixer workflow is enabled",
@@ -5080,6 +5393,12 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "EnableTasklistIsolation is a feature to enable isolation-groups for a domain. Should not be enabled without a deep understanding of this feature",
 		DefaultValue: false,
 	},
+	EnableIsolationGroupDrainMigration: {
+		KeyName:      "matching.enableIsolationGroupDrainMigration",
+		Filters:      []Filter{DomainName},
+		Description:  "EnableIsolationGroupDrainMigration resets sticky task lists pinned to drained isolation groups and reroutes their task list partitions to healthy groups",
+		DefaultValue: false,
+	},
 	EnableServiceAuthorization: {
 		KeyName:      "system.enableServiceAuthorization",
 		Description:  "EnableServiceAuthorization is the key to enable authorization for a service, only for extension binary:",
@@ -5410,6 +5729,11 @@ var FloatKeys = map[FloatKey]DynamicFloat{
 		Description:  "WorkerBlobIntegrityCheckProbability controls the probability of running an integrity check for any given archival",
 		DefaultValue: 0.002,
 	},
+	VisibilityScannerSampleRate: {
+		KeyName:      "worker.visibilityScannerSampleRate",
+		Description:  "VisibilityScannerSampleRate is the fraction of executions whose visibility records are checked by visibility scanner",
+		DefaultValue: 0.01,
+	},
 	HistoryGlobalRatelimiterNewDataWeight: {
 		KeyName:      "history.globalRatelimiterNewDataWeight",
 		Description:  "HistoryGlobalRatelimiterNewDataWeight defines how much weight to give each host's newest data, per update.  Must be between 0 and 1, higher values match new values more closely after a single update",
@@ -5492,6 +5816,12 @@ var StringKeys = map[StringKey]DynamicString{
 		DefaultValue: "disabled",
 		Filters:      []Filter{RatelimitKey},
 	},
+	MatchingGlobalRatelimiterMode: {
+		KeyName:      "matching.globalRatelimiterMode",
+		Description:  "MatchingGlobalRatelimiterMode defines which mode a task list dispatch key should be in, to share dispatch rate limits of task lists between matching hosts",
+		DefaultValue: "disabled",
+		Filters:      []Filter{RatelimitKey},
+	},
 	EnableAuthorizationV2: {
 		KeyName:      "system.enableAuthorizationV2",
 		Description:  "EnableAuthorizationV2 is the key to enable authorization v2 for a domain, only for extension binary:",
@@ -5531,6 +5861,12 @@ var StringKeys = map[StringKey]DynamicString{
 		DefaultValue: "disabled",
 		Filters:      []Filter{ShardID},
 	},
+	DiagnosticsHistoryEventLinkTemplate: {
+		KeyName:      "worker.diagnosticsHistoryEventLinkTemplate",
+		Description:  "DiagnosticsHistoryEventLinkTemplate is the link to a history event in the reports of the diagnostics workflow, {domain}, {workflowID}, {runID} and {eventID} are replaced by the values of the event. Events are not linked when it is empty.",
+		DefaultValue: "",
+		Filters:      []Filter{DomainName},
+	},
 }
 
 var DurationKeys = map[DurationKey]DynamicDuration{
@@ -5698,6 +6034,12 @@ var DurationKeys = map[DurationKey]DynamicDuration{
 		Description:  "HistoryShutdownDrainDuration is the duration of traffic drain during shutdown",
 		DefaultValue: 0,
 	},
+	HistoryPersistenceDomainMaxWait: {
+		KeyName:      "history.persistenceDomainMaxWait",
+		Filters:      []Filter{DomainName},
+		Description:  "HistoryPersistenceDomainMaxWait is how long a persistence request may queue for its domain's per-shard budget before being rejected",
+		DefaultValue: time.Millisecond * 100,
+	},
 	EventsCacheTTL: {
 		KeyName:      "history.eventsCacheTTL",
 		Description:  "EventsCacheTTL is TTL of events cache",
@@ -5856,6 +6198,12 @@ var DurationKeys = map[DurationKey]DynamicDuration{
 		Description:  "ReplicatorUpperLatency indicates the max allowed replication latency between clusters",
 		DefaultValue: time.Second * 40,
 	},
+	ReplicationLagSLOThreshold: {
+		KeyName:      "history.replicationLagSLOThreshold",
+		Filters:      []Filter{DomainName},
+		Description:  "ReplicationLagSLOThreshold is the replication lag above which a domain is reported as breaching its replication SLO. Zero disables the check for a domain, the check is skipped when no domain has a non-zero value.",
+		DefaultValue: 0,
+	},
 	ShardUpdateMinInterval: {
 		KeyName:      "history.shardUpdateMinInterval",
 		Description:  "ShardUpdateMinInterval is the minimal time interval which the shard info can be updated",
@@ -6002,6 +6350,12 @@ var DurationKeys = map[DurationKey]DynamicDuration{
 		Description:  "ESAnalyzerBufferWaitTime controls min time required to consider a worklow stuck",
 		DefaultValue: time.Minute * 30,
 	},
+	DiagnosticsPendingDecisionAgeThreshold: {
+		KeyName:      "worker.diagnosticsPendingDecisionAgeThreshold",
+		Filters:      []Filter{DomainName},
+		Description:  "DiagnosticsPendingDecisionAgeThreshold is how long a decision task stays scheduled before the diagnostics workflow reports it as pending",
+		DefaultValue: time.Minute,
+	},
 	AsyncTaskDispatchTimeout: {
 		KeyName:      "matching.asyncTaskDispatchTimeout",
 		Filters:      []Filter{DomainName, TaskListName, TaskType},
@@ -6105,6 +6459,22 @@ var MapKeys = map[MapKey]DynamicMap{
 		Description:  "ValidSearchAttributes is legal indexed keys that can be used in list APIs. When overriding, ensure to include the existing default attributes of the current release",
 		DefaultValue: definition.GetDefaultIndexedKeys(),
 	},
+	DeprecatedSearchAttributes: {
+		KeyName:      "frontend.deprecatedSearchAttributes",
+		Description:  "DeprecatedSearchAttributes is the set of removed search attributes, which are rejected in upserts and hidden from GetSearchAttributes. Values are ignored",
+		DefaultValue: map[string]interface{}{},
+	},
+	SearchAttributeAliases: {
+		KeyName:      "frontend.searchAttributeAliases",
+		Description:  "SearchAttributeAliases maps the new names of renamed search attributes to the valid search attributes they resolve to in queries",
+		DefaultValue: map[string]interface{}{},
+	},
+	DomainSearchAttributes: {
+		KeyName:      "frontend.domainSearchAttributes",
+		Description:  "DomainSearchAttributes is the custom search attributes registered for a domain with their types, in addition to ValidSearchAttributes",
+		Filters:      []Filter{DomainName},
+		DefaultValue: map[string]interface{}{},
+	},
 	TaskSchedulerRoundRobinWeights: {
 		KeyName:      "history.taskSchedulerRoundRobinWeight",
 		Description:  "TaskSchedulerRoundRobinWeights is the priority weight for weighted round robin task scheduler",
@@ -6148,6 +6518,11 @@ var ListKeys = map[ListKey]DynamicList{
 		Description:  "List of caller types that bypass rate limiters (both frontend and persistence)",
 		DefaultValue: []interface{}{},
 	},
+	IsolationGroupDrainSchedules: {
+		KeyName:      "system.isolationGroupDrainSchedules",
+		Description:  "A list of planned isolation-group drain windows which are applied at their start time and reverted at their end time",
+		DefaultValue: []interface{}{},
+	},
 	DefaultIsolationGroupConfigStoreManagerGlobalMapping: {
 		KeyName: "system.defaultIsolationGroupConfigStoreManagerGlobalMapping",
 		Description: "A configuration store for global isolation groups - used in isolation-group config only, not normal dynamic config." +
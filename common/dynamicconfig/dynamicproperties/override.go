@@ -0,0 +1,232 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicproperties
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dgryski/go-farm"
+
+	"github.com/uber/cadence/common/types"
+)
+
+// Reserved filter names carrying the override policy of a value written to the config store.
+// They are not lookup dimensions: a value is matched on its other filters, then the policy decides if it is in effect.
+const (
+	// ExpiresAtFilterName holds the RFC3339 time after which the value no longer applies
+	ExpiresAtFilterName = "expiresAt"
+	// RolloutFilterName holds the list of rollout stages of the value
+	RolloutFilterName = "rollout"
+)
+
+type (
+	// Override is the policy of a config store value: when it expires and to which domains and shards it applies over time
+	Override struct {
+		// ExpiresAt is the time the value stops applying, zero if it never expires
+		ExpiresAt time.Time
+		// Rollout stages are ordered by start time, the value applies everywhere if there are none
+		Rollout []RolloutStage
+	}
+
+	// RolloutStage widens a value to a percentage of domains and a set of shards from StartTime on
+	RolloutStage struct {
+		StartTime        time.Time `json:"startTime"`
+		DomainPercentage int       `json:"domainPercentage,omitempty"`
+		ShardIDs         []int     `json:"shardIDs,omitempty"`
+	}
+)
+
+// IsOverrideFilter returns true if the filter name is reserved for the override policy
+func IsOverrideFilter(name string) bool {
+	return name == ExpiresAtFilterName || name == RolloutFilterName
+}
+
+// ParseOverride splits the filters of a value into its override policy and the filters used for lookups
+func ParseOverride(filters []*types.DynamicConfigFilter) (Override, []*types.DynamicConfigFilter, error) {
+	var override Override
+	if !hasOverrideFilter(filters) {
+		return override, filters, nil
+	}
+	lookupFilters := make([]*types.DynamicConfigFilter, 0, len(filters))
+	for _, filter := range filters {
+		if filter == nil || !IsOverrideFilter(filter.Name) {
+			lookupFilters = append(lookupFilters, filter)
+			continue
+		}
+		if filter.Value == nil || filter.Value.GetEncodingType() != types.EncodingTypeJSON {
+			return override, nil, fmt.Errorf("filter %q: unsupported blob encoding", filter.Name)
+		}
+		switch filter.Name {
+		case ExpiresAtFilterName:
+			if err := json.Unmarshal(filter.Value.Data, &override.ExpiresAt); err != nil {
+				return override, nil, fmt.Errorf("filter %q must be an RFC3339 time: %w", filter.Name, err)
+			}
+		case RolloutFilterName:
+			if err := json.Unmarshal(filter.Value.Data, &override.Rollout); err != nil {
+				return override, nil, fmt.Errorf("filter %q must be a list of rollout stages: %w", filter.Name, err)
+			}
+		}
+	}
+	return override, lookupFilters, nil
+}
+
+// OverrideFilters encodes an override policy as filters to be appended to the filters of a value
+func OverrideFilters(override Override) ([]*types.DynamicConfigFilter, error) {
+	var filters []*types.DynamicConfigFilter
+	if !override.ExpiresAt.IsZero() {
+		data, err := json.Marshal(override.ExpiresAt.UTC())
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, &types.DynamicConfigFilter{
+			Name:  ExpiresAtFilterName,
+			Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: data},
+		})
+	}
+	if len(override.Rollout) > 0 {
+		data, err := json.Marshal(override.Rollout)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, &types.DynamicConfigFilter{
+			Name:  RolloutFilterName,
+			Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: data},
+		})
+	}
+	return filters, nil
+}
+
+// Validate checks the rollout stages of the override of a value of key
+func (o Override) Validate(key Key) error {
+	declared := key.Filters()
+	for i, stage := range o.Rollout {
+		if stage.StartTime.IsZero() {
+			return fmt.Errorf("rollout stage %d: start time is not set", i)
+		}
+		if i > 0 && !stage.StartTime.After(o.Rollout[i-1].StartTime) {
+			return fmt.Errorf("rollout stage %d: stages must be ordered by increasing start time", i)
+		}
+		if stage.DomainPercentage < 0 || stage.DomainPercentage > 100 {
+			return fmt.Errorf("rollout stage %d: domain percentage must be between 0 and 100", i)
+		}
+		if stage.DomainPercentage == 0 && len(stage.ShardIDs) == 0 {
+			return fmt.Errorf("rollout stage %d: neither domain percentage nor shard IDs are set", i)
+		}
+		if len(declared) == 0 {
+			continue
+		}
+		if stage.DomainPercentage > 0 && stage.DomainPercentage < 100 && !containsFilter(declared, DomainName) {
+			return fmt.Errorf("rollout stage %d: %s has no domain name filter to roll out by domain percentage", i, key.String())
+		}
+		if len(stage.ShardIDs) > 0 && !containsFilter(declared, ShardID) {
+			return fmt.Errorf("rollout stage %d: %s has no shard filter to roll out by shard IDs", i, key.String())
+		}
+	}
+	if !o.ExpiresAt.IsZero() && len(o.Rollout) > 0 && !o.ExpiresAt.After(o.Rollout[0].StartTime) {
+		return errors.New("value expires before its rollout starts")
+	}
+	return nil
+}
+
+// Expired returns true if the value expired at now
+func (o Override) Expired(now time.Time) bool {
+	return !o.ExpiresAt.IsZero() && !now.Before(o.ExpiresAt)
+}
+
+// TTL returns the remaining time to live of the value at now, zero if it expired and negative if it never expires
+func (o Override) TTL(now time.Time) time.Duration {
+	if o.ExpiresAt.IsZero() {
+		return -1
+	}
+	if o.Expired(now) {
+		return 0
+	}
+	return o.ExpiresAt.Sub(now)
+}
+
+// CurrentStage returns the rollout stage in effect at now, false if the rollout did not start yet or there is none
+func (o Override) CurrentStage(now time.Time) (RolloutStage, bool) {
+	for i := len(o.Rollout) - 1; i >= 0; i-- {
+		if !now.Before(o.Rollout[i].StartTime) {
+			return o.Rollout[i], true
+		}
+	}
+	return RolloutStage{}, false
+}
+
+// Applies returns true if the value is in effect at now for a lookup with filters.
+// Domains are bucketed by a hash of their name, so a domain stays in the rollout as the percentage grows.
+// The name is the only identifier hashed: a domain looked up by ID would otherwise land in another bucket.
+// A lookup without domain name or shard only gets a fully rolled out value.
+func (o Override) Applies(now time.Time, filters map[Filter]interface{}) bool {
+	if o.Expired(now) {
+		return false
+	}
+	if len(o.Rollout) == 0 {
+		return true
+	}
+	stage, ok := o.CurrentStage(now)
+	if !ok {
+		return false
+	}
+	if stage.DomainPercentage >= 100 {
+		return true
+	}
+	if shardID, ok := intFilterValue(filters[ShardID]); ok {
+		for _, id := range stage.ShardIDs {
+			if id == shardID {
+				return true
+			}
+		}
+	}
+	if stage.DomainPercentage > 0 {
+		if domain, ok := filters[DomainName].(string); ok && domain != "" {
+			return int(farm.Fingerprint32([]byte(domain))%100) < stage.DomainPercentage
+		}
+	}
+	return false
+}
+
+func hasOverrideFilter(filters []*types.DynamicConfigFilter) bool {
+	for _, filter := range filters {
+		if filter != nil && IsOverrideFilter(filter.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func intFilterValue(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicproperties
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/types"
+)
+
+func TestParseOverride(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	override := Override{
+		ExpiresAt: now.Add(time.Hour),
+		Rollout: []RolloutStage{
+			{StartTime: now, DomainPercentage: 10, ShardIDs: []int{1}},
+			{StartTime: now.Add(time.Minute), DomainPercentage: 100},
+		},
+	}
+	overrideFilters, err := OverrideFilters(override)
+	require.NoError(t, err)
+	require.Len(t, overrideFilters, 2)
+
+	domainFilter := &types.DynamicConfigFilter{
+		Name:  DomainName.String(),
+		Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: []byte(`"domain"`)},
+	}
+	parsed, lookupFilters, err := ParseOverride(append([]*types.DynamicConfigFilter{domainFilter}, overrideFilters...))
+	require.NoError(t, err)
+	assert.Equal(t, []*types.DynamicConfigFilter{domainFilter}, lookupFilters)
+	assert.True(t, override.ExpiresAt.Equal(parsed.ExpiresAt))
+	require.Len(t, parsed.Rollout, 2)
+	assert.Equal(t, 10, parsed.Rollout[0].DomainPercentage)
+	assert.Equal(t, []int{1}, parsed.Rollout[0].ShardIDs)
+
+	parsed, lookupFilters, err = ParseOverride([]*types.DynamicConfigFilter{domainFilter})
+	require.NoError(t, err)
+	assert.Equal(t, Override{}, parsed)
+	assert.Equal(t, []*types.DynamicConfigFilter{domainFilter}, lookupFilters)
+
+	_, _, err = ParseOverride([]*types.DynamicConfigFilter{{
+		Name:  ExpiresAtFilterName,
+		Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: []byte(`"tomorrow"`)},
+	}})
+	assert.ErrorContains(t, err, "RFC3339")
+}
+
+func TestOverrideValidate(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		key         Key
+		override    Override
+		errContains string
+	}{
+		"no policy": {
+			key: MatchingMinTaskThrottlingBurstSize,
+		},
+		"domain rollout": {
+			key: MatchingMinTaskThrottlingBurstSize,
+			override: Override{ExpiresAt: now.Add(time.Hour), Rollout: []RolloutStage{
+				{StartTime: now, DomainPercentage: 10},
+				{StartTime: now.Add(time.Minute), DomainPercentage: 100},
+			}},
+		},
+		"unordered stages": {
+			key: MatchingMinTaskThrottlingBurstSize,
+			override: Override{Rollout: []RolloutStage{
+				{StartTime: now, DomainPercentage: 10},
+				{StartTime: now, DomainPercentage: 100},
+			}},
+			errContains: "increasing start time",
+		},
+		"percentage out of range": {
+			key:         MatchingMinTaskThrottlingBurstSize,
+			override:    Override{Rollout: []RolloutStage{{StartTime: now, DomainPercentage: 101}}},
+			errContains: "between 0 and 100",
+		},
+		"empty stage": {
+			key:         MatchingMinTaskThrottlingBurstSize,
+			override:    Override{Rollout: []RolloutStage{{StartTime: now}}},
+			errContains: "neither domain percentage nor shard IDs",
+		},
+		"shard rollout for key without shard filter": {
+			key:         MatchingMinTaskThrottlingBurstSize,
+			override:    Override{Rollout: []RolloutStage{{StartTime: now, ShardIDs: []int{1}}}},
+			errContains: "no shard filter",
+		},
+		"domain rollout for key filtered by domain ID": {
+			key:         MatchingEnableTaskInfoLogByDomainID,
+			override:    Override{Rollout: []RolloutStage{{StartTime: now, DomainPercentage: 10}}},
+			errContains: "no domain name filter",
+		},
+		"shard rollout for key without declared filters": {
+			key:      TestGetIntPropertyKey,
+			override: Override{Rollout: []RolloutStage{{StartTime: now, ShardIDs: []int{1}}}},
+		},
+		"expires before rollout": {
+			key:         MatchingMinTaskThrottlingBurstSize,
+			override:    Override{ExpiresAt: now, Rollout: []RolloutStage{{StartTime: now, DomainPercentage: 10}}},
+			errContains: "expires before its rollout starts",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.override.Validate(tc.key)
+			if tc.errContains == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.errContains)
+			}
+		})
+	}
+}
+
+func TestOverrideApplies(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	override := Override{
+		ExpiresAt: now.Add(time.Hour),
+		Rollout: []RolloutStage{
+			{StartTime: now, DomainPercentage: 50, ShardIDs: []int{3}},
+			{StartTime: now.Add(30 * time.Minute), DomainPercentage: 100},
+		},
+	}
+
+	assert.False(t, override.Applies(now.Add(-time.Second), map[Filter]interface{}{ShardID: 3}), "rollout not started")
+	assert.True(t, override.Applies(now, map[Filter]interface{}{ShardID: 3}), "listed shard")
+	assert.True(t, override.Applies(now, map[Filter]interface{}{ShardID: float64(3)}), "listed shard decoded from JSON")
+	assert.False(t, override.Applies(now, map[Filter]interface{}{ShardID: 4}), "unlisted shard")
+	assert.False(t, override.Applies(now, nil), "no domain or shard before full rollout")
+	assert.True(t, override.Applies(now.Add(30*time.Minute), nil), "fully rolled out")
+	assert.False(t, override.Applies(now.Add(time.Hour), nil), "expired")
+
+	// about half of the domains are in the first stage and all of them once fully rolled out
+	inFirstStage := 0
+	for i := 0; i < 1000; i++ {
+		filters := map[Filter]interface{}{DomainName: fmt.Sprintf("domain-%d", i)}
+		if override.Applies(now, filters) {
+			inFirstStage++
+			assert.True(t, override.Applies(now.Add(30*time.Minute), filters))
+		}
+	}
+	assert.InDelta(t, 500, inFirstStage, 100)
+	assert.False(t, override.Applies(now, map[Filter]interface{}{DomainID: "domain-id"}), "domains are only bucketed by name")
+
+	assert.True(t, Override{}.Applies(now, nil))
+	assert.Equal(t, time.Duration(-1), Override{}.TTL(now))
+	assert.Equal(t, time.Hour, override.TTL(now))
+	assert.Equal(t, time.Duration(0), override.TTL(now.Add(2*time.Hour)))
+}
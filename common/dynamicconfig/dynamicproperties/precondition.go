@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicproperties
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/uber/cadence/common/types"
+)
+
+// ExpectedValuesFilterName is reserved for the precondition of an update: the update only applies if the values
+// stored for the key still have the given digest. The admin UpdateDynamicConfig request has no field for it,
+// so it is sent as an extra value whose only filter has this name and holds the digest. It is never stored.
+//
+// The value itself is a JSON null, which is not a valid value for any key. Frontends which don't know the precondition
+// validate every value against the type of the key and reject the whole update, instead of storing the precondition
+// as a value or applying the update without checking it. Frontends which know it reject unknown filters too.
+const ExpectedValuesFilterName = "expectedValues"
+
+// ValuesDigest returns the digest of the values of a key, compared with the precondition of an update
+func ValuesDigest(values []*types.DynamicConfigValue) (string, error) {
+	type canonicalFilter struct {
+		Name  string `json:"name"`
+		Value []byte `json:"value"`
+	}
+	type canonicalValue struct {
+		Value   []byte            `json:"value"`
+		Filters []canonicalFilter `json:"filters"`
+	}
+	// empty and missing fields are normalized as they don't survive a round trip through the IDL the same way
+	canonical := make([]canonicalValue, 0, len(values))
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		v := canonicalValue{Value: value.Value.GetData(), Filters: []canonicalFilter{}}
+		for _, filter := range value.Filters {
+			if filter != nil {
+				v.Filters = append(v.Filters, canonicalFilter{Name: filter.Name, Value: filter.Value.GetData()})
+			}
+		}
+		canonical = append(canonical, v)
+	}
+	data, err := json.Marshal(canonical)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// WithExpectedValues appends the precondition that the values stored for the key are expected to be to values
+func WithExpectedValues(values []*types.DynamicConfigValue, expected []*types.DynamicConfigValue) ([]*types.DynamicConfigValue, error) {
+	digest, err := ValuesDigest(expected)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(digest)
+	if err != nil {
+		return nil, err
+	}
+	return append(values, &types.DynamicConfigValue{
+		Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: []byte("null")},
+		Filters: []*types.DynamicConfigFilter{{
+			Name:  ExpectedValuesFilterName,
+			Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: data},
+		}},
+	}), nil
+}
+
+// SplitPrecondition returns the values to store and the digest they are expected to replace, if there is a precondition
+func SplitPrecondition(values []*types.DynamicConfigValue) ([]*types.DynamicConfigValue, string, bool, error) {
+	var (
+		stored []*types.DynamicConfigValue
+		digest string
+		found  bool
+	)
+	for _, value := range values {
+		if !isPrecondition(value) {
+			stored = append(stored, value)
+			continue
+		}
+		if found {
+			return nil, "", false, fmt.Errorf("filter %q is given more than once", ExpectedValuesFilterName)
+		}
+		if value.Value.GetEncodingType() != types.EncodingTypeJSON || string(value.Value.GetData()) != "null" {
+			return nil, "", false, fmt.Errorf("filter %q: the value of the precondition must be null", ExpectedValuesFilterName)
+		}
+		filter := value.Filters[0]
+		if filter.Value.GetEncodingType() != types.EncodingTypeJSON {
+			return nil, "", false, fmt.Errorf("filter %q: unsupported blob encoding", ExpectedValuesFilterName)
+		}
+		if err := json.Unmarshal(filter.Value.GetData(), &digest); err != nil {
+			return nil, "", false, fmt.Errorf("filter %q must be a string: %w", ExpectedValuesFilterName, err)
+		}
+		found = true
+	}
+	return stored, digest, found, nil
+}
+
+func isPrecondition(value *types.DynamicConfigValue) bool {
+	return value != nil && len(value.Filters) == 1 && value.Filters[0] != nil && value.Filters[0].Name == ExpectedValuesFilterName
+}
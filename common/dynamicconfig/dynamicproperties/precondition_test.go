@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicproperties
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/types"
+)
+
+func TestPrecondition(t *testing.T) {
+	jsonBlob := func(data string) *types.DataBlob {
+		return &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: []byte(data)}
+	}
+	current := []*types.DynamicConfigValue{
+		{Value: jsonBlob("true"), Filters: []*types.DynamicConfigFilter{{Name: "domainName", Value: jsonBlob(`"domain"`)}}},
+		{Value: jsonBlob("false")},
+	}
+	values := []*types.DynamicConfigValue{{Value: jsonBlob("true")}}
+
+	withPrecondition, err := WithExpectedValues(values, current)
+	require.NoError(t, err)
+	require.NoError(t, ValidateConfigValues(TestGetBoolPropertyKey, withPrecondition))
+
+	stored, digest, found, err := SplitPrecondition(withPrecondition)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, values, stored)
+	currentDigest, err := ValuesDigest(current)
+	require.NoError(t, err)
+	assert.Equal(t, currentDigest, digest)
+
+	stored, _, found, err = SplitPrecondition(values)
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.Equal(t, values, stored)
+
+	twice, err := WithExpectedValues(withPrecondition, current)
+	require.NoError(t, err)
+	_, _, _, err = SplitPrecondition(twice)
+	assert.ErrorContains(t, err, "given more than once")
+
+	notNull := []*types.DynamicConfigValue{{Value: jsonBlob("true"), Filters: withPrecondition[1].Filters}}
+	_, _, _, err = SplitPrecondition(notNull)
+	assert.ErrorContains(t, err, "must be null")
+}
+
+func TestPrecondition_RejectedByValueValidation(t *testing.T) {
+	// frontends which don't know the precondition validate it as a value of the key, which must fail for every key type
+	withPrecondition, err := WithExpectedValues(nil, nil)
+	require.NoError(t, err)
+	require.Len(t, withPrecondition, 1)
+	decoded, err := decodeJSONBlob(withPrecondition[0].Value)
+	require.NoError(t, err)
+
+	for _, key := range []Key{
+		TestGetIntPropertyKey,
+		TestGetBoolPropertyKey,
+		TestGetFloat64PropertyKey,
+		TestGetStringPropertyKey,
+		TestGetDurationPropertyKey,
+		TestGetMapPropertyKey,
+		TestGetListPropertyKey,
+	} {
+		assert.Error(t, ValidateJSONValue(key, decoded), key.String())
+	}
+}
+
+func TestValuesDigest(t *testing.T) {
+	blob := &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: []byte("1")}
+	digest := func(values []*types.DynamicConfigValue) string {
+		d, err := ValuesDigest(values)
+		require.NoError(t, err)
+		return d
+	}
+
+	assert.Equal(t, digest(nil), digest([]*types.DynamicConfigValue{}))
+	assert.Equal(t, digest([]*types.DynamicConfigValue{{Value: blob}}), digest([]*types.DynamicConfigValue{{Value: blob, Filters: []*types.DynamicConfigFilter{}}}))
+	assert.NotEqual(t, digest(nil), digest([]*types.DynamicConfigValue{{Value: blob}}))
+	assert.NotEqual(t,
+		digest([]*types.DynamicConfigValue{{Value: blob}}),
+		digest([]*types.DynamicConfigValue{{Value: blob, Filters: []*types.DynamicConfigFilter{{Name: "domainName", Value: blob}}}}),
+	)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicproperties
+
+import (
+	"time"
+)
+
+// JSONSchemaDraft is the JSON Schema version of the schema returned by JSONSchema
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema returns the catalog of all production keys as a JSON Schema describing a dynamic config file:
+// every key maps to a list of values, each with an optional set of constraints keyed by filter name.
+// The type, description and default of every key and the filters it supports are taken from its definition.
+func JSONSchema() map[string]interface{} {
+	properties := make(map[string]interface{})
+	for _, key := range ListAllProductionKeys() {
+		properties[key.String()] = keySchema(key)
+	}
+	return map[string]interface{}{
+		"$schema":              JSONSchemaDraft,
+		"title":                "Cadence dynamic config",
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+func keySchema(key Key) map[string]interface{} {
+	value := ValueSchema(key)
+	value["default"] = jsonDefaultValue(key)
+
+	constraints := make(map[string]interface{})
+	filters := key.Filters()
+	if len(filters) == 0 {
+		for f := UnknownFilter + 1; f < LastFilterTypeForTest; f++ {
+			filters = append(filters, f)
+		}
+	}
+	for _, f := range filters {
+		if filterTakesInt(f) {
+			constraints[f.String()] = map[string]interface{}{"type": "integer"}
+		} else {
+			constraints[f.String()] = map[string]interface{}{"type": "string"}
+		}
+	}
+
+	return map[string]interface{}{
+		"description": key.Description(),
+		"type":        "array",
+		"items": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"value": value,
+				"constraints": map[string]interface{}{
+					"type":                 "object",
+					"properties":           constraints,
+					"additionalProperties": false,
+				},
+			},
+			"required":             []string{"value"},
+			"additionalProperties": false,
+		},
+	}
+}
+
+// ValueSchema returns the JSON Schema of a value of key
+func ValueSchema(key Key) map[string]interface{} {
+	switch key.(type) {
+	case IntKey:
+		return map[string]interface{}{"type": "integer"}
+	case BoolKey:
+		return map[string]interface{}{"type": "boolean"}
+	case FloatKey:
+		return map[string]interface{}{"type": "number"}
+	case StringKey:
+		return map[string]interface{}{"type": "string"}
+	case DurationKey:
+		return map[string]interface{}{"type": "string", "format": "duration", "description": "Go duration, e.g. 1m30s"}
+	case MapKey:
+		return map[string]interface{}{"type": "object"}
+	case ListKey:
+		return map[string]interface{}{"type": "array"}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func jsonDefaultValue(key Key) interface{} {
+	if d, ok := key.DefaultValue().(time.Duration); ok {
+		return d.String()
+	}
+	return key.DefaultValue()
+}
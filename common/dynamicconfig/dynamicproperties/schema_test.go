@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicproperties
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchema(t *testing.T) {
+	schema := JSONSchema()
+	assert.Equal(t, JSONSchemaDraft, schema["$schema"])
+
+	properties := schema["properties"].(map[string]interface{})
+	assert.Len(t, properties, len(ListAllProductionKeys()))
+	assert.NotContains(t, properties, TestGetIntPropertyKey.String())
+
+	keySchema := properties[MatchingMinTaskThrottlingBurstSize.String()].(map[string]interface{})
+	assert.Equal(t, "array", keySchema["type"])
+	item := keySchema["items"].(map[string]interface{})
+	itemProperties := item["properties"].(map[string]interface{})
+	value := itemProperties["value"].(map[string]interface{})
+	assert.Equal(t, "integer", value["type"])
+	constraints := itemProperties["constraints"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Len(t, constraints, 3)
+	assert.Equal(t, map[string]interface{}{"type": "integer"}, constraints[TaskType.String()])
+	assert.Equal(t, map[string]interface{}{"type": "string"}, constraints[DomainName.String()])
+
+	// the schema must be serializable to be exported
+	_, err := json.Marshal(schema)
+	require.NoError(t, err)
+}
+
+func TestValueSchema(t *testing.T) {
+	assert.Equal(t, "boolean", ValueSchema(TestGetBoolPropertyKey)["type"])
+	assert.Equal(t, "duration", ValueSchema(TestGetDurationPropertyKey)["format"])
+	assert.Equal(t, "array", ValueSchema(TestGetListPropertyKey)["type"])
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicproperties
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/uber/cadence/common/types"
+)
+
+// ValidateJSONValue checks that a JSON decoded value is valid for the type of key.
+// Int values may be decoded as float64 and duration values as strings parsable by time.ParseDuration.
+func ValidateJSONValue(key Key, value interface{}) error {
+	err := fmt.Errorf("key value pair mismatch, key type: %T, value type: %T", key, value)
+	switch key.(type) {
+	case IntKey:
+		if _, ok := value.(int); !ok {
+			floatVal, ok := value.(float64)
+			if !ok { // int can be decoded as float64
+				return err
+			}
+			if floatVal != math.Trunc(floatVal) {
+				return errors.New("value type is not int")
+			}
+		}
+	case BoolKey:
+		if _, ok := value.(bool); !ok {
+			return err
+		}
+	case FloatKey:
+		if _, ok := value.(float64); !ok {
+			return err
+		}
+	case StringKey:
+		if _, ok := value.(string); !ok {
+			return err
+		}
+	case DurationKey:
+		if _, ok := value.(time.Duration); !ok {
+			durationStr, ok := value.(string)
+			if !ok {
+				return err
+			}
+			if _, err = time.ParseDuration(durationStr); err != nil {
+				return errors.New("value string encoding cannot be parsed into duration")
+			}
+		}
+	case MapKey:
+		if _, ok := value.(map[string]interface{}); !ok {
+			return err
+		}
+	case ListKey:
+		if _, ok := value.([]interface{}); !ok {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown key type: %T", key)
+	}
+	return nil
+}
+
+// ValidateFilter checks that a filter is known and its JSON decoded value has the type of the filter.
+// Keys declaring their filters only accept those, keys without declared filters accept any known filter.
+func ValidateFilter(key Key, filterName string, value interface{}) error {
+	filter := ParseFilter(filterName)
+	if filter == UnknownFilter {
+		return fmt.Errorf("unknown filter %q", filterName)
+	}
+	if declared := key.Filters(); len(declared) > 0 && !containsFilter(declared, filter) {
+		return fmt.Errorf("filter %q is not supported by %s, supported filters: %v", filterName, key.String(), declared)
+	}
+	if filterTakesInt(filter) {
+		number, ok := value.(float64)
+		if _, isInt := value.(int); !isInt && (!ok || number != math.Trunc(number)) {
+			return fmt.Errorf("value of filter %q must be an int, got %T", filterName, value)
+		}
+		return nil
+	}
+	if _, ok := value.(string); !ok {
+		return fmt.Errorf("value of filter %q must be a string, got %T", filterName, value)
+	}
+	return nil
+}
+
+// ValidateConfigValues checks the values written to key through the admin API, the same filter can only be given once per value.
+// Filters reserved for the override policy of a value are validated as such, and so is the precondition of the update.
+func ValidateConfigValues(key Key, values []*types.DynamicConfigValue) error {
+	values, _, _, err := SplitPrecondition(values)
+	if err != nil {
+		return err
+	}
+	for i, value := range values {
+		if value == nil {
+			return fmt.Errorf("value %d: value is not set", i)
+		}
+		decoded, err := decodeJSONBlob(value.Value)
+		if err != nil {
+			return fmt.Errorf("value %d: %w", i, err)
+		}
+		if err := ValidateJSONValue(key, decoded); err != nil {
+			return fmt.Errorf("value %d: %w", i, err)
+		}
+		override, lookupFilters, err := ParseOverride(value.Filters)
+		if err != nil {
+			return fmt.Errorf("value %d: %w", i, err)
+		}
+		if err := override.Validate(key); err != nil {
+			return fmt.Errorf("value %d: %w", i, err)
+		}
+		if err := ValidateConfigFilters(key, lookupFilters); err != nil {
+			return fmt.Errorf("value %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ValidateConfigFilters checks the filters of a value of key given through the admin API
+func ValidateConfigFilters(key Key, filters []*types.DynamicConfigFilter) error {
+	seen := make(map[string]bool, len(filters))
+	for _, filter := range filters {
+		if filter == nil {
+			return errors.New("filter is not set")
+		}
+		if seen[filter.Name] {
+			return fmt.Errorf("filter %q is given more than once", filter.Name)
+		}
+		seen[filter.Name] = true
+		decoded, err := decodeJSONBlob(filter.Value)
+		if err != nil {
+			return fmt.Errorf("filter %q: %w", filter.Name, err)
+		}
+		if err := ValidateFilter(key, filter.Name, decoded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func filterTakesInt(filter Filter) bool {
+	return filter == TaskType || filter == ShardID
+}
+
+func containsFilter(filters []Filter, filter Filter) bool {
+	for _, f := range filters {
+		if f == filter {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeJSONBlob(blob *types.DataBlob) (interface{}, error) {
+	if blob == nil {
+		return nil, errors.New("value is not set")
+	}
+	if blob.GetEncodingType() != types.EncodingTypeJSON {
+		return nil, errors.New("unsupported blob encoding")
+	}
+	var value interface{}
+	err := json.Unmarshal(blob.Data, &value)
+	return value, err
+}
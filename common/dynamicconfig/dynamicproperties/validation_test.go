@@ -0,0 +1,158 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicproperties
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/types"
+)
+
+func TestValidateJSONValue(t *testing.T) {
+	tests := map[string]struct {
+		key     Key
+		value   interface{}
+		wantErr bool
+	}{
+		"int":                  {key: TestGetIntPropertyKey, value: float64(10)},
+		"int as fraction":      {key: TestGetIntPropertyKey, value: 1.5, wantErr: true},
+		"int as string":        {key: TestGetIntPropertyKey, value: "10", wantErr: true},
+		"bool":                 {key: TestGetBoolPropertyKey, value: true},
+		"bool as string":       {key: TestGetBoolPropertyKey, value: "true", wantErr: true},
+		"float":                {key: TestGetFloat64PropertyKey, value: 0.5},
+		"string":               {key: TestGetStringPropertyKey, value: "value"},
+		"duration":             {key: TestGetDurationPropertyKey, value: "1m30s"},
+		"duration unparsable":  {key: TestGetDurationPropertyKey, value: "90", wantErr: true},
+		"map":                  {key: TestGetMapPropertyKey, value: map[string]interface{}{"a": 1}},
+		"map as list":          {key: TestGetMapPropertyKey, value: []interface{}{1}, wantErr: true},
+		"list":                 {key: TestGetListPropertyKey, value: []interface{}{1}},
+		"list as scalar value": {key: TestGetListPropertyKey, value: float64(1), wantErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateJSONValue(tc.key, tc.value)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateFilter(t *testing.T) {
+	tests := map[string]struct {
+		key         Key
+		filter      string
+		value       interface{}
+		errContains string
+	}{
+		"unknown filter": {
+			key:         TestGetIntPropertyKey,
+			filter:      "unknownFilter",
+			value:       "x",
+			errContains: "unknown filter",
+		},
+		"any filter for key without declared filters": {
+			key:    TestGetIntPropertyKey,
+			filter: WorkflowType.String(),
+			value:  "wf",
+		},
+		"declared filter": {
+			key:    MatchingMinTaskThrottlingBurstSize,
+			filter: TaskListName.String(),
+			value:  "tl",
+		},
+		"undeclared filter": {
+			key:         MatchingMinTaskThrottlingBurstSize,
+			filter:      ShardID.String(),
+			value:       float64(1),
+			errContains: "is not supported by",
+		},
+		"int filter": {
+			key:    MatchingMinTaskThrottlingBurstSize,
+			filter: TaskType.String(),
+			value:  float64(1),
+		},
+		"int filter with string value": {
+			key:         MatchingMinTaskThrottlingBurstSize,
+			filter:      TaskType.String(),
+			value:       "1",
+			errContains: "must be an int",
+		},
+		"string filter with int value": {
+			key:         MatchingMinTaskThrottlingBurstSize,
+			filter:      DomainName.String(),
+			value:       float64(1),
+			errContains: "must be a string",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateFilter(tc.key, tc.filter, tc.value)
+			if tc.errContains == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.errContains)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues(t *testing.T) {
+	blob := func(v interface{}) *types.DataBlob {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: data}
+	}
+	domainFilter := &types.DynamicConfigFilter{Name: DomainName.String(), Value: blob("domain")}
+
+	assert.NoError(t, ValidateConfigValues(MatchingMinTaskThrottlingBurstSize, []*types.DynamicConfigValue{
+		{Value: blob(1)},
+		{Value: blob(2), Filters: []*types.DynamicConfigFilter{domainFilter}},
+	}))
+	assert.ErrorContains(t, ValidateConfigValues(MatchingMinTaskThrottlingBurstSize, []*types.DynamicConfigValue{
+		{Value: blob(1)},
+		{Value: blob("2")},
+	}), "value 1: key value pair mismatch")
+	assert.ErrorContains(t, ValidateConfigValues(MatchingMinTaskThrottlingBurstSize, []*types.DynamicConfigValue{
+		{Value: blob(1), Filters: []*types.DynamicConfigFilter{domainFilter, domainFilter}},
+	}), "given more than once")
+	assert.ErrorContains(t, ValidateConfigValues(MatchingMinTaskThrottlingBurstSize, []*types.DynamicConfigValue{
+		{Value: &types.DataBlob{EncodingType: types.EncodingTypeThriftRW.Ptr(), Data: []byte("1")}},
+	}), "unsupported blob encoding")
+	assert.ErrorContains(t, ValidateConfigValues(MatchingMinTaskThrottlingBurstSize, []*types.DynamicConfigValue{nil}), "value 0")
+
+	expiresAt := &types.DynamicConfigFilter{Name: ExpiresAtFilterName, Value: blob("2026-01-01T00:00:00Z")}
+	assert.NoError(t, ValidateConfigValues(MatchingMinTaskThrottlingBurstSize, []*types.DynamicConfigValue{
+		{Value: blob(2), Filters: []*types.DynamicConfigFilter{domainFilter, expiresAt}},
+	}))
+	assert.ErrorContains(t, ValidateConfigValues(MatchingMinTaskThrottlingBurstSize, []*types.DynamicConfigValue{
+		{Value: blob(2), Filters: []*types.DynamicConfigFilter{
+			{Name: RolloutFilterName, Value: blob([]map[string]interface{}{{"startTime": "2026-01-01T00:00:00Z", "shardIDs": []int{1}}})},
+		}},
+	}), "value 0: rollout stage 0")
+	assert.ErrorContains(t, ValidateConfigFilters(MatchingMinTaskThrottlingBurstSize, []*types.DynamicConfigFilter{expiresAt}), "unknown filter")
+}
@@ -62,6 +62,7 @@ type fileBasedClient struct {
 	config          *FileBasedClientConfig
 	doneCh          chan struct{}
 	logger          log.Logger
+	subscriptions   *Subscriptions
 }
 
 // NewFileBasedClient creates a file based client.
@@ -71,9 +72,10 @@ func NewFileBasedClient(config *FileBasedClientConfig, logger log.Logger, doneCh
 	}
 
 	client := &fileBasedClient{
-		config: config,
-		doneCh: doneCh,
-		logger: logger,
+		config:        config,
+		doneCh:        doneCh,
+		logger:        logger,
+		subscriptions: NewSubscriptions(),
 	}
 	if err := client.update(); err != nil {
 		return nil, err
@@ -239,6 +241,10 @@ func (fc *fileBasedClient) ListValue(name dynamicproperties.Key) ([]*types.Dynam
 	return nil, errors.New("not supported for file based client")
 }
 
+func (fc *fileBasedClient) Subscribe(name dynamicproperties.Key, filters map[dynamicproperties.Filter]interface{}, onChange func(value interface{})) func() {
+	return fc.subscriptions.Subscribe(fc.GetValueWithFilters, name, filters, onChange)
+}
+
 func (fc *fileBasedClient) update() error {
 	defer func() {
 		fc.lastUpdatedTime = time.Now()
@@ -283,6 +289,7 @@ func (fc *fileBasedClient) storeValues(newValues map[string][]*constrainedValue)
 
 	fc.values.Store(newValues)
 	fc.logger.Info("Updated dynamic config")
+	fc.subscriptions.Notify(fc.GetValueWithFilters)
 	return nil
 }
 
@@ -351,3 +351,24 @@ func (s *fileBasedClientSuite) TestUpdateConfig() {
 	err = client.UpdateValue(key, v)
 	s.NoError(err)
 }
+
+func (s *fileBasedClientSuite) TestSubscribe() {
+	client := s.client.(*fileBasedClient)
+	key := dynamicproperties.ValidSearchAttributes
+
+	var changes []interface{}
+	cancel := client.Subscribe(key, nil, func(value interface{}) {
+		changes = append(changes, value)
+	})
+	defer cancel()
+
+	s.NoError(client.UpdateValue(key, map[string]interface{}{"DomainID": 1}))
+	s.Empty(changes)
+
+	s.NoError(client.UpdateValue(key, map[string]interface{}{"DomainID": 2}))
+	s.Equal([]interface{}{map[string]interface{}{"DomainID": 2}}, changes)
+
+	// revert test file back
+	s.NoError(client.UpdateValue(key, map[string]interface{}{"DomainID": 1}))
+	s.Len(changes, 2)
+}
@@ -32,21 +32,24 @@ import (
 type inMemoryClient struct {
 	sync.RWMutex
 
-	globalValues map[dynamicproperties.Key]interface{}
+	globalValues  map[dynamicproperties.Key]interface{}
+	subscriptions *Subscriptions
 }
 
 // NewInMemoryClient creates a new in memory dynamic config client for testing purpose
 func NewInMemoryClient() Client {
 	return &inMemoryClient{
-		globalValues: make(map[dynamicproperties.Key]interface{}),
+		globalValues:  make(map[dynamicproperties.Key]interface{}),
+		subscriptions: NewSubscriptions(),
 	}
 }
 
 func (mc *inMemoryClient) SetValue(key dynamicproperties.Key, value interface{}) {
 	mc.Lock()
-	defer mc.Unlock()
-
 	mc.globalValues[key] = value
+	mc.Unlock()
+
+	mc.subscriptions.Notify(mc.GetValueWithFilters)
 }
 
 func (mc *inMemoryClient) GetValue(key dynamicproperties.Key) (interface{}, error) {
@@ -151,3 +154,7 @@ func (mc *inMemoryClient) RestoreValue(name dynamicproperties.Key, filters map[d
 func (mc *inMemoryClient) ListValue(name dynamicproperties.Key) ([]*types.DynamicConfigEntry, error) {
 	return nil, errors.New("not supported for in-memory client")
 }
+
+func (mc *inMemoryClient) Subscribe(name dynamicproperties.Key, filters map[dynamicproperties.Filter]interface{}, onChange func(value interface{})) func() {
+	return mc.subscriptions.Subscribe(mc.GetValueWithFilters, name, filters, onChange)
+}
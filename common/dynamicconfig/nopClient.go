@@ -83,6 +83,11 @@ func (mc *nopClient) ListValue(name dynamicproperties.Key) ([]*types.DynamicConf
 	return nil, errors.New("not supported for nop client")
 }
 
+// Subscribe never calls onChange since values of the nop client never change
+func (mc *nopClient) Subscribe(name dynamicproperties.Key, filters map[dynamicproperties.Filter]interface{}, onChange func(value interface{})) func() {
+	return func() {}
+}
+
 // NewNopClient creates a nop client
 func NewNopClient() Client {
 	return &nopClient{}
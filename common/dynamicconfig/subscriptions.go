@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicconfig
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+)
+
+type (
+	// Subscriptions keeps track of the subscriptions of a Client. Clients call Notify after each refresh of their values,
+	// subscribers whose value changed since the previous notification are then called with the new value.
+	Subscriptions struct {
+		sync.Mutex
+		nextID        int
+		subscriptions map[int]*subscription
+	}
+
+	subscription struct {
+		name     dynamicproperties.Key
+		filters  map[dynamicproperties.Filter]interface{}
+		onChange func(value interface{})
+		// notifications are serialized per subscription so subscribers observe changes in order
+		notifyLock sync.Mutex
+		value      interface{}
+	}
+
+	// ValueGetter returns the current value of a key for filters, it is the GetValueWithFilters method of the client
+	ValueGetter func(name dynamicproperties.Key, filters map[dynamicproperties.Filter]interface{}) (interface{}, error)
+)
+
+// NewSubscriptions creates an empty set of subscriptions
+func NewSubscriptions() *Subscriptions {
+	return &Subscriptions{
+		subscriptions: make(map[int]*subscription),
+	}
+}
+
+// Subscribe registers onChange for the value of name for filters, the current value is read with get.
+// onChange is not called for the current value. The returned function cancels the subscription.
+func (s *Subscriptions) Subscribe(
+	get ValueGetter,
+	name dynamicproperties.Key,
+	filters map[dynamicproperties.Filter]interface{},
+	onChange func(value interface{}),
+) func() {
+	sub := &subscription{
+		name:     name,
+		filters:  copyFilters(filters),
+		onChange: onChange,
+	}
+	if value, ok := currentValue(get, name, filters); ok {
+		sub.value = value
+	} else {
+		sub.value = name.DefaultValue()
+	}
+
+	s.Lock()
+	id := s.nextID
+	s.nextID++
+	s.subscriptions[id] = sub
+	s.Unlock()
+
+	return func() {
+		s.Lock()
+		defer s.Unlock()
+		delete(s.subscriptions, id)
+	}
+}
+
+// Notify reads the value of every subscription with get and calls the subscribers whose value changed.
+// Subscribers are called synchronously, outside of the lock of the subscriptions so they can subscribe and cancel.
+func (s *Subscriptions) Notify(get ValueGetter) {
+	s.Lock()
+	subscriptions := make([]*subscription, 0, len(s.subscriptions))
+	for _, sub := range s.subscriptions {
+		subscriptions = append(subscriptions, sub)
+	}
+	s.Unlock()
+
+	for _, sub := range subscriptions {
+		sub.notify(get)
+	}
+}
+
+// Len returns the number of active subscriptions
+func (s *Subscriptions) Len() int {
+	s.Lock()
+	defer s.Unlock()
+	return len(s.subscriptions)
+}
+
+func (sub *subscription) notify(get ValueGetter) {
+	sub.notifyLock.Lock()
+	defer sub.notifyLock.Unlock()
+
+	value, ok := currentValue(get, sub.name, sub.filters)
+	if !ok || reflect.DeepEqual(value, sub.value) {
+		return
+	}
+	sub.value = value
+	sub.onChange(value)
+}
+
+// currentValue returns the value of name for filters or its default value if it is not set.
+// ok is false if the value cannot be read, subscribers are not notified then.
+func currentValue(get ValueGetter, name dynamicproperties.Key, filters map[dynamicproperties.Filter]interface{}) (interface{}, bool) {
+	value, err := get(name, filters)
+	if err != nil && err != NotFoundError {
+		return nil, false
+	}
+	if value == nil {
+		return name.DefaultValue(), true
+	}
+	return value, true
+}
+
+func copyFilters(filters map[dynamicproperties.Filter]interface{}) map[dynamicproperties.Filter]interface{} {
+	copied := make(map[dynamicproperties.Filter]interface{}, len(filters))
+	for filter, value := range filters {
+		copied[filter] = value
+	}
+	return copied
+}
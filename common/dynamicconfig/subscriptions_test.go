@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicconfig
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/log"
+)
+
+func TestSubscriptions(t *testing.T) {
+	key := dynamicproperties.TestGetIntPropertyKey
+	values := map[string]interface{}{}
+	var getErr error
+	get := func(name dynamicproperties.Key, filters map[dynamicproperties.Filter]interface{}) (interface{}, error) {
+		if getErr != nil {
+			return nil, getErr
+		}
+		if value, ok := values[filters[dynamicproperties.DomainName].(string)]; ok {
+			return value, nil
+		}
+		return name.DefaultValue(), NotFoundError
+	}
+
+	subscriptions := NewSubscriptions()
+	var domainA, domainB []interface{}
+	cancelA := subscriptions.Subscribe(get, key, map[dynamicproperties.Filter]interface{}{dynamicproperties.DomainName: "a"}, func(value interface{}) {
+		domainA = append(domainA, value)
+	})
+	subscriptions.Subscribe(get, key, map[dynamicproperties.Filter]interface{}{dynamicproperties.DomainName: "b"}, func(value interface{}) {
+		domainB = append(domainB, value)
+	})
+	assert.Equal(t, 2, subscriptions.Len())
+
+	subscriptions.Notify(get)
+	assert.Empty(t, domainA, "subscribers are not called for unchanged values")
+
+	values["a"] = 10
+	subscriptions.Notify(get)
+	subscriptions.Notify(get)
+	assert.Equal(t, []interface{}{10}, domainA)
+	assert.Empty(t, domainB)
+
+	getErr = errors.New("unavailable")
+	subscriptions.Notify(get)
+	assert.Equal(t, []interface{}{10}, domainA, "subscribers are not called when values cannot be read")
+	getErr = nil
+
+	delete(values, "a")
+	values["b"] = 20
+	subscriptions.Notify(get)
+	assert.Equal(t, []interface{}{10, key.DefaultValue()}, domainA, "removed values fall back to the default value")
+	assert.Equal(t, []interface{}{20}, domainB)
+
+	cancelA()
+	assert.Equal(t, 1, subscriptions.Len())
+	values["a"] = 30
+	subscriptions.Notify(get)
+	assert.Len(t, domainA, 2)
+}
+
+func TestCollectionSubscribe(t *testing.T) {
+	client := NewInMemoryClient().(*inMemoryClient)
+	cln := NewCollection(client, log.NewNoop())
+	key := dynamicproperties.TestGetIntPropertyKey
+	property := cln.GetIntProperty(key)
+
+	var observed []int
+	cancel := cln.Subscribe(key, func() {
+		observed = append(observed, property())
+	}, dynamicproperties.DomainFilter("domain"))
+
+	client.SetValue(key, 5)
+	client.SetValue(key, 5)
+	client.SetValue(key, 6)
+	assert.Equal(t, []int{5, 6}, observed)
+
+	cancel()
+	client.SetValue(key, 7)
+	assert.Equal(t, []int{5, 6}, observed)
+}
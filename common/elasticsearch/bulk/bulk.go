@@ -57,6 +57,9 @@ type (
 		Backoff       GenericBackoff
 		BeforeFunc    GenericBulkBeforeFunc
 		AfterFunc     GenericBulkAfterFunc
+		// DisableItemRetries stops the processor from re-committing requests that were rejected
+		// with a retryable status, so that AfterFunc can decide whether to Add them again
+		DisableItemRetries bool
 	}
 
 	// GenericBackoff allows callers to implement their own Backoff strategy.
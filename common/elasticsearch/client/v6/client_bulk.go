@@ -42,6 +42,8 @@ type bulkProcessorParametersV6 struct {
 	Backoff       elastic.Backoff
 	BeforeFunc    elastic.BulkBeforeFunc
 	AfterFunc     elastic.BulkAfterFunc
+	// DisableItemRetries leaves retryable item failures to AfterFunc
+	DisableItemRetries bool
 }
 
 type v6BulkProcessor struct {
@@ -96,7 +98,11 @@ func (v *v6BulkProcessor) Flush() error {
 }
 
 func (c *ElasticV6) runBulkProcessor(ctx context.Context, p *bulkProcessorParametersV6) (*v6BulkProcessor, error) {
-	processor, err := c.client.BulkProcessor().
+	service := c.client.BulkProcessor()
+	if p.DisableItemRetries {
+		service = service.RetryItemStatusCodes()
+	}
+	processor, err := service.
 		Name(p.Name).
 		Workers(p.NumOfWorkers).
 		BulkActions(p.BulkActions).
@@ -128,14 +134,15 @@ func (c *ElasticV6) RunBulkProcessor(ctx context.Context, parameters *bulk.BulkP
 	}
 
 	return c.runBulkProcessor(ctx, &bulkProcessorParametersV6{
-		Name:          parameters.Name,
-		NumOfWorkers:  parameters.NumOfWorkers,
-		BulkActions:   parameters.BulkActions,
-		BulkSize:      parameters.BulkSize,
-		FlushInterval: parameters.FlushInterval,
-		Backoff:       parameters.Backoff,
-		BeforeFunc:    beforeFunc,
-		AfterFunc:     afterFunc,
+		Name:               parameters.Name,
+		NumOfWorkers:       parameters.NumOfWorkers,
+		BulkActions:        parameters.BulkActions,
+		BulkSize:           parameters.BulkSize,
+		FlushInterval:      parameters.FlushInterval,
+		Backoff:            parameters.Backoff,
+		BeforeFunc:         beforeFunc,
+		AfterFunc:          afterFunc,
+		DisableItemRetries: parameters.DisableItemRetries,
 	})
 }
 
@@ -400,6 +400,20 @@ func TestRunBulkProcessor(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "item retries disabled",
+			params: &bulk.BulkProcessorParameters{
+				Name:               "test-processor",
+				DisableItemRetries: true,
+				NumOfWorkers:       5,
+				BulkActions:        1000,
+				BulkSize:           2 * 1024 * 1024, // 2MB
+				FlushInterval:      30 * time.Second,
+				Backoff:            elastic.NewExponentialBackoff(10*time.Millisecond, 8*time.Second),
+				BeforeFunc: func(executionId int64, requests []bulk.GenericBulkableRequest) {
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -41,6 +41,8 @@ bulkProcessorParametersV7 struct {
 	Backoff       elastic.Backoff
 	BeforeFunc    elastic.BulkBeforeFunc
 	AfterFunc     elastic.BulkAfterFunc
+	// DisableItemRetries leaves retryable item failures to AfterFunc
+	DisableItemRetries bool
 }
 
 type v7BulkProcessor struct {
@@ -91,7 +93,11 @@ func (v *v7BulkProcessor) Flush() error {
 }
 
 func (c *ElasticV7) runBulkProcessor(ctx context.Context, p *bulkProcessorParametersV7) (*v7BulkProcessor, error) {
-	processor, err := c.client.BulkProcessor().
+	service := c.client.BulkProcessor()
+	if p.DisableItemRetries {
+		service = service.RetryItemStatusCodes()
+	}
+	processor, err := service.
 		Name(p.Name).
 		Workers(p.NumOfWorkers).
 		BulkActions(p.BulkActions).
@@ -172,14 +178,15 @@ func (c *ElasticV7) RunBulkProcessor(ctx context.Context, parameters *bulk.BulkP
 	}
 
 	return c.runBulkProcessor(ctx, &bulkProcessorParametersV7{
-		Name:          parameters.Name,
-		NumOfWorkers:  parameters.NumOfWorkers,
-		BulkActions:   parameters.BulkActions,
-		BulkSize:      parameters.BulkSize,
-		FlushInterval: parameters.FlushInterval,
-		Backoff:       parameters.Backoff,
-		BeforeFunc:    beforeFunc,
-		AfterFunc:     afterFunc,
+		Name:               parameters.Name,
+		NumOfWorkers:       parameters.NumOfWorkers,
+		BulkActions:        parameters.BulkActions,
+		BulkSize:           parameters.BulkSize,
+		FlushInterval:      parameters.FlushInterval,
+		Backoff:            parameters.Backoff,
+		BeforeFunc:         beforeFunc,
+		AfterFunc:          afterFunc,
+		DisableItemRetries: parameters.DisableItemRetries,
 	})
 }
 func convertV7ErrorToGenericError(err error) *bulk.GenericError {
@@ -31,28 +31,36 @@ import (
 	"github.com/uber/cadence/common/definition"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/visibility"
 )
 
 // VisibilityQueryValidator for sql query validation
 type VisibilityQueryValidator struct {
 	validSearchAttributes          dynamicproperties.MapPropertyFn
+	domainSearchAttributes         dynamicproperties.MapPropertyFnWithDomainFilter
+	searchAttributeAliases         dynamicproperties.MapPropertyFn
 	enableQueryAttributeValidation dynamicproperties.BoolPropertyFn
 }
 
 // NewQueryValidator create VisibilityQueryValidator
 func NewQueryValidator(
 	validSearchAttributes dynamicproperties.MapPropertyFn,
+	domainSearchAttributes dynamicproperties.MapPropertyFnWithDomainFilter,
+	searchAttributeAliases dynamicproperties.MapPropertyFn,
 	enableQueryAttributeValidation dynamicproperties.BoolPropertyFn) *VisibilityQueryValidator {
 	return &VisibilityQueryValidator{
 		validSearchAttributes:          validSearchAttributes,
+		domainSearchAttributes:         domainSearchAttributes,
+		searchAttributeAliases:         searchAttributeAliases,
 		enableQueryAttributeValidation: enableQueryAttributeValidation,
 	}
 }
 
-// ValidateQuery validates that search attributes in the query are legal.
-// Adds attr prefix for customized fields and returns modified query.
-func (qv *VisibilityQueryValidator) ValidateQuery(whereClause string) (string, error) {
+// ValidateQuery validates that search attributes in the query are legal in domain.
+// Resolves aliases of renamed search attributes, adds attr prefix for customized fields and returns modified query.
+func (qv *VisibilityQueryValidator) ValidateQuery(whereClause string, domain string) (string, error) {
 	if len(whereClause) != 0 {
+		qv = qv.forDomain(domain)
 		// Build a placeholder query that allows us to easily parse the contents of the where clause.
 		// IMPORTANT: This query is never executed, it is just used to parse and validate whereClause
 		var placeholderQuery string
@@ -139,7 +147,7 @@ func (qv *VisibilityQueryValidator) validateComparisonExpr(expr sqlparser.Expr)
 	if !ok {
 		return errors.New("invalid comparison expression")
 	}
-	colNameStr := colName.Name.String()
+	colNameStr := qv.resolveAlias(colName.Name.String())
 	if !qv.isValidSearchAttributes(colNameStr) {
 		return fmt.Errorf("invalid search attribute %q", colNameStr)
 	}
@@ -161,7 +169,7 @@ func (qv *VisibilityQueryValidator) validateRangeExpr(expr sqlparser.Expr) error
 	if !ok {
 		return errors.New("invalid range expression")
 	}
-	colNameStr := colName.Name.String()
+	colNameStr := qv.resolveAlias(colName.Name.String())
 
 	if !qv.isValidSearchAttributes(colNameStr) {
 		return fmt.Errorf("invalid search attribute %q", colNameStr)
@@ -184,7 +192,7 @@ func (qv *VisibilityQueryValidator) validateOrderByExpr(orderBy sqlparser.OrderB
 		if !ok {
 			return errors.New("invalid order by expression")
 		}
-		colNameStr := colName.Name.String()
+		colNameStr := qv.resolveAlias(colName.Name.String())
 		if qv.isValidSearchAttributes(colNameStr) {
 			if !definition.IsSystemIndexedKey(colNameStr) { // add search attribute prefix
 				orderByExpr.Expr = &sqlparser.ColName{
@@ -200,6 +208,15 @@ func (qv *VisibilityQueryValidator) validateOrderByExpr(orderBy sqlparser.OrderB
 	return nil
 }
 
+// forDomain returns a validator whose valid search attributes include the ones registered for domain
+func (qv *VisibilityQueryValidator) forDomain(domain string) *VisibilityQueryValidator {
+	validated := *qv
+	validated.validSearchAttributes = func(opts ...dynamicproperties.FilterOption) map[string]interface{} {
+		return visibility.EffectiveSearchAttributes(qv.validSearchAttributes(opts...), qv.domainSearchAttributes(domain))
+	}
+	return &validated
+}
+
 // isValidSearchAttributes return true if key is registered
 func (qv *VisibilityQueryValidator) isValidSearchAttributes(key string) bool {
 	if qv.enableQueryAttributeValidation() {
@@ -209,3 +226,8 @@ func (qv *VisibilityQueryValidator) isValidSearchAttributes(key string) bool {
 	}
 	return true
 }
+
+// resolveAlias returns the search attribute an alias of a renamed search attribute resolves to
+func (qv *VisibilityQueryValidator) resolveAlias(name string) string {
+	return visibility.ResolveSearchAttributeAlias(qv.searchAttributeAliases(), name)
+}
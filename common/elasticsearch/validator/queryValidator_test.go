@@ -36,7 +36,10 @@ func TestValidateQuery(t *testing.T) {
 		query     string
 		validated string
 		err       string
+		domain    string
 		dcValid   map[string]interface{}
+		dcDomain  map[string]interface{}
+		dcAliases map[string]interface{}
 	}{
 		{
 			msg:       "empty query",
@@ -173,6 +176,41 @@ func TestValidateQuery(t *testing.T) {
 				"CustomStringField": types.IndexedValueTypeString,
 			},
 		},
+		{
+			msg:       "alias of renamed field",
+			query:     "CustomName = 'value' and CustomCount between 1 and 10 order by CustomCount desc",
+			validated: "`Attr.CustomStringField` = 'value' and `Attr.CustomIntField` between 1 and 10 order by `Attr.CustomIntField` desc",
+			dcAliases: map[string]interface{}{
+				"CustomName":  "CustomStringField",
+				"CustomCount": "CustomIntField",
+			},
+		},
+		{
+			msg:   "alias of unknown field",
+			query: "CustomName = 'value'",
+			err:   "invalid search attribute \"UnknownField\"",
+			dcAliases: map[string]interface{}{
+				"CustomName": "UnknownField",
+			},
+		},
+		{
+			msg:       "field registered for domain",
+			query:     "CustomTeamField = 'value' order by CustomTeamField",
+			validated: "`Attr.CustomTeamField` = 'value' order by `Attr.CustomTeamField` asc",
+			domain:    "team-domain",
+			dcDomain: map[string]interface{}{
+				"CustomTeamField": types.IndexedValueTypeKeyword,
+			},
+		},
+		{
+			msg:    "field registered for another domain",
+			query:  "CustomTeamField = 'value'",
+			err:    "invalid search attribute \"CustomTeamField\"",
+			domain: "other-domain",
+			dcDomain: map[string]interface{}{
+				"CustomTeamField": types.IndexedValueTypeKeyword,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -184,9 +222,15 @@ func TestValidateQuery(t *testing.T) {
 				}
 				return valid
 			}
+			domainSearchAttr := func(domain string) map[string]interface{} {
+				if domain == "team-domain" {
+					return tt.dcDomain
+				}
+				return nil
+			}
 			validateSearchAttr := dynamicproperties.GetBoolPropertyFn(true)
-			qv := NewQueryValidator(validSearchAttr, validateSearchAttr)
-			validated, err := qv.ValidateQuery(tt.query)
+			qv := NewQueryValidator(validSearchAttr, domainSearchAttr, dynamicproperties.GetMapPropertyFn(tt.dcAliases), validateSearchAttr)
+			validated, err := qv.ValidateQuery(tt.query, tt.domain)
 			if err != nil {
 				assert.Equal(t, tt.err, err.Error())
 			} else {
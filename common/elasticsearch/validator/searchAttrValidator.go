@@ -29,6 +29,7 @@ import (
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/visibility"
 )
 
 // SearchAttributesValidator is used to validate search attributes
@@ -37,6 +38,9 @@ type SearchAttributesValidator struct {
 
 	enableQueryAttributeValidation    dynamicproperties.BoolPropertyFn
 	validSearchAttributes             dynamicproperties.MapPropertyFn
+	deprecatedSearchAttributes        dynamicproperties.MapPropertyFn
+	domainSearchAttributes            dynamicproperties.MapPropertyFnWithDomainFilter
+	searchAttributeAliases            dynamicproperties.MapPropertyFn
 	searchAttributesNumberOfKeysLimit dynamicproperties.IntPropertyFnWithDomainFilter
 	searchAttributesSizeOfValueLimit  dynamicproperties.IntPropertyFnWithDomainFilter
 	searchAttributesTotalSizeLimit    dynamicproperties.IntPropertyFnWithDomainFilter
@@ -47,6 +51,9 @@ func NewSearchAttributesValidator(
 	logger log.Logger,
 	enableQueryAttributeValidation dynamicproperties.BoolPropertyFn,
 	validSearchAttributes dynamicproperties.MapPropertyFn,
+	deprecatedSearchAttributes dynamicproperties.MapPropertyFn,
+	domainSearchAttributes dynamicproperties.MapPropertyFnWithDomainFilter,
+	searchAttributeAliases dynamicproperties.MapPropertyFn,
 	searchAttributesNumberOfKeysLimit dynamicproperties.IntPropertyFnWithDomainFilter,
 	searchAttributesSizeOfValueLimit dynamicproperties.IntPropertyFnWithDomainFilter,
 	searchAttributesTotalSizeLimit dynamicproperties.IntPropertyFnWithDomainFilter,
@@ -55,13 +62,16 @@ func NewSearchAttributesValidator(
 		logger:                            logger,
 		enableQueryAttributeValidation:    enableQueryAttributeValidation,
 		validSearchAttributes:             validSearchAttributes,
+		deprecatedSearchAttributes:        deprecatedSearchAttributes,
+		domainSearchAttributes:            domainSearchAttributes,
+		searchAttributeAliases:            searchAttributeAliases,
 		searchAttributesNumberOfKeysLimit: searchAttributesNumberOfKeysLimit,
 		searchAttributesSizeOfValueLimit:  searchAttributesSizeOfValueLimit,
 		searchAttributesTotalSizeLimit:    searchAttributesTotalSizeLimit,
 	}
 }
 
-// ValidateSearchAttributes validate search attributes are valid for writing and not exceed limits
+// ValidateSearchAttributes validate search attributes are valid for writing in domain and not exceed the limits of domain
 func (sv *SearchAttributesValidator) ValidateSearchAttributes(input *types.SearchAttributes, domain string) error {
 	if input == nil {
 		return nil
@@ -83,7 +93,9 @@ func (sv *SearchAttributesValidator) ValidateSearchAttributes(input *types.Searc
 	if validateAttrFn != nil {
 		validateAttr = validateAttrFn()
 	}
-	validAttr := sv.validSearchAttributes()
+	validAttr := visibility.EffectiveSearchAttributes(sv.validSearchAttributes(), sv.domainSearchAttributes(domain))
+	deprecatedAttr := sv.deprecatedSearchAttributes()
+	aliases := sv.searchAttributeAliases()
 	for key, val := range fields {
 		if validateAttr {
 			// verify: key is whitelisted
@@ -92,8 +104,19 @@ func (sv *SearchAttributesValidator) ValidateSearchAttributes(input *types.Searc
 					Error("invalid search attribute key")
 				return &types.BadRequestError{Message: fmt.Sprintf("%s is not a valid search attribute key", key)}
 			}
-			// verify: value has the correct type
-			if !sv.isValidSearchAttributesValue(validAttr, key, val) {
+			// verify: key is not removed
+			if visibility.IsDeprecatedSearchAttribute(deprecatedAttr, key) {
+				sv.logger.WithTags(tag.ESKey(key), tag.WorkflowDomainName(domain)).
+					Error("removed search attribute key")
+				return &types.BadRequestError{Message: fmt.Sprintf("%s is a removed search attribute key", key)}
+			}
+			// verify: value has the correct type, which is the type of the search attribute a key is aliased to after a type change.
+			// Values of either type are valid while the type change is pending reindex.
+			typeKey := visibility.ResolveSearchAttributeAlias(aliases, key)
+			if _, ok := visibility.PendingTypeChange(aliases, key); ok {
+				typeKey = visibility.IndexedSearchAttributeKey(validAttr, aliases, key, val)
+			}
+			if !sv.isValidSearchAttributesValue(validAttr, typeKey, val) {
 				sv.logger.WithTags(tag.ESKey(key), tag.ESValue(val), tag.WorkflowDomainName(domain)).
 					Error("invalid search attribute value")
 				return &types.BadRequestError{Message: fmt.Sprintf("%s is not a valid search attribute value for key %s", val, key)}
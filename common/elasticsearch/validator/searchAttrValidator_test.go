@@ -48,6 +48,18 @@ func (s *searchAttributesValidatorSuite) TestValidateSearchAttributes() {
 	validator := NewSearchAttributesValidator(log.NewNoop(),
 		dynamicproperties.GetBoolPropertyFn(true),
 		dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+		dynamicproperties.GetMapPropertyFn(map[string]interface{}{"CustomDoubleField": true}),
+		func(domain string) map[string]interface{} {
+			if domain == "team-domain" {
+				return map[string]interface{}{"CustomTeamField": float64(types.IndexedValueTypeKeyword)}
+			}
+			return map[string]interface{}{}
+		},
+		dynamicproperties.GetMapPropertyFn(map[string]interface{}{
+			"CustomDatetimeField": "CustomIntField",
+			"CustomNumber":        "CustomIntField",
+			"CustomStringField":   map[string]interface{}{"pendingReindex": "CustomIntField"},
+		}),
 		dynamicproperties.GetIntPropertyFilteredByDomain(numOfKeysLimit),
 		dynamicproperties.GetIntPropertyFilteredByDomain(sizeOfValueLimit),
 		dynamicproperties.GetIntPropertyFilteredByDomain(sizeOfTotalLimit))
@@ -83,6 +95,64 @@ func (s *searchAttributesValidatorSuite) TestValidateSearchAttributes() {
 	err = validator.ValidateSearchAttributes(attr, domain)
 	s.Equal(`InvalidKey is not a valid search attribute key`, err.Error())
 
+	fields = map[string][]byte{
+		"CustomTeamField": []byte(`"t"`),
+	}
+	attr.IndexedFields = fields
+	err = validator.ValidateSearchAttributes(attr, domain)
+	s.Equal(`CustomTeamField is not a valid search attribute key`, err.Error())
+	err = validator.ValidateSearchAttributes(attr, "team-domain")
+	s.NoError(err)
+
+	fields = map[string][]byte{
+		"CustomTeamField": []byte(`1`),
+	}
+	attr.IndexedFields = fields
+	err = validator.ValidateSearchAttributes(attr, "team-domain")
+	s.Equal(`1 is not a valid search attribute value for key CustomTeamField`, err.Error())
+
+	fields = map[string][]byte{
+		"CustomDatetimeField": []byte(`1`),
+	}
+	attr.IndexedFields = fields
+	err = validator.ValidateSearchAttributes(attr, domain)
+	s.NoError(err)
+
+	fields = map[string][]byte{
+		"CustomDatetimeField": []byte(`"2020-01-01T00:00:00Z"`),
+	}
+	attr.IndexedFields = fields
+	err = validator.ValidateSearchAttributes(attr, domain)
+	s.Equal(`"2020-01-01T00:00:00Z" is not a valid search attribute value for key CustomDatetimeField`, err.Error())
+
+	fields = map[string][]byte{
+		"CustomStringField": []byte(`1`),
+	}
+	attr.IndexedFields = fields
+	err = validator.ValidateSearchAttributes(attr, domain)
+	s.NoError(err)
+
+	fields = map[string][]byte{
+		"CustomStringField": []byte(`true`),
+	}
+	attr.IndexedFields = fields
+	err = validator.ValidateSearchAttributes(attr, domain)
+	s.Equal(`true is not a valid search attribute value for key CustomStringField`, err.Error())
+
+	fields = map[string][]byte{
+		"CustomNumber": []byte(`1`),
+	}
+	attr.IndexedFields = fields
+	err = validator.ValidateSearchAttributes(attr, domain)
+	s.Equal(`CustomNumber is not a valid search attribute key`, err.Error())
+
+	fields = map[string][]byte{
+		"CustomDoubleField": []byte(`1.5`),
+	}
+	attr.IndexedFields = fields
+	err = validator.ValidateSearchAttributes(attr, domain)
+	s.Equal(`CustomDoubleField is a removed search attribute key`, err.Error())
+
 	fields = map[string][]byte{
 		"CustomStringField": []byte(`"1"`),
 		"CustomBoolField":   []byte(`123`),
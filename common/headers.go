@@ -40,6 +40,13 @@ const (
 	// Header name to pass the feature flags from customer to client or server
 	ClientFeatureFlagsHeaderName = "cadence-client-feature-flags"
 
+	// DomainHeaderName refers to the name of the header that contains the domain of requests without a domain field,
+	// e.g. GetSearchAttributes includes the search attributes registered for the domain.
+	// It is a stopgap until those requests have a domain field in the IDL. Only clients which set the header, such as
+	// the CLI, list the domain's search attributes. Other clients, including the SDKs, get the search attributes valid
+	// for all domains. Upserts and queries carry the domain, so they accept the domain's search attributes either way.
+	DomainHeaderName = "cadence-domain"
+
 	// ClientImplHeaderName refers to the name of the
 	// header that contains the client implementation
 	ClientImplHeaderName = "cadence-client-name"
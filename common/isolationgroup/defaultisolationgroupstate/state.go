@@ -29,11 +29,13 @@ import (
 
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/isolationgroup"
 	"github.com/uber/cadence/common/isolationgroup/isolationgroupapi"
 	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/types"
 )
@@ -46,6 +48,10 @@ type defaultIsolationGroupStateHandler struct {
 	globalIsolationGroupDrains dynamicconfig.Client
 	config                     defaultConfig
 	metricsClient              metrics.Client
+	timeSource                 clock.TimeSource
+	// drainSchedules holds the []isolationgroupapi.DrainSchedule parsed from dynamic config
+	drainSchedules       atomic.Value
+	cancelDrainSchedules func()
 }
 
 // NewDefaultIsolationGroupStateWatcherWithConfigStoreClient Is a constructor which allows passing in the dynamic config client
@@ -62,9 +68,10 @@ func NewDefaultIsolationGroupStateWatcherWithConfigStoreClient(
 	config := defaultConfig{
 		IsolationGroupEnabled: dc.GetBoolPropertyFilteredByDomain(dynamicproperties.EnableTasklistIsolation),
 		AllIsolationGroups:    getIsolationGroups,
+		DrainSchedules:        dc.GetListProperty(dynamicproperties.IsolationGroupDrainSchedules),
 	}
 
-	return &defaultIsolationGroupStateHandler{
+	handler := &defaultIsolationGroupStateHandler{
 		done:                       stopChan,
 		domainCache:                domainCache,
 		globalIsolationGroupDrains: cfgStoreClient,
@@ -72,7 +79,11 @@ func NewDefaultIsolationGroupStateWatcherWithConfigStoreClient(
 		log:                        logger,
 		config:                     config,
 		metricsClient:              metricsClient,
-	}, nil
+		timeSource:                 clock.NewRealTimeSource(),
+	}
+	handler.loadDrainSchedules()
+	handler.cancelDrainSchedules = dc.Subscribe(dynamicproperties.IsolationGroupDrainSchedules, handler.loadDrainSchedules)
+	return handler, nil
 }
 
 func (z *defaultIsolationGroupStateHandler) IsDrained(ctx context.Context, domain string, isolationGroup string) (bool, error) {
@@ -102,6 +113,9 @@ func (z *defaultIsolationGroupStateHandler) Stop() {
 	if z == nil {
 		return
 	}
+	if z.cancelDrainSchedules != nil {
+		z.cancelDrainSchedules()
+	}
 	if !atomic.CompareAndSwapInt32(&z.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
 		return
 	}
@@ -142,9 +156,54 @@ func (z *defaultIsolationGroupStateHandler) get(ctx context.Context, domain stri
 		ig.Global = globalState
 	}
 
+	z.applyDrainSchedules(ig, domain)
+
 	return ig, nil
 }
 
+// loadDrainSchedules parses the drain schedules from dynamic config. It is called once and then whenever the
+// schedules change, IsDrained is called for every task matching dispatches and must not parse them every time.
+// Malformed schedules are skipped, they must not make the state of every domain unavailable.
+func (z *defaultIsolationGroupStateHandler) loadDrainSchedules() {
+	if z.config.DrainSchedules == nil {
+		return
+	}
+	schedules, errs := isolationgroupapi.ParseDrainSchedules(z.config.DrainSchedules())
+	for _, err := range errs {
+		z.log.Error("Skipping malformed isolationGroup drain schedule", tag.Error(err))
+	}
+	z.drainSchedules.Store(schedules)
+}
+
+// applyDrainSchedules overlays the scheduled drains which are currently in their window on top of the
+// manually configured state. Since this is evaluated on every read, a scheduled drain takes effect at its
+// start time and is reverted at its end time without anyone having to update the stored configuration.
+func (z *defaultIsolationGroupStateHandler) applyDrainSchedules(ig *isolationGroups, domain string) {
+	schedules, _ := z.drainSchedules.Load().([]isolationgroupapi.DrainSchedule)
+	if len(schedules) == 0 {
+		return
+	}
+	global, domainScoped := isolationgroupapi.ActiveDrainSchedules(schedules, domain, z.timeSource.Now())
+	ig.Global = mergeIsolationGroups(ig.Global, global)
+	ig.Domain = mergeIsolationGroups(ig.Domain, domainScoped)
+}
+
+// mergeIsolationGroups returns a copy of the base configuration with the overrides applied on top,
+// the base configuration is shared with caches and must not be modified
+func mergeIsolationGroups(base types.IsolationGroupConfiguration, overrides types.IsolationGroupConfiguration) types.IsolationGroupConfiguration {
+	if len(overrides) == 0 {
+		return base
+	}
+	out := make(types.IsolationGroupConfiguration, len(base)+len(overrides))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range overrides {
+		out[k] = v
+	}
+	return out
+}
+
 func isDrained(isolationGroup string, global types.IsolationGroupConfiguration, domain types.IsolationGroupConfiguration) bool {
 	globalCfg, hasGlobalConfig := global[isolationGroup]
 	domainCfg, hasDomainConfig := domain[isolationGroup]
@@ -27,11 +27,13 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/isolationgroup/isolationgroupapi"
@@ -142,6 +144,128 @@ func TestIsDrainedHandler(t *testing.T) {
 	}
 }
 
+func TestIsDrainedWithSchedules(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	schedules, err := isolationgroupapi.MapUpdateDrainSchedulesRequest([]isolationgroupapi.DrainSchedule{
+		{ID: "global-active", IsolationGroup: "zone-1", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)},
+		{ID: "global-upcoming", IsolationGroup: "zone-2", StartTime: now.Add(time.Hour), EndTime: now.Add(2 * time.Hour)},
+		{ID: "global-completed", IsolationGroup: "zone-3", StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-time.Hour)},
+		{ID: "domain-active", IsolationGroup: "zone-4", Domain: "domain", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)},
+		{ID: "other-domain-active", IsolationGroup: "zone-5", Domain: "other-domain", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)},
+		{ID: "cancelled", IsolationGroup: "zone-6", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour), CancelledTime: &now},
+	})
+	assert.NoError(t, err)
+	var dcValue []interface{}
+	assert.NoError(t, json.Unmarshal(schedules[0].Value.GetData(), &dcValue))
+
+	tests := map[string]struct {
+		isolationGroup string
+		schedules      []interface{}
+		expected       bool
+	}{
+		"active global window": {
+			isolationGroup: "zone-1",
+			schedules:      dcValue,
+			expected:       true,
+		},
+		"window not started yet": {
+			isolationGroup: "zone-2",
+			schedules:      dcValue,
+			expected:       false,
+		},
+		"window ended - drain is reverted": {
+			isolationGroup: "zone-3",
+			schedules:      dcValue,
+			expected:       false,
+		},
+		"active domain window": {
+			isolationGroup: "zone-4",
+			schedules:      dcValue,
+			expected:       true,
+		},
+		"active window for another domain": {
+			isolationGroup: "zone-5",
+			schedules:      dcValue,
+			expected:       false,
+		},
+		"cancelled window": {
+			isolationGroup: "zone-6",
+			schedules:      dcValue,
+			expected:       false,
+		},
+		"malformed schedules are skipped": {
+			isolationGroup: "zone-1",
+			schedules:      append([]interface{}{"not-a-schedule", map[string]interface{}{"id": "no-isolation-group"}}, dcValue...),
+			expected:       true,
+		},
+	}
+
+	for name, td := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtl := gomock.NewController(t)
+			domaincacheMock := cache.NewMockDomainCache(mockCtl)
+			domainResponse := cache.NewDomainCacheEntryForTest(&persistence.DomainInfo{ID: "domain-id", Name: "domain"}, &persistence.DomainConfig{}, true, nil, 0, nil, 0, 0, 0)
+			domaincacheMock.EXPECT().GetDomain("domain").Return(domainResponse, nil)
+
+			handler := defaultIsolationGroupStateHandler{
+				log:         testlogger.New(t),
+				domainCache: domaincacheMock,
+				config: defaultConfig{
+					IsolationGroupEnabled: func(string) bool { return true },
+					DrainSchedules:        func(...dynamicproperties.FilterOption) []interface{} { return td.schedules },
+				},
+				timeSource: clock.NewMockedTimeSourceAt(now),
+			}
+			handler.loadDrainSchedules()
+			res, err := handler.IsDrained(context.Background(), "domain", td.isolationGroup)
+			assert.NoError(t, err)
+			assert.Equal(t, td.expected, res)
+		})
+	}
+}
+
+func TestDrainSchedulesReloadedOnChange(t *testing.T) {
+	now := time.Now()
+	schedules, err := isolationgroupapi.MapUpdateDrainSchedulesRequest([]isolationgroupapi.DrainSchedule{
+		{ID: "active", IsolationGroup: "zone-1", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)},
+	})
+	assert.NoError(t, err)
+	var dcValue []interface{}
+	assert.NoError(t, json.Unmarshal(schedules[0].Value.GetData(), &dcValue))
+
+	mockCtl := gomock.NewController(t)
+	domaincacheMock := cache.NewMockDomainCache(mockCtl)
+	domainResponse := cache.NewDomainCacheEntryForTest(&persistence.DomainInfo{ID: "domain-id", Name: "domain"}, &persistence.DomainConfig{}, true, nil, 0, nil, 0, 0, 0)
+	domaincacheMock.EXPECT().GetDomain("domain").Return(domainResponse, nil).AnyTimes()
+
+	client := dynamicconfig.NewInMemoryClient()
+	assert.NoError(t, client.UpdateValue(dynamicproperties.EnableTasklistIsolation, true))
+	handler, err := NewDefaultIsolationGroupStateWatcherWithConfigStoreClient(
+		testlogger.New(t),
+		dynamicconfig.NewCollection(client, testlogger.New(t)),
+		domaincacheMock,
+		nil,
+		metrics.NewNoopMetricsClient(),
+		func() []string { return []string{"zone-1"} },
+	)
+	assert.NoError(t, err)
+
+	drained, err := handler.IsDrained(context.Background(), "domain", "zone-1")
+	assert.NoError(t, err)
+	assert.False(t, drained)
+
+	assert.NoError(t, client.UpdateValue(dynamicproperties.IsolationGroupDrainSchedules, dcValue))
+	drained, err = handler.IsDrained(context.Background(), "domain", "zone-1")
+	assert.NoError(t, err)
+	assert.True(t, drained)
+
+	handler.Stop()
+	assert.NoError(t, client.UpdateValue(dynamicproperties.IsolationGroupDrainSchedules, []interface{}{}))
+	drained, err = handler.IsDrained(context.Background(), "domain", "zone-1")
+	assert.NoError(t, err)
+	assert.True(t, drained, "schedules are not reloaded once the handler is stopped")
+}
+
 func TestIsDrained(t *testing.T) {
 
 	igA := "isolationGroupA"
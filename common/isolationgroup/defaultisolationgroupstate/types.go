@@ -40,4 +40,6 @@ type defaultConfig struct {
 	IsolationGroupEnabled dynamicproperties.BoolPropertyFnWithDomainFilter
 	// AllIsolationGroups is all the possible isolation-groups available for a region
 	AllIsolationGroups func() []string
+	// DrainSchedules is the list of planned drain windows, applied on top of the stored isolation-group state
+	DrainSchedules dynamicproperties.ListPropertyFn
 }
@@ -0,0 +1,176 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package isolationgroupapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/uber/cadence/common/types"
+)
+
+// DrainScheduleStatus is the lifecycle state of a DrainSchedule at a given point in time
+type DrainScheduleStatus string
+
+const (
+	DrainScheduleStatusUpcoming  DrainScheduleStatus = "upcoming"
+	DrainScheduleStatusActive    DrainScheduleStatus = "active"
+	DrainScheduleStatusCompleted DrainScheduleStatus = "completed"
+	DrainScheduleStatusCancelled DrainScheduleStatus = "cancelled"
+)
+
+// DrainSchedule is a planned window during which an isolation-group is drained, either for
+// all domains (when Domain is empty) or for a single domain. Schedules are never deleted when
+// cancelled so that the list doubles as an audit trail of planned maintenance.
+type DrainSchedule struct {
+	ID             string     `json:"id"`
+	IsolationGroup string     `json:"isolationGroup"`
+	Domain         string     `json:"domain,omitempty"`
+	StartTime      time.Time  `json:"startTime"`
+	EndTime        time.Time  `json:"endTime"`
+	Reason         string     `json:"reason"`
+	CreatedBy      string     `json:"createdBy,omitempty"`
+	CreatedTime    time.Time  `json:"createdTime"`
+	CancelledBy    string     `json:"cancelledBy,omitempty"`
+	CancelledTime  *time.Time `json:"cancelledTime,omitempty"`
+}
+
+// Status returns where the schedule is in its lifecycle at the given time
+func (s DrainSchedule) Status(now time.Time) DrainScheduleStatus {
+	switch {
+	case s.CancelledTime != nil:
+		return DrainScheduleStatusCancelled
+	case now.Before(s.StartTime):
+		return DrainScheduleStatusUpcoming
+	case now.Before(s.EndTime):
+		return DrainScheduleStatusActive
+	default:
+		return DrainScheduleStatusCompleted
+	}
+}
+
+// Validate checks that the schedule describes a usable drain window
+func (s DrainSchedule) Validate() error {
+	if s.ID == "" {
+		return fmt.Errorf("drain schedule id is required")
+	}
+	if s.IsolationGroup == "" {
+		return fmt.Errorf("isolation group is required for drain schedule %q", s.ID)
+	}
+	if !s.EndTime.After(s.StartTime) {
+		return fmt.Errorf("end time must be after start time for drain schedule %q", s.ID)
+	}
+	return nil
+}
+
+// ActiveDrainSchedules returns the isolation-group drains in effect for the domain at the given time,
+// split into the drains which apply to all domains and the ones which apply to this domain only
+func ActiveDrainSchedules(schedules []DrainSchedule, domain string, now time.Time) (global types.IsolationGroupConfiguration, domainScoped types.IsolationGroupConfiguration) {
+	for _, s := range schedules {
+		if s.Status(now) != DrainScheduleStatusActive {
+			continue
+		}
+		partition := types.IsolationGroupPartition{
+			Name:  s.IsolationGroup,
+			State: types.IsolationGroupStateDrained,
+		}
+		switch s.Domain {
+		case "":
+			if global == nil {
+				global = types.IsolationGroupConfiguration{}
+			}
+			global[s.IsolationGroup] = partition
+		case domain:
+			if domainScoped == nil {
+				domainScoped = types.IsolationGroupConfiguration{}
+			}
+			domainScoped[s.IsolationGroup] = partition
+		}
+	}
+	return global, domainScoped
+}
+
+// MapDrainSchedulesResponse converts the dynamic config representation of drain schedules,
+// failing if any of them is malformed
+func MapDrainSchedulesResponse(in []interface{}) ([]DrainSchedule, error) {
+	out, errs := ParseDrainSchedules(in)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return out, nil
+}
+
+// ParseDrainSchedules converts the dynamic config representation of drain schedules one by one,
+// so that a malformed schedule is returned as an error without preventing the others from being used
+func ParseDrainSchedules(in []interface{}) ([]DrainSchedule, []error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	var (
+		out  []DrainSchedule
+		errs []error
+	)
+	for i, item := range in {
+		data, err := json.Marshal(item)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse drain schedule %d from dynamic config: %w", i, err))
+			continue
+		}
+		var schedule DrainSchedule
+		if err := json.Unmarshal(data, &schedule); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse drain schedule %d from dynamic config: %w", i, err))
+			continue
+		}
+		if err := schedule.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid drain schedule %d in dynamic config: %w", i, err))
+			continue
+		}
+		out = append(out, schedule)
+	}
+	return out, errs
+}
+
+// MapUpdateDrainSchedulesRequest converts drain schedules into the value stored in dynamic config
+func MapUpdateDrainSchedulesRequest(in []DrainSchedule) ([]*types.DynamicConfigValue, error) {
+	for _, s := range in {
+		if err := s.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if in == nil {
+		in = []DrainSchedule{}
+	}
+	jsonData, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal drain schedules for dynamic config: %w", err)
+	}
+	return []*types.DynamicConfigValue{
+		{
+			Value: &types.DataBlob{
+				EncodingType: types.EncodingTypeJSON.Ptr(),
+				Data:         jsonData,
+			},
+		},
+	}, nil
+}
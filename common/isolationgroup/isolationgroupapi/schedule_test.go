@@ -0,0 +1,85 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package isolationgroupapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainScheduleStatus(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	schedule := DrainSchedule{ID: "id", IsolationGroup: "zone-1", StartTime: now, EndTime: now.Add(time.Hour)}
+
+	tests := map[string]struct {
+		at        time.Time
+		cancelled bool
+		expected  DrainScheduleStatus
+	}{
+		"before the window":         {at: now.Add(-time.Second), expected: DrainScheduleStatusUpcoming},
+		"at the start of window":    {at: now, expected: DrainScheduleStatusActive},
+		"at the end of window":      {at: now.Add(time.Hour), expected: DrainScheduleStatusCompleted},
+		"cancelled within a window": {at: now.Add(time.Minute), cancelled: true, expected: DrainScheduleStatusCancelled},
+	}
+
+	for name, td := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := schedule
+			if td.cancelled {
+				s.CancelledTime = &now
+			}
+			assert.Equal(t, td.expected, s.Status(td.at))
+		})
+	}
+}
+
+func TestMapDrainSchedules(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	schedules := []DrainSchedule{
+		{ID: "a", IsolationGroup: "zone-1", StartTime: now, EndTime: now.Add(time.Hour), Reason: "maintenance"},
+		{ID: "b", IsolationGroup: "zone-2", Domain: "domain", StartTime: now, EndTime: now.Add(time.Hour)},
+	}
+
+	values, err := MapUpdateDrainSchedulesRequest(schedules)
+	assert.NoError(t, err)
+	var dcValue []interface{}
+	assert.NoError(t, json.Unmarshal(values[0].Value.Data, &dcValue))
+	res, err := MapDrainSchedulesResponse(dcValue)
+	assert.NoError(t, err)
+	assert.Equal(t, schedules, res)
+
+	malformed := append([]interface{}{"not-a-schedule", map[string]interface{}{"id": "e"}}, dcValue...)
+	_, err = MapDrainSchedulesResponse(malformed)
+	assert.ErrorContains(t, err, "failed to parse drain schedule 0")
+	res, errs := ParseDrainSchedules(malformed)
+	assert.Equal(t, schedules, res)
+	assert.Len(t, errs, 2)
+
+	_, err = MapUpdateDrainSchedulesRequest([]DrainSchedule{{ID: "c", IsolationGroup: "zone-1", StartTime: now, EndTime: now}})
+	assert.ErrorContains(t, err, "end time must be after start time")
+	_, err = MapUpdateDrainSchedulesRequest([]DrainSchedule{{ID: "d", StartTime: now, EndTime: now.Add(time.Hour)}})
+	assert.ErrorContains(t, err, "isolation group is required")
+}
@@ -301,8 +301,9 @@ var (
 	StoreOperationGetDLQSize                 = storeOperation("get-dlq-size")
 	StoreOperationDeleteMessageFromDLQ       = storeOperation("delete-message-from-dlq")
 
-	StoreOperationFetchDynamicConfig  = storeOperation("fetch-dynamic-config")
-	StoreOperationUpdateDynamicConfig = storeOperation("update-dynamic-config")
+	StoreOperationFetchDynamicConfig        = storeOperation("fetch-dynamic-config")
+	StoreOperationUpdateDynamicConfig       = storeOperation("update-dynamic-config")
+	StoreOperationFetchDynamicConfigHistory = storeOperation("fetch-dynamic-config-history")
 )
 
 // Pre-defined values for TagSysClientOperation
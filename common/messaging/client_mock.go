@@ -186,6 +186,20 @@ func (mr *MockMessageMockRecorder) Nack() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Nack", reflect.TypeOf((*MockMessage)(nil).Nack))
 }
 
+// NackWithReason mocks base method.
+func (m *MockMessage) NackWithReason(reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NackWithReason", reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NackWithReason indicates an expected call of NackWithReason.
+func (mr *MockMessageMockRecorder) NackWithReason(reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NackWithReason", reflect.TypeOf((*MockMessage)(nil).NackWithReason), reason)
+}
+
 // Offset mocks base method.
 func (m *MockMessage) Offset() int64 {
 	m.ctrl.T.Helper()
@@ -53,6 +53,8 @@ type (
 		Ack() error
 		// Nack marks the message processing as failed and the message will be retried or sent to DLQ.
 		Nack() error
+		// NackWithReason is Nack, additionally recording why processing failed alongside the message in the DLQ.
+		NackWithReason(reason string) error
 	}
 
 	// Producer is the interface used to send replication tasks to other clusters through replicator
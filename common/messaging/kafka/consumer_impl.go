@@ -38,6 +38,9 @@ import (
 const rcvBufferSize = 2 * 1024
 const dlqPublishTimeout = time.Minute
 
+// DLQReasonHeader is the header of DLQ messages carrying the reason given when the message was nacked
+const DLQReasonHeader = "cadence-dlq-reason"
+
 type (
 	// a wrapper of sarama consumer group for our consumer interface
 	consumerImpl struct {
@@ -187,16 +190,17 @@ func (h *consumerHandlerImpl) getCurrentSession() sarama.ConsumerGroupSession {
 	return h.currentSession
 }
 
-func (h *consumerHandlerImpl) completeMessage(message *messageImpl, isAck bool) error {
+func (h *consumerHandlerImpl) completeMessage(message *messageImpl, isAck bool, reason string) error {
 	h.RLock()
 	defer h.RUnlock()
 
 	if !isAck {
+		dlqMsg := newDLQMessage(message.saramaMsg, reason)
 		op := func(ctx context.Context) error {
 			// NOTE: current KafkaProducer is not taking use the this context, because saramaProducer doesn't support it
 			// https://github.com/IBM/sarama/issues/1849
 			ctx, cancel := context.WithTimeout(ctx, dlqPublishTimeout)
-			err := h.dlqProducer.Publish(ctx, message.saramaMsg)
+			err := h.dlqProducer.Publish(ctx, dlqMsg)
 			cancel()
 			return err
 		}
@@ -205,11 +209,13 @@ func (h *consumerHandlerImpl) completeMessage(message *messageImpl, isAck bool)
 			h.metricsClient.IncCounter(metrics.MessagingClientConsumerScope, metrics.KafkaConsumerMessageNackDlqErr)
 			h.logger.Error("Fail to publish message to DLQ when nacking message, please take action!!",
 				tag.KafkaPartition(message.Partition()),
-				tag.KafkaOffset(message.Offset()))
+				tag.KafkaOffset(message.Offset()),
+				tag.Reason(reason))
 		} else {
 			h.logger.Warn("nack message and publish to DLQ",
 				tag.KafkaPartition(message.Partition()),
-				tag.KafkaOffset(message.Offset()))
+				tag.KafkaOffset(message.Offset()),
+				tag.Reason(reason))
 		}
 	}
 	ackLevel, err := h.manager.CompleteMessage(message.Partition(), message.Offset(), isAck)
@@ -268,14 +274,18 @@ func (m *messageImpl) Ack() error {
 	if m.isFromPreviousSession() {
 		return nil
 	}
-	return m.handler.completeMessage(m, true)
+	return m.handler.completeMessage(m, true, "")
 }
 
 func (m *messageImpl) Nack() error {
+	return m.NackWithReason("")
+}
+
+func (m *messageImpl) NackWithReason(reason string) error {
 	if m.isFromPreviousSession() {
 		return nil
 	}
-	return m.handler.completeMessage(m, false)
+	return m.handler.completeMessage(m, false, reason)
 }
 
 func (m *messageImpl) isFromPreviousSession() bool {
@@ -290,3 +300,17 @@ func (m *messageImpl) isFromPreviousSession() bool {
 	}
 	return false
 }
+
+// newDLQMessage returns the copy of a consumed message to publish to the DLQ,
+// with the nack reason attached as a header when there is one
+func newDLQMessage(msg *sarama.ConsumerMessage, reason string) *sarama.ConsumerMessage {
+	if reason == "" {
+		return msg
+	}
+	dlqMsg := *msg
+	dlqMsg.Headers = append(append([]*sarama.RecordHeader(nil), msg.Headers...), &sarama.RecordHeader{
+		Key:   []byte(DLQReasonHeader),
+		Value: []byte(reason),
+	})
+	return &dlqMsg
+}
@@ -148,6 +148,17 @@ func TestMessageImpl(t *testing.T) {
 	err = msgImpl.Nack()
 	assert.NoError(t, err)
 
+	// the nack reason is published as a header of the DLQ message
+	mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		assert.Equal(t, []sarama.RecordHeader{{Key: []byte(DLQReasonHeader), Value: []byte("test reason")}}, msg.Headers)
+		return nil
+	})
+	msgImpl.handler.manager.AddMessage(partition, offset+1)
+	msgImpl.saramaMsg.Offset = offset + 1
+	err = msgImpl.NackWithReason("test reason")
+	assert.NoError(t, err)
+	assert.Empty(t, msgImpl.saramaMsg.Headers, "consumed message should not be modified")
+
 	close(msgChan)
 }
 
@@ -111,6 +111,11 @@ func (p *producerImpl) getProducerMessage(message interface{}) (*sarama.Producer
 			Key:   sarama.ByteEncoder(message.Key),
 			Value: sarama.ByteEncoder(message.Value),
 		}
+		for _, header := range message.Headers {
+			if header != nil {
+				msg.Headers = append(msg.Headers, *header)
+			}
+		}
 		return msg, nil
 	case *indexer.PinotMessage:
 		msg := &sarama.ProducerMessage{
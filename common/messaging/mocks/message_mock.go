@@ -55,6 +55,20 @@ func (_m *Message) Nack() error {
 	return r0
 }
 
+// NackWithReason provides a mock function with given fields: reason
+func (_m *Message) NackWithReason(reason string) error {
+	ret := _m.Called(reason)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Offset provides a mock function with given fields:
 func (_m *Message) Offset() int64 {
 	ret := _m.Called()
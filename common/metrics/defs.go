@@ -322,6 +322,8 @@ const (
 	PersistenceFetchDynamicConfigScope
 	// PersistenceUpdateDynamicConfigScope tracks UpdateDynamicConfig calls made by service to persistence layer
 	PersistenceUpdateDynamicConfigScope
+	// PersistenceFetchDynamicConfigHistoryScope tracks FetchDynamicConfigHistory calls made by service to persistence layer
+	PersistenceFetchDynamicConfigHistoryScope
 	// PersistenceShardRequestCountScope tracks number of persistence calls made to each shard
 	PersistenceShardRequestCountScope
 	// PersistencePerHostScope is a constant scope for per-host persistence latency metrics
@@ -1647,6 +1649,7 @@ var ScopeDefs = map[ServiceIdx]map[ScopeIdx]scopeDefinition{
 		PersistenceGetDLQSizeScope:                               {operation: "GetDLQSize"},
 		PersistenceFetchDynamicConfigScope:                       {operation: "FetchDynamicConfig"},
 		PersistenceUpdateDynamicConfigScope:                      {operation: "UpdateDynamicConfig"},
+		PersistenceFetchDynamicConfigHistoryScope:                {operation: "FetchDynamicConfigHistory"},
 		PersistenceShardRequestCountScope:                        {operation: "ShardIdPersistenceRequest"},
 		PersistencePerHostScope:                                  {operation: "persistence_operations"},
 		PersistenceGetActiveClusterSelectionPolicyScope:          {operation: "GetActiveClusterSelectionPolicy"},
@@ -2537,6 +2540,9 @@ const (
 	GlobalRatelimiterRemovedLimits
 	GlobalRatelimiterRemovedHostLimits
 
+	// RequestCostUnitsCounter is the number of ratelimiter tokens consumed by allowed frontend requests with a cost, per domain
+	RequestCostUnitsCounter
+
 	// p2p rpc metrics
 	P2PPeersCount
 	P2PPeerAdded
@@ -2877,6 +2883,8 @@ const (
 	LastRetrievedMessageID
 	LastProcessedMessageID
 	ReplicationLatency
+	ReplicationLagSLOViolation
+	ReplicationDomainLatency
 	ReplicationTasksApplied
 	ReplicationTasksFailed
 	ReplicationTasksLag
@@ -3070,6 +3078,8 @@ const (
 	IsolationGroupStoppedPolling
 	IsolationGroupUpscale
 	IsolationGroupDownscale
+	IsolationGroupDrainReroute
+	IsolationGroupDrainStickyReset
 	PartitionDrained
 
 	NumMatchingMetrics
@@ -3503,6 +3513,8 @@ var MetricDefs = map[ServiceIdx]map[MetricIdx]metricDefinition{
 		GlobalRatelimiterRemovedLimits:     {metricName: "global_ratelimiter_removed_limits", metricType: Histogram, buckets: GlobalRatelimiterUsageHistogram},
 		GlobalRatelimiterRemovedHostLimits: {metricName: "global_ratelimiter_removed_host_limits", metricType: Histogram, buckets: GlobalRatelimiterUsageHistogram},
 
+		RequestCostUnitsCounter: {metricName: "request_cost_units", metricType: Counter},
+
 		P2PPeersCount:                          {metricName: "peers_count", metricType: Gauge},
 		P2PPeerAdded:                           {metricName: "peer_added", metricType: Counter},
 		P2PPeerRemoved:                         {metricName: "peer_removed", metricType: Counter},
@@ -3825,6 +3837,8 @@ var MetricDefs = map[ServiceIdx]map[MetricIdx]metricDefinition{
 		LastRetrievedMessageID:                                        {metricName: "last_retrieved_message_id", metricType: Gauge},
 		LastProcessedMessageID:                                        {metricName: "last_processed_message_id", metricType: Gauge},
 		ReplicationLatency:                                            {metricName: "replication_latency", metricType: Gauge},
+		ReplicationLagSLOViolation:                                    {metricName: "replication_lag_slo_violation", metricType: Counter},
+		ReplicationDomainLatency:                                      {metricName: "replication_domain_latency", metricType: Gauge},
 		ReplicationTasksApplied:                                       {metricName: "replication_tasks_applied", metricType: Counter},
 		ReplicationTasksFailed:                                        {metricName: "replication_tasks_failed", metricType: Counter},
 		ReplicationTasksLag:                                           {metricName: "replication_tasks_lag", metricType: Timer},
@@ -4014,6 +4028,8 @@ var MetricDefs = map[ServiceIdx]map[MetricIdx]metricDefinition{
 		IsolationGroupStoppedPolling:                                     {metricName: "ig_stopped_polling_per_tl", metricRollupName: "ig_stopped_polling"},
 		IsolationGroupUpscale:                                            {metricName: "ig_upscale_per_tl", metricRollupName: "ig_upscale"},
 		IsolationGroupDownscale:                                          {metricName: "ig_downscale_per_tl", metricRollupName: "ig_downscale"},
+		IsolationGroupDrainReroute:                                       {metricName: "ig_drain_reroute_per_tl", metricRollupName: "ig_drain_reroute"},
+		IsolationGroupDrainStickyReset:                                   {metricName: "ig_drain_sticky_reset_per_tl", metricRollupName: "ig_drain_sticky_reset"},
 		IsolationGroupPartitionsGauge:                                    {metricName: "ig_partitions_per_tl", metricType: Gauge},
 	},
 	Worker: {
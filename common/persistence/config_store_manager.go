@@ -22,6 +22,7 @@ package persistence
 
 import (
 	"context"
+	"math"
 
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/constants"
@@ -67,11 +68,37 @@ func (m *configStoreManagerImpl) FetchDynamicConfig(ctx context.Context, cfgType
 	}
 
 	return &FetchDynamicConfigResponse{Snapshot: &DynamicConfigSnapshot{
-		Version: values.Version,
-		Values:  config,
+		Version:   values.Version,
+		Values:    config,
+		Timestamp: values.Timestamp,
 	}}, nil
 }
 
+func (m *configStoreManagerImpl) FetchDynamicConfigHistory(ctx context.Context, request *FetchDynamicConfigHistoryRequest, cfgType ConfigType) (*FetchDynamicConfigHistoryResponse, error) {
+	maxVersion := request.MaxVersion
+	if maxVersion == 0 {
+		maxVersion = math.MaxInt64
+	}
+	entries, err := m.persistence.FetchConfigHistory(ctx, cfgType, maxVersion, request.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*DynamicConfigSnapshot, 0, len(entries))
+	for _, entry := range entries {
+		config, err := m.serializer.DeserializeDynamicConfigBlob(entry.Values)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, &DynamicConfigSnapshot{
+			Version:   entry.Version,
+			Values:    config,
+			Timestamp: entry.Timestamp,
+		})
+	}
+	return &FetchDynamicConfigHistoryResponse{Snapshots: snapshots}, nil
+}
+
 func (m *configStoreManagerImpl) UpdateDynamicConfig(ctx context.Context, request *UpdateDynamicConfigRequest, cfgType ConfigType) error {
 	blob, err := m.serializer.SerializeDynamicConfigBlob(request.Snapshot.Values, constants.EncodingTypeThriftRW)
 	if err != nil {
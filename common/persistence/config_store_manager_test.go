@@ -25,6 +25,7 @@ package persistence
 import (
 	"context"
 	"errors"
+	"math"
 	"testing"
 	"time"
 
@@ -66,6 +67,7 @@ func TestNewConfigStoreManagerImpl(t *testing.T) {
 
 func TestFetchDynamicConfig(t *testing.T) {
 	encodingType := constants.EncodingTypeThriftRW
+	timestamp := time.Now()
 	testCases := []struct {
 		name             string
 		setupMock        func(mockStore *MockConfigStore, mockSerializer *MockPayloadSerializer)
@@ -80,7 +82,7 @@ func TestFetchDynamicConfig(t *testing.T) {
 				// Mocking persistence DataBlob
 				mockStore.EXPECT().FetchConfig(gomock.Any(), DynamicConfig).Return(&InternalConfigStoreEntry{
 					Version:   1,
-					Timestamp: time.Now(),
+					Timestamp: timestamp,
 					Values:    &DataBlob{Encoding: encodingType, Data: []byte("serialized-values")},
 				}, nil).Times(1)
 
@@ -117,7 +119,8 @@ func TestFetchDynamicConfig(t *testing.T) {
 			expectError: false,
 			expectedResponse: &FetchDynamicConfigResponse{
 				Snapshot: &DynamicConfigSnapshot{
-					Version: 1,
+					Version:   1,
+					Timestamp: timestamp,
 					Values: &types.DynamicConfigBlob{
 						SchemaVersion: 1,
 						Entries: []*types.DynamicConfigEntry{
@@ -192,6 +195,48 @@ func TestFetchDynamicConfig(t *testing.T) {
 	}
 }
 
+func TestFetchDynamicConfigHistory(t *testing.T) {
+	now := time.Now()
+	blob := &DataBlob{Encoding: constants.EncodingTypeThriftRW, Data: []byte("serialized-values")}
+	values := &types.DynamicConfigBlob{SchemaVersion: 1}
+
+	t.Run("latest snapshots", func(t *testing.T) {
+		configStoreManager, mockStore, mockSerializer := setUpMocksForConfigStoreManager(t)
+		mockStore.EXPECT().FetchConfigHistory(gomock.Any(), DynamicConfig, int64(math.MaxInt64), 2).Return([]*InternalConfigStoreEntry{
+			{Version: 3, Timestamp: now, Values: blob},
+			{Version: 2, Timestamp: now.Add(-time.Minute), Values: blob},
+		}, nil).Times(1)
+		mockSerializer.EXPECT().DeserializeDynamicConfigBlob(blob).Return(values, nil).Times(2)
+
+		resp, err := configStoreManager.FetchDynamicConfigHistory(context.Background(), &FetchDynamicConfigHistoryRequest{PageSize: 2}, DynamicConfig)
+		assert.NoError(t, err)
+		assert.Equal(t, &FetchDynamicConfigHistoryResponse{Snapshots: []*DynamicConfigSnapshot{
+			{Version: 3, Timestamp: now, Values: values},
+			{Version: 2, Timestamp: now.Add(-time.Minute), Values: values},
+		}}, resp)
+	})
+
+	t.Run("older snapshots", func(t *testing.T) {
+		configStoreManager, mockStore, _ := setUpMocksForConfigStoreManager(t)
+		mockStore.EXPECT().FetchConfigHistory(gomock.Any(), DynamicConfig, int64(1), 10).Return(nil, nil).Times(1)
+
+		resp, err := configStoreManager.FetchDynamicConfigHistory(context.Background(), &FetchDynamicConfigHistoryRequest{MaxVersion: 1, PageSize: 10}, DynamicConfig)
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Snapshots)
+	})
+
+	t.Run("deserialization error", func(t *testing.T) {
+		configStoreManager, mockStore, mockSerializer := setUpMocksForConfigStoreManager(t)
+		mockStore.EXPECT().FetchConfigHistory(gomock.Any(), DynamicConfig, gomock.Any(), gomock.Any()).Return([]*InternalConfigStoreEntry{
+			{Version: 3, Timestamp: now, Values: blob},
+		}, nil).Times(1)
+		mockSerializer.EXPECT().DeserializeDynamicConfigBlob(blob).Return(nil, errors.New("deserialization error")).Times(1)
+
+		_, err := configStoreManager.FetchDynamicConfigHistory(context.Background(), &FetchDynamicConfigHistoryRequest{PageSize: 10}, DynamicConfig)
+		assert.ErrorContains(t, err, "deserialization error")
+	})
+}
+
 func TestUpdateDynamicConfig(t *testing.T) {
 	encodingType := constants.EncodingTypeThriftRW
 	testCases := []struct {
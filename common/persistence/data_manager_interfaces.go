@@ -1654,6 +1654,20 @@ type (
 	DynamicConfigSnapshot struct {
 		Version int64
 		Values  *types.DynamicConfigBlob
+		// Timestamp is the time the snapshot was written, it is only set when reading snapshots
+		Timestamp time.Time
+	}
+
+	// FetchDynamicConfigHistoryRequest is a request to read previous dynamic config snapshots
+	FetchDynamicConfigHistoryRequest struct {
+		// MaxVersion is the version of the latest snapshot to return, the latest snapshots are returned if it is 0
+		MaxVersion int64
+		PageSize   int
+	}
+
+	// FetchDynamicConfigHistoryResponse is a response to FetchDynamicConfigHistoryRequest, snapshots are ordered from the latest
+	FetchDynamicConfigHistoryResponse struct {
+		Snapshots []*DynamicConfigSnapshot
 	}
 
 	// Closeable is an interface for any entity that supports a close operation to release resources
@@ -1967,6 +1981,7 @@ type (
 		Closeable
 		FetchDynamicConfig(ctx context.Context, cfgType ConfigType) (*FetchDynamicConfigResponse, error)
 		UpdateDynamicConfig(ctx context.Context, request *UpdateDynamicConfigRequest, cfgType ConfigType) error
+		FetchDynamicConfigHistory(ctx context.Context, request *FetchDynamicConfigHistoryRequest, cfgType ConfigType) (*FetchDynamicConfigHistoryResponse, error)
 		// can add functions for config types other than dynamic config
 	}
 )
@@ -1833,6 +1833,21 @@ func (mr *MockConfigStoreManagerMockRecorder) FetchDynamicConfig(ctx, cfgType an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDynamicConfig", reflect.TypeOf((*MockConfigStoreManager)(nil).FetchDynamicConfig), ctx, cfgType)
 }
 
+// FetchDynamicConfigHistory mocks base method.
+func (m *MockConfigStoreManager) FetchDynamicConfigHistory(ctx context.Context, request *FetchDynamicConfigHistoryRequest, cfgType ConfigType) (*FetchDynamicConfigHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDynamicConfigHistory", ctx, request, cfgType)
+	ret0, _ := ret[0].(*FetchDynamicConfigHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDynamicConfigHistory indicates an expected call of FetchDynamicConfigHistory.
+func (mr *MockConfigStoreManagerMockRecorder) FetchDynamicConfigHistory(ctx, request, cfgType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDynamicConfigHistory", reflect.TypeOf((*MockConfigStoreManager)(nil).FetchDynamicConfigHistory), ctx, request, cfgType)
+}
+
 // UpdateDynamicConfig mocks base method.
 func (m *MockConfigStoreManager) UpdateDynamicConfig(ctx context.Context, request *UpdateDynamicConfigRequest, cfgType ConfigType) error {
 	m.ctrl.T.Helper()
@@ -202,6 +202,8 @@ type (
 		Closeable
 		FetchConfig(ctx context.Context, configType ConfigType) (*InternalConfigStoreEntry, error)
 		UpdateConfig(ctx context.Context, value *InternalConfigStoreEntry) error
+		// FetchConfigHistory returns at most pageSize entries with a version not larger than maxVersion, latest first
+		FetchConfigHistory(ctx context.Context, configType ConfigType, maxVersion int64, pageSize int) ([]*InternalConfigStoreEntry, error)
 	}
 
 	InternalConfigStoreEntry struct {
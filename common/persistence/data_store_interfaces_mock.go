@@ -1048,6 +1048,21 @@ func (mr *MockConfigStoreMockRecorder) FetchConfig(ctx, configType any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchConfig", reflect.TypeOf((*MockConfigStore)(nil).FetchConfig), ctx, configType)
 }
 
+// FetchConfigHistory mocks base method.
+func (m *MockConfigStore) FetchConfigHistory(ctx context.Context, configType ConfigType, maxVersion int64, pageSize int) ([]*InternalConfigStoreEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchConfigHistory", ctx, configType, maxVersion, pageSize)
+	ret0, _ := ret[0].([]*InternalConfigStoreEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchConfigHistory indicates an expected call of FetchConfigHistory.
+func (mr *MockConfigStoreMockRecorder) FetchConfigHistory(ctx, configType, maxVersion, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchConfigHistory", reflect.TypeOf((*MockConfigStore)(nil).FetchConfigHistory), ctx, configType, maxVersion, pageSize)
+}
+
 // UpdateConfig mocks base method.
 func (m *MockConfigStore) UpdateConfig(ctx context.Context, value *InternalConfigStoreEntry) error {
 	m.ctrl.T.Helper()
@@ -48,6 +48,7 @@ import (
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/common/types/mapper/thrift"
+	"github.com/uber/cadence/common/visibility"
 )
 
 type (
@@ -575,22 +576,22 @@ func getESQueryDSLForCount(request *p.CountWorkflowExecutionsRequest) (string, e
 
 func (v *esVisibilityStore) getESQueryDSL(request *p.ListWorkflowExecutionsByQueryRequest, token *es.ElasticVisibilityPageToken) (string, error) {
 	sql := getSQLFromListRequest(request)
-	return v.processedDSLfromSQL(sql, request.DomainUUID, token)
+	return v.processedDSLfromSQL(sql, request.DomainUUID, request.Domain, token)
 }
 
-func (v *esVisibilityStore) processedDSLfromSQL(sql, domainUUID string, token *es.ElasticVisibilityPageToken) (string, error) {
+func (v *esVisibilityStore) processedDSLfromSQL(sql, domainUUID, domain string, token *es.ElasticVisibilityPageToken) (string, error) {
 	dsl, err := getCustomizedDSLFromSQL(sql, domainUUID)
 	if err != nil {
 		return "", err
 	}
 
-	sortField, err := v.processSortField(dsl)
+	sortField, err := v.processSortField(dsl, domain)
 	if err != nil {
 		return "", err
 	}
 
 	if es.ShouldSearchAfter(token) {
-		valueOfSearchAfter, err := v.getValueOfSearchAfterInJSON(token, sortField)
+		valueOfSearchAfter, err := v.getValueOfSearchAfterInJSON(token, sortField, domain)
 		if err != nil {
 			return "", err
 		}
@@ -729,7 +730,7 @@ func addMustQuery(dsl *fastjson.Value, queryString string) {
 	valOfTopQuery.Set("bool", fastjson.MustParse(newValOfBool))
 }
 
-func (v *esVisibilityStore) processSortField(dsl *fastjson.Value) (string, error) {
+func (v *esVisibilityStore) processSortField(dsl *fastjson.Value, domain string) (string, error) {
 	isSorted := dsl.Exists(dslFieldSort)
 	var sortField string
 
@@ -746,7 +747,7 @@ func (v *esVisibilityStore) processSortField(dsl *fastjson.Value) (string, error
 		obj.Visit(func(k []byte, v *fastjson.Value) { // visit is only way to get object key in fastjson
 			sortField = string(k)
 		})
-		if v.getFieldType(sortField) == types.IndexedValueTypeString {
+		if v.getFieldType(sortField, domain) == types.IndexedValueTypeString {
 			return "", errors.New("not able to sort by IndexedValueTypeString field, use IndexedValueTypeKeyword field")
 		}
 		// add RunID as tie-breaker
@@ -756,11 +757,11 @@ func (v *esVisibilityStore) processSortField(dsl *fastjson.Value) (string, error
 	return sortField, nil
 }
 
-func (v *esVisibilityStore) getFieldType(fieldName string) types.IndexedValueType {
+func (v *esVisibilityStore) getFieldType(fieldName, domain string) types.IndexedValueType {
 	if strings.HasPrefix(fieldName, definition.Attr) {
 		fieldName = fieldName[len(definition.Attr)+1:] // remove prefix
 	}
-	validMap := v.config.ValidSearchAttributes()
+	validMap := visibility.EffectiveSearchAttributes(v.config.ValidSearchAttributes(), v.config.DomainSearchAttributes(domain))
 	fieldType, ok := validMap[fieldName]
 	if !ok {
 		v.logger.Error("Unknown fieldName, validation should be done in frontend already", tag.Value(fieldName))
@@ -768,10 +769,10 @@ func (v *esVisibilityStore) getFieldType(fieldName string) types.IndexedValueTyp
 	return common.ConvertIndexedValueTypeToInternalType(fieldType, v.logger)
 }
 
-func (v *esVisibilityStore) getValueOfSearchAfterInJSON(token *es.ElasticVisibilityPageToken, sortField string) (string, error) {
+func (v *esVisibilityStore) getValueOfSearchAfterInJSON(token *es.ElasticVisibilityPageToken, sortField, domain string) (string, error) {
 	var sortVal interface{}
 	var err error
-	switch v.getFieldType(sortField) {
+	switch v.getFieldType(sortField, domain) {
 	case types.IndexedValueTypeInt, types.IndexedValueTypeDatetime, types.IndexedValueTypeBool:
 		sortVal, err = token.SortValue.(json.Number).Int64()
 		if err != nil {
@@ -102,6 +102,7 @@ func (s *ESVisibilitySuite) SetupTest() {
 	config := &service.Config{
 		ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(esIndexMaxResultWindow),
 		ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+		DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 	}
 
 	s.mockProducer = &mocks.KafkaProducer{}
@@ -1031,8 +1032,17 @@ func (s *ESVisibilitySuite) TestProcessAllValuesForKey() {
 }
 
 func (s *ESVisibilitySuite) TestGetFieldType() {
-	s.Equal(types.IndexedValueTypeInt, s.visibilityStore.getFieldType("StartTime"))
-	s.Equal(types.IndexedValueTypeDatetime, s.visibilityStore.getFieldType("Attr.CustomDatetimeField"))
+	s.Equal(types.IndexedValueTypeInt, s.visibilityStore.getFieldType("StartTime", ""))
+	s.Equal(types.IndexedValueTypeDatetime, s.visibilityStore.getFieldType("Attr.CustomDatetimeField", ""))
+
+	s.visibilityStore.config.DomainSearchAttributes = func(domain string) map[string]interface{} {
+		if domain == "team-domain" {
+			return map[string]interface{}{"CustomTeamField": float64(types.IndexedValueTypeKeyword)}
+		}
+		return map[string]interface{}{}
+	}
+	s.Equal(types.IndexedValueTypeKeyword, s.visibilityStore.getFieldType("Attr.CustomTeamField", "team-domain"))
+	s.Equal(types.IndexedValueTypeDatetime, s.visibilityStore.getFieldType("Attr.CustomDatetimeField", "team-domain"))
 }
 
 func (s *ESVisibilitySuite) TestGetValueOfSearchAfterInJSON() {
@@ -1041,7 +1051,7 @@ func (s *ESVisibilitySuite) TestGetValueOfSearchAfterInJSON() {
 	// Int field
 	token := s.getTokenHelper(123)
 	sortField := definition.CustomIntField
-	res, err := v.getValueOfSearchAfterInJSON(token, sortField)
+	res, err := v.getValueOfSearchAfterInJSON(token, sortField, "")
 	s.Nil(err)
 	s.Equal(`[123, "t"]`, res)
 
@@ -1050,7 +1060,7 @@ func (s *ESVisibilitySuite) TestGetValueOfSearchAfterInJSON() {
 	dec.UseNumber()
 	err = dec.Decode(&token)
 	s.Nil(err)
-	res, err = v.getValueOfSearchAfterInJSON(token, sortField)
+	res, err = v.getValueOfSearchAfterInJSON(token, sortField, "")
 	s.Nil(err)
 	s.Equal(`[-9223372036854775808, "t"]`, res)
 
@@ -1059,14 +1069,14 @@ func (s *ESVisibilitySuite) TestGetValueOfSearchAfterInJSON() {
 	dec.UseNumber()
 	err = dec.Decode(&token)
 	s.Nil(err)
-	res, err = v.getValueOfSearchAfterInJSON(token, sortField)
+	res, err = v.getValueOfSearchAfterInJSON(token, sortField, "")
 	s.Nil(err)
 	s.Equal(`[9223372036854775807, "t"]`, res)
 
 	// Double field
 	token = s.getTokenHelper(1.11)
 	sortField = definition.CustomDoubleField
-	res, err = v.getValueOfSearchAfterInJSON(token, sortField)
+	res, err = v.getValueOfSearchAfterInJSON(token, sortField, "")
 	s.Nil(err)
 	s.Equal(`[1.11, "t"]`, res)
 
@@ -1075,19 +1085,19 @@ func (s *ESVisibilitySuite) TestGetValueOfSearchAfterInJSON() {
 	dec.UseNumber()
 	err = dec.Decode(&token)
 	s.Nil(err)
-	res, err = v.getValueOfSearchAfterInJSON(token, sortField)
+	res, err = v.getValueOfSearchAfterInJSON(token, sortField, "")
 	s.Nil(err)
 	s.Equal(`["-Infinity", "t"]`, res)
 
 	// Keyword field
 	token = s.getTokenHelper("keyword")
 	sortField = definition.CustomKeywordField
-	res, err = v.getValueOfSearchAfterInJSON(token, sortField)
+	res, err = v.getValueOfSearchAfterInJSON(token, sortField, "")
 	s.Nil(err)
 	s.Equal(`["keyword", "t"]`, res)
 
 	token = s.getTokenHelper(nil)
-	res, err = v.getValueOfSearchAfterInJSON(token, sortField)
+	res, err = v.getValueOfSearchAfterInJSON(token, sortField, "")
 	s.Nil(err)
 	s.Equal(`[null, "t"]`, res)
 }
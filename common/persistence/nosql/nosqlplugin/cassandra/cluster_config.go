@@ -22,11 +22,13 @@ package cassandra
 
 import (
 	"context"
+	"math"
 	"time"
 
 	"github.com/uber/cadence/common/constants"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/common/types"
 )
 
 func (db *CDB) InsertConfig(ctx context.Context, row *persistence.InternalConfigStoreEntry) error {
@@ -63,3 +65,39 @@ func (db *CDB) SelectLatestConfig(ctx context.Context, rowType int) (*persistenc
 		},
 	}, err
 }
+
+func (db *CDB) SelectConfigs(ctx context.Context, rowType int, maxVersion int64, pageSize int) ([]*persistence.InternalConfigStoreEntry, error) {
+	// version is an int column, a larger bound fails to marshal and selects the same rows as the largest int
+	if maxVersion > math.MaxInt32 {
+		maxVersion = math.MaxInt32
+	}
+	query := db.session.Query(templateSelectConfigs, rowType, maxVersion, pageSize).WithContext(ctx)
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &types.InternalServiceError{
+			Message: "SelectConfigs operation failed. Not able to create query iterator.",
+		}
+	}
+
+	var entries []*persistence.InternalConfigStoreEntry
+	var version int64
+	var timestamp time.Time
+	var data []byte
+	var encoding constants.EncodingType
+	for iter.Scan(&rowType, &version, &timestamp, &data, &encoding) {
+		entries = append(entries, &persistence.InternalConfigStoreEntry{
+			RowType:   rowType,
+			Version:   version,
+			Timestamp: timestamp,
+			Values: &persistence.DataBlob{
+				Data:     data,
+				Encoding: encoding,
+			},
+		})
+		data = nil
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -27,6 +27,10 @@ const (
 		`WHERE row_type = ? ` +
 		`LIMIT 1;`
 
+	templateSelectConfigs = `SELECT row_type, version, timestamp, values, encoding FROM cluster_config ` +
+		`WHERE row_type = ? and version <= ? ` +
+		`LIMIT ?;`
+
 	templateInsertConfig = `INSERT INTO cluster_config (row_type, version, timestamp, values, encoding) ` +
 		`VALUES (?, ?, ?, ?, ?) ` +
 		`IF NOT EXISTS;`
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin/cassandra/gocql"
+)
+
+func TestSelectConfigs(t *testing.T) {
+	timestamp := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		maxVersion  int64
+		iterMockFn  func(iter *gocql.MockIter)
+		wantEntries []*persistence.InternalConfigStoreEntry
+		wantQuery   string
+		wantErr     bool
+	}{
+		{
+			name:       "unbounded max version is clamped to the int column",
+			maxVersion: math.MaxInt64,
+			iterMockFn: func(iter *gocql.MockIter) {
+				iter.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(args ...interface{}) bool {
+					*args[1].(*int64) = 7
+					*args[2].(*time.Time) = timestamp
+					*args[3].(*[]byte) = []byte("values")
+					*args[4].(*constants.EncodingType) = constants.EncodingTypeThriftRW
+					return true
+				}).Times(1)
+				iter.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false).Times(1)
+				iter.EXPECT().Close().Return(nil).Times(1)
+			},
+			wantEntries: []*persistence.InternalConfigStoreEntry{
+				{
+					RowType:   int(persistence.DynamicConfig),
+					Version:   7,
+					Timestamp: timestamp,
+					Values:    &persistence.DataBlob{Data: []byte("values"), Encoding: constants.EncodingTypeThriftRW},
+				},
+			},
+			wantQuery: `SELECT row_type, version, timestamp, values, encoding FROM cluster_config WHERE row_type = 0 and version <= 2147483647 LIMIT 10;`,
+		},
+		{
+			name:       "bounded max version",
+			maxVersion: 5,
+			iterMockFn: func(iter *gocql.MockIter) {
+				iter.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false).Times(1)
+				iter.EXPECT().Close().Return(nil).Times(1)
+			},
+			wantQuery: `SELECT row_type, version, timestamp, values, encoding FROM cluster_config WHERE row_type = 0 and version <= 5 LIMIT 10;`,
+		},
+		{
+			name:       "iterator close failed",
+			maxVersion: 5,
+			iterMockFn: func(iter *gocql.MockIter) {
+				iter.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false).Times(1)
+				iter.EXPECT().Close().Return(errors.New("close failed")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			query := gocql.NewMockQuery(ctrl)
+			iter := gocql.NewMockIter(ctrl)
+			query.EXPECT().WithContext(gomock.Any()).Return(query).Times(1)
+			query.EXPECT().Iter().Return(iter).Times(1)
+			tc.iterMockFn(iter)
+			session := &fakeSession{
+				query: query,
+			}
+			db := NewCassandraDBFromSession(&config.NoSQL{}, session, testlogger.New(t), &persistence.DynamicConfiguration{}, DbWithClient(gocql.NewMockClient(ctrl)))
+
+			entries, err := db.SelectConfigs(context.Background(), int(persistence.DynamicConfig), tc.maxVersion, 10)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantEntries, entries)
+			assert.Equal(t, []string{tc.wantQuery}, session.queries)
+		})
+	}
+}
@@ -34,3 +34,7 @@ func (db *ddb) InsertConfig(ctx context.Context, row *persistence.InternalConfig
 func (db *ddb) SelectLatestConfig(ctx context.Context, rowType int) (*persistence.InternalConfigStoreEntry, error) {
 	return nil, errors.New("TODO")
 }
+
+func (db *ddb) SelectConfigs(ctx context.Context, rowType int, maxVersion int64, pageSize int) ([]*persistence.InternalConfigStoreEntry, error) {
+	return nil, errors.New("TODO")
+}
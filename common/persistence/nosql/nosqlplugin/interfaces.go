@@ -529,6 +529,8 @@ type (
 		InsertConfig(ctx context.Context, row *persistence.InternalConfigStoreEntry) error
 		// SelectLatestConfig returns the config entry of the row_type with the largest(latest) version value
 		SelectLatestConfig(ctx context.Context, rowType int) (*persistence.InternalConfigStoreEntry, error)
+		// SelectConfigs returns at most pageSize config entries of the row_type with a version not larger than maxVersion, latest first
+		SelectConfigs(ctx context.Context, rowType int, maxVersion int64, pageSize int) ([]*persistence.InternalConfigStoreEntry, error)
 	}
 
 	/***
@@ -877,6 +877,21 @@ func (mr *MockDBMockRecorder) SelectAllWorkflowExecutions(ctx, shardID, pageToke
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAllWorkflowExecutions", reflect.TypeOf((*MockDB)(nil).SelectAllWorkflowExecutions), ctx, shardID, pageToken, pageSize)
 }
 
+// SelectConfigs mocks base method.
+func (m *MockDB) SelectConfigs(ctx context.Context, rowType int, maxVersion int64, pageSize int) ([]*persistence.InternalConfigStoreEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectConfigs", ctx, rowType, maxVersion, pageSize)
+	ret0, _ := ret[0].([]*persistence.InternalConfigStoreEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectConfigs indicates an expected call of SelectConfigs.
+func (mr *MockDBMockRecorder) SelectConfigs(ctx, rowType, maxVersion, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectConfigs", reflect.TypeOf((*MockDB)(nil).SelectConfigs), ctx, rowType, maxVersion, pageSize)
+}
+
 // SelectCurrentWorkflow mocks base method.
 func (m *MockDB) SelectCurrentWorkflow(ctx context.Context, shardID int, domainID, workflowID string) (*CurrentWorkflowRow, error) {
 	m.ctrl.T.Helper()
@@ -2040,6 +2055,21 @@ func (mr *MocktableCRUDMockRecorder) SelectAllWorkflowExecutions(ctx, shardID, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAllWorkflowExecutions", reflect.TypeOf((*MocktableCRUD)(nil).SelectAllWorkflowExecutions), ctx, shardID, pageToken, pageSize)
 }
 
+// SelectConfigs mocks base method.
+func (m *MocktableCRUD) SelectConfigs(ctx context.Context, rowType int, maxVersion int64, pageSize int) ([]*persistence.InternalConfigStoreEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectConfigs", ctx, rowType, maxVersion, pageSize)
+	ret0, _ := ret[0].([]*persistence.InternalConfigStoreEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectConfigs indicates an expected call of SelectConfigs.
+func (mr *MocktableCRUDMockRecorder) SelectConfigs(ctx, rowType, maxVersion, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectConfigs", reflect.TypeOf((*MocktableCRUD)(nil).SelectConfigs), ctx, rowType, maxVersion, pageSize)
+}
+
 // SelectCurrentWorkflow mocks base method.
 func (m *MocktableCRUD) SelectCurrentWorkflow(ctx context.Context, shardID int, domainID, workflowID string) (*CurrentWorkflowRow, error) {
 	m.ctrl.T.Helper()
@@ -3828,6 +3858,21 @@ func (mr *MockConfigStoreCRUDMockRecorder) InsertConfig(ctx, row any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertConfig", reflect.TypeOf((*MockConfigStoreCRUD)(nil).InsertConfig), ctx, row)
 }
 
+// SelectConfigs mocks base method.
+func (m *MockConfigStoreCRUD) SelectConfigs(ctx context.Context, rowType int, maxVersion int64, pageSize int) ([]*persistence.InternalConfigStoreEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectConfigs", ctx, rowType, maxVersion, pageSize)
+	ret0, _ := ret[0].([]*persistence.InternalConfigStoreEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectConfigs indicates an expected call of SelectConfigs.
+func (mr *MockConfigStoreCRUDMockRecorder) SelectConfigs(ctx, rowType, maxVersion, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectConfigs", reflect.TypeOf((*MockConfigStoreCRUD)(nil).SelectConfigs), ctx, rowType, maxVersion, pageSize)
+}
+
 // SelectLatestConfig mocks base method.
 func (m *MockConfigStoreCRUD) SelectLatestConfig(ctx context.Context, rowType int) (*persistence.InternalConfigStoreEntry, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +116,7 @@ func TestMetricClientRecordWorkflowExecutionStarted(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -203,6 +204,7 @@ func TestMetricClientRecordWorkflowExecutionClosed(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -280,6 +282,7 @@ func TestMetricClientRecordWorkflowExecutionUninitialized(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -357,6 +360,7 @@ func TestMetricClientUpsertWorkflowExecution(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -432,6 +436,7 @@ func TestMetricClientListOpenWorkflowExecutions(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -507,6 +512,7 @@ func TestMetricClientListClosedWorkflowExecutions(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -583,6 +589,7 @@ func TestMetricClientListOpenWorkflowExecutionsByType(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -659,6 +666,7 @@ func TestMetricClientListClosedWorkflowExecutionsByType(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -734,6 +742,7 @@ func TestMetricClientListOpenWorkflowExecutionsByWorkflowID(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -809,6 +818,7 @@ func TestMetricClientListClosedWorkflowExecutionsByWorkflowID(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -888,6 +898,7 @@ func TestMetricClientListClosedWorkflowExecutionsByStatus(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -965,6 +976,7 @@ func TestMetricClientGetClosedWorkflowExecution(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -1038,6 +1050,7 @@ func TestMetricClientListWorkflowExecutions(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -1107,6 +1120,7 @@ func TestMetricClientScanWorkflowExecutions(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -1176,6 +1190,7 @@ func TestMetricClientCountWorkflowExecutions(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -1253,6 +1268,7 @@ func TestMetricClientDeleteWorkflowExecution(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -1326,6 +1342,7 @@ func TestMetricClientDeleteUninitializedWorkflowExecution(t *testing.T) {
 			logger := log.NewNoop()
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 			}, mockProducer, testlogger.New(t))
 			visibilityStore := mgr.(*pinotVisibilityStore)
@@ -1359,6 +1376,7 @@ func TestMetricClientClose(t *testing.T) {
 	logger := log.NewNoop()
 	mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 		ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+		DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 		ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 	}, mockProducer, testlogger.New(t))
 	visibilityStore := mgr.(*pinotVisibilityStore)
@@ -1385,6 +1403,7 @@ func TestMetricClientGetName(t *testing.T) {
 	logger := log.NewNoop()
 	mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 		ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+		DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 		ESIndexMaxResultWindow: dynamicproperties.GetIntPropertyFn(3),
 	}, mockProducer, testlogger.New(t))
 	visibilityStore := mgr.(*pinotVisibilityStore)
@@ -39,6 +39,7 @@ import (
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/common/types/mapper/thrift"
+	"github.com/uber/cadence/common/visibility"
 )
 
 const (
@@ -94,7 +95,7 @@ func NewPinotVisibilityStore(
 		producer:            producer,
 		logger:              logger.WithTags(tag.ComponentPinotVisibilityManager),
 		config:              config,
-		pinotQueryValidator: pnt.NewPinotQueryValidator(config.ValidSearchAttributes, config.PinotOptimizedQueryColumns),
+		pinotQueryValidator: pnt.NewPinotQueryValidator(config.ValidSearchAttributes, config.DomainSearchAttributes, config.SearchAttributeAliases, config.PinotOptimizedQueryColumns),
 	}
 }
 
@@ -127,7 +128,7 @@ func (v *pinotVisibilityStore) RecordWorkflowExecutionStarted(
 		0,  // will be updated when workflow execution updates
 		request.UpdateTimestamp.UnixMilli(),
 		int64(request.ShardID),
-		request.SearchAttributes,
+		v.indexedSearchAttributes(request.SearchAttributes),
 		false,
 		request.Memo,
 	)
@@ -157,7 +158,7 @@ func (v *pinotVisibilityStore) RecordWorkflowExecutionClosed(ctx context.Context
 		request.HistoryLength,
 		request.UpdateTimestamp.UnixMilli(),
 		int64(request.ShardID),
-		request.SearchAttributes,
+		v.indexedSearchAttributes(request.SearchAttributes),
 		false,
 		request.Memo,
 	)
@@ -216,7 +217,7 @@ func (v *pinotVisibilityStore) UpsertWorkflowExecution(ctx context.Context, requ
 		0,  // will not be used
 		request.UpdateTimestamp.UnixMilli(),
 		request.ShardID,
-		request.SearchAttributes,
+		v.indexedSearchAttributes(request.SearchAttributes),
 		false,
 		request.Memo,
 	)
@@ -642,6 +643,21 @@ func createVisibilityMessage(
 
 }
 
+// indexedSearchAttributes returns the search attributes keyed by the keys their values are indexed under,
+// values of a search attribute whose type changed go to the search attribute it is aliased to
+func (v *pinotVisibilityStore) indexedSearchAttributes(searchAttributes map[string][]byte) map[string][]byte {
+	if v.config.SearchAttributeAliases == nil || len(searchAttributes) == 0 {
+		return searchAttributes
+	}
+	validAttr := v.config.ValidSearchAttributes()
+	aliases := v.config.SearchAttributeAliases()
+	indexed := make(map[string][]byte, len(searchAttributes))
+	for key, value := range searchAttributes {
+		indexed[visibility.IndexedSearchAttributeKey(validAttr, aliases, key, value)] = value
+	}
+	return indexed
+}
+
 // check if value is time.Time type
 // if it is, convert it to unixMilli
 // if it isn't time, return the original value
@@ -773,7 +789,7 @@ func (v *pinotVisibilityStore) getCountWorkflowExecutionsQuery(tableName string,
 	requestQuery = filterPrefix(requestQuery)
 
 	comparExpr, _ := parseOrderBy(requestQuery)
-	comparExpr, err := v.pinotQueryValidator.ValidateQuery(comparExpr)
+	comparExpr, err := v.pinotQueryValidator.ValidateQuery(comparExpr, request.Domain)
 	if err != nil {
 		return "", &types.BadRequestError{Message: fmt.Sprintf("pinot query validator error: %s, query: %s", err.Error(), request.Query)}
 	}
@@ -817,7 +833,7 @@ func (v *pinotVisibilityStore) getListWorkflowExecutionsByQueryQuery(tableName s
 	}
 
 	comparExpr, orderBy := parseOrderBy(requestQuery)
-	comparExpr, err = v.pinotQueryValidator.ValidateQuery(comparExpr)
+	comparExpr, err = v.pinotQueryValidator.ValidateQuery(comparExpr, request.Domain)
 	if err != nil {
 		return "", &types.BadRequestError{Message: fmt.Sprintf("pinot query validator error: %s, query: %s", err.Error(), request.Query)}
 	}
@@ -132,6 +132,7 @@ func TestRecordWorkflowExecutionStarted(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -197,6 +198,7 @@ func TestRecordWorkflowExecutionClosed(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -243,6 +245,7 @@ func TestRecordWorkflowExecutionUninitialized(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -295,6 +298,21 @@ func TestUpsertWorkflowExecution(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		"Case3: search attribute whose type changed": {
+			request: &p.InternalUpsertWorkflowExecutionRequest{
+				WorkflowID:       "wid",
+				SearchAttributes: map[string][]byte{"CustomKeywordField": []byte(`1`)},
+			},
+			producerMockAffordance: func(mockProducer *mocks.KafkaProducer) {
+				mockProducer.On("Publish", mock.Anything, mock.MatchedBy(func(input *indexer.PinotMessage) bool {
+					var payload map[string]interface{}
+					assert.NoError(t, json.Unmarshal(input.GetPayload(), &payload))
+					assert.Equal(t, map[string]interface{}{"CustomIntField": float64(1)}, payload[Attr])
+					return true
+				})).Return(nil).Once()
+			},
+			expectedError: nil,
+		},
 	}
 
 	for name, test := range tests {
@@ -304,8 +322,10 @@ func TestUpsertWorkflowExecution(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
+				SearchAttributeAliases:     dynamicproperties.GetMapPropertyFn(map[string]interface{}{"CustomKeywordField": "CustomIntField"}),
 			}, mockProducer, log.NewNoop())
 			visibilityStore := mgr.(*pinotVisibilityStore)
 
@@ -350,6 +370,7 @@ func TestDeleteWorkflowExecution(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -397,6 +418,7 @@ func TestDeleteUninitializedWorkflowExecution(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -455,6 +477,7 @@ func TestListOpenWorkflowExecutions(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -515,6 +538,7 @@ func TestListClosedWorkflowExecutions(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -576,6 +600,7 @@ func TestListOpenWorkflowExecutionsByType(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -636,6 +661,7 @@ func TestListClosedWorkflowExecutionsByType(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -695,6 +721,7 @@ func TestListOpenWorkflowExecutionsByWorkflowID(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -754,6 +781,7 @@ func TestListClosedWorkflowExecutionsByWorkflowID(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -813,6 +841,7 @@ func TestListClosedWorkflowExecutionsByStatus(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -880,6 +909,7 @@ func TestGetClosedWorkflowExecution(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -935,6 +965,7 @@ func TestListWorkflowExecutions(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -991,6 +1022,7 @@ func TestScanWorkflowExecutions(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -1054,6 +1086,7 @@ func TestCountWorkflowExecutions(t *testing.T) {
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -1078,6 +1111,7 @@ func TestGetName(t *testing.T) {
 	mockProducer := &mocks.KafkaProducer{}
 	mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 		ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+		DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 		ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 		PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 	}, mockProducer, log.NewNoop())
@@ -1089,7 +1123,8 @@ func TestNewPinotVisibilityStore(t *testing.T) {
 	mockPinotClient := &pnt.MockGenericClient{}
 	assert.NotPanics(t, func() {
 		NewPinotVisibilityStore(mockPinotClient, &service.Config{
-			ValidSearchAttributes: dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+			ValidSearchAttributes:  dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+			DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 		}, nil, log.NewNoop())
 	})
 }
@@ -1151,6 +1186,7 @@ AND WorkflowID = 'wfid'
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -1412,6 +1448,7 @@ LIMIT 0, 0
 			mockProducer := &mocks.KafkaProducer{}
 			mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 				ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+				DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 				ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 				PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			}, mockProducer, log.NewNoop())
@@ -1961,6 +1998,7 @@ func TestClose(t *testing.T) {
 	mockProducer := &mocks.KafkaProducer{}
 	mgr := NewPinotVisibilityStore(mockPinotClient, &service.Config{
 		ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+		DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
 		ESIndexMaxResultWindow:     dynamicproperties.GetIntPropertyFn(3),
 		PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 	}, mockProducer, log.NewNoop())
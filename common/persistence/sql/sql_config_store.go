@@ -64,6 +64,14 @@ func (m *sqlConfigStore) FetchConfig(ctx context.Context, configType persistence
 	return entry, nil
 }
 
+func (m *sqlConfigStore) FetchConfigHistory(ctx context.Context, configType persistence.ConfigType, maxVersion int64, pageSize int) ([]*persistence.InternalConfigStoreEntry, error) {
+	entries, err := m.db.SelectConfigs(ctx, int(configType), maxVersion, pageSize)
+	if err != nil {
+		return nil, convertCommonErrors(m.db, "FetchConfigHistory", "", err)
+	}
+	return entries, nil
+}
+
 func (m *sqlConfigStore) UpdateConfig(ctx context.Context, value *persistence.InternalConfigStoreEntry) error {
 	err := m.db.InsertConfig(ctx, value)
 	if err != nil {
@@ -1191,6 +1191,21 @@ func (mr *MocktableCRUDMockRecorder) ReplaceIntoVisibility(ctx, row any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceIntoVisibility", reflect.TypeOf((*MocktableCRUD)(nil).ReplaceIntoVisibility), ctx, row)
 }
 
+// SelectConfigs mocks base method.
+func (m *MocktableCRUD) SelectConfigs(ctx context.Context, rowType int, maxVersion int64, pageSize int) ([]*persistence.InternalConfigStoreEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectConfigs", ctx, rowType, maxVersion, pageSize)
+	ret0, _ := ret[0].([]*persistence.InternalConfigStoreEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectConfigs indicates an expected call of SelectConfigs.
+func (mr *MocktableCRUDMockRecorder) SelectConfigs(ctx, rowType, maxVersion, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectConfigs", reflect.TypeOf((*MocktableCRUD)(nil).SelectConfigs), ctx, rowType, maxVersion, pageSize)
+}
+
 // SelectFromActiveClusterSelectionPolicy mocks base method.
 func (m *MocktableCRUD) SelectFromActiveClusterSelectionPolicy(ctx context.Context, filter *ActiveClusterSelectionPolicyFilter) (*ActiveClusterSelectionPolicyRow, error) {
 	m.ctrl.T.Helper()
@@ -3129,6 +3144,21 @@ func (mr *MockTxMockRecorder) Rollback() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockTx)(nil).Rollback))
 }
 
+// SelectConfigs mocks base method.
+func (m *MockTx) SelectConfigs(ctx context.Context, rowType int, maxVersion int64, pageSize int) ([]*persistence.InternalConfigStoreEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectConfigs", ctx, rowType, maxVersion, pageSize)
+	ret0, _ := ret[0].([]*persistence.InternalConfigStoreEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectConfigs indicates an expected call of SelectConfigs.
+func (mr *MockTxMockRecorder) SelectConfigs(ctx, rowType, maxVersion, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectConfigs", reflect.TypeOf((*MockTx)(nil).SelectConfigs), ctx, rowType, maxVersion, pageSize)
+}
+
 // SelectFromActiveClusterSelectionPolicy mocks base method.
 func (m *MockTx) SelectFromActiveClusterSelectionPolicy(ctx context.Context, filter *ActiveClusterSelectionPolicyFilter) (*ActiveClusterSelectionPolicyRow, error) {
 	m.ctrl.T.Helper()
@@ -4925,6 +4955,21 @@ func (mr *MockDBMockRecorder) ReplaceIntoVisibility(ctx, row any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceIntoVisibility", reflect.TypeOf((*MockDB)(nil).ReplaceIntoVisibility), ctx, row)
 }
 
+// SelectConfigs mocks base method.
+func (m *MockDB) SelectConfigs(ctx context.Context, rowType int, maxVersion int64, pageSize int) ([]*persistence.InternalConfigStoreEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectConfigs", ctx, rowType, maxVersion, pageSize)
+	ret0, _ := ret[0].([]*persistence.InternalConfigStoreEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectConfigs indicates an expected call of SelectConfigs.
+func (mr *MockDBMockRecorder) SelectConfigs(ctx, rowType, maxVersion, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectConfigs", reflect.TypeOf((*MockDB)(nil).SelectConfigs), ctx, rowType, maxVersion, pageSize)
+}
+
 // SelectFromActiveClusterSelectionPolicy mocks base method.
 func (m *MockDB) SelectFromActiveClusterSelectionPolicy(ctx context.Context, filter *ActiveClusterSelectionPolicyFilter) (*ActiveClusterSelectionPolicyRow, error) {
 	m.ctrl.T.Helper()
@@ -52,3 +52,24 @@ func (mdb *DB) SelectLatestConfig(ctx context.Context, rowType int) (*persistenc
 		},
 	}, nil
 }
+
+func (mdb *DB) SelectConfigs(ctx context.Context, rowType int, maxVersion int64, pageSize int) ([]*persistence.InternalConfigStoreEntry, error) {
+	var rows []sqlplugin.ClusterConfigRow
+	err := mdb.driver.SelectContext(ctx, sqlplugin.DbDefaultShard, &rows, _selectConfigsQuery, rowType, -1*maxVersion, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*persistence.InternalConfigStoreEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, &persistence.InternalConfigStoreEntry{
+			RowType:   row.RowType,
+			Version:   -1 * row.Version,
+			Timestamp: mdb.converter.FromDateTime(row.Timestamp),
+			Values: &persistence.DataBlob{
+				Data:     row.Data,
+				Encoding: constants.EncodingType(row.DataEncoding),
+			},
+		})
+	}
+	return entries, nil
+}
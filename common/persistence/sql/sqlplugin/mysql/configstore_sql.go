@@ -25,5 +25,8 @@ package mysql
 const (
 	_selectLatestConfigQuery = "SELECT row_type, version, timestamp, data, data_encoding FROM cluster_config WHERE row_type = ? ORDER BY version LIMIT 1;"
 
+	// versions are stored negated, so the latest versions come first in ascending order
+	_selectConfigsQuery = "SELECT row_type, version, timestamp, data, data_encoding FROM cluster_config WHERE row_type = ? AND version >= ? ORDER BY version LIMIT ?;"
+
 	_insertConfigQuery = "INSERT INTO cluster_config (row_type, version, timestamp, data, data_encoding) VALUES(?, ?, ?, ?, ?)"
 )
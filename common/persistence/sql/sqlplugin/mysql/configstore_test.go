@@ -155,3 +155,30 @@ func TestSelectLatestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectConfigs(t *testing.T) {
+	now := time.Now()
+	ctrl := gomock.NewController(t)
+	mockDriver := sqldriver.NewMockDriver(ctrl)
+	mdb := &DB{driver: mockDriver, converter: &converter{}}
+
+	mockDriver.EXPECT().SelectContext(gomock.Any(), sqlplugin.DbDefaultShard, gomock.Any(), _selectConfigsQuery, 1, int64(-5), 2).DoAndReturn(
+		func(ctx context.Context, shardID int, rows *[]sqlplugin.ClusterConfigRow, query string, args ...interface{}) error {
+			*rows = []sqlplugin.ClusterConfigRow{
+				{RowType: 1, Version: -5, Timestamp: now, Data: []byte("v5"), DataEncoding: "json"},
+				{RowType: 1, Version: -4, Timestamp: now, Data: []byte("v4"), DataEncoding: "json"},
+			}
+			return nil
+		},
+	)
+	entries, err := mdb.SelectConfigs(context.Background(), 1, 5, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []*persistence.InternalConfigStoreEntry{
+		{RowType: 1, Version: 5, Timestamp: now, Values: &persistence.DataBlob{Data: []byte("v5"), Encoding: constants.EncodingType("json")}},
+		{RowType: 1, Version: 4, Timestamp: now, Values: &persistence.DataBlob{Data: []byte("v4"), Encoding: constants.EncodingType("json")}},
+	}, entries)
+
+	mockDriver.EXPECT().SelectContext(gomock.Any(), sqlplugin.DbDefaultShard, gomock.Any(), _selectConfigsQuery, 1, int64(-5), 2).Return(errors.New("some error"))
+	_, err = mdb.SelectConfigs(context.Background(), 1, 5, 2)
+	assert.Error(t, err)
+}
@@ -33,6 +33,9 @@ import (
 const (
 	_selectLatestConfigQuery = "SELECT row_type, version, timestamp, data, data_encoding FROM cluster_config WHERE row_type = $1 ORDER BY version LIMIT 1;"
 
+	// versions are stored negated, so the latest versions come first in ascending order
+	_selectConfigsQuery = "SELECT row_type, version, timestamp, data, data_encoding FROM cluster_config WHERE row_type = $1 AND version >= $2 ORDER BY version LIMIT $3;"
+
 	_insertConfigQuery = "INSERT INTO cluster_config (row_type, version, timestamp, data, data_encoding) VALUES($1, $2, $3, $4, $5)"
 )
 
@@ -58,3 +61,24 @@ func (pdb *db) SelectLatestConfig(ctx context.Context, rowType int) (*persistenc
 		},
 	}, nil
 }
+
+func (pdb *db) SelectConfigs(ctx context.Context, rowType int, maxVersion int64, pageSize int) ([]*persistence.InternalConfigStoreEntry, error) {
+	var rows []sqlplugin.ClusterConfigRow
+	err := pdb.driver.SelectContext(ctx, sqlplugin.DbDefaultShard, &rows, _selectConfigsQuery, rowType, -1*maxVersion, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*persistence.InternalConfigStoreEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, &persistence.InternalConfigStoreEntry{
+			RowType:   row.RowType,
+			Version:   -1 * row.Version,
+			Timestamp: pdb.converter.FromPostgresDateTime(row.Timestamp),
+			Values: &persistence.DataBlob{
+				Data:     row.Data,
+				Encoding: constants.EncodingType(row.DataEncoding),
+			},
+		})
+	}
+	return entries, nil
+}
@@ -51,6 +51,7 @@ const (
 )
 
 // ResponseComparatorContextKey is for Pinot/ES response comparator. This struct will be passed into ctx as a key.
+// Reads with a store name (e.g. "es" or "pinot") under ContextKey are served by that store only.
 type ResponseComparatorContextKey string
 
 type OperationType string
@@ -434,6 +435,15 @@ func (v *visibilityHybridManager) CountWorkflowExecutions(
 }
 
 func (v *visibilityHybridManager) chooseVisibilityManagerForRead(ctx context.Context, domain string) (VisibilityManager, VisibilityManager) {
+	// a store named under ContextKey overrides the configured read stores, e.g. to compare the records of each store
+	if store, ok := ctx.Value(ContextKey).(string); ok {
+		if mgr := v.visibilityMgrs[store]; mgr != nil {
+			return mgr, nil
+		}
+		v.logger.Warn("visibility store override is not available, using the configured read store",
+			tag.WorkflowDomainName(domain), tag.Value(store))
+	}
+
 	var visibilityMgr, shadowMgr VisibilityManager
 	stores := strings.Split(v.readVisibilityStoreName(domain), ",")
 	for i := range stores {
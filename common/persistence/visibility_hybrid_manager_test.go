@@ -2217,3 +2217,46 @@ func TestVisibilityHybridCountWorkflowExecutions(t *testing.T) {
 		})
 	}
 }
+
+func TestVisibilityHybridReadStoreOverride(t *testing.T) {
+	request := &ListWorkflowExecutionsByQueryRequest{
+		Domain: "test-domain",
+	}
+	tests := map[string]struct {
+		store         string
+		expectedStore string
+	}{
+		"override reads from the named store without shadowing": {
+			store:         esStoreName,
+			expectedStore: esStoreName,
+		},
+		"unavailable override falls back to the configured read store": {
+			store:         "os",
+			expectedStore: pinotStoreName,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			visibilityMgrs := map[string]VisibilityManager{
+				dbVisStoreName: NewMockVisibilityManager(ctrl),
+				esStoreName:    NewMockVisibilityManager(ctrl),
+				pinotStoreName: NewMockVisibilityManager(ctrl),
+			}
+			visibilityMgrs[test.expectedStore].(*MockVisibilityManager).EXPECT().
+				ListWorkflowExecutions(gomock.Any(), request).Return(&ListWorkflowExecutionsResponse{}, nil).Times(1)
+			visibilityManager := NewVisibilityHybridManager(
+				visibilityMgrs,
+				dynamicproperties.GetStringPropertyFnFilteredByDomain(pinotStoreName),
+				nil,
+				nil,
+				testStoreName,
+				log.NewNoop(),
+			)
+
+			ctx := context.WithValue(context.Background(), ContextKey, test.store)
+			_, err := visibilityManager.ListWorkflowExecutions(ctx, request)
+			assert.NoError(t, err)
+		})
+	}
+}
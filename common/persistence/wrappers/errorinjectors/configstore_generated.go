@@ -55,6 +55,21 @@ func (c *injectorConfigStoreManager) FetchDynamicConfig(ctx context.Context, cfg
 	return
 }
 
+func (c *injectorConfigStoreManager) FetchDynamicConfigHistory(ctx context.Context, request *persistence.FetchDynamicConfigHistoryRequest, cfgType persistence.ConfigType) (fp1 *persistence.FetchDynamicConfigHistoryResponse, err error) {
+	fakeErr := generateFakeError(c.errorRate, c.starttime)
+	var forwardCall bool
+	if forwardCall = shouldForwardCallToPersistence(fakeErr); forwardCall {
+		fp1, err = c.wrapped.FetchDynamicConfigHistory(ctx, request, cfgType)
+	}
+
+	if fakeErr != nil {
+		logErr(c.logger, "ConfigStoreManager.FetchDynamicConfigHistory", fakeErr, forwardCall, err)
+		err = fakeErr
+		return
+	}
+	return
+}
+
 func (c *injectorConfigStoreManager) UpdateDynamicConfig(ctx context.Context, request *persistence.UpdateDynamicConfigRequest, cfgType persistence.ConfigType) (err error) {
 	fakeErr := generateFakeError(c.errorRate, c.starttime)
 	var forwardCall bool
@@ -142,6 +142,8 @@ func configManagerTags(op string) *tag.Tag {
 		return &tag.StoreOperationFetchDynamicConfig
 	case "ConfigStoreManager.UpdateDynamicConfig":
 		return &tag.StoreOperationUpdateDynamicConfig
+	case "ConfigStoreManager.FetchDynamicConfigHistory":
+		return &tag.StoreOperationFetchDynamicConfigHistory
 	}
 	return nil
 }
@@ -53,6 +53,17 @@ func (c *meteredConfigStoreManager) FetchDynamicConfig(ctx context.Context, cfgT
 	return
 }
 
+func (c *meteredConfigStoreManager) FetchDynamicConfigHistory(ctx context.Context, request *persistence.FetchDynamicConfigHistoryRequest, cfgType persistence.ConfigType) (fp1 *persistence.FetchDynamicConfigHistoryResponse, err error) {
+	op := func() error {
+		fp1, err = c.wrapped.FetchDynamicConfigHistory(ctx, request, cfgType)
+		c.emptyMetric("ConfigStoreManager.FetchDynamicConfigHistory", request, fp1, err)
+		return err
+	}
+
+	err = c.call(metrics.PersistenceFetchDynamicConfigHistoryScope, op, getCustomMetricTags(request)...)
+	return
+}
+
 func (c *meteredConfigStoreManager) UpdateDynamicConfig(ctx context.Context, request *persistence.UpdateDynamicConfigRequest, cfgType persistence.ConfigType) (err error) {
 	op := func() error {
 		err = c.wrapped.UpdateDynamicConfig(ctx, request, cfgType)
@@ -230,6 +230,7 @@ func prepareMockForTest(t *testing.T, input interface{}, expectedErr error) {
 	case *persistence.MockConfigStoreManager:
 		mocked.EXPECT().UpdateDynamicConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedErr).Times(1)
 		mocked.EXPECT().FetchDynamicConfig(gomock.Any(), gomock.Any()).Return(&persistence.FetchDynamicConfigResponse{}, expectedErr).Times(1)
+		mocked.EXPECT().FetchDynamicConfigHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(&persistence.FetchDynamicConfigHistoryResponse{}, expectedErr).Times(1)
 	case *persistence.MockDomainManager:
 		mocked.EXPECT().CreateDomain(gomock.Any(), gomock.Any()).Return(&persistence.CreateDomainResponse{}, expectedErr).Times(1)
 		mocked.EXPECT().GetDomain(gomock.Any(), gomock.Any()).Return(&persistence.GetDomainResponse{}, expectedErr).Times(1)
@@ -44,6 +44,14 @@ func (c *ratelimitedConfigStoreManager) FetchDynamicConfig(ctx context.Context,
 	return c.wrapped.FetchDynamicConfig(ctx, cfgType)
 }
 
+func (c *ratelimitedConfigStoreManager) FetchDynamicConfigHistory(ctx context.Context, request *persistence.FetchDynamicConfigHistoryRequest, cfgType persistence.ConfigType) (fp1 *persistence.FetchDynamicConfigHistoryResponse, err error) {
+	if !c.callerBypass.AllowLimiter(ctx, c.rateLimiter) {
+		err = ErrPersistenceLimitExceeded
+		return
+	}
+	return c.wrapped.FetchDynamicConfigHistory(ctx, request, cfgType)
+}
+
 func (c *ratelimitedConfigStoreManager) UpdateDynamicConfig(ctx context.Context, request *persistence.UpdateDynamicConfigRequest, cfgType persistence.ConfigType) (err error) {
 	if !c.callerBypass.AllowLimiter(ctx, c.rateLimiter) {
 		err = ErrPersistenceLimitExceeded
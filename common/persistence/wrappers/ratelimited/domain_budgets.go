@@ -0,0 +1,227 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ratelimited
+
+import (
+	"context"
+
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	dynamicquotas "github.com/uber/cadence/common/dynamicconfig/quotas"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/quotas"
+)
+
+type (
+	// DomainBudgets holds one persistence rate limiter per domain, so a single
+	// domain can't consume the whole budget of the component owning it (e.g. a history shard).
+	//
+	// Requests that exceed their domain's budget queue on that domain's limiter for up to
+	// maxWait before being rejected. Since every domain has its own queue, a burst from
+	// one domain only delays that domain's requests.
+	//
+	// The budget of the component itself is shared fairly: once it is exhausted, domains with
+	// waiting requests take turns for its tokens, whatever the number of requests each one has queued.
+	DomainBudgets struct {
+		limiters     *quotas.Collection[string]
+		maxQPS       dynamicproperties.IntPropertyFnWithDomainFilter
+		totalMaxQPS  dynamicproperties.IntPropertyFn
+		total        *fairQueue
+		maxWait      dynamicproperties.DurationPropertyFnWithDomainFilter
+		callerBypass quotas.CallerBypass
+	}
+
+	domainExecutionManager struct {
+		persistence.ExecutionManager
+		budgets *DomainBudgets
+	}
+
+	domainHistoryManager struct {
+		persistence.HistoryManager
+		budgets *DomainBudgets
+	}
+)
+
+// NewDomainBudgets creates per-domain persistence budgets sharing a total budget fairly.
+// A domain with a max QPS of zero or less is only limited by the total budget,
+// which is disabled when totalMaxQPS is zero or less.
+func NewDomainBudgets(
+	maxQPS dynamicproperties.IntPropertyFnWithDomainFilter,
+	totalMaxQPS dynamicproperties.IntPropertyFn,
+	maxWait dynamicproperties.DurationPropertyFnWithDomainFilter,
+	callerBypass quotas.CallerBypass,
+) *DomainBudgets {
+	return &DomainBudgets{
+		limiters:     quotas.NewCollection(dynamicquotas.NewSimpleDynamicRateLimiterFactory(maxQPS)),
+		maxQPS:       maxQPS,
+		totalMaxQPS:  totalMaxQPS,
+		total:        newFairQueue(quotas.NewDynamicRateLimiter(totalMaxQPS.AsFloat64())),
+		maxWait:      maxWait,
+		callerBypass: callerBypass,
+	}
+}
+
+// Allow blocks until the domain's budget and then the total budget admit the request.
+// It returns ErrPersistenceDomainLimitExceeded or ErrPersistenceTotalLimitExceeded if that does not
+// happen within the domain's max wait, and never blocks when the max wait is zero or less.
+// Errors from the parent context are returned as-is. A nil DomainBudgets admits every request.
+func (b *DomainBudgets) Allow(ctx context.Context, domain string) error {
+	if b == nil || domain == "" {
+		return nil
+	}
+	domainLimited := b.maxQPS(domain) > 0
+	totalLimited := b.totalMaxQPS() > 0
+	if !domainLimited && !totalLimited {
+		return nil
+	}
+
+	maxWait := b.maxWait(domain)
+	if maxWait <= 0 {
+		if domainLimited && !b.callerBypass.AllowLimiter(ctx, b.limiters.For(domain)) {
+			return ErrPersistenceDomainLimitExceeded
+		}
+		if totalLimited && !b.total.Allow() && !b.callerBypass.ShouldBypass(ctx) {
+			return ErrPersistenceTotalLimitExceeded
+		}
+		return nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, maxWait)
+	defer cancel()
+	if domainLimited {
+		if err := b.limiters.For(domain).Wait(waitCtx); err != nil {
+			return b.waitError(ctx, ErrPersistenceDomainLimitExceeded)
+		}
+	}
+	if totalLimited {
+		if err := b.total.Wait(waitCtx, domain); err != nil {
+			return b.waitError(ctx, ErrPersistenceTotalLimitExceeded)
+		}
+	}
+	return nil
+}
+
+func (b *DomainBudgets) waitError(ctx context.Context, limitErr error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if b.callerBypass.ShouldBypass(ctx) {
+		return nil
+	}
+	return limitErr
+}
+
+// NewDomainExecutionManager returns an ExecutionManager which charges workflow execution
+// requests to the budget of the domain they belong to. Requests which are not tied to a
+// single domain (task queues, DLQs, scans) are passed through.
+// Creating, updating and conflict resolving executions are not charged here: the shard context
+// issues them under its lock, so it charges them itself before taking the lock.
+func NewDomainExecutionManager(wrapped persistence.ExecutionManager, budgets *DomainBudgets) persistence.ExecutionManager {
+	return &domainExecutionManager{
+		ExecutionManager: wrapped,
+		budgets:          budgets,
+	}
+}
+
+func (c *domainExecutionManager) GetWorkflowExecution(ctx context.Context, request *persistence.GetWorkflowExecutionRequest) (*persistence.GetWorkflowExecutionResponse, error) {
+	if err := c.budgets.Allow(ctx, request.DomainName); err != nil {
+		return nil, err
+	}
+	return c.ExecutionManager.GetWorkflowExecution(ctx, request)
+}
+
+func (c *domainExecutionManager) DeleteWorkflowExecution(ctx context.Context, request *persistence.DeleteWorkflowExecutionRequest) error {
+	if err := c.budgets.Allow(ctx, request.DomainName); err != nil {
+		return err
+	}
+	return c.ExecutionManager.DeleteWorkflowExecution(ctx, request)
+}
+
+func (c *domainExecutionManager) DeleteCurrentWorkflowExecution(ctx context.Context, request *persistence.DeleteCurrentWorkflowExecutionRequest) error {
+	if err := c.budgets.Allow(ctx, request.DomainName); err != nil {
+		return err
+	}
+	return c.ExecutionManager.DeleteCurrentWorkflowExecution(ctx, request)
+}
+
+func (c *domainExecutionManager) GetCurrentExecution(ctx context.Context, request *persistence.GetCurrentExecutionRequest) (*persistence.GetCurrentExecutionResponse, error) {
+	if err := c.budgets.Allow(ctx, request.DomainName); err != nil {
+		return nil, err
+	}
+	return c.ExecutionManager.GetCurrentExecution(ctx, request)
+}
+
+func (c *domainExecutionManager) IsWorkflowExecutionExists(ctx context.Context, request *persistence.IsWorkflowExecutionExistsRequest) (*persistence.IsWorkflowExecutionExistsResponse, error) {
+	if err := c.budgets.Allow(ctx, request.DomainName); err != nil {
+		return nil, err
+	}
+	return c.ExecutionManager.IsWorkflowExecutionExists(ctx, request)
+}
+
+// NewDomainHistoryManager returns a HistoryManager which charges history branch requests
+// to the budget of the domain they belong to.
+func NewDomainHistoryManager(wrapped persistence.HistoryManager, budgets *DomainBudgets) persistence.HistoryManager {
+	return &domainHistoryManager{
+		HistoryManager: wrapped,
+		budgets:        budgets,
+	}
+}
+
+func (c *domainHistoryManager) AppendHistoryNodes(ctx context.Context, request *persistence.AppendHistoryNodesRequest) (*persistence.AppendHistoryNodesResponse, error) {
+	if err := c.budgets.Allow(ctx, request.DomainName); err != nil {
+		return nil, err
+	}
+	return c.HistoryManager.AppendHistoryNodes(ctx, request)
+}
+
+func (c *domainHistoryManager) ReadHistoryBranch(ctx context.Context, request *persistence.ReadHistoryBranchRequest) (*persistence.ReadHistoryBranchResponse, error) {
+	if err := c.budgets.Allow(ctx, request.DomainName); err != nil {
+		return nil, err
+	}
+	return c.HistoryManager.ReadHistoryBranch(ctx, request)
+}
+
+func (c *domainHistoryManager) ReadHistoryBranchByBatch(ctx context.Context, request *persistence.ReadHistoryBranchRequest) (*persistence.ReadHistoryBranchByBatchResponse, error) {
+	if err := c.budgets.Allow(ctx, request.DomainName); err != nil {
+		return nil, err
+	}
+	return c.HistoryManager.ReadHistoryBranchByBatch(ctx, request)
+}
+
+func (c *domainHistoryManager) ReadRawHistoryBranch(ctx context.Context, request *persistence.ReadHistoryBranchRequest) (*persistence.ReadRawHistoryBranchResponse, error) {
+	if err := c.budgets.Allow(ctx, request.DomainName); err != nil {
+		return nil, err
+	}
+	return c.HistoryManager.ReadRawHistoryBranch(ctx, request)
+}
+
+func (c *domainHistoryManager) ForkHistoryBranch(ctx context.Context, request *persistence.ForkHistoryBranchRequest) (*persistence.ForkHistoryBranchResponse, error) {
+	if err := c.budgets.Allow(ctx, request.DomainName); err != nil {
+		return nil, err
+	}
+	return c.HistoryManager.ForkHistoryBranch(ctx, request)
+}
+
+func (c *domainHistoryManager) DeleteHistoryBranch(ctx context.Context, request *persistence.DeleteHistoryBranchRequest) error {
+	if err := c.budgets.Allow(ctx, request.DomainName); err != nil {
+		return err
+	}
+	return c.HistoryManager.DeleteHistoryBranch(ctx, request)
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ratelimited
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/quotas"
+	"github.com/uber/cadence/common/types"
+)
+
+func testDomainBudgets(qps map[string]int, maxWait time.Duration, bypassCallerTypes ...interface{}) *DomainBudgets {
+	return testSharedDomainBudgets(qps, 0, maxWait, bypassCallerTypes...)
+}
+
+func testSharedDomainBudgets(qps map[string]int, totalQPS int, maxWait time.Duration, bypassCallerTypes ...interface{}) *DomainBudgets {
+	return NewDomainBudgets(
+		func(domain string) int { return qps[domain] },
+		func(opts ...dynamicproperties.FilterOption) int { return totalQPS },
+		func(domain string) time.Duration { return maxWait },
+		quotas.NewCallerBypass(func(opts ...dynamicproperties.FilterOption) []interface{} { return bypassCallerTypes }),
+	)
+}
+
+func TestDomainBudgetsAllow(t *testing.T) {
+	t.Run("unlimited domains pass through", func(t *testing.T) {
+		budgets := testDomainBudgets(map[string]int{"limited": 1}, 0)
+		for i := 0; i < 10; i++ {
+			assert.NoError(t, budgets.Allow(context.Background(), "unlimited"))
+			assert.NoError(t, budgets.Allow(context.Background(), ""))
+		}
+	})
+	t.Run("exhausted domain is rejected without affecting others", func(t *testing.T) {
+		budgets := testDomainBudgets(map[string]int{"noisy": 1, "quiet": 1}, 0)
+		require.NoError(t, budgets.Allow(context.Background(), "noisy"))
+		assert.Equal(t, ErrPersistenceDomainLimitExceeded, budgets.Allow(context.Background(), "noisy"))
+		assert.NoError(t, budgets.Allow(context.Background(), "quiet"))
+	})
+	t.Run("requests queue up to the max wait", func(t *testing.T) {
+		budgets := testDomainBudgets(map[string]int{"domain": 20}, time.Second)
+		for i := 0; i < 20; i++ { // drain the burst
+			require.NoError(t, budgets.Allow(context.Background(), "domain"))
+		}
+		start := time.Now()
+		assert.NoError(t, budgets.Allow(context.Background(), "domain"))
+		assert.Greater(t, time.Since(start), 10*time.Millisecond, "should have waited for a token")
+	})
+	t.Run("requests that would wait longer than the max wait are rejected", func(t *testing.T) {
+		budgets := testDomainBudgets(map[string]int{"domain": 1}, time.Millisecond)
+		require.NoError(t, budgets.Allow(context.Background(), "domain"))
+		assert.Equal(t, ErrPersistenceDomainLimitExceeded, budgets.Allow(context.Background(), "domain"))
+	})
+	t.Run("parent context errors are returned as-is", func(t *testing.T) {
+		budgets := testDomainBudgets(map[string]int{"domain": 1}, time.Second)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, budgets.Allow(ctx, "domain"), context.Canceled)
+	})
+	t.Run("nil budgets admit every request", func(t *testing.T) {
+		var budgets *DomainBudgets
+		assert.NoError(t, budgets.Allow(context.Background(), "domain"))
+	})
+	t.Run("total budget is shared by all domains", func(t *testing.T) {
+		budgets := testSharedDomainBudgets(nil, 1, 0)
+		require.NoError(t, budgets.Allow(context.Background(), "noisy"))
+		assert.Equal(t, ErrPersistenceTotalLimitExceeded, budgets.Allow(context.Background(), "quiet"))
+		assert.NoError(t, budgets.Allow(context.Background(), ""), "requests without a domain are not charged")
+	})
+	t.Run("requests queue for the total budget up to the max wait", func(t *testing.T) {
+		budgets := testSharedDomainBudgets(nil, 20, time.Second)
+		for i := 0; i < 20; i++ { // drain the burst
+			require.NoError(t, budgets.Allow(context.Background(), "domain"))
+		}
+		start := time.Now()
+		assert.NoError(t, budgets.Allow(context.Background(), "other"))
+		assert.Greater(t, time.Since(start), 10*time.Millisecond, "should have waited for a token")
+
+		budgets = testSharedDomainBudgets(nil, 1, time.Millisecond)
+		require.NoError(t, budgets.Allow(context.Background(), "domain"))
+		assert.Equal(t, ErrPersistenceTotalLimitExceeded, budgets.Allow(context.Background(), "other"))
+	})
+	t.Run("bypass caller types are allowed", func(t *testing.T) {
+		for _, maxWait := range []time.Duration{0, time.Millisecond} {
+			budgets := testDomainBudgets(map[string]int{"domain": 1}, maxWait, "cli")
+			ctx := types.ContextWithCallerInfo(context.Background(), types.NewCallerInfo(types.CallerTypeCLI))
+			for i := 0; i < 3; i++ {
+				assert.NoError(t, budgets.Allow(ctx, "domain"), "max wait %v", maxWait)
+			}
+			assert.Equal(t, ErrPersistenceDomainLimitExceeded, budgets.Allow(context.Background(), "domain"), "max wait %v", maxWait)
+		}
+	})
+}
+
+func TestDomainExecutionManager(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	wrapped := persistence.NewMockExecutionManager(ctrl)
+	manager := NewDomainExecutionManager(wrapped, testDomainBudgets(map[string]int{"domain": 1}, 0))
+
+	wrapped.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).Return(&persistence.GetWorkflowExecutionResponse{}, nil).Times(1)
+	_, err := manager.GetWorkflowExecution(context.Background(), &persistence.GetWorkflowExecutionRequest{DomainName: "domain"})
+	require.NoError(t, err)
+	_, err = manager.GetCurrentExecution(context.Background(), &persistence.GetCurrentExecutionRequest{DomainName: "domain"})
+	assert.Equal(t, ErrPersistenceDomainLimitExceeded, err)
+
+	// writes issued under the shard lock are charged by the shard before it takes the lock
+	wrapped.EXPECT().UpdateWorkflowExecution(gomock.Any(), gomock.Any()).Return(&persistence.UpdateWorkflowExecutionResponse{}, nil).Times(1)
+	_, err = manager.UpdateWorkflowExecution(context.Background(), &persistence.UpdateWorkflowExecutionRequest{DomainName: "domain"})
+	assert.NoError(t, err)
+
+	// requests without a domain are not charged
+	wrapped.EXPECT().GetHistoryTasks(gomock.Any(), gomock.Any()).Return(&persistence.GetHistoryTasksResponse{}, nil).Times(1)
+	_, err = manager.GetHistoryTasks(context.Background(), &persistence.GetHistoryTasksRequest{})
+	assert.NoError(t, err)
+}
+
+func TestDomainHistoryManager(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	wrapped := persistence.NewMockHistoryManager(ctrl)
+	manager := NewDomainHistoryManager(wrapped, testDomainBudgets(map[string]int{"domain": 1}, 0))
+
+	wrapped.EXPECT().AppendHistoryNodes(gomock.Any(), gomock.Any()).Return(&persistence.AppendHistoryNodesResponse{}, nil).Times(1)
+	_, err := manager.AppendHistoryNodes(context.Background(), &persistence.AppendHistoryNodesRequest{DomainName: "domain"})
+	require.NoError(t, err)
+	_, err = manager.ReadHistoryBranch(context.Background(), &persistence.ReadHistoryBranchRequest{DomainName: "domain"})
+	assert.Equal(t, ErrPersistenceDomainLimitExceeded, err)
+
+	wrapped.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).Return(&persistence.ReadHistoryBranchResponse{}, nil).Times(1)
+	_, err = manager.ReadHistoryBranch(context.Background(), &persistence.ReadHistoryBranchRequest{DomainName: "other"})
+	assert.NoError(t, err)
+}
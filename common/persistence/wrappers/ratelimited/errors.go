@@ -27,4 +27,8 @@ import (
 var (
 	// ErrPersistenceLimitExceeded is the error indicating QPS limit reached.
 	ErrPersistenceLimitExceeded = &types.ServiceBusyError{Message: "Persistence Max QPS Reached."}
+	// ErrPersistenceDomainLimitExceeded is the error indicating a domain's QPS budget is exhausted.
+	ErrPersistenceDomainLimitExceeded = &types.ServiceBusyError{Message: "Persistence Max QPS Reached for domain."}
+	// ErrPersistenceTotalLimitExceeded is the error indicating the budget shared by all domains is exhausted.
+	ErrPersistenceTotalLimitExceeded = &types.ServiceBusyError{Message: "Persistence Max QPS Reached for shard."}
 )
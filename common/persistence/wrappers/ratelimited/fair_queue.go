@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ratelimited
+
+import (
+	"context"
+	"sync"
+
+	"github.com/uber/cadence/common/quotas"
+)
+
+type (
+	// fairQueue shares the tokens of a limiter fairly between domains.
+	// Waiting requests are queued per domain and domains with waiting requests take turns,
+	// one token each, so a domain with many waiting requests does not starve the others.
+	// Only the request holding the turn waits on the limiter.
+	fairQueue struct {
+		limiter quotas.Limiter
+
+		sync.Mutex
+		waiting map[string][]*fairWaiter
+		// domains with waiting requests, in the order they take turns
+		domains []string
+		next    int
+		// busy is true while a request holds the turn
+		busy bool
+	}
+
+	fairWaiter struct {
+		domain  string
+		turn    chan struct{}
+		granted bool
+	}
+)
+
+func newFairQueue(limiter quotas.Limiter) *fairQueue {
+	return &fairQueue{
+		limiter: limiter,
+		waiting: make(map[string][]*fairWaiter),
+	}
+}
+
+// Allow takes a token without waiting, false if requests are already waiting for one
+func (q *fairQueue) Allow() bool {
+	q.Lock()
+	defer q.Unlock()
+	if q.busy {
+		return false
+	}
+	return q.limiter.Allow()
+}
+
+// Wait waits for the turn of domain and then for a token of the limiter
+func (q *fairQueue) Wait(ctx context.Context, domain string) error {
+	q.Lock()
+	if !q.busy {
+		q.busy = true
+		q.Unlock()
+		return q.take(ctx)
+	}
+	waiter := &fairWaiter{domain: domain, turn: make(chan struct{})}
+	if len(q.waiting[domain]) == 0 {
+		q.domains = append(q.domains, domain)
+	}
+	q.waiting[domain] = append(q.waiting[domain], waiter)
+	q.Unlock()
+
+	select {
+	case <-waiter.turn:
+		return q.take(ctx)
+	case <-ctx.Done():
+		q.Lock()
+		if waiter.granted {
+			// the turn was handed over concurrently, pass it on
+			q.Unlock()
+			q.release()
+		} else {
+			q.removeLocked(waiter)
+			q.Unlock()
+		}
+		return ctx.Err()
+	}
+}
+
+func (q *fairQueue) take(ctx context.Context) error {
+	defer q.release()
+	return q.limiter.Wait(ctx)
+}
+
+// release hands the turn over to the first waiting request of the next domain
+func (q *fairQueue) release() {
+	q.Lock()
+	defer q.Unlock()
+	if len(q.domains) == 0 {
+		q.busy = false
+		return
+	}
+	if q.next >= len(q.domains) {
+		q.next = 0
+	}
+	domain := q.domains[q.next]
+	waiters := q.waiting[domain]
+	waiter := waiters[0]
+	if len(waiters) == 1 {
+		delete(q.waiting, domain)
+		q.domains = append(q.domains[:q.next], q.domains[q.next+1:]...)
+	} else {
+		q.waiting[domain] = waiters[1:]
+		q.next++
+	}
+	waiter.granted = true
+	close(waiter.turn)
+}
+
+func (q *fairQueue) removeLocked(waiter *fairWaiter) {
+	waiters := q.waiting[waiter.domain]
+	for i, w := range waiters {
+		if w != waiter {
+			continue
+		}
+		waiters = append(waiters[:i], waiters[i+1:]...)
+		break
+	}
+	if len(waiters) > 0 {
+		q.waiting[waiter.domain] = waiters
+		return
+	}
+	delete(q.waiting, waiter.domain)
+	for i, domain := range q.domains {
+		if domain != waiter.domain {
+			continue
+		}
+		q.domains = append(q.domains[:i], q.domains[i+1:]...)
+		if i < q.next {
+			q.next--
+		}
+		return
+	}
+}
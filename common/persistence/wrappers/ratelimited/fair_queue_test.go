@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ratelimited
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/quotas"
+)
+
+// tokenLimiter hands out one token per value sent on its channel
+func tokenLimiter(ctrl *gomock.Controller) (quotas.Limiter, chan struct{}) {
+	tokens := make(chan struct{})
+	limiter := quotas.NewMockLimiter(ctrl)
+	limiter.EXPECT().Wait(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+		select {
+		case <-tokens:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}).AnyTimes()
+	return limiter, tokens
+}
+
+func queuedWaiters(q *fairQueue) int {
+	q.Lock()
+	defer q.Unlock()
+	count := 0
+	for _, waiters := range q.waiting {
+		count += len(waiters)
+	}
+	return count
+}
+
+func TestFairQueueTakesTurnsAcrossDomains(t *testing.T) {
+	limiter, tokens := tokenLimiter(gomock.NewController(t))
+	q := newFairQueue(limiter)
+
+	var mu sync.Mutex
+	var served []string
+	var wg sync.WaitGroup
+	wait := func(domain string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, q.Wait(context.Background(), domain))
+			mu.Lock()
+			served = append(served, domain)
+			mu.Unlock()
+		}()
+	}
+	servedCount := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(served)
+	}
+
+	// the first request holds the turn, the next ones queue in order
+	wait("noisy")
+	require.Eventually(t, func() bool { q.Lock(); defer q.Unlock(); return q.busy }, time.Second, time.Millisecond)
+	for i, domain := range []string{"noisy", "noisy", "noisy", "quiet"} {
+		wait(domain)
+		want := i + 1
+		require.Eventually(t, func() bool { return queuedWaiters(q) == want }, time.Second, time.Millisecond)
+	}
+
+	for i := 1; i <= 5; i++ {
+		tokens <- struct{}{}
+		require.Eventually(t, func() bool { return servedCount() == i }, time.Second, time.Millisecond)
+	}
+	wg.Wait()
+	assert.Equal(t, []string{"noisy", "noisy", "quiet", "noisy", "noisy"}, served)
+	assert.False(t, q.busy)
+	assert.Empty(t, q.domains)
+}
+
+func TestFairQueueCancelledWaiterLeavesTheQueue(t *testing.T) {
+	limiter, tokens := tokenLimiter(gomock.NewController(t))
+	q := newFairQueue(limiter)
+
+	holderDone := make(chan error)
+	go func() { holderDone <- q.Wait(context.Background(), "holder") }()
+	require.Eventually(t, func() bool { q.Lock(); defer q.Unlock(); return q.busy }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelledDone := make(chan error)
+	go func() { cancelledDone <- q.Wait(ctx, "cancelled") }()
+	require.Eventually(t, func() bool { return queuedWaiters(q) == 1 }, time.Second, time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-cancelledDone, context.Canceled)
+	assert.Zero(t, queuedWaiters(q))
+	assert.Empty(t, q.domains)
+
+	tokens <- struct{}{}
+	assert.NoError(t, <-holderDone)
+	assert.False(t, q.busy)
+}
+
+func TestFairQueueAllow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	limiter := quotas.NewMockLimiter(ctrl)
+	q := newFairQueue(limiter)
+
+	limiter.EXPECT().Allow().Return(true).Times(1)
+	assert.True(t, q.Allow())
+
+	// requests waiting for a token are served first
+	q.busy = true
+	assert.False(t, q.Allow())
+}
@@ -45,6 +45,10 @@ func (l limiterAlwaysAllow) Reserve() clock.Reservation {
 	return &reservationAlwaysAllow{}
 }
 
+func (l limiterAlwaysAllow) ReserveN(n int) clock.Reservation {
+	return &reservationAlwaysAllow{}
+}
+
 func (l limiterAlwaysAllow) Limit() rate.Limit {
 	return rate.Inf
 }
@@ -64,6 +68,10 @@ func (l limiterNeverAllow) Reserve() clock.Reservation {
 	return &reservationNeverAllow{}
 }
 
+func (l limiterNeverAllow) ReserveN(n int) clock.Reservation {
+	return &reservationNeverAllow{}
+}
+
 func (l limiterNeverAllow) Limit() rate.Limit {
 	return 0
 }
@@ -166,6 +166,7 @@ func builderForPassThrough(t *testing.T, injector any, limiter quotas.Limiter, e
 		if expectCalls {
 			mocked.EXPECT().UpdateDynamicConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedErr)
 			mocked.EXPECT().FetchDynamicConfig(gomock.Any(), gomock.Any()).Return(&persistence.FetchDynamicConfigResponse{}, expectedErr)
+			mocked.EXPECT().FetchDynamicConfigHistory(gomock.Any(), gomock.Any(), gomock.Any()).Return(&persistence.FetchDynamicConfigHistoryResponse{}, expectedErr)
 		}
 	case *ratelimitedDomainManager:
 		mocked := persistence.NewMockDomainManager(ctrl)
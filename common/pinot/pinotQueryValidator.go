@@ -36,11 +36,14 @@ import (
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/visibility"
 )
 
 // VisibilityQueryValidator for sql query validation
 type VisibilityQueryValidator struct {
 	validSearchAttributes      dynamicproperties.MapPropertyFn
+	domainSearchAttributes     dynamicproperties.MapPropertyFnWithDomainFilter
+	searchAttributeAliases     dynamicproperties.MapPropertyFn
 	pinotOptimizedQueryColumns dynamicproperties.MapPropertyFn
 }
 
@@ -52,16 +55,25 @@ var timeSystemKeys = map[string]bool{
 }
 
 // NewPinotQueryValidator create VisibilityQueryValidator
-func NewPinotQueryValidator(validSearchAttributes dynamicproperties.MapPropertyFn, pinotOptimizedQueryColumns dynamicproperties.MapPropertyFn) *VisibilityQueryValidator {
+func NewPinotQueryValidator(
+	validSearchAttributes dynamicproperties.MapPropertyFn,
+	domainSearchAttributes dynamicproperties.MapPropertyFnWithDomainFilter,
+	searchAttributeAliases dynamicproperties.MapPropertyFn,
+	pinotOptimizedQueryColumns dynamicproperties.MapPropertyFn,
+) *VisibilityQueryValidator {
 	return &VisibilityQueryValidator{
 		validSearchAttributes:      validSearchAttributes,
+		domainSearchAttributes:     domainSearchAttributes,
+		searchAttributeAliases:     searchAttributeAliases,
 		pinotOptimizedQueryColumns: pinotOptimizedQueryColumns,
 	}
 }
 
-// ValidateQuery validates that search attributes in the query and returns modified query.
-func (qv *VisibilityQueryValidator) ValidateQuery(whereClause string) (string, error) {
+// ValidateQuery validates that search attributes in the query are legal in domain and returns modified query.
+// Aliases of renamed search attributes, or of search attributes whose type changed, are resolved.
+func (qv *VisibilityQueryValidator) ValidateQuery(whereClause string, domain string) (string, error) {
 	if len(whereClause) != 0 {
+		qv = qv.forDomain(domain)
 		// Build a placeholder query that allows us to easily parse the contents of the where clause.
 		// IMPORTANT: This query is never executed, it is just used to parse and validate whereClause
 		var placeholderQuery string
@@ -125,7 +137,7 @@ func (qv *VisibilityQueryValidator) validateRangeExpr(expr sqlparser.Expr) (stri
 	if !ok {
 		return "", errors.New("invalid range expression: fail to get colname")
 	}
-	colNameStr := colName.Name.String()
+	colNameStr := qv.resolveAlias(colName)
 
 	if !qv.IsValidSearchAttributes(colNameStr) {
 		return "", fmt.Errorf("invalid search attribute %q", colNameStr)
@@ -210,7 +222,7 @@ func (qv *VisibilityQueryValidator) validateComparisonExpr(expr sqlparser.Expr)
 		return "", errors.New("invalid comparison expression, left")
 	}
 
-	colNameStr := colName.Name.String()
+	colNameStr := qv.resolveAlias(colName)
 
 	if !qv.IsValidSearchAttributes(colNameStr) {
 		return "", fmt.Errorf("invalid search attribute %q", colNameStr)
@@ -227,6 +239,25 @@ func (qv *VisibilityQueryValidator) validateComparisonExpr(expr sqlparser.Expr)
 	return qv.processCustomKey(expr)
 }
 
+// forDomain returns a validator whose valid search attributes include the ones registered for domain
+func (qv *VisibilityQueryValidator) forDomain(domain string) *VisibilityQueryValidator {
+	validated := *qv
+	validated.validSearchAttributes = func(opts ...dynamicproperties.FilterOption) map[string]interface{} {
+		return visibility.EffectiveSearchAttributes(qv.validSearchAttributes(opts...), qv.domainSearchAttributes(domain))
+	}
+	return &validated
+}
+
+// resolveAlias replaces the name of colName with the search attribute it is an alias of, and returns the name
+func (qv *VisibilityQueryValidator) resolveAlias(colName *sqlparser.ColName) string {
+	if qv.searchAttributeAliases == nil {
+		return colName.Name.String()
+	}
+	name := visibility.ResolveSearchAttributeAlias(qv.searchAttributeAliases(), colName.Name.String())
+	colName.Name = sqlparser.NewColIdent(name)
+	return name
+}
+
 // IsValidSearchAttributes return true if key is registered
 func (qv *VisibilityQueryValidator) IsValidSearchAttributes(key string) bool {
 	validAttr := qv.validSearchAttributes()
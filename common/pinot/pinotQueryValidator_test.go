@@ -36,6 +36,7 @@ import (
 func TestValidateQuery(t *testing.T) {
 	tests := map[string]struct {
 		query     string
+		domain    string
 		validated string
 		err       string
 	}{
@@ -78,6 +79,16 @@ func TestValidateQuery(t *testing.T) {
 			query:     "CustomKeywordField = 'custom'",
 			validated: `(JSON_MATCH(Attr, '"$.CustomKeywordField"=''custom''') or JSON_MATCH(Attr, '"$.CustomKeywordField[*]"=''custom'''))`,
 		},
+		"Case5-1: custom keyword field registered for domain": {
+			query:     "CustomTeamField = 'custom'",
+			domain:    "team-domain",
+			validated: `(JSON_MATCH(Attr, '"$.CustomTeamField"=''custom''') or JSON_MATCH(Attr, '"$.CustomTeamField[*]"=''custom'''))`,
+		},
+		"Case5-2: custom keyword field registered for another domain": {
+			query:  "CustomTeamField = 'custom'",
+			domain: "other-domain",
+			err:    "invalid search attribute \"CustomTeamField\"",
+		},
 		"Case6-1: complex query I: with parenthesis": {
 			query:     "(CustomStringField = 'custom and custom2 or custom3 order by') or CustomIntField between 1 and 10",
 			validated: `(JSON_MATCH(Attr, '"$.CustomStringField" is not null') AND JSON_MATCH(Attr, 'REGEXP_LIKE("$.CustomStringField", ''.*custom and custom2 or custom3 order by.*'')') or (JSON_MATCH(Attr, '"$.CustomIntField" is not null') AND CAST(JSON_EXTRACT_SCALAR(Attr, '$.CustomIntField') AS INT) >= 1 AND CAST(JSON_EXTRACT_SCALAR(Attr, '$.CustomIntField') AS INT) <= 10))`,
@@ -150,6 +161,14 @@ func TestValidateQuery(t *testing.T) {
 			query:     "CustomIntField = 1 or CustomIntField = 2",
 			validated: `(JSON_MATCH(Attr, '"$.CustomIntField"=''1''') or JSON_MATCH(Attr, '"$.CustomIntField"=''2'''))`,
 		},
+		"Case13-2: alias of a search attribute": {
+			query:     "CustomNumber = 1 or CustomNumber = 2",
+			validated: `(JSON_MATCH(Attr, '"$.CustomIntField"=''1''') or JSON_MATCH(Attr, '"$.CustomIntField"=''2'''))`,
+		},
+		"Case13-3: alias of a search attribute in range query": {
+			query:     "CustomNumber BETWEEN 1 AND 2",
+			validated: `(JSON_MATCH(Attr, '"$.CustomIntField" is not null') AND CAST(JSON_EXTRACT_SCALAR(Attr, '$.CustomIntField') AS INT) >= 1 AND CAST(JSON_EXTRACT_SCALAR(Attr, '$.CustomIntField') AS INT) <= 2)`,
+		},
 		"Case14-1: range query: custom filed": {
 			query:     "CustomIntField BETWEEN 1 AND 2",
 			validated: `(JSON_MATCH(Attr, '"$.CustomIntField" is not null') AND CAST(JSON_EXTRACT_SCALAR(Attr, '$.CustomIntField') AS INT) >= 1 AND CAST(JSON_EXTRACT_SCALAR(Attr, '$.CustomIntField') AS INT) <= 2)`,
@@ -373,11 +392,20 @@ func TestValidateQuery(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			validSearchAttr := dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys())
+			domainSearchAttr := func(domain string) map[string]interface{} {
+				if domain == "team-domain" {
+					return map[string]interface{}{"CustomTeamField": types.IndexedValueTypeKeyword}
+				}
+				return map[string]interface{}{}
+			}
 			pinotOptimizedQueryColumns := dynamicproperties.GetMapPropertyFn(map[string]interface{}{
 				"CustomTestField": "test",
 			})
-			qv := NewPinotQueryValidator(validSearchAttr, pinotOptimizedQueryColumns)
-			validated, err := qv.ValidateQuery(test.query)
+			searchAttributeAliases := dynamicproperties.GetMapPropertyFn(map[string]interface{}{
+				"CustomNumber": "CustomIntField",
+			})
+			qv := NewPinotQueryValidator(validSearchAttr, domainSearchAttr, searchAttributeAliases, pinotOptimizedQueryColumns)
+			validated, err := qv.ValidateQuery(test.query, test.domain)
 			if err != nil {
 				assert.Equal(t, test.err, err.Error())
 			} else {
@@ -412,7 +440,7 @@ func TestProcessInClause_FailedInputExprCases(t *testing.T) {
 	pinotOptimizedQueryColumns := dynamicproperties.GetMapPropertyFn(map[string]interface{}{
 		"CustomTestField": "test",
 	})
-	qv := NewPinotQueryValidator(validSearchAttr, pinotOptimizedQueryColumns)
+	qv := NewPinotQueryValidator(validSearchAttr, dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}), dynamicproperties.GetMapPropertyFn(map[string]interface{}{}), pinotOptimizedQueryColumns)
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -516,8 +544,8 @@ func TestIsOptimizedQueryColumn(t *testing.T) {
 				"CustomTestKeywordField": "test",
 				"CustomTestStringField":  "test",
 			})
-			qv := NewPinotQueryValidator(validSearchAttr, pinotOptimizedQueryColumns)
-			validated, err := qv.ValidateQuery(test.query)
+			qv := NewPinotQueryValidator(validSearchAttr, dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}), dynamicproperties.GetMapPropertyFn(map[string]interface{}{}), pinotOptimizedQueryColumns)
+			validated, err := qv.ValidateQuery(test.query, "")
 			if err != nil {
 				assert.Equal(t, test.err, err.Error())
 			} else {
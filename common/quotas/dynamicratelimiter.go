@@ -101,6 +101,12 @@ func (d *DynamicRateLimiter) Reserve() clock.Reservation {
 	return d.rl.Reserve()
 }
 
+// ReserveN reserves n rate limit tokens
+func (d *DynamicRateLimiter) ReserveN(n int) clock.Reservation {
+	d.maybeRefreshRps()
+	return d.rl.ReserveN(n)
+}
+
 func (d *DynamicRateLimiter) Limit() rate.Limit {
 	d.maybeRefreshRps()
 	return d.rl.Limit()
@@ -310,6 +310,12 @@ func (c *Collection) For(key string) quotas.Limiter {
 	}
 }
 
+// Enabled returns false if the key is in "disabled" mode, where For passes through to the disabled collection.
+// Callers that keep their own limiters while global ratelimiting is disabled can use this to bypass the collection.
+func (c *Collection) Enabled(key string) bool {
+	return c.keyMode(c.km.LocalToGlobal(shared.LocalKey(key))) != modeDisabled
+}
+
 func (c *Collection) shouldDeleteKey(mode keyMode, local bool) bool {
 	if local {
 		return !mode.usesLocal()
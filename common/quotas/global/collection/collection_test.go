@@ -336,6 +336,7 @@ func TestTogglingMode(t *testing.T) {
 
 	t.Run("disabled should not make any internal limiters or update calls", func(t *testing.T) {
 		mode.Store(modeDisabled)
+		assert.False(t, c.Enabled("key"), "disabled keys should not be enabled")
 		c.For("key").Allow()
 		assert.Equal(t, c.local.Len(), 0, "local collection should be empty when disabled")
 		assert.Equal(t, c.global.Len(), 0, "global collection should be empty when disabled")
@@ -347,6 +348,7 @@ func TestTogglingMode(t *testing.T) {
 	require.False(t, t.Failed(), "stopping early, already failed")
 	t.Run("local creates only local keys", func(t *testing.T) {
 		mode.Store(modeLocal)
+		assert.True(t, c.Enabled("key"), "local keys should be enabled")
 		c.For("key").Allow()
 		assertLimiterKeys(t, c.local, "local", "key")
 		assertLimiterKeys(t, c.global, "global") // not in a global-accessing mode
@@ -41,6 +41,7 @@ type (
 	countedReservation struct {
 		wrapped clock.Reservation
 		usage   *AtomicUsage // reference to the reservation's limiter's usage
+		n       int          // number of tokens reserved, counted as that many requests
 	}
 
 	AtomicUsage struct {
@@ -78,6 +79,15 @@ func (c CountedLimiter) Reserve() clock.Reservation {
 	return countedReservation{
 		wrapped: c.wrapped.Reserve(),
 		usage:   c.usage,
+		n:       1,
+	}
+}
+
+func (c CountedLimiter) ReserveN(n int) clock.Reservation {
+	return countedReservation{
+		wrapped: c.wrapped.ReserveN(n),
+		usage:   c.usage,
+		n:       n,
 	}
 }
 
@@ -100,7 +110,7 @@ func (c countedReservation) Used(wasUsed bool) {
 		if wasUsed {
 			// only counts as allowed if used, else it is hopefully rolled back.
 			// this may or may not restore the token, but it does imply "this limiter did not limit the event".
-			c.usage.Count(true)
+			c.usage.CountN(true, c.n)
 		}
 
 		// else it was canceled, and not "used".
@@ -116,15 +126,20 @@ func (c countedReservation) Used(wasUsed bool) {
 		// trusted to mean "will not use for some other reason", and the underlying
 		// rate.Limiter did not change state anyway because it returned the
 		// pending-token before becoming a clock.Reservation.
-		c.usage.Count(false)
+		c.usage.CountN(false, c.n)
 	}
 }
 
 func (a *AtomicUsage) Count(allowed bool) {
+	a.CountN(allowed, 1)
+}
+
+// CountN counts a request that costs n tokens as n requests, so the aggregated usage is in tokens
+func (a *AtomicUsage) CountN(allowed bool, n int) {
 	if allowed {
-		a.allowed.Add(1)
+		a.allowed.Add(int64(n))
 	} else {
-		a.rejected.Add(1)
+		a.rejected.Add(int64(n))
 	}
 	a.idle.Store(0)
 }
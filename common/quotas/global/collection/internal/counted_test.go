@@ -96,6 +96,20 @@ func TestUsage(t *testing.T) {
 		r.Used(false)
 		assert.Equal(t, UsageMetrics{0, 1, 0}, lim.Collect(), "not-allowed reservations count as rejection")
 	})
+	t.Run("tracks reserve n", func(t *testing.T) {
+		ts := clock.NewMockedTimeSource()
+		lim := NewCountedLimiter(clock.NewRateLimiterWithTimeSource(ts, 10, 10))
+
+		r := lim.ReserveN(4)
+		assert.True(t, r.Allow(), "should have used part of the available burst")
+		r.Used(true)
+		assert.Equal(t, UsageMetrics{4, 0, 0}, lim.Collect(), "each reserved token should count as a request")
+
+		r = lim.ReserveN(7)
+		assert.False(t, r.Allow(), "should not have enough tokens available")
+		r.Used(false)
+		assert.Equal(t, UsageMetrics{0, 7, 0}, lim.Collect(), "each rejected token should count as a rejection")
+	})
 	// largely for coverage
 	t.Run("supports Limit", func(t *testing.T) {
 		rps := rate.Limit(1)
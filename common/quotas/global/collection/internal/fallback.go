@@ -213,6 +213,15 @@ func (b *FallbackLimiter) Reserve() clock.Reservation {
 	return countedReservation{
 		wrapped: b.both().Reserve(),
 		usage:   &b.usage,
+		n:       1,
+	}
+}
+
+func (b *FallbackLimiter) ReserveN(n int) clock.Reservation {
+	return countedReservation{
+		wrapped: b.both().ReserveN(n),
+		usage:   &b.usage,
+		n:       n,
 	}
 }
 
@@ -256,10 +256,11 @@ var _ clock.Reservation = allowres{}
 type allowlimiter struct{}
 type allowres struct{}
 
-func (allowlimiter) Allow() bool                  { return true }
-func (a allowlimiter) Wait(context.Context) error { return nil }
-func (a allowlimiter) Reserve() clock.Reservation { return allowres{} }
-func (a allowlimiter) Limit() rate.Limit          { return rate.Inf }
+func (allowlimiter) Allow() bool                      { return true }
+func (a allowlimiter) Wait(context.Context) error     { return nil }
+func (a allowlimiter) Reserve() clock.Reservation     { return allowres{} }
+func (a allowlimiter) ReserveN(int) clock.Reservation { return allowres{} }
+func (a allowlimiter) Limit() rate.Limit              { return rate.Inf }
 
 func (a allowres) Allow() bool { return true }
 func (a allowres) Used(bool)   {}
@@ -81,6 +81,13 @@ func (s shadowedLimiter) Reserve() clock.Reservation {
 	}
 }
 
+func (s shadowedLimiter) ReserveN(n int) clock.Reservation {
+	return shadowedReservation{
+		primary: s.primary.ReserveN(n),
+		shadow:  s.shadow.ReserveN(n),
+	}
+}
+
 func (s shadowedLimiter) Limit() rate.Limit {
 	return s.primary.Limit()
 }
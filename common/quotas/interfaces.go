@@ -41,6 +41,14 @@ type RPSKeyFunc func(key string) float64
 type Info struct {
 	Domain   string
 	TaskList string
+	// Cost is the number of tokens the request consumes when it is allowed, zero is treated as one.
+	// It is ignored by Wait, which always waits for a single token.
+	Cost int
+}
+
+// GetCost returns the number of tokens the request consumes, at least one
+func (i Info) GetCost() int {
+	return max(1, i.Cost)
 }
 
 // Limiter corresponds to basic rate limiting functionality.
@@ -61,6 +69,10 @@ type Limiter interface {
 	// Reserve reserves a rate limit token
 	Reserve() clock.Reservation
 
+	// ReserveN reserves n rate limit tokens, for requests that cost more than one token.
+	// n is capped at the current burst, see clock.Ratelimiter.ReserveN.
+	ReserveN(n int) clock.Reservation
+
 	// Limit returns the current configured ratelimit.
 	//
 	// If this Limiter wraps multiple values, this is generally the "most relevant" one,
@@ -85,6 +85,20 @@ func (mr *MockLimiterMockRecorder) Reserve() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockLimiter)(nil).Reserve))
 }
 
+// ReserveN mocks base method.
+func (m *MockLimiter) ReserveN(n int) clock.Reservation {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveN", n)
+	ret0, _ := ret[0].(clock.Reservation)
+	return ret0
+}
+
+// ReserveN indicates an expected call of ReserveN.
+func (mr *MockLimiterMockRecorder) ReserveN(n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveN", reflect.TypeOf((*MockLimiter)(nil).ReserveN), n)
+}
+
 // Wait mocks base method.
 func (m *MockLimiter) Wait(ctx context.Context) error {
 	m.ctrl.T.Helper()
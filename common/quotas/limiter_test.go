@@ -88,6 +88,19 @@ func TestMultiStageRateLimitingMultipleDomains(t *testing.T) {
 	check(" after refill")
 }
 
+func TestMultiStageRateLimiterCost(t *testing.T) {
+	t.Parallel()
+	policy := newFixedRpsMultiStageRateLimiter(t, 10, 5) // 5 tokens per domain, 10 total
+
+	assert.True(t, policy.Allow(Info{Domain: "one", Cost: 3}), "1:1 should work")
+	assert.False(t, policy.Allow(Info{Domain: "one", Cost: 3}), "1:2 should be limited") // only 2 domain tokens left
+	assert.True(t, policy.Allow(Info{Domain: "one", Cost: 2}), "1:3 should use the remaining domain tokens")
+
+	assert.True(t, policy.Allow(Info{Domain: "two", Cost: 5}), "2:1 should use the remaining global tokens")
+	assert.False(t, policy.Allow(Info{Domain: "three"}), "3:1 should be limited by global") // zero cost counts as one
+	assert.False(t, policy.Allow(Info{Domain: "three", Cost: -1}), "3:2 should still be limited by global")
+}
+
 func TestMultiStageRateLimiterWait(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
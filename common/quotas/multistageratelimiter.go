@@ -73,13 +73,14 @@ func NewMultiStageRateLimiter(global Limiter, domainLimiters ICollection[string]
 func (d *MultiStageRateLimiter) Allow(info Info) (allowed bool) {
 	domain := info.Domain
 	taskList := info.TaskList
+	cost := info.GetCost()
 
 	if taskList != "" && d.taskListLimiters != nil {
 		taskListKey := TaskListKey{
 			Domain:   domain,
 			TaskList: taskList,
 		}
-		rsv := d.taskListLimiters.For(taskListKey.String()).Reserve()
+		rsv := d.taskListLimiters.For(taskListKey.String()).ReserveN(cost)
 		defer func() {
 			rsv.Used(allowed) // returns the token if allowed but not used
 		}()
@@ -91,7 +92,7 @@ func (d *MultiStageRateLimiter) Allow(info Info) (allowed bool) {
 
 	if domain != "" && d.domainLimiters != nil {
 		// take a reservation with the domain limiter first
-		rsv := d.domainLimiters.For(domain).Reserve()
+		rsv := d.domainLimiters.For(domain).ReserveN(cost)
 		defer func() {
 			rsv.Used(allowed) // returns the token if allowed but not used
 		}()
@@ -103,10 +104,11 @@ func (d *MultiStageRateLimiter) Allow(info Info) (allowed bool) {
 
 	// ensure that the reservation does not break the global rate limit, if it
 	// does, cancel the reservation and do not allow to proceed.
-	if !d.globalLimiter.Allow() {
-		return false
-	}
-	return true
+	rsv := d.globalLimiter.ReserveN(cost)
+	defer func() {
+		rsv.Used(allowed)
+	}()
+	return rsv.Allow()
 }
 
 // Wait waits up till the context deadline for a rate limit token to allow the request
@@ -30,3 +30,16 @@ const (
 	CheckDataCorruptionWorkflowTimeoutInSeconds     = 24 * 60 * 60
 	CheckDataCorruptionWorkflowTaskTimeoutInSeconds = 60
 )
+
+// Targeted scan workflow relates
+
+const (
+	TargetedScanWorkflowType = "targeted-scan-workflow"
+	// TargetedScanWorkflowTaskList is shared with the data corruption workflow, both run on demand
+	TargetedScanWorkflowTaskList = CheckDataCorruptionWorkflowTaskList
+	// TargetedScanReportQuery returns the TargetedScanReport of a running or completed targeted scan
+	TargetedScanReportQuery                  = "report"
+	TargetedScanWorkflowIDPrefix             = "targeted-scan-"
+	TargetedScanWorkflowTimeoutInSeconds     = 24 * 60 * 60
+	TargetedScanWorkflowTaskTimeoutInSeconds = 60
+)
@@ -24,6 +24,7 @@ package fetcher
 
 import (
 	"context"
+	"math/rand"
 
 	"github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common/codec"
@@ -42,6 +43,31 @@ func ConcreteExecutionIterator(
 	return pagination.NewIterator(ctx, nil, getConcreteExecutions(retryer, pageSize, codec.NewThriftRWEncoder()))
 }
 
+// SampledConcreteExecutionIterator is a ConcreteExecutionIterator which only returns
+// a random sample of the executions, each one being included with probability sampleRate.
+func SampledConcreteExecutionIterator(
+	ctx context.Context,
+	retryer persistence.Retryer,
+	pageSize int,
+	sampleRate float64,
+) pagination.Iterator {
+	fetchFn := getConcreteExecutions(retryer, pageSize, codec.NewThriftRWEncoder())
+	return pagination.NewIterator(ctx, nil, func(ctx context.Context, token pagination.PageToken) (pagination.Page, error) {
+		page, err := fetchFn(ctx, token)
+		if err != nil {
+			return pagination.Page{}, err
+		}
+		sampled := page.Entities[:0]
+		for _, e := range page.Entities {
+			if rand.Float64() < sampleRate {
+				sampled = append(sampled, e)
+			}
+		}
+		page.Entities = sampled
+		return page, nil
+	})
+}
+
 // ConcreteExecution returns a single ConcreteExecution from persistence
 func ConcreteExecution(
 	ctx context.Context,
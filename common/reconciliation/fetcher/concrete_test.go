@@ -54,6 +54,46 @@ func TestConcreteExecutionIterator(t *testing.T) {
 	require.NotNil(t, iterator)
 }
 
+func TestSampledConcreteExecutionIterator(t *testing.T) {
+	encoder := codec.NewThriftRWEncoder()
+	executions := make([]*persistence.ListConcreteExecutionsEntity, 10)
+	for i := range executions {
+		executions[i] = &persistence.ListConcreteExecutionsEntity{
+			ExecutionInfo: &persistence.WorkflowExecutionInfo{
+				BranchToken: mustGetValidBranchToken(t, encoder, "test-tree-id", "test-branch-id"),
+				State:       persistence.WorkflowStateRunning,
+				DomainID:    "test-domain-id",
+				WorkflowID:  fmt.Sprintf("test-workflow-id-%d", i),
+				RunID:       "test-run-id",
+			},
+		}
+	}
+	for _, tc := range []struct {
+		sampleRate float64
+		want       int
+	}{
+		{sampleRate: 0, want: 0},
+		{sampleRate: 1, want: len(executions)},
+	} {
+		t.Run(fmt.Sprintf("sample rate %v", tc.sampleRate), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			retryer := persistence.NewMockRetryer(ctrl)
+			retryer.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).
+				Return(&persistence.ListConcreteExecutionsResponse{Executions: executions}, nil).Times(1)
+			retryer.EXPECT().GetShardID().Return(1).AnyTimes()
+
+			iterator := SampledConcreteExecutionIterator(context.Background(), retryer, 10, tc.sampleRate)
+			count := 0
+			for iterator.HasNext() {
+				_, err := iterator.Next()
+				require.NoError(t, err)
+				count++
+			}
+			require.Equal(t, tc.want, count)
+		})
+	}
+}
+
 func TestConcreteExecution(t *testing.T) {
 	encoder := codec.NewThriftRWEncoder()
 	tests := []struct {
@@ -0,0 +1,429 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	// parentCloseGracePeriod is how long a parent must have been closed before its
+	// parent close policy is expected to have been applied to its children.
+	parentCloseGracePeriod = time.Hour
+
+	childParentLinkageIdentity = "cadence-scanner"
+)
+
+type (
+	childParentLinkage struct {
+		pr              persistence.Retryer
+		dc              cache.DomainCache
+		historyClient   history.Client
+		workflowRetryer WorkflowRetryer
+	}
+
+	// linkageIssue describes the broken link found by childParentLinkage,
+	// it carries enough information for Fix to repair it.
+	linkageIssue struct {
+		// set when a pending child of the execution does not exist
+		missingChild *persistence.ChildExecutionInfo
+		// set when the parent of the execution is closed without applying its parent close policy
+		parentExecution *types.WorkflowExecution
+		parentChildInfo *persistence.ChildExecutionInfo
+	}
+)
+
+// NewChildParentLinkage returns a new invariant for checking that parent and child executions agree with each other.
+// An open parent must not point at a pending child which does not exist, and an open child must not
+// outlive a closed parent whose parent close policy was never applied.
+// Parents and children are usually owned by other shards than the execution, they are loaded through
+// workflowRetryer. historyClient is only used by Fix, and may be nil when the invariant is only used for checks.
+func NewChildParentLinkage(
+	pr persistence.Retryer, dc cache.DomainCache, historyClient history.Client, workflowRetryer WorkflowRetryer,
+) Invariant {
+	return &childParentLinkage{
+		pr:              pr,
+		dc:              dc,
+		historyClient:   historyClient,
+		workflowRetryer: workflowRetryer,
+	}
+}
+
+func (c *childParentLinkage) Check(
+	ctx context.Context,
+	execution interface{},
+) CheckResult {
+	checkResult, _ := c.check(ctx, execution)
+	return checkResult
+}
+
+func (c *childParentLinkage) Fix(
+	ctx context.Context,
+	execution interface{},
+) FixResult {
+	if fixResult := validateFixContext(ctx, c.Name()); fixResult != nil {
+		return *fixResult
+	}
+
+	checkResult, issue := c.check(ctx, execution)
+	switch checkResult.CheckResultType {
+	case CheckResultTypeHealthy:
+		return FixResult{
+			FixResultType: FixResultTypeSkipped,
+			InvariantName: c.Name(),
+			CheckResult:   checkResult,
+			Info:          "skipped fix because execution was healthy",
+		}
+	case CheckResultTypeFailed:
+		return FixResult{
+			FixResultType: FixResultTypeFailed,
+			InvariantName: c.Name(),
+			CheckResult:   checkResult,
+			Info:          "failed fix because check failed",
+		}
+	}
+	if issue.missingChild != nil {
+		// there is no close event of the child to record on the parent, making one up would hand
+		// the parent workflow a result the child never produced
+		return FixResult{
+			FixResultType: FixResultTypeSkipped,
+			InvariantName: c.Name(),
+			CheckResult:   checkResult,
+			Info:          "skipped fix because a missing child execution has no close event to record on the parent",
+		}
+	}
+	if c.historyClient == nil {
+		return FixResult{
+			FixResultType: FixResultTypeFailed,
+			InvariantName: c.Name(),
+			CheckResult:   checkResult,
+			Info:          "failed fix because history client is not available",
+		}
+	}
+
+	concreteExecution := execution.(*entity.ConcreteExecution)
+	if err := c.applyParentClosePolicy(ctx, concreteExecution, issue.parentExecution, issue.parentChildInfo); err != nil {
+		return FixResult{
+			FixResultType: FixResultTypeFailed,
+			InvariantName: c.Name(),
+			CheckResult:   checkResult,
+			Info:          "failed to repair parent child linkage",
+			InfoDetails:   err.Error(),
+		}
+	}
+	return FixResult{
+		FixResultType: FixResultTypeFixed,
+		InvariantName: c.Name(),
+		CheckResult:   checkResult,
+	}
+}
+
+func (c *childParentLinkage) Name() Name {
+	return ChildParentLinkage
+}
+
+func (c *childParentLinkage) check(
+	ctx context.Context,
+	execution interface{},
+) (CheckResult, *linkageIssue) {
+	if checkResult := validateCheckContext(ctx, c.Name()); checkResult != nil {
+		return *checkResult, nil
+	}
+
+	concreteExecution, ok := execution.(*entity.ConcreteExecution)
+	if !ok {
+		return c.failed("failed to check: expected concrete execution", nil), nil
+	}
+	if !Open(concreteExecution.State) {
+		return c.healthy(), nil
+	}
+	if c.workflowRetryer == nil {
+		return c.failed("failed to check: executions on other shards cannot be loaded", nil), nil
+	}
+
+	state, err := c.getMutableState(ctx, c.pr, concreteExecution.DomainID, concreteExecution.WorkflowID, concreteExecution.RunID)
+	if err != nil {
+		return c.failed("failed to get concrete execution", err), nil
+	}
+	if state == nil || !Open(state.ExecutionInfo.State) {
+		return c.healthy(), nil
+	}
+
+	initiatedIDs := make([]int64, 0, len(state.ChildExecutionInfos))
+	for initiatedID := range state.ChildExecutionInfos {
+		initiatedIDs = append(initiatedIDs, initiatedID)
+	}
+	sort.Slice(initiatedIDs, func(i, j int) bool { return initiatedIDs[i] < initiatedIDs[j] })
+	for _, initiatedID := range initiatedIDs {
+		childInfo := state.ChildExecutionInfos[initiatedID]
+		if childInfo.StartedID == constants.EmptyEventID {
+			// child is not started yet, there is nothing to point at
+			continue
+		}
+		exists, err := c.childExists(ctx, concreteExecution, childInfo)
+		if err != nil {
+			return c.failed("failed to check if pending child execution exists", err), nil
+		}
+		if !exists {
+			return CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   c.Name(),
+				Info:            "open execution has a pending child execution which does not exist",
+				InfoDetails: fmt.Sprintf("initiatedID: %v, childWorkflowID: %v, childRunID: %v",
+					childInfo.InitiatedID, childInfo.StartedWorkflowID, childInfo.StartedRunID),
+			}, &linkageIssue{missingChild: childInfo}
+		}
+	}
+
+	info := state.ExecutionInfo
+	if info.ParentWorkflowID == "" || info.ParentRunID == "" {
+		return c.healthy(), nil
+	}
+	parentPR, err := c.workflowRetryer(info.ParentWorkflowID)
+	if err != nil {
+		return c.failed("failed to resolve shard of parent execution", err), nil
+	}
+	parentState, err := c.getMutableState(ctx, parentPR, info.ParentDomainID, info.ParentWorkflowID, info.ParentRunID)
+	if err != nil {
+		return c.failed("failed to get parent execution", err), nil
+	}
+	if parentState == nil || Open(parentState.ExecutionInfo.State) {
+		// a missing parent is already covered by retention, an open parent is healthy
+		return c.healthy(), nil
+	}
+	if time.Since(parentState.ExecutionInfo.LastUpdatedTimestamp) < parentCloseGracePeriod {
+		// parent close policy is applied asynchronously, give it time to catch up
+		return c.healthy(), nil
+	}
+	parentChildInfo, ok := parentState.ChildExecutionInfos[info.InitiatedID]
+	if !ok || parentChildInfo.StartedWorkflowID != concreteExecution.WorkflowID {
+		return c.healthy(), nil
+	}
+	switch parentChildInfo.ParentClosePolicy {
+	case types.ParentClosePolicyTerminate:
+	case types.ParentClosePolicyRequestCancel:
+		if info.CancelRequested {
+			return c.healthy(), nil
+		}
+	default:
+		return c.healthy(), nil
+	}
+	return CheckResult{
+		CheckResultType: CheckResultTypeCorrupted,
+		InvariantName:   c.Name(),
+		Info:            "open child execution has a closed parent which did not apply its parent close policy",
+		InfoDetails: fmt.Sprintf("parentWorkflowID: %v, parentRunID: %v, parentClosePolicy: %v",
+			info.ParentWorkflowID, info.ParentRunID, parentChildInfo.ParentClosePolicy),
+	}, &linkageIssue{
+		parentExecution: &types.WorkflowExecution{
+			WorkflowID: info.ParentWorkflowID,
+			RunID:      info.ParentRunID,
+		},
+		parentChildInfo: parentChildInfo,
+	}
+}
+
+// getMutableState returns nil state if the execution does not exist
+func (c *childParentLinkage) getMutableState(
+	ctx context.Context,
+	pr persistence.Retryer,
+	domainID string,
+	workflowID string,
+	runID string,
+) (*persistence.WorkflowMutableState, error) {
+	domainName, err := c.dc.GetDomainName(domainID)
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	resp, err := pr.GetWorkflowExecution(ctx, &persistence.GetWorkflowExecutionRequest{
+		DomainID: domainID,
+		Execution: types.WorkflowExecution{
+			WorkflowID: workflowID,
+			RunID:      runID,
+		},
+		DomainName: domainName,
+	})
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp.State, nil
+}
+
+func (c *childParentLinkage) childExists(
+	ctx context.Context,
+	parent *entity.ConcreteExecution,
+	childInfo *persistence.ChildExecutionInfo,
+) (bool, error) {
+	childDomainID, err := c.childDomainID(parent.DomainID, childInfo)
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			// child domain is deleted, nothing to compare with
+			return true, nil
+		}
+		return false, err
+	}
+	pr, err := c.workflowRetryer(childInfo.StartedWorkflowID)
+	if err != nil {
+		return false, err
+	}
+	state, err := c.getMutableState(ctx, pr, childDomainID, childInfo.StartedWorkflowID, childInfo.StartedRunID)
+	if err != nil {
+		return false, err
+	}
+	if state != nil {
+		return true, nil
+	}
+
+	// child may have continued as new, and its first run may be gone already
+	domainName, err := c.dc.GetDomainName(childDomainID)
+	if err != nil {
+		return false, err
+	}
+	current, err := pr.GetCurrentExecution(ctx, &persistence.GetCurrentExecutionRequest{
+		DomainID:   childDomainID,
+		WorkflowID: childInfo.StartedWorkflowID,
+		DomainName: domainName,
+	})
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			return false, nil
+		}
+		return false, err
+	}
+	currentState, err := c.getMutableState(ctx, pr, childDomainID, childInfo.StartedWorkflowID, current.RunID)
+	if err != nil {
+		return false, err
+	}
+	if currentState == nil || !isRunOfChild(currentState.ExecutionInfo, parent, childInfo) {
+		// the workflow ID was reused by another execution
+		return false, nil
+	}
+	return Open(current.State), nil
+}
+
+// isRunOfChild returns whether a run was continued as new from the child started by the parent.
+// Runs keep the first run ID of their chain, older runs without it are matched on their parent.
+func isRunOfChild(
+	info *persistence.WorkflowExecutionInfo,
+	parent *entity.ConcreteExecution,
+	childInfo *persistence.ChildExecutionInfo,
+) bool {
+	if info.FirstExecutionRunID != "" {
+		return info.FirstExecutionRunID == childInfo.StartedRunID
+	}
+	return info.ParentWorkflowID == parent.WorkflowID &&
+		info.ParentRunID == parent.RunID &&
+		info.InitiatedID == childInfo.InitiatedID
+}
+
+func (c *childParentLinkage) childDomainID(
+	parentDomainID string,
+	childInfo *persistence.ChildExecutionInfo,
+) (string, error) {
+	if childInfo.DomainID != "" {
+		return childInfo.DomainID, nil
+	}
+	if childInfo.DomainNameDEPRECATED != "" {
+		return c.dc.GetDomainID(childInfo.DomainNameDEPRECATED)
+	}
+	return parentDomainID, nil
+}
+
+// applyParentClosePolicy mirrors what history does when a parent closes
+func (c *childParentLinkage) applyParentClosePolicy(
+	ctx context.Context,
+	child *entity.ConcreteExecution,
+	parentExecution *types.WorkflowExecution,
+	parentChildInfo *persistence.ChildExecutionInfo,
+) error {
+	domainName, err := c.dc.GetDomainName(child.DomainID)
+	if err != nil {
+		return err
+	}
+	switch parentChildInfo.ParentClosePolicy {
+	case types.ParentClosePolicyTerminate:
+		return c.historyClient.TerminateWorkflowExecution(ctx, &types.HistoryTerminateWorkflowExecutionRequest{
+			DomainUUID: child.DomainID,
+			TerminateRequest: &types.TerminateWorkflowExecutionRequest{
+				Domain: domainName,
+				WorkflowExecution: &types.WorkflowExecution{
+					WorkflowID: parentChildInfo.StartedWorkflowID,
+				},
+				Reason:              "by parent close policy",
+				Identity:            childParentLinkageIdentity,
+				FirstExecutionRunID: parentChildInfo.StartedRunID,
+			},
+			ExternalWorkflowExecution: parentExecution,
+			ChildWorkflowOnly:         true,
+		})
+	case types.ParentClosePolicyRequestCancel:
+		return c.historyClient.RequestCancelWorkflowExecution(ctx, &types.HistoryRequestCancelWorkflowExecutionRequest{
+			DomainUUID: child.DomainID,
+			CancelRequest: &types.RequestCancelWorkflowExecutionRequest{
+				Domain: domainName,
+				WorkflowExecution: &types.WorkflowExecution{
+					WorkflowID: parentChildInfo.StartedWorkflowID,
+				},
+				Identity:            childParentLinkageIdentity,
+				FirstExecutionRunID: parentChildInfo.StartedRunID,
+			},
+			ExternalWorkflowExecution: parentExecution,
+			ChildWorkflowOnly:         true,
+		})
+	default:
+		return fmt.Errorf("unexpected parent close policy: %v", parentChildInfo.ParentClosePolicy)
+	}
+}
+
+func (c *childParentLinkage) healthy() CheckResult {
+	return CheckResult{
+		CheckResultType: CheckResultTypeHealthy,
+		InvariantName:   c.Name(),
+	}
+}
+
+func (c *childParentLinkage) failed(info string, err error) CheckResult {
+	result := CheckResult{
+		CheckResultType: CheckResultTypeFailed,
+		InvariantName:   c.Name(),
+		Info:            info,
+	}
+	if err != nil {
+		result.InfoDetails = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,374 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	childWorkflowID  = "test-child-workflow-id"
+	childRunID       = "test-child-run-id"
+	parentWorkflowID = "test-parent-workflow-id"
+	parentRunID      = "test-parent-run-id"
+)
+
+func TestChildParentLinkageCheck(t *testing.T) {
+	startedChild := map[int64]*persistence.ChildExecutionInfo{
+		5: {
+			InitiatedID:       5,
+			StartedID:         6,
+			StartedWorkflowID: childWorkflowID,
+			StartedRunID:      childRunID,
+			DomainID:          domainID,
+		},
+	}
+	parentChild := map[int64]*persistence.ChildExecutionInfo{
+		5: {
+			InitiatedID:       5,
+			StartedID:         6,
+			StartedWorkflowID: workflowID,
+			StartedRunID:      runID,
+			DomainID:          domainID,
+			ParentClosePolicy: types.ParentClosePolicyTerminate,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		closed     bool
+		executions map[string]*persistence.WorkflowMutableState
+		getErr     error
+		current    *persistence.GetCurrentExecutionResponse
+		want       CheckResult
+	}{
+		{
+			name:   "closed execution",
+			closed: true,
+			want: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   ChildParentLinkage,
+			},
+		},
+		{
+			name:   "failed to get execution",
+			getErr: errors.New("boom"),
+			want: CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   ChildParentLinkage,
+				Info:            "failed to get concrete execution",
+				InfoDetails:     "boom",
+			},
+		},
+		{
+			name: "pending child exists",
+			executions: map[string]*persistence.WorkflowMutableState{
+				runID:      mutableState(openState, time.Now(), startedChild),
+				childRunID: mutableState(openState, time.Now(), nil),
+			},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   ChildParentLinkage,
+			},
+		},
+		{
+			name: "pending child continued as new",
+			executions: map[string]*persistence.WorkflowMutableState{
+				runID:     mutableState(openState, time.Now(), startedChild),
+				"new-run": continuedRun(childRunID, "", ""),
+			},
+			current: &persistence.GetCurrentExecutionResponse{RunID: "new-run", State: openState},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   ChildParentLinkage,
+			},
+		},
+		{
+			name: "pending child continued as new without first run ID",
+			executions: map[string]*persistence.WorkflowMutableState{
+				runID:     mutableState(openState, time.Now(), startedChild),
+				"new-run": continuedRun("", workflowID, runID),
+			},
+			current: &persistence.GetCurrentExecutionResponse{RunID: "new-run", State: openState},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   ChildParentLinkage,
+			},
+		},
+		{
+			name: "child workflow ID reused by another execution",
+			executions: map[string]*persistence.WorkflowMutableState{
+				runID:     mutableState(openState, time.Now(), startedChild),
+				"new-run": continuedRun("other-run", "", ""),
+			},
+			current: &persistence.GetCurrentExecutionResponse{RunID: "new-run", State: openState},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   ChildParentLinkage,
+				Info:            "open execution has a pending child execution which does not exist",
+				InfoDetails:     "initiatedID: 5, childWorkflowID: test-child-workflow-id, childRunID: test-child-run-id",
+			},
+		},
+		{
+			name: "child workflow ID reused by an execution of another parent",
+			executions: map[string]*persistence.WorkflowMutableState{
+				runID:     mutableState(openState, time.Now(), startedChild),
+				"new-run": continuedRun("", workflowID, "other-parent-run"),
+			},
+			current: &persistence.GetCurrentExecutionResponse{RunID: "new-run", State: openState},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   ChildParentLinkage,
+				Info:            "open execution has a pending child execution which does not exist",
+				InfoDetails:     "initiatedID: 5, childWorkflowID: test-child-workflow-id, childRunID: test-child-run-id",
+			},
+		},
+		{
+			name: "pending child does not exist",
+			executions: map[string]*persistence.WorkflowMutableState{
+				runID: mutableState(openState, time.Now(), startedChild),
+			},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   ChildParentLinkage,
+				Info:            "open execution has a pending child execution which does not exist",
+				InfoDetails:     "initiatedID: 5, childWorkflowID: test-child-workflow-id, childRunID: test-child-run-id",
+			},
+		},
+		{
+			name: "parent is open",
+			executions: map[string]*persistence.WorkflowMutableState{
+				runID:       childOf(mutableState(openState, time.Now(), nil)),
+				parentRunID: mutableState(openState, time.Now(), parentChild),
+			},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   ChildParentLinkage,
+			},
+		},
+		{
+			name: "parent closed recently",
+			executions: map[string]*persistence.WorkflowMutableState{
+				runID:       childOf(mutableState(openState, time.Now(), nil)),
+				parentRunID: mutableState(closedState, time.Now(), parentChild),
+			},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   ChildParentLinkage,
+			},
+		},
+		{
+			name: "parent closed without applying parent close policy",
+			executions: map[string]*persistence.WorkflowMutableState{
+				runID:       childOf(mutableState(openState, time.Now(), nil)),
+				parentRunID: mutableState(closedState, time.Now().Add(-2*parentCloseGracePeriod), parentChild),
+			},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   ChildParentLinkage,
+				Info:            "open child execution has a closed parent which did not apply its parent close policy",
+				InfoDetails:     "parentWorkflowID: test-parent-workflow-id, parentRunID: test-parent-run-id, parentClosePolicy: TERMINATE",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			pr, dc := linkageMocks(ctrl, tc.executions, tc.getErr, tc.current)
+
+			execution := getOpenConcreteExecution()
+			if tc.closed {
+				execution = getClosedConcreteExecution()
+			}
+			result := NewChildParentLinkage(pr, dc, nil, singleShard(pr)).Check(context.Background(), execution)
+			assert.Equal(t, tc.want, result)
+		})
+	}
+}
+
+func TestChildParentLinkageCheckAcrossShards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	pr, dc := linkageMocks(ctrl, map[string]*persistence.WorkflowMutableState{
+		runID: mutableState(openState, time.Now(), map[int64]*persistence.ChildExecutionInfo{
+			5: {InitiatedID: 5, StartedID: 6, StartedWorkflowID: childWorkflowID, StartedRunID: childRunID, DomainID: domainID},
+		}),
+	}, nil, nil)
+	childPR, _ := linkageMocks(ctrl, map[string]*persistence.WorkflowMutableState{
+		childRunID: mutableState(openState, time.Now(), nil),
+	}, nil, nil)
+	workflowRetryer := func(workflowID string) (persistence.Retryer, error) {
+		if workflowID == childWorkflowID {
+			return childPR, nil
+		}
+		return pr, nil
+	}
+
+	result := NewChildParentLinkage(pr, dc, nil, workflowRetryer).Check(context.Background(), getOpenConcreteExecution())
+	assert.Equal(t, CheckResult{
+		CheckResultType: CheckResultTypeHealthy,
+		InvariantName:   ChildParentLinkage,
+	}, result)
+
+	result = NewChildParentLinkage(pr, dc, nil, nil).Check(context.Background(), getOpenConcreteExecution())
+	assert.Equal(t, CheckResultTypeFailed, result.CheckResultType)
+}
+
+func TestChildParentLinkageFix(t *testing.T) {
+	t.Run("missing child is not completed on the parent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		pr, dc := linkageMocks(ctrl, map[string]*persistence.WorkflowMutableState{
+			runID: mutableState(openState, time.Now(), map[int64]*persistence.ChildExecutionInfo{
+				5: {InitiatedID: 5, StartedID: 6, StartedWorkflowID: childWorkflowID, StartedRunID: childRunID},
+			}),
+		}, nil, nil)
+		historyClient := history.NewMockClient(ctrl)
+
+		result := NewChildParentLinkage(pr, dc, historyClient, singleShard(pr)).Fix(context.Background(), getOpenConcreteExecution())
+		assert.Equal(t, FixResultTypeSkipped, result.FixResultType)
+		assert.Equal(t, CheckResultTypeCorrupted, result.CheckResult.CheckResultType)
+	})
+
+	t.Run("parent close policy is applied to child", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		pr, dc := linkageMocks(ctrl, map[string]*persistence.WorkflowMutableState{
+			runID: childOf(mutableState(openState, time.Now(), nil)),
+			parentRunID: mutableState(closedState, time.Now().Add(-2*parentCloseGracePeriod), map[int64]*persistence.ChildExecutionInfo{
+				5: {InitiatedID: 5, StartedID: 6, StartedWorkflowID: workflowID, StartedRunID: runID, ParentClosePolicy: types.ParentClosePolicyRequestCancel},
+			}),
+		}, nil, nil)
+		historyClient := history.NewMockClient(ctrl)
+		historyClient.EXPECT().RequestCancelWorkflowExecution(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *types.HistoryRequestCancelWorkflowExecutionRequest, _ ...interface{}) error {
+				assert.Equal(t, workflowID, req.CancelRequest.WorkflowExecution.WorkflowID)
+				assert.Equal(t, runID, req.CancelRequest.FirstExecutionRunID)
+				assert.Equal(t, parentWorkflowID, req.ExternalWorkflowExecution.WorkflowID)
+				assert.True(t, req.ChildWorkflowOnly)
+				return nil
+			})
+
+		result := NewChildParentLinkage(pr, dc, historyClient, singleShard(pr)).Fix(context.Background(), getOpenConcreteExecution())
+		assert.Equal(t, FixResultTypeFixed, result.FixResultType)
+	})
+
+	t.Run("healthy execution is skipped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		pr, dc := linkageMocks(ctrl, nil, nil, nil)
+
+		result := NewChildParentLinkage(pr, dc, nil, singleShard(pr)).Fix(context.Background(), getClosedConcreteExecution())
+		assert.Equal(t, FixResultTypeSkipped, result.FixResultType)
+	})
+
+	t.Run("fix fails without history client", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		pr, dc := linkageMocks(ctrl, map[string]*persistence.WorkflowMutableState{
+			runID: childOf(mutableState(openState, time.Now(), nil)),
+			parentRunID: mutableState(closedState, time.Now().Add(-2*parentCloseGracePeriod), map[int64]*persistence.ChildExecutionInfo{
+				5: {InitiatedID: 5, StartedID: 6, StartedWorkflowID: workflowID, StartedRunID: runID, ParentClosePolicy: types.ParentClosePolicyTerminate},
+			}),
+		}, nil, nil)
+
+		result := NewChildParentLinkage(pr, dc, nil, singleShard(pr)).Fix(context.Background(), getOpenConcreteExecution())
+		assert.Equal(t, FixResultTypeFailed, result.FixResultType)
+		assert.Equal(t, "failed fix because history client is not available", result.Info)
+	})
+}
+
+func mutableState(
+	state int,
+	lastUpdated time.Time,
+	children map[int64]*persistence.ChildExecutionInfo,
+) *persistence.WorkflowMutableState {
+	return &persistence.WorkflowMutableState{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{
+			State:                state,
+			LastUpdatedTimestamp: lastUpdated,
+		},
+		ChildExecutionInfos: children,
+	}
+}
+
+// childOf makes the execution a child started by the test parent at initiated ID 5
+func childOf(state *persistence.WorkflowMutableState) *persistence.WorkflowMutableState {
+	state.ExecutionInfo.ParentDomainID = domainID
+	state.ExecutionInfo.ParentWorkflowID = parentWorkflowID
+	state.ExecutionInfo.ParentRunID = parentRunID
+	state.ExecutionInfo.InitiatedID = 5
+	return state
+}
+
+// continuedRun returns an open run of the test child workflow ID, continued as new from firstRunID
+func continuedRun(firstRunID, parentWorkflowID, parentRunID string) *persistence.WorkflowMutableState {
+	state := mutableState(openState, time.Now(), nil)
+	state.ExecutionInfo.FirstExecutionRunID = firstRunID
+	state.ExecutionInfo.ParentWorkflowID = parentWorkflowID
+	state.ExecutionInfo.ParentRunID = parentRunID
+	state.ExecutionInfo.InitiatedID = 5
+	return state
+}
+
+// singleShard returns a WorkflowRetryer which serves all workflows from pr
+func singleShard(pr persistence.Retryer) WorkflowRetryer {
+	return func(string) (persistence.Retryer, error) {
+		return pr, nil
+	}
+}
+
+// linkageMocks returns a retryer which serves executions keyed by run ID, missing runs do not exist
+func linkageMocks(
+	ctrl *gomock.Controller,
+	executions map[string]*persistence.WorkflowMutableState,
+	getErr error,
+	current *persistence.GetCurrentExecutionResponse,
+) (persistence.Retryer, cache.DomainCache) {
+	pr := persistence.NewMockRetryer(ctrl)
+	pr.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *persistence.GetWorkflowExecutionRequest) (*persistence.GetWorkflowExecutionResponse, error) {
+			if getErr != nil {
+				return nil, getErr
+			}
+			state, ok := executions[req.Execution.RunID]
+			if !ok {
+				return nil, &types.EntityNotExistsError{}
+			}
+			return &persistence.GetWorkflowExecutionResponse{State: state}, nil
+		}).AnyTimes()
+	pr.EXPECT().GetCurrentExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *persistence.GetCurrentExecutionRequest) (*persistence.GetCurrentExecutionResponse, error) {
+			if current == nil {
+				return nil, &types.EntityNotExistsError{}
+			}
+			return current, nil
+		}).AnyTimes()
+	dc := cache.NewMockDomainCache(ctrl)
+	dc.EXPECT().GetDomainName(gomock.Any()).Return(domainName, nil).AnyTimes()
+	return pr, dc
+}
@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _CollectionName = "CollectionMutableStateCollectionHistoryCollectionDomainCollectionStale"
+const _CollectionName = "CollectionMutableStateCollectionHistoryCollectionDomainCollectionStaleCollectionChildParentCollectionTimerTask"
 
-var _CollectionIndex = [...]uint8{0, 22, 39, 55, 70}
+var _CollectionIndex = [...]uint8{0, 22, 39, 55, 70, 91, 110}
 
-const _CollectionLowerName = "collectionmutablestatecollectionhistorycollectiondomaincollectionstale"
+const _CollectionLowerName = "collectionmutablestatecollectionhistorycollectiondomaincollectionstalecollectionchildparentcollectiontimertask"
 
 func (i Collection) String() string {
 	if i < 0 || i >= Collection(len(_CollectionIndex)-1) {
@@ -28,19 +28,25 @@ func _CollectionNoOp() {
 	_ = x[CollectionHistory-(1)]
 	_ = x[CollectionDomain-(2)]
 	_ = x[CollectionStale-(3)]
+	_ = x[CollectionChildParent-(4)]
+	_ = x[CollectionTimerTask-(5)]
 }
 
-var _CollectionValues = []Collection{CollectionMutableState, CollectionHistory, CollectionDomain, CollectionStale}
+var _CollectionValues = []Collection{CollectionMutableState, CollectionHistory, CollectionDomain, CollectionStale, CollectionChildParent, CollectionTimerTask}
 
 var _CollectionNameToValueMap = map[string]Collection{
-	_CollectionName[0:22]:       CollectionMutableState,
-	_CollectionLowerName[0:22]:  CollectionMutableState,
-	_CollectionName[22:39]:      CollectionHistory,
-	_CollectionLowerName[22:39]: CollectionHistory,
-	_CollectionName[39:55]:      CollectionDomain,
-	_CollectionLowerName[39:55]: CollectionDomain,
-	_CollectionName[55:70]:      CollectionStale,
-	_CollectionLowerName[55:70]: CollectionStale,
+	_CollectionName[0:22]:        CollectionMutableState,
+	_CollectionLowerName[0:22]:   CollectionMutableState,
+	_CollectionName[22:39]:       CollectionHistory,
+	_CollectionLowerName[22:39]:  CollectionHistory,
+	_CollectionName[39:55]:       CollectionDomain,
+	_CollectionLowerName[39:55]:  CollectionDomain,
+	_CollectionName[55:70]:       CollectionStale,
+	_CollectionLowerName[55:70]:  CollectionStale,
+	_CollectionName[70:91]:       CollectionChildParent,
+	_CollectionLowerName[70:91]:  CollectionChildParent,
+	_CollectionName[91:110]:      CollectionTimerTask,
+	_CollectionLowerName[91:110]: CollectionTimerTask,
 }
 
 var _CollectionNames = []string{
@@ -48,6 +54,8 @@ var _CollectionNames = []string{
 	_CollectionName[22:39],
 	_CollectionName[39:55],
 	_CollectionName[55:70],
+	_CollectionName[70:91],
+	_CollectionName[91:110],
 }
 
 // CollectionString retrieves an enum value from the enum constants string name.
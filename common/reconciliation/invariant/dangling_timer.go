@@ -0,0 +1,436 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	// danglingTimerGracePeriod skips executions which were updated recently,
+	// their timer tasks may have been written after the index was loaded.
+	danglingTimerGracePeriod = 10 * time.Minute
+	// timerTaskIndexTTL is how old the timer task index may be before a missing
+	// timer task is double-checked against a freshly loaded index.
+	timerTaskIndexTTL      = 5 * time.Minute
+	timerTaskIndexPageSize = 1000
+	// timerTaskIndexMaxTasks bounds the number of timer tasks loaded into the index of a shard
+	timerTaskIndexMaxTasks = 200000
+	// timerTaskIndexMaxShards bounds the number of shard indexes kept by a TimerTaskIndexCache
+	timerTaskIndexMaxShards = 4
+	// timerTaskStatusNone is the task status of timers and activities without a timer task,
+	// same as execution.TimerTaskStatusNone in the history service
+	timerTaskStatusNone = 0
+)
+
+type (
+	danglingTimer struct {
+		pr            persistence.Retryer
+		dc            cache.DomainCache
+		historyClient history.Client
+		indexes       *TimerTaskIndexCache
+	}
+
+	// TimerTaskIndexCache keeps the timer task index of recently checked shards.
+	// It is shared by dangling timer invariants which are created per execution,
+	// so that the timer queue of a shard is not loaded again for every execution.
+	TimerTaskIndexCache struct {
+		sync.Mutex
+		shards map[int]*timerTaskIndexEntry
+		// uses orders the shard entries by their last use, for eviction
+		uses uint64
+	}
+
+	// timerTaskIndexEntry holds the index of a single shard. Its lock is held while the index
+	// is loaded, so checks of other shards are not blocked and the shard is only loaded once.
+	timerTaskIndexEntry struct {
+		sync.Mutex
+		index   *timerTaskIndex
+		lastUse uint64
+	}
+
+	// timerTaskIndex contains the timer tasks present in the shard's timer queue, per execution
+	timerTaskIndex struct {
+		loadedAt time.Time
+		tasks    map[timerTaskIndexKey]map[timerTaskRef]struct{}
+		// truncated is set when the timer queue has more than timerTaskIndexMaxTasks tasks
+		truncated bool
+	}
+
+	timerTaskIndexKey struct {
+		domainID   string
+		workflowID string
+		runID      string
+	}
+
+	// timerTaskRef identifies the timer or activity a timer task was created for
+	timerTaskRef struct {
+		taskType int
+		eventID  int64
+	}
+)
+
+// NewDanglingTimer returns a new invariant for checking that pending timers and activities
+// of an open execution have a matching task in the shard's timer queue.
+// Without such a task the timer or activity will never fire and the execution is stuck.
+// historyClient is only used by Fix, and may be nil when the invariant is only used for checks.
+// indexes may be nil, in which case the invariant keeps its own index cache.
+func NewDanglingTimer(
+	pr persistence.Retryer, dc cache.DomainCache, historyClient history.Client, indexes *TimerTaskIndexCache,
+) Invariant {
+	if indexes == nil {
+		indexes = NewTimerTaskIndexCache()
+	}
+	return &danglingTimer{
+		pr:            pr,
+		dc:            dc,
+		historyClient: historyClient,
+		indexes:       indexes,
+	}
+}
+
+// NewTimerTaskIndexCache returns an empty cache of shard timer task indexes
+func NewTimerTaskIndexCache() *TimerTaskIndexCache {
+	return &TimerTaskIndexCache{
+		shards: make(map[int]*timerTaskIndexEntry),
+	}
+}
+
+func (d *danglingTimer) Check(
+	ctx context.Context,
+	execution interface{},
+) CheckResult {
+	if checkResult := validateCheckContext(ctx, d.Name()); checkResult != nil {
+		return *checkResult
+	}
+
+	concreteExecution, ok := execution.(*entity.ConcreteExecution)
+	if !ok {
+		return CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   d.Name(),
+			Info:            "failed to check: expected concrete execution",
+		}
+	}
+	if !Open(concreteExecution.State) {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   d.Name(),
+		}
+	}
+	domainName, err := d.dc.GetDomainName(concreteExecution.DomainID)
+	if err != nil {
+		return CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   d.Name(),
+			Info:            "failed to fetch Domain Name",
+			InfoDetails:     err.Error(),
+		}
+	}
+	resp, err := d.pr.GetWorkflowExecution(ctx, &persistence.GetWorkflowExecutionRequest{
+		DomainID: concreteExecution.DomainID,
+		Execution: types.WorkflowExecution{
+			WorkflowID: concreteExecution.WorkflowID,
+			RunID:      concreteExecution.RunID,
+		},
+		DomainName: domainName,
+	})
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			return CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   d.Name(),
+			}
+		}
+		return CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   d.Name(),
+			Info:            "failed to get concrete execution",
+			InfoDetails:     err.Error(),
+		}
+	}
+	state := resp.State
+	if !Open(state.ExecutionInfo.State) ||
+		time.Since(state.ExecutionInfo.LastUpdatedTimestamp) < danglingTimerGracePeriod {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   d.Name(),
+		}
+	}
+
+	var missing []string
+	key := timerTaskIndexKey{
+		domainID:   concreteExecution.DomainID,
+		workflowID: concreteExecution.WorkflowID,
+		runID:      concreteExecution.RunID,
+	}
+	for attempt := 0; attempt < 2; attempt++ {
+		tasks, complete, err := d.getTimerTasks(ctx, concreteExecution.ShardID, key, attempt > 0)
+		if err != nil {
+			return CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   d.Name(),
+				Info:            "failed to load timer tasks",
+				InfoDetails:     err.Error(),
+			}
+		}
+		missing = missingTimerTasks(state, tasks)
+		if len(missing) == 0 {
+			return CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   d.Name(),
+			}
+		}
+		if !complete {
+			return CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   d.Name(),
+				Info:            "failed to check: timer queue of the shard is too large to be indexed",
+				InfoDetails:     fmt.Sprintf("shard %d has more than %d timer tasks", concreteExecution.ShardID, timerTaskIndexMaxTasks),
+			}
+		}
+	}
+	return CheckResult{
+		CheckResultType: CheckResultTypeCorrupted,
+		InvariantName:   d.Name(),
+		Info:            "open execution has pending timers without a timer task",
+		InfoDetails:     fmt.Sprintf("missing timer tasks for: %v", missing),
+	}
+}
+
+func (d *danglingTimer) Fix(
+	ctx context.Context,
+	execution interface{},
+) FixResult {
+	if fixResult := validateFixContext(ctx, d.Name()); fixResult != nil {
+		return *fixResult
+	}
+
+	fixResult, checkResult := checkBeforeFix(ctx, d, execution)
+	if fixResult != nil {
+		return *fixResult
+	}
+	if d.historyClient == nil {
+		return FixResult{
+			FixResultType: FixResultTypeFailed,
+			InvariantName: d.Name(),
+			CheckResult:   *checkResult,
+			Info:          "failed fix because history client is not available",
+		}
+	}
+	concreteExecution := execution.(*entity.ConcreteExecution)
+	domainName, err := d.dc.GetDomainName(concreteExecution.DomainID)
+	if err == nil {
+		// refreshing regenerates all tasks of the execution from its mutable state
+		err = d.historyClient.RefreshWorkflowTasks(ctx, &types.HistoryRefreshWorkflowTasksRequest{
+			DomainUIID: concreteExecution.DomainID,
+			Request: &types.RefreshWorkflowTasksRequest{
+				Domain: domainName,
+				Execution: &types.WorkflowExecution{
+					WorkflowID: concreteExecution.WorkflowID,
+					RunID:      concreteExecution.RunID,
+				},
+			},
+		})
+	}
+	if err != nil {
+		return FixResult{
+			FixResultType: FixResultTypeFailed,
+			InvariantName: d.Name(),
+			CheckResult:   *checkResult,
+			Info:          "failed to refresh workflow tasks",
+			InfoDetails:   err.Error(),
+		}
+	}
+	return FixResult{
+		FixResultType: FixResultTypeFixed,
+		InvariantName: d.Name(),
+		CheckResult:   *checkResult,
+	}
+}
+
+func (d *danglingTimer) Name() Name {
+	return DanglingTimer
+}
+
+// getTimerTasks returns the timer tasks of the execution.
+// The shard's timer queue is loaded once and reused for all executions of the shard,
+// reload forces a fresh load if the current index is older than timerTaskIndexTTL.
+// complete is false when the index was truncated and the execution's tasks may not have been loaded.
+func (d *danglingTimer) getTimerTasks(
+	ctx context.Context,
+	shardID int,
+	key timerTaskIndexKey,
+	reload bool,
+) (tasks map[timerTaskRef]struct{}, complete bool, err error) {
+	entry := d.indexes.entry(shardID)
+	entry.Lock()
+	defer entry.Unlock()
+
+	if entry.index == nil || (reload && time.Since(entry.index.loadedAt) > timerTaskIndexTTL) {
+		index, err := d.loadTimerTaskIndex(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		entry.index = index
+	}
+	tasks, ok := entry.index.tasks[key]
+	return tasks, ok || !entry.index.truncated, nil
+}
+
+// entry returns the index entry of a shard, evicting the least recently used entry when the cache is full
+func (c *TimerTaskIndexCache) entry(shardID int) *timerTaskIndexEntry {
+	c.Lock()
+	defer c.Unlock()
+
+	c.uses++
+	entry, ok := c.shards[shardID]
+	if !ok {
+		if len(c.shards) >= timerTaskIndexMaxShards {
+			oldest := -1
+			for id, cached := range c.shards {
+				if oldest == -1 || cached.lastUse < c.shards[oldest].lastUse {
+					oldest = id
+				}
+			}
+			delete(c.shards, oldest)
+		}
+		entry = &timerTaskIndexEntry{}
+		c.shards[shardID] = entry
+	}
+	entry.lastUse = c.uses
+	return entry
+}
+
+func (d *danglingTimer) loadTimerTaskIndex(ctx context.Context) (*timerTaskIndex, error) {
+	index := &timerTaskIndex{
+		loadedAt: time.Now(),
+		tasks:    make(map[timerTaskIndexKey]map[timerTaskRef]struct{}),
+	}
+	var token []byte
+	loaded := 0
+	for {
+		resp, err := d.pr.GetHistoryTasks(ctx, &persistence.GetHistoryTasksRequest{
+			TaskCategory:        persistence.HistoryTaskCategoryTimer,
+			InclusiveMinTaskKey: persistence.MinimumHistoryTaskKey,
+			ExclusiveMaxTaskKey: persistence.MaximumHistoryTaskKey,
+			PageSize:            timerTaskIndexPageSize,
+			NextPageToken:       token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range resp.Tasks {
+			key := timerTaskIndexKey{
+				domainID:   task.GetDomainID(),
+				workflowID: task.GetWorkflowID(),
+				runID:      task.GetRunID(),
+			}
+			if index.tasks[key] == nil {
+				index.tasks[key] = make(map[timerTaskRef]struct{})
+			}
+			index.tasks[key][newTimerTaskRef(task)] = struct{}{}
+		}
+		loaded += len(resp.Tasks)
+		if len(resp.NextPageToken) == 0 {
+			return index, nil
+		}
+		if loaded >= timerTaskIndexMaxTasks {
+			index.truncated = true
+			return index, nil
+		}
+		token = resp.NextPageToken
+	}
+}
+
+func newTimerTaskRef(task persistence.Task) timerTaskRef {
+	ref := timerTaskRef{taskType: task.GetTaskType()}
+	switch t := task.(type) {
+	case *persistence.UserTimerTask:
+		ref.eventID = t.EventID
+	case *persistence.ActivityTimeoutTask:
+		ref.eventID = t.EventID
+	case *persistence.ActivityRetryTimerTask:
+		ref.eventID = t.EventID
+	}
+	return ref
+}
+
+// missingTimerTasks returns the pending timers and activities in mutable state which have no timer task.
+// A timer task is only created for the earliest timer, and mutable state marks the timers and activities
+// it was created for. Each of them needs a task for its event, and when none is marked
+// the earliest timer has no task either, so nothing of that kind will ever fire.
+func missingTimerTasks(
+	state *persistence.WorkflowMutableState,
+	tasks map[timerTaskRef]struct{},
+) []string {
+	var missing []string
+
+	var unmarkedTimers []string
+	markedTimer := false
+	for _, timer := range state.TimerInfos {
+		name := fmt.Sprintf("user timer %v", timer.TimerID)
+		if timer.TaskStatus == timerTaskStatusNone {
+			unmarkedTimers = append(unmarkedTimers, name)
+			continue
+		}
+		markedTimer = true
+		if _, ok := tasks[timerTaskRef{taskType: persistence.TaskTypeUserTimer, eventID: timer.StartedID}]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if !markedTimer {
+		missing = append(missing, unmarkedTimers...)
+	}
+
+	var unmarkedActivities []string
+	markedActivity := false
+	for _, activity := range state.ActivityInfos {
+		name := fmt.Sprintf("activity %v", activity.ActivityID)
+		if _, ok := tasks[timerTaskRef{taskType: persistence.TaskTypeActivityRetryTimer, eventID: activity.ScheduleID}]; ok {
+			continue
+		}
+		if activity.TimerTaskStatus == timerTaskStatusNone {
+			unmarkedActivities = append(unmarkedActivities, name)
+			continue
+		}
+		markedActivity = true
+		if _, ok := tasks[timerTaskRef{taskType: persistence.TaskTypeActivityTimeout, eventID: activity.ScheduleID}]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if !markedActivity {
+		missing = append(missing, unmarkedActivities...)
+	}
+
+	sort.Strings(missing)
+	return missing
+}
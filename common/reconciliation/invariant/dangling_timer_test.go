@@ -0,0 +1,319 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestDanglingTimerCheck(t *testing.T) {
+	stale := time.Now().Add(-2 * danglingTimerGracePeriod)
+	timerTask := func(taskType int, eventID int64) persistence.Task {
+		info := persistence.WorkflowIdentifier{DomainID: domainID, WorkflowID: workflowID, RunID: runID}
+		switch taskType {
+		case persistence.TaskTypeUserTimer:
+			return &persistence.UserTimerTask{WorkflowIdentifier: info, EventID: eventID}
+		case persistence.TaskTypeActivityRetryTimer:
+			return &persistence.ActivityRetryTimerTask{WorkflowIdentifier: info, EventID: eventID}
+		default:
+			return &persistence.ActivityTimeoutTask{WorkflowIdentifier: info, EventID: eventID}
+		}
+	}
+	unmarked := func(state *persistence.WorkflowMutableState) *persistence.WorkflowMutableState {
+		for _, timer := range state.TimerInfos {
+			timer.TaskStatus = timerTaskStatusNone
+		}
+		for _, activity := range state.ActivityInfos {
+			activity.TimerTaskStatus = timerTaskStatusNone
+		}
+		return state
+	}
+
+	tests := []struct {
+		name  string
+		state *persistence.WorkflowMutableState
+		tasks []persistence.Task
+		want  CheckResult
+	}{
+		{
+			name:  "no pending timers",
+			state: timerState(stale, 0, 0),
+			want: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   DanglingTimer,
+			},
+		},
+		{
+			name:  "recently updated execution",
+			state: timerState(time.Now(), 1, 1),
+			want: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   DanglingTimer,
+			},
+		},
+		{
+			name:  "timers with tasks",
+			state: timerState(stale, 2, 2),
+			tasks: []persistence.Task{timerTask(persistence.TaskTypeUserTimer, 10), timerTask(persistence.TaskTypeActivityTimeout, 20)},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   DanglingTimer,
+			},
+		},
+		{
+			name:  "activity waiting for a retry",
+			state: unmarked(timerState(stale, 0, 1)),
+			tasks: []persistence.Task{timerTask(persistence.TaskTypeActivityRetryTimer, 20)},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   DanglingTimer,
+			},
+		},
+		{
+			name:  "user timer without task",
+			state: timerState(stale, 2, 1),
+			tasks: []persistence.Task{timerTask(persistence.TaskTypeActivityTimeout, 20)},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   DanglingTimer,
+				Info:            "open execution has pending timers without a timer task",
+				InfoDetails:     "missing timer tasks for: [user timer timer-0]",
+			},
+		},
+		{
+			name:  "task of another user timer",
+			state: timerState(stale, 2, 0),
+			tasks: []persistence.Task{timerTask(persistence.TaskTypeUserTimer, 11)},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   DanglingTimer,
+				Info:            "open execution has pending timers without a timer task",
+				InfoDetails:     "missing timer tasks for: [user timer timer-0]",
+			},
+		},
+		{
+			name:  "activity without task",
+			state: timerState(stale, 0, 3),
+			tasks: []persistence.Task{timerTask(persistence.TaskTypeActivityTimeout, 21)},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   DanglingTimer,
+				Info:            "open execution has pending timers without a timer task",
+				InfoDetails:     "missing timer tasks for: [activity activity-0]",
+			},
+		},
+		{
+			name:  "no timer task was created",
+			state: unmarked(timerState(stale, 2, 1)),
+			want: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   DanglingTimer,
+				Info:            "open execution has pending timers without a timer task",
+				InfoDetails:     "missing timer tasks for: [activity activity-0 user timer timer-0 user timer timer-1]",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			pr, dc := danglingTimerMocks(ctrl, tc.state, tc.tasks)
+
+			result := NewDanglingTimer(pr, dc, nil, nil).Check(context.Background(), getOpenConcreteExecution())
+			assert.Equal(t, tc.want, result)
+		})
+	}
+}
+
+func TestDanglingTimerIndexIsLoadedOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	pr := persistence.NewMockRetryer(ctrl)
+	pr.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).
+		Return(&persistence.GetWorkflowExecutionResponse{State: timerState(time.Now().Add(-time.Hour), 1, 0)}, nil).Times(3)
+	pr.EXPECT().GetHistoryTasks(gomock.Any(), gomock.Any()).Return(&persistence.GetHistoryTasksResponse{
+		Tasks: []persistence.Task{&persistence.UserTimerTask{
+			WorkflowIdentifier: persistence.WorkflowIdentifier{DomainID: domainID, WorkflowID: workflowID, RunID: runID},
+			EventID:            10,
+		}},
+		NextPageToken: []byte("next"),
+	}, nil).Times(1)
+	pr.EXPECT().GetHistoryTasks(gomock.Any(), gomock.Any()).Return(&persistence.GetHistoryTasksResponse{}, nil).Times(1)
+	dc := cache.NewMockDomainCache(ctrl)
+	dc.EXPECT().GetDomainName(gomock.Any()).Return(domainName, nil).AnyTimes()
+
+	// invariants created per execution share the index of the shard through the cache
+	indexes := NewTimerTaskIndexCache()
+	for i := 0; i < 3; i++ {
+		iv := NewDanglingTimer(pr, dc, nil, indexes)
+		assert.Equal(t, CheckResultTypeHealthy, iv.Check(context.Background(), getOpenConcreteExecution()).CheckResultType)
+	}
+}
+
+func TestDanglingTimerIndexIsBounded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	page := make([]persistence.Task, timerTaskIndexPageSize)
+	for i := range page {
+		page[i] = &persistence.UserTimerTask{
+			WorkflowIdentifier: persistence.WorkflowIdentifier{DomainID: domainID, WorkflowID: "other-workflow-id", RunID: runID},
+		}
+	}
+	pr := persistence.NewMockRetryer(ctrl)
+	pr.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).
+		Return(&persistence.GetWorkflowExecutionResponse{State: timerState(time.Now().Add(-time.Hour), 1, 0)}, nil).AnyTimes()
+	pr.EXPECT().GetHistoryTasks(gomock.Any(), gomock.Any()).
+		Return(&persistence.GetHistoryTasksResponse{Tasks: page, NextPageToken: []byte("next")}, nil).
+		Times(timerTaskIndexMaxTasks / timerTaskIndexPageSize)
+	dc := cache.NewMockDomainCache(ctrl)
+	dc.EXPECT().GetDomainName(gomock.Any()).Return(domainName, nil).AnyTimes()
+
+	result := NewDanglingTimer(pr, dc, nil, nil).Check(context.Background(), getOpenConcreteExecution())
+	assert.Equal(t, CheckResultTypeFailed, result.CheckResultType)
+	assert.Equal(t, "failed to check: timer queue of the shard is too large to be indexed", result.Info)
+}
+
+func TestTimerTaskIndexCacheEvictsLeastRecentlyUsedShard(t *testing.T) {
+	indexes := NewTimerTaskIndexCache()
+	for shardID := 0; shardID < timerTaskIndexMaxShards; shardID++ {
+		indexes.entry(shardID)
+	}
+	indexes.entry(0)
+	indexes.entry(timerTaskIndexMaxShards)
+	assert.Len(t, indexes.shards, timerTaskIndexMaxShards)
+	assert.Contains(t, indexes.shards, 0)
+	assert.NotContains(t, indexes.shards, 1)
+	assert.Contains(t, indexes.shards, timerTaskIndexMaxShards)
+}
+
+func TestDanglingTimerIndexLoadDoesNotBlockOtherShards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	loading := make(chan struct{})
+	release := make(chan struct{})
+	blocked := persistence.NewMockRetryer(ctrl)
+	blocked.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).
+		Return(&persistence.GetWorkflowExecutionResponse{State: timerState(time.Now().Add(-time.Hour), 1, 0)}, nil).Times(1)
+	blocked.EXPECT().GetHistoryTasks(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, *persistence.GetHistoryTasksRequest) (*persistence.GetHistoryTasksResponse, error) {
+			close(loading)
+			<-release
+			return &persistence.GetHistoryTasksResponse{}, nil
+		}).Times(1)
+	dc := cache.NewMockDomainCache(ctrl)
+	dc.EXPECT().GetDomainName(gomock.Any()).Return(domainName, nil).AnyTimes()
+
+	indexes := NewTimerTaskIndexCache()
+	done := make(chan CheckResult)
+	go func() {
+		done <- NewDanglingTimer(blocked, dc, nil, indexes).Check(context.Background(), getOpenConcreteExecution())
+	}()
+	<-loading
+
+	// a check on another shard neither waits for nor reuses the index being loaded
+	other := getOpenConcreteExecution()
+	other.ShardID = shardID + 1
+	otherPR := persistence.NewMockRetryer(ctrl)
+	otherPR.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).
+		Return(&persistence.GetWorkflowExecutionResponse{State: timerState(time.Now().Add(-time.Hour), 1, 0)}, nil).Times(1)
+	otherPR.EXPECT().GetHistoryTasks(gomock.Any(), gomock.Any()).Return(&persistence.GetHistoryTasksResponse{
+		Tasks: []persistence.Task{&persistence.UserTimerTask{
+			WorkflowIdentifier: persistence.WorkflowIdentifier{DomainID: domainID, WorkflowID: workflowID, RunID: runID},
+			EventID:            10,
+		}},
+	}, nil).Times(1)
+	assert.Equal(t, CheckResultTypeHealthy, NewDanglingTimer(otherPR, dc, nil, indexes).Check(context.Background(), other).CheckResultType)
+
+	close(release)
+	assert.Equal(t, CheckResultTypeCorrupted, (<-done).CheckResultType)
+}
+
+func TestDanglingTimerFix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	pr, dc := danglingTimerMocks(ctrl, timerState(time.Now().Add(-time.Hour), 1, 0), nil)
+	historyClient := history.NewMockClient(ctrl)
+	historyClient.EXPECT().RefreshWorkflowTasks(gomock.Any(), &types.HistoryRefreshWorkflowTasksRequest{
+		DomainUIID: domainID,
+		Request: &types.RefreshWorkflowTasksRequest{
+			Domain: domainName,
+			Execution: &types.WorkflowExecution{
+				WorkflowID: workflowID,
+				RunID:      runID,
+			},
+		},
+	}).Return(nil).Times(1)
+
+	result := NewDanglingTimer(pr, dc, historyClient, nil).Fix(context.Background(), getOpenConcreteExecution())
+	assert.Equal(t, FixResultTypeFixed, result.FixResultType)
+	assert.Equal(t, CheckResultTypeCorrupted, result.CheckResult.CheckResultType)
+
+	result = NewDanglingTimer(pr, dc, nil, nil).Fix(context.Background(), getOpenConcreteExecution())
+	assert.Equal(t, FixResultTypeFailed, result.FixResultType)
+}
+
+func timerState(lastUpdated time.Time, timers int, activities int) *persistence.WorkflowMutableState {
+	state := &persistence.WorkflowMutableState{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{
+			State:                openState,
+			LastUpdatedTimestamp: lastUpdated,
+		},
+		TimerInfos:    make(map[string]*persistence.TimerInfo),
+		ActivityInfos: make(map[int64]*persistence.ActivityInfo),
+	}
+	// only the first timer and activity are marked with a timer task, like the earliest timer of a workflow
+	for i := 0; i < timers; i++ {
+		timer := &persistence.TimerInfo{TimerID: fmt.Sprintf("timer-%d", i), StartedID: int64(10 + i)}
+		if i == 0 {
+			timer.TaskStatus = 1
+		}
+		state.TimerInfos[timer.TimerID] = timer
+	}
+	for i := 0; i < activities; i++ {
+		activity := &persistence.ActivityInfo{ActivityID: fmt.Sprintf("activity-%d", i), ScheduleID: int64(20 + i)}
+		if i == 0 {
+			activity.TimerTaskStatus = 1
+		}
+		state.ActivityInfos[activity.ScheduleID] = activity
+	}
+	return state
+}
+
+func danglingTimerMocks(
+	ctrl *gomock.Controller,
+	state *persistence.WorkflowMutableState,
+	tasks []persistence.Task,
+) (persistence.Retryer, cache.DomainCache) {
+	pr := persistence.NewMockRetryer(ctrl)
+	pr.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).
+		Return(&persistence.GetWorkflowExecutionResponse{State: state}, nil).AnyTimes()
+	pr.EXPECT().GetHistoryTasks(gomock.Any(), gomock.Any()).
+		Return(&persistence.GetHistoryTasksResponse{Tasks: tasks}, nil).AnyTimes()
+	dc := cache.NewMockDomainCache(ctrl)
+	dc.EXPECT().GetDomainName(gomock.Any()).Return(domainName, nil).AnyTimes()
+	return pr, dc
+}
@@ -57,6 +57,20 @@ const (
 	// implying a failed cleanup / lost timers / etc of some kind.
 	StaleWorkflow Name = "stale_workflow"
 
+	// ChildParentLinkage asserts that open parent and child executions point at each other correctly
+	ChildParentLinkage Name = "child_parent_linkage"
+	// DanglingTimer asserts that pending timers and activities of an open execution have a timer task
+	DanglingTimer Name = "dangling_timer"
+
+	// VisibilityConsistency asserts that visibility records of an execution match its state
+	VisibilityConsistency Name = "visibility_consistency"
+	// VisibilityRecordMissing is reported by VisibilityConsistency when an execution has no visibility record
+	VisibilityRecordMissing Name = "visibility_record_missing"
+	// VisibilityRecordClosed is reported by VisibilityConsistency when an open execution shows as closed in visibility
+	VisibilityRecordClosed Name = "visibility_record_closed"
+	// VisibilityCloseRecordMissing is reported by VisibilityConsistency when a closed execution shows as open in visibility
+	VisibilityCloseRecordMissing Name = "visibility_close_record_missing"
+
 	// CollectionMutableState is the collection of invariants relating to mutable state
 	CollectionMutableState Collection = 0
 	// CollectionHistory is the collection  of invariants relating to history
@@ -65,6 +79,10 @@ const (
 	CollectionDomain Collection = 2
 	// CollectionStale contains the stale workflow scanner
 	CollectionStale Collection = 3
+	// CollectionChildParent contains the parent and child execution linkage invariant
+	CollectionChildParent Collection = 4
+	// CollectionTimerTask contains the dangling timer invariant
+	CollectionTimerTask Collection = 5
 )
 
 type (
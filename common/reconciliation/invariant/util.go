@@ -24,12 +24,42 @@ package invariant
 
 import (
 	"context"
+	"sync"
 
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/reconciliation/entity"
 )
 
+// WorkflowRetryer returns the persistence retryer of the history shard which owns the workflow
+type WorkflowRetryer func(workflowID string) (persistence.Retryer, error)
+
+// NewWorkflowRetryer returns a WorkflowRetryer which resolves the shard of a workflow and
+// creates the retryer of that shard with newRetryer. Retryers are created once per shard.
+func NewWorkflowRetryer(
+	numHistoryShards int,
+	newRetryer func(shardID int) (persistence.Retryer, error),
+) WorkflowRetryer {
+	var lock sync.Mutex
+	retryers := make(map[int]persistence.Retryer)
+	return func(workflowID string) (persistence.Retryer, error) {
+		shardID := common.WorkflowIDToHistoryShard(workflowID, numHistoryShards)
+
+		lock.Lock()
+		defer lock.Unlock()
+		if pr, ok := retryers[shardID]; ok {
+			return pr, nil
+		}
+		pr, err := newRetryer(shardID)
+		if err != nil {
+			return nil, err
+		}
+		retryers[shardID] = pr
+		return pr, nil
+	}
+}
+
 func checkBeforeFix(
 	ctx context.Context,
 	invariant Invariant,
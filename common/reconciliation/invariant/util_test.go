@@ -207,3 +207,26 @@ func (s *UtilSuite) TestExecutionStillExists() {
 		}
 	}
 }
+
+func (s *UtilSuite) TestWorkflowRetryer() {
+	ctrl := gomock.NewController(s.T())
+	numHistoryShards := 16
+	var created []int
+	workflowRetryer := NewWorkflowRetryer(numHistoryShards, func(shardID int) (persistence.Retryer, error) {
+		created = append(created, shardID)
+		return persistence.NewMockRetryer(ctrl), nil
+	})
+
+	first, err := workflowRetryer("workflow-id")
+	s.NoError(err)
+	second, err := workflowRetryer("workflow-id")
+	s.NoError(err)
+	s.True(first == second)
+	s.Equal([]int{common.WorkflowIDToHistoryShard("workflow-id", numHistoryShards)}, created)
+
+	workflowRetryer = NewWorkflowRetryer(numHistoryShards, func(int) (persistence.Retryer, error) {
+		return nil, errors.New("boom")
+	})
+	_, err = workflowRetryer("workflow-id")
+	s.Error(err)
+}
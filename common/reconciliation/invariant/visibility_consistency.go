@@ -0,0 +1,279 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package invariant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/visibility"
+)
+
+// visibilityGracePeriod skips executions which were updated recently,
+// their visibility records are written asynchronously and may not be indexed yet.
+const visibilityGracePeriod = 10 * time.Minute
+
+type (
+	visibilityConsistency struct {
+		pr                persistence.Retryer
+		dc                cache.DomainCache
+		visibilityManager persistence.VisibilityManager
+		stores            []string
+	}
+
+	visibilityMismatch struct {
+		store    string
+		category Name
+	}
+)
+
+// NewVisibilityConsistency returns a new invariant for checking that the visibility record of an execution
+// in each of the given visibility stores (e.g. "es" and "pinot") exists and agrees on whether the execution is closed.
+// Corrupted results are reported under the name of the mismatch category, see VisibilityRecordMissing,
+// VisibilityRecordClosed and VisibilityCloseRecordMissing, so scan reports break mismatches down by category.
+// Fix republishes the record through visibilityManager, so it must be able to write when the invariant is used for fixes.
+func NewVisibilityConsistency(
+	pr persistence.Retryer,
+	dc cache.DomainCache,
+	visibilityManager persistence.VisibilityManager,
+	stores []string,
+) Invariant {
+	return &visibilityConsistency{
+		pr:                pr,
+		dc:                dc,
+		visibilityManager: visibilityManager,
+		stores:            stores,
+	}
+}
+
+func (v *visibilityConsistency) Check(
+	ctx context.Context,
+	execution interface{},
+) CheckResult {
+	if checkResult := validateCheckContext(ctx, v.Name()); checkResult != nil {
+		return *checkResult
+	}
+
+	concreteExecution, ok := execution.(*entity.ConcreteExecution)
+	if !ok {
+		return CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   v.Name(),
+			Info:            "failed to check: expected concrete execution",
+		}
+	}
+	if !visible(concreteExecution.State) {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   v.Name(),
+		}
+	}
+	if v.visibilityManager == nil {
+		return CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   v.Name(),
+			Info:            "failed to check: visibility manager is not available",
+		}
+	}
+	domainName, err := v.dc.GetDomainName(concreteExecution.DomainID)
+	if err != nil {
+		return CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   v.Name(),
+			Info:            "failed to fetch Domain Name",
+			InfoDetails:     err.Error(),
+		}
+	}
+	resp, err := v.pr.GetWorkflowExecution(ctx, &persistence.GetWorkflowExecutionRequest{
+		DomainID: concreteExecution.DomainID,
+		Execution: types.WorkflowExecution{
+			WorkflowID: concreteExecution.WorkflowID,
+			RunID:      concreteExecution.RunID,
+		},
+		DomainName: domainName,
+	})
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			return CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   v.Name(),
+			}
+		}
+		return CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   v.Name(),
+			Info:            "failed to get concrete execution",
+			InfoDetails:     err.Error(),
+		}
+	}
+	executionInfo := resp.State.ExecutionInfo
+	if !visible(executionInfo.State) || time.Since(executionInfo.LastUpdatedTimestamp) < visibilityGracePeriod {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   v.Name(),
+		}
+	}
+
+	var mismatches []visibilityMismatch
+	for _, store := range v.stores {
+		record, err := v.getRecord(ctx, store, domainName, executionInfo)
+		if err != nil {
+			return CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   v.Name(),
+				Info:            fmt.Sprintf("failed to get visibility record from %v", store),
+				InfoDetails:     err.Error(),
+			}
+		}
+		if category := mismatchCategory(Open(executionInfo.State), record); category != "" {
+			mismatches = append(mismatches, visibilityMismatch{store: store, category: category})
+		}
+	}
+	if len(mismatches) == 0 {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   v.Name(),
+		}
+	}
+	details := make([]string, 0, len(mismatches))
+	for _, mismatch := range mismatches {
+		details = append(details, fmt.Sprintf("%v: %v", mismatch.store, mismatch.category))
+	}
+	return CheckResult{
+		CheckResultType: CheckResultTypeCorrupted,
+		InvariantName:   mismatches[0].category,
+		Info:            "visibility record does not match execution",
+		InfoDetails:     strings.Join(details, ", "),
+	}
+}
+
+func (v *visibilityConsistency) Fix(
+	ctx context.Context,
+	execution interface{},
+) FixResult {
+	if fixResult := validateFixContext(ctx, v.Name()); fixResult != nil {
+		return *fixResult
+	}
+
+	fixResult, checkResult := checkBeforeFix(ctx, v, execution)
+	if fixResult != nil {
+		return *fixResult
+	}
+	concreteExecution := execution.(*entity.ConcreteExecution)
+	if err := v.republish(ctx, concreteExecution); err != nil {
+		return FixResult{
+			FixResultType: FixResultTypeFailed,
+			InvariantName: v.Name(),
+			CheckResult:   *checkResult,
+			Info:          "failed to republish visibility record",
+			InfoDetails:   err.Error(),
+		}
+	}
+	return FixResult{
+		FixResultType: FixResultTypeFixed,
+		InvariantName: v.Name(),
+		CheckResult:   *checkResult,
+	}
+}
+
+func (v *visibilityConsistency) Name() Name {
+	return VisibilityConsistency
+}
+
+// republish writes the visibility record of the execution again from its mutable state, through the visibility manager
+// rather than history, so records are also fixed in clusters where the domain of the execution is not active
+func (v *visibilityConsistency) republish(ctx context.Context, concreteExecution *entity.ConcreteExecution) error {
+	domainEntry, err := v.dc.GetDomainByID(concreteExecution.DomainID)
+	if err != nil {
+		return err
+	}
+	resp, err := v.pr.GetWorkflowExecution(ctx, &persistence.GetWorkflowExecutionRequest{
+		DomainID: concreteExecution.DomainID,
+		Execution: types.WorkflowExecution{
+			WorkflowID: concreteExecution.WorkflowID,
+			RunID:      concreteExecution.RunID,
+		},
+		DomainName: domainEntry.GetInfo().Name,
+	})
+	if err != nil {
+		return err
+	}
+	return visibility.Republish(ctx, v.visibilityManager, v.pr, &visibility.RepublishRequest{
+		DomainName:       domainEntry.GetInfo().Name,
+		NumClusters:      int16(len(domainEntry.GetReplicationConfig().Clusters)),
+		RetentionDays:    domainEntry.GetRetentionDays(concreteExecution.WorkflowID),
+		ShardID:          concreteExecution.ShardID,
+		ExecutionInfo:    resp.State.ExecutionInfo,
+		VersionHistories: resp.State.VersionHistories,
+	})
+}
+
+// getRecord returns the visibility record of the execution in the given store, or nil if there is none
+func (v *visibilityConsistency) getRecord(
+	ctx context.Context,
+	store string,
+	domainName string,
+	executionInfo *persistence.WorkflowExecutionInfo,
+) (*types.WorkflowExecutionInfo, error) {
+	resp, err := v.visibilityManager.ListWorkflowExecutions(
+		context.WithValue(ctx, persistence.ContextKey, store),
+		&persistence.ListWorkflowExecutionsByQueryRequest{
+			DomainUUID: executionInfo.DomainID,
+			Domain:     domainName,
+			PageSize:   10,
+			Query:      fmt.Sprintf("RunID = '%v'", executionInfo.RunID),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range resp.Executions {
+		if record.GetExecution().GetWorkflowID() == executionInfo.WorkflowID {
+			return record, nil
+		}
+	}
+	return nil, nil
+}
+
+// mismatchCategory returns the category of mismatch between an execution and its visibility record, if any
+func mismatchCategory(open bool, record *types.WorkflowExecutionInfo) Name {
+	switch {
+	case record == nil:
+		return VisibilityRecordMissing
+	case open && record.CloseStatus != nil:
+		return VisibilityRecordClosed
+	case !open && record.CloseStatus == nil:
+		return VisibilityCloseRecordMissing
+	default:
+		return ""
+	}
+}
+
+// visible returns true if executions in the given state have visibility records,
+// zombie, void and corrupted executions are not checked
+func visible(state int) bool {
+	return Open(state) || state == persistence.WorkflowStateCompleted
+}
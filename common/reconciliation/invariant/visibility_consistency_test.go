@@ -0,0 +1,256 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package invariant
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestVisibilityConsistencyCheck(t *testing.T) {
+	stale := time.Now().Add(-2 * visibilityGracePeriod)
+	openRecord := &types.WorkflowExecutionInfo{
+		Execution: &types.WorkflowExecution{WorkflowID: workflowID, RunID: runID},
+	}
+	closedRecord := &types.WorkflowExecutionInfo{
+		Execution:   &types.WorkflowExecution{WorkflowID: workflowID, RunID: runID},
+		CloseStatus: types.WorkflowExecutionCloseStatusCompleted.Ptr(),
+	}
+	otherWorkflowRecord := &types.WorkflowExecutionInfo{
+		Execution: &types.WorkflowExecution{WorkflowID: "other-workflow-id", RunID: runID},
+	}
+
+	tests := []struct {
+		name        string
+		execution   *entity.ConcreteExecution
+		state       int
+		lastUpdated time.Time
+		records     map[string][]*types.WorkflowExecutionInfo
+		listErr     error
+		want        CheckResult
+	}{
+		{
+			name:      "zombie execution is not checked",
+			execution: &entity.ConcreteExecution{Execution: entity.Execution{State: persistence.WorkflowStateZombie}},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   VisibilityConsistency,
+			},
+		},
+		{
+			name:        "recently updated execution",
+			execution:   getOpenConcreteExecution(),
+			state:       openState,
+			lastUpdated: time.Now(),
+			want: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   VisibilityConsistency,
+			},
+		},
+		{
+			name:        "open execution with open records",
+			execution:   getOpenConcreteExecution(),
+			state:       openState,
+			lastUpdated: stale,
+			records:     map[string][]*types.WorkflowExecutionInfo{"es": {openRecord}, "pinot": {openRecord}},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   VisibilityConsistency,
+			},
+		},
+		{
+			name:        "closed execution with closed records",
+			execution:   getClosedConcreteExecution(),
+			state:       closedState,
+			lastUpdated: stale,
+			records:     map[string][]*types.WorkflowExecutionInfo{"es": {closedRecord}, "pinot": {closedRecord}},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   VisibilityConsistency,
+			},
+		},
+		{
+			name:        "record missing from one store",
+			execution:   getOpenConcreteExecution(),
+			state:       openState,
+			lastUpdated: stale,
+			records:     map[string][]*types.WorkflowExecutionInfo{"es": {openRecord}, "pinot": {otherWorkflowRecord}},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   VisibilityRecordMissing,
+				Info:            "visibility record does not match execution",
+				InfoDetails:     "pinot: visibility_record_missing",
+			},
+		},
+		{
+			name:        "open execution shows as closed",
+			execution:   getOpenConcreteExecution(),
+			state:       openState,
+			lastUpdated: stale,
+			records:     map[string][]*types.WorkflowExecutionInfo{"es": {closedRecord}, "pinot": {openRecord}},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   VisibilityRecordClosed,
+				Info:            "visibility record does not match execution",
+				InfoDetails:     "es: visibility_record_closed",
+			},
+		},
+		{
+			name:        "close records missing",
+			execution:   getClosedConcreteExecution(),
+			state:       closedState,
+			lastUpdated: stale,
+			records:     map[string][]*types.WorkflowExecutionInfo{"es": {openRecord}, "pinot": {openRecord}},
+			want: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   VisibilityCloseRecordMissing,
+				Info:            "visibility record does not match execution",
+				InfoDetails:     "es: visibility_close_record_missing, pinot: visibility_close_record_missing",
+			},
+		},
+		{
+			name:        "visibility store error",
+			execution:   getOpenConcreteExecution(),
+			state:       openState,
+			lastUpdated: stale,
+			listErr:     errors.New("store unavailable"),
+			want: CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   VisibilityConsistency,
+				Info:            "failed to get visibility record from es",
+				InfoDetails:     "store unavailable",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			pr, dc := visibilityConsistencyMocks(ctrl, tc.state, tc.lastUpdated)
+			visibilityManager := persistence.NewMockVisibilityManager(ctrl)
+			visibilityManager.EXPECT().ListWorkflowExecutions(gomock.Any(), &persistence.ListWorkflowExecutionsByQueryRequest{
+				DomainUUID: domainID,
+				Domain:     domainName,
+				PageSize:   10,
+				Query:      "RunID = '" + runID + "'",
+			}).DoAndReturn(func(ctx context.Context, _ *persistence.ListWorkflowExecutionsByQueryRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+				if tc.listErr != nil {
+					return nil, tc.listErr
+				}
+				return &persistence.ListWorkflowExecutionsResponse{
+					Executions: tc.records[ctx.Value(persistence.ContextKey).(string)],
+				}, nil
+			}).AnyTimes()
+
+			iv := NewVisibilityConsistency(pr, dc, visibilityManager, []string{"es", "pinot"})
+			assert.Equal(t, tc.want, iv.Check(context.Background(), tc.execution))
+		})
+	}
+}
+
+func TestVisibilityConsistencyFix(t *testing.T) {
+	tests := []struct {
+		name      string
+		recordErr error
+		want      FixResultType
+	}{
+		{
+			name: "record republished",
+			want: FixResultTypeFixed,
+		},
+		{
+			name:      "record fails to be republished",
+			recordErr: errors.New("store unavailable"),
+			want:      FixResultTypeFailed,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			pr, dc := visibilityConsistencyMocks(ctrl, openState, time.Now().Add(-time.Hour))
+			// the domain is active in another cluster, records are republished without going through history
+			dc.EXPECT().GetDomainByID(domainID).Return(cache.NewGlobalDomainCacheEntryForTest(
+				&persistence.DomainInfo{ID: domainID, Name: domainName},
+				&persistence.DomainConfig{Retention: 3},
+				&persistence.DomainReplicationConfig{
+					ActiveClusterName: "other-cluster",
+					Clusters: []*persistence.ClusterReplicationConfig{
+						{ClusterName: "current-cluster"},
+						{ClusterName: "other-cluster"},
+					},
+				},
+				1,
+			), nil).Times(1)
+			pr.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).Return(&persistence.ReadHistoryBranchResponse{
+				HistoryEvents: []*types.HistoryEvent{{
+					ID:                                      constants.FirstEventID,
+					WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{},
+				}},
+			}, nil).Times(1)
+			visibilityManager := persistence.NewMockVisibilityManager(ctrl)
+			visibilityManager.EXPECT().ListWorkflowExecutions(gomock.Any(), gomock.Any()).
+				Return(&persistence.ListWorkflowExecutionsResponse{}, nil).AnyTimes()
+			visibilityManager.EXPECT().RecordWorkflowExecutionStarted(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, request *persistence.RecordWorkflowExecutionStartedRequest) error {
+					assert.Equal(t, domainName, request.Domain)
+					assert.Equal(t, types.WorkflowExecution{WorkflowID: workflowID, RunID: runID}, request.Execution)
+					assert.Equal(t, int16(2), request.NumClusters)
+					assert.Equal(t, int16(shardID), request.ShardID)
+					return tc.recordErr
+				}).Times(1)
+
+			result := NewVisibilityConsistency(pr, dc, visibilityManager, []string{"es"}).Fix(context.Background(), getOpenConcreteExecution())
+			assert.Equal(t, tc.want, result.FixResultType)
+			assert.Equal(t, VisibilityConsistency, result.InvariantName)
+			assert.Equal(t, VisibilityRecordMissing, result.CheckResult.InvariantName)
+		})
+	}
+}
+
+func visibilityConsistencyMocks(
+	ctrl *gomock.Controller,
+	state int,
+	lastUpdated time.Time,
+) (*persistence.MockRetryer, *cache.MockDomainCache) {
+	pr := persistence.NewMockRetryer(ctrl)
+	pr.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).
+		Return(&persistence.GetWorkflowExecutionResponse{State: &persistence.WorkflowMutableState{
+			ExecutionInfo: &persistence.WorkflowExecutionInfo{
+				DomainID:             domainID,
+				WorkflowID:           workflowID,
+				RunID:                runID,
+				State:                state,
+				LastUpdatedTimestamp: lastUpdated,
+			},
+		}}, nil).AnyTimes()
+	dc := cache.NewMockDomainCache(ctrl)
+	dc.EXPECT().GetDomainName(gomock.Any()).Return(domainName, nil).AnyTimes()
+	return pr, dc
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package reconciliation
+
+import (
+	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/reconciliation/invariant"
+)
+
+type (
+	// TargetedScanParams selects the executions scanned by a targeted scan.
+	// Executions are selected from Domain by either WorkflowIDs (their current runs),
+	// or by a visibility Query, an empty query selects every execution of the domain.
+	TargetedScanParams struct {
+		Domain      string
+		Query       string
+		WorkflowIDs []string
+		// Collections are the invariant collections to check, see invariant.CollectionStrings
+		Collections []string
+		// Fix runs the fixes of the invariants on corrupted executions
+		Fix bool
+		// MaxExecutions stops the scan after that many executions, missing ones included, 0 means no limit
+		MaxExecutions int
+		// Progress is set by the scan when it continues as new, callers leave it empty
+		Progress *TargetedScanProgress `json:",omitempty"`
+	}
+
+	// TargetedScanProgress carries the report and the position of a targeted scan into its next run
+	TargetedScanProgress struct {
+		Report        TargetedScanReport
+		NextPageToken []byte
+		Offset        int
+	}
+
+	// TargetedScanReport is the outcome of a targeted scan
+	TargetedScanReport struct {
+		Params    TargetedScanParams
+		Completed bool
+		Scanned   int
+		Healthy   int
+		Corrupted int
+		Failed    int
+		Fixed     int
+		// Missing counts executions which were selected but did not exist anymore when scanned
+		Missing int
+		// Executions lists executions which were not healthy, up to TargetedScanMaxReportedExecutions
+		Executions []TargetedScanExecution
+		Truncated  bool
+	}
+
+	// TargetedScanExecution is the result of scanning a single execution
+	TargetedScanExecution struct {
+		Execution   entity.Execution
+		CheckResult invariant.ManagerCheckResult
+		FixResult   *invariant.ManagerFixResult `json:",omitempty"`
+	}
+)
+
+// TargetedScanMaxReportedExecutions caps the executions listed in a report, to keep it within blob size limits
+const TargetedScanMaxReportedExecutions = 1000
+
+// Add records the result of scanning an execution in the report
+func (r *TargetedScanReport) Add(execution TargetedScanExecution) {
+	r.Scanned++
+	switch execution.CheckResult.CheckResultType {
+	case invariant.CheckResultTypeHealthy:
+		r.Healthy++
+		return
+	case invariant.CheckResultTypeCorrupted:
+		r.Corrupted++
+	case invariant.CheckResultTypeFailed:
+		r.Failed++
+	}
+	if execution.FixResult != nil && execution.FixResult.FixResultType == invariant.FixResultTypeFixed {
+		r.Fixed++
+	}
+	if len(r.Executions) >= TargetedScanMaxReportedExecutions {
+		r.Truncated = true
+		return
+	}
+	r.Executions = append(r.Executions, execution)
+}
+
+// Selected is the number of executions the scan went through, whether they still existed or not
+func (r *TargetedScanReport) Selected() int {
+	return r.Scanned + r.Missing
+}
+
+// Merge adds the results of a partial report to the report
+func (r *TargetedScanReport) Merge(page TargetedScanReport) {
+	r.Scanned += page.Scanned
+	r.Healthy += page.Healthy
+	r.Corrupted += page.Corrupted
+	r.Failed += page.Failed
+	r.Fixed += page.Fixed
+	r.Missing += page.Missing
+	r.Truncated = r.Truncated || page.Truncated
+	for _, execution := range page.Executions {
+		if len(r.Executions) >= TargetedScanMaxReportedExecutions {
+			r.Truncated = true
+			break
+		}
+		r.Executions = append(r.Executions, execution)
+	}
+}
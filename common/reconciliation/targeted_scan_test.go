@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package reconciliation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/reconciliation/invariant"
+)
+
+func TestTargetedScanReport(t *testing.T) {
+	execution := func(checkResultType invariant.CheckResultType, fixResultType invariant.FixResultType) TargetedScanExecution {
+		result := TargetedScanExecution{
+			CheckResult: invariant.ManagerCheckResult{CheckResultType: checkResultType},
+		}
+		if fixResultType != "" {
+			result.FixResult = &invariant.ManagerFixResult{FixResultType: fixResultType}
+		}
+		return result
+	}
+
+	page := TargetedScanReport{}
+	page.Add(execution(invariant.CheckResultTypeHealthy, ""))
+	page.Add(execution(invariant.CheckResultTypeCorrupted, invariant.FixResultTypeFixed))
+	page.Add(execution(invariant.CheckResultTypeFailed, ""))
+	assert.Equal(t, 3, page.Scanned)
+	assert.Equal(t, 1, page.Healthy)
+	assert.Equal(t, 1, page.Corrupted)
+	assert.Equal(t, 1, page.Failed)
+	assert.Equal(t, 1, page.Fixed)
+	assert.Len(t, page.Executions, 2, "healthy executions are not listed")
+
+	report := TargetedScanReport{}
+	for i := 0; i < TargetedScanMaxReportedExecutions; i++ {
+		report.Add(execution(invariant.CheckResultTypeCorrupted, ""))
+	}
+	assert.False(t, report.Truncated)
+	report.Merge(page)
+	assert.Equal(t, TargetedScanMaxReportedExecutions+3, report.Scanned)
+	assert.Equal(t, TargetedScanMaxReportedExecutions+1, report.Corrupted)
+	assert.Len(t, report.Executions, TargetedScanMaxReportedExecutions)
+	assert.True(t, report.Truncated)
+}
@@ -47,10 +47,12 @@ type (
 		DBVisibilityListMaxQPS                      dynamicproperties.IntPropertyFnWithDomainFilter `yaml:"-" json:"-"`
 
 		// configs for es visibility
-		ESIndexMaxResultWindow          dynamicproperties.IntPropertyFn `yaml:"-" json:"-"`
-		ValidSearchAttributes           dynamicproperties.MapPropertyFn `yaml:"-" json:"-"`
-		PinotOptimizedQueryColumns      dynamicproperties.MapPropertyFn `yaml:"-" json:"-"`
-		SearchAttributesHiddenValueKeys dynamicproperties.MapPropertyFn `yaml:"-" json:"-"`
+		ESIndexMaxResultWindow          dynamicproperties.IntPropertyFn                 `yaml:"-" json:"-"`
+		ValidSearchAttributes           dynamicproperties.MapPropertyFn                 `yaml:"-" json:"-"`
+		DomainSearchAttributes          dynamicproperties.MapPropertyFnWithDomainFilter `yaml:"-" json:"-"`
+		SearchAttributeAliases          dynamicproperties.MapPropertyFn                 `yaml:"-" json:"-"`
+		PinotOptimizedQueryColumns      dynamicproperties.MapPropertyFn                 `yaml:"-" json:"-"`
+		SearchAttributesHiddenValueKeys dynamicproperties.MapPropertyFn                 `yaml:"-" json:"-"`
 		// deprecated: never read from, all ES reads and writes erroneously use PersistenceMaxQPS
 		ESVisibilityListMaxQPS dynamicproperties.IntPropertyFnWithDomainFilter `yaml:"-" json:"-"`
 
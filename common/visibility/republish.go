@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+type (
+	// HistoryReader reads the history of executions, it is implemented by persistence.HistoryManager and persistence.Retryer
+	HistoryReader interface {
+		ReadHistoryBranch(context.Context, *persistence.ReadHistoryBranchRequest) (*persistence.ReadHistoryBranchResponse, error)
+	}
+
+	// RepublishRequest is the execution whose visibility record is republished, as persisted in its shard
+	RepublishRequest struct {
+		DomainName       string
+		NumClusters      int16
+		RetentionDays    int32
+		ShardID          int
+		ExecutionInfo    *persistence.WorkflowExecutionInfo
+		VersionHistories *persistence.VersionHistories
+	}
+)
+
+// Republish writes the visibility record of an execution again from its persisted state: as started while the
+// execution is open, and as closed once it completed. The task ID of its last event is the version of the record,
+// so it replaces records written for earlier events. Only visibility is written, neither history nor mutable state,
+// so records of executions whose domain is active in another cluster are republished the same way.
+func Republish(
+	ctx context.Context,
+	visibilityManager persistence.VisibilityManager,
+	historyReader HistoryReader,
+	request *RepublishRequest,
+) error {
+	info := request.ExecutionInfo
+	startEvent, err := getStartEvent(ctx, historyReader, request)
+	if err != nil {
+		return err
+	}
+
+	var memo *types.Memo
+	if info.Memo != nil {
+		memo = &types.Memo{Fields: info.Memo}
+	}
+	workflowExecution := types.WorkflowExecution{WorkflowID: info.WorkflowID, RunID: info.RunID}
+	clusterAttribute := info.ActiveClusterSelectionPolicy.GetClusterAttribute()
+	updateTimestamp := time.Now().UnixNano()
+
+	if info.State != persistence.WorkflowStateCompleted {
+		return visibilityManager.RecordWorkflowExecutionStarted(ctx, &persistence.RecordWorkflowExecutionStartedRequest{
+			DomainUUID:                  info.DomainID,
+			Domain:                      request.DomainName,
+			Execution:                   workflowExecution,
+			WorkflowTypeName:            info.WorkflowTypeName,
+			StartTimestamp:              info.StartTimestamp.UnixNano(),
+			ExecutionTimestamp:          getExecutionTimestamp(startEvent),
+			WorkflowTimeout:             int64(info.WorkflowTimeout),
+			TaskID:                      info.LastEventTaskID,
+			Memo:                        memo,
+			TaskList:                    info.TaskList,
+			IsCron:                      len(info.CronSchedule) > 0,
+			NumClusters:                 request.NumClusters,
+			ClusterAttributeScope:       clusterAttribute.GetScope(),
+			ClusterAttributeName:        clusterAttribute.GetName(),
+			UpdateTimestamp:             updateTimestamp,
+			SearchAttributes:            info.SearchAttributes,
+			ShardID:                     int16(request.ShardID),
+			ExecutionStatus:             info.ExecutionStatus,
+			CronSchedule:                info.CronSchedule,
+			ScheduledExecutionTimestamp: info.ScheduledExecutionTimestamp,
+		})
+	}
+
+	closeTimestamp := info.LastUpdatedTimestamp.UnixNano()
+	if info.CompletionEvent != nil {
+		closeTimestamp = info.CompletionEvent.GetTimestamp()
+	}
+	closeStatus := persistence.ToInternalWorkflowExecutionCloseStatus(info.CloseStatus)
+	if closeStatus == nil {
+		return fmt.Errorf("completed workflow has no close status")
+	}
+	return visibilityManager.RecordWorkflowExecutionClosed(ctx, &persistence.RecordWorkflowExecutionClosedRequest{
+		DomainUUID:                  info.DomainID,
+		Domain:                      request.DomainName,
+		Execution:                   workflowExecution,
+		WorkflowTypeName:            info.WorkflowTypeName,
+		StartTimestamp:              info.StartTimestamp.UnixNano(),
+		ExecutionTimestamp:          getExecutionTimestamp(startEvent),
+		CloseTimestamp:              closeTimestamp,
+		Status:                      *closeStatus,
+		HistoryLength:               info.NextEventID - 1,
+		RetentionSeconds:            int64(request.RetentionDays) * int64(24*time.Hour/time.Second),
+		TaskID:                      info.LastEventTaskID,
+		Memo:                        memo,
+		TaskList:                    info.TaskList,
+		IsCron:                      len(info.CronSchedule) > 0,
+		CronSchedule:                info.CronSchedule,
+		NumClusters:                 request.NumClusters,
+		ClusterAttributeScope:       clusterAttribute.GetScope(),
+		ClusterAttributeName:        clusterAttribute.GetName(),
+		UpdateTimestamp:             updateTimestamp,
+		SearchAttributes:            info.SearchAttributes,
+		ShardID:                     int16(request.ShardID),
+		ExecutionStatus:             info.ExecutionStatus,
+		ScheduledExecutionTimestamp: info.ScheduledExecutionTimestamp,
+	})
+}
+
+func getStartEvent(
+	ctx context.Context,
+	historyReader HistoryReader,
+	request *RepublishRequest,
+) (*types.HistoryEvent, error) {
+	branchToken := request.ExecutionInfo.BranchToken
+	if request.VersionHistories != nil {
+		currentVersionHistory, err := request.VersionHistories.GetCurrentVersionHistory()
+		if err != nil {
+			return nil, err
+		}
+		branchToken = currentVersionHistory.GetBranchToken()
+	}
+	shardID := request.ShardID
+	resp, err := historyReader.ReadHistoryBranch(ctx, &persistence.ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+		MinEventID:  constants.FirstEventID,
+		MaxEventID:  constants.FirstEventID + 1,
+		PageSize:    1,
+		ShardID:     &shardID,
+		DomainName:  request.DomainName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read start event: %w", err)
+	}
+	if len(resp.HistoryEvents) == 0 {
+		return nil, fmt.Errorf("start event not found")
+	}
+	return resp.HistoryEvents[0], nil
+}
+
+// getExecutionTimestamp mirrors the execution time history records in visibility:
+// zero, unless the first decision of the workflow was delayed (e.g. cron or retry backoff)
+func getExecutionTimestamp(startEvent *types.HistoryEvent) int64 {
+	backoffSeconds := startEvent.WorkflowExecutionStartedEventAttributes.GetFirstDecisionTaskBackoffSeconds()
+	if backoffSeconds == 0 {
+		return 0
+	}
+	return startEvent.GetTimestamp() + int64(backoffSeconds)*int64(time.Second)
+}
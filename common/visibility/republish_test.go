@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestRepublish(t *testing.T) {
+	startTime := time.Unix(1000, 0)
+	startEvent := &types.HistoryEvent{
+		ID:        1,
+		Timestamp: common.Int64Ptr(startTime.UnixNano()),
+		WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{
+			FirstDecisionTaskBackoffSeconds: common.Int32Ptr(60),
+		},
+	}
+	executionInfo := func(state, closeStatus int) *persistence.WorkflowExecutionInfo {
+		return &persistence.WorkflowExecutionInfo{
+			DomainID:         "domain-id",
+			WorkflowID:       "workflow-id",
+			RunID:            "run-id",
+			WorkflowTypeName: "workflow-type",
+			TaskList:         "task-list",
+			BranchToken:      []byte("branch-token"),
+			StartTimestamp:   startTime,
+			LastEventTaskID:  42,
+			NextEventID:      10,
+			SearchAttributes: map[string][]byte{"CustomKeywordField": []byte(`"value"`)},
+			State:            state,
+			CloseStatus:      closeStatus,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		executionInfo *persistence.WorkflowExecutionInfo
+		readErr       error
+		expect        func(*persistence.MockVisibilityManager)
+		wantErr       string
+	}{
+		{
+			name:          "open execution is recorded as started",
+			executionInfo: executionInfo(persistence.WorkflowStateRunning, persistence.WorkflowCloseStatusNone),
+			expect: func(m *persistence.MockVisibilityManager) {
+				m.EXPECT().RecordWorkflowExecutionStarted(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, request *persistence.RecordWorkflowExecutionStartedRequest) error {
+						assert.Equal(t, "domain-name", request.Domain)
+						assert.Equal(t, types.WorkflowExecution{WorkflowID: "workflow-id", RunID: "run-id"}, request.Execution)
+						assert.Equal(t, startTime.Add(time.Minute).UnixNano(), request.ExecutionTimestamp)
+						assert.Equal(t, int64(42), request.TaskID)
+						assert.Equal(t, int16(2), request.NumClusters)
+						assert.Equal(t, int16(3), request.ShardID)
+						assert.Equal(t, map[string][]byte{"CustomKeywordField": []byte(`"value"`)}, request.SearchAttributes)
+						return nil
+					})
+			},
+		},
+		{
+			name:          "completed execution is recorded as closed",
+			executionInfo: executionInfo(persistence.WorkflowStateCompleted, persistence.WorkflowCloseStatusCompleted),
+			expect: func(m *persistence.MockVisibilityManager) {
+				m.EXPECT().RecordWorkflowExecutionClosed(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, request *persistence.RecordWorkflowExecutionClosedRequest) error {
+						assert.Equal(t, types.WorkflowExecutionCloseStatusCompleted, request.Status)
+						assert.Equal(t, int64(9), request.HistoryLength)
+						assert.Equal(t, int64(7*24*60*60), request.RetentionSeconds)
+						assert.Equal(t, int64(42), request.TaskID)
+						return nil
+					})
+			},
+		},
+		{
+			name:          "completed execution without close status",
+			executionInfo: executionInfo(persistence.WorkflowStateCompleted, persistence.WorkflowCloseStatusNone),
+			expect:        func(m *persistence.MockVisibilityManager) {},
+			wantErr:       "completed workflow has no close status",
+		},
+		{
+			name:          "start event can't be read",
+			executionInfo: executionInfo(persistence.WorkflowStateRunning, persistence.WorkflowCloseStatusNone),
+			readErr:       errors.New("history unavailable"),
+			expect:        func(m *persistence.MockVisibilityManager) {},
+			wantErr:       "failed to read start event: history unavailable",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			historyManager := persistence.NewMockHistoryManager(ctrl)
+			historyManager.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, request *persistence.ReadHistoryBranchRequest) (*persistence.ReadHistoryBranchResponse, error) {
+					assert.Equal(t, []byte("branch-token"), request.BranchToken)
+					if tc.readErr != nil {
+						return nil, tc.readErr
+					}
+					return &persistence.ReadHistoryBranchResponse{HistoryEvents: []*types.HistoryEvent{startEvent}}, nil
+				})
+			visibilityManager := persistence.NewMockVisibilityManager(ctrl)
+			tc.expect(visibilityManager)
+
+			err := Republish(context.Background(), visibilityManager, historyManager, &RepublishRequest{
+				DomainName:    "domain-name",
+				NumClusters:   2,
+				RetentionDays: 7,
+				ShardID:       3,
+				ExecutionInfo: tc.executionInfo,
+			})
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
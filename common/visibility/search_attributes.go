@@ -0,0 +1,355 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility
+
+import (
+	"fmt"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/definition"
+	"github.com/uber/cadence/common/types"
+)
+
+// The lifecycle of custom search attributes is kept in three dynamic config maps:
+// the valid search attributes with their types, the removed (deprecated) search attributes,
+// and the aliases of renamed search attributes, mapping each new name to the valid search attribute it resolves to.
+// Documents keep using the original key, so renaming does not require reindexing.
+// Changing the type of a search attribute adds a search attribute with the new type, and aliases the key to it.
+// The alias is pending reindex first, stored as {"pendingReindex": newKey}: values of the new type are indexed under
+// the new search attribute, but queries by the key keep resolving to the key, which existing documents are indexed under.
+// Once the workflows with the key are reindexed, the alias is completed to the new search attribute, which queries by
+// the key resolve to from then on. The key stays valid, so workers keep upserting it.
+// Domains can register more custom search attributes, which are only valid for the domain.
+// Their documents share the visibility index with other domains, so a key has the same type in all domains.
+
+const pendingReindexField = "pendingReindex"
+
+// ResolveSearchAttributeAlias returns the search attribute an alias resolves to in queries, other names are returned
+// unchanged. Type changes pending reindex are not resolved, see PendingTypeChange.
+func ResolveSearchAttributeAlias(aliases map[string]interface{}, name string) string {
+	if key, ok := aliases[name].(string); ok && key != "" {
+		return key
+	}
+	return name
+}
+
+// PendingTypeChange returns the search attribute added for a type change of key if the type change is pending reindex
+func PendingTypeChange(aliases map[string]interface{}, key string) (string, bool) {
+	pending, ok := aliases[key].(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	newKey, ok := pending[pendingReindexField].(string)
+	return newKey, ok && newKey != ""
+}
+
+// CompleteTypeChange returns the aliases after completing the type change of key pending reindex,
+// queries by key resolve to the search attribute added for it from then on
+func CompleteTypeChange(aliases map[string]interface{}, key string) (map[string]interface{}, error) {
+	newKey, ok := PendingTypeChange(aliases, key)
+	if !ok {
+		return nil, fmt.Errorf("search attribute %q has no type change pending reindex", key)
+	}
+	updated := copyMap(aliases)
+	updated[key] = newKey
+	return updated, nil
+}
+
+// resolveIndexedAlias returns the search attribute an alias resolves to when indexing values,
+// which includes type changes pending reindex
+func resolveIndexedAlias(aliases map[string]interface{}, name string) string {
+	if newKey, ok := PendingTypeChange(aliases, name); ok {
+		return newKey
+	}
+	return ResolveSearchAttributeAlias(aliases, name)
+}
+
+// EffectiveSearchAttributes returns the search attributes a domain can use: the valid search attributes
+// and the custom search attributes registered for the domain. Registrations can't change the type of a valid search attribute.
+func EffectiveSearchAttributes(valid, domain map[string]interface{}) map[string]interface{} {
+	if len(domain) == 0 {
+		return valid
+	}
+	effective := copyMap(valid)
+	for key, valueType := range domain {
+		if _, ok := effective[key]; !ok {
+			effective[key] = valueType
+		}
+	}
+	return effective
+}
+
+// IsDeprecatedSearchAttribute returns true if the search attribute was removed
+func IsDeprecatedSearchAttribute(deprecated map[string]interface{}, key string) bool {
+	_, ok := deprecated[key]
+	return ok
+}
+
+// VisibleSearchAttributes returns the search attributes which are listed to users: valid search attributes
+// which were not removed, and the aliases of renamed ones with the type of the search attribute they resolve to
+func VisibleSearchAttributes(valid, deprecated, aliases map[string]interface{}) map[string]interface{} {
+	visible := make(map[string]interface{}, len(valid)+len(aliases))
+	for key, valueType := range valid {
+		if !IsDeprecatedSearchAttribute(deprecated, key) {
+			visible[key] = valueType
+		}
+	}
+	for alias := range aliases {
+		key := ResolveSearchAttributeAlias(aliases, alias)
+		if valueType, ok := valid[key]; ok && !IsDeprecatedSearchAttribute(deprecated, key) {
+			visible[alias] = valueType
+		}
+	}
+	return visible
+}
+
+// RemoveSearchAttribute returns the removed search attributes after removing key.
+// Removed search attributes stay valid, so existing documents can still be queried and indexed.
+func RemoveSearchAttribute(valid, deprecated map[string]interface{}, key string) (map[string]interface{}, error) {
+	if err := validateCustomSearchAttribute(valid, deprecated, key); err != nil {
+		return nil, err
+	}
+	updated := copyMap(deprecated)
+	updated[key] = true
+	return updated, nil
+}
+
+// RenameSearchAttribute returns the aliases after renaming key to newName.
+// Renaming an alias replaces it, renaming a valid search attribute keeps the original key valid for existing workers.
+func RenameSearchAttribute(valid, deprecated, aliases map[string]interface{}, key, newName string) (map[string]interface{}, error) {
+	target := ResolveSearchAttributeAlias(aliases, key)
+	if err := validateCustomSearchAttribute(valid, deprecated, target); err != nil {
+		return nil, err
+	}
+	if err := ValidateSearchAttributeKey(newName); err != nil {
+		return nil, fmt.Errorf("invalid search attribute name %q: %w", newName, err)
+	}
+	if definition.IsSystemIndexedKey(newName) {
+		return nil, fmt.Errorf("search attribute name %q is reserved by system", newName)
+	}
+	if _, ok := valid[newName]; ok {
+		return nil, fmt.Errorf("search attribute %q already exists", newName)
+	}
+	if _, ok := aliases[newName]; ok {
+		return nil, fmt.Errorf("search attribute %q is already an alias of %v", newName, aliases[newName])
+	}
+	updated := copyMap(aliases)
+	if _, ok := valid[key]; !ok && target != key {
+		delete(updated, key)
+	}
+	updated[newName] = target
+	return updated, nil
+}
+
+// ChangeSearchAttributeType returns the search attribute added for values of key with valueType and the aliases after
+// aliasing key to it, pending reindex. The search attribute is named after key and the type, it has to be added to the
+// valid search attributes and the visibility index first. Changing the type back to the original one of key removes the alias.
+func ChangeSearchAttributeType(
+	valid, deprecated, aliases map[string]interface{},
+	key string,
+	valueType types.IndexedValueType,
+) (string, map[string]interface{}, error) {
+	if err := validateCustomSearchAttribute(valid, deprecated, key); err != nil {
+		return "", nil, err
+	}
+	if valueType < types.IndexedValueTypeString || valueType > types.IndexedValueTypeDatetime {
+		return "", nil, fmt.Errorf("unknown search attribute type %d", valueType)
+	}
+	if current, ok := indexedValueType(valid[resolveIndexedAlias(aliases, key)]); ok && current == valueType {
+		return "", nil, fmt.Errorf("search attribute %q is already of type %v", key, valueType)
+	}
+	updated := copyMap(aliases)
+	if current, ok := indexedValueType(valid[key]); ok && current == valueType {
+		delete(updated, key)
+		return key, updated, nil
+	}
+	newKey := key + indexedValueTypeNames[valueType]
+	if current, ok := indexedValueType(valid[newKey]); ok && current != valueType {
+		return "", nil, fmt.Errorf("search attribute %q already exists with type %v", newKey, current)
+	}
+	if _, ok := aliases[newKey]; ok {
+		return "", nil, fmt.Errorf("search attribute %q is already an alias of %v", newKey, aliases[newKey])
+	}
+	updated[key] = map[string]interface{}{pendingReindexField: newKey}
+	return newKey, updated, nil
+}
+
+// ValidateRemovedSearchAttributes checks the search attributes updated removes in addition to deprecated,
+// only custom search attributes which are valid can be removed. Dropping removed search attributes restores them.
+func ValidateRemovedSearchAttributes(valid, deprecated, updated map[string]interface{}) error {
+	for key := range updated {
+		if IsDeprecatedSearchAttribute(deprecated, key) {
+			continue
+		}
+		if err := validateCustomSearchAttribute(valid, deprecated, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateSearchAttributeAliases checks the aliases updated adds or changes in addition to aliases. A rename resolves
+// to a valid search attribute which was not removed, a type change of a valid search attribute resolves to the search
+// attribute named after it and its new type, which has to be valid already. A type change is pending reindex first,
+// and can only be completed from there.
+func ValidateSearchAttributeAliases(valid, deprecated, aliases, updated map[string]interface{}) error {
+	for alias, target := range updated {
+		key, _ := target.(string)
+		pending := false
+		if pendingKey, ok := PendingTypeChange(updated, alias); ok {
+			key, pending = pendingKey, true
+		}
+		if key == "" || key == alias {
+			return fmt.Errorf("alias %q does not resolve to another search attribute", alias)
+		}
+		if current, ok := PendingTypeChange(aliases, alias); pending && ok && current == key {
+			continue
+		}
+		if current, _ := aliases[alias].(string); !pending && current == key {
+			continue
+		}
+		if err := validateCustomSearchAttribute(valid, deprecated, key); err != nil {
+			return err
+		}
+		if _, ok := valid[alias]; !ok {
+			if pending {
+				return fmt.Errorf("alias %q of a renamed search attribute can't be pending reindex", alias)
+			}
+			if err := ValidateSearchAttributeKey(alias); err != nil {
+				return fmt.Errorf("invalid search attribute name %q: %w", alias, err)
+			}
+			if definition.IsSystemIndexedKey(alias) {
+				return fmt.Errorf("search attribute name %q is reserved by system", alias)
+			}
+			continue
+		}
+		if err := validateCustomSearchAttribute(valid, deprecated, alias); err != nil {
+			return err
+		}
+		valueType, _ := indexedValueType(valid[key])
+		if current, _ := indexedValueType(valid[alias]); current == valueType || key != alias+indexedValueTypeNames[valueType] {
+			return fmt.Errorf("search attribute %q can only be aliased to the search attribute added for a type change, not %q", alias, key)
+		}
+		if current, _ := PendingTypeChange(aliases, alias); !pending && current != key {
+			return fmt.Errorf("type change of search attribute %q to %q has to be pending reindex before it is completed", alias, key)
+		}
+	}
+	return nil
+}
+
+// IndexedSearchAttributeKey returns the key a value of the search attribute key is indexed under:
+// the search attribute key is aliased to after a type change if the value has its type, otherwise key.
+// Type changes pending reindex are included, so documents are indexed the way queries resolve once they are completed.
+func IndexedSearchAttributeKey(valid, aliases map[string]interface{}, key string, value []byte) string {
+	target := resolveIndexedAlias(aliases, key)
+	if target == key {
+		return key
+	}
+	if _, ok := valid[key]; !ok {
+		return key
+	}
+	valueType, ok := indexedValueType(valid[target])
+	if !ok {
+		return key
+	}
+	if _, err := common.DeserializeSearchAttributeValue(value, valueType); err != nil {
+		return key
+	}
+	return target
+}
+
+// RegisterDomainSearchAttribute returns the search attributes registered for a domain after registering key with valueType.
+// otherDomains are the search attributes registered for the other domains, key must have the same type in all of them.
+func RegisterDomainSearchAttribute(
+	valid map[string]interface{},
+	registered map[string]interface{},
+	otherDomains []map[string]interface{},
+	key string,
+	valueType types.IndexedValueType,
+) (map[string]interface{}, error) {
+	if err := ValidateSearchAttributeKey(key); err != nil {
+		return nil, fmt.Errorf("invalid search attribute name %q: %w", key, err)
+	}
+	if definition.IsSystemIndexedKey(key) {
+		return nil, fmt.Errorf("search attribute %q is reserved by system", key)
+	}
+	if _, ok := valid[key]; ok {
+		return nil, fmt.Errorf("search attribute %q is already valid for all domains", key)
+	}
+	if _, ok := registered[key]; ok {
+		return nil, fmt.Errorf("search attribute %q is already registered for the domain", key)
+	}
+	if valueType < types.IndexedValueTypeString || valueType > types.IndexedValueTypeDatetime {
+		return nil, fmt.Errorf("unknown search attribute type %d", valueType)
+	}
+	for _, other := range otherDomains {
+		if current, ok := indexedValueType(other[key]); ok && current != valueType {
+			return nil, fmt.Errorf("search attribute %q is registered with type %v by another domain", key, current)
+		}
+	}
+	updated := copyMap(registered)
+	updated[key] = int(valueType)
+	return updated, nil
+}
+
+// indexedValueTypeNames name the search attributes added for type changes
+var indexedValueTypeNames = map[types.IndexedValueType]string{
+	types.IndexedValueTypeString:   "String",
+	types.IndexedValueTypeKeyword:  "Keyword",
+	types.IndexedValueTypeInt:      "Int",
+	types.IndexedValueTypeDouble:   "Double",
+	types.IndexedValueTypeBool:     "Bool",
+	types.IndexedValueTypeDatetime: "Datetime",
+}
+
+func validateCustomSearchAttribute(valid, deprecated map[string]interface{}, key string) error {
+	if definition.IsSystemIndexedKey(key) {
+		return fmt.Errorf("search attribute %q is reserved by system", key)
+	}
+	if _, ok := valid[key]; !ok {
+		return fmt.Errorf("search attribute %q is not a valid search attribute", key)
+	}
+	if IsDeprecatedSearchAttribute(deprecated, key) {
+		return fmt.Errorf("search attribute %q is removed", key)
+	}
+	return nil
+}
+
+func indexedValueType(value interface{}) (types.IndexedValueType, bool) {
+	switch t := value.(type) {
+	case float64:
+		return types.IndexedValueType(t), true
+	case int:
+		return types.IndexedValueType(t), true
+	case types.IndexedValueType:
+		return t, true
+	default:
+		return 0, false
+	}
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(m)+1)
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,552 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/types"
+)
+
+var testValidSearchAttributes = map[string]interface{}{
+	"WorkflowType":  float64(types.IndexedValueTypeKeyword),
+	"CustomKeyword": float64(types.IndexedValueTypeKeyword),
+	"CustomInt":     float64(types.IndexedValueTypeInt),
+	"CustomOld":     float64(types.IndexedValueTypeString),
+}
+
+func TestVisibleSearchAttributes(t *testing.T) {
+	deprecated := map[string]interface{}{"CustomOld": true}
+	aliases := map[string]interface{}{
+		"CustomNumber": "CustomInt",
+		"CustomLegacy": "CustomOld",
+		"CustomGone":   "Unknown",
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"WorkflowType":  float64(types.IndexedValueTypeKeyword),
+		"CustomKeyword": float64(types.IndexedValueTypeKeyword),
+		"CustomInt":     float64(types.IndexedValueTypeInt),
+		"CustomNumber":  float64(types.IndexedValueTypeInt),
+	}, VisibleSearchAttributes(testValidSearchAttributes, deprecated, aliases))
+	assert.Equal(t, testValidSearchAttributes, VisibleSearchAttributes(testValidSearchAttributes, nil, nil))
+}
+
+func TestResolveSearchAttributeAlias(t *testing.T) {
+	aliases := map[string]interface{}{
+		"CustomNumber":  "CustomInt",
+		"Invalid":       1,
+		"CustomKeyword": map[string]interface{}{"pendingReindex": "CustomKeywordInt"},
+	}
+
+	assert.Equal(t, "CustomInt", ResolveSearchAttributeAlias(aliases, "CustomNumber"))
+	assert.Equal(t, "CustomInt", ResolveSearchAttributeAlias(aliases, "CustomInt"))
+	assert.Equal(t, "Invalid", ResolveSearchAttributeAlias(aliases, "Invalid"))
+	assert.Equal(t, "CustomKeyword", ResolveSearchAttributeAlias(aliases, "CustomKeyword"))
+	assert.Equal(t, "CustomInt", ResolveSearchAttributeAlias(nil, "CustomInt"))
+}
+
+func TestRemoveSearchAttribute(t *testing.T) {
+	deprecated := map[string]interface{}{"CustomOld": true}
+	tests := []struct {
+		name    string
+		key     string
+		want    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name: "custom search attribute",
+			key:  "CustomKeyword",
+			want: map[string]interface{}{"CustomOld": true, "CustomKeyword": true},
+		},
+		{
+			name:    "system search attribute",
+			key:     "WorkflowType",
+			wantErr: "reserved by system",
+		},
+		{
+			name:    "unknown search attribute",
+			key:     "Unknown",
+			wantErr: "not a valid search attribute",
+		},
+		{
+			name:    "already removed",
+			key:     "CustomOld",
+			wantErr: "is removed",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := RemoveSearchAttribute(testValidSearchAttributes, deprecated, tc.key)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+	assert.Equal(t, map[string]interface{}{"CustomOld": true}, deprecated, "input must not be modified")
+}
+
+func TestRenameSearchAttribute(t *testing.T) {
+	aliases := map[string]interface{}{"CustomNumber": "CustomInt"}
+	tests := []struct {
+		name    string
+		key     string
+		newName string
+		want    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "valid search attribute",
+			key:     "CustomKeyword",
+			newName: "CustomTag",
+			want:    map[string]interface{}{"CustomNumber": "CustomInt", "CustomTag": "CustomKeyword"},
+		},
+		{
+			name:    "alias is replaced",
+			key:     "CustomNumber",
+			newName: "CustomCount",
+			want:    map[string]interface{}{"CustomCount": "CustomInt"},
+		},
+		{
+			name:    "removed search attribute",
+			key:     "CustomOld",
+			newName: "CustomNew",
+			wantErr: "is removed",
+		},
+		{
+			name:    "invalid new name",
+			key:     "CustomKeyword",
+			newName: "1Custom",
+			wantErr: "invalid search attribute name",
+		},
+		{
+			name:    "system new name",
+			key:     "CustomKeyword",
+			newName: "WorkflowID",
+			wantErr: "reserved by system",
+		},
+		{
+			name:    "existing new name",
+			key:     "CustomKeyword",
+			newName: "CustomInt",
+			wantErr: "already exists",
+		},
+		{
+			name:    "existing alias",
+			key:     "CustomKeyword",
+			newName: "CustomNumber",
+			wantErr: "already an alias",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := RenameSearchAttribute(testValidSearchAttributes, map[string]interface{}{"CustomOld": true}, aliases, tc.key, tc.newName)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	// the alias of a search attribute whose type changed is kept
+	valid := map[string]interface{}{
+		"CustomKeyword":    float64(types.IndexedValueTypeKeyword),
+		"CustomKeywordInt": float64(types.IndexedValueTypeInt),
+	}
+	got, err := RenameSearchAttribute(valid, nil, map[string]interface{}{"CustomKeyword": "CustomKeywordInt"}, "CustomKeyword", "CustomCount")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"CustomKeyword": "CustomKeywordInt", "CustomCount": "CustomKeywordInt"}, got)
+}
+
+func TestChangeSearchAttributeType(t *testing.T) {
+	tests := []struct {
+		name        string
+		aliases     map[string]interface{}
+		key         string
+		valueType   types.IndexedValueType
+		wantKey     string
+		wantAliases map[string]interface{}
+		wantErr     string
+	}{
+		{
+			name:      "new search attribute is aliased pending reindex",
+			aliases:   map[string]interface{}{"CustomNumber": "CustomInt"},
+			key:       "CustomKeyword",
+			valueType: types.IndexedValueTypeInt,
+			wantKey:   "CustomKeywordInt",
+			wantAliases: map[string]interface{}{
+				"CustomNumber":  "CustomInt",
+				"CustomKeyword": map[string]interface{}{"pendingReindex": "CustomKeywordInt"},
+			},
+		},
+		{
+			name:        "changed again",
+			aliases:     map[string]interface{}{"CustomKeyword": "CustomKeywordInt"},
+			key:         "CustomKeyword",
+			valueType:   types.IndexedValueTypeDouble,
+			wantKey:     "CustomKeywordDouble",
+			wantAliases: map[string]interface{}{"CustomKeyword": map[string]interface{}{"pendingReindex": "CustomKeywordDouble"}},
+		},
+		{
+			name:      "same type as the type change pending reindex",
+			aliases:   map[string]interface{}{"CustomKeyword": map[string]interface{}{"pendingReindex": "CustomKeywordInt"}},
+			key:       "CustomKeyword",
+			valueType: types.IndexedValueTypeInt,
+			wantErr:   "already of type",
+		},
+		{
+			name:        "changed back to the original type",
+			aliases:     map[string]interface{}{"CustomKeyword": "CustomKeywordInt"},
+			key:         "CustomKeyword",
+			valueType:   types.IndexedValueTypeKeyword,
+			wantKey:     "CustomKeyword",
+			wantAliases: map[string]interface{}{},
+		},
+		{
+			name:      "same type",
+			key:       "CustomInt",
+			valueType: types.IndexedValueTypeInt,
+			wantErr:   "already of type",
+		},
+		{
+			name:      "same type as the aliased search attribute",
+			aliases:   map[string]interface{}{"CustomKeyword": "CustomInt"},
+			key:       "CustomKeyword",
+			valueType: types.IndexedValueTypeInt,
+			wantErr:   "already of type",
+		},
+		{
+			name:      "unknown type",
+			key:       "CustomInt",
+			valueType: types.IndexedValueType(10),
+			wantErr:   "unknown search attribute type",
+		},
+		{
+			name:      "system search attribute",
+			key:       "WorkflowType",
+			valueType: types.IndexedValueTypeString,
+			wantErr:   "reserved by system",
+		},
+		{
+			name:      "removed search attribute",
+			key:       "CustomOld",
+			valueType: types.IndexedValueTypeKeyword,
+			wantErr:   "is removed",
+		},
+		{
+			name:      "new search attribute is an alias",
+			aliases:   map[string]interface{}{"CustomIntString": "CustomKeyword"},
+			key:       "CustomInt",
+			valueType: types.IndexedValueTypeString,
+			wantErr:   "already an alias",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			valid := copyMap(testValidSearchAttributes)
+			valid["CustomKeywordInt"] = float64(types.IndexedValueTypeInt)
+			gotKey, gotAliases, err := ChangeSearchAttributeType(valid, map[string]interface{}{"CustomOld": true}, tc.aliases, tc.key, tc.valueType)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantKey, gotKey)
+			assert.Equal(t, tc.wantAliases, gotAliases)
+		})
+	}
+}
+
+func TestCompleteTypeChange(t *testing.T) {
+	aliases := map[string]interface{}{
+		"CustomNumber":  "CustomInt",
+		"CustomKeyword": map[string]interface{}{"pendingReindex": "CustomKeywordInt"},
+	}
+
+	newKey, ok := PendingTypeChange(aliases, "CustomKeyword")
+	assert.True(t, ok)
+	assert.Equal(t, "CustomKeywordInt", newKey)
+	_, ok = PendingTypeChange(aliases, "CustomNumber")
+	assert.False(t, ok)
+
+	completed, err := CompleteTypeChange(aliases, "CustomKeyword")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"CustomNumber": "CustomInt", "CustomKeyword": "CustomKeywordInt"}, completed)
+	assert.Equal(t, "CustomKeywordInt", ResolveSearchAttributeAlias(completed, "CustomKeyword"))
+
+	_, err = CompleteTypeChange(aliases, "CustomNumber")
+	assert.ErrorContains(t, err, "no type change pending reindex")
+}
+
+func TestValidateRemovedSearchAttributes(t *testing.T) {
+	deprecated := map[string]interface{}{"CustomOld": true}
+	tests := []struct {
+		name    string
+		updated map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "custom search attribute",
+			updated: map[string]interface{}{"CustomOld": true, "CustomInt": true},
+		},
+		{
+			name:    "restore removed search attribute",
+			updated: map[string]interface{}{},
+		},
+		{
+			name:    "system search attribute",
+			updated: map[string]interface{}{"WorkflowType": true},
+			wantErr: "reserved by system",
+		},
+		{
+			name:    "unknown search attribute",
+			updated: map[string]interface{}{"Unknown": true},
+			wantErr: "not a valid search attribute",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRemovedSearchAttributes(testValidSearchAttributes, deprecated, tt.updated)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateSearchAttributeAliases(t *testing.T) {
+	deprecated := map[string]interface{}{"CustomOld": true}
+	valid := copyMap(testValidSearchAttributes)
+	valid["CustomKeywordInt"] = float64(types.IndexedValueTypeInt)
+	tests := []struct {
+		name    string
+		aliases map[string]interface{}
+		updated map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "rename",
+			updated: map[string]interface{}{"CustomLegacy": "CustomOld", "CustomNumber": "CustomInt"},
+		},
+		{
+			name:    "type change",
+			updated: map[string]interface{}{"CustomKeyword": map[string]interface{}{"pendingReindex": "CustomKeywordInt"}},
+		},
+		{
+			name:    "type change completed after reindex",
+			aliases: map[string]interface{}{"CustomKeyword": map[string]interface{}{"pendingReindex": "CustomKeywordInt"}},
+			updated: map[string]interface{}{"CustomKeyword": "CustomKeywordInt"},
+		},
+		{
+			name:    "type change completed without reindex",
+			updated: map[string]interface{}{"CustomKeyword": "CustomKeywordInt"},
+			wantErr: "has to be pending reindex before it is completed",
+		},
+		{
+			name:    "type change to a search attribute which is not added",
+			updated: map[string]interface{}{"CustomInt": map[string]interface{}{"pendingReindex": "CustomIntKeyword"}},
+			wantErr: "not a valid search attribute",
+		},
+		{
+			name:    "rename pending reindex",
+			updated: map[string]interface{}{"CustomNumber": map[string]interface{}{"pendingReindex": "CustomInt"}},
+			wantErr: "can't be pending reindex",
+		},
+		{
+			name:    "rename to a removed search attribute",
+			updated: map[string]interface{}{"CustomAncient": "CustomOld"},
+			wantErr: `search attribute "CustomOld" is removed`,
+		},
+		{
+			name:    "rename to a system search attribute",
+			updated: map[string]interface{}{"WorkflowType": "CustomInt"},
+			wantErr: "reserved by system",
+		},
+		{
+			name:    "rename to an unknown search attribute",
+			updated: map[string]interface{}{"CustomNumber": "Unknown"},
+			wantErr: "not a valid search attribute",
+		},
+		{
+			name:    "invalid name",
+			updated: map[string]interface{}{"Custom Number": "CustomInt"},
+			wantErr: "invalid search attribute name",
+		},
+		{
+			name:    "alias of another type",
+			updated: map[string]interface{}{"CustomKeyword": "CustomInt"},
+			wantErr: "can only be aliased to the search attribute added for a type change",
+		},
+		{
+			name:    "not a name",
+			updated: map[string]interface{}{"CustomNumber": 1},
+			wantErr: "does not resolve to another search attribute",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aliases := map[string]interface{}{"CustomLegacy": "CustomOld"}
+			for alias, target := range tt.aliases {
+				aliases[alias] = target
+			}
+			err := ValidateSearchAttributeAliases(valid, deprecated, aliases, tt.updated)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestIndexedSearchAttributeKey(t *testing.T) {
+	valid := map[string]interface{}{
+		"CustomKeyword":    float64(types.IndexedValueTypeKeyword),
+		"CustomKeywordInt": float64(types.IndexedValueTypeInt),
+		"CustomInt":        float64(types.IndexedValueTypeInt),
+		"CustomIntKeyword": float64(types.IndexedValueTypeKeyword),
+	}
+	aliases := map[string]interface{}{
+		"CustomKeyword": "CustomKeywordInt",
+		"CustomNumber":  "CustomInt",
+		"CustomInt":     map[string]interface{}{"pendingReindex": "CustomIntKeyword"},
+	}
+	tests := []struct {
+		name  string
+		key   string
+		value string
+		want  string
+	}{
+		{name: "value of the new type", key: "CustomKeyword", value: `1`, want: "CustomKeywordInt"},
+		{name: "value of the previous type", key: "CustomKeyword", value: `"one"`, want: "CustomKeyword"},
+		{name: "value of the type pending reindex", key: "CustomInt", value: `"one"`, want: "CustomIntKeyword"},
+		{name: "value of the type before the change pending reindex", key: "CustomInt", value: `1`, want: "CustomInt"},
+		{name: "alias of a renamed search attribute", key: "CustomNumber", value: `1`, want: "CustomNumber"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IndexedSearchAttributeKey(valid, aliases, tc.key, []byte(tc.value)))
+		})
+	}
+}
+
+func TestEffectiveSearchAttributes(t *testing.T) {
+	domain := map[string]interface{}{
+		"CustomTeam": float64(types.IndexedValueTypeKeyword),
+		"CustomInt":  float64(types.IndexedValueTypeString),
+	}
+
+	effective := EffectiveSearchAttributes(testValidSearchAttributes, domain)
+	assert.Len(t, effective, len(testValidSearchAttributes)+1)
+	assert.Equal(t, float64(types.IndexedValueTypeKeyword), effective["CustomTeam"])
+	assert.Equal(t, float64(types.IndexedValueTypeInt), effective["CustomInt"], "registrations must not change valid search attributes")
+	assert.NotContains(t, testValidSearchAttributes, "CustomTeam", "input must not be modified")
+	assert.Equal(t, testValidSearchAttributes, EffectiveSearchAttributes(testValidSearchAttributes, nil))
+}
+
+func TestRegisterDomainSearchAttribute(t *testing.T) {
+	registered := map[string]interface{}{"CustomTeam": float64(types.IndexedValueTypeKeyword)}
+	otherDomains := []map[string]interface{}{
+		{"CustomRegion": float64(types.IndexedValueTypeKeyword)},
+	}
+	tests := []struct {
+		name      string
+		key       string
+		valueType types.IndexedValueType
+		want      map[string]interface{}
+		wantErr   string
+	}{
+		{
+			name:      "new search attribute",
+			key:       "CustomOwner",
+			valueType: types.IndexedValueTypeKeyword,
+			want: map[string]interface{}{
+				"CustomTeam":  float64(types.IndexedValueTypeKeyword),
+				"CustomOwner": int(types.IndexedValueTypeKeyword),
+			},
+		},
+		{
+			name:      "same type as another domain",
+			key:       "CustomRegion",
+			valueType: types.IndexedValueTypeKeyword,
+			want: map[string]interface{}{
+				"CustomTeam":   float64(types.IndexedValueTypeKeyword),
+				"CustomRegion": int(types.IndexedValueTypeKeyword),
+			},
+		},
+		{
+			name:      "different type than another domain",
+			key:       "CustomRegion",
+			valueType: types.IndexedValueTypeInt,
+			wantErr:   "registered with type KEYWORD by another domain",
+		},
+		{
+			name:      "valid search attribute",
+			key:       "CustomInt",
+			valueType: types.IndexedValueTypeInt,
+			wantErr:   "already valid for all domains",
+		},
+		{
+			name:      "already registered",
+			key:       "CustomTeam",
+			valueType: types.IndexedValueTypeKeyword,
+			wantErr:   "already registered",
+		},
+		{
+			name:      "system search attribute",
+			key:       "WorkflowID",
+			valueType: types.IndexedValueTypeKeyword,
+			wantErr:   "reserved by system",
+		},
+		{
+			name:      "invalid name",
+			key:       "1Custom",
+			valueType: types.IndexedValueTypeKeyword,
+			wantErr:   "invalid search attribute name",
+		},
+		{
+			name:      "unknown type",
+			key:       "CustomOwner",
+			valueType: types.IndexedValueType(10),
+			wantErr:   "unknown search attribute type",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := RegisterDomainSearchAttribute(testValidSearchAttributes, registered, otherDomains, tc.key, tc.valueType)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
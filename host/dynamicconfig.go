@@ -67,8 +67,9 @@ func validSearchAttributesWithSchedules() map[string]interface{} {
 type dynamicClient struct {
 	sync.RWMutex
 
-	overrides map[dynamicproperties.Key]interface{}
-	client    dynamicconfig.Client
+	overrides     map[dynamicproperties.Key]interface{}
+	client        dynamicconfig.Client
+	subscriptions *dynamicconfig.Subscriptions
 }
 
 func (d *dynamicClient) GetValue(name dynamicproperties.Key) (interface{}, error) {
@@ -176,14 +177,10 @@ func (d *dynamicClient) GetListValue(name dynamicproperties.ListKey, filters map
 
 func (d *dynamicClient) UpdateValue(name dynamicproperties.Key, value interface{}) error {
 	if name == dynamicproperties.WriteVisibilityStoreName { // override for es integration tests
-		d.Lock()
-		defer d.Unlock()
-		d.overrides[dynamicproperties.WriteVisibilityStoreName] = value.(string)
+		d.OverrideValue(dynamicproperties.WriteVisibilityStoreName, value.(string))
 		return nil
 	} else if name == dynamicproperties.ReadVisibilityStoreName { // override for pinot integration tests
-		d.Lock()
-		defer d.Unlock()
-		d.overrides[dynamicproperties.ReadVisibilityStoreName] = value.(string)
+		d.OverrideValue(dynamicproperties.ReadVisibilityStoreName, value.(string))
 		return nil
 	}
 	return d.client.UpdateValue(name, value)
@@ -191,8 +188,10 @@ func (d *dynamicClient) UpdateValue(name dynamicproperties.Key, value interface{
 
 func (d *dynamicClient) OverrideValue(name dynamicproperties.Key, value interface{}) {
 	d.Lock()
-	defer d.Unlock()
 	d.overrides[name] = value
+	d.Unlock()
+
+	d.subscriptions.Notify(d.GetValueWithFilters)
 }
 
 func (d *dynamicClient) ListValue(name dynamicproperties.Key) ([]*types.DynamicConfigEntry, error) {
@@ -203,13 +202,26 @@ func (d *dynamicClient) RestoreValue(name dynamicproperties.Key, filters map[dyn
 	return d.client.RestoreValue(name, filters)
 }
 
+// Subscribe notifies onChange of overrides and of changes of the underlying client
+func (d *dynamicClient) Subscribe(name dynamicproperties.Key, filters map[dynamicproperties.Filter]interface{}, onChange func(value interface{})) func() {
+	cancel := d.subscriptions.Subscribe(d.GetValueWithFilters, name, filters, onChange)
+	cancelClient := d.client.Subscribe(name, filters, func(interface{}) {
+		d.subscriptions.Notify(d.GetValueWithFilters)
+	})
+	return func() {
+		cancelClient()
+		cancel()
+	}
+}
+
 var _ dynamicconfig.Client = (*dynamicClient)(nil)
 
 // newIntegrationConfigClient - returns a dynamic config client for integration testing
 func newIntegrationConfigClient(client dynamicconfig.Client, overrides map[dynamicproperties.Key]interface{}) *dynamicClient {
 	integrationClient := &dynamicClient{
-		overrides: make(map[dynamicproperties.Key]interface{}),
-		client:    client,
+		overrides:     make(map[dynamicproperties.Key]interface{}),
+		client:        client,
+		subscriptions: dynamicconfig.NewSubscriptions(),
 	}
 
 	for key, value := range staticOverrides {
@@ -972,8 +972,13 @@ func (c *cadenceImpl) startWorker(hosts map[string][]membership.HostInfo, startW
 		c.startWorkerClientWorker(params, service, clientWorkerDomainCache)
 	}
 
+	var indexerDomainCache cache.DomainCache
 	if c.workerConfig.EnableIndexer {
-		c.startWorkerIndexer(params, service)
+		metadataProxyManager := metered.NewDomainManager(c.domainManager, service.GetMetricsClient(), c.logger, &c.persistenceConfig)
+		indexerDomainCache = cache.NewDomainCache(metadataProxyManager, c.clusterMetadata, service.GetMetricsClient(), service.GetLogger())
+		indexerDomainCache.Start()
+		defer indexerDomainCache.Stop()
+		c.startWorkerIndexer(params, service, indexerDomainCache)
 	}
 
 	var asyncWFDomainCache cache.DomainCache
@@ -1077,13 +1082,14 @@ func (c *cadenceImpl) startWorkerClientWorker(params *resource.Params, svc Servi
 	}
 }
 
-func (c *cadenceImpl) startWorkerIndexer(params *resource.Params, service Service) {
+func (c *cadenceImpl) startWorkerIndexer(params *resource.Params, service Service, domainCache cache.DomainCache) {
 	params.DynamicConfig.UpdateValue(dynamicproperties.WriteVisibilityStoreName, constants.VisibilityModeES)
 	workerConfig := worker.NewConfig(params)
 	c.indexer = indexer.NewIndexer(
 		workerConfig.IndexerCfg,
 		c.messagingClient,
 		c.esClient,
+		domainCache,
 		c.esConfig.Indices[constants.VisibilityAppName],
 		c.esConfig.ConsumerName,
 		c.logger,
@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -54,6 +55,7 @@ import (
 	"github.com/uber/cadence/common/resource"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/visibility"
 	"github.com/uber/cadence/service/frontend/config"
 	"github.com/uber/cadence/service/frontend/validate"
 	"github.com/uber/cadence/service/history/execution"
@@ -80,6 +82,7 @@ type (
 		throttleRetry         *backoff.ThrottleRetry
 		isolationGroups       isolationgroupapi.Handler
 		asyncWFQueueConfigs   queueconfigapi.Handler
+		searchAttributesLock  sync.Mutex
 	}
 
 	workflowQueryTemplate struct {
@@ -1594,6 +1597,8 @@ func (adh *adminHandlerImpl) GetDynamicConfig(ctx context.Context, request *type
 	return &types.GetDynamicConfigResponse{Value: blob}, nil
 }
 
+// UpdateDynamicConfig updates the values of a dynamic config key. Updates of the removed search attributes,
+// the search attribute aliases and the domain search attributes are validated, see updateSearchAttributeConfig.
 func (adh *adminHandlerImpl) UpdateDynamicConfig(ctx context.Context, request *types.UpdateDynamicConfigRequest) (retError error) {
 	defer func() { log.CapturePanic(recover(), adh.GetLogger(), &retError) }()
 	scope, sw := adh.startRequestProfile(ctx, metrics.AdminUpdateDynamicConfigScope)
@@ -1604,6 +1609,8 @@ func (adh *adminHandlerImpl) UpdateDynamicConfig(ctx context.Context, request *t
 	return adh.updateDynamicConfigValue(ctx, scope, adh.params.DynamicConfig, request.ConfigName, request.ConfigValues)
 }
 
+// RestoreDynamicConfig deletes the value of a dynamic config key with filters. The removed search attributes,
+// the search attribute aliases and the domain search attributes can't be restored, they are updated instead.
 func (adh *adminHandlerImpl) RestoreDynamicConfig(ctx context.Context, request *types.RestoreDynamicConfigRequest) (retError error) {
 	defer func() { log.CapturePanic(recover(), adh.GetLogger(), &retError) }()
 	scope, sw := adh.startRequestProfile(ctx, metrics.AdminRestoreDynamicConfigScope)
@@ -1783,9 +1790,230 @@ func (adh *adminHandlerImpl) updateDynamicConfigValue(
 	if err != nil {
 		return err
 	}
+	if err := dynamicproperties.ValidateConfigValues(keyVal, values); err != nil {
+		return adh.error(&types.BadRequestError{Message: err.Error()}, scope)
+	}
+	switch keyVal {
+	case dynamicproperties.DeprecatedSearchAttributes, dynamicproperties.SearchAttributeAliases, dynamicproperties.DomainSearchAttributes:
+		return adh.updateSearchAttributeConfig(scope, client, keyVal, values)
+	}
 	return client.UpdateValue(keyVal, values)
 }
 
+// updateSearchAttributeConfig validates updates of the removed search attributes, the search attribute aliases and the
+// domain search attributes, which remove, rename or change the type of search attributes, or register them for domains.
+// The admin API IDL has no requests for these yet. An update has no side effects: the search attribute added for a type
+// change has to be added with AddSearchAttribute first. An update only replaces the values it sets: the unfiltered value
+// of the removed search attributes and aliases, or the values of the updated domains. The other values are kept,
+// and the update fails if the config is changed by another frontend in the meantime.
+func (adh *adminHandlerImpl) updateSearchAttributeConfig(
+	scope metrics.Scope,
+	client dynamicconfig.Client,
+	key dynamicproperties.Key,
+	values []*types.DynamicConfigValue,
+) error {
+	if _, _, hasPrecondition, _ := dynamicproperties.SplitPrecondition(values); hasPrecondition {
+		return adh.error(&types.BadRequestError{Message: fmt.Sprintf("%v can't be updated conditionally, the values it keeps are checked by the update", key.String())}, scope)
+	}
+
+	adh.searchAttributesLock.Lock()
+	defer adh.searchAttributesLock.Unlock()
+
+	current, err := listDynamicConfigValues(client, key)
+	if err != nil {
+		return adh.error(&types.InternalServiceError{Message: fmt.Sprintf("Failed to get dynamic config, err: %v", err)}, scope)
+	}
+	valid, err := client.GetMapValue(dynamicproperties.ValidSearchAttributes, nil)
+	if err != nil {
+		return adh.error(&types.InternalServiceError{Message: fmt.Sprintf("Failed to get dynamic config, err: %v", err)}, scope)
+	}
+
+	var updated []*types.DynamicConfigValue
+	if key == dynamicproperties.DomainSearchAttributes {
+		updated, err = adh.updateDomainSearchAttributes(scope, valid, current, values)
+	} else {
+		updated, err = adh.updateSearchAttributeLifecycle(scope, client, key, valid, current, values)
+	}
+	if err != nil {
+		return err
+	}
+	return adh.compareAndUpdateDynamicConfig(scope, client, key, updated, current)
+}
+
+// updateSearchAttributeLifecycle validates the removed search attributes or the aliases in values
+func (adh *adminHandlerImpl) updateSearchAttributeLifecycle(
+	scope metrics.Scope,
+	client dynamicconfig.Client,
+	key dynamicproperties.Key,
+	valid map[string]interface{},
+	current []*types.DynamicConfigValue,
+	values []*types.DynamicConfigValue,
+) ([]*types.DynamicConfigValue, error) {
+	if len(values) != 1 || len(values[0].Filters) != 0 {
+		return nil, adh.error(&types.BadRequestError{Message: fmt.Sprintf("Only the value of %v without filters can be updated", key.String())}, scope)
+	}
+	requested, err := decodeSearchAttributeConfigValue(values[0])
+	if err != nil {
+		return nil, adh.error(&types.BadRequestError{Message: fmt.Sprintf("Failed to decode %v, err: %v", key.String(), err)}, scope)
+	}
+	deprecated, err := client.GetMapValue(dynamicproperties.DeprecatedSearchAttributes, nil)
+	if err != nil {
+		return nil, adh.error(&types.InternalServiceError{Message: fmt.Sprintf("Failed to get dynamic config, err: %v", err)}, scope)
+	}
+
+	if key == dynamicproperties.DeprecatedSearchAttributes {
+		if err := visibility.ValidateRemovedSearchAttributes(valid, deprecated, requested); err != nil {
+			return nil, adh.error(&types.BadRequestError{Message: err.Error()}, scope)
+		}
+		return replaceUnfilteredValue(current, values[0]), nil
+	}
+
+	aliases, err := client.GetMapValue(dynamicproperties.SearchAttributeAliases, nil)
+	if err != nil {
+		return nil, adh.error(&types.InternalServiceError{Message: fmt.Sprintf("Failed to get dynamic config, err: %v", err)}, scope)
+	}
+	if err := visibility.ValidateSearchAttributeAliases(valid, deprecated, aliases, requested); err != nil {
+		return nil, adh.error(&types.BadRequestError{Message: err.Error()}, scope)
+	}
+	return replaceUnfilteredValue(current, values[0]), nil
+}
+
+// updateDomainSearchAttributes validates the search attributes registered for each domain in values,
+// the values of the other domains are kept
+func (adh *adminHandlerImpl) updateDomainSearchAttributes(
+	scope metrics.Scope,
+	valid map[string]interface{},
+	current []*types.DynamicConfigValue,
+	values []*types.DynamicConfigValue,
+) ([]*types.DynamicConfigValue, error) {
+	registered := make(map[string]map[string]interface{}, len(current))
+	for _, value := range current {
+		domain, ok := domainOfConfigValue(value)
+		if !ok {
+			continue
+		}
+		attributes, err := decodeSearchAttributeConfigValue(value)
+		if err != nil {
+			return nil, adh.error(&types.InternalServiceError{Message: fmt.Sprintf("Failed to decode dynamic config, err: %v", err)}, scope)
+		}
+		registered[domain] = attributes
+	}
+
+	requested := make(map[string]*types.DynamicConfigValue, len(values))
+	for _, value := range values {
+		domain, ok := domainOfConfigValue(value)
+		if !ok {
+			return nil, adh.error(&types.BadRequestError{Message: fmt.Sprintf("Values of %v must be filtered by domain name only", dynamicproperties.DomainSearchAttributes.String())}, scope)
+		}
+		attributes, err := decodeSearchAttributeConfigValue(value)
+		if err != nil {
+			return nil, adh.error(&types.BadRequestError{Message: fmt.Sprintf("Failed to decode %v, err: %v", dynamicproperties.DomainSearchAttributes.String(), err)}, scope)
+		}
+		var otherDomains []map[string]interface{}
+		for other, otherAttributes := range registered {
+			if other != domain {
+				otherDomains = append(otherDomains, otherAttributes)
+			}
+		}
+		for key, value := range attributes {
+			valueType, ok := value.(float64)
+			if !ok {
+				return nil, adh.error(&types.BadRequestError{Message: fmt.Sprintf("Unknown value type, %v", value)}, scope)
+			}
+			if current, ok := registered[domain][key].(float64); ok && current == valueType {
+				continue
+			}
+			if _, err := visibility.RegisterDomainSearchAttribute(valid, nil, otherDomains, key, types.IndexedValueType(valueType)); err != nil {
+				return nil, adh.error(&types.BadRequestError{Message: err.Error()}, scope)
+			}
+		}
+		registered[domain] = attributes
+		requested[domain] = value
+	}
+
+	updated := make([]*types.DynamicConfigValue, 0, len(current)+len(values))
+	for _, value := range current {
+		if domain, ok := domainOfConfigValue(value); ok && requested[domain] != nil {
+			continue
+		}
+		updated = append(updated, value)
+	}
+	for _, value := range values {
+		updated = append(updated, value)
+	}
+	return updated, nil
+}
+
+// compareAndUpdateDynamicConfig replaces the values of a dynamic config key, read earlier as expected, with values.
+// The update is rejected if the values stored for the key were changed in the meantime.
+func (adh *adminHandlerImpl) compareAndUpdateDynamicConfig(
+	scope metrics.Scope,
+	client dynamicconfig.Client,
+	key dynamicproperties.Key,
+	values []*types.DynamicConfigValue,
+	expected []*types.DynamicConfigValue,
+) error {
+	values, err := dynamicproperties.WithExpectedValues(values, expected)
+	if err != nil {
+		return adh.error(&types.InternalServiceError{Message: fmt.Sprintf("Failed to encode dynamic config, err: %v", err)}, scope)
+	}
+	if err := client.UpdateValue(key, values); err != nil {
+		return adh.error(err, scope)
+	}
+	return nil
+}
+
+// listDynamicConfigValues returns all values stored for a dynamic config key
+func listDynamicConfigValues(client dynamicconfig.Client, key dynamicproperties.Key) ([]*types.DynamicConfigValue, error) {
+	entries, err := client.ListValue(key)
+	if err != nil {
+		return nil, err
+	}
+	var values []*types.DynamicConfigValue
+	for _, entry := range entries {
+		if entry != nil && entry.Name == key.String() {
+			values = append(values, entry.Values...)
+		}
+	}
+	return values, nil
+}
+
+// replaceUnfilteredValue returns values with the value stored without filters replaced by value
+func replaceUnfilteredValue(values []*types.DynamicConfigValue, value *types.DynamicConfigValue) []*types.DynamicConfigValue {
+	updated := []*types.DynamicConfigValue{value}
+	for _, current := range values {
+		if len(current.Filters) > 0 {
+			updated = append(updated, current)
+		}
+	}
+	return updated
+}
+
+// domainOfConfigValue returns the domain of a value filtered by domain name only, overrides of it are not
+func domainOfConfigValue(value *types.DynamicConfigValue) (string, bool) {
+	if len(value.Filters) != 1 || value.Filters[0] == nil || value.Filters[0].Value == nil ||
+		value.Filters[0].Name != dynamicproperties.DomainName.String() {
+		return "", false
+	}
+	var domain string
+	if err := json.Unmarshal(value.Filters[0].Value.Data, &domain); err != nil {
+		return "", false
+	}
+	return domain, true
+}
+
+// decodeSearchAttributeConfigValue returns the map of a search attribute dynamic config value
+func decodeSearchAttributeConfigValue(value *types.DynamicConfigValue) (map[string]interface{}, error) {
+	attributes := map[string]interface{}{}
+	if value.Value == nil {
+		return attributes, nil
+	}
+	if err := json.Unmarshal(value.Value.Data, &attributes); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
 // restoreDynamicConfigValue is the shared implementation for RestoreDynamicConfig and
 // RestoreOperationalDynamicConfig.
 func (adh *adminHandlerImpl) restoreDynamicConfigValue(
@@ -1799,6 +2027,14 @@ func (adh *adminHandlerImpl) restoreDynamicConfigValue(
 	if err != nil {
 		return err
 	}
+	if err := dynamicproperties.ValidateConfigFilters(keyVal, filters); err != nil {
+		return adh.error(&types.BadRequestError{Message: err.Error()}, scope)
+	}
+	switch keyVal {
+	case dynamicproperties.DeprecatedSearchAttributes, dynamicproperties.SearchAttributeAliases, dynamicproperties.DomainSearchAttributes:
+		// restoring would drop values without the validation of updateSearchAttributeConfig
+		return adh.error(&types.BadRequestError{Message: fmt.Sprintf("%v can't be restored, update it with the values to keep instead", keyVal.String())}, scope)
+	}
 	var convFilters map[dynamicproperties.Filter]interface{}
 	if filters != nil {
 		convFilters, err = convertFilterListToMap(filters)
@@ -739,6 +739,45 @@ func (s *adminHandlerSuite) Test_ConfigStore_InvalidKey() {
 	s.Error(err)
 }
 
+func (s *adminHandlerSuite) Test_UpdateDynamicConfig_Validation() {
+	ctx := context.Background()
+	handler := s.handler
+	dynamicConfig := dynamicconfig.NewMockClient(s.controller)
+	handler.params.DynamicConfig = dynamicConfig
+
+	encValue := func(v interface{}) *types.DataBlob {
+		data, err := json.Marshal(v)
+		s.NoError(err)
+		return &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: data}
+	}
+
+	err := handler.UpdateDynamicConfig(ctx, &types.UpdateDynamicConfigRequest{
+		ConfigName:   dynamicproperties.TestGetIntPropertyKey.String(),
+		ConfigValues: []*types.DynamicConfigValue{{Value: encValue("not an int")}},
+	})
+	s.IsType(&types.BadRequestError{}, err)
+
+	err = handler.UpdateDynamicConfig(ctx, &types.UpdateDynamicConfigRequest{
+		ConfigName: dynamicproperties.TestGetIntPropertyKey.String(),
+		ConfigValues: []*types.DynamicConfigValue{{
+			Value:   encValue(10),
+			Filters: []*types.DynamicConfigFilter{{Name: "unknownFilter", Value: encValue("x")}},
+		}},
+	})
+	s.IsType(&types.BadRequestError{}, err)
+
+	values := []*types.DynamicConfigValue{{
+		Value:   encValue(10),
+		Filters: []*types.DynamicConfigFilter{{Name: dynamicproperties.DomainName.String(), Value: encValue("test-domain")}},
+	}}
+	dynamicConfig.EXPECT().UpdateValue(dynamicproperties.TestGetIntPropertyKey, values).Return(nil)
+	err = handler.UpdateDynamicConfig(ctx, &types.UpdateDynamicConfigRequest{
+		ConfigName:   dynamicproperties.TestGetIntPropertyKey.String(),
+		ConfigValues: values,
+	})
+	s.NoError(err)
+}
+
 func (s *adminHandlerSuite) Test_GetDynamicConfig_NoFilter() {
 	ctx := context.Background()
 	handler := s.handler
@@ -794,6 +833,219 @@ func (s *adminHandlerSuite) Test_GetDynamicConfig_FilterMatch() {
 	s.Equal(resp.Value.Data, encTrue)
 }
 
+func Test_UpdateDynamicConfig_SearchAttributes(t *testing.T) {
+	jsonBlob := func(v interface{}) *types.DataBlob {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: data}
+	}
+	unfiltered := func(v map[string]interface{}) *types.DynamicConfigValue {
+		return &types.DynamicConfigValue{Value: jsonBlob(v)}
+	}
+	domainValue := func(domain string, v map[string]interface{}) *types.DynamicConfigValue {
+		return &types.DynamicConfigValue{
+			Value:   jsonBlob(v),
+			Filters: []*types.DynamicConfigFilter{{Name: dynamicproperties.DomainName.String(), Value: jsonBlob(domain)}},
+		}
+	}
+	entries := func(key dynamicproperties.Key, values ...*types.DynamicConfigValue) []*types.DynamicConfigEntry {
+		return []*types.DynamicConfigEntry{{Name: key.String(), Values: values}}
+	}
+	// expectUpdate expects values to be stored for key, on the condition that the values read are unchanged
+	expectUpdate := func(client *dynamicconfig.MockClient, key dynamicproperties.Key, values ...*types.DynamicConfigValue) *gomock.Call {
+		return client.EXPECT().UpdateValue(key, gomock.Any()).DoAndReturn(func(_ dynamicproperties.Key, value interface{}) error {
+			stored, _, hasPrecondition, err := dynamicproperties.SplitPrecondition(value.([]*types.DynamicConfigValue))
+			require.NoError(t, err)
+			assert.True(t, hasPrecondition)
+			require.Len(t, stored, len(values))
+			for i := range values {
+				assert.Equal(t, values[i].Filters, stored[i].Filters)
+				assert.JSONEq(t, string(values[i].Value.Data), string(stored[i].Value.Data))
+			}
+			return nil
+		})
+	}
+	valid := map[string]interface{}{
+		"CustomKeywordField": float64(types.IndexedValueTypeKeyword),
+		"CustomIntField":     float64(types.IndexedValueTypeInt),
+	}
+	validWithTypeChange := map[string]interface{}{
+		"CustomKeywordField":    float64(types.IndexedValueTypeKeyword),
+		"CustomIntField":        float64(types.IndexedValueTypeInt),
+		"CustomKeywordFieldInt": float64(types.IndexedValueTypeInt),
+	}
+	pendingReindex := map[string]interface{}{"pendingReindex": "CustomKeywordFieldInt"}
+	domainDeprecated := domainValue("test-domain", map[string]interface{}{"CustomKeywordField": true})
+	otherDomain := domainValue("other-domain", map[string]interface{}{"TeamKey": float64(types.IndexedValueTypeKeyword)})
+
+	tests := map[string]struct {
+		key         dynamicproperties.Key
+		values      []*types.DynamicConfigValue
+		mockSetup   func(client *dynamicconfig.MockClient)
+		expectedErr string
+	}{
+		"remove keeps filtered values": {
+			key:    dynamicproperties.DeprecatedSearchAttributes,
+			values: []*types.DynamicConfigValue{unfiltered(map[string]interface{}{"CustomIntField": true})},
+			mockSetup: func(client *dynamicconfig.MockClient) {
+				client.EXPECT().ListValue(dynamicproperties.DeprecatedSearchAttributes).Return(entries(dynamicproperties.DeprecatedSearchAttributes, domainDeprecated), nil)
+				client.EXPECT().GetMapValue(dynamicproperties.ValidSearchAttributes, nil).Return(valid, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.DeprecatedSearchAttributes, nil).Return(map[string]interface{}{}, nil)
+				expectUpdate(client, dynamicproperties.DeprecatedSearchAttributes, unfiltered(map[string]interface{}{"CustomIntField": true}), domainDeprecated)
+			},
+		},
+		"remove unknown search attribute": {
+			key:    dynamicproperties.DeprecatedSearchAttributes,
+			values: []*types.DynamicConfigValue{unfiltered(map[string]interface{}{"Unknown": true})},
+			mockSetup: func(client *dynamicconfig.MockClient) {
+				client.EXPECT().ListValue(dynamicproperties.DeprecatedSearchAttributes).Return(nil, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.ValidSearchAttributes, nil).Return(valid, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.DeprecatedSearchAttributes, nil).Return(map[string]interface{}{}, nil)
+			},
+			expectedErr: `search attribute "Unknown" is not a valid search attribute`,
+		},
+		"filtered removal": {
+			key:    dynamicproperties.DeprecatedSearchAttributes,
+			values: []*types.DynamicConfigValue{domainDeprecated},
+			mockSetup: func(client *dynamicconfig.MockClient) {
+				client.EXPECT().ListValue(dynamicproperties.DeprecatedSearchAttributes).Return(nil, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.ValidSearchAttributes, nil).Return(valid, nil)
+			},
+			expectedErr: "Only the value of frontend.deprecatedSearchAttributes without filters can be updated",
+		},
+		"conditional update": {
+			key: dynamicproperties.DeprecatedSearchAttributes,
+			values: func() []*types.DynamicConfigValue {
+				values, err := dynamicproperties.WithExpectedValues([]*types.DynamicConfigValue{unfiltered(map[string]interface{}{"CustomIntField": true})}, nil)
+				require.NoError(t, err)
+				return values
+			}(),
+			expectedErr: "frontend.deprecatedSearchAttributes can't be updated conditionally",
+		},
+		"rename": {
+			key:    dynamicproperties.SearchAttributeAliases,
+			values: []*types.DynamicConfigValue{unfiltered(map[string]interface{}{"CustomTag": "CustomKeywordField"})},
+			mockSetup: func(client *dynamicconfig.MockClient) {
+				client.EXPECT().ListValue(dynamicproperties.SearchAttributeAliases).Return(nil, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.ValidSearchAttributes, nil).Return(valid, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.DeprecatedSearchAttributes, nil).Return(map[string]interface{}{}, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.SearchAttributeAliases, nil).Return(map[string]interface{}{}, nil)
+				expectUpdate(client, dynamicproperties.SearchAttributeAliases, unfiltered(map[string]interface{}{"CustomTag": "CustomKeywordField"}))
+			},
+		},
+		"rename to a system search attribute": {
+			key:    dynamicproperties.SearchAttributeAliases,
+			values: []*types.DynamicConfigValue{unfiltered(map[string]interface{}{"WorkflowType": "CustomKeywordField"})},
+			mockSetup: func(client *dynamicconfig.MockClient) {
+				client.EXPECT().ListValue(dynamicproperties.SearchAttributeAliases).Return(nil, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.ValidSearchAttributes, nil).Return(valid, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.DeprecatedSearchAttributes, nil).Return(map[string]interface{}{}, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.SearchAttributeAliases, nil).Return(map[string]interface{}{}, nil)
+			},
+			expectedErr: `search attribute name "WorkflowType" is reserved by system`,
+		},
+		"type change pending reindex": {
+			key:    dynamicproperties.SearchAttributeAliases,
+			values: []*types.DynamicConfigValue{unfiltered(map[string]interface{}{"CustomKeywordField": pendingReindex})},
+			mockSetup: func(client *dynamicconfig.MockClient) {
+				client.EXPECT().ListValue(dynamicproperties.SearchAttributeAliases).Return(nil, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.ValidSearchAttributes, nil).Return(validWithTypeChange, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.DeprecatedSearchAttributes, nil).Return(map[string]interface{}{}, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.SearchAttributeAliases, nil).Return(map[string]interface{}{}, nil)
+				expectUpdate(client, dynamicproperties.SearchAttributeAliases, unfiltered(map[string]interface{}{"CustomKeywordField": pendingReindex}))
+			},
+		},
+		"type change before the search attribute is added": {
+			key:    dynamicproperties.SearchAttributeAliases,
+			values: []*types.DynamicConfigValue{unfiltered(map[string]interface{}{"CustomKeywordField": pendingReindex})},
+			mockSetup: func(client *dynamicconfig.MockClient) {
+				client.EXPECT().ListValue(dynamicproperties.SearchAttributeAliases).Return(nil, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.ValidSearchAttributes, nil).Return(valid, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.DeprecatedSearchAttributes, nil).Return(map[string]interface{}{}, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.SearchAttributeAliases, nil).Return(map[string]interface{}{}, nil)
+			},
+			expectedErr: `search attribute "CustomKeywordFieldInt" is not a valid search attribute`,
+		},
+		"type change completed without reindex": {
+			key:    dynamicproperties.SearchAttributeAliases,
+			values: []*types.DynamicConfigValue{unfiltered(map[string]interface{}{"CustomKeywordField": "CustomKeywordFieldInt"})},
+			mockSetup: func(client *dynamicconfig.MockClient) {
+				client.EXPECT().ListValue(dynamicproperties.SearchAttributeAliases).Return(nil, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.ValidSearchAttributes, nil).Return(validWithTypeChange, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.DeprecatedSearchAttributes, nil).Return(map[string]interface{}{}, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.SearchAttributeAliases, nil).Return(map[string]interface{}{}, nil)
+			},
+			expectedErr: "has to be pending reindex before it is completed",
+		},
+		"type change to another search attribute": {
+			key:    dynamicproperties.SearchAttributeAliases,
+			values: []*types.DynamicConfigValue{unfiltered(map[string]interface{}{"CustomKeywordField": "CustomIntField"})},
+			mockSetup: func(client *dynamicconfig.MockClient) {
+				client.EXPECT().ListValue(dynamicproperties.SearchAttributeAliases).Return(nil, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.ValidSearchAttributes, nil).Return(valid, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.DeprecatedSearchAttributes, nil).Return(map[string]interface{}{}, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.SearchAttributeAliases, nil).Return(map[string]interface{}{}, nil)
+			},
+			expectedErr: `search attribute "CustomKeywordField" can only be aliased to the search attribute added for a type change`,
+		},
+		"register for a domain keeps other domains": {
+			key:    dynamicproperties.DomainSearchAttributes,
+			values: []*types.DynamicConfigValue{domainValue("test-domain", map[string]interface{}{"TeamKey": float64(types.IndexedValueTypeKeyword)})},
+			mockSetup: func(client *dynamicconfig.MockClient) {
+				client.EXPECT().ListValue(dynamicproperties.DomainSearchAttributes).Return(entries(dynamicproperties.DomainSearchAttributes,
+					otherDomain, domainValue("test-domain", map[string]interface{}{})), nil)
+				client.EXPECT().GetMapValue(dynamicproperties.ValidSearchAttributes, nil).Return(valid, nil)
+				expectUpdate(client, dynamicproperties.DomainSearchAttributes,
+					otherDomain, domainValue("test-domain", map[string]interface{}{"TeamKey": float64(types.IndexedValueTypeKeyword)}))
+			},
+		},
+		"register with the type of another domain": {
+			key:    dynamicproperties.DomainSearchAttributes,
+			values: []*types.DynamicConfigValue{domainValue("test-domain", map[string]interface{}{"TeamKey": float64(types.IndexedValueTypeInt)})},
+			mockSetup: func(client *dynamicconfig.MockClient) {
+				client.EXPECT().ListValue(dynamicproperties.DomainSearchAttributes).Return(entries(dynamicproperties.DomainSearchAttributes, otherDomain), nil)
+				client.EXPECT().GetMapValue(dynamicproperties.ValidSearchAttributes, nil).Return(valid, nil)
+			},
+			expectedErr: `search attribute "TeamKey" is registered with type KEYWORD by another domain`,
+		},
+		"register without a domain": {
+			key:    dynamicproperties.DomainSearchAttributes,
+			values: []*types.DynamicConfigValue{unfiltered(map[string]interface{}{"TeamKey": float64(types.IndexedValueTypeKeyword)})},
+			mockSetup: func(client *dynamicconfig.MockClient) {
+				client.EXPECT().ListValue(dynamicproperties.DomainSearchAttributes).Return(nil, nil)
+				client.EXPECT().GetMapValue(dynamicproperties.ValidSearchAttributes, nil).Return(valid, nil)
+			},
+			expectedErr: "Values of frontend.domainSearchAttributes must be filtered by domain name only",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := dynamicconfig.NewMockClient(gomock.NewController(t))
+			if tt.mockSetup != nil {
+				tt.mockSetup(client)
+			}
+			handler := adminHandlerImpl{
+				Resource: &resource.Test{
+					Logger:        testlogger.New(t),
+					MetricsClient: metrics.NewNoopMetricsClient(),
+				},
+				params: &resource.Params{DynamicConfig: client},
+			}
+
+			err := handler.UpdateDynamicConfig(context.Background(), &types.UpdateDynamicConfigRequest{
+				ConfigName:   tt.key.String(),
+				ConfigValues: tt.values,
+			})
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func Test_GetGlobalIsolationGroups(t *testing.T) {
 
 	validResponse := types.GetGlobalIsolationGroupsResponse{
@@ -1376,6 +1628,15 @@ func Test_DescribeQueue(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		"missing type request": {
+			input: &types.DescribeQueueRequest{
+				ShardID:     1,
+				ClusterName: "test-cluster",
+			},
+			hcHandlerFunc: func(mock *history.MockClient) {
+			},
+			wantErr: true,
+		},
 		"normal request": {
 			input: &types.DescribeQueueRequest{
 				ShardID:     1,
@@ -2640,6 +2901,12 @@ func TestRestoreDynamicConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		"search attribute aliases": {
+			input: &types.RestoreDynamicConfigRequest{
+				ConfigName: dynamicproperties.SearchAttributeAliases.String(),
+			},
+			wantErr: true,
+		},
 	}
 
 	for name, td := range tests {
@@ -54,6 +54,7 @@ import (
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/common/types/mapper/thrift"
+	"github.com/uber/cadence/common/visibility"
 	"github.com/uber/cadence/service/frontend/config"
 	"github.com/uber/cadence/service/frontend/validate"
 	"github.com/uber/cadence/service/worker/diagnostics"
@@ -128,12 +129,17 @@ func NewWorkflowHandler(
 		domainHandler:   domainHandler,
 		visibilityQueryValidator: validator.NewQueryValidator(
 			config.ValidSearchAttributes,
+			config.DomainSearchAttributes,
+			config.SearchAttributeAliases,
 			config.EnableQueryAttributeValidation,
 		),
 		searchAttributesValidator: validator.NewSearchAttributesValidator(
 			resource.GetLogger(),
 			config.EnableQueryAttributeValidation,
 			config.ValidSearchAttributes,
+			config.DeprecatedSearchAttributes,
+			config.DomainSearchAttributes,
+			config.SearchAttributeAliases,
 			config.SearchAttributesNumberOfKeysLimit,
 			config.SearchAttributesSizeOfValueLimit,
 			config.SearchAttributesTotalSizeLimit,
@@ -218,7 +224,7 @@ func (wh *WorkflowHandler) DiagnoseWorkflowExecution(ctx context.Context, reques
 
 	wfExecution := request.GetWorkflowExecution()
 	diagnosticWorkflowDomain := "cadence-system"
-	diagnosticWorkflowID := fmt.Sprintf("%s-%s", request.GetDomain(), wfExecution.GetRunID())
+	diagnosticWorkflowID := diagnostics.DiagnosticsWorkflowID(request.GetDomain(), wfExecution.GetRunID())
 
 	diagnosticWorkflowInput := diagnostics.DiagnosticsStarterWorkflowInput{
 		Domain:     request.GetDomain(),
@@ -2636,13 +2642,21 @@ func (wh *WorkflowHandler) RestartWorkflowExecution(ctx context.Context, request
 	return resp, nil
 }
 
-// GetSearchAttributes return valid indexed keys
+// GetSearchAttributes return valid indexed keys, except removed ones, and the aliases of renamed ones.
+// When the cadence-domain header is set, the search attributes registered for the domain are included.
+// The header is a stopgap: GetSearchAttributes has no request, and the domain moves to a request field once the IDL has one.
+// Clients which don't set the header get the search attributes valid for all domains, without the domain's ones.
 func (wh *WorkflowHandler) GetSearchAttributes(ctx context.Context) (resp *types.GetSearchAttributesResponse, retError error) {
 	if wh.isShuttingDown() {
 		return nil, validate.ErrShuttingDown
 	}
 
-	keys := wh.config.ValidSearchAttributes()
+	domainName := yarpc.CallFromContext(ctx).Header(common.DomainHeaderName)
+	keys := visibility.VisibleSearchAttributes(
+		visibility.EffectiveSearchAttributes(wh.config.ValidSearchAttributes(), wh.config.DomainSearchAttributes(domainName)),
+		wh.config.DeprecatedSearchAttributes(),
+		wh.config.SearchAttributeAliases(),
+	)
 	resp = &types.GetSearchAttributesResponse{
 		Keys: wh.convertIndexedKeyToThrift(keys),
 	}
@@ -3101,6 +3115,17 @@ func verifyHistoryIsComplete(
 	}
 }
 
+// HistoryTokenEventCount returns the number of events paged over by a GetWorkflowExecutionHistory next page token,
+// as read from the workflow's mutable state when the first page was requested.
+// It returns false if the token is not a valid history token.
+func HistoryTokenEventCount(nextPageToken []byte) (int64, bool) {
+	token, err := deserializeHistoryToken(nextPageToken)
+	if err != nil || token.NextEventID <= token.FirstEventID {
+		return 0, false
+	}
+	return token.NextEventID - token.FirstEventID, true
+}
+
 func deserializeHistoryToken(bytes []byte) (*getHistoryContinuationToken, error) {
 	token := &getHistoryContinuationToken{}
 	err := json.Unmarshal(bytes, token)
@@ -1889,6 +1889,54 @@ func (s *workflowHandlerSuite) TestGetSearchAttributes() {
 	s.NotNil(resp)
 }
 
+func (s *workflowHandlerSuite) TestGetSearchAttributes_RemovedAndRenamed() {
+	config := s.newConfig(dc.NewInMemoryClient())
+	config.ValidSearchAttributes = dynamicproperties.GetMapPropertyFn(map[string]interface{}{
+		"CustomKeywordField": types.IndexedValueTypeKeyword,
+		"CustomIntField":     types.IndexedValueTypeInt,
+	})
+	config.DeprecatedSearchAttributes = dynamicproperties.GetMapPropertyFn(map[string]interface{}{"CustomIntField": true})
+	config.SearchAttributeAliases = dynamicproperties.GetMapPropertyFn(map[string]interface{}{"CustomTag": "CustomKeywordField"})
+	wh := s.getWorkflowHandler(config)
+
+	resp, err := wh.GetSearchAttributes(context.Background())
+	s.NoError(err)
+	s.Equal(map[string]types.IndexedValueType{
+		"CustomKeywordField": types.IndexedValueTypeKeyword,
+		"CustomTag":          types.IndexedValueTypeKeyword,
+	}, resp.Keys)
+}
+
+func (s *workflowHandlerSuite) TestGetSearchAttributes_Domain() {
+	config := s.newConfig(dc.NewInMemoryClient())
+	config.ValidSearchAttributes = dynamicproperties.GetMapPropertyFn(map[string]interface{}{
+		"CustomKeywordField": types.IndexedValueTypeKeyword,
+	})
+	config.DomainSearchAttributes = func(domain string) map[string]interface{} {
+		if domain == s.testDomain {
+			return map[string]interface{}{"CustomTeamField": types.IndexedValueTypeInt}
+		}
+		return map[string]interface{}{}
+	}
+	wh := s.getWorkflowHandler(config)
+
+	resp, err := wh.GetSearchAttributes(context.Background())
+	s.NoError(err)
+	s.Equal(map[string]types.IndexedValueType{
+		"CustomKeywordField": types.IndexedValueTypeKeyword,
+	}, resp.Keys)
+
+	ctx := yarpctest.ContextWithCall(context.Background(), &yarpctest.Call{
+		Headers: map[string]string{common.DomainHeaderName: s.testDomain},
+	})
+	resp, err = wh.GetSearchAttributes(ctx)
+	s.NoError(err)
+	s.Equal(map[string]types.IndexedValueType{
+		"CustomKeywordField": types.IndexedValueTypeKeyword,
+		"CustomTeamField":    types.IndexedValueTypeInt,
+	}, resp.Keys)
+}
+
 func (s *workflowHandlerSuite) TestGetWorkflowExecutionHistory__Success__RawHistoryEnabledTransientDecisionEmitted() {
 	var nextEventID int64 = 5
 	s.getWorkflowExecutionHistory(5, &types.TransientDecisionInfo{
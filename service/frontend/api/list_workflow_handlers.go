@@ -45,7 +45,7 @@ func (wh *WorkflowHandler) CountWorkflowExecutions(
 	if err := wh.requestValidator.ValidateCountWorkflowExecutionsRequest(ctx, countRequest); err != nil {
 		return nil, err
 	}
-	validatedQuery, err := wh.visibilityQueryValidator.ValidateQuery(countRequest.GetQuery())
+	validatedQuery, err := wh.visibilityQueryValidator.ValidateQuery(countRequest.GetQuery(), countRequest.GetDomain())
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +83,7 @@ func (wh *WorkflowHandler) ScanWorkflowExecutions(
 	if err := wh.requestValidator.ValidateListWorkflowExecutionsRequest(ctx, listRequest); err != nil {
 		return nil, err
 	}
-	validatedQuery, err := wh.visibilityQueryValidator.ValidateQuery(listRequest.GetQuery())
+	validatedQuery, err := wh.visibilityQueryValidator.ValidateQuery(listRequest.GetQuery(), listRequest.GetDomain())
 	if err != nil {
 		return nil, err
 	}
@@ -337,7 +337,7 @@ func (wh *WorkflowHandler) ListWorkflowExecutions(
 	if err := wh.requestValidator.ValidateListWorkflowExecutionsRequest(ctx, listRequest); err != nil {
 		return nil, err
 	}
-	validatedQuery, err := wh.visibilityQueryValidator.ValidateQuery(listRequest.GetQuery())
+	validatedQuery, err := wh.visibilityQueryValidator.ValidateQuery(listRequest.GetQuery(), listRequest.GetDomain())
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +65,8 @@ type Config struct {
 	GlobalTaskListAsyncRPS            dynamicproperties.IntPropertyFnWithDomainAndTaskListFilter
 	MaxWorkerPollDelay                dynamicproperties.DurationPropertyFnWithDomainFilter
 	RateLimiterBypassCallerTypes      dynamicproperties.ListPropertyFn
+	EnableRequestCost                 dynamicproperties.BoolPropertyFnWithDomainFilter
+	RequestCostUnitSize               dynamicproperties.IntPropertyFnWithDomainFilter
 	EnableClientVersionCheck          dynamicproperties.BoolPropertyFn
 	EnableQueryAttributeValidation    dynamicproperties.BoolPropertyFn
 	DisallowQuery                     dynamicproperties.BoolPropertyFnWithDomainFilter
@@ -110,6 +112,9 @@ type Config struct {
 
 	// ValidSearchAttributes is legal indexed keys that can be used in list APIs
 	ValidSearchAttributes             dynamicproperties.MapPropertyFn
+	DeprecatedSearchAttributes        dynamicproperties.MapPropertyFn
+	DomainSearchAttributes            dynamicproperties.MapPropertyFnWithDomainFilter
+	SearchAttributeAliases            dynamicproperties.MapPropertyFn
 	SearchAttributesNumberOfKeysLimit dynamicproperties.IntPropertyFnWithDomainFilter
 	SearchAttributesSizeOfValueLimit  dynamicproperties.IntPropertyFnWithDomainFilter
 	SearchAttributesTotalSizeLimit    dynamicproperties.IntPropertyFnWithDomainFilter
@@ -167,6 +172,8 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, isAdvancedVis
 		GlobalDomainAsyncRPS:                              dc.GetIntPropertyFilteredByDomain(dynamicproperties.FrontendGlobalDomainAsyncRPS),
 		GlobalTaskListAsyncRPS:                            dc.GetIntPropertyFilteredByDomainAndTaskList(dynamicproperties.FrontendGlobalTaskListAsyncRPS),
 		MaxWorkerPollDelay:                                dc.GetDurationPropertyFilteredByDomain(dynamicproperties.FrontendMaxWorkerPollDelay),
+		EnableRequestCost:                                 dc.GetBoolPropertyFilteredByDomain(dynamicproperties.FrontendEnableRequestCost),
+		RequestCostUnitSize:                               dc.GetIntPropertyFilteredByDomain(dynamicproperties.FrontendRequestCostUnitSize),
 		RateLimiterBypassCallerTypes:                      dc.GetListProperty(dynamicproperties.RateLimiterBypassCallerTypes),
 		GlobalRatelimiterKeyMode:                          dc.GetStringPropertyFilteredByRatelimitKey(dynamicproperties.FrontendGlobalRatelimiterMode),
 		GlobalRatelimiterUpdateInterval:                   dc.GetDurationProperty(dynamicproperties.GlobalRatelimiterUpdateInterval),
@@ -194,6 +201,9 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, isAdvancedVis
 		EnableClientVersionCheck:                          dc.GetBoolProperty(dynamicproperties.EnableClientVersionCheck),
 		EnableQueryAttributeValidation:                    dc.GetBoolProperty(dynamicproperties.EnableQueryAttributeValidation),
 		ValidSearchAttributes:                             dc.GetMapProperty(dynamicproperties.ValidSearchAttributes),
+		DeprecatedSearchAttributes:                        dc.GetMapProperty(dynamicproperties.DeprecatedSearchAttributes),
+		DomainSearchAttributes:                            dc.GetMapPropertyFilteredByDomain(dynamicproperties.DomainSearchAttributes),
+		SearchAttributeAliases:                            dc.GetMapProperty(dynamicproperties.SearchAttributeAliases),
 		SearchAttributesNumberOfKeysLimit:                 dc.GetIntPropertyFilteredByDomain(dynamicproperties.SearchAttributesNumberOfKeysLimit),
 		SearchAttributesSizeOfValueLimit:                  dc.GetIntPropertyFilteredByDomain(dynamicproperties.SearchAttributesSizeOfValueLimit),
 		SearchAttributesTotalSizeLimit:                    dc.GetIntPropertyFilteredByDomain(dynamicproperties.SearchAttributesTotalSizeLimit),
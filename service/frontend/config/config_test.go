@@ -71,6 +71,8 @@ func TestNewConfig(t *testing.T) {
 		"GlobalDomainVisibilityRPS":                         {dynamicproperties.FrontendGlobalDomainVisibilityRPS, 18},
 		"GlobalDomainAsyncRPS":                              {dynamicproperties.FrontendGlobalDomainAsyncRPS, 19},
 		"MaxWorkerPollDelay":                                {dynamicproperties.FrontendMaxWorkerPollDelay, time.Duration(30)},
+		"EnableRequestCost":                                 {dynamicproperties.FrontendEnableRequestCost, true},
+		"RequestCostUnitSize":                               {dynamicproperties.FrontendRequestCostUnitSize, 50},
 		"MaxIDLengthWarnLimit":                              {dynamicproperties.MaxIDLengthWarnLimit, 20},
 		"DomainNameMaxLength":                               {dynamicproperties.DomainNameMaxLength, 21},
 		"IdentityMaxLength":                                 {dynamicproperties.IdentityMaxLength, 22},
@@ -95,6 +97,9 @@ func TestNewConfig(t *testing.T) {
 		"EnableClientVersionCheck":                          {dynamicproperties.EnableClientVersionCheck, true},
 		"EnableQueryAttributeValidation":                    {dynamicproperties.EnableQueryAttributeValidation, false},
 		"ValidSearchAttributes":                             {dynamicproperties.ValidSearchAttributes, map[string]interface{}{"foo": "bar"}},
+		"DeprecatedSearchAttributes":                        {dynamicproperties.DeprecatedSearchAttributes, map[string]interface{}{"foo": true}},
+		"DomainSearchAttributes":                            {dynamicproperties.DomainSearchAttributes, map[string]interface{}{"baz": 1}},
+		"SearchAttributeAliases":                            {dynamicproperties.SearchAttributeAliases, map[string]interface{}{"bar": "foo"}},
 		"SearchAttributesNumberOfKeysLimit":                 {dynamicproperties.SearchAttributesNumberOfKeysLimit, 35},
 		"SearchAttributesSizeOfValueLimit":                  {dynamicproperties.SearchAttributesSizeOfValueLimit, 36},
 		"SearchAttributesTotalSizeLimit":                    {dynamicproperties.SearchAttributesTotalSizeLimit, 37},
@@ -184,6 +189,8 @@ func getValue(f *reflect.Value) interface{} {
 			return fn()
 		case dynamicproperties.MapPropertyFn:
 			return fn()
+		case dynamicproperties.MapPropertyFnWithDomainFilter:
+			return fn("domain")
 		case dynamicproperties.StringPropertyFn:
 			return fn()
 		case dynamicproperties.StringPropertyWithRatelimitKeyFilter:
@@ -104,6 +104,8 @@ func NewService(
 			ESVisibilityListMaxQPS:     serviceConfig.ESVisibilityListMaxQPS,
 			ESIndexMaxResultWindow:     serviceConfig.ESIndexMaxResultWindow,
 			ValidSearchAttributes:      serviceConfig.ValidSearchAttributes,
+			DomainSearchAttributes:     serviceConfig.DomainSearchAttributes,
+			SearchAttributeAliases:     serviceConfig.SearchAttributeAliases,
 			IsErrorRetryableFunction:   common.FrontendRetry,
 			PinotOptimizedQueryColumns: serviceConfig.PinotOptimizedQueryColumns,
 		},
@@ -159,7 +161,7 @@ func (s *Service) Start() {
 	var handler api.Handler = s.handler
 	handler = versioncheck.NewAPIHandler(handler, s.config, client.NewVersionChecker())
 	callerBypass := quotas.NewCallerBypass(s.config.RateLimiterBypassCallerTypes)
-	handler = ratelimited.NewAPIHandler(handler, s.GetDomainCache(), userRateLimiter, workerRateLimiter, visibilityRateLimiter, asyncRateLimiter, s.config.MaxWorkerPollDelay, callerBypass)
+	handler = ratelimited.NewAPIHandler(handler, s.GetDomainCache(), userRateLimiter, workerRateLimiter, visibilityRateLimiter, asyncRateLimiter, s.config.MaxWorkerPollDelay, callerBypass, s.config, s.GetMetricsClient(), s.GetDomainMetricsScopeCache())
 	handler = metered.NewAPIHandler(handler, s.GetLogger(), s.GetMetricsClient(), s.GetDomainCache(), s.config)
 	if s.params.ClusterRedirectionPolicy != nil {
 		handler = clusterredirection.NewAPIHandler(handler, s, s.config, *s.params.ClusterRedirectionPolicy)
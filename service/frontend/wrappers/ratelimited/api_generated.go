@@ -10,9 +10,11 @@ import (
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/quotas"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/frontend/api"
+	"github.com/uber/cadence/service/frontend/config"
 	"github.com/uber/cadence/service/frontend/validate"
 )
 
@@ -27,6 +29,9 @@ type apiHandler struct {
 	asyncRateLimiter      quotas.Policy
 	maxWorkerPollDelay    dynamicproperties.DurationPropertyFnWithDomainFilter
 	callerBypass          quotas.CallerBypass
+	requestCost           requestCost
+	metricsClient         metrics.Client
+	metricsScopeCache     cache.DomainMetricsScopeCache
 }
 
 // NewAPIHandler creates a new instance of Handler with ratelimiter.
@@ -39,6 +44,9 @@ func NewAPIHandler(
 	asyncRateLimiter quotas.Policy,
 	maxWorkerPollDelay dynamicproperties.DurationPropertyFnWithDomainFilter,
 	callerBypass quotas.CallerBypass,
+	cfg *config.Config,
+	metricsClient metrics.Client,
+	metricsScopeCache cache.DomainMetricsScopeCache,
 ) api.Handler {
 	return &apiHandler{
 		wrapped:               wrapped,
@@ -50,6 +58,9 @@ func NewAPIHandler(
 		asyncRateLimiter:      asyncRateLimiter,
 		maxWorkerPollDelay:    maxWorkerPollDelay,
 		callerBypass:          callerBypass,
+		requestCost:           newRequestCost(cfg),
+		metricsClient:         metricsClient,
+		metricsScopeCache:     metricsScopeCache,
 	}
 }
 
@@ -62,7 +73,7 @@ func (h *apiHandler) BackfillSchedule(ctx context.Context, bp1 *types.BackfillSc
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: bp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendBackfillScheduleScope, ratelimitTypeUser, quotas.Info{Domain: bp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -78,7 +89,7 @@ func (h *apiHandler) CountWorkflowExecutions(ctx context.Context, cp1 *types.Cou
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeVisibility, quotas.Info{Domain: cp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendCountWorkflowExecutionsScope, ratelimitTypeVisibility, quotas.Info{Domain: cp1.GetDomain(), Cost: h.requestCost.countWorkflowExecutions(cp1)}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -94,7 +105,7 @@ func (h *apiHandler) CreateSchedule(ctx context.Context, cp1 *types.CreateSchedu
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: cp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendCreateScheduleScope, ratelimitTypeUser, quotas.Info{Domain: cp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -114,7 +125,7 @@ func (h *apiHandler) DeleteSchedule(ctx context.Context, dp1 *types.DeleteSchedu
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: dp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendDeleteScheduleScope, ratelimitTypeUser, quotas.Info{Domain: dp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -138,7 +149,7 @@ func (h *apiHandler) DescribeSchedule(ctx context.Context, dp1 *types.DescribeSc
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: dp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendDescribeScheduleScope, ratelimitTypeUser, quotas.Info{Domain: dp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -154,7 +165,7 @@ func (h *apiHandler) DescribeTaskList(ctx context.Context, dp1 *types.DescribeTa
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: dp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendDescribeTaskListScope, ratelimitTypeUser, quotas.Info{Domain: dp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -170,7 +181,7 @@ func (h *apiHandler) DescribeWorkflowExecution(ctx context.Context, dp1 *types.D
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: dp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendDescribeWorkflowExecutionScope, ratelimitTypeUser, quotas.Info{Domain: dp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -186,7 +197,7 @@ func (h *apiHandler) DiagnoseWorkflowExecution(ctx context.Context, dp1 *types.D
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: dp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendDiagnoseWorkflowExecutionScope, ratelimitTypeUser, quotas.Info{Domain: dp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -202,7 +213,7 @@ func (h *apiHandler) FailoverDomain(ctx context.Context, fp1 *types.FailoverDoma
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: fp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendFailoverDomainScope, ratelimitTypeUser, quotas.Info{Domain: fp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -226,7 +237,7 @@ func (h *apiHandler) GetTaskListsByDomain(ctx context.Context, gp1 *types.GetTas
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: gp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendGetTaskListsByDomainScope, ratelimitTypeUser, quotas.Info{Domain: gp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -242,7 +253,7 @@ func (h *apiHandler) GetWorkflowExecutionHistory(ctx context.Context, gp1 *types
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: gp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendGetWorkflowExecutionHistoryScope, ratelimitTypeUser, quotas.Info{Domain: gp1.GetDomain(), Cost: h.requestCost.getWorkflowExecutionHistory(gp1)}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -262,7 +273,7 @@ func (h *apiHandler) ListArchivedWorkflowExecutions(ctx context.Context, lp1 *ty
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeVisibility, quotas.Info{Domain: lp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendListArchivedWorkflowExecutionsScope, ratelimitTypeVisibility, quotas.Info{Domain: lp1.GetDomain(), Cost: h.requestCost.listArchivedWorkflowExecutions(lp1)}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -278,7 +289,7 @@ func (h *apiHandler) ListClosedWorkflowExecutions(ctx context.Context, lp1 *type
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeVisibility, quotas.Info{Domain: lp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendListClosedWorkflowExecutionsScope, ratelimitTypeVisibility, quotas.Info{Domain: lp1.GetDomain(), Cost: h.requestCost.listClosedWorkflowExecutions(lp1)}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -302,7 +313,7 @@ func (h *apiHandler) ListOpenWorkflowExecutions(ctx context.Context, lp1 *types.
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeVisibility, quotas.Info{Domain: lp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendListOpenWorkflowExecutionsScope, ratelimitTypeVisibility, quotas.Info{Domain: lp1.GetDomain(), Cost: h.requestCost.listOpenWorkflowExecutions(lp1)}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -318,7 +329,7 @@ func (h *apiHandler) ListSchedules(ctx context.Context, lp1 *types.ListSchedules
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: lp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendListSchedulesScope, ratelimitTypeUser, quotas.Info{Domain: lp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -334,7 +345,7 @@ func (h *apiHandler) ListTaskListPartitions(ctx context.Context, lp1 *types.List
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: lp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendListTaskListPartitionsScope, ratelimitTypeUser, quotas.Info{Domain: lp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -350,7 +361,7 @@ func (h *apiHandler) ListWorkflowExecutions(ctx context.Context, lp1 *types.List
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeVisibility, quotas.Info{Domain: lp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendListWorkflowExecutionsScope, ratelimitTypeVisibility, quotas.Info{Domain: lp1.GetDomain(), Cost: h.requestCost.listWorkflowExecutions(lp1)}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -366,7 +377,7 @@ func (h *apiHandler) PauseSchedule(ctx context.Context, pp1 *types.PauseSchedule
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: pp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendPauseScheduleScope, ratelimitTypeUser, quotas.Info{Domain: pp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -382,7 +393,7 @@ func (h *apiHandler) PollForActivityTask(ctx context.Context, pp1 *types.PollFor
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeWorkerPoll, quotas.Info{Domain: pp1.GetDomain(), TaskList: getTaskListName(pp1.GetTaskList())}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendPollForActivityTaskScope, ratelimitTypeWorkerPoll, quotas.Info{Domain: pp1.GetDomain(), TaskList: getTaskListName(pp1.GetTaskList())}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -398,7 +409,7 @@ func (h *apiHandler) PollForDecisionTask(ctx context.Context, pp1 *types.PollFor
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeWorkerPoll, quotas.Info{Domain: pp1.GetDomain(), TaskList: getTaskListName(pp1.GetTaskList())}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendPollForDecisionTaskScope, ratelimitTypeWorkerPoll, quotas.Info{Domain: pp1.GetDomain(), TaskList: getTaskListName(pp1.GetTaskList())}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -414,7 +425,7 @@ func (h *apiHandler) QueryWorkflow(ctx context.Context, qp1 *types.QueryWorkflow
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: qp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendQueryWorkflowScope, ratelimitTypeUser, quotas.Info{Domain: qp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -444,7 +455,7 @@ func (h *apiHandler) RecordActivityTaskHeartbeat(ctx context.Context, rp1 *types
 	}
 	// Count the request in the host RPS,
 	// but we still accept it even if RPS is exceeded
-	h.allowDomain(ctx, ratelimitTypeWorker, quotas.Info{Domain: domainName})
+	h.allowDomain(ctx, metrics.FrontendRecordActivityTaskHeartbeatScope, ratelimitTypeWorker, quotas.Info{Domain: domainName})
 	return h.wrapped.RecordActivityTaskHeartbeat(ctx, rp1)
 }
 
@@ -459,7 +470,7 @@ func (h *apiHandler) RecordActivityTaskHeartbeatByID(ctx context.Context, rp1 *t
 	}
 	// Count the request in the host RPS,
 	// but we still accept it even if RPS is exceeded
-	h.allowDomain(ctx, ratelimitTypeWorker, quotas.Info{Domain: rp1.GetDomain()})
+	h.allowDomain(ctx, metrics.FrontendRecordActivityTaskHeartbeatByIDScope, ratelimitTypeWorker, quotas.Info{Domain: rp1.GetDomain()})
 	return h.wrapped.RecordActivityTaskHeartbeatByID(ctx, rp1)
 }
 
@@ -472,7 +483,7 @@ func (h *apiHandler) RefreshWorkflowTasks(ctx context.Context, rp1 *types.Refres
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: rp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendRefreshWorkflowTasksScope, ratelimitTypeUser, quotas.Info{Domain: rp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -492,7 +503,7 @@ func (h *apiHandler) RequestCancelWorkflowExecution(ctx context.Context, rp1 *ty
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: rp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendRequestCancelWorkflowExecutionScope, ratelimitTypeUser, quotas.Info{Domain: rp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -510,7 +521,7 @@ func (h *apiHandler) ResetStickyTaskList(ctx context.Context, rp1 *types.ResetSt
 	}
 	// Count the request in the host RPS,
 	// but we still accept it even if RPS is exceeded
-	h.allowDomain(ctx, ratelimitTypeWorker, quotas.Info{Domain: rp1.GetDomain()})
+	h.allowDomain(ctx, metrics.FrontendResetStickyTaskListScope, ratelimitTypeWorker, quotas.Info{Domain: rp1.GetDomain()})
 	return h.wrapped.ResetStickyTaskList(ctx, rp1)
 }
 
@@ -523,7 +534,7 @@ func (h *apiHandler) ResetWorkflowExecution(ctx context.Context, rp1 *types.Rese
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: rp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendResetWorkflowExecutionScope, ratelimitTypeUser, quotas.Info{Domain: rp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -553,7 +564,7 @@ func (h *apiHandler) RespondActivityTaskCanceled(ctx context.Context, rp1 *types
 	}
 	// Count the request in the host RPS,
 	// but we still accept it even if RPS is exceeded
-	h.allowDomain(ctx, ratelimitTypeWorker, quotas.Info{Domain: domainName})
+	h.allowDomain(ctx, metrics.FrontendRespondActivityTaskCanceledScope, ratelimitTypeWorker, quotas.Info{Domain: domainName})
 	return h.wrapped.RespondActivityTaskCanceled(ctx, rp1)
 }
 
@@ -568,7 +579,7 @@ func (h *apiHandler) RespondActivityTaskCanceledByID(ctx context.Context, rp1 *t
 	}
 	// Count the request in the host RPS,
 	// but we still accept it even if RPS is exceeded
-	h.allowDomain(ctx, ratelimitTypeWorker, quotas.Info{Domain: rp1.GetDomain()})
+	h.allowDomain(ctx, metrics.FrontendRespondActivityTaskCanceledByIDScope, ratelimitTypeWorker, quotas.Info{Domain: rp1.GetDomain()})
 	return h.wrapped.RespondActivityTaskCanceledByID(ctx, rp1)
 }
 
@@ -595,7 +606,7 @@ func (h *apiHandler) RespondActivityTaskCompleted(ctx context.Context, rp1 *type
 	}
 	// Count the request in the host RPS,
 	// but we still accept it even if RPS is exceeded
-	h.allowDomain(ctx, ratelimitTypeWorker, quotas.Info{Domain: domainName})
+	h.allowDomain(ctx, metrics.FrontendRespondActivityTaskCompletedScope, ratelimitTypeWorker, quotas.Info{Domain: domainName})
 	return h.wrapped.RespondActivityTaskCompleted(ctx, rp1)
 }
 
@@ -610,7 +621,7 @@ func (h *apiHandler) RespondActivityTaskCompletedByID(ctx context.Context, rp1 *
 	}
 	// Count the request in the host RPS,
 	// but we still accept it even if RPS is exceeded
-	h.allowDomain(ctx, ratelimitTypeWorker, quotas.Info{Domain: rp1.GetDomain()})
+	h.allowDomain(ctx, metrics.FrontendRespondActivityTaskCompletedByIDScope, ratelimitTypeWorker, quotas.Info{Domain: rp1.GetDomain()})
 	return h.wrapped.RespondActivityTaskCompletedByID(ctx, rp1)
 }
 
@@ -637,7 +648,7 @@ func (h *apiHandler) RespondActivityTaskFailed(ctx context.Context, rp1 *types.R
 	}
 	// Count the request in the host RPS,
 	// but we still accept it even if RPS is exceeded
-	h.allowDomain(ctx, ratelimitTypeWorker, quotas.Info{Domain: domainName})
+	h.allowDomain(ctx, metrics.FrontendRespondActivityTaskFailedScope, ratelimitTypeWorker, quotas.Info{Domain: domainName})
 	return h.wrapped.RespondActivityTaskFailed(ctx, rp1)
 }
 
@@ -652,7 +663,7 @@ func (h *apiHandler) RespondActivityTaskFailedByID(ctx context.Context, rp1 *typ
 	}
 	// Count the request in the host RPS,
 	// but we still accept it even if RPS is exceeded
-	h.allowDomain(ctx, ratelimitTypeWorker, quotas.Info{Domain: rp1.GetDomain()})
+	h.allowDomain(ctx, metrics.FrontendRespondActivityTaskFailedByIDScope, ratelimitTypeWorker, quotas.Info{Domain: rp1.GetDomain()})
 	return h.wrapped.RespondActivityTaskFailedByID(ctx, rp1)
 }
 
@@ -679,7 +690,7 @@ func (h *apiHandler) RespondDecisionTaskCompleted(ctx context.Context, rp1 *type
 	}
 	// Count the request in the host RPS,
 	// but we still accept it even if RPS is exceeded
-	h.allowDomain(ctx, ratelimitTypeWorker, quotas.Info{Domain: domainName})
+	h.allowDomain(ctx, metrics.FrontendRespondDecisionTaskCompletedScope, ratelimitTypeWorker, quotas.Info{Domain: domainName})
 	return h.wrapped.RespondDecisionTaskCompleted(ctx, rp1)
 }
 
@@ -706,7 +717,7 @@ func (h *apiHandler) RespondDecisionTaskFailed(ctx context.Context, rp1 *types.R
 	}
 	// Count the request in the host RPS,
 	// but we still accept it even if RPS is exceeded
-	h.allowDomain(ctx, ratelimitTypeWorker, quotas.Info{Domain: domainName})
+	h.allowDomain(ctx, metrics.FrontendRespondDecisionTaskFailedScope, ratelimitTypeWorker, quotas.Info{Domain: domainName})
 	return h.wrapped.RespondDecisionTaskFailed(ctx, rp1)
 }
 
@@ -733,7 +744,7 @@ func (h *apiHandler) RespondQueryTaskCompleted(ctx context.Context, rp1 *types.R
 	}
 	// Count the request in the host RPS,
 	// but we still accept it even if RPS is exceeded
-	h.allowDomain(ctx, ratelimitTypeWorker, quotas.Info{Domain: domainName})
+	h.allowDomain(ctx, metrics.FrontendRespondQueryTaskCompletedScope, ratelimitTypeWorker, quotas.Info{Domain: domainName})
 	return h.wrapped.RespondQueryTaskCompleted(ctx, rp1)
 }
 
@@ -746,7 +757,7 @@ func (h *apiHandler) RestartWorkflowExecution(ctx context.Context, rp1 *types.Re
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: rp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendRestartWorkflowExecutionScope, ratelimitTypeUser, quotas.Info{Domain: rp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -762,7 +773,7 @@ func (h *apiHandler) ScanWorkflowExecutions(ctx context.Context, lp1 *types.List
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeVisibility, quotas.Info{Domain: lp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendScanWorkflowExecutionsScope, ratelimitTypeVisibility, quotas.Info{Domain: lp1.GetDomain(), Cost: h.requestCost.scanWorkflowExecutions(lp1)}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -778,7 +789,7 @@ func (h *apiHandler) SignalWithStartWorkflowExecution(ctx context.Context, sp1 *
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: sp1.GetDomain(), TaskList: getTaskListName(sp1.GetTaskList())}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendSignalWithStartWorkflowExecutionScope, ratelimitTypeUser, quotas.Info{Domain: sp1.GetDomain(), TaskList: getTaskListName(sp1.GetTaskList())}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -794,7 +805,7 @@ func (h *apiHandler) SignalWithStartWorkflowExecutionAsync(ctx context.Context,
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeAsync, quotas.Info{Domain: sp1.GetDomain(), TaskList: getTaskListName(sp1.GetTaskList())}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendSignalWithStartWorkflowExecutionAsyncScope, ratelimitTypeAsync, quotas.Info{Domain: sp1.GetDomain(), TaskList: getTaskListName(sp1.GetTaskList())}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -810,7 +821,7 @@ func (h *apiHandler) SignalWorkflowExecution(ctx context.Context, sp1 *types.Sig
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: sp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendSignalWorkflowExecutionScope, ratelimitTypeUser, quotas.Info{Domain: sp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -826,7 +837,7 @@ func (h *apiHandler) StartWorkflowExecution(ctx context.Context, sp1 *types.Star
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: sp1.GetDomain(), TaskList: getTaskListName(sp1.GetTaskList())}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendStartWorkflowExecutionScope, ratelimitTypeUser, quotas.Info{Domain: sp1.GetDomain(), TaskList: getTaskListName(sp1.GetTaskList())}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -842,7 +853,7 @@ func (h *apiHandler) StartWorkflowExecutionAsync(ctx context.Context, sp1 *types
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeAsync, quotas.Info{Domain: sp1.GetDomain(), TaskList: getTaskListName(sp1.GetTaskList())}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendStartWorkflowExecutionAsyncScope, ratelimitTypeAsync, quotas.Info{Domain: sp1.GetDomain(), TaskList: getTaskListName(sp1.GetTaskList())}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -858,7 +869,7 @@ func (h *apiHandler) TerminateWorkflowExecution(ctx context.Context, tp1 *types.
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: tp1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendTerminateWorkflowExecutionScope, ratelimitTypeUser, quotas.Info{Domain: tp1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -874,7 +885,7 @@ func (h *apiHandler) UnpauseSchedule(ctx context.Context, up1 *types.UnpauseSche
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: up1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendUnpauseScheduleScope, ratelimitTypeUser, quotas.Info{Domain: up1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -894,7 +905,7 @@ func (h *apiHandler) UpdateSchedule(ctx context.Context, up1 *types.UpdateSchedu
 		err = validate.ErrDomainNotSet
 		return
 	}
-	if limitErr := h.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: up1.GetDomain()}); limitErr != nil {
+	if limitErr := h.allowDomain(ctx, metrics.FrontendUpdateScheduleScope, ratelimitTypeUser, quotas.Info{Domain: up1.GetDomain()}); limitErr != nil {
 		err = limitErr
 		return
 	}
@@ -0,0 +1,172 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ratelimited
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/frontend/api"
+	"github.com/uber/cadence/service/frontend/config"
+)
+
+// requestCost estimates how many ratelimiter tokens a request consumes from the amount of data it can read,
+// so a large page of workflows or of history events costs more than a call that reads a single record.
+//
+// A cost of 0 means the request consumes a single token, which is always the case while it is disabled for the domain.
+type requestCost struct {
+	enabled            dynamicproperties.BoolPropertyFnWithDomainFilter
+	unitSize           dynamicproperties.IntPropertyFnWithDomainFilter
+	visibilityPageSize dynamicproperties.IntPropertyFnWithDomainFilter
+	historyPageSize    dynamicproperties.IntPropertyFnWithDomainFilter
+}
+
+func newRequestCost(cfg *config.Config) requestCost {
+	return requestCost{
+		enabled:            cfg.EnableRequestCost,
+		unitSize:           cfg.RequestCostUnitSize,
+		visibilityPageSize: cfg.VisibilityMaxPageSize,
+		historyPageSize:    cfg.HistoryMaxPageSize,
+	}
+}
+
+func (c requestCost) countWorkflowExecutions(req *types.CountWorkflowExecutionsRequest) int {
+	if !c.enabled(req.GetDomain()) {
+		return 0
+	}
+	return queryConditions(req.GetQuery())
+}
+
+func (c requestCost) listWorkflowExecutions(req *types.ListWorkflowExecutionsRequest) int {
+	return c.visibility(req.GetDomain(), req.GetPageSize(), req.GetQuery())
+}
+
+func (c requestCost) scanWorkflowExecutions(req *types.ListWorkflowExecutionsRequest) int {
+	return c.visibility(req.GetDomain(), req.GetPageSize(), req.GetQuery())
+}
+
+func (c requestCost) listArchivedWorkflowExecutions(req *types.ListArchivedWorkflowExecutionsRequest) int {
+	return c.visibility(req.GetDomain(), req.GetPageSize(), req.GetQuery())
+}
+
+func (c requestCost) listOpenWorkflowExecutions(req *types.ListOpenWorkflowExecutionsRequest) int {
+	return c.visibility(req.GetDomain(), req.GetMaximumPageSize(), "")
+}
+
+func (c requestCost) listClosedWorkflowExecutions(req *types.ListClosedWorkflowExecutionsRequest) int {
+	return c.visibility(req.GetDomain(), req.GetMaximumPageSize(), "")
+}
+
+// getWorkflowExecutionHistory charges for the events a page can return, which the handler limits to
+// constants.GetHistoryMaxPageSize. Pages after the first one are also bounded by the size of the history read
+// from mutable state, which is carried in the next page token.
+func (c requestCost) getWorkflowExecutionHistory(req *types.GetWorkflowExecutionHistoryRequest) int {
+	domain := req.GetDomain()
+	if !c.enabled(domain) {
+		return 0
+	}
+	if req.GetHistoryEventFilterType() == types.HistoryEventFilterTypeCloseEvent {
+		return 1
+	}
+	events := int64(req.GetMaximumPageSize())
+	if events <= 0 {
+		events = int64(c.historyPageSize(domain))
+	}
+	events = min(events, constants.GetHistoryMaxPageSize)
+	if historySize, ok := api.HistoryTokenEventCount(req.GetNextPageToken()); ok {
+		events = min(events, historySize)
+	}
+	return c.units(domain, events)
+}
+
+// visibility charges for the page of workflows a request can return, plus one token per condition
+// of its query beyond the first one
+func (c requestCost) visibility(domain string, pageSize int32, query string) int {
+	if !c.enabled(domain) {
+		return 0
+	}
+	if pageSize <= 0 {
+		pageSize = int32(c.visibilityPageSize(domain))
+	}
+	return c.units(domain, int64(pageSize)) + max(0, queryConditions(query)-1)
+}
+
+func (c requestCost) units(domain string, items int64) int {
+	unitSize := int64(max(1, c.unitSize(domain)))
+	return int(max(1, (items+unitSize-1)/unitSize))
+}
+
+// queryConditions estimates the complexity of a visibility query by its number of conditions,
+// i.e. one more than its number of "and" and "or" operators outside of quoted values.
+// A query without a filter, e.g. only an "order by" clause, has no condition.
+func queryConditions(query string) int {
+	hasFilter := false
+	operators := 0
+	var quote rune
+	var word strings.Builder
+	// endWord returns true once the filter part of the query is over
+	endWord := func() bool {
+		w := strings.ToLower(word.String())
+		word.Reset()
+		switch w {
+		case "":
+		case "and", "or":
+			operators++
+		case "order":
+			return true
+		default:
+			hasFilter = true
+		}
+		return false
+	}
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+			hasFilter = true
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			word.WriteRune(r)
+		default:
+			if endWord() {
+				return filterConditions(hasFilter, operators)
+			}
+			if !unicode.IsSpace(r) {
+				hasFilter = true
+			}
+		}
+	}
+	endWord()
+	return filterConditions(hasFilter, operators)
+}
+
+func filterConditions(hasFilter bool, operators int) int {
+	if !hasFilter {
+		return 0
+	}
+	return operators + 1
+}
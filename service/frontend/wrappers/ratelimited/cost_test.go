@@ -0,0 +1,203 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ratelimited
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestQueryConditions(t *testing.T) {
+	cases := map[string]int{
+		"":                                       0,
+		"order by StartTime desc":                0,
+		"ORDER BY StartTime":                     0,
+		"WorkflowType = 'a'":                     1,
+		"CloseStatus = 1 and WorkflowType = 'a'": 2,
+		"(CloseStatus = 1 OR CloseStatus = 2) and WorkflowType = 'a' order by StartTime": 3,
+		"WorkflowType = 'a and b or c'":                   1,
+		`CustomKeywordField = "x" and CustomIntField > 5`: 2,
+		"Operator = 'a' and Ordering = 'b'":               2,
+	}
+	for query, expected := range cases {
+		t.Run(query, func(t *testing.T) {
+			assert.Equal(t, expected, queryConditions(query))
+		})
+	}
+}
+
+func TestRequestCost(t *testing.T) {
+	historyToken := func(t *testing.T, firstEventID, nextEventID int64) []byte {
+		token, err := json.Marshal(map[string]int64{"FirstEventID": firstEventID, "NextEventID": nextEventID})
+		require.NoError(t, err)
+		return token
+	}
+	closeEvent := types.HistoryEventFilterTypeCloseEvent
+
+	cases := []struct {
+		name     string
+		disabled bool
+		cost     func(t *testing.T, c requestCost) int
+		expected int
+	}{
+		{
+			name: "disabled",
+			cost: func(t *testing.T, c requestCost) int {
+				return c.listWorkflowExecutions(&types.ListWorkflowExecutionsRequest{Domain: testDomain, PageSize: 1000})
+			},
+			disabled: true,
+			expected: 0,
+		},
+		{
+			name: "list charges page size",
+			cost: func(t *testing.T, c requestCost) int {
+				return c.listWorkflowExecutions(&types.ListWorkflowExecutionsRequest{Domain: testDomain, PageSize: 1000})
+			},
+			expected: 10,
+		},
+		{
+			name: "list rounds page cost up",
+			cost: func(t *testing.T, c requestCost) int {
+				return c.listWorkflowExecutions(&types.ListWorkflowExecutionsRequest{Domain: testDomain, PageSize: 101})
+			},
+			expected: 2,
+		},
+		{
+			name: "list defaults to max page size",
+			cost: func(t *testing.T, c requestCost) int {
+				return c.listWorkflowExecutions(&types.ListWorkflowExecutionsRequest{Domain: testDomain})
+			},
+			expected: 5,
+		},
+		{
+			name: "scan charges query conditions",
+			cost: func(t *testing.T, c requestCost) int {
+				return c.scanWorkflowExecutions(&types.ListWorkflowExecutionsRequest{Domain: testDomain, PageSize: 10, Query: "a = 1 and b = 2 or c = 3"})
+			},
+			expected: 3,
+		},
+		{
+			name: "archived list",
+			cost: func(t *testing.T, c requestCost) int {
+				return c.listArchivedWorkflowExecutions(&types.ListArchivedWorkflowExecutionsRequest{Domain: testDomain, PageSize: 200, Query: "a = 1 and b = 2"})
+			},
+			expected: 3,
+		},
+		{
+			name: "open list",
+			cost: func(t *testing.T, c requestCost) int {
+				return c.listOpenWorkflowExecutions(&types.ListOpenWorkflowExecutionsRequest{Domain: testDomain, MaximumPageSize: 300})
+			},
+			expected: 3,
+		},
+		{
+			name: "closed list",
+			cost: func(t *testing.T, c requestCost) int {
+				return c.listClosedWorkflowExecutions(&types.ListClosedWorkflowExecutionsRequest{Domain: testDomain})
+			},
+			expected: 5,
+		},
+		{
+			name: "count charges query conditions only",
+			cost: func(t *testing.T, c requestCost) int {
+				return c.countWorkflowExecutions(&types.CountWorkflowExecutionsRequest{Domain: testDomain, Query: "a = 1 and b = 2"})
+			},
+			expected: 2,
+		},
+		{
+			name: "first history page charges page size",
+			cost: func(t *testing.T, c requestCost) int {
+				return c.getWorkflowExecutionHistory(&types.GetWorkflowExecutionHistoryRequest{Domain: testDomain})
+			},
+			expected: 10,
+		},
+		{
+			name: "history page size is clamped to the server max",
+			cost: func(t *testing.T, c requestCost) int {
+				return c.getWorkflowExecutionHistory(&types.GetWorkflowExecutionHistoryRequest{Domain: testDomain, MaximumPageSize: 100_000})
+			},
+			expected: 10,
+		},
+		{
+			name: "default history page size is clamped to the server max",
+			cost: func(t *testing.T, c requestCost) int {
+				c.historyPageSize = dynamicproperties.GetIntPropertyFilteredByDomain(5000)
+				return c.getWorkflowExecutionHistory(&types.GetWorkflowExecutionHistoryRequest{Domain: testDomain})
+			},
+			expected: 10,
+		},
+		{
+			name: "history pages of large workflows charge page size",
+			cost: func(t *testing.T, c requestCost) int {
+				return c.getWorkflowExecutionHistory(&types.GetWorkflowExecutionHistoryRequest{
+					Domain:          testDomain,
+					MaximumPageSize: 500,
+					NextPageToken:   historyToken(t, 1, 50_001),
+				})
+			},
+			expected: 5,
+		},
+		{
+			name: "history pages of small workflows charge history size",
+			cost: func(t *testing.T, c requestCost) int {
+				return c.getWorkflowExecutionHistory(&types.GetWorkflowExecutionHistoryRequest{
+					Domain:        testDomain,
+					NextPageToken: historyToken(t, 1, 151),
+				})
+			},
+			expected: 2,
+		},
+		{
+			name: "invalid history token charges page size",
+			cost: func(t *testing.T, c requestCost) int {
+				return c.getWorkflowExecutionHistory(&types.GetWorkflowExecutionHistoryRequest{
+					Domain:        testDomain,
+					NextPageToken: []byte("not a token"),
+				})
+			},
+			expected: 10,
+		},
+		{
+			name: "close event only",
+			cost: func(t *testing.T, c requestCost) int {
+				return c.getWorkflowExecutionHistory(&types.GetWorkflowExecutionHistoryRequest{Domain: testDomain, HistoryEventFilterType: &closeEvent})
+			},
+			expected: 1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := requestCost{
+				enabled:            dynamicproperties.GetBoolPropertyFnFilteredByDomain(!tc.disabled),
+				unitSize:           dynamicproperties.GetIntPropertyFilteredByDomain(100),
+				visibilityPageSize: dynamicproperties.GetIntPropertyFilteredByDomain(500),
+				historyPageSize:    dynamicproperties.GetIntPropertyFilteredByDomain(1000),
+			}
+			assert.Equal(t, tc.expected, tc.cost(t, c))
+		})
+	}
+}
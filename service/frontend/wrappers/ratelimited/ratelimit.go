@@ -26,6 +26,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/quotas"
 	"github.com/uber/cadence/common/types"
 )
@@ -47,7 +48,31 @@ func newErrRateLimited() error {
 	return &errRateLimited
 }
 
-func (h *apiHandler) allowDomain(ctx context.Context, requestType ratelimitType, info quotas.Info) error {
+func (h *apiHandler) allowDomain(ctx context.Context, scope metrics.ScopeIdx, requestType ratelimitType, info quotas.Info) error {
+	err := h.allowPolicy(ctx, requestType, info)
+	// requests without a cost, which includes every request while it is disabled for the domain, consume a single token
+	if err == nil && info.Cost > 0 {
+		h.getOrCreateDomainTaggedScope(scope, info.Domain).AddCounter(metrics.RequestCostUnitsCounter, int64(info.Cost))
+	}
+	return err
+}
+
+// getOrCreateDomainTaggedScope returns cached domain-tagged metrics scope if exists
+// otherwise, it creates a new domain-tagged scope, cache and return the scope
+func (h *apiHandler) getOrCreateDomainTaggedScope(scopeIdx metrics.ScopeIdx, domain string) metrics.Scope {
+	domainID, err := h.domainCache.GetDomainID(domain)
+	if err != nil {
+		return h.metricsClient.Scope(scopeIdx, metrics.DomainTag(domain))
+	}
+	scope, ok := h.metricsScopeCache.Get(domainID, scopeIdx)
+	if !ok {
+		scope = h.metricsClient.Scope(scopeIdx, metrics.DomainTag(domain))
+		h.metricsScopeCache.Put(domainID, scopeIdx, scope)
+	}
+	return scope
+}
+
+func (h *apiHandler) allowPolicy(ctx context.Context, requestType ratelimitType, info quotas.Info) error {
 	var policy quotas.Policy
 	switch requestType {
 	case ratelimitTypeUser:
@@ -7,15 +7,18 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/uber-go/tally"
 	"go.uber.org/mock/gomock"
 
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/quotas"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/frontend/api"
+	"github.com/uber/cadence/service/frontend/config"
 )
 
 const testDomain = "test-domain"
@@ -45,6 +48,14 @@ func setupHandlerWithDC(t *testing.T, dc *dynamicconfig.Collection) *apiHandler
 		&mockPolicy{}, // asyncRateLimiter
 		func(domain string) time.Duration { return 0 }, // maxWorkerPollDelay
 		callerBypass,
+		&config.Config{
+			EnableRequestCost:     dynamicproperties.GetBoolPropertyFnFilteredByDomain(false),
+			RequestCostUnitSize:   dynamicproperties.GetIntPropertyFilteredByDomain(100),
+			VisibilityMaxPageSize: dynamicproperties.GetIntPropertyFilteredByDomain(1000),
+			HistoryMaxPageSize:    dynamicproperties.GetIntPropertyFilteredByDomain(1000),
+		},
+		metrics.NewNoopMetricsClient(),
+		cache.NewDomainMetricsScopeCache(),
 	).(*apiHandler)
 }
 
@@ -112,7 +123,7 @@ func TestAllowDomainRequestRouting(t *testing.T) {
 			handler := setupHandler(t)
 			tc.setupMock(handler)
 
-			err := handler.allowDomain(context.Background(), tc.requestType, quotas.Info{Domain: testDomain})
+			err := handler.allowDomain(context.Background(), metrics.FrontendStartWorkflowExecutionScope, tc.requestType, quotas.Info{Domain: testDomain})
 
 			if tc.expectedErr != nil {
 				assert.Error(t, err)
@@ -167,6 +178,22 @@ func TestHandlerRpcRouting(t *testing.T) {
 				h.wrapped.(*api.MockHandler).EXPECT().CountWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&types.CountWorkflowExecutionsResponse{}, nil).Times(1)
 			},
 		},
+		{
+			name: "ListWorkflowExecutions charges its page size and query conditions when request cost is enabled",
+			operation: func(h *apiHandler) (interface{}, error) {
+				return h.ListWorkflowExecutions(context.Background(), &types.ListWorkflowExecutionsRequest{
+					Domain:   testDomain,
+					PageSize: 500,
+					Query:    "WorkflowType = 'a' and CloseStatus = 1 order by StartTime",
+				})
+			},
+			limiterSetup: func(h *apiHandler) {
+				h.requestCost.enabled = dynamicproperties.GetBoolPropertyFnFilteredByDomain(true)
+				h.visibilityRateLimiter.(*mockPolicy).On("Allow", quotas.Info{Domain: testDomain, Cost: 6}).Return(true).Once()
+				h.domainCache.(*cache.MockDomainCache).EXPECT().GetDomainID(testDomain).Return("test-domain-id", nil).Times(1)
+				h.wrapped.(*api.MockHandler).EXPECT().ListWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&types.ListWorkflowExecutionsResponse{}, nil).Times(1)
+			},
+		},
 		{
 			name: "DescribeTaskList uses user limiter with Allow",
 			operation: func(h *apiHandler) (interface{}, error) {
@@ -198,6 +225,32 @@ func TestHandlerRpcRouting(t *testing.T) {
 	}
 }
 
+func TestAllowDomainEmitsCostUnits(t *testing.T) {
+	handler := setupHandler(t)
+	testScope := tally.NewTestScope("test", nil)
+	handler.metricsClient = metrics.NewClient(testScope, metrics.Frontend, metrics.MigrationConfig{})
+	handler.domainCache.(*cache.MockDomainCache).EXPECT().GetDomainID(testDomain).Return("test-domain-id", nil).Times(1)
+	handler.visibilityRateLimiter.(*mockPolicy).On("Allow", quotas.Info{Domain: testDomain}).Return(true).Once()
+	handler.visibilityRateLimiter.(*mockPolicy).On("Allow", quotas.Info{Domain: testDomain, Cost: 5}).Return(true).Once()
+	handler.visibilityRateLimiter.(*mockPolicy).On("Allow", quotas.Info{Domain: testDomain, Cost: 7}).Return(false).Once()
+
+	assert.NoError(t, handler.allowDomain(context.Background(), metrics.FrontendListWorkflowExecutionsScope, ratelimitTypeVisibility, quotas.Info{Domain: testDomain, Cost: 5}))
+	assert.NoError(t, handler.allowDomain(context.Background(), metrics.FrontendListWorkflowExecutionsScope, ratelimitTypeVisibility, quotas.Info{Domain: testDomain}))
+	assert.Error(t, handler.allowDomain(context.Background(), metrics.FrontendListWorkflowExecutionsScope, ratelimitTypeVisibility, quotas.Info{Domain: testDomain, Cost: 7}))
+
+	var found bool
+	for _, counter := range testScope.Snapshot().Counters() {
+		if counter.Name() == "test.request_cost_units" {
+			found = true
+			assert.Equal(t, int64(5), counter.Value(), "only allowed requests with a cost should consume cost units")
+			assert.Equal(t, testDomain, counter.Tags()["domain"])
+			assert.Equal(t, "ListWorkflowExecutions", counter.Tags()["operation"])
+		}
+	}
+	assert.True(t, found, "request_cost_units counter not found")
+	handler.visibilityRateLimiter.(*mockPolicy).AssertExpectations(t)
+}
+
 type mockPolicy struct {
 	mock.Mock
 }
@@ -272,7 +325,7 @@ func TestCallerTypeBypass(t *testing.T) {
 				handler.userRateLimiter.(*mockPolicy).On("Allow", quotas.Info{Domain: testDomain}).Return(true).Once()
 			}
 
-			err := handler.allowDomain(ctx, ratelimitTypeUser, quotas.Info{Domain: testDomain})
+			err := handler.allowDomain(ctx, metrics.FrontendStartWorkflowExecutionScope, ratelimitTypeUser, quotas.Info{Domain: testDomain})
 
 			if tt.expectBypass {
 				assert.NoError(t, err, "Expected bypass to allow request")
@@ -329,7 +382,7 @@ func TestCallerTypeBypassWithWait(t *testing.T) {
 			handler.workerRateLimiter.(*mockPolicy).On("Allow", quotas.Info{Domain: testDomain}).Return(false).Maybe()
 			handler.workerRateLimiter.(*mockPolicy).On("Wait", mock.Anything, quotas.Info{Domain: testDomain}).Return(assert.AnError).Once()
 
-			err := handler.allowDomain(ctx, ratelimitTypeWorkerPoll, quotas.Info{Domain: testDomain})
+			err := handler.allowDomain(ctx, metrics.FrontendPollForActivityTaskScope, ratelimitTypeWorkerPoll, quotas.Info{Domain: testDomain})
 
 			if tt.expectBypass {
 				assert.NoError(t, err, "Expected bypass to allow request")
@@ -48,6 +48,10 @@ type Config struct {
 	TimerIDMaxLength                 dynamicproperties.IntPropertyFnWithDomainFilter
 	PersistenceMaxQPS                dynamicproperties.IntPropertyFn
 	PersistenceGlobalMaxQPS          dynamicproperties.IntPropertyFn
+	PersistenceDomainMaxQPS          dynamicproperties.IntPropertyFnWithDomainFilter
+	PersistenceShardMaxQPS           dynamicproperties.IntPropertyFn
+	PersistenceDomainMaxWait         dynamicproperties.DurationPropertyFnWithDomainFilter
+	RateLimiterBypassCallerTypes     dynamicproperties.ListPropertyFn
 	EnableVisibilitySampling         dynamicproperties.BoolPropertyFn
 	EnableReadFromClosedExecutionV2  dynamicproperties.BoolPropertyFn
 	VisibilityOpenMaxQPS             dynamicproperties.IntPropertyFnWithDomainFilter
@@ -197,6 +201,8 @@ type Config struct {
 	ReplicationBudgetManagerMaxSizeBytes     dynamicproperties.IntPropertyFn
 	ReplicationBudgetManagerMaxSizeCount     dynamicproperties.IntPropertyFn
 	ReplicationBudgetManagerSoftCapThreshold dynamicproperties.FloatPropertyFn
+	ReplicationStatusMaxScanTasks            dynamicproperties.IntPropertyFn
+	ReplicationLagSLOThreshold               dynamicproperties.DurationPropertyFnWithDomainFilter
 
 	// System Limits
 	MaximumBufferedEventsBatch dynamicproperties.IntPropertyFn
@@ -249,11 +255,17 @@ type Config struct {
 	// ValidSearchAttributes is legal indexed keys that can be used in list APIs
 	EnableQueryAttributeValidation    dynamicproperties.BoolPropertyFn
 	ValidSearchAttributes             dynamicproperties.MapPropertyFn
+	DeprecatedSearchAttributes        dynamicproperties.MapPropertyFn
+	DomainSearchAttributes            dynamicproperties.MapPropertyFnWithDomainFilter
+	SearchAttributeAliases            dynamicproperties.MapPropertyFn
 	SearchAttributesNumberOfKeysLimit dynamicproperties.IntPropertyFnWithDomainFilter
 	SearchAttributesSizeOfValueLimit  dynamicproperties.IntPropertyFnWithDomainFilter
 	SearchAttributesTotalSizeLimit    dynamicproperties.IntPropertyFnWithDomainFilter
 	SearchAttributesHiddenValueKeys   dynamicproperties.MapPropertyFn
 
+	// EnableChildSearchAttributeValidation gates validating the search attributes of child workflows in their domain
+	EnableChildSearchAttributeValidation dynamicproperties.BoolPropertyFnWithDomainFilter
+
 	// Decision settings
 	// StickyTTL is to expire a sticky tasklist if no update more than this duration
 	// TODO https://github.com/uber/cadence/issues/2357
@@ -382,6 +394,10 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, i
 		TimerIDMaxLength:                     dc.GetIntPropertyFilteredByDomain(dynamicproperties.TimerIDMaxLength),
 		PersistenceMaxQPS:                    dc.GetIntProperty(dynamicproperties.HistoryPersistenceMaxQPS),
 		PersistenceGlobalMaxQPS:              dc.GetIntProperty(dynamicproperties.HistoryPersistenceGlobalMaxQPS),
+		PersistenceDomainMaxQPS:              dc.GetIntPropertyFilteredByDomain(dynamicproperties.HistoryPersistenceDomainMaxQPS),
+		PersistenceShardMaxQPS:               dc.GetIntProperty(dynamicproperties.HistoryPersistenceShardMaxQPS),
+		PersistenceDomainMaxWait:             dc.GetDurationPropertyFilteredByDomain(dynamicproperties.HistoryPersistenceDomainMaxWait),
+		RateLimiterBypassCallerTypes:         dc.GetListProperty(dynamicproperties.RateLimiterBypassCallerTypes),
 		ShutdownDrainDuration:                dc.GetDurationProperty(dynamicproperties.HistoryShutdownDrainDuration),
 		EnableVisibilitySampling:             dc.GetBoolProperty(dynamicproperties.EnableVisibilitySampling),
 		EnableReadFromClosedExecutionV2:      dc.GetBoolProperty(dynamicproperties.EnableReadFromClosedExecutionV2),
@@ -513,6 +529,8 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, i
 		ReplicationBudgetManagerMaxSizeBytes:     dc.GetIntProperty(dynamicproperties.ReplicationBudgetManagerMaxSizeBytes),
 		ReplicationBudgetManagerMaxSizeCount:     dc.GetIntProperty(dynamicproperties.ReplicationBudgetManagerMaxSizeCount),
 		ReplicationBudgetManagerSoftCapThreshold: dc.GetFloat64Property(dynamicproperties.ReplicationBudgetManagerSoftCapThreshold),
+		ReplicationStatusMaxScanTasks:            dc.GetIntProperty(dynamicproperties.ReplicationStatusMaxScanTasks),
+		ReplicationLagSLOThreshold:               dc.GetDurationPropertyFilteredByDomain(dynamicproperties.ReplicationLagSLOThreshold),
 
 		MaximumBufferedEventsBatch:      dc.GetIntProperty(dynamicproperties.MaximumBufferedEventsBatch),
 		MaximumSignalsPerExecution:      dc.GetIntPropertyFilteredByDomain(dynamicproperties.MaximumSignalsPerExecution),
@@ -552,10 +570,14 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, i
 
 		EnableQueryAttributeValidation:           dc.GetBoolProperty(dynamicproperties.EnableQueryAttributeValidation),
 		ValidSearchAttributes:                    dc.GetMapProperty(dynamicproperties.ValidSearchAttributes),
+		DeprecatedSearchAttributes:               dc.GetMapProperty(dynamicproperties.DeprecatedSearchAttributes),
+		DomainSearchAttributes:                   dc.GetMapPropertyFilteredByDomain(dynamicproperties.DomainSearchAttributes),
+		SearchAttributeAliases:                   dc.GetMapProperty(dynamicproperties.SearchAttributeAliases),
 		SearchAttributesNumberOfKeysLimit:        dc.GetIntPropertyFilteredByDomain(dynamicproperties.SearchAttributesNumberOfKeysLimit),
 		SearchAttributesSizeOfValueLimit:         dc.GetIntPropertyFilteredByDomain(dynamicproperties.SearchAttributesSizeOfValueLimit),
 		SearchAttributesTotalSizeLimit:           dc.GetIntPropertyFilteredByDomain(dynamicproperties.SearchAttributesTotalSizeLimit),
 		SearchAttributesHiddenValueKeys:          dc.GetMapProperty(dynamicproperties.SearchAttributesHiddenValueKeys),
+		EnableChildSearchAttributeValidation:     dc.GetBoolPropertyFilteredByDomain(dynamicproperties.EnableChildSearchAttributeValidation),
 		StickyTTL:                                dc.GetDurationPropertyFilteredByDomain(dynamicproperties.StickyTTL),
 		DecisionHeartbeatTimeout:                 dc.GetDurationPropertyFilteredByDomain(dynamicproperties.DecisionHeartbeatTimeout),
 		DecisionRetryCriticalAttempts:            dc.GetIntProperty(dynamicproperties.DecisionRetryCriticalAttempts),
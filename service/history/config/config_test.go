@@ -63,6 +63,10 @@ func TestNewConfig(t *testing.T) {
 		"TimerIDMaxLength":                                     {dynamicproperties.TimerIDMaxLength, 13},
 		"PersistenceMaxQPS":                                    {dynamicproperties.HistoryPersistenceMaxQPS, 14},
 		"PersistenceGlobalMaxQPS":                              {dynamicproperties.HistoryPersistenceGlobalMaxQPS, 15},
+		"PersistenceDomainMaxQPS":                              {dynamicproperties.HistoryPersistenceDomainMaxQPS, 150},
+		"PersistenceShardMaxQPS":                               {dynamicproperties.HistoryPersistenceShardMaxQPS, 600},
+		"PersistenceDomainMaxWait":                             {dynamicproperties.HistoryPersistenceDomainMaxWait, time.Second},
+		"RateLimiterBypassCallerTypes":                         {dynamicproperties.RateLimiterBypassCallerTypes, []interface{}{"cli", "ui"}},
 		"EnableVisibilitySampling":                             {dynamicproperties.EnableVisibilitySampling, true},
 		"EnableReadFromClosedExecutionV2":                      {dynamicproperties.EnableReadFromClosedExecutionV2, true},
 		"VisibilityOpenMaxQPS":                                 {dynamicproperties.HistoryVisibilityOpenMaxQPS, 16},
@@ -174,6 +178,8 @@ func TestNewConfig(t *testing.T) {
 		"ReplicationBudgetManagerMaxSizeBytes":                 {dynamicproperties.ReplicationBudgetManagerMaxSizeBytes, 0},
 		"ReplicationBudgetManagerMaxSizeCount":                 {dynamicproperties.ReplicationBudgetManagerMaxSizeCount, 0},
 		"ReplicationBudgetManagerSoftCapThreshold":             {dynamicproperties.ReplicationBudgetManagerSoftCapThreshold, 1.0},
+		"ReplicationStatusMaxScanTasks":                        {dynamicproperties.ReplicationStatusMaxScanTasks, 500},
+		"ReplicationLagSLOThreshold":                           {dynamicproperties.ReplicationLagSLOThreshold, time.Minute},
 		"MaximumBufferedEventsBatch":                           {dynamicproperties.MaximumBufferedEventsBatch, 59},
 		"MaximumSignalsPerExecution":                           {dynamicproperties.MaximumSignalsPerExecution, 60},
 		"ShardUpdateMinInterval":                               {dynamicproperties.ShardUpdateMinInterval, time.Second},
@@ -204,6 +210,10 @@ func TestNewConfig(t *testing.T) {
 		"PendingActivityValidationEnabled":                     {dynamicproperties.EnablePendingActivityValidation, true},
 		"EnableQueryAttributeValidation":                       {dynamicproperties.EnableQueryAttributeValidation, true},
 		"ValidSearchAttributes":                                {dynamicproperties.ValidSearchAttributes, map[string]interface{}{"key": 1}},
+		"DeprecatedSearchAttributes":                           {dynamicproperties.DeprecatedSearchAttributes, map[string]interface{}{"key": true}},
+		"DomainSearchAttributes":                               {dynamicproperties.DomainSearchAttributes, map[string]interface{}{"key": 1}},
+		"SearchAttributeAliases":                               {dynamicproperties.SearchAttributeAliases, map[string]interface{}{"alias": "key"}},
+		"EnableChildSearchAttributeValidation":                 {dynamicproperties.EnableChildSearchAttributeValidation, true},
 		"SearchAttributesNumberOfKeysLimit":                    {dynamicproperties.SearchAttributesNumberOfKeysLimit, 78},
 		"SearchAttributesSizeOfValueLimit":                     {dynamicproperties.SearchAttributesSizeOfValueLimit, 79},
 		"SearchAttributesTotalSizeLimit":                       {dynamicproperties.SearchAttributesTotalSizeLimit, 80},
@@ -367,6 +377,8 @@ func getValue(f *reflect.Value) interface{} {
 			return fn()
 		case dynamicproperties.StringPropertyFn:
 			return fn()
+		case dynamicproperties.ListPropertyFn:
+			return fn()
 		case dynamicproperties.DurationPropertyFnWithDomainIDFilter:
 			return fn("domain")
 		case dynamicproperties.IntPropertyFnWithShardIDFilter:
@@ -85,6 +85,9 @@ func newAttrValidator(
 			logger,
 			config.EnableQueryAttributeValidation,
 			config.ValidSearchAttributes,
+			config.DeprecatedSearchAttributes,
+			config.DomainSearchAttributes,
+			config.SearchAttributeAliases,
 			config.SearchAttributesNumberOfKeysLimit,
 			config.SearchAttributesSizeOfValueLimit,
 			config.SearchAttributesTotalSizeLimit,
@@ -795,6 +798,19 @@ func (v *attrValidator) validateStartChildExecutionAttributes(
 		attributes.TaskStartToCloseTimeoutSeconds = common.Int32Ptr(parentInfo.DecisionStartToCloseTimeout)
 	}
 
+	// Child search attributes are indexed in the target domain, so they are validated against its search attributes and limits.
+	// Children were started with any search attributes before, so this is only enforced for domains which opt in.
+	if attributes.SearchAttributes != nil {
+		targetDomainName, err := v.domainCache.GetDomainName(targetDomainID)
+		if err != nil {
+			return err
+		}
+		if !v.config.EnableChildSearchAttributeValidation(targetDomainName) {
+			return nil
+		}
+		return v.searchAttributesValidator.ValidateSearchAttributes(attributes.SearchAttributes, targetDomainName)
+	}
+
 	return nil
 }
 
@@ -88,19 +88,30 @@ func (s *attrValidatorSuite) SetupTest() {
 	s.controller = gomock.NewController(s.T())
 	s.mockDomainCache = cache.NewMockDomainCache(s.controller)
 	config := &config.Config{
-		MaxIDLengthWarnLimit:              dynamicproperties.GetIntPropertyFn(128),
-		DomainNameMaxLength:               dynamicproperties.GetIntPropertyFilteredByDomain(1000),
-		IdentityMaxLength:                 dynamicproperties.GetIntPropertyFilteredByDomain(1000),
-		WorkflowIDMaxLength:               dynamicproperties.GetIntPropertyFilteredByDomain(1000),
-		SignalNameMaxLength:               dynamicproperties.GetIntPropertyFilteredByDomain(1000),
-		WorkflowTypeMaxLength:             dynamicproperties.GetIntPropertyFilteredByDomain(1000),
-		RequestIDMaxLength:                dynamicproperties.GetIntPropertyFilteredByDomain(1000),
-		TaskListNameMaxLength:             dynamicproperties.GetIntPropertyFilteredByDomain(1000),
-		ActivityIDMaxLength:               dynamicproperties.GetIntPropertyFilteredByDomain(1000),
-		ActivityTypeMaxLength:             dynamicproperties.GetIntPropertyFilteredByDomain(1000),
-		MarkerNameMaxLength:               dynamicproperties.GetIntPropertyFilteredByDomain(1000),
-		TimerIDMaxLength:                  dynamicproperties.GetIntPropertyFilteredByDomain(1000),
-		ValidSearchAttributes:             dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+		MaxIDLengthWarnLimit:       dynamicproperties.GetIntPropertyFn(128),
+		DomainNameMaxLength:        dynamicproperties.GetIntPropertyFilteredByDomain(1000),
+		IdentityMaxLength:          dynamicproperties.GetIntPropertyFilteredByDomain(1000),
+		WorkflowIDMaxLength:        dynamicproperties.GetIntPropertyFilteredByDomain(1000),
+		SignalNameMaxLength:        dynamicproperties.GetIntPropertyFilteredByDomain(1000),
+		WorkflowTypeMaxLength:      dynamicproperties.GetIntPropertyFilteredByDomain(1000),
+		RequestIDMaxLength:         dynamicproperties.GetIntPropertyFilteredByDomain(1000),
+		TaskListNameMaxLength:      dynamicproperties.GetIntPropertyFilteredByDomain(1000),
+		ActivityIDMaxLength:        dynamicproperties.GetIntPropertyFilteredByDomain(1000),
+		ActivityTypeMaxLength:      dynamicproperties.GetIntPropertyFilteredByDomain(1000),
+		MarkerNameMaxLength:        dynamicproperties.GetIntPropertyFilteredByDomain(1000),
+		TimerIDMaxLength:           dynamicproperties.GetIntPropertyFilteredByDomain(1000),
+		ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+		DeprecatedSearchAttributes: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
+		DomainSearchAttributes: func(domain string) map[string]interface{} {
+			if domain == "team-domain" {
+				return map[string]interface{}{"CustomTeamField": float64(types.IndexedValueTypeKeyword)}
+			}
+			return map[string]interface{}{}
+		},
+		EnableChildSearchAttributeValidation: func(domain string) bool {
+			return domain != "unvalidated-domain"
+		},
+		SearchAttributeAliases:            dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 		EnableQueryAttributeValidation:    dynamicproperties.GetBoolPropertyFn(true),
 		SearchAttributesNumberOfKeysLimit: dynamicproperties.GetIntPropertyFilteredByDomain(100),
 		SearchAttributesSizeOfValueLimit:  dynamicproperties.GetIntPropertyFilteredByDomain(2 * 1024),
@@ -185,6 +196,48 @@ func (s *attrValidatorSuite) TestValidateUpsertWorkflowSearchAttributes() {
 	s.Nil(err)
 }
 
+func (s *attrValidatorSuite) TestValidateStartChildExecutionAttributes_SearchAttributes() {
+	parentInfo := &persistence.WorkflowExecutionInfo{
+		TaskList:                    "task-list",
+		WorkflowTimeout:             100,
+		DecisionStartToCloseTimeout: 10,
+	}
+	newAttributes := func(fields map[string][]byte) *types.StartChildWorkflowExecutionDecisionAttributes {
+		return &types.StartChildWorkflowExecutionDecisionAttributes{
+			WorkflowID:       "workflow-id",
+			WorkflowType:     &types.WorkflowType{Name: "workflow-type"},
+			TaskList:         &types.TaskList{Name: "task-list"},
+			SearchAttributes: &types.SearchAttributes{IndexedFields: fields},
+		}
+	}
+
+	err := s.validator.validateStartChildExecutionAttributes(s.testDomainID, s.testDomainID, &types.StartChildWorkflowExecutionDecisionAttributes{
+		WorkflowID:   "workflow-id",
+		WorkflowType: &types.WorkflowType{Name: "workflow-type"},
+		TaskList:     &types.TaskList{Name: "task-list"},
+	}, parentInfo, metrics.HistoryRespondDecisionTaskCompletedScope)
+	s.NoError(err)
+
+	s.mockDomainCache.EXPECT().GetDomainName(s.testDomainID).Return("team-domain", nil).Times(2)
+	err = s.validator.validateStartChildExecutionAttributes(s.testDomainID, s.testDomainID,
+		newAttributes(map[string][]byte{"CustomTeamField": []byte(`"team"`)}), parentInfo, metrics.HistoryRespondDecisionTaskCompletedScope)
+	s.NoError(err)
+	err = s.validator.validateStartChildExecutionAttributes(s.testDomainID, s.testDomainID,
+		newAttributes(map[string][]byte{"InvalidKey": []byte(`"value"`)}), parentInfo, metrics.HistoryRespondDecisionTaskCompletedScope)
+	s.EqualError(err, "InvalidKey is not a valid search attribute key")
+
+	s.mockDomainCache.EXPECT().GetDomainName(s.testDomainID).Return("other-domain", nil).Times(1)
+	err = s.validator.validateStartChildExecutionAttributes(s.testDomainID, s.testDomainID,
+		newAttributes(map[string][]byte{"CustomTeamField": []byte(`"team"`)}), parentInfo, metrics.HistoryRespondDecisionTaskCompletedScope)
+	s.EqualError(err, "CustomTeamField is not a valid search attribute key")
+
+	// domains which have not opted in start children with any search attributes
+	s.mockDomainCache.EXPECT().GetDomainName(s.testDomainID).Return("unvalidated-domain", nil).Times(1)
+	err = s.validator.validateStartChildExecutionAttributes(s.testDomainID, s.testDomainID,
+		newAttributes(map[string][]byte{"InvalidKey": []byte(`"value"`)}), parentInfo, metrics.HistoryRespondDecisionTaskCompletedScope)
+	s.NoError(err)
+}
+
 func (s *attrValidatorSuite) TestValidateCrossDomainCall_LocalToLocal() {
 	domainEntry := cache.NewLocalDomainCacheEntryForTest(
 		&persistence.DomainInfo{Name: s.testDomainID},
@@ -167,6 +167,13 @@ func NewEngineWithShardContext(
 	)
 	replicationDynamicTaskBatchSizer := replication.NewDynamicTaskBatchSizer(shard.GetShardID(), logger, config, shard.GetMetricsClient())
 	replicationReader := replication.NewTaskReader(shard.GetShardID(), executionManager)
+	replicationLagSLOReporter := replication.NewLagSLOReporter(
+		shard,
+		replicationReader,
+		shard.GetDomainCache(),
+		config.ReplicationStatusMaxScanTasks,
+		config.ReplicationLagSLOThreshold,
+	)
 
 	historyEngImpl := &historyEngineImpl{
 		currentClusterName:   currentClusterName,
@@ -208,7 +215,7 @@ func NewEngineWithShardContext(
 		),
 		replicationTaskStore: replicationTaskStore,
 		replicationMetricsEmitter: replication.NewMetricsEmitter(
-			shard.GetShardID(), shard, replicationReader, shard.GetMetricsClient()),
+			shard.GetShardID(), shard, replicationReader, replicationLagSLOReporter, shard.GetMetricsClient()),
 		updateWithActionFn: workflow.UpdateWithAction,
 		queueProcessors:    make(map[persistence.HistoryTaskCategory]queue.Processor),
 	}
@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package replication
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	lagSLOScanPageSize = 100
+)
+
+type (
+	// LagSLOReporter finds the domains of a shard whose replication lag exceeds their SLO
+	// by scanning the pending replication tasks of the shard.
+	LagSLOReporter struct {
+		shardData    lagSLOShardData
+		reader       taskReader
+		domainCache  cache.DomainCache
+		maxScanTasks dynamicproperties.IntPropertyFn
+		lagSLO       dynamicproperties.DurationPropertyFnWithDomainFilter
+	}
+
+	lagSLOShardData interface {
+		GetQueueClusterAckLevel(category persistence.HistoryTaskCategory, cluster string) persistence.HistoryTaskKey
+		UpdateIfNeededAndGetQueueMaxReadLevel(category persistence.HistoryTaskCategory, cluster string) persistence.HistoryTaskKey
+		GetTimeSource() clock.TimeSource
+	}
+
+	// domainLag is the replication lag of a domain, i.e. the age of its oldest pending replication task
+	domainLag struct {
+		domainName          string
+		pendingTasks        int
+		oldestPendingTaskID int64
+		lag                 time.Duration
+	}
+)
+
+// NewLagSLOReporter creates a new replication lag SLO reporter for a shard
+func NewLagSLOReporter(
+	shardData lagSLOShardData,
+	reader taskReader,
+	domainCache cache.DomainCache,
+	maxScanTasks dynamicproperties.IntPropertyFn,
+	lagSLO dynamicproperties.DurationPropertyFnWithDomainFilter,
+) *LagSLOReporter {
+	return &LagSLOReporter{
+		shardData:    shardData,
+		reader:       reader,
+		domainCache:  domainCache,
+		maxScanTasks: maxScanTasks,
+		lagSLO:       lagSLO,
+	}
+}
+
+// Enabled returns whether a replication lag SLO is set, either cluster-wide or for any domain in the domain cache.
+func (r *LagSLOReporter) Enabled() bool {
+	if r.lagSLO("") > 0 {
+		return true
+	}
+	for _, domain := range r.domainCache.GetAllDomain() {
+		if r.lagSLO(domain.GetInfo().Name) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// breaches returns the domains whose replication lag towards the given remote cluster exceeds their SLO,
+// ordered by their oldest pending task. At most ReplicationStatusMaxScanTasks pending tasks are read,
+// so domains whose tasks are all beyond the scanned ones are not reported.
+func (r *LagSLOReporter) breaches(ctx context.Context, remoteCluster string) ([]domainLag, error) {
+	now := r.shardData.GetTimeSource().Now()
+	readLevel := r.shardData.GetQueueClusterAckLevel(persistence.HistoryTaskCategoryReplication, remoteCluster).GetTaskID()
+	maxReadLevel := r.shardData.UpdateIfNeededAndGetQueueMaxReadLevel(persistence.HistoryTaskCategoryReplication, remoteCluster).GetTaskID()
+
+	domains := make(map[string]*domainLag)
+	remaining := r.maxScanTasks()
+	for remaining > 0 {
+		tasks, hasMore, err := r.reader.Read(ctx, readLevel, maxReadLevel, min(lagSLOScanPageSize, remaining))
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range tasks {
+			domain, ok := domains[task.GetDomainID()]
+			if !ok {
+				domain = &domainLag{
+					oldestPendingTaskID: task.GetTaskID(),
+					lag:                 now.Sub(task.GetVisibilityTimestamp()),
+				}
+				domains[task.GetDomainID()] = domain
+			}
+			domain.pendingTasks++
+			readLevel = task.GetTaskID()
+		}
+		remaining -= len(tasks)
+		if !hasMore || len(tasks) == 0 {
+			break
+		}
+	}
+
+	var result []domainLag
+	for domainID, domain := range domains {
+		domainName, err := r.domainCache.GetDomainName(domainID)
+		if err != nil {
+			continue
+		}
+		if slo := r.lagSLO(domainName); slo <= 0 || domain.lag <= slo {
+			continue
+		}
+		domain.domainName = domainName
+		result = append(result, *domain)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].oldestPendingTaskID < result[j].oldestPendingTaskID
+	})
+	return result, nil
+}
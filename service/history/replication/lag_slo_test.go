@@ -0,0 +1,152 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package replication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/persistence"
+)
+
+func TestLagSLOReporterBreaches(t *testing.T) {
+	timeSource := clock.NewMockedTimeSource()
+	now := timeSource.Now()
+	newTask := func(taskID int64, domainID string, age time.Duration) persistence.Task {
+		return &persistence.HistoryReplicationTask{
+			WorkflowIdentifier: persistence.WorkflowIdentifier{DomainID: domainID},
+			TaskData: persistence.TaskData{
+				TaskID:              taskID,
+				VisibilityTimestamp: now.Add(-age),
+			},
+		}
+	}
+	tasks := exclusiveTaskReader{
+		newTask(11, "domain-a", time.Hour),
+		newTask(12, "domain-b", 30*time.Minute),
+		newTask(13, "domain-a", time.Minute),
+		newTask(14, "domain-b", time.Second),
+	}
+
+	tests := map[string]struct {
+		ackLevel     int64
+		maxReadLevel int64
+		maxScanTasks int
+		reader       taskReader
+		want         []domainLag
+		wantErr      bool
+	}{
+		"caught up": {
+			ackLevel:     14,
+			maxReadLevel: 14,
+			maxScanTasks: 10,
+			reader:       tasks,
+		},
+		"lagging domains": {
+			ackLevel:     10,
+			maxReadLevel: 14,
+			maxScanTasks: 10,
+			reader:       tasks,
+			want: []domainLag{
+				{domainName: "domain-a-name", pendingTasks: 2, oldestPendingTaskID: 11, lag: time.Hour},
+				{domainName: "domain-b-name", pendingTasks: 2, oldestPendingTaskID: 12, lag: 30 * time.Minute},
+			},
+		},
+		"within SLO": {
+			ackLevel:     12,
+			maxReadLevel: 14,
+			maxScanTasks: 10,
+			reader:       tasks,
+		},
+		"scan bounded": {
+			ackLevel:     10,
+			maxReadLevel: 14,
+			maxScanTasks: 1,
+			reader:       tasks,
+			want: []domainLag{
+				{domainName: "domain-a-name", pendingTasks: 1, oldestPendingTaskID: 11, lag: time.Hour},
+			},
+		},
+		"read error": {
+			ackLevel:     10,
+			maxReadLevel: 14,
+			maxScanTasks: 10,
+			reader:       fakeTaskReader(nil),
+			wantErr:      true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			domainCache := cache.NewMockDomainCache(gomock.NewController(t))
+			domainCache.EXPECT().GetDomainName(gomock.Any()).DoAndReturn(func(domainID string) (string, error) {
+				return domainID + "-name", nil
+			}).AnyTimes()
+			lagSLO := func(domain string) time.Duration {
+				switch domain {
+				case "domain-a-name":
+					return 10 * time.Minute
+				case "domain-b-name":
+					return 20 * time.Minute
+				}
+				return 0
+			}
+
+			shardData := newTestShardData(timeSource, newClusterMetadata(t))
+			shardData.clusterReplicationLevel[cluster2] = persistence.NewImmediateTaskKey(tc.ackLevel)
+			shardData.maxReadLevel = persistence.NewImmediateTaskKey(tc.maxReadLevel)
+
+			reporter := NewLagSLOReporter(shardData, tc.reader, domainCache, dynamicproperties.GetIntPropertyFn(tc.maxScanTasks), lagSLO)
+			breaches, err := reporter.breaches(context.Background(), cluster2)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, breaches)
+		})
+	}
+}
+
+// exclusiveTaskReader returns tasks in (readLevel, maxReadLevel] like the persistence backed TaskReader
+type exclusiveTaskReader []persistence.Task
+
+func (r exclusiveTaskReader) Read(ctx context.Context, readLevel int64, maxReadLevel int64, batchSize int) ([]persistence.Task, bool, error) {
+	var result []persistence.Task
+	for _, task := range r {
+		if task.GetTaskID() <= readLevel || task.GetTaskID() > maxReadLevel {
+			continue
+		}
+		if len(result) == batchSize {
+			return result, true, nil
+		}
+		result = append(result, task)
+	}
+	return result, false, nil
+}
@@ -52,6 +52,7 @@ type (
 		remoteClusters map[string]config.ClusterInformation
 		shardData      metricsEmitterShardData
 		reader         taskReader
+		lagSLOReporter *LagSLOReporter
 		scope          metrics.Scope
 		logger         log.Logger
 		status         int32
@@ -75,6 +76,7 @@ func NewMetricsEmitter(
 	shardID int,
 	shardData metricsEmitterShardData,
 	reader taskReader,
+	lagSLOReporter *LagSLOReporter,
 	metricsClient metrics.Client,
 ) *MetricsEmitterImpl {
 	currentCluster := shardData.GetClusterMetadata().GetCurrentClusterName()
@@ -97,6 +99,7 @@ func NewMetricsEmitter(
 		status:         common.DaemonStatusInitialized,
 		shardData:      shardData,
 		reader:         reader,
+		lagSLOReporter: lagSLOReporter,
 		scope:          scope,
 		interval:       metricsEmissionInterval,
 		logger:         logger,
@@ -156,6 +159,32 @@ func (m *MetricsEmitterImpl) emitMetrics() {
 
 		scope.UpdateGauge(metrics.ReplicationLatency, float64(replicationLatency.Nanoseconds()))
 		logger.Debug(fmt.Sprintf("ReplicationLatency metric emitted: %v", float64(replicationLatency.Nanoseconds())))
+
+		m.emitDomainLagSLOMetrics(remoteClusterName, scope, logger)
+	}
+}
+
+// emitDomainLagSLOMetrics reports domains whose replication lag exceeds the configured SLO.
+// Only breaching domains are emitted to keep the domain tag cardinality bounded.
+func (m *MetricsEmitterImpl) emitDomainLagSLOMetrics(remoteClusterName string, scope metrics.Scope, logger log.Logger) {
+	if !m.lagSLOReporter.Enabled() {
+		return
+	}
+
+	breaches, err := m.lagSLOReporter.breaches(m.ctx, remoteClusterName)
+	if err != nil {
+		logger.Error("Error reading replication tasks when checking replication lag SLO", tag.Error(err))
+		return
+	}
+
+	for _, domain := range breaches {
+		domainScope := scope.Tagged(metrics.DomainTag(domain.domainName))
+		domainScope.UpdateGauge(metrics.ReplicationDomainLatency, float64(domain.lag.Nanoseconds()))
+		domainScope.IncCounter(metrics.ReplicationLagSLOViolation)
+		logger.Warn("Replication lag exceeds SLO",
+			tag.WorkflowDomainName(domain.domainName),
+			tag.Duration(domain.lag),
+			tag.Number(int64(domain.pendingTasks)))
 	}
 }
 
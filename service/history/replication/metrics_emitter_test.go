@@ -23,15 +23,19 @@
 package replication
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/goleak"
+	"go.uber.org/mock/gomock"
 
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/metrics"
@@ -50,8 +54,12 @@ func TestMetricsEmitterStartStop(t *testing.T) {
 	timeSource := clock.NewMockedTimeSource()
 	metadata := newClusterMetadata(t)
 	testShardData := newTestShardData(timeSource, metadata)
+	domainCache := cache.NewMockDomainCache(gomock.NewController(t))
+	domainCache.EXPECT().GetAllDomain().Return(nil).AnyTimes()
 
-	metricsEmitter := NewMetricsEmitter(1, testShardData, fakeTaskReader{}, metrics.NewNoopMetricsClient())
+	lagSLOReporter := NewLagSLOReporter(testShardData, fakeTaskReader{}, domainCache,
+		dynamicproperties.GetIntPropertyFn(10), dynamicproperties.GetDurationPropertyFnFilteredByDomain(0))
+	metricsEmitter := NewMetricsEmitter(1, testShardData, fakeTaskReader{}, lagSLOReporter, metrics.NewNoopMetricsClient())
 	metricsEmitter.interval = 5 * time.Millisecond
 	metricsEmitter.Start()
 	time.Sleep(20 * time.Millisecond) // let the metrics emitter run a few times
@@ -77,7 +85,7 @@ func TestMetricsEmitter(t *testing.T) {
 	}
 	reader := fakeTaskReader{task1, task2}
 
-	metricsEmitter := NewMetricsEmitter(1, testShardData, reader, metrics.NewNoopMetricsClient())
+	metricsEmitter := NewMetricsEmitter(1, testShardData, reader, nil, metrics.NewNoopMetricsClient())
 	latency, err := metricsEmitter.determineReplicationLatency(cluster2)
 	assert.NoError(t, err)
 	assert.Equal(t, time.Hour, latency)
@@ -100,9 +108,63 @@ func TestMetricsEmitter(t *testing.T) {
 	assert.Equal(t, time.Hour, latency)
 }
 
+func TestMetricsEmitterDomainLagSLO(t *testing.T) {
+	tests := map[string]struct {
+		lagSLO    dynamicproperties.DurationPropertyFnWithDomainFilter
+		wantReads int
+	}{
+		"threshold disabled": {
+			lagSLO:    dynamicproperties.GetDurationPropertyFnFilteredByDomain(0),
+			wantReads: 0,
+		},
+		"threshold enabled": {
+			lagSLO:    dynamicproperties.GetDurationPropertyFnFilteredByDomain(time.Minute),
+			wantReads: 1,
+		},
+		"threshold enabled for a single domain": {
+			lagSLO: func(domain string) time.Duration {
+				if domain == "domain-with-slo" {
+					return time.Minute
+				}
+				return 0
+			},
+			wantReads: 1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			timeSource := clock.NewMockedTimeSource()
+			testShardData := newTestShardData(timeSource, newClusterMetadata(t))
+			reader := &countingTaskReader{}
+			domainCache := cache.NewMockDomainCache(gomock.NewController(t))
+			domainCache.EXPECT().GetAllDomain().Return(map[string]*cache.DomainCacheEntry{
+				"domain-id": cache.NewLocalDomainCacheEntryForTest(&persistence.DomainInfo{ID: "domain-id", Name: "domain-with-slo"}, nil, cluster1),
+				"other-id":  cache.NewLocalDomainCacheEntryForTest(&persistence.DomainInfo{ID: "other-id", Name: "domain-without-slo"}, nil, cluster1),
+			}).AnyTimes()
+
+			lagSLOReporter := NewLagSLOReporter(testShardData, reader, domainCache, dynamicproperties.GetIntPropertyFn(10), tc.lagSLO)
+			metricsEmitter := NewMetricsEmitter(1, testShardData, fakeTaskReader{}, lagSLOReporter, metrics.NewNoopMetricsClient())
+			metricsEmitter.emitDomainLagSLOMetrics(cluster2, metricsEmitter.scope, metricsEmitter.logger)
+
+			assert.Equal(t, tc.wantReads, reader.reads)
+		})
+	}
+}
+
+type countingTaskReader struct {
+	reads int
+}
+
+func (r *countingTaskReader) Read(ctx context.Context, readLevel int64, maxReadLevel int64, batchSize int) ([]persistence.Task, bool, error) {
+	r.reads++
+	return nil, false, nil
+}
+
 type testShardData struct {
 	logger                  log.Logger
 	clusterReplicationLevel map[string]persistence.HistoryTaskKey
+	maxReadLevel            persistence.HistoryTaskKey
 	timeSource              clock.TimeSource
 	metadata                cluster.Metadata
 }
@@ -129,6 +191,10 @@ func (t testShardData) GetQueueClusterAckLevel(category persistence.HistoryTaskC
 	return t.clusterReplicationLevel[cluster]
 }
 
+func (t testShardData) UpdateIfNeededAndGetQueueMaxReadLevel(category persistence.HistoryTaskCategory, cluster string) persistence.HistoryTaskKey {
+	return t.maxReadLevel
+}
+
 func (t testShardData) GetTimeSource() clock.TimeSource {
 	return t.timeSource
 }
@@ -124,6 +124,8 @@ func New(
 			ESVisibilityListMaxQPS:   nil,                          // history service never read,
 			ESIndexMaxResultWindow:   nil,                          // history service never read,
 			ValidSearchAttributes:    config.ValidSearchAttributes, // history service never read, (Pinot need this to initialize pinotQueryValidator)
+			DomainSearchAttributes:   config.DomainSearchAttributes,
+			SearchAttributeAliases:   config.SearchAttributeAliases,
 			IsErrorRetryableFunction: common.IsServiceTransientError,
 		},
 	)
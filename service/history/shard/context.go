@@ -42,6 +42,8 @@ import (
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/wrappers/ratelimited"
+	"github.com/uber/cadence/common/quotas"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/config"
 	"github.com/uber/cadence/service/history/engine"
@@ -121,6 +123,8 @@ type (
 		shardID                  int
 		rangeID                  int64
 		executionManager         persistence.ExecutionManager
+		historyManager           persistence.HistoryManager
+		domainBudgets            *ratelimited.DomainBudgets
 		activeClusterManager     activecluster.Manager
 		eventsCache              events.Cache
 		closeCallback            func(int, *historyShardsItem)
@@ -189,6 +193,10 @@ func (s *contextImpl) GetExecutionManager() persistence.ExecutionManager {
 	return s.executionManager
 }
 
+func (s *contextImpl) GetHistoryManager() persistence.HistoryManager {
+	return s.historyManager
+}
+
 func (s *contextImpl) GetEngine() engine.Engine {
 	return s.engine
 }
@@ -649,6 +657,10 @@ func (s *contextImpl) CreateWorkflowExecution(
 	if err != nil {
 		return nil, err
 	}
+	// charge the domain's persistence budget before taking the shard lock, waiting for it must not block the shard
+	if err := s.domainBudgets.Allow(ctx, domainEntry.GetInfo().Name); err != nil {
+		return nil, err
+	}
 
 	s.Lock()
 	defer s.Unlock()
@@ -756,6 +768,9 @@ func (s *contextImpl) UpdateWorkflowExecution(
 		return nil, err
 	}
 	request.Encoding = s.getDefaultEncoding(domainEntry.GetInfo().Name)
+	if err := s.domainBudgets.Allow(ctx, domainEntry.GetInfo().Name); err != nil {
+		return nil, err
+	}
 
 	s.Lock()
 	defer s.Unlock()
@@ -869,6 +884,9 @@ func (s *contextImpl) ConflictResolveWorkflowExecution(
 	}
 
 	request.Encoding = s.getDefaultEncoding(domainEntry.GetInfo().Name)
+	if err := s.domainBudgets.Allow(ctx, domainEntry.GetInfo().Name); err != nil {
+		return nil, err
+	}
 
 	s.Lock()
 	defer s.Unlock()
@@ -1727,11 +1745,21 @@ func acquireShard(
 		return nil, err
 	}
 
+	// per-domain budgets are scoped to the shard, so a busy domain only throttles itself
+	domainBudgets := ratelimited.NewDomainBudgets(
+		shardItem.config.PersistenceDomainMaxQPS,
+		shardItem.config.PersistenceShardMaxQPS,
+		shardItem.config.PersistenceDomainMaxWait,
+		quotas.NewCallerBypass(shardItem.config.RateLimiterBypassCallerTypes),
+	)
+
 	context := &contextImpl{
 		Resource:                       shardItem.Resource,
 		shardItem:                      shardItem,
 		shardID:                        shardItem.shardID,
-		executionManager:               executionMgr,
+		executionManager:               ratelimited.NewDomainExecutionManager(executionMgr, domainBudgets),
+		historyManager:                 ratelimited.NewDomainHistoryManager(shardItem.GetHistoryManager(), domainBudgets),
+		domainBudgets:                  domainBudgets,
 		activeClusterManager:           shardItem.GetActiveClusterManager(),
 		shardInfo:                      updatedShardInfo,
 		closeCallback:                  closeCallback,
@@ -1748,7 +1776,7 @@ func acquireShard(
 	// TODO remove once migrated to global event cache
 	context.eventsCache = events.NewCache(
 		context.shardID,
-		context.Resource.GetHistoryManager(),
+		context.GetHistoryManager(),
 		context.config,
 		context.logger,
 		context.Resource.GetMetricsClient(),
@@ -41,11 +41,14 @@ import (
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/mocks"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/wrappers/ratelimited"
+	"github.com/uber/cadence/common/quotas"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/config"
 	"github.com/uber/cadence/service/history/engine"
@@ -123,6 +126,7 @@ func (s *contextTestSuite) newContext() *contextImpl {
 		rangeID:                      shardInfo.RangeID,
 		shardInfo:                    shardInfo,
 		executionManager:             s.mockResource.ExecutionMgr,
+		historyManager:               s.mockResource.HistoryMgr,
 		activeClusterManager:         s.mockResource.ActiveClusterMgr,
 		closeCallback:                func(i int, item *historyShardsItem) {},
 		config:                       config,
@@ -1043,6 +1047,37 @@ func (s *contextTestSuite) TestConflictResolveWorkflowExecution() {
 	}
 }
 
+func (s *contextTestSuite) TestUpdateWorkflowExecution_DomainBudgetExhausted() {
+	s.context.domainBudgets = ratelimited.NewDomainBudgets(
+		dynamicproperties.GetIntPropertyFilteredByDomain(1),
+		dynamicproperties.GetIntPropertyFn(0),
+		dynamicproperties.GetDurationPropertyFnFilteredByDomain(0),
+		quotas.NewCallerBypass(func(opts ...dynamicproperties.FilterOption) []interface{} { return nil }),
+	)
+	domainCacheEntry := cache.NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: testDomainID, Name: testDomain},
+		&persistence.DomainConfig{Retention: 7},
+		testCluster,
+	)
+	s.mockResource.DomainCache.EXPECT().GetDomainByID(testDomainID).Return(domainCacheEntry, nil).Times(1)
+	s.Require().NoError(s.context.domainBudgets.Allow(context.Background(), testDomain))
+
+	// the budget is charged before the shard lock is taken, so the write never reaches persistence
+	_, err := s.context.UpdateWorkflowExecution(context.Background(), &persistence.UpdateWorkflowExecutionRequest{
+		RangeID: 123,
+		Mode:    persistence.UpdateWorkflowModeUpdateCurrent,
+		UpdateWorkflowMutation: persistence.WorkflowMutation{
+			ExecutionInfo: &persistence.WorkflowExecutionInfo{
+				DomainID:   testDomainID,
+				WorkflowID: testWorkflowID,
+			},
+		},
+		DomainName: testDomain,
+	})
+	s.ErrorIs(err, ratelimited.ErrPersistenceDomainLimitExceeded)
+	s.mockResource.ExecutionMgr.AssertNotCalled(s.T(), "UpdateWorkflowExecution", mock.Anything, mock.Anything)
+}
+
 func (s *contextTestSuite) TestAppendHistoryV2Events() {
 	cases := []struct {
 		name            string
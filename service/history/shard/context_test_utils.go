@@ -64,6 +64,7 @@ func NewTestContext(
 		rangeID:                      shardInfo.RangeID,
 		shardInfo:                    shardInfo,
 		executionManager:             resource.ExecutionMgr,
+		historyManager:               resource.HistoryMgr,
 		activeClusterManager:         resource.ActiveClusterMgr,
 		config:                       config,
 		logger:                       resource.GetLogger(),
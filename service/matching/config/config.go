@@ -98,8 +98,9 @@ type (
 		ActivityTaskSyncMatchWaitTime dynamicproperties.DurationPropertyFnWithDomainFilter
 
 		// isolation configuration
-		EnableTasklistIsolation dynamicproperties.BoolPropertyFnWithDomainFilter
-		AllIsolationGroups      func() []string
+		EnableTasklistIsolation            dynamicproperties.BoolPropertyFnWithDomainFilter
+		EnableIsolationGroupDrainMigration dynamicproperties.BoolPropertyFnWithDomainFilter
+		AllIsolationGroups                 func() []string
 		// hostname info
 		HostName string
 		// RPCConfig contains RPC configuration including ports and bindOnLocalHost
@@ -107,6 +108,9 @@ type (
 		// rate limiter configuration
 		TaskDispatchRPS    float64
 		TaskDispatchRPSTTL time.Duration
+		// global ratelimiter configuration, shares task list dispatch rates between hosts
+		GlobalRatelimiterKeyMode        dynamicproperties.StringPropertyWithRatelimitKeyFilter
+		GlobalRatelimiterUpdateInterval dynamicproperties.DurationPropertyFn
 		// task gc configuration
 		MaxTimeBetweenTaskDeletes time.Duration
 
@@ -162,7 +166,8 @@ type (
 		EnableAdaptiveScaler                 func() bool
 		EnablePartitionEmptyCheck            func() bool
 		// isolation configuration
-		EnableTasklistIsolation func() bool
+		EnableTasklistIsolation            func() bool
+		EnableIsolationGroupDrainMigration func() bool
 		// A function which returns all the isolation groups
 		AllIsolationGroups        func() []string
 		TaskIsolationDuration     func() time.Duration
@@ -214,6 +219,7 @@ func NewConfig(dc *dynamicconfig.Collection, operationalDC *dynamicconfig.Collec
 		EnableTaskInfoLogByDomainID:                dc.GetBoolPropertyFilteredByDomainID(dynamicproperties.MatchingEnableTaskInfoLogByDomainID),
 		ActivityTaskSyncMatchWaitTime:              dc.GetDurationPropertyFilteredByDomain(dynamicproperties.MatchingActivityTaskSyncMatchWaitTime),
 		EnableTasklistIsolation:                    dc.GetBoolPropertyFilteredByDomain(dynamicproperties.EnableTasklistIsolation),
+		EnableIsolationGroupDrainMigration:         dc.GetBoolPropertyFilteredByDomain(dynamicproperties.EnableIsolationGroupDrainMigration),
 		AppendTaskTimeout:                          dc.GetDurationPropertyFilteredByTaskListInfo(dynamicproperties.AppendTaskTimeout),
 		AsyncTaskDispatchTimeout:                   dc.GetDurationPropertyFilteredByTaskListInfo(dynamicproperties.AsyncTaskDispatchTimeout),
 		LocalPollWaitTime:                          dc.GetDurationPropertyFilteredByTaskListInfo(dynamicproperties.LocalPollWaitTime),
@@ -239,6 +245,8 @@ func NewConfig(dc *dynamicconfig.Collection, operationalDC *dynamicconfig.Collec
 		RPCConfig:                                  rpcConfig,
 		TaskDispatchRPS:                            100000.0,
 		TaskDispatchRPSTTL:                         time.Minute,
+		GlobalRatelimiterKeyMode:                   dc.GetStringPropertyFilteredByRatelimitKey(dynamicproperties.MatchingGlobalRatelimiterMode),
+		GlobalRatelimiterUpdateInterval:            dc.GetDurationProperty(dynamicproperties.GlobalRatelimiterUpdateInterval),
 		MaxTimeBetweenTaskDeletes:                  time.Second,
 		AllIsolationGroups:                         getIsolationGroups,
 		EnableStandbyTaskCompletion:                dc.GetBoolPropertyFilteredByTaskListInfo(dynamicproperties.MatchingEnableStandbyTaskCompletion),
@@ -75,6 +75,7 @@ func TestNewConfig(t *testing.T) {
 		"EnableTaskInfoLogByDomainID":               {dynamicproperties.MatchingEnableTaskInfoLogByDomainID, true},
 		"ActivityTaskSyncMatchWaitTime":             {dynamicproperties.MatchingActivityTaskSyncMatchWaitTime, time.Duration(24)},
 		"EnableTasklistIsolation":                   {dynamicproperties.EnableTasklistIsolation, false},
+		"EnableIsolationGroupDrainMigration":        {dynamicproperties.EnableIsolationGroupDrainMigration, true},
 		"AsyncTaskDispatchTimeout":                  {dynamicproperties.AsyncTaskDispatchTimeout, time.Duration(25)},
 		"LocalPollWaitTime":                         {dynamicproperties.LocalPollWaitTime, time.Duration(10)},
 		"LocalTaskWaitTime":                         {dynamicproperties.LocalTaskWaitTime, time.Duration(10)},
@@ -82,6 +83,8 @@ func TestNewConfig(t *testing.T) {
 		"RPCConfig":                                 {nil, config.RPC{}},
 		"TaskDispatchRPS":                           {nil, 100000.0},
 		"TaskDispatchRPSTTL":                        {nil, time.Minute},
+		"GlobalRatelimiterKeyMode":                  {dynamicproperties.MatchingGlobalRatelimiterMode, "global"},
+		"GlobalRatelimiterUpdateInterval":           {dynamicproperties.GlobalRatelimiterUpdateInterval, 3 * time.Second},
 		"MaxTimeBetweenTaskDeletes":                 {nil, time.Second},
 		"AllIsolationGroups":                        {nil, []string{"zone-1", "zone-2"}},
 		"EnableGetNumberOfPartitionsFromCache":      {dynamicproperties.MatchingEnableGetNumberOfPartitionsFromCache, false},
@@ -192,6 +195,8 @@ func getValue(f *reflect.Value) interface{} {
 			return fn()
 		case dynamicproperties.FloatPropertyFnWithTaskListInfoFilters:
 			return fn("domain", "tasklist", int(types.TaskListTypeDecision))
+		case dynamicproperties.StringPropertyWithRatelimitKeyFilter:
+			return fn("taskListDispatch:domain/tasklist/decision")
 		case func() []string:
 			return fn()
 		default:
@@ -63,7 +63,7 @@ import (
 const (
 	// If sticky poller is not seem in last 10s, we treat it as sticky worker unavailable
 	// This seems aggressive, but the default sticky schedule_to_start timeout is 5s, so 10s seems reasonable.
-	_stickyPollerUnavailableWindow = 10 * time.Second
+	_stickyPollerUnavailableWindow = tasklist.StickyPollerUnavailableWindow
 
 	// _defaultSDReportTTL is the default TTL for shard status reports from matching executor to shard distributor.
 	// This controls how frequently the executor reports its shard load/status to the distributor.
@@ -115,6 +115,7 @@ type (
 		ShardDistributorMatchingConfig clientcommon.Config
 		drainObserver                  clientcommon.DrainSignalObserver
 		percentageOnboarded            membership.PercentageOnboarded
+		dispatchLimiters               *tasklist.DispatchLimiters
 	}
 )
 
@@ -147,6 +148,7 @@ func NewEngine(
 	ShardDistributorMatchingConfig clientcommon.Config,
 	drainObserver clientcommon.DrainSignalObserver,
 	percentageOnboarded membership.PercentageOnboarded,
+	dispatchLimiters *tasklist.DispatchLimiters,
 ) Engine {
 	e := &matchingEngineImpl{
 		taskListRegistry:               tasklist.NewTaskListRegistry(metricsClient),
@@ -171,6 +173,7 @@ func NewEngine(
 		ShardDistributorMatchingConfig: ShardDistributorMatchingConfig,
 		drainObserver:                  drainObserver,
 		percentageOnboarded:            percentageOnboarded,
+		dispatchLimiters:               dispatchLimiters,
 	}
 
 	e.setupExecutor(shardDistributorClient)
@@ -321,20 +324,21 @@ func (e *matchingEngineImpl) getOrCreateTaskListManager(ctx context.Context, tas
 
 	logger.Debug("Task list manager state changed", tag.LifeCycleStarting)
 	params := tasklist.ManagerParams{
-		DomainCache:     e.domainCache,
-		Logger:          e.logger,
-		MetricsClient:   e.metricsClient,
-		TaskManager:     e.taskManager,
-		ClusterMetadata: e.clusterMetadata,
-		IsolationState:  e.isolationState,
-		MatchingClient:  e.matchingClient,
-		Registry:        e.taskListRegistry,
-		TaskList:        taskList,
-		TaskListKind:    taskListKind,
-		Cfg:             e.config,
-		TimeSource:      e.timeSource,
-		CreateTime:      e.timeSource.Now(),
-		HistoryService:  e.historyService,
+		DomainCache:      e.domainCache,
+		Logger:           e.logger,
+		MetricsClient:    e.metricsClient,
+		TaskManager:      e.taskManager,
+		ClusterMetadata:  e.clusterMetadata,
+		IsolationState:   e.isolationState,
+		MatchingClient:   e.matchingClient,
+		Registry:         e.taskListRegistry,
+		TaskList:         taskList,
+		TaskListKind:     taskListKind,
+		Cfg:              e.config,
+		TimeSource:       e.timeSource,
+		CreateTime:       e.timeSource.Now(),
+		HistoryService:   e.historyService,
+		DispatchLimiters: e.dispatchLimiters,
 	}
 	mgr, err := tasklist.NewManager(params)
 	if err != nil {
@@ -432,7 +436,7 @@ func (e *matchingEngineImpl) AddDecisionTask(
 
 	if taskListKind == types.TaskListKindSticky {
 		// check if the sticky worker is still available, if not, fail this request early
-		if !tlMgr.HasPollerAfter(e.timeSource.Now().Add(-_stickyPollerUnavailableWindow)) {
+		if !e.isStickyWorkerAvailable(hCtx, tlMgr) {
 			return nil, _stickyPollerUnavailableError
 		}
 	}
@@ -882,6 +886,21 @@ pollLoop:
 	}
 }
 
+// isStickyWorkerAvailable checks whether the worker of a sticky task list polled recently and is not in a drained isolation group.
+// Failing the request makes history fall back to the normal task list, so a worker in a healthy isolation group picks up
+// the decision and takes over the sticky execution.
+func (e *matchingEngineImpl) isStickyWorkerAvailable(hCtx *handlerContext, tlMgr tasklist.Manager) bool {
+	after := e.timeSource.Now().Add(-_stickyPollerUnavailableWindow)
+	if !tlMgr.HasPollerAfter(after) {
+		return false
+	}
+	if tlMgr.HasOnlyDrainedPollersAfter(hCtx.Context, after) {
+		hCtx.scope.IncCounter(metrics.IsolationGroupDrainStickyReset)
+		return false
+	}
+	return true
+}
+
 // QueryWorkflow creates a DecisionTask with query data, send it through sync match channel, wait for that DecisionTask
 // to be processed by worker, and then return the query result.
 func (e *matchingEngineImpl) QueryWorkflow(
@@ -903,7 +922,7 @@ func (e *matchingEngineImpl) QueryWorkflow(
 
 	if taskListKind == types.TaskListKindSticky {
 		// check if the sticky worker is still available, if not, fail this request early
-		if !tlMgr.HasPollerAfter(e.timeSource.Now().Add(-_stickyPollerUnavailableWindow)) {
+		if !e.isStickyWorkerAvailable(hCtx, tlMgr) {
 			return nil, _stickyPollerUnavailableError
 		}
 	}
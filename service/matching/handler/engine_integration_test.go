@@ -202,6 +202,7 @@ func (s *matchingEngineSuite) newMatchingEngine(
 		defaultSDExecutorConfig(),
 		nil,
 		pct,
+		nil,
 	).(*matchingEngineImpl)
 	// Replace the real executor with a mock that behaves as a fully onboarded SD executor.
 	mockExec := executorclient.NewMockExecutor[tasklist.ShardProcessor](s.controller)
@@ -669,6 +669,26 @@ func TestQueryWorkflow(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "sticky worker in drained isolation group",
+			req: &types.MatchingQueryWorkflowRequest{
+				DomainUUID: "test-domain-id",
+				TaskList: &types.TaskList{
+					Name: "test-tasklist",
+					Kind: types.TaskListKindSticky.Ptr(),
+				},
+			},
+			hCtx: &handlerContext{
+				Context: context.Background(),
+				scope:   metrics.NewNoopMetricsClient().Scope(0),
+			},
+			mockSetup: func(mockManager *tasklist.MockManager, queryResultMap *lockableQueryTaskMap, mockCtrl *gomock.Controller, executor *executorclient.MockExecutor[tasklist.ShardProcessor]) {
+				executor.EXPECT().GetShardProcess(gomock.Any(), gomock.Any()).Return(tasklist.NewMockShardProcessor(mockCtrl), nil)
+				mockManager.EXPECT().HasPollerAfter(gomock.Any()).Return(true)
+				mockManager.EXPECT().HasOnlyDrainedPollersAfter(gomock.Any(), gomock.Any()).Return(true)
+			},
+			wantErr: true,
+		},
 		{
 			name: "failed to dispatch query task",
 			req: &types.MatchingQueryWorkflowRequest{
@@ -174,7 +174,7 @@ func (s *handlerSuite) TestAddActivityTask() {
 		{
 			name: "Success case",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().AddActivityTask(gomock.Any(), &request).Return(&types.AddActivityTaskResponse{
 					PartitionConfig: &types.TaskListPartitionConfig{
 						Version:         1,
@@ -194,14 +194,14 @@ func (s *handlerSuite) TestAddActivityTask() {
 		{
 			name: "Error case - rate limiter not allowed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(false).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(false)).Times(1)
 			},
 			err: &types.ServiceBusyError{Message: "Matching host rps exceeded"},
 		},
 		{
 			name: "Error case - AddActivityTask failed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1) // Ensure Allow() returns true
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1) // Ensure the reservation is allowed
 				s.mockEngine.EXPECT().AddActivityTask(gomock.Any(), &request).Return(nil, errors.New("add-activity-error")).Times(1)
 			},
 			err: &types.InternalServiceError{Message: "add-activity-error"},
@@ -242,7 +242,7 @@ func (s *handlerSuite) TestAddDecisionTask() {
 		{
 			name: "Success case",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().AddDecisionTask(gomock.Any(), &request).Return(&types.AddDecisionTaskResponse{
 					PartitionConfig: &types.TaskListPartitionConfig{
 						Version:         1,
@@ -262,14 +262,14 @@ func (s *handlerSuite) TestAddDecisionTask() {
 		{
 			name: "Error case - rate limiter not allowed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(false).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(false)).Times(1)
 			},
 			err: &types.ServiceBusyError{Message: "Matching host rps exceeded"},
 		},
 		{
 			name: "Error case - AddDecisionTask failed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1) // Ensure Allow() returns true
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1) // Ensure the reservation is allowed
 				s.mockEngine.EXPECT().AddDecisionTask(gomock.Any(), &request).Return(nil, errors.New("add-decision-error")).Times(1)
 			},
 			err: &types.InternalServiceError{Message: "add-decision-error"},
@@ -309,7 +309,7 @@ func (s *handlerSuite) TestPollForActivityTask() {
 		{
 			name: "Success case",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().PollForActivityTask(gomock.Any(), &request).
 					Return(&types.MatchingPollForActivityTaskResponse{TaskToken: []byte("task-token")}, nil).Times(1)
 			},
@@ -321,7 +321,7 @@ func (s *handlerSuite) TestPollForActivityTask() {
 		{
 			name: "Error case - rate limiter not allowed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(false).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(false)).Times(1)
 			},
 			getCtx: func() (context.Context, context.CancelFunc) { return context.Background(), nil },
 			err:    &types.ServiceBusyError{Message: "Matching host rps exceeded"},
@@ -329,7 +329,7 @@ func (s *handlerSuite) TestPollForActivityTask() {
 		{
 			name: "Error case - LongPollContextTimeout not set",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 			},
 			getCtx: func() (context.Context, context.CancelFunc) { return context.Background(), nil },
 			err:    common.ErrContextTimeoutNotSet,
@@ -337,7 +337,7 @@ func (s *handlerSuite) TestPollForActivityTask() {
 		{
 			name: "Error case - PollForActivityTask failed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().PollForActivityTask(gomock.Any(), &request).Return(nil, errors.New("poll-activity-error")).Times(1)
 			},
 			getCtx: func() (context.Context, context.CancelFunc) {
@@ -386,7 +386,7 @@ func (s *handlerSuite) TestPollForDecisionTask() {
 		{
 			name: "Success case",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().PollForDecisionTask(gomock.Any(), &request).
 					Return(&types.MatchingPollForDecisionTaskResponse{TaskToken: []byte("task-token")}, nil).Times(1)
 			},
@@ -398,7 +398,7 @@ func (s *handlerSuite) TestPollForDecisionTask() {
 		{
 			name: "Error case - rate limiter not allowed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(false).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(false)).Times(1)
 			},
 			getCtx: func() (context.Context, context.CancelFunc) { return context.Background(), nil },
 			err:    &types.ServiceBusyError{Message: "Matching host rps exceeded"},
@@ -406,7 +406,7 @@ func (s *handlerSuite) TestPollForDecisionTask() {
 		{
 			name: "Error case - LongPollContextTimeout not set",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 			},
 			getCtx: func() (context.Context, context.CancelFunc) { return context.Background(), nil },
 			err:    common.ErrContextTimeoutNotSet,
@@ -414,7 +414,7 @@ func (s *handlerSuite) TestPollForDecisionTask() {
 		{
 			name: "Error case - PollForDecisionTask failed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().PollForDecisionTask(gomock.Any(), &request).Return(nil, errors.New("poll-decision-error")).Times(1)
 			},
 			getCtx: func() (context.Context, context.CancelFunc) {
@@ -462,7 +462,7 @@ func (s *handlerSuite) TestQueryWorkflow() {
 		{
 			name: "Success case",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().QueryWorkflow(gomock.Any(), &request).
 					Return(&types.MatchingQueryWorkflowResponse{QueryResult: []byte("query-result")}, nil).Times(1)
 			},
@@ -470,14 +470,14 @@ func (s *handlerSuite) TestQueryWorkflow() {
 		{
 			name: "Error case - rate limiter not allowed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(false).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(false)).Times(1)
 			},
 			err: &types.ServiceBusyError{Message: "Matching host rps exceeded"},
 		},
 		{
 			name: "Error case - QueryWorkflow failed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().QueryWorkflow(gomock.Any(), &request).Return(nil, errors.New("query-error")).Times(1)
 			},
 			err: &types.InternalServiceError{Message: "query-error"},
@@ -531,7 +531,7 @@ func (s *handlerSuite) TestRespondQueryTaskCompleted() {
 		s.T().Run(tc.name, func(t *testing.T) {
 			tc.setupMocks()
 			s.mockDomainCache.EXPECT().GetDomainName(request.DomainUUID).Return(s.testDomain, nil).Times(1)
-			s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+			s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 
 			err := s.handler.RespondQueryTaskCompleted(context.Background(), &request)
 
@@ -575,7 +575,7 @@ func (s *handlerSuite) TestCancelOutstandingPoll() {
 			tc.setupMocks()
 
 			s.mockDomainCache.EXPECT().GetDomainName(request.DomainUUID).Return(s.testDomain, nil).Times(1)
-			s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+			s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 
 			err := s.handler.CancelOutstandingPoll(context.Background(), &request)
 
@@ -605,7 +605,7 @@ func (s *handlerSuite) TestDescribeTaskList() {
 		{
 			name: "Success case",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().DescribeTaskList(gomock.Any(), &request).
 					Return(&types.DescribeTaskListResponse{Pollers: []*types.PollerInfo{{}}}, nil).Times(1)
 			},
@@ -613,14 +613,14 @@ func (s *handlerSuite) TestDescribeTaskList() {
 		{
 			name: "Error case - rate limiter not allowed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(false).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(false)).Times(1)
 			},
 			err: &types.ServiceBusyError{Message: "Matching host rps exceeded"},
 		},
 		{
 			name: "Error case - DescribeTaskList failed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().DescribeTaskList(gomock.Any(), &request).
 					Return(nil, errors.New("describe-tasklist-error")).Times(1)
 			},
@@ -661,7 +661,7 @@ func (s *handlerSuite) TestListTaskListPartitions() {
 		{
 			name: "Success case",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().ListTaskListPartitions(gomock.Any(), &request).
 					Return(&types.ListTaskListPartitionsResponse{ActivityTaskListPartitions: []*types.TaskListPartitionMetadata{
 						{Key: "test-key", OwnerHostName: "test-host"}}}, nil).Times(1)
@@ -670,14 +670,14 @@ func (s *handlerSuite) TestListTaskListPartitions() {
 		{
 			name: "Error case - rate limiter not allowed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(false).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(false)).Times(1)
 			},
 			err: &types.ServiceBusyError{Message: "Matching host rps exceeded"},
 		},
 		{
 			name: "Error case - ListTaskListPartitions failed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().ListTaskListPartitions(gomock.Any(), &request).
 					Return(nil, errors.New("list-tasklist-partitions-error")).Times(1)
 			},
@@ -716,7 +716,7 @@ func (s *handlerSuite) TestGetTaskListsByDomain() {
 		{
 			name: "Success case",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().GetTaskListsByDomain(gomock.Any(), &request).
 					Return(&types.GetTaskListsByDomainResponse{
 						DecisionTaskListMap: map[string]*types.DescribeTaskListResponse{"test-decision-task-list": {}},
@@ -727,14 +727,14 @@ func (s *handlerSuite) TestGetTaskListsByDomain() {
 		{
 			name: "Error case - rate limiter not allowed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(false).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(false)).Times(1)
 			},
 			err: &types.ServiceBusyError{Message: "Matching host rps exceeded"},
 		},
 		{
 			name: "Error case - GetTaskListsByDomain failed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().GetTaskListsByDomain(gomock.Any(), &request).
 					Return(nil, errors.New("get-tasklists-error")).Times(1)
 			},
@@ -810,7 +810,7 @@ func (s *handlerSuite) TestRefreshTaskListPartitionConfig() {
 		{
 			name: "Success case",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().RefreshTaskListPartitionConfig(gomock.Any(), &request).
 					Return(&types.MatchingRefreshTaskListPartitionConfigResponse{}, nil).Times(1)
 			},
@@ -819,7 +819,7 @@ func (s *handlerSuite) TestRefreshTaskListPartitionConfig() {
 		{
 			name: "Error case - RefreshTaskListPartitionConfig failed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().RefreshTaskListPartitionConfig(gomock.Any(), &request).
 					Return(nil, errors.New("refresh-tasklist-error")).Times(1)
 			},
@@ -860,7 +860,7 @@ func (s *handlerSuite) TestUpdateTaskListPartitionConfig() {
 		{
 			name: "Success case",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().UpdateTaskListPartitionConfig(gomock.Any(), &request).
 					Return(&types.MatchingUpdateTaskListPartitionConfigResponse{}, nil).Times(1)
 			},
@@ -869,14 +869,14 @@ func (s *handlerSuite) TestUpdateTaskListPartitionConfig() {
 		{
 			name: "Error case - rate limiter not allowed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(false).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(false)).Times(1)
 			},
 			err: &types.ServiceBusyError{Message: "Matching host rps exceeded"},
 		},
 		{
 			name: "Error case - UpdateTaskListPartitionConfig failed",
 			setupMocks: func() {
-				s.mockLimiter.EXPECT().Allow().Return(true).Times(1)
+				s.mockLimiter.EXPECT().ReserveN(1).Return(testReservation(true)).Times(1)
 				s.mockEngine.EXPECT().UpdateTaskListPartitionConfig(gomock.Any(), &request).
 					Return(nil, errors.New("update-tasklist-error")).Times(1)
 			},
@@ -909,3 +909,9 @@ func partitions(num int) map[int]*types.TaskListPartition {
 	}
 	return result
 }
+
+// testReservation is a clock.Reservation of the mocked global limiter that is either allowed or not
+type testReservation bool
+
+func (r testReservation) Allow() bool { return bool(r) }
+func (r testReservation) Used(bool)   {}
@@ -135,6 +135,7 @@ func TestGetTaskListManager_OwnerShip(t *testing.T) {
 				defaultSDExecutorConfig(),
 				nil,
 				pct,
+				nil,
 			).(*matchingEngineImpl)
 
 			// All task lists are excluded from the ShardDistributor, so GetShardProcess is
@@ -21,6 +21,7 @@
 package matching
 
 import (
+	"context"
 	"sync/atomic"
 	"time"
 
@@ -29,11 +30,13 @@ import (
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/resource"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/service/matching/config"
 	"github.com/uber/cadence/service/matching/handler"
+	"github.com/uber/cadence/service/matching/tasklist"
 	"github.com/uber/cadence/service/matching/wrappers/grpc"
 	"github.com/uber/cadence/service/matching/wrappers/thrift"
 )
@@ -49,6 +52,7 @@ type Service struct {
 	ShardDistributorMatchingConfig clientcommon.Config
 	drainObserver                  clientcommon.DrainSignalObserver
 	percentageOnboarded            membership.PercentageOnboarded
+	dispatchLimiters               *tasklist.DispatchLimiters
 }
 
 // NewService builds a new cadence-matching service
@@ -107,6 +111,12 @@ func (s *Service) Start() {
 	logger := s.GetLogger()
 	logger.Info("matching starting")
 
+	dispatchLimiters, err := tasklist.NewDispatchLimiters(s.config, s.GetRatelimiterAggregatorsClient(), s.GetLogger(), s.GetMetricsClient())
+	if err != nil {
+		logger.Fatal("failed to create task list dispatch global ratelimiter collection", tag.Error(err))
+	}
+	s.dispatchLimiters = dispatchLimiters
+
 	engine := handler.NewEngine(
 		s.GetTaskManager(),
 		s.GetClusterMetadata(),
@@ -125,6 +135,7 @@ func (s *Service) Start() {
 		s.ShardDistributorMatchingConfig,
 		s.drainObserver,
 		s.percentageOnboarded,
+		s.dispatchLimiters,
 	)
 
 	s.handler = handler.NewHandler(engine, s.config, s.GetDomainCache(), s.GetMetricsClient(), s.GetLogger(), s.GetThrottledLogger())
@@ -137,6 +148,9 @@ func (s *Service) Start() {
 
 	// must start base service first
 	s.Resource.Start()
+	if err := s.dispatchLimiters.OnStart(context.Background()); err != nil {
+		logger.Fatal("failed to start task list dispatch global ratelimiter collection", tag.Error(err))
+	}
 	s.handler.Start()
 
 	logger.Info("matching started")
@@ -159,6 +173,11 @@ func (s *Service) Stop() {
 	close(s.stopC)
 
 	s.handler.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.dispatchLimiters.OnStop(ctx); err != nil {
+		s.GetLogger().Error("failed to stop task list dispatch global ratelimiter collection", tag.Error(err))
+	}
 	s.Resource.Stop()
 
 	s.GetLogger().Info("matching stopped")
@@ -71,6 +71,7 @@ type (
 		totalQPS                   float64
 		qpsByIsolationGroup        map[string]float64
 		hasPollersByIsolationGroup map[string]bool
+		drainedIsolationGroups     map[string]bool
 		byPartition                map[int]*partitionMetrics
 		isIsolationEnabled         bool
 	}
@@ -354,7 +355,11 @@ func (a *adaptiveScalerImpl) fetchMetricsFromPartitions(config *types.TaskListPa
 		return nil, err
 	}
 
-	return a.toAggregateMetrics(results), err
+	m := a.toAggregateMetrics(results)
+	if a.config.EnableIsolationGroupDrainMigration() {
+		m.drainedIsolationGroups = a.tlMgr.DrainedIsolationGroups(a.ctx)
+	}
+	return m, nil
 }
 
 func (a *adaptiveScalerImpl) describePartition(partitionID int) (*types.DescribeTaskListResponse, error) {
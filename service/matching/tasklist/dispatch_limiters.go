@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tasklist
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/quotas"
+	"github.com/uber/cadence/common/quotas/global/collection"
+	"github.com/uber/cadence/common/quotas/global/rpc"
+	"github.com/uber/cadence/service/matching/config"
+)
+
+const (
+	dispatchCollectionName = "taskListDispatch"
+	// dispatchLimiterRefreshInterval is how often local dispatch limiters pick up changes of the rate reported by pollers
+	// or of the number of partitions
+	dispatchLimiterRefreshInterval = time.Second
+)
+
+type (
+	// DispatchLimiters shares the dispatch rate limit of task lists between the matching hosts owning their partitions.
+	// Partitions of normal task lists register their limiter, and the usage of each task list is reported to the global
+	// ratelimiter aggregators, which return the portion of the task list rate this host may dispatch at.
+	// Task lists in "disabled" mode keep their per partition limiter, see dynamicproperties.MatchingGlobalRatelimiterMode.
+	DispatchLimiters struct {
+		collection *collection.Collection
+
+		lock sync.RWMutex
+		// limiters of the partitions on this host, by task list key
+		limiters map[string]map[*taskListLimiter]struct{}
+	}
+
+	dispatchLimiterFactory struct {
+		limiters *DispatchLimiters
+	}
+)
+
+// NewDispatchLimiters creates the dispatch limiters of a matching host, OnStart must be called to start sharing rates
+func NewDispatchLimiters(
+	cfg *config.Config,
+	aggs rpc.Client,
+	logger log.Logger,
+	metricsClient metrics.Client,
+) (*DispatchLimiters, error) {
+	d := &DispatchLimiters{
+		limiters: make(map[string]map[*taskListLimiter]struct{}),
+	}
+	c, err := collection.New(
+		dispatchCollectionName,
+		// the local and fallback collections must not share limiters, or shadowing double-counts dispatches
+		quotas.NewCollection[string](dispatchLimiterFactory{limiters: d}),
+		quotas.NewCollection[string](dispatchLimiterFactory{limiters: d}),
+		cfg.GlobalRatelimiterUpdateInterval,
+		d.targetRPS,
+		cfg.GlobalRatelimiterKeyMode,
+		aggs,
+		logger,
+		metricsClient,
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.collection = c
+	return d, nil
+}
+
+// OnStart starts reporting dispatch usage to aggregators, it follows fx's OnStart hook semantics
+func (d *DispatchLimiters) OnStart(ctx context.Context) error {
+	return d.collection.OnStart(ctx)
+}
+
+// OnStop stops reporting dispatch usage to aggregators, it follows fx's OnStop hook semantics
+func (d *DispatchLimiters) OnStop(ctx context.Context) error {
+	return d.collection.OnStop(ctx)
+}
+
+func (d *DispatchLimiters) register(key string, limiter *taskListLimiter) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.limiters[key] == nil {
+		d.limiters[key] = make(map[*taskListLimiter]struct{})
+	}
+	d.limiters[key][limiter] = struct{}{}
+}
+
+func (d *DispatchLimiters) unregister(key string, limiter *taskListLimiter) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	delete(d.limiters[key], limiter)
+	if len(d.limiters[key]) == 0 {
+		delete(d.limiters, key)
+	}
+}
+
+// enabled returns false if the task list dispatches with per partition limiters
+func (d *DispatchLimiters) enabled(key string) bool {
+	return d.collection.Enabled(key)
+}
+
+// limiter returns the limiter shared by the partitions of the task list on this host
+func (d *DispatchLimiters) limiter(key string) quotas.Limiter {
+	return d.collection.For(key)
+}
+
+// targetRPS is the dispatch rate of the task list across all of its partitions
+func (d *DispatchLimiters) targetRPS(key string) int {
+	rps, _, _ := d.rates(key)
+	return int(math.Ceil(rps))
+}
+
+// localRPS is the dispatch rate of the partitions of the task list on this host when the rate is not shared with other hosts,
+// i.e. the sum of the per partition rates.
+func (d *DispatchLimiters) localRPS(key string) float64 {
+	rps, partitions, local := d.rates(key)
+	if partitions == 0 {
+		return 0
+	}
+	return rps * float64(min(local, partitions)) / float64(partitions)
+}
+
+// rates returns the lowest rate reported to the partitions of the task list on this host, the number of partitions
+// of the task list and the number of them on this host
+func (d *DispatchLimiters) rates(key string) (rps float64, partitions int, local int) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	first := true
+	for limiter := range d.limiters[key] {
+		value, numPartitions := limiter.dispatchRPS()
+		if first || value < rps {
+			rps = value
+		}
+		partitions = max(partitions, numPartitions)
+		first = false
+	}
+	return rps, partitions, len(d.limiters[key])
+}
+
+func (f dispatchLimiterFactory) GetLimiter(key string) quotas.Limiter {
+	return quotas.NewDynamicRateLimiterWithOpts(func() float64 {
+		return f.limiters.localRPS(key)
+	}, quotas.DynamicRateLimiterOpts{
+		TTL:      dispatchLimiterRefreshInterval,
+		MinBurst: 1,
+	})
+}
+
+// dispatchLimiterKey identifies a task list across its partitions, e.g. "samples-domain/orders/activity"
+func dispatchLimiterKey(domainName string, taskList *Identifier) string {
+	taskType := "decision"
+	if taskList.GetType() == persistence.TaskListTypeActivity {
+		taskType = "activity"
+	}
+	return quotas.TaskListKey{Domain: domainName, TaskList: taskList.GetRoot()}.String() + "/" + taskType
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tasklist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/service/matching/config"
+)
+
+func TestDispatchLimiterKey(t *testing.T) {
+	decision, err := NewIdentifier("domain-id", "/__cadence_sys/orders/2", persistence.TaskListTypeDecision)
+	require.NoError(t, err)
+	activity, err := NewIdentifier("domain-id", "orders", persistence.TaskListTypeActivity)
+	require.NoError(t, err)
+
+	assert.Equal(t, "samples-domain/orders/decision", dispatchLimiterKey("samples-domain", decision))
+	assert.Equal(t, "samples-domain/orders/activity", dispatchLimiterKey("samples-domain", activity))
+}
+
+func TestDispatchLimiters(t *testing.T) {
+	const key = "samples-domain/orders/activity"
+	cases := []struct {
+		name string
+		// rps and number of partitions of the partitions on this host
+		partitions [][2]int
+		mode       string
+		targetRPS  int
+		localRPS   float64
+		shared     bool
+	}{
+		{
+			name:       "no partitions",
+			mode:       "global",
+			targetRPS:  0,
+			localRPS:   0,
+			shared:     true,
+			partitions: nil,
+		},
+		{
+			name:       "one of four partitions",
+			mode:       "global",
+			partitions: [][2]int{{100, 4}},
+			targetRPS:  100,
+			localRPS:   25,
+			shared:     true,
+		},
+		{
+			name:       "three of four partitions, lowest rate wins",
+			mode:       "local",
+			partitions: [][2]int{{100, 4}, {80, 4}, {100, 4}},
+			targetRPS:  80,
+			localRPS:   60,
+			shared:     true,
+		},
+		{
+			name:       "partition count shrinking",
+			mode:       "local-shadow-global",
+			partitions: [][2]int{{100, 2}, {100, 1}, {100, 1}},
+			targetRPS:  100,
+			localRPS:   100,
+			shared:     true,
+		},
+		{
+			name:       "disabled",
+			mode:       "disabled",
+			partitions: [][2]int{{100, 4}},
+			targetRPS:  100,
+			localRPS:   25,
+			shared:     false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{
+				GlobalRatelimiterKeyMode: func(globalRatelimitKey string) string {
+					assert.Equal(t, dispatchCollectionName+":"+key, globalRatelimitKey)
+					return tc.mode
+				},
+				GlobalRatelimiterUpdateInterval: func(...dynamicproperties.FilterOption) time.Duration { return time.Second },
+			}
+			d, err := NewDispatchLimiters(cfg, nil, testlogger.New(t), metrics.NewNoopMetricsClient())
+			require.NoError(t, err)
+
+			var limiters []*taskListLimiter
+			for _, p := range tc.partitions {
+				limiter := newTestTaskListLimiter(float64(p[0]), p[1])
+				limiter.shareDispatch(d, key)
+				limiters = append(limiters, limiter)
+			}
+
+			assert.Equal(t, tc.targetRPS, d.targetRPS(key))
+			assert.InDelta(t, tc.localRPS, d.localRPS(key), precision)
+			for _, limiter := range limiters {
+				if tc.shared {
+					assert.Equal(t, rate.Limit(tc.localRPS), limiter.Limit(), "partitions on the host should share the host portion of the rate")
+				} else {
+					assert.Equal(t, limiter.backing.Limit(), limiter.Limit(), "partitions should keep their own limiter")
+				}
+			}
+
+			for _, limiter := range limiters {
+				limiter.stopSharingDispatch()
+			}
+			assert.Empty(t, d.limiters, "unloaded partitions should be unregistered")
+			assert.Zero(t, d.localRPS(key))
+		})
+	}
+}
+
+func newTestTaskListLimiter(rps float64, partitions int) *taskListLimiter {
+	return newTaskListLimiter(clock.NewMockedTimeSource(), metrics.NoopScope, &config.TaskListConfig{
+		TaskDispatchRPSTTL:         time.Second,
+		TaskDispatchRPS:            rps,
+		MinTaskThrottlingBurstSize: func() int { return 1 },
+	}, func() int { return partitions })
+}
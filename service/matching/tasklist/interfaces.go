@@ -82,6 +82,10 @@ type (
 		CancelPoller(pollerID string)
 		GetAllPollerInfo() []*types.PollerInfo
 		HasPollerAfter(accessTime time.Time) bool
+		// HasOnlyDrainedPollersAfter checks if all pollers after a timestamp are in drained isolation groups
+		HasOnlyDrainedPollersAfter(ctx context.Context, accessTime time.Time) bool
+		// DrainedIsolationGroups returns the drained isolation groups work should be migrated away from
+		DrainedIsolationGroups(ctx context.Context) map[string]bool
 		// DescribeTaskList returns information about the target tasklist
 		DescribeTaskList(includeTaskListStatus bool) *types.DescribeTaskListResponse
 		String() string
@@ -222,6 +222,20 @@ func (mr *MockManagerMockRecorder) DispatchTask(ctx, task any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchTask", reflect.TypeOf((*MockManager)(nil).DispatchTask), ctx, task)
 }
 
+// DrainedIsolationGroups mocks base method.
+func (m *MockManager) DrainedIsolationGroups(ctx context.Context) map[string]bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DrainedIsolationGroups", ctx)
+	ret0, _ := ret[0].(map[string]bool)
+	return ret0
+}
+
+// DrainedIsolationGroups indicates an expected call of DrainedIsolationGroups.
+func (mr *MockManagerMockRecorder) DrainedIsolationGroups(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DrainedIsolationGroups", reflect.TypeOf((*MockManager)(nil).DrainedIsolationGroups), ctx)
+}
+
 // GetAllPollerInfo mocks base method.
 func (m *MockManager) GetAllPollerInfo() []*types0.PollerInfo {
 	m.ctrl.T.Helper()
@@ -265,6 +279,20 @@ func (mr *MockManagerMockRecorder) GetTaskListKind() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskListKind", reflect.TypeOf((*MockManager)(nil).GetTaskListKind))
 }
 
+// HasOnlyDrainedPollersAfter mocks base method.
+func (m *MockManager) HasOnlyDrainedPollersAfter(ctx context.Context, accessTime time.Time) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasOnlyDrainedPollersAfter", ctx, accessTime)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasOnlyDrainedPollersAfter indicates an expected call of HasOnlyDrainedPollersAfter.
+func (mr *MockManagerMockRecorder) HasOnlyDrainedPollersAfter(ctx, accessTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasOnlyDrainedPollersAfter", reflect.TypeOf((*MockManager)(nil).HasOnlyDrainedPollersAfter), ctx, accessTime)
+}
+
 // HasPollerAfter mocks base method.
 func (m *MockManager) HasPollerAfter(accessTime time.Time) bool {
 	m.ctrl.T.Helper()
@@ -138,11 +138,17 @@ func (i *isolationBalancer) calculatePartitionGoal(aggregateMetrics *aggregatePa
 	assignableGroups := 0
 	// Identify groups that have switched to assignable and count them. This impacts the minimum partitions per group
 	for group, state := range i.groupState {
-		groupHasPollers := aggregateMetrics.hasPollersByIsolationGroup[group]
+		// Pollers of a drained group don't count, so the group has to keep pollers for a sustained period after it's undrained
+		isDrained := aggregateMetrics.drainedIsolationGroups[group]
+		groupHasPollers := aggregateMetrics.hasPollersByIsolationGroup[group] && !isDrained
 		sustainedPollers := state.hasPollers.CheckAndReset(groupHasPollers)
 		sustainedNoPollers := state.noPollers.CheckAndReset(!groupHasPollers)
 		if state.canAssignToPartitions {
-			if sustainedNoPollers {
+			if isDrained {
+				// Reroute the partitions of a drained group right away rather than waiting for its pollers to go away
+				i.scope.Tagged(metrics.IsolationGroupTag(group)).IncCounter(metrics.IsolationGroupDrainReroute)
+				state.canAssignToPartitions = false
+			} else if sustainedNoPollers {
 				i.scope.Tagged(metrics.IsolationGroupTag(group)).IncCounter(metrics.IsolationGroupStoppedPolling)
 				state.canAssignToPartitions = false
 			} else {
@@ -1263,6 +1263,59 @@ func TestAdjustWritePartitions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "drained group is rerouted immediately and reassigned after undrain",
+			cycles: []*testCycle{
+				{
+					metrics: &aggregatePartitionMetrics{
+						qpsByIsolationGroup: map[string]float64{
+							"a": 100,
+							"b": 99,
+						},
+						hasPollersByIsolationGroup: map[string]bool{
+							"a": true,
+							"b": true,
+						},
+						drainedIsolationGroups: map[string]bool{
+							"b": true,
+						},
+					},
+					partitions: map[int]*types.TaskListPartition{
+						0: {IsolationGroups: []string{"a", "b"}},
+						1: {IsolationGroups: []string{"a", "b"}},
+					},
+					expected: map[int]*types.TaskListPartition{
+						0: {IsolationGroups: []string{"a"}},
+						1: {IsolationGroups: []string{"a"}},
+					},
+				},
+				{
+					metrics: &aggregatePartitionMetrics{
+						qpsByIsolationGroup: map[string]float64{
+							"a": 100,
+							"b": 99,
+						},
+						hasPollersByIsolationGroup: map[string]bool{
+							"a": true,
+							"b": true,
+						},
+					},
+					partitions: map[int]*types.TaskListPartition{
+						0: {IsolationGroups: []string{"a"}},
+						1: {IsolationGroups: []string{"a"}},
+					},
+				},
+				{},
+				{},
+				{},
+				{
+					expected: map[int]*types.TaskListPartition{
+						0: {IsolationGroups: []string{"a", "b"}},
+						1: {IsolationGroups: []string{"a", "b"}},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
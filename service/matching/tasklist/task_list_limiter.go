@@ -11,6 +11,7 @@ import (
 
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/quotas"
 	"github.com/uber/cadence/service/matching/config"
 )
 
@@ -28,6 +29,9 @@ type taskListLimiter struct {
 	lastUpdate        atomic.Time
 	countPartitions   func() int
 	partitions        int
+	// dispatchLimiters shares the rate of the task list with its partitions on other hosts, nil if it is not shared
+	dispatchLimiters *DispatchLimiters
+	dispatchKey      string
 }
 
 func newTaskListLimiter(timeSource clock.TimeSource, scope metrics.Scope, config *config.TaskListConfig, numPartitions func() int) *taskListLimiter {
@@ -47,20 +51,53 @@ func newTaskListLimiter(timeSource clock.TimeSource, scope metrics.Scope, config
 	return l
 }
 
+// shareDispatch makes the limiter share the rate of the task list with its partitions on other hosts.
+// It must be called before the limiter is used, stopSharingDispatch must be called once the partition is unloaded.
+func (l *taskListLimiter) shareDispatch(limiters *DispatchLimiters, key string) {
+	l.dispatchLimiters = limiters
+	l.dispatchKey = key
+	limiters.register(key, l)
+}
+
+func (l *taskListLimiter) stopSharingDispatch() {
+	if l.dispatchLimiters != nil {
+		l.dispatchLimiters.unregister(l.dispatchKey, l)
+	}
+}
+
+// current returns the limiter shared with other hosts if the task list is not in "disabled" mode, or the partition limiter
+func (l *taskListLimiter) current() quotas.Limiter {
+	if l.dispatchLimiters != nil && l.dispatchLimiters.enabled(l.dispatchKey) {
+		return l.dispatchLimiters.limiter(l.dispatchKey)
+	}
+	return l.backing
+}
+
 func (l *taskListLimiter) Allow() bool {
-	return l.backing.Allow()
+	return l.current().Allow()
 }
 
 func (l *taskListLimiter) Wait(ctx context.Context) error {
-	return l.backing.Wait(ctx)
+	return l.current().Wait(ctx)
 }
 
 func (l *taskListLimiter) Reserve() clock.Reservation {
-	return l.backing.Reserve()
+	return l.current().Reserve()
+}
+
+func (l *taskListLimiter) ReserveN(n int) clock.Reservation {
+	return l.current().ReserveN(n)
 }
 
 func (l *taskListLimiter) Limit() rate.Limit {
-	return l.backing.Limit()
+	return l.current().Limit()
+}
+
+// dispatchRPS returns the rate of the task list across all of its partitions and its number of partitions
+func (l *taskListLimiter) dispatchRPS() (float64, int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.value.Load(), l.partitions
 }
 
 func (l *taskListLimiter) ReportLimit(rps float64) {
@@ -103,6 +103,8 @@ type (
 		TimeSource      clock.TimeSource
 		CreateTime      time.Time
 		HistoryService  history.Client
+		// DispatchLimiters shares dispatch rates of normal task lists between hosts, optional
+		DispatchLimiters *DispatchLimiters
 	}
 
 	AddTaskParams struct {
@@ -151,12 +153,20 @@ type (
 		partitionConfig     *types.TaskListPartitionConfig
 		historyService      history.Client
 		taskCompleter       TaskCompleter
+
+		drainedGroupsLock      sync.Mutex
+		drainedGroups          map[string]bool
+		drainedGroupsCheckedAt time.Time
 	}
 )
 
 const (
 	// maxSyncMatchWaitTime is the max amount of time that we are willing to wait for a sync match to happen
 	maxSyncMatchWaitTime = 200 * time.Millisecond
+	// StickyPollerUnavailableWindow is how long the worker of a sticky task list is considered available after its last poll
+	StickyPollerUnavailableWindow = 10 * time.Second
+	// drainedGroupsCheckInterval is how long the drained isolation groups of a task list are reused before being looked up again
+	drainedGroupsCheckInterval = time.Second
 )
 
 var errRemoteSyncMatchFailed = &types.RemoteSyncMatchedError{Message: "remote sync match failed"}
@@ -248,6 +258,9 @@ func NewManager(p ManagerParams) (Manager, error) {
 		return taskListConfig.NumReadPartitions()
 	}
 	tlMgr.limiter = newTaskListLimiter(p.TimeSource, tlMgr.scope, taskListConfig, numReadPartitionsFn)
+	if p.DispatchLimiters != nil && p.TaskListKind == types.TaskListKindNormal {
+		tlMgr.limiter.shareDispatch(p.DispatchLimiters, dispatchLimiterKey(domainName, p.TaskList))
+	}
 	tlMgr.matcher = newTaskMatcher(taskListConfig, fwdr, tlMgr.scope, isolationGroups, tlMgr.logger, p.TaskList, p.TaskListKind, tlMgr.limiter).(*taskMatcherImpl)
 	tlMgr.taskWriter = newTaskWriter(tlMgr)
 	tlMgr.taskReader = newTaskReader(tlMgr, isolationGroups)
@@ -328,6 +341,7 @@ func (c *taskListManagerImpl) Stop() {
 	}
 	c.qpsTracker.Stop()
 	c.liveness.Stop()
+	c.limiter.stopSharingDispatch()
 	c.taskWriter.Stop()
 	c.taskReader.Stop()
 	c.matcher.DisconnectBlockedPollers()
@@ -743,6 +757,71 @@ func (c *taskListManagerImpl) HasPollerAfter(accessTime time.Time) bool {
 	return c.pollers.HasPollerAfter(accessTime)
 }
 
+// HasOnlyDrainedPollersAfter checks if all pollers after a timestamp are in drained isolation groups.
+// It is only used for sticky task lists, which are polled by a single worker, so pollers without an isolation group are ignored.
+// Always false unless isolation group drain migration is enabled for the domain.
+func (c *taskListManagerImpl) HasOnlyDrainedPollersAfter(ctx context.Context, accessTime time.Time) bool {
+	if !c.config.EnableIsolationGroupDrainMigration() {
+		return false
+	}
+	pollersByGroup := c.pollers.GetCountByIsolationGroup(accessTime)
+	if len(pollersByGroup) == 0 {
+		return false
+	}
+	drained := c.cachedDrainedIsolationGroups(ctx)
+	for group := range pollersByGroup {
+		if !drained[group] {
+			return false
+		}
+	}
+	return true
+}
+
+// isStickyWorkerDrained checks whether the worker of a sticky task list is in a drained isolation group,
+// in which case the executions of its tasks are moved to their normal task list.
+func (c *taskListManagerImpl) isStickyWorkerDrained(ctx context.Context) bool {
+	return c.taskListKind == types.TaskListKindSticky &&
+		c.HasOnlyDrainedPollersAfter(ctx, c.timeSource.Now().Add(-StickyPollerUnavailableWindow))
+}
+
+// DrainedIsolationGroups returns the isolation groups currently drained for the domain of the task list.
+// Always empty unless isolation group drain migration is enabled for the domain.
+func (c *taskListManagerImpl) DrainedIsolationGroups(ctx context.Context) map[string]bool {
+	drained := make(map[string]bool)
+	if !c.config.EnableIsolationGroupDrainMigration() {
+		return drained
+	}
+	for _, group := range c.config.AllIsolationGroups() {
+		if c.isIsolationGroupDrained(ctx, group) {
+			drained[group] = true
+		}
+	}
+	return drained
+}
+
+// cachedDrainedIsolationGroups returns the result of DrainedIsolationGroups, looked up at most once per drainedGroupsCheckInterval.
+// It is checked for every task dispatched from a sticky task list, which must not query the isolation group state each time.
+func (c *taskListManagerImpl) cachedDrainedIsolationGroups(ctx context.Context) map[string]bool {
+	c.drainedGroupsLock.Lock()
+	defer c.drainedGroupsLock.Unlock()
+	now := c.timeSource.Now()
+	if c.drainedGroups == nil || now.Sub(c.drainedGroupsCheckedAt) >= drainedGroupsCheckInterval {
+		c.drainedGroups = c.DrainedIsolationGroups(ctx)
+		c.drainedGroupsCheckedAt = now
+	}
+	return c.drainedGroups
+}
+
+func (c *taskListManagerImpl) isIsolationGroupDrained(ctx context.Context, group string) bool {
+	isDrained, err := c.isolationState.IsDrained(ctx, c.domainName, group)
+	if err != nil {
+		// fail open, the group keeps its work as it would without drain migration
+		c.logger.Error("Failed to determine whether isolation group is drained", tag.IsolationGroup(group), tag.Error(err))
+		return false
+	}
+	return isDrained
+}
+
 func (c *taskListManagerImpl) CancelPoller(pollerID string) {
 	if c.pollers.CancelPoll(pollerID) {
 		c.logger.Info("canceled outstanding poller", tag.WorkflowDomainName(c.domainName))
@@ -1025,6 +1104,9 @@ func newTaskListConfig(id *Identifier, cfg *config.Config, domainName string) *c
 		EnableTasklistIsolation: func() bool {
 			return cfg.EnableTasklistIsolation(domainName)
 		},
+		EnableIsolationGroupDrainMigration: func() bool {
+			return cfg.EnableIsolationGroupDrainMigration(domainName)
+		},
 		ActivityTaskSyncMatchWaitTime: cfg.ActivityTaskSyncMatchWaitTime,
 		GetTasksBatchSize: func() int {
 			return cfg.GetTasksBatchSize(domainName, taskListName, taskType)
@@ -23,6 +23,7 @@ package tasklist
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -1373,6 +1374,92 @@ func TestTaskListManagerImpl_HasPollerAfter(t *testing.T) {
 	}
 }
 
+func TestTaskListManagerImpl_HasOnlyDrainedPollersAfter(t *testing.T) {
+	for name, tc := range map[string]struct {
+		migrationEnabled bool
+		pollerGroups     []string
+		mockSetup        func(*isolationgroup.MockState)
+		want             bool
+	}{
+		"migration disabled": {
+			pollerGroups: []string{"datacenterA"},
+			want:         false,
+		},
+		"no pollers with isolation group": {
+			migrationEnabled: true,
+			pollerGroups:     []string{""},
+			want:             false,
+		},
+		"poller in drained group": {
+			migrationEnabled: true,
+			pollerGroups:     []string{"datacenterA"},
+			mockSetup: func(state *isolationgroup.MockState) {
+				state.EXPECT().IsDrained(gomock.Any(), "domainName", "datacenterA").Return(true, nil)
+				state.EXPECT().IsDrained(gomock.Any(), "domainName", "datacenterB").Return(false, nil)
+			},
+			want: true,
+		},
+		"poller in healthy group": {
+			migrationEnabled: true,
+			pollerGroups:     []string{"datacenterA", "datacenterB"},
+			mockSetup: func(state *isolationgroup.MockState) {
+				state.EXPECT().IsDrained(gomock.Any(), "domainName", "datacenterA").Return(true, nil)
+				state.EXPECT().IsDrained(gomock.Any(), "domainName", "datacenterB").Return(false, nil)
+			},
+			want: false,
+		},
+		"drain state error": {
+			migrationEnabled: true,
+			pollerGroups:     []string{"datacenterA"},
+			mockSetup: func(state *isolationgroup.MockState) {
+				state.EXPECT().IsDrained(gomock.Any(), "domainName", "datacenterA").Return(false, errors.New("some error"))
+				state.EXPECT().IsDrained(gomock.Any(), "domainName", "datacenterB").Return(false, nil)
+			},
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tlm, deps := setupMocksForTaskListManager(t, NewTestTaskListID(t, "domain-id", "sticky-tl", persistence.TaskListTypeDecision), types.TaskListKindSticky)
+			require.NoError(t, deps.dynamicClient.UpdateValue(dynamicproperties.EnableIsolationGroupDrainMigration, tc.migrationEnabled))
+			for i, group := range tc.pollerGroups {
+				tlm.pollers.StartPoll(fmt.Sprintf("poller%d", i), func() {}, &poller.Info{Identity: fmt.Sprintf("identity%d", i), IsolationGroup: group})
+			}
+			if tc.mockSetup != nil {
+				tc.mockSetup(deps.mockIsolationState)
+			}
+
+			assert.Equal(t, tc.want, tlm.HasOnlyDrainedPollersAfter(context.Background(), time.Time{}))
+		})
+	}
+}
+
+func TestTaskListManagerImpl_DrainedIsolationGroups(t *testing.T) {
+	tlm, deps := setupMocksForTaskListManager(t, NewTestTaskListID(t, "domain-id", "tl", persistence.TaskListTypeDecision), types.TaskListKindNormal)
+	assert.Empty(t, tlm.DrainedIsolationGroups(context.Background()))
+
+	require.NoError(t, deps.dynamicClient.UpdateValue(dynamicproperties.EnableIsolationGroupDrainMigration, true))
+	deps.mockIsolationState.EXPECT().IsDrained(gomock.Any(), "domainName", "datacenterA").Return(true, nil)
+	deps.mockIsolationState.EXPECT().IsDrained(gomock.Any(), "domainName", "datacenterB").Return(false, nil)
+	assert.Equal(t, map[string]bool{"datacenterA": true}, tlm.DrainedIsolationGroups(context.Background()))
+}
+
+func TestTaskListManagerImpl_HasOnlyDrainedPollersAfter_CachesDrainedGroups(t *testing.T) {
+	tlm, deps := setupMocksForTaskListManager(t, NewTestTaskListID(t, "domain-id", "sticky-tl", persistence.TaskListTypeDecision), types.TaskListKindSticky)
+	require.NoError(t, deps.dynamicClient.UpdateValue(dynamicproperties.EnableIsolationGroupDrainMigration, true))
+	tlm.pollers.StartPoll("poller", func() {}, &poller.Info{Identity: "identity", IsolationGroup: "datacenterA"})
+
+	deps.mockIsolationState.EXPECT().IsDrained(gomock.Any(), "domainName", "datacenterA").Return(false, nil).Times(1)
+	deps.mockIsolationState.EXPECT().IsDrained(gomock.Any(), "domainName", "datacenterB").Return(false, nil).Times(1)
+	assert.False(t, tlm.HasOnlyDrainedPollersAfter(context.Background(), time.Time{}))
+	deps.mockTimeSource.Advance(drainedGroupsCheckInterval / 2)
+	assert.False(t, tlm.HasOnlyDrainedPollersAfter(context.Background(), time.Time{}))
+
+	deps.mockIsolationState.EXPECT().IsDrained(gomock.Any(), "domainName", "datacenterA").Return(true, nil).Times(1)
+	deps.mockIsolationState.EXPECT().IsDrained(gomock.Any(), "domainName", "datacenterB").Return(false, nil).Times(1)
+	deps.mockTimeSource.Advance(drainedGroupsCheckInterval)
+	assert.True(t, tlm.HasOnlyDrainedPollersAfter(context.Background(), time.Time{}))
+}
+
 func getIsolationgroupsHelper() []string {
 	return testIsolationGroups
 }
@@ -55,6 +55,9 @@ const (
 
 	// a buffer to add to the dispatch timeout to have some room even task waits for the whole rate-limit period
 	taskDispatchTimeoutBuffer = 100 * time.Millisecond
+
+	// timeout of resetting the stickiness of an execution whose sticky worker was drained
+	resetStickyTaskListTimeout = 5 * time.Second
 )
 
 type (
@@ -411,6 +414,30 @@ func (tr *taskReader) completeTask(task *persistence.TaskInfo, err error) {
 	tr.taskGC.Run(ackLevel)
 }
 
+// resetStickyTaskList clears the stickiness of the execution of a task, so its next decisions are scheduled on the normal task list
+// instead of first trying the drained sticky worker
+func (tr *taskReader) resetStickyTaskList(taskInfo *persistence.TaskInfo) {
+	tr.scope.IncCounter(metrics.IsolationGroupDrainStickyReset)
+	ctx, cancel := context.WithTimeout(tr.cancelCtx, resetStickyTaskListTimeout)
+	defer cancel()
+	_, err := tr.tlMgr.historyService.ResetStickyTaskList(ctx, &types.HistoryResetStickyTaskListRequest{
+		DomainUUID: taskInfo.DomainID,
+		Execution: &types.WorkflowExecution{
+			WorkflowID: taskInfo.WorkflowID,
+			RunID:      taskInfo.RunID,
+		},
+	})
+	if err != nil {
+		// the history service clears the stickiness itself once the sticky decision times out
+		tr.logger.Warn("Failed to reset sticky task list of execution with drained sticky worker",
+			tag.WorkflowDomainID(taskInfo.DomainID),
+			tag.WorkflowID(taskInfo.WorkflowID),
+			tag.WorkflowRunID(taskInfo.RunID),
+			tag.Error(err),
+		)
+	}
+}
+
 func (tr *taskReader) newDispatchContext(isolationGroup string, isolationDuration time.Duration) (context.Context, context.CancelFunc) {
 	rps := float64(tr.rateLimit())
 	if isolationGroup != "" || rps > 1e-7 { // 1e-7 is a random number chosen to avoid overflow, normally user don't set such a low rps
@@ -471,6 +498,15 @@ func (tr *taskReader) dispatchSingleTaskFromBuffer(taskInfo *persistence.TaskInf
 		tr.taskAckManager.AckItem(taskInfo.TaskID)
 		return false, true
 	}
+	if tr.tlMgr.isStickyWorkerDrained(tr.cancelCtx) {
+		// the history service times out the sticky decision and schedules it on the normal task list,
+		// where a worker of a healthy isolation group picks it up, so the task is no longer needed
+		e.EventName = "Sticky Worker Drained"
+		event.Log(e)
+		tr.resetStickyTaskList(taskInfo)
+		tr.taskAckManager.AckItem(taskInfo.TaskID)
+		return false, true
+	}
 	isolationGroup, isolationDuration := tr.getIsolationGroupForTask(tr.cancelCtx, taskInfo)
 	_, isolationGroupIsKnown := tr.taskBuffers[isolationGroup]
 	if !isolationGroupIsKnown {
@@ -34,13 +34,17 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
 	commonConfig "github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/matching/config"
+	"github.com/uber/cadence/service/matching/poller"
 )
 
 const defaultIsolationGroup = "a"
@@ -240,6 +244,57 @@ func TestDispatchSingleTaskFromBuffer(t *testing.T) {
 	}
 }
 
+func TestDispatchSingleTaskFromBuffer_StickyWorkerDrained(t *testing.T) {
+	testCases := []struct {
+		name     string
+		drained  bool
+		resetErr error
+		dispatch bool
+	}{
+		{
+			name:    "drained sticky worker resets the stickiness of the execution",
+			drained: true,
+		},
+		{
+			name:     "task is dropped when stickiness can't be reset",
+			drained:  true,
+			resetErr: errors.New("history unavailable"),
+		},
+		{
+			name:     "healthy sticky worker gets the task",
+			dispatch: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tlm, deps := setupMocksForTaskListManager(t, NewTestTaskListID(t, "domain-id", "sticky-tl", persistence.TaskListTypeDecision), types.TaskListKindSticky)
+			require.NoError(t, deps.dynamicClient.UpdateValue(dynamicproperties.EnableIsolationGroupDrainMigration, true))
+			tlm.pollers.StartPoll("poller", func() {}, &poller.Info{Identity: "identity", IsolationGroup: "datacenterA"})
+			deps.mockIsolationState.EXPECT().IsDrained(gomock.Any(), "domainName", "datacenterA").Return(tc.drained, nil)
+			deps.mockIsolationState.EXPECT().IsDrained(gomock.Any(), "domainName", "datacenterB").Return(false, nil)
+			deps.mockDomainCache.EXPECT().GetDomainByID("domain-id").Return(cache.CreateDomainCacheEntry("domainName"), nil).AnyTimes()
+			taskInfo := newTask(deps.mockTimeSource)
+			if tc.drained {
+				tlm.historyService.(*history.MockClient).EXPECT().ResetStickyTaskList(gomock.Any(), &types.HistoryResetStickyTaskListRequest{
+					DomainUUID: taskInfo.DomainID,
+					Execution:  &types.WorkflowExecution{WorkflowID: taskInfo.WorkflowID, RunID: taskInfo.RunID},
+				}).Return(&types.HistoryResetStickyTaskListResponse{}, tc.resetErr)
+			}
+			reader := tlm.taskReader
+			dispatched := false
+			reader.dispatchTask = func(ctx context.Context, task *InternalTask) error {
+				dispatched = true
+				return nil
+			}
+
+			breakDispatch, breakRetries := reader.dispatchSingleTaskFromBuffer(taskInfo)
+			assert.False(t, breakDispatch)
+			assert.True(t, breakRetries)
+			assert.Equal(t, tc.dispatch, dispatched)
+		})
+	}
+}
+
 func TestGetDispatchTimeout(t *testing.T) {
 	testCases := []struct {
 		name              string
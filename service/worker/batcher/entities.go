@@ -56,6 +56,25 @@ type ReplicateParams struct {
 	TargetCluster string
 }
 
+// ResetParams is the parameters for resetting workflow
+type ResetParams struct {
+	// ResetType is where to reset the workflow to, one of AllResetTypes
+	ResetType string
+	// DecisionOffset moves the reset point of LastDecisionCompleted and LastDecisionScheduled back by that many decisions.
+	// Only zero or negative numbers are supported.
+	DecisionOffset int
+	// BadBinaryChecksum is required for ResetTypeBadBinary
+	BadBinaryChecksum string
+	// SkipSignalReapply skips reapplying the signals received after the reset point
+	SkipSignalReapply bool
+}
+
+// DeleteParams is the parameters for deleting workflow
+type DeleteParams struct {
+	// SkipErrors continues deleting the rest of a workflow's data when part of it fails to be deleted
+	SkipErrors bool
+}
+
 // BatchParams is the parameters for batch operation workflow
 type BatchParams struct {
 	// Target domain to execute batch operation
@@ -64,7 +83,7 @@ type BatchParams struct {
 	Query string
 	// Reason for the operation
 	Reason string
-	// Supporting: reset,terminate
+	// One of AllBatchTypes
 	BatchType string
 
 	// Below are all optional
@@ -76,6 +95,12 @@ type BatchParams struct {
 	SignalParams SignalParams
 	// ReplicateParams is params only for BatchTypeReplicate
 	ReplicateParams ReplicateParams
+	// ResetParams is params only for BatchTypeReset
+	ResetParams ResetParams
+	// DeleteParams is params only for BatchTypeDelete
+	DeleteParams DeleteParams
+	// DryRun only records the workflows matching Query in the result log without processing them
+	DryRun bool
 	// RPS of processing. Default to DefaultRPS
 	// TODO we will implement smarter way than this static rate limiter: https://github.com/uber/cadence/issues/2138
 	RPS int
@@ -106,15 +131,58 @@ type HeartBeatDetails struct {
 	CurrentPage int
 	// This is just an estimation for visibility
 	TotalEstimate int64
-	// Number of workflows processed successfully
+	// Number of workflows processed successfully, or matched by a dry run
 	SuccessCount int
 	// Number of workflows that give up due to errors.
 	ErrorCount int
 }
 
+func (hbd *HeartBeatDetails) addResults(results []WorkflowResult) {
+	for _, result := range results {
+		if result.Outcome == OutcomeFailed {
+			hbd.ErrorCount++
+		} else {
+			hbd.SuccessCount++
+		}
+	}
+}
+
 type taskDetail struct {
 	execution types.WorkflowExecution
 	attempts  int
 	// passing along the current heartbeat details to make heartbeat within a task so that it won't timeout
 	hbd HeartBeatDetails
 }
+
+// BatchProgress is a summary of HeartBeatDetails for reporting the progress of a running batch operation
+type BatchProgress struct {
+	Processed       int           `json:"processed"`
+	Succeeded       int           `json:"succeeded"`
+	Failed          int           `json:"failed"`
+	TotalEstimate   int64         `json:"totalEstimate"`
+	PercentComplete float64       `json:"percentComplete"`
+	Elapsed         time.Duration `json:"elapsed"`
+	// Remaining is estimated from the processing rate so far, zero if unknown
+	Remaining time.Duration `json:"remaining"`
+}
+
+// Progress returns the progress of a batch operation which has been running for elapsed time
+func (hbd HeartBeatDetails) Progress(elapsed time.Duration) BatchProgress {
+	progress := BatchProgress{
+		Processed:     hbd.SuccessCount + hbd.ErrorCount,
+		Succeeded:     hbd.SuccessCount,
+		Failed:        hbd.ErrorCount,
+		TotalEstimate: hbd.TotalEstimate,
+		Elapsed:       elapsed,
+	}
+	if progress.TotalEstimate <= 0 {
+		return progress
+	}
+	// the estimate is taken before the scan starts, so more workflows than estimated can be processed
+	progress.PercentComplete = min(100, float64(progress.Processed)*100/float64(progress.TotalEstimate))
+	remaining := progress.TotalEstimate - int64(progress.Processed)
+	if progress.Processed > 0 && remaining > 0 {
+		progress.Remaining = time.Duration(float64(elapsed) / float64(progress.Processed) * float64(remaining))
+	}
+	return progress
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package batcher
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	// ReplayBundleWFTypeName is the workflow type of sampling closed workflow histories into a replay bundle
+	ReplayBundleWFTypeName   = "cadence-sys-batch-replay-bundle-workflow"
+	replayBundleActivityName = "cadence-sys-batch-replay-bundle-activity"
+
+	// DefaultReplayBundleSampleSize is the default value for ReplayBundleParams.SampleSize
+	DefaultReplayBundleSampleSize = 100
+	// MaxReplayBundleSampleSize is the maximum number of histories in a replay bundle
+	MaxReplayBundleSampleSize = 1000
+	// DefaultReplayBundleScanLimit is the default value for ReplayBundleParams.ScanLimit
+	DefaultReplayBundleScanLimit = 10000
+)
+
+var (
+	replayBundleActivityOptions = workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    time.Hour,
+		HeartbeatTimeout:       time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          10 * time.Second,
+			BackoffCoefficient:       1.7,
+			MaximumInterval:          5 * time.Minute,
+			ExpirationInterval:       6 * time.Hour,
+			NonRetriableErrorReasons: []string{_nonRetriableReason},
+		},
+	}
+)
+
+type (
+	// ReplayBundleParams is the parameters of a replay bundle job
+	ReplayBundleParams struct {
+		// DomainName is the domain of the workflows to sample
+		DomainName string
+		// Query is the visibility query selecting the workflows to sample, only closed workflows are included
+		Query string
+		// SampleSize is the number of histories in the bundle. Default to DefaultReplayBundleSampleSize
+		SampleSize int
+		// ScanLimit is the number of visibility records the sample is drawn from. Default to DefaultReplayBundleScanLimit
+		ScanLimit int
+		// PageSize is the page size of scanning visibility records. Default to DefaultPageSize
+		PageSize int
+	}
+
+	// ReplayBundleEntry describes a history in a replay bundle.
+	// EventCount and SizeBytes are filled in when the history is fetched.
+	ReplayBundleEntry struct {
+		Index        int    `json:"index"`
+		WorkflowID   string `json:"workflowID"`
+		RunID        string `json:"runID"`
+		WorkflowType string `json:"workflowType"`
+		CloseStatus  string `json:"closeStatus"`
+		EventCount   int    `json:"eventCount"`
+		SizeBytes    int    `json:"sizeBytes"`
+	}
+
+	// ReplayBundleManifest lists the histories of a replay bundle. Only the workflow identifiers are kept,
+	// the histories are read from the sampled domain when the bundle is fetched, so they are subject to its retention.
+	ReplayBundleManifest struct {
+		JobID       string              `json:"jobID"`
+		DomainName  string              `json:"domain"`
+		Query       string              `json:"query"`
+		CreatedTime time.Time           `json:"createdTime"`
+		Scanned     int                 `json:"scanned"`
+		Histories   []ReplayBundleEntry `json:"histories"`
+	}
+
+	replayBundleProgress struct {
+		PageToken []byte
+		Scanned   int
+		// Closed is the number of closed workflows the candidates are sampled from
+		Closed     int
+		ScanDone   bool
+		Candidates []ReplayBundleEntry
+	}
+)
+
+func init() {
+	workflow.RegisterWithOptions(ReplayBundleWorkflow, workflow.RegisterOptions{Name: ReplayBundleWFTypeName})
+	activity.RegisterWithOptions(replayBundleActivity, activity.RegisterOptions{Name: replayBundleActivityName})
+}
+
+// ReplayBundleWorkflow samples closed workflows matching a visibility query and returns them as the manifest of a replay bundle
+func ReplayBundleWorkflow(ctx workflow.Context, params ReplayBundleParams) (ReplayBundleManifest, error) {
+	if params.DomainName == "" {
+		return ReplayBundleManifest{}, fmt.Errorf("must provide domain name")
+	}
+	if params.SampleSize <= 0 {
+		params.SampleSize = DefaultReplayBundleSampleSize
+	}
+	if params.SampleSize > MaxReplayBundleSampleSize {
+		return ReplayBundleManifest{}, fmt.Errorf("sample size must not exceed %d", MaxReplayBundleSampleSize)
+	}
+	if params.ScanLimit <= 0 {
+		params.ScanLimit = DefaultReplayBundleScanLimit
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = DefaultPageSize
+	}
+	var manifest ReplayBundleManifest
+	err := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, replayBundleActivityOptions), replayBundleActivityName, params).Get(ctx, &manifest)
+	return manifest, err
+}
+
+func replayBundleActivity(ctx context.Context, params ReplayBundleParams) (ReplayBundleManifest, error) {
+	batcher := ctx.Value(BatcherContextKey).(*Batcher)
+	client := batcher.clientBean.GetFrontendClient()
+	logger := getActivityLogger(ctx)
+
+	var progress replayBundleProgress
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &progress); err != nil {
+			logger.Error("Failed to recover replay bundle progress", tag.Error(err))
+			progress = replayBundleProgress{}
+		}
+	}
+
+	// reservoir sampling keeps every closed workflow scanned equally likely to end up in the bundle
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for !progress.ScanDone {
+		resp, err := client.ScanWorkflowExecutions(ctx, &types.ListWorkflowExecutionsRequest{
+			Domain:        params.DomainName,
+			PageSize:      int32(params.PageSize),
+			NextPageToken: progress.PageToken,
+			Query:         params.Query,
+		})
+		if err != nil {
+			return ReplayBundleManifest{}, err
+		}
+		for _, execution := range resp.Executions {
+			progress.Scanned++
+			if execution.CloseStatus == nil {
+				continue
+			}
+			candidate := ReplayBundleEntry{
+				WorkflowID:   execution.GetExecution().GetWorkflowID(),
+				RunID:        execution.GetExecution().GetRunID(),
+				WorkflowType: execution.GetType().GetName(),
+				CloseStatus:  execution.CloseStatus.String(),
+			}
+			progress.Closed++
+			if len(progress.Candidates) < params.SampleSize {
+				progress.Candidates = append(progress.Candidates, candidate)
+			} else if i := random.Intn(progress.Closed); i < params.SampleSize {
+				progress.Candidates[i] = candidate
+			}
+		}
+		progress.PageToken = resp.NextPageToken
+		progress.ScanDone = len(resp.NextPageToken) == 0 || progress.Scanned >= params.ScanLimit
+		activity.RecordHeartbeat(ctx, progress)
+	}
+
+	for i := range progress.Candidates {
+		progress.Candidates[i].Index = i
+	}
+	manifest := ReplayBundleManifest{
+		JobID:       activity.GetInfo(ctx).WorkflowExecution.ID,
+		DomainName:  params.DomainName,
+		Query:       params.Query,
+		CreatedTime: time.Now().UTC(),
+		Scanned:     progress.Scanned,
+		Histories:   progress.Candidates,
+	}
+	if manifest.Histories == nil {
+		manifest.Histories = []ReplayBundleEntry{}
+	}
+	return manifest, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package batcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/types"
+)
+
+func TestReplayBundleActivity(t *testing.T) {
+	activityEnv, batcher, mockResource := setupResultLogTest(t)
+	activityEnv.RegisterActivity(replayBundleActivity)
+	// the bundle only keeps workflow identifiers, it must not depend on a blobstore
+	batcher.blobstoreClient = nil
+
+	closed := types.WorkflowExecutionCloseStatusCompleted.Ptr()
+	mockResource.FrontendClient.EXPECT().ScanWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&types.ListWorkflowExecutionsResponse{
+		Executions: []*types.WorkflowExecutionInfo{
+			{Execution: &types.WorkflowExecution{WorkflowID: "wid1", RunID: "rid1"}, Type: &types.WorkflowType{Name: "wf"}, CloseStatus: closed},
+			{Execution: &types.WorkflowExecution{WorkflowID: "open", RunID: "rid"}, Type: &types.WorkflowType{Name: "wf"}},
+			{Execution: &types.WorkflowExecution{WorkflowID: "wid2", RunID: "rid2"}, Type: &types.WorkflowType{Name: "wf"}, CloseStatus: closed},
+		},
+	}, nil)
+
+	val, err := activityEnv.ExecuteActivity(replayBundleActivity, ReplayBundleParams{DomainName: "domain", Query: "WorkflowType = 'wf'", SampleSize: 10, ScanLimit: 100, PageSize: 10})
+	require.NoError(t, err)
+	var manifest ReplayBundleManifest
+	require.NoError(t, val.Get(&manifest))
+	assert.Equal(t, "domain", manifest.DomainName)
+	assert.Equal(t, "WorkflowType = 'wf'", manifest.Query)
+	assert.Equal(t, 3, manifest.Scanned)
+	assert.Equal(t, []ReplayBundleEntry{
+		{Index: 0, WorkflowID: "wid1", RunID: "rid1", WorkflowType: "wf", CloseStatus: "COMPLETED"},
+		{Index: 1, WorkflowID: "wid2", RunID: "rid2", WorkflowType: "wf", CloseStatus: "COMPLETED"},
+	}, manifest.Histories)
+}
+
+func TestReplayBundleActivity_ResumeKeepsClosedCount(t *testing.T) {
+	activityEnv, _, mockResource := setupResultLogTest(t)
+	activityEnv.RegisterActivity(replayBundleActivity)
+	// the sample is already full and was drawn from far more closed workflows than the sample size, so the
+	// workflow scanned after resuming replaces a candidate with a probability of 1 in 1000001 at most
+	candidate := ReplayBundleEntry{WorkflowID: "sampled", RunID: "rid"}
+	activityEnv.SetHeartbeatDetails(replayBundleProgress{
+		PageToken:  []byte("next"),
+		Scanned:    1000000,
+		Closed:     1000000,
+		Candidates: []ReplayBundleEntry{candidate},
+	})
+
+	closed := types.WorkflowExecutionCloseStatusCompleted.Ptr()
+	mockResource.FrontendClient.EXPECT().ScanWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&types.ListWorkflowExecutionsResponse{
+		Executions: []*types.WorkflowExecutionInfo{
+			{Execution: &types.WorkflowExecution{WorkflowID: "late", RunID: "rid"}, Type: &types.WorkflowType{Name: "wf"}, CloseStatus: closed},
+		},
+	}, nil)
+
+	val, err := activityEnv.ExecuteActivity(replayBundleActivity, ReplayBundleParams{DomainName: "domain", SampleSize: 1, ScanLimit: 2000000, PageSize: 10})
+	require.NoError(t, err)
+	var manifest ReplayBundleManifest
+	require.NoError(t, val.Get(&manifest))
+	assert.Equal(t, 1000001, manifest.Scanned)
+	require.Len(t, manifest.Histories, 1)
+	assert.Equal(t, "sampled", manifest.Histories[0].WorkflowID)
+}
+
+func TestReplayBundleWorkflow(t *testing.T) {
+	var env testsuite.WorkflowTestSuite
+	workflowEnv := env.NewTestWorkflowEnvironment()
+	workflowEnv.RegisterWorkflow(ReplayBundleWorkflow)
+	workflowEnv.OnActivity(replayBundleActivityName, mock.Anything, ReplayBundleParams{
+		DomainName: "domain",
+		SampleSize: DefaultReplayBundleSampleSize,
+		ScanLimit:  DefaultReplayBundleScanLimit,
+		PageSize:   DefaultPageSize,
+	}).Return(ReplayBundleManifest{JobID: "job"}, nil)
+
+	workflowEnv.ExecuteWorkflow(ReplayBundleWorkflow, ReplayBundleParams{DomainName: "domain"})
+	require.True(t, workflowEnv.IsWorkflowCompleted())
+	require.NoError(t, workflowEnv.GetWorkflowError())
+	var manifest ReplayBundleManifest
+	require.NoError(t, workflowEnv.GetWorkflowResult(&manifest))
+	assert.Equal(t, "job", manifest.JobID)
+}
+
+func TestReplayBundleWorkflow_InvalidParams(t *testing.T) {
+	tests := map[string]struct {
+		params  ReplayBundleParams
+		wantErr string
+	}{
+		"missing domain": {
+			params:  ReplayBundleParams{},
+			wantErr: "must provide domain name",
+		},
+		"sample too large": {
+			params:  ReplayBundleParams{DomainName: "domain", SampleSize: MaxReplayBundleSampleSize + 1},
+			wantErr: "sample size must not exceed",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var env testsuite.WorkflowTestSuite
+			workflowEnv := env.NewTestWorkflowEnvironment()
+			workflowEnv.RegisterWorkflow(ReplayBundleWorkflow)
+
+			workflowEnv.ExecuteWorkflow(ReplayBundleWorkflow, tc.params)
+			require.True(t, workflowEnv.IsWorkflowCompleted())
+			assert.ErrorContains(t, workflowEnv.GetWorkflowError(), tc.wantErr)
+		})
+	}
+}
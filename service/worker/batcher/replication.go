@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package batcher
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/uber/cadence/common/types"
+)
+
+// ReplicationWindowQuery returns a visibility query matching the workflows of a domain that may have changed
+// between start and end: workflows whose visibility record was updated in the window,
+// plus workflows that are still open and started before the end of the window.
+func ReplicationWindowQuery(start, end time.Time) string {
+	return fmt.Sprintf(
+		"(UpdateTime BETWEEN %d AND %d) OR (CloseTime = missing AND StartTime <= %d)",
+		start.UnixNano(), end.UnixNano(), end.UnixNano(),
+	)
+}
+
+// validateReplicationTarget checks that the domain is replicated to the target cluster of a replicate batch
+func validateReplicationTarget(domain *types.DescribeDomainResponse, params BatchParams) error {
+	if !domain.GetIsGlobalDomain() {
+		return fmt.Errorf("domain %s is not a global domain", params.DomainName)
+	}
+	for _, cluster := range domain.ReplicationConfiguration.GetClusters() {
+		if cluster.GetClusterName() == params.ReplicateParams.TargetCluster {
+			return nil
+		}
+	}
+	return fmt.Errorf("domain %s is not replicated to cluster %s", params.DomainName, params.ReplicateParams.TargetCluster)
+}
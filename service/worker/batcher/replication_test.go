@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package batcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/types"
+)
+
+func TestReplicationWindowQuery(t *testing.T) {
+	start := time.Unix(0, 1000)
+	end := time.Unix(0, 2000)
+	assert.Equal(t,
+		"(UpdateTime BETWEEN 1000 AND 2000) OR (CloseTime = missing AND StartTime <= 2000)",
+		ReplicationWindowQuery(start, end),
+	)
+}
+
+func TestValidateReplicationTarget(t *testing.T) {
+	params := BatchParams{
+		DomainName:      "test-domain",
+		ReplicateParams: ReplicateParams{SourceCluster: "cluster0", TargetCluster: "cluster1"},
+	}
+
+	tests := map[string]struct {
+		domain  *types.DescribeDomainResponse
+		wantErr string
+	}{
+		"local domain": {
+			domain:  &types.DescribeDomainResponse{},
+			wantErr: "domain test-domain is not a global domain",
+		},
+		"target cluster not in replication config": {
+			domain: &types.DescribeDomainResponse{
+				IsGlobalDomain: true,
+				ReplicationConfiguration: &types.DomainReplicationConfiguration{
+					Clusters: []*types.ClusterReplicationConfiguration{{ClusterName: "cluster0"}},
+				},
+			},
+			wantErr: "domain test-domain is not replicated to cluster cluster1",
+		},
+		"valid": {
+			domain: &types.DescribeDomainResponse{
+				IsGlobalDomain: true,
+				ReplicationConfiguration: &types.DomainReplicationConfiguration{
+					Clusters: []*types.ClusterReplicationConfiguration{{ClusterName: "cluster0"}, {ClusterName: "cluster1"}},
+				},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateReplicationTarget(tt.domain, params)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHeartBeatDetailsProgress(t *testing.T) {
+	tests := map[string]struct {
+		hbd     HeartBeatDetails
+		elapsed time.Duration
+		want    BatchProgress
+	}{
+		"no estimate": {
+			hbd:     HeartBeatDetails{SuccessCount: 3},
+			elapsed: time.Minute,
+			want:    BatchProgress{Processed: 3, Succeeded: 3, Elapsed: time.Minute},
+		},
+		"in progress": {
+			hbd:     HeartBeatDetails{TotalEstimate: 100, SuccessCount: 20, ErrorCount: 5},
+			elapsed: time.Minute,
+			want: BatchProgress{
+				Processed:       25,
+				Succeeded:       20,
+				Failed:          5,
+				TotalEstimate:   100,
+				PercentComplete: 25,
+				Elapsed:         time.Minute,
+				Remaining:       3 * time.Minute,
+			},
+		},
+		"more than estimated": {
+			hbd:     HeartBeatDetails{TotalEstimate: 10, SuccessCount: 12},
+			elapsed: time.Minute,
+			want: BatchProgress{
+				Processed:       12,
+				Succeeded:       12,
+				TotalEstimate:   10,
+				PercentComplete: 100,
+				Elapsed:         time.Minute,
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.hbd.Progress(tt.elapsed))
+		})
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package batcher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	// ResetTypeFirstDecisionCompleted resets to the first completed decision
+	ResetTypeFirstDecisionCompleted = "FirstDecisionCompleted"
+	// ResetTypeLastDecisionCompleted resets to the last completed decision, moved back by DecisionOffset
+	ResetTypeLastDecisionCompleted = "LastDecisionCompleted"
+	// ResetTypeFirstDecisionScheduled resets to the first scheduled decision
+	ResetTypeFirstDecisionScheduled = "FirstDecisionScheduled"
+	// ResetTypeLastDecisionScheduled resets to the last scheduled decision, moved back by DecisionOffset
+	ResetTypeLastDecisionScheduled = "LastDecisionScheduled"
+	// ResetTypeBadBinary resets to before the first decision completed by BadBinaryChecksum
+	ResetTypeBadBinary = "BadBinary"
+
+	resetHistoryPageSize = 1000
+)
+
+// resetRequestIDNamespace is the UUID namespace of the request IDs of batch resets
+var resetRequestIDNamespace = uuid.NewSHA1(uuid.NameSpaceDNS, []byte("cadence.batcher.reset"))
+
+// AllResetTypes is the reset types supported by batch reset
+var AllResetTypes = []string{
+	ResetTypeFirstDecisionCompleted,
+	ResetTypeLastDecisionCompleted,
+	ResetTypeFirstDecisionScheduled,
+	ResetTypeLastDecisionScheduled,
+	ResetTypeBadBinary,
+}
+
+func validateResetParams(params ResetParams) error {
+	switch params.ResetType {
+	case ResetTypeBadBinary:
+		if params.BadBinaryChecksum == "" {
+			return fmt.Errorf("must provide bad binary checksum")
+		}
+		return nil
+	case ResetTypeLastDecisionCompleted, ResetTypeLastDecisionScheduled:
+		if params.DecisionOffset > 0 {
+			return fmt.Errorf("decision offset must not be positive")
+		}
+		return nil
+	case ResetTypeFirstDecisionCompleted, ResetTypeFirstDecisionScheduled:
+		return nil
+	default:
+		return fmt.Errorf("not supported reset type: %v", params.ResetType)
+	}
+}
+
+// resetWorkflow resets a run of a workflow to the reset point of params
+func resetWorkflow(
+	ctx context.Context,
+	client frontend.Client,
+	params BatchParams,
+	workflowID string,
+	runID string,
+	requestID string,
+) error {
+	decisionFinishID, err := getResetEventID(ctx, client, params.DomainName, workflowID, runID, params.ResetParams)
+	if err != nil {
+		return err
+	}
+	_, err = client.ResetWorkflowExecution(ctx, &types.ResetWorkflowExecutionRequest{
+		Domain: params.DomainName,
+		WorkflowExecution: &types.WorkflowExecution{
+			WorkflowID: workflowID,
+			RunID:      runID,
+		},
+		Reason:                params.Reason,
+		DecisionFinishEventID: decisionFinishID,
+		RequestID:             requestID,
+		SkipSignalReapply:     params.ResetParams.SkipSignalReapply,
+	})
+	return err
+}
+
+// resetRequestID derives the request ID of resetting a run from the batch workflow and the run, so that resets
+// repeated by a retried batch activity are deduplicated instead of resetting the new run again
+func resetRequestID(batchWorkflowID, workflowID, runID string) string {
+	name := fmt.Sprintf("%d:%s/%d:%s/%s", len(batchWorkflowID), batchWorkflowID, len(workflowID), workflowID, runID)
+	return uuid.NewSHA1(resetRequestIDNamespace, []byte(name)).String()
+}
+
+// getResetEventID returns the DecisionFinishEventID to reset a run to, the same way as the CLI reset command does
+func getResetEventID(
+	ctx context.Context,
+	client frontend.Client,
+	domain string,
+	workflowID string,
+	runID string,
+	params ResetParams,
+) (int64, error) {
+	execution := &types.WorkflowExecution{
+		WorkflowID: workflowID,
+		RunID:      runID,
+	}
+	if params.ResetType == ResetTypeBadBinary {
+		resp, err := client.DescribeWorkflowExecution(ctx, &types.DescribeWorkflowExecutionRequest{
+			Domain:    domain,
+			Execution: execution,
+		})
+		if err != nil {
+			return 0, err
+		}
+		if info := resp.GetWorkflowExecutionInfo(); info != nil && info.AutoResetPoints != nil {
+			for _, point := range info.AutoResetPoints.Points {
+				if point.GetBinaryChecksum() == params.BadBinaryChecksum {
+					return point.GetFirstDecisionCompletedID(), nil
+				}
+			}
+		}
+		return 0, &types.BadRequestError{Message: fmt.Sprintf("no decision completed by binary %s", params.BadBinaryChecksum)}
+	}
+
+	eventType := types.EventTypeDecisionTaskCompleted
+	if params.ResetType == ResetTypeFirstDecisionScheduled || params.ResetType == ResetTypeLastDecisionScheduled {
+		eventType = types.EventTypeDecisionTaskScheduled
+	}
+	first := params.ResetType == ResetTypeFirstDecisionCompleted || params.ResetType == ResetTypeFirstDecisionScheduled
+	// remembers the last -DecisionOffset+1 decision event IDs, the oldest of them is the reset point
+	eventIDs := make([]int64, 0, 1-params.DecisionOffset)
+
+	request := &types.GetWorkflowExecutionHistoryRequest{
+		Domain:          domain,
+		Execution:       execution,
+		MaximumPageSize: resetHistoryPageSize,
+	}
+	for {
+		resp, err := client.GetWorkflowExecutionHistory(ctx, request)
+		if err != nil {
+			return 0, err
+		}
+		for _, event := range resp.GetHistory().GetEvents() {
+			if event.GetEventType() != eventType {
+				continue
+			}
+			eventIDs = append(eventIDs, event.ID)
+			if first {
+				break
+			}
+			if len(eventIDs) > 1-params.DecisionOffset {
+				eventIDs = eventIDs[1:]
+			}
+		}
+		if (first && len(eventIDs) > 0) || len(resp.NextPageToken) == 0 {
+			break
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+	if len(eventIDs) == 0 {
+		return 0, &types.BadRequestError{Message: fmt.Sprintf("no %v event to reset to", eventType)}
+	}
+	if eventType == types.EventTypeDecisionTaskScheduled {
+		// DecisionFinishEventID is exclusive in reset API
+		return eventIDs[0] + 1, nil
+	}
+	return eventIDs[0], nil
+}
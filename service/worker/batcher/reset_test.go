@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package batcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/yarpc"
+
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestResetRequestID(t *testing.T) {
+	requestID := resetRequestID("batch-wid", "wid", "rid")
+	assert.Equal(t, requestID, resetRequestID("batch-wid", "wid", "rid"), "retried resets must use the same request ID")
+	assert.NotEqual(t, requestID, resetRequestID("other-batch-wid", "wid", "rid"))
+	assert.NotEqual(t, requestID, resetRequestID("batch-wid", "wid", "other-rid"))
+	assert.NotEqual(t, resetRequestID("a", "b/c", "d"), resetRequestID("a/b", "c", "d"))
+}
+
+func TestValidateResetParams(t *testing.T) {
+	tests := map[string]struct {
+		params  ResetParams
+		wantErr string
+	}{
+		"first decision completed": {
+			params: ResetParams{ResetType: ResetTypeFirstDecisionCompleted},
+		},
+		"last decision scheduled with offset": {
+			params: ResetParams{ResetType: ResetTypeLastDecisionScheduled, DecisionOffset: -2},
+		},
+		"positive offset": {
+			params:  ResetParams{ResetType: ResetTypeLastDecisionCompleted, DecisionOffset: 1},
+			wantErr: "decision offset must not be positive",
+		},
+		"bad binary without checksum": {
+			params:  ResetParams{ResetType: ResetTypeBadBinary},
+			wantErr: "must provide bad binary checksum",
+		},
+		"unknown reset type": {
+			params:  ResetParams{ResetType: "LastContinuedAsNew"},
+			wantErr: "not supported reset type: LastContinuedAsNew",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateResetParams(tc.params)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetResetEventID(t *testing.T) {
+	decisionEvents := func(ids ...int64) *types.History {
+		history := &types.History{}
+		for _, id := range ids {
+			history.Events = append(history.Events,
+				&types.HistoryEvent{ID: id, EventType: types.EventTypeDecisionTaskScheduled.Ptr()},
+				&types.HistoryEvent{ID: id + 2, EventType: types.EventTypeDecisionTaskCompleted.Ptr()},
+			)
+		}
+		return history
+	}
+	// two pages of history with decisions scheduled at 2, 10 and 20
+	expectHistory := func(client *frontend.MockClient) {
+		client.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, request *types.GetWorkflowExecutionHistoryRequest, _ ...yarpc.CallOption) (*types.GetWorkflowExecutionHistoryResponse, error) {
+				if request.NextPageToken == nil {
+					return &types.GetWorkflowExecutionHistoryResponse{History: decisionEvents(2, 10), NextPageToken: []byte("next")}, nil
+				}
+				return &types.GetWorkflowExecutionHistoryResponse{History: decisionEvents(20)}, nil
+			}).AnyTimes()
+	}
+
+	tests := map[string]struct {
+		params  ResetParams
+		setup   func(client *frontend.MockClient)
+		want    int64
+		wantErr string
+	}{
+		"first decision completed": {
+			params: ResetParams{ResetType: ResetTypeFirstDecisionCompleted},
+			setup:  expectHistory,
+			want:   4,
+		},
+		"first decision scheduled": {
+			params: ResetParams{ResetType: ResetTypeFirstDecisionScheduled},
+			setup:  expectHistory,
+			want:   3,
+		},
+		"last decision completed": {
+			params: ResetParams{ResetType: ResetTypeLastDecisionCompleted},
+			setup:  expectHistory,
+			want:   22,
+		},
+		"last decision completed with offset across pages": {
+			params: ResetParams{ResetType: ResetTypeLastDecisionCompleted, DecisionOffset: -1},
+			setup:  expectHistory,
+			want:   12,
+		},
+		"last decision scheduled with offset": {
+			params: ResetParams{ResetType: ResetTypeLastDecisionScheduled, DecisionOffset: -2},
+			setup:  expectHistory,
+			want:   3,
+		},
+		"no decision": {
+			params: ResetParams{ResetType: ResetTypeLastDecisionCompleted},
+			setup: func(client *frontend.MockClient) {
+				client.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(&types.GetWorkflowExecutionHistoryResponse{History: &types.History{}}, nil)
+			},
+			wantErr: "no DecisionTaskCompleted event to reset to",
+		},
+		"bad binary": {
+			params: ResetParams{ResetType: ResetTypeBadBinary, BadBinaryChecksum: "bad"},
+			setup: func(client *frontend.MockClient) {
+				client.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&types.DescribeWorkflowExecutionResponse{
+					WorkflowExecutionInfo: &types.WorkflowExecutionInfo{
+						AutoResetPoints: &types.ResetPoints{Points: []*types.ResetPointInfo{
+							{BinaryChecksum: "good", FirstDecisionCompletedID: 4},
+							{BinaryChecksum: "bad", FirstDecisionCompletedID: 12},
+						}},
+					},
+				}, nil)
+			},
+			want: 12,
+		},
+		"bad binary not found": {
+			params: ResetParams{ResetType: ResetTypeBadBinary, BadBinaryChecksum: "bad"},
+			setup: func(client *frontend.MockClient) {
+				client.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&types.DescribeWorkflowExecutionResponse{
+					WorkflowExecutionInfo: &types.WorkflowExecutionInfo{},
+				}, nil)
+			},
+			wantErr: "no decision completed by binary bad",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := frontend.NewMockClient(gomock.NewController(t))
+			tc.setup(client)
+			got, err := getResetEventID(context.Background(), client, "test-domain", "wid", "rid", tc.params)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
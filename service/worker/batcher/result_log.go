@@ -0,0 +1,219 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package batcher
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	// OutcomeSucceeded is the outcome of a workflow processed successfully
+	OutcomeSucceeded = "succeeded"
+	// OutcomeFailed is the outcome of a workflow given up on due to errors
+	OutcomeFailed = "failed"
+	// OutcomeDryRun is the outcome of a workflow matched by a dry run
+	OutcomeDryRun = "dry_run"
+
+	// BatchReportWFTypeName is the workflow type of reading the result log of a batch job
+	BatchReportWFTypeName   = "cadence-sys-batch-report-workflow"
+	batchReportActivityName = "cadence-sys-batch-report-activity"
+
+	// DefaultReportMaxResults is the default value for BatchReportParams.MaxResults
+	DefaultReportMaxResults = 1000
+
+	resultLogTimeout = 10 * time.Second
+)
+
+var batchReportActivityOptions = workflow.ActivityOptions{
+	ScheduleToStartTimeout: time.Minute,
+	StartToCloseTimeout:    time.Minute,
+	RetryPolicy: &cadence.RetryPolicy{
+		InitialInterval:          time.Second,
+		BackoffCoefficient:       2,
+		MaximumInterval:          10 * time.Second,
+		ExpirationInterval:       5 * time.Minute,
+		NonRetriableErrorReasons: []string{_nonRetriableReason},
+	},
+}
+
+type (
+	// WorkflowResult is the outcome of a batch operation on a workflow, recorded in the result log of the batch job
+	WorkflowResult struct {
+		WorkflowID string `json:"workflowID"`
+		RunID      string `json:"runID"`
+		Outcome    string `json:"outcome"`
+		Error      string `json:"error,omitempty"`
+	}
+
+	// BatchReportParams is the parameters for reading the result log of a batch job
+	BatchReportParams struct {
+		// JobID is the workflow ID of the batch job
+		JobID string
+		// FromPage is the result log page to start reading from
+		FromPage int
+		// MaxResults stops reading at the end of the page this many results are reached. Default to DefaultReportMaxResults
+		MaxResults int
+		// FailuresOnly only returns the results of failed workflows
+		FailuresOnly bool
+	}
+
+	// BatchReport is a part of the result log of a batch job
+	BatchReport struct {
+		Results []WorkflowResult
+		// NextPage is the FromPage to read the rest of the result log from, if HasMore is true
+		NextPage int
+		HasMore  bool
+	}
+)
+
+func init() {
+	workflow.RegisterWithOptions(BatchReportWorkflow, workflow.RegisterOptions{Name: BatchReportWFTypeName})
+	activity.RegisterWithOptions(batchReportActivity, activity.RegisterOptions{Name: batchReportActivityName})
+}
+
+func newWorkflowResult(execution types.WorkflowExecution, outcome string, err error) WorkflowResult {
+	result := WorkflowResult{
+		WorkflowID: execution.GetWorkflowID(),
+		RunID:      execution.GetRunID(),
+		Outcome:    outcome,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// BatchReportWorkflow is the workflow that reads the result log of a batch job
+func BatchReportWorkflow(ctx workflow.Context, params BatchReportParams) (BatchReport, error) {
+	if params.JobID == "" {
+		return BatchReport{}, fmt.Errorf("must provide job ID")
+	}
+	if params.MaxResults <= 0 {
+		params.MaxResults = DefaultReportMaxResults
+	}
+	var report BatchReport
+	err := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, batchReportActivityOptions), batchReportActivityName, params).Get(ctx, &report)
+	return report, err
+}
+
+func batchReportActivity(ctx context.Context, params BatchReportParams) (BatchReport, error) {
+	batcher := ctx.Value(BatcherContextKey).(*Batcher)
+	if batcher.blobstoreClient == nil {
+		return BatchReport{}, cadence.NewCustomError(_nonRetriableReason, "result logs of batch jobs are not enabled, blobstore is not configured")
+	}
+
+	report := BatchReport{Results: []WorkflowResult{}}
+	for page := params.FromPage; ; page++ {
+		results, ok, err := readResultLog(ctx, batcher.blobstoreClient, params.JobID, page)
+		if err != nil {
+			return BatchReport{}, err
+		}
+		if !ok {
+			return report, nil
+		}
+		for _, result := range results {
+			if !params.FailuresOnly || result.Outcome == OutcomeFailed {
+				report.Results = append(report.Results, result)
+			}
+		}
+		if len(report.Results) >= params.MaxResults {
+			report.NextPage = page + 1
+			report.HasMore = true
+			return report, nil
+		}
+	}
+}
+
+// writeResultLog writes the results of a page of the running batch job to its result log.
+// A failure to write is logged rather than failing the batch job, as retrying would process the page again.
+func writeResultLog(ctx context.Context, page int, results []WorkflowResult) {
+	batcher := ctx.Value(BatcherContextKey).(*Batcher)
+	if batcher.blobstoreClient == nil {
+		return
+	}
+
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	for _, result := range results {
+		if err := encoder.Encode(result); err != nil {
+			getActivityLogger(ctx).Error("Failed to encode batch operation result", tag.Error(err))
+			return
+		}
+	}
+	putCtx, cancel := context.WithTimeout(ctx, resultLogTimeout)
+	defer cancel()
+	_, err := batcher.blobstoreClient.Put(putCtx, &blobstore.PutRequest{
+		Key:  resultLogKey(activity.GetInfo(ctx).WorkflowExecution.ID, page),
+		Blob: blobstore.Blob{Body: buffer.Bytes()},
+	})
+	if err != nil {
+		batcher.metricsClient.IncCounter(metrics.BatcherScope, metrics.BatcherProcessorFailures)
+		getActivityLogger(ctx).Error("Failed to write batch operation result log", tag.Error(err), tag.Counter(page))
+	}
+}
+
+// readResultLog returns the results of a page of a batch job's result log, false if the page doesn't exist
+func readResultLog(ctx context.Context, client blobstore.Client, jobID string, page int) ([]WorkflowResult, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, resultLogTimeout)
+	defer cancel()
+	key := resultLogKey(jobID, page)
+	exists, err := client.Exists(ctx, &blobstore.ExistsRequest{Key: key})
+	if err != nil {
+		return nil, false, err
+	}
+	if !exists.Exists {
+		return nil, false, nil
+	}
+	resp, err := client.Get(ctx, &blobstore.GetRequest{Key: key})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var results []WorkflowResult
+	scanner := bufio.NewScanner(bytes.NewReader(resp.Blob.Body))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), len(resp.Blob.Body)+1)
+	for scanner.Scan() {
+		var result WorkflowResult
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			return nil, false, err
+		}
+		results = append(results, result)
+	}
+	return results, true, scanner.Err()
+}
+
+func resultLogKey(jobID string, page int) string {
+	return fmt.Sprintf("batch_%v_%v.results", jobID, page)
+}
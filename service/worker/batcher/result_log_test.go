@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package batcher
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/worker"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/yarpc"
+
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/metrics"
+	mmocks "github.com/uber/cadence/common/metrics/mocks"
+	"github.com/uber/cadence/common/resource"
+	"github.com/uber/cadence/common/types"
+)
+
+func setupResultLogTest(t *testing.T) (*testsuite.TestActivityEnvironment, *Batcher, *resource.Test) {
+	var env testsuite.WorkflowTestSuite
+	activityEnv := env.NewTestActivityEnvironment()
+	activityEnv.RegisterActivity(BatchActivity)
+	activityEnv.RegisterActivity(batchReportActivity)
+
+	batcher, mockResource := setuptest(t)
+	batcher.blobstoreClient = mockResource.BlobstoreClient
+	ctx := context.WithValue(context.Background(), BatcherContextKey, batcher)
+	activityEnv.SetWorkerOptions(worker.Options{
+		MetricsScope:              tally.TestScope(nil),
+		BackgroundActivityContext: ctx,
+		Tracer:                    opentracing.GlobalTracer(),
+	})
+	return activityEnv, batcher, mockResource
+}
+
+func expectScan(mockResource *resource.Test, workflowIDs ...string) {
+	executions := make([]*types.WorkflowExecutionInfo, 0, len(workflowIDs))
+	for _, workflowID := range workflowIDs {
+		executions = append(executions, &types.WorkflowExecutionInfo{Execution: &types.WorkflowExecution{WorkflowID: workflowID, RunID: "rid"}})
+	}
+	mockResource.FrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).Return(&types.DescribeDomainResponse{}, nil)
+	mockResource.FrontendClient.EXPECT().CountWorkflowExecutions(gomock.Any(), gomock.Any()).
+		Return(&types.CountWorkflowExecutionsResponse{Count: int64(len(workflowIDs))}, nil)
+	mockResource.FrontendClient.EXPECT().ScanWorkflowExecutions(gomock.Any(), gomock.Any()).
+		Return(&types.ListWorkflowExecutionsResponse{Executions: executions}, nil)
+}
+
+func TestBatchActivity_ResultLog(t *testing.T) {
+	activityEnv, batcher, mockResource := setupResultLogTest(t)
+	metricsMock := &mmocks.Client{}
+	metricsMock.On("IncCounter", metrics.BatcherScope, metrics.BatcherProcessorSuccess).Once()
+	metricsMock.On("IncCounter", metrics.BatcherScope, metrics.BatcherProcessorFailures).Once()
+	batcher.metricsClient = metricsMock
+
+	expectScan(mockResource, "wid1", "wid2")
+	mockResource.FrontendClient.EXPECT().RequestCancelWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *types.RequestCancelWorkflowExecutionRequest, _ ...yarpc.CallOption) error {
+			if request.WorkflowExecution.WorkflowID == "wid2" {
+				return errors.New("HeartbeatTimeoutError")
+			}
+			return nil
+		}).Times(2)
+	mockResource.FrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).
+		Return(&types.DescribeWorkflowExecutionResponse{}, nil)
+
+	var body string
+	mockResource.BlobstoreClient.EXPECT().Put(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *blobstore.PutRequest) (*blobstore.PutResponse, error) {
+			assert.True(t, strings.HasPrefix(request.Key, "batch_"))
+			assert.True(t, strings.HasSuffix(request.Key, "_0.results"))
+			body = string(request.Blob.Body)
+			return &blobstore.PutResponse{}, nil
+		})
+
+	val, err := activityEnv.ExecuteActivity(BatchActivity, createParams(BatchTypeCancel))
+	require.NoError(t, err)
+	var result HeartBeatDetails
+	require.NoError(t, val.Get(&result))
+	assert.Equal(t, 1, result.SuccessCount)
+	assert.Equal(t, 1, result.ErrorCount)
+	assert.Contains(t, body, `{"workflowID":"wid1","runID":"rid","outcome":"succeeded"}`)
+	assert.Contains(t, body, `{"workflowID":"wid2","runID":"rid","outcome":"failed","error":"HeartbeatTimeoutError"}`)
+}
+
+func TestBatchActivity_DryRun(t *testing.T) {
+	activityEnv, _, mockResource := setupResultLogTest(t)
+
+	expectScan(mockResource, "wid1", "wid2")
+	var body string
+	mockResource.BlobstoreClient.EXPECT().Put(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *blobstore.PutRequest) (*blobstore.PutResponse, error) {
+			body = string(request.Blob.Body)
+			return &blobstore.PutResponse{}, nil
+		})
+
+	params := createParams(BatchTypeTerminate)
+	params.DryRun = true
+	val, err := activityEnv.ExecuteActivity(BatchActivity, params)
+	require.NoError(t, err)
+	var result HeartBeatDetails
+	require.NoError(t, val.Get(&result))
+	assert.Equal(t, 2, result.SuccessCount)
+	assert.Equal(t, 1, result.CurrentPage)
+	assert.Equal(t,
+		`{"workflowID":"wid1","runID":"rid","outcome":"dry_run"}`+"\n"+`{"workflowID":"wid2","runID":"rid","outcome":"dry_run"}`+"\n",
+		body,
+	)
+}
+
+func TestBatchReportActivity(t *testing.T) {
+	pages := map[string]string{
+		"batch_job_0.results": `{"workflowID":"wid1","runID":"rid","outcome":"succeeded"}` + "\n" +
+			`{"workflowID":"wid2","runID":"rid","outcome":"failed","error":"error"}` + "\n",
+		"batch_job_1.results": `{"workflowID":"wid3","runID":"rid","outcome":"failed","error":"error"}` + "\n",
+		"batch_job_2.results": `{"workflowID":"wid4","runID":"rid","outcome":"succeeded"}` + "\n",
+	}
+	tests := map[string]struct {
+		params BatchReportParams
+		want   BatchReport
+	}{
+		"all results": {
+			params: BatchReportParams{JobID: "job", MaxResults: 10},
+			want: BatchReport{Results: []WorkflowResult{
+				{WorkflowID: "wid1", RunID: "rid", Outcome: OutcomeSucceeded},
+				{WorkflowID: "wid2", RunID: "rid", Outcome: OutcomeFailed, Error: "error"},
+				{WorkflowID: "wid3", RunID: "rid", Outcome: OutcomeFailed, Error: "error"},
+				{WorkflowID: "wid4", RunID: "rid", Outcome: OutcomeSucceeded},
+			}},
+		},
+		"failures only with more pages": {
+			params: BatchReportParams{JobID: "job", MaxResults: 2, FailuresOnly: true},
+			want: BatchReport{
+				Results: []WorkflowResult{
+					{WorkflowID: "wid2", RunID: "rid", Outcome: OutcomeFailed, Error: "error"},
+					{WorkflowID: "wid3", RunID: "rid", Outcome: OutcomeFailed, Error: "error"},
+				},
+				NextPage: 2,
+				HasMore:  true,
+			},
+		},
+		"from page": {
+			params: BatchReportParams{JobID: "job", FromPage: 2, MaxResults: 10},
+			want: BatchReport{Results: []WorkflowResult{
+				{WorkflowID: "wid4", RunID: "rid", Outcome: OutcomeSucceeded},
+			}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			activityEnv, _, mockResource := setupResultLogTest(t)
+			mockResource.BlobstoreClient.EXPECT().Exists(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, request *blobstore.ExistsRequest) (*blobstore.ExistsResponse, error) {
+					_, ok := pages[request.Key]
+					return &blobstore.ExistsResponse{Exists: ok}, nil
+				}).AnyTimes()
+			mockResource.BlobstoreClient.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, request *blobstore.GetRequest) (*blobstore.GetResponse, error) {
+					return &blobstore.GetResponse{Blob: blobstore.Blob{Body: []byte(pages[request.Key])}}, nil
+				}).AnyTimes()
+
+			val, err := activityEnv.ExecuteActivity(batchReportActivity, tc.params)
+			require.NoError(t, err)
+			var report BatchReport
+			require.NoError(t, val.Get(&report))
+			assert.Equal(t, tc.want, report)
+		})
+	}
+}
+
+func TestBatchReportActivity_BlobstoreNotConfigured(t *testing.T) {
+	activityEnv, batcher, _ := setupResultLogTest(t)
+	batcher.blobstoreClient = nil
+
+	_, err := activityEnv.ExecuteActivity(batchReportActivity, BatchReportParams{JobID: "job"})
+	assert.ErrorContains(t, err, _nonRetriableReason)
+}
+
+func TestBatchReportWorkflow(t *testing.T) {
+	var env testsuite.WorkflowTestSuite
+	workflowEnv := env.NewTestWorkflowEnvironment()
+	workflowEnv.RegisterWorkflow(BatchReportWorkflow)
+	workflowEnv.OnActivity(batchReportActivityName, mock.Anything, BatchReportParams{JobID: "job", MaxResults: DefaultReportMaxResults}).
+		Return(BatchReport{Results: []WorkflowResult{{WorkflowID: "wid", Outcome: OutcomeSucceeded}}}, nil)
+
+	workflowEnv.ExecuteWorkflow(BatchReportWorkflow, BatchReportParams{JobID: "job"})
+	require.True(t, workflowEnv.IsWorkflowCompleted())
+	require.NoError(t, workflowEnv.GetWorkflowError())
+	var report BatchReport
+	require.NoError(t, workflowEnv.GetWorkflowResult(&report))
+	assert.Equal(t, "wid", report.Results[0].WorkflowID)
+}
+
+func TestBatchReportWorkflow_MissingJobID(t *testing.T) {
+	var env testsuite.WorkflowTestSuite
+	workflowEnv := env.NewTestWorkflowEnvironment()
+	workflowEnv.RegisterWorkflow(BatchReportWorkflow)
+
+	workflowEnv.ExecuteWorkflow(BatchReportWorkflow, BatchReportParams{})
+	require.True(t, workflowEnv.IsWorkflowCompleted())
+	assert.ErrorContains(t, workflowEnv.GetWorkflowError(), "must provide job ID")
+}
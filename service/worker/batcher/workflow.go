@@ -79,10 +79,14 @@ const (
 	BatchTypeSignal = "signal"
 	// BatchTypeReplicate is batch type for replicating workflows
 	BatchTypeReplicate = "replicate"
+	// BatchTypeReset is batch type for resetting workflows
+	BatchTypeReset = "reset"
+	// BatchTypeDelete is batch type for deleting workflows
+	BatchTypeDelete = "delete"
 )
 
 // AllBatchTypes is the batch types we supported
-var AllBatchTypes = []string{BatchTypeTerminate, BatchTypeCancel, BatchTypeSignal, BatchTypeReplicate}
+var AllBatchTypes = []string{BatchTypeTerminate, BatchTypeCancel, BatchTypeSignal, BatchTypeReplicate, BatchTypeReset, BatchTypeDelete}
 
 var (
 	BatchActivityRetryPolicy = cadence.RetryPolicy{
@@ -124,17 +128,9 @@ func BatchWorkflow(ctx workflow.Context, batchParams BatchParams) (HeartBeatDeta
 func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetails, error) {
 	batcher := ctx.Value(BatcherContextKey).(*Batcher)
 	client := batcher.clientBean.GetFrontendClient()
-	var adminClient admin.Client
-	if batchParams.BatchType == BatchTypeReplicate {
-		currentCluster := batcher.cfg.ClusterMetadata.GetCurrentClusterName()
-		if currentCluster != batchParams.ReplicateParams.SourceCluster {
-			return HeartBeatDetails{}, cadence.NewCustomError(_nonRetriableReason, fmt.Sprintf("the activity must run in the source cluster, current cluster is %s", currentCluster))
-		}
-		var err error
-		adminClient, err = batcher.clientBean.GetRemoteAdminClient(batchParams.ReplicateParams.TargetCluster)
-		if err != nil {
-			return HeartBeatDetails{}, cadence.NewCustomError(_nonRetriableReason, err.Error())
-		}
+	adminClient, err := getAdminClient(batcher, batchParams)
+	if err != nil {
+		return HeartBeatDetails{}, err
 	}
 
 	domainResp, err := client.DescribeDomain(ctx, &types.DescribeDomainRequest{
@@ -143,6 +139,11 @@ func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetai
 	if err != nil {
 		return HeartBeatDetails{}, err
 	}
+	if batchParams.BatchType == BatchTypeReplicate {
+		if err := validateReplicationTarget(domainResp, batchParams); err != nil {
+			return HeartBeatDetails{}, cadence.NewCustomError(_nonRetriableReason, err.Error())
+		}
+	}
 	domainID := domainResp.GetDomainInfo().GetUUID()
 	hbd, ok := getHeartBeatDetails(ctx)
 
@@ -158,7 +159,7 @@ func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetai
 	}
 	rateLimiter := rate.NewLimiter(rate.Limit(batchParams.RPS), batchParams.RPS)
 	taskCh := make(chan taskDetail, batchParams.PageSize)
-	respCh := make(chan error, batchParams.PageSize)
+	respCh := make(chan WorkflowResult, batchParams.PageSize)
 	for i := 0; i < batchParams.Concurrency; i++ {
 		go startTaskProcessor(ctx, batchParams, domainID, taskCh, respCh, rateLimiter, client, adminClient, BatchWFTypeName)
 	}
@@ -176,44 +177,19 @@ func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetai
 		if err != nil {
 			return HeartBeatDetails{}, err
 		}
-		batchCount := len(resp.Executions)
-		if batchCount <= 0 {
+		if len(resp.Executions) == 0 {
 			break
 		}
 
-		// send all tasks
-		for _, wf := range resp.Executions {
-			taskCh <- taskDetail{
-				execution: *wf.Execution,
-				attempts:  0,
-				hbd:       hbd,
-			}
-		}
-
-		succCount := 0
-		errCount := 0
-		// wait for counters indicate this batch is done
-	Loop:
-		for {
-			select {
-			case err := <-respCh:
-				if err == nil {
-					succCount++
-				} else {
-					errCount++
-				}
-				if succCount+errCount == batchCount {
-					break Loop
-				}
-			case <-ctx.Done():
-				return HeartBeatDetails{}, ctx.Err()
-			}
+		results, err := processPage(ctx, batchParams, resp.Executions, hbd, taskCh, respCh)
+		if err != nil {
+			return HeartBeatDetails{}, err
 		}
+		writeResultLog(ctx, hbd.CurrentPage, results)
 
 		hbd.CurrentPage++
 		hbd.PageToken = resp.NextPageToken
-		hbd.SuccessCount += succCount
-		hbd.ErrorCount += errCount
+		hbd.addResults(results)
 		activity.RecordHeartbeat(ctx, hbd)
 
 		if len(hbd.PageToken) == 0 {
@@ -224,6 +200,68 @@ func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetai
 	return hbd, nil
 }
 
+// getAdminClient returns the admin client of the cluster a batch type operates on, nil if the batch type doesn't need one
+func getAdminClient(batcher *Batcher, params BatchParams) (admin.Client, error) {
+	switch params.BatchType {
+	case BatchTypeReplicate:
+		currentCluster := batcher.cfg.ClusterMetadata.GetCurrentClusterName()
+		if currentCluster != params.ReplicateParams.SourceCluster {
+			return nil, cadence.NewCustomError(_nonRetriableReason, fmt.Sprintf("the activity must run in the source cluster, current cluster is %s", currentCluster))
+		}
+		adminClient, err := batcher.clientBean.GetRemoteAdminClient(params.ReplicateParams.TargetCluster)
+		if err != nil {
+			return nil, cadence.NewCustomError(_nonRetriableReason, err.Error())
+		}
+		return adminClient, nil
+	case BatchTypeDelete:
+		adminClient, err := batcher.clientBean.GetRemoteAdminClient(batcher.cfg.ClusterMetadata.GetCurrentClusterName())
+		if err != nil {
+			return nil, cadence.NewCustomError(_nonRetriableReason, err.Error())
+		}
+		return adminClient, nil
+	default:
+		return nil, nil
+	}
+}
+
+// processPage sends the executions of a scanned page to the task processors and waits for their results.
+// In a dry run the executions are only recorded as matched.
+func processPage(
+	ctx context.Context,
+	batchParams BatchParams,
+	executions []*types.WorkflowExecutionInfo,
+	hbd HeartBeatDetails,
+	taskCh chan taskDetail,
+	respCh chan WorkflowResult,
+) ([]WorkflowResult, error) {
+	results := make([]WorkflowResult, 0, len(executions))
+	if batchParams.DryRun {
+		for _, wf := range executions {
+			results = append(results, newWorkflowResult(*wf.Execution, OutcomeDryRun, nil))
+		}
+		return results, nil
+	}
+
+	// send all tasks
+	for _, wf := range executions {
+		taskCh <- taskDetail{
+			execution: *wf.Execution,
+			attempts:  0,
+			hbd:       hbd,
+		}
+	}
+	// wait for all results of this page
+	for len(results) < len(executions) {
+		select {
+		case result := <-respCh:
+			results = append(results, result)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return results, nil
+}
+
 func getHeartBeatDetails(ctx context.Context) (hbd HeartBeatDetails, ok bool) {
 	if activity.HasHeartbeatDetails(ctx) {
 		if err := activity.GetHeartbeatDetails(ctx, &hbd); err != nil {
@@ -243,7 +281,7 @@ func startTaskProcessor(
 	batchParams BatchParams,
 	domainID string,
 	taskCh chan taskDetail,
-	respCh chan error,
+	respCh chan WorkflowResult,
 	limiter *rate.Limiter,
 	client frontend.Client,
 	adminClient admin.Client,
@@ -315,6 +353,25 @@ func startTaskProcessor(
 							RemoteCluster: batchParams.ReplicateParams.SourceCluster,
 						})
 					})
+			case BatchTypeReset:
+				err = processTask(ctx, limiter, task, batchParams, client, common.BoolPtr(false),
+					func(workflowID, runID string) error {
+						return resetWorkflow(ctx, client, batchParams, workflowID, runID,
+							resetRequestID(activity.GetInfo(ctx).WorkflowExecution.ID, workflowID, runID))
+					})
+			case BatchTypeDelete:
+				err = processTask(ctx, limiter, task, batchParams, client, common.BoolPtr(false),
+					func(workflowID, runID string) error {
+						_, err := adminClient.DeleteWorkflow(ctx, &types.AdminDeleteWorkflowRequest{
+							Domain: batchParams.DomainName,
+							Execution: &types.WorkflowExecution{
+								WorkflowID: workflowID,
+								RunID:      runID,
+							},
+							SkipErrors: batchParams.DeleteParams.SkipErrors,
+						})
+						return err
+					})
 			}
 			if err != nil {
 				batcher.metricsClient.IncCounter(metrics.BatcherScope, metrics.BatcherProcessorFailures)
@@ -322,7 +379,7 @@ func startTaskProcessor(
 
 				_, ok := batchParams._nonRetryableErrors[err.Error()]
 				if ok || task.attempts >= batchParams.AttemptsOnRetryableError {
-					respCh <- err
+					respCh <- newWorkflowResult(task.execution, OutcomeFailed, err)
 				} else {
 					// put back to the channel if less than attemptsOnError
 					task.attempts++
@@ -330,7 +387,7 @@ func startTaskProcessor(
 				}
 			} else {
 				batcher.metricsClient.IncCounter(metrics.BatcherScope, metrics.BatcherProcessorSuccess)
-				respCh <- nil
+				respCh <- newWorkflowResult(task.execution, OutcomeSucceeded, nil)
 			}
 		}
 	}
@@ -47,8 +47,12 @@ func validateParams(params BatchParams) error {
 			return fmt.Errorf("must provide target cluster")
 		}
 		return nil
+	case BatchTypeReset:
+		return validateResetParams(params.ResetParams)
 	case BatchTypeCancel:
 		fallthrough
+	case BatchTypeDelete:
+		fallthrough
 	case BatchTypeTerminate:
 		return nil
 	default:
@@ -64,7 +64,15 @@ func (s *workflowSuite) SetupTest() {
 	metricsMock.On("IncCounter", metrics.BatcherScope, metrics.BatcherProcessorSuccess).Once()
 	batcher.metricsClient = metricsMock
 
-	mockResource.FrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).Return(&types.DescribeDomainResponse{}, nil).AnyTimes()
+	mockResource.FrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).Return(&types.DescribeDomainResponse{
+		IsGlobalDomain: true,
+		ReplicationConfiguration: &types.DomainReplicationConfiguration{
+			Clusters: []*types.ClusterReplicationConfiguration{
+				{ClusterName: "test-primary-cluster"},
+				{ClusterName: "test-secondary-cluster"},
+			},
+		},
+	}, nil).AnyTimes()
 	mockResource.FrontendClient.EXPECT().ScanWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&types.ListWorkflowExecutionsResponse{
 		Executions:    []*types.WorkflowExecutionInfo{{Execution: &types.WorkflowExecution{WorkflowID: "wid", RunID: "rid"}}},
 		NextPageToken: nil,
@@ -75,7 +83,13 @@ func (s *workflowSuite) SetupTest() {
 	mockResource.FrontendClient.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	mockResource.FrontendClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
+	mockResource.FrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(&types.GetWorkflowExecutionHistoryResponse{
+		History: &types.History{Events: []*types.HistoryEvent{{ID: 4, EventType: types.EventTypeDecisionTaskCompleted.Ptr()}}},
+	}, nil).AnyTimes()
+	mockResource.FrontendClient.EXPECT().ResetWorkflowExecution(gomock.Any(), gomock.Any()).Return(&types.ResetWorkflowExecutionResponse{}, nil).AnyTimes()
+
 	mockResource.RemoteAdminClient.EXPECT().ResendReplicationTasks(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockResource.RemoteAdminClient.EXPECT().DeleteWorkflow(gomock.Any(), gomock.Any()).Return(&types.AdminDeleteWorkflowResponse{}, nil).AnyTimes()
 
 	ctx := context.WithValue(context.Background(), BatcherContextKey, batcher)
 	workerOpts := worker.Options{
@@ -120,6 +134,18 @@ func (s *workflowSuite) TestActivity_BatchReplicate() {
 	s.NoError(err)
 }
 
+func (s *workflowSuite) TestActivity_BatchReset() {
+	params := createParams(BatchTypeReset)
+	_, err := s.activityEnv.ExecuteActivity(BatchActivity, params)
+	s.NoError(err)
+}
+
+func (s *workflowSuite) TestActivity_BatchDelete() {
+	params := createParams(BatchTypeDelete)
+	_, err := s.activityEnv.ExecuteActivity(BatchActivity, params)
+	s.NoError(err)
+}
+
 func (s *workflowSuite) TestWorkflow_BatchTypeCancelValidationError() {
 	params := createParams(BatchTypeCancel)
 	params.Query = ""
@@ -159,6 +185,14 @@ func (s *workflowSuite) TestWorkflow_BatchTypeReplicateTargetCLusterValidation()
 	s.ErrorContains(s.workflowEnv.GetWorkflowError(), "must provide target cluster")
 }
 
+func (s *workflowSuite) TestWorkflow_BatchTypeResetValidation() {
+	params := createParams(BatchTypeReset)
+	params.ResetParams.ResetType = ResetTypeBadBinary
+	s.workflowEnv.ExecuteWorkflow(BatchWorkflow, params)
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	s.ErrorContains(s.workflowEnv.GetWorkflowError(), "must provide bad binary checksum")
+}
+
 func (s *workflowSuite) TearDownTest() {
 	s.workflowEnv.AssertExpectations(s.T())
 }
@@ -182,6 +216,9 @@ func createParams(batchType string) BatchParams {
 			SourceCluster: "test-primary-cluster",
 			TargetCluster: "test-secondary-cluster",
 		},
+		ResetParams: ResetParams{
+			ResetType: ResetTypeLastDecisionCompleted,
+		},
 		RPS:                      5,
 		Concurrency:              5,
 		PageSize:                 10,
@@ -22,7 +22,6 @@ package batcher
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"go.uber.org/cadence"
@@ -30,7 +29,6 @@ import (
 	"go.uber.org/cadence/workflow"
 	"golang.org/x/time/rate"
 
-	"github.com/uber/cadence/client/admin"
 	"github.com/uber/cadence/common/types"
 )
 
@@ -143,17 +141,9 @@ func BatchWorkflowV2(ctx workflow.Context, batchParams BatchParams) (HeartBeatDe
 func batchActivityV2(ctx context.Context, params BatchParams) (HeartBeatDetails, error) {
 	batcher := ctx.Value(BatcherContextKey).(*Batcher)
 	client := batcher.clientBean.GetFrontendClient()
-	var adminClient admin.Client
-	if params.BatchType == BatchTypeReplicate {
-		currentCluster := batcher.cfg.ClusterMetadata.GetCurrentClusterName()
-		if currentCluster != params.ReplicateParams.SourceCluster {
-			return HeartBeatDetails{}, cadence.NewCustomError(_nonRetriableReason, fmt.Sprintf("the activity must run in the source cluster, current cluster is %s", currentCluster))
-		}
-		var err error
-		adminClient, err = batcher.clientBean.GetRemoteAdminClient(params.ReplicateParams.TargetCluster)
-		if err != nil {
-			return HeartBeatDetails{}, cadence.NewCustomError(_nonRetriableReason, err.Error())
-		}
+	adminClient, err := getAdminClient(batcher, params)
+	if err != nil {
+		return HeartBeatDetails{}, err
 	}
 
 	domainResp, err := client.DescribeDomain(ctx, &types.DescribeDomainRequest{
@@ -162,6 +152,11 @@ func batchActivityV2(ctx context.Context, params BatchParams) (HeartBeatDetails,
 	if err != nil {
 		return HeartBeatDetails{}, err
 	}
+	if params.BatchType == BatchTypeReplicate {
+		if err := validateReplicationTarget(domainResp, params); err != nil {
+			return HeartBeatDetails{}, cadence.NewCustomError(_nonRetriableReason, err.Error())
+		}
+	}
 	domainID := domainResp.GetDomainInfo().GetUUID()
 
 	hbd, ok := getHeartBeatDetails(ctx)
@@ -184,7 +179,7 @@ func batchActivityV2(ctx context.Context, params BatchParams) (HeartBeatDetails,
 
 	rateLimiter := rate.NewLimiter(rate.Limit(params.RPS), params.RPS)
 	taskCh := make(chan taskDetail, params.PageSize)
-	respCh := make(chan error, params.PageSize)
+	respCh := make(chan WorkflowResult, params.PageSize)
 	for i := 0; i < params.Concurrency; i++ {
 		go startTaskProcessor(ctx, params, domainID, taskCh, respCh, rateLimiter, client, adminClient, BatchWFV2TypeName)
 	}
@@ -199,39 +194,17 @@ func batchActivityV2(ctx context.Context, params BatchParams) (HeartBeatDetails,
 		if err != nil {
 			return hbd, err
 		}
-		batchCount := len(resp.Executions)
-		if batchCount <= 0 {
+		if len(resp.Executions) == 0 {
 			break
 		}
 
-		for _, wf := range resp.Executions {
-			taskCh <- taskDetail{
-				execution: *wf.Execution,
-				attempts:  0,
-				hbd:       hbd,
-			}
+		results, err := processPage(ctx, params, resp.Executions, hbd, taskCh, respCh)
+		if err != nil {
+			return hbd, cadence.NewCanceledError(hbd)
 		}
+		writeResultLog(ctx, hbd.CurrentPage, results)
 
-		succCount := 0
-		errCount := 0
-	Loop:
-		for {
-			select {
-			case err := <-respCh:
-				if err == nil {
-					succCount++
-				} else {
-					errCount++
-				}
-				if succCount+errCount == batchCount {
-					break Loop
-				}
-			case <-ctx.Done():
-				return hbd, cadence.NewCanceledError(hbd)
-			}
-		}
-		hbd.SuccessCount += succCount
-		hbd.ErrorCount += errCount
+		hbd.addResults(results)
 		hbd.CurrentPage++
 		hbd.PageToken = resp.NextPageToken
 		activity.RecordHeartbeat(ctx, hbd)
@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package child
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+// Child is an invariant that will be used to identify child workflows blocking the parent workflow from closing
+type Child invariant.Invariant
+
+type child struct {
+}
+
+func NewInvariant() Child {
+	return &child{}
+}
+
+func (c *child) Check(ctx context.Context, params invariant.InvariantCheckInput) ([]invariant.InvariantCheckResult, error) {
+	result := make([]invariant.InvariantCheckResult, 0)
+	events := params.WorkflowExecutionHistory.GetHistory().GetEvents()
+	if invariant.WorkflowClosed(events) {
+		return result, nil
+	}
+
+	pendingChildren := fetchPendingChildren(events)
+	// the parent is only blocked by its children when it has nothing else left to wait on
+	if len(pendingChildren) > 0 && !hasPendingWork(events) {
+		result = append(result, invariant.InvariantCheckResult{
+			IssueID:       0,
+			InvariantType: ChildWorkflowBlockingParent.String(),
+			Reason:        fmt.Sprintf("%d child workflows are still running and the parent has no other pending activities, timers or decisions", len(pendingChildren)),
+			Metadata: invariant.MarshalData(ChildWorkflowMetadata{
+				PendingChildren: pendingChildren,
+			}),
+		})
+	}
+	return result, nil
+}
+
+func (c *child) RootCause(ctx context.Context, params invariant.InvariantRootCauseInput) ([]invariant.InvariantRootCauseResult, error) {
+	// Not implemented since this invariant does not have any root cause.
+	// Issue identified in Check() are the root cause.
+	result := make([]invariant.InvariantRootCauseResult, 0)
+	return result, nil
+}
+
+// fetchPendingChildren returns the child workflows initiated by the workflow which have not been closed yet
+func fetchPendingChildren(events []*types.HistoryEvent) []PendingChild {
+	pending := make(map[int64]*PendingChild)
+	for _, event := range events {
+		switch event.GetEventType() {
+		case types.EventTypeStartChildWorkflowExecutionInitiated:
+			attr := event.GetStartChildWorkflowExecutionInitiatedEventAttributes()
+			pending[event.ID] = &PendingChild{
+				InitiatedEventID:  event.ID,
+				Domain:            attr.GetDomain(),
+				WorkflowID:        attr.GetWorkflowID(),
+				WorkflowType:      attr.GetWorkflowType().GetName(),
+				ParentClosePolicy: attr.ParentClosePolicy,
+			}
+		case types.EventTypeChildWorkflowExecutionStarted:
+			attr := event.GetChildWorkflowExecutionStartedEventAttributes()
+			if p, ok := pending[attr.GetInitiatedEventID()]; ok {
+				p.Started = true
+				p.RunID = attr.GetWorkflowExecution().GetRunID()
+			}
+		case types.EventTypeStartChildWorkflowExecutionFailed:
+			delete(pending, event.GetStartChildWorkflowExecutionFailedEventAttributes().GetInitiatedEventID())
+		case types.EventTypeChildWorkflowExecutionCompleted:
+			delete(pending, event.GetChildWorkflowExecutionCompletedEventAttributes().GetInitiatedEventID())
+		case types.EventTypeChildWorkflowExecutionFailed:
+			delete(pending, event.GetChildWorkflowExecutionFailedEventAttributes().GetInitiatedEventID())
+		case types.EventTypeChildWorkflowExecutionCanceled:
+			delete(pending, event.GetChildWorkflowExecutionCanceledEventAttributes().GetInitiatedEventID())
+		case types.EventTypeChildWorkflowExecutionTimedOut:
+			delete(pending, event.GetChildWorkflowExecutionTimedOutEventAttributes().GetInitiatedEventID())
+		case types.EventTypeChildWorkflowExecutionTerminated:
+			delete(pending, event.GetChildWorkflowExecutionTerminatedEventAttributes().GetInitiatedEventID())
+		}
+	}
+
+	result := make([]PendingChild, 0, len(pending))
+	for _, p := range pending {
+		result = append(result, *p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].InitiatedEventID < result[j].InitiatedEventID
+	})
+	return result
+}
+
+// hasPendingWork returns whether the workflow has open activities, timers or decisions
+func hasPendingWork(events []*types.HistoryEvent) bool {
+	activities := make(map[int64]struct{})
+	timers := make(map[string]struct{})
+	decisions := make(map[int64]struct{})
+	for _, event := range events {
+		switch event.GetEventType() {
+		case types.EventTypeActivityTaskScheduled:
+			activities[event.ID] = struct{}{}
+		case types.EventTypeActivityTaskCompleted:
+			delete(activities, event.GetActivityTaskCompletedEventAttributes().GetScheduledEventID())
+		case types.EventTypeActivityTaskFailed:
+			delete(activities, event.GetActivityTaskFailedEventAttributes().GetScheduledEventID())
+		case types.EventTypeActivityTaskTimedOut:
+			delete(activities, event.GetActivityTaskTimedOutEventAttributes().GetScheduledEventID())
+		case types.EventTypeActivityTaskCanceled:
+			delete(activities, event.GetActivityTaskCanceledEventAttributes().GetScheduledEventID())
+		case types.EventTypeTimerStarted:
+			timers[event.GetTimerStartedEventAttributes().GetTimerID()] = struct{}{}
+		case types.EventTypeTimerFired:
+			delete(timers, event.GetTimerFiredEventAttributes().GetTimerID())
+		case types.EventTypeTimerCanceled:
+			delete(timers, event.GetTimerCanceledEventAttributes().GetTimerID())
+		case types.EventTypeDecisionTaskScheduled:
+			decisions[event.ID] = struct{}{}
+		case types.EventTypeDecisionTaskCompleted:
+			if attr := event.GetDecisionTaskCompletedEventAttributes(); attr != nil {
+				delete(decisions, attr.ScheduledEventID)
+			}
+		case types.EventTypeDecisionTaskFailed:
+			if attr := event.GetDecisionTaskFailedEventAttributes(); attr != nil {
+				delete(decisions, attr.ScheduledEventID)
+			}
+		case types.EventTypeDecisionTaskTimedOut:
+			delete(decisions, event.GetDecisionTaskTimedOutEventAttributes().GetScheduledEventID())
+		}
+	}
+	return len(activities) > 0 || len(timers) > 0 || len(decisions) > 0
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package child
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+func Test__Check(t *testing.T) {
+	testCases := []struct {
+		name           string
+		testData       *types.GetWorkflowExecutionHistoryResponse
+		expectedResult []invariant.InvariantCheckResult
+	}{
+		{
+			name:     "running children block parent",
+			testData: childWfHistory(false),
+			expectedResult: []invariant.InvariantCheckResult{
+				{
+					IssueID:       0,
+					InvariantType: ChildWorkflowBlockingParent.String(),
+					Reason:        "2 child workflows are still running and the parent has no other pending activities, timers or decisions",
+					Metadata: invariant.MarshalData(ChildWorkflowMetadata{
+						PendingChildren: []PendingChild{
+							{
+								InitiatedEventID:  3,
+								Domain:            "test-domain",
+								WorkflowID:        "child-2",
+								RunID:             "run-2",
+								WorkflowType:      "child-type",
+								Started:           true,
+								ParentClosePolicy: types.ParentClosePolicyTerminate.Ptr(),
+							},
+							{
+								InitiatedEventID:  4,
+								Domain:            "test-domain",
+								WorkflowID:        "child-3",
+								WorkflowType:      "child-type",
+								ParentClosePolicy: types.ParentClosePolicyTerminate.Ptr(),
+							},
+						},
+					}),
+				},
+			},
+		},
+		{
+			name: "parent waiting on a timer",
+			testData: withEvents(childWfHistory(false), &types.HistoryEvent{
+				ID:                          8,
+				EventType:                   types.EventTypeTimerStarted.Ptr(),
+				TimerStartedEventAttributes: &types.TimerStartedEventAttributes{TimerID: "timer"},
+			}),
+			expectedResult: []invariant.InvariantCheckResult{},
+		},
+		{
+			name: "parent with a pending activity",
+			testData: withEvents(childWfHistory(false), &types.HistoryEvent{
+				ID:                                   8,
+				EventType:                            types.EventTypeActivityTaskScheduled.Ptr(),
+				ActivityTaskScheduledEventAttributes: &types.ActivityTaskScheduledEventAttributes{ActivityID: "activity"},
+			}),
+			expectedResult: []invariant.InvariantCheckResult{},
+		},
+		{
+			name: "parent with a pending decision",
+			testData: withEvents(childWfHistory(false), &types.HistoryEvent{
+				ID:                                   8,
+				EventType:                            types.EventTypeDecisionTaskScheduled.Ptr(),
+				DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{},
+			}),
+			expectedResult: []invariant.InvariantCheckResult{},
+		},
+		{
+			name: "fired timer and completed activity",
+			testData: withEvents(childWfHistory(false),
+				&types.HistoryEvent{
+					ID:                          8,
+					EventType:                   types.EventTypeTimerStarted.Ptr(),
+					TimerStartedEventAttributes: &types.TimerStartedEventAttributes{TimerID: "timer"},
+				},
+				&types.HistoryEvent{
+					ID:                        9,
+					EventType:                 types.EventTypeTimerFired.Ptr(),
+					TimerFiredEventAttributes: &types.TimerFiredEventAttributes{TimerID: "timer"},
+				},
+				&types.HistoryEvent{
+					ID:                                   10,
+					EventType:                            types.EventTypeActivityTaskScheduled.Ptr(),
+					ActivityTaskScheduledEventAttributes: &types.ActivityTaskScheduledEventAttributes{ActivityID: "activity"},
+				},
+				&types.HistoryEvent{
+					ID:                                   11,
+					EventType:                            types.EventTypeActivityTaskCompleted.Ptr(),
+					ActivityTaskCompletedEventAttributes: &types.ActivityTaskCompletedEventAttributes{ScheduledEventID: 10},
+				},
+			),
+			expectedResult: []invariant.InvariantCheckResult{
+				{
+					IssueID:       0,
+					InvariantType: ChildWorkflowBlockingParent.String(),
+					Reason:        "2 child workflows are still running and the parent has no other pending activities, timers or decisions",
+					Metadata: invariant.MarshalData(ChildWorkflowMetadata{
+						PendingChildren: []PendingChild{
+							{
+								InitiatedEventID:  3,
+								Domain:            "test-domain",
+								WorkflowID:        "child-2",
+								RunID:             "run-2",
+								WorkflowType:      "child-type",
+								Started:           true,
+								ParentClosePolicy: types.ParentClosePolicyTerminate.Ptr(),
+							},
+							{
+								InitiatedEventID:  4,
+								Domain:            "test-domain",
+								WorkflowID:        "child-3",
+								WorkflowType:      "child-type",
+								ParentClosePolicy: types.ParentClosePolicyTerminate.Ptr(),
+							},
+						},
+					}),
+				},
+			},
+		},
+		{
+			name:           "parent closed",
+			testData:       childWfHistory(true),
+			expectedResult: []invariant.InvariantCheckResult{},
+		},
+	}
+	inv := NewInvariant()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := inv.Check(context.Background(), invariant.InvariantCheckInput{
+				WorkflowExecutionHistory: tc.testData,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func childWfHistory(closed bool) *types.GetWorkflowExecutionHistoryResponse {
+	events := []*types.HistoryEvent{
+		{
+			ID:                                      1,
+			EventType:                               types.EventTypeWorkflowExecutionStarted.Ptr(),
+			WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{},
+		},
+		childInitiated(2, "child-1"),
+		childInitiated(3, "child-2"),
+		childInitiated(4, "child-3"),
+		{
+			ID:        5,
+			EventType: types.EventTypeChildWorkflowExecutionStarted.Ptr(),
+			ChildWorkflowExecutionStartedEventAttributes: &types.ChildWorkflowExecutionStartedEventAttributes{
+				InitiatedEventID:  2,
+				WorkflowExecution: &types.WorkflowExecution{WorkflowID: "child-1", RunID: "run-1"},
+			},
+		},
+		{
+			ID:        6,
+			EventType: types.EventTypeChildWorkflowExecutionStarted.Ptr(),
+			ChildWorkflowExecutionStartedEventAttributes: &types.ChildWorkflowExecutionStartedEventAttributes{
+				InitiatedEventID:  3,
+				WorkflowExecution: &types.WorkflowExecution{WorkflowID: "child-2", RunID: "run-2"},
+			},
+		},
+		{
+			ID:        7,
+			EventType: types.EventTypeChildWorkflowExecutionCompleted.Ptr(),
+			ChildWorkflowExecutionCompletedEventAttributes: &types.ChildWorkflowExecutionCompletedEventAttributes{
+				InitiatedEventID: 2,
+			},
+		},
+	}
+	if closed {
+		events = append(events, &types.HistoryEvent{
+			ID:        8,
+			EventType: types.EventTypeWorkflowExecutionTerminated.Ptr(),
+			WorkflowExecutionTerminatedEventAttributes: &types.WorkflowExecutionTerminatedEventAttributes{},
+		})
+	}
+	return &types.GetWorkflowExecutionHistoryResponse{
+		History: &types.History{Events: events},
+	}
+}
+
+func withEvents(history *types.GetWorkflowExecutionHistoryResponse, events ...*types.HistoryEvent) *types.GetWorkflowExecutionHistoryResponse {
+	history.History.Events = append(history.History.Events, events...)
+	return history
+}
+
+func childInitiated(id int64, workflowID string) *types.HistoryEvent {
+	return &types.HistoryEvent{
+		ID:        id,
+		EventType: types.EventTypeStartChildWorkflowExecutionInitiated.Ptr(),
+		StartChildWorkflowExecutionInitiatedEventAttributes: &types.StartChildWorkflowExecutionInitiatedEventAttributes{
+			Domain:            "test-domain",
+			WorkflowID:        workflowID,
+			WorkflowType:      &types.WorkflowType{Name: "child-type"},
+			ParentClosePolicy: types.ParentClosePolicyTerminate.Ptr(),
+		},
+	}
+}
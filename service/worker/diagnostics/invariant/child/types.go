@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package child
+
+import "github.com/uber/cadence/common/types"
+
+type ChildIssueType string
+
+const (
+	ChildWorkflowBlockingParent ChildIssueType = "Parent workflow is waiting on child workflows that have not completed"
+)
+
+func (c ChildIssueType) String() string {
+	return string(c)
+}
+
+const (
+	ChildWorkflowBlockingParentRemediation = "Diagnose the child workflows to find out why they have not completed. " +
+		"If the parent does not need the result of a child, start it with ParentClosePolicy ABANDON and do not wait on the child future."
+)
+
+// Remediation returns the suggested action for an issue identified by this invariant
+func Remediation(issueType string) string {
+	if issueType == ChildWorkflowBlockingParent.String() {
+		return ChildWorkflowBlockingParentRemediation
+	}
+	return ""
+}
+
+type PendingChild struct {
+	InitiatedEventID  int64
+	Domain            string
+	WorkflowID        string
+	RunID             string
+	WorkflowType      string
+	Started           bool
+	ParentClosePolicy *types.ParentClosePolicy
+}
+
+type ChildWorkflowMetadata struct {
+	PendingChildren []PendingChild
+}
@@ -0,0 +1,296 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package decision
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
+	"go.uber.org/cadence/.gen/go/shared"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+const _maxDetailsLength = 512 // maximum length of the failure details kept in the metadata
+
+// Decision is an invariant that will be used to identify workflows stuck on decision tasks
+type Decision invariant.Invariant
+
+type decision struct {
+	client                           workflowserviceclient.Interface
+	nonDeterministicFailureThreshold dynamicproperties.IntPropertyFnWithDomainFilter
+	pendingDecisionAgeThreshold      dynamicproperties.DurationPropertyFnWithDomainFilter
+	logger                           log.Logger
+	timeSource                       clock.TimeSource
+}
+
+type Params struct {
+	Client                           workflowserviceclient.Interface
+	NonDeterministicFailureThreshold dynamicproperties.IntPropertyFnWithDomainFilter
+	PendingDecisionAgeThreshold      dynamicproperties.DurationPropertyFnWithDomainFilter
+	Logger                           log.Logger
+}
+
+func NewInvariant(p Params) Decision {
+	logger := p.Logger
+	if logger == nil {
+		logger = log.NewNoop()
+	}
+	return &decision{
+		client:                           p.Client,
+		nonDeterministicFailureThreshold: p.NonDeterministicFailureThreshold,
+		pendingDecisionAgeThreshold:      p.PendingDecisionAgeThreshold,
+		logger:                           logger,
+		timeSource:                       clock.NewRealTimeSource(),
+	}
+}
+
+func (d *decision) Check(ctx context.Context, params invariant.InvariantCheckInput) ([]invariant.InvariantCheckResult, error) {
+	result := make([]invariant.InvariantCheckResult, 0)
+	events := params.WorkflowExecutionHistory.GetHistory().GetEvents()
+	issueID := 0
+
+	metadata := checkNonDeterminism(events)
+	if metadata.FailedCount > 0 && metadata.consecutiveFailures() >= d.failureThreshold(params.Domain) {
+		result = append(result, invariant.InvariantCheckResult{
+			IssueID:       issueID,
+			InvariantType: DecisionTaskNonDeterminism.String(),
+			Reason:        metadata.Cause,
+			Metadata:      invariant.MarshalData(metadata),
+		})
+		issueID++
+	}
+
+	if invariant.WorkflowClosed(events) {
+		return result, nil
+	}
+
+	if pending := fetchPendingDecision(events); pending != nil {
+		attr := pending.GetDecisionTaskScheduledEventAttributes()
+		scheduledTime := time.Unix(0, common.Int64Default(pending.Timestamp))
+		age := d.timeSource.Now().Sub(scheduledTime)
+		if age < d.ageThreshold(params.Domain) {
+			// decisions are usually started within seconds, a recently scheduled one is not stuck yet
+			return result, nil
+		}
+		result = append(result, invariant.InvariantCheckResult{
+			IssueID:       issueID,
+			InvariantType: DecisionTaskPending.String(),
+			Reason:        fmt.Sprintf("decision task scheduled at event %d has not been started for %v", pending.ID, age.Round(time.Second)),
+			Metadata: invariant.MarshalData(PendingDecisionMetadata{
+				ScheduledEventID: pending.ID,
+				ScheduledTime:    scheduledTime,
+				Attempt:          attr.GetAttempt(),
+				Tasklist:         attr.TaskList,
+			}),
+		})
+		issueID++
+	}
+	return result, nil
+}
+
+func (d *decision) RootCause(ctx context.Context, params invariant.InvariantRootCauseInput) ([]invariant.InvariantRootCauseResult, error) {
+	result := make([]invariant.InvariantRootCauseResult, 0)
+	for _, issue := range params.Issues {
+		switch issue.InvariantType {
+		case DecisionTaskPending.String():
+			pollerStatus, err := d.checkTasklist(ctx, issue, params.Domain)
+			if err != nil {
+				// the tasklist could not be described, keep the root causes of the other issues
+				d.logger.Warn("Failed to check the tasklist of a pending decision",
+					tag.WorkflowDomainName(params.Domain),
+					tag.Error(err))
+				continue
+			}
+			result = append(result, pollerStatus)
+		case DecisionTaskNonDeterminism.String():
+			rootCause, err := checkDeployment(issue)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, rootCause)
+		}
+	}
+	return result, nil
+}
+
+func (d *decision) checkTasklist(ctx context.Context, issue invariant.InvariantCheckResult, domain string) (invariant.InvariantRootCauseResult, error) {
+	var metadata PendingDecisionMetadata
+	err := json.Unmarshal(issue.Metadata, &metadata)
+	if err != nil {
+		return invariant.InvariantRootCauseResult{}, err
+	}
+	if metadata.Tasklist == nil {
+		return invariant.InvariantRootCauseResult{}, fmt.Errorf("tasklist not set")
+	}
+
+	resp, err := d.client.DescribeTaskList(ctx, &shared.DescribeTaskListRequest{
+		Domain: &domain,
+		TaskList: &shared.TaskList{
+			Name: &metadata.Tasklist.Name,
+			Kind: taskListKind(metadata.Tasklist.GetKind()),
+		},
+		TaskListType: shared.TaskListTypeDecision.Ptr(),
+	})
+	if err != nil {
+		return invariant.InvariantRootCauseResult{}, err
+	}
+
+	pollersMetadataInBytes := invariant.MarshalData(DecisionRootCauseMetadata{
+		PollersMetadata: &PollersMetadata{
+			TaskListName:    metadata.Tasklist.Name,
+			TaskListBacklog: resp.GetTaskListStatus().GetBacklogCountHint(),
+		},
+	})
+	if len(resp.GetPollers()) == 0 {
+		return invariant.InvariantRootCauseResult{
+			IssueID:   issue.IssueID,
+			RootCause: invariant.RootCauseTypeDecisionMissingPollers,
+			Metadata:  pollersMetadataInBytes,
+		}, nil
+	}
+	return invariant.InvariantRootCauseResult{
+		IssueID:   issue.IssueID,
+		RootCause: invariant.RootCauseTypeDecisionPollersStatus,
+		Metadata:  pollersMetadataInBytes,
+	}, nil
+}
+
+// checkDeployment identifies whether the non-deterministic failures started with a new worker binary
+func checkDeployment(issue invariant.InvariantCheckResult) (invariant.InvariantRootCauseResult, error) {
+	var metadata NonDeterminismMetadata
+	err := json.Unmarshal(issue.Metadata, &metadata)
+	if err != nil {
+		return invariant.InvariantRootCauseResult{}, err
+	}
+	rootCause := invariant.RootCauseTypeNonDeterministicWorkflowCode
+	if metadata.FailingBinaryChecksum != "" && metadata.LastGoodBinaryChecksum != "" &&
+		metadata.FailingBinaryChecksum != metadata.LastGoodBinaryChecksum {
+		rootCause = invariant.RootCauseTypeNonDeterministicDeployment
+	}
+	return invariant.InvariantRootCauseResult{
+		IssueID:   issue.IssueID,
+		RootCause: rootCause,
+		Metadata:  invariant.MarshalData(DecisionRootCauseMetadata{NonDeterminismMetadata: &metadata}),
+	}, nil
+}
+
+func (d *decision) failureThreshold(domain string) int {
+	if d.nonDeterministicFailureThreshold == nil {
+		return dynamicproperties.DiagnosticsNonDeterministicFailureThreshold.DefaultInt()
+	}
+	return d.nonDeterministicFailureThreshold(domain)
+}
+
+func (d *decision) ageThreshold(domain string) time.Duration {
+	if d.pendingDecisionAgeThreshold == nil {
+		return dynamicproperties.DiagnosticsPendingDecisionAgeThreshold.DefaultDuration()
+	}
+	return d.pendingDecisionAgeThreshold(domain)
+}
+
+// checkNonDeterminism collects the non-deterministic failures since the last completed decision task.
+// A single failure can be a transient worker issue, the caller only reports failures that keep repeating.
+func checkNonDeterminism(events []*types.HistoryEvent) NonDeterminismMetadata {
+	var metadata NonDeterminismMetadata
+	var lastGoodBinaryChecksum string
+	for _, event := range events {
+		if attr := event.GetDecisionTaskCompletedEventAttributes(); attr != nil {
+			lastGoodBinaryChecksum = attr.GetBinaryChecksum()
+			metadata = NonDeterminismMetadata{}
+			continue
+		}
+		if attr := event.GetDecisionTaskScheduledEventAttributes(); attr != nil && metadata.FailedCount > 0 {
+			// failures of retried decision tasks are not written to the history, the attempt counts them
+			metadata.Attempt = attr.GetAttempt()
+			continue
+		}
+		attr := event.GetDecisionTaskFailedEventAttributes()
+		if attr == nil || !isNonDeterministicFailure(attr) {
+			continue
+		}
+		metadata.FailedCount++
+		metadata.LastFailedEventID = event.ID
+		metadata.Cause = attr.GetCause().String()
+		metadata.Identity = attr.Identity
+		metadata.FailingBinaryChecksum = attr.BinaryChecksum
+		metadata.LastGoodBinaryChecksum = lastGoodBinaryChecksum
+		metadata.Details = truncate(string(attr.Details), _maxDetailsLength)
+	}
+	return metadata
+}
+
+// isNonDeterministicFailure matches the failures reported by workers which could not replay the history: workers fail
+// the decision task with WorkflowWorkerUnhandledFailure, which they also use for panics, and describe the mismatch.
+func isNonDeterministicFailure(attr *types.DecisionTaskFailedEventAttributes) bool {
+	if attr.GetCause() != types.DecisionTaskFailedCauseWorkflowWorkerUnhandledFailure {
+		return false
+	}
+	for _, text := range []string{string(attr.Details), common.StringDefault(attr.Reason)} {
+		text = strings.ToLower(text)
+		if strings.Contains(text, "nondetermini") || strings.Contains(text, "non-determini") {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchPendingDecision returns the scheduled event of the decision task that has not been started yet, if any
+func fetchPendingDecision(events []*types.HistoryEvent) *types.HistoryEvent {
+	var pending *types.HistoryEvent
+	for _, event := range events {
+		switch event.GetEventType() {
+		case types.EventTypeDecisionTaskScheduled:
+			pending = event
+		case types.EventTypeDecisionTaskStarted,
+			types.EventTypeDecisionTaskCompleted,
+			types.EventTypeDecisionTaskFailed,
+			types.EventTypeDecisionTaskTimedOut:
+			pending = nil
+		}
+	}
+	return pending
+}
+
+func taskListKind(kind types.TaskListKind) *shared.TaskListKind {
+	if kind.String() == shared.TaskListKindNormal.String() {
+		return shared.TaskListKindNormal.Ptr()
+	}
+
+	return shared.TaskListKindSticky.Ptr()
+}
+
+func truncate(s string, length int) string {
+	if len(s) > length {
+		return s[:length]
+	}
+	return s
+}
@@ -0,0 +1,396 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package decision
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	publicservicetest "go.uber.org/cadence/.gen/go/cadence/workflowservicetest"
+	"go.uber.org/cadence/.gen/go/shared"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+const (
+	testTimeStamp       = int64(2547596872371000000)
+	testTasklist        = "test-tasklist"
+	testDomain          = "test-domain"
+	testTaskListBacklog = int64(10)
+	testDetails         = "nondeterministic workflow: history event is ActivityTaskScheduled, replay decision is StartTimer"
+)
+
+func Test__Check(t *testing.T) {
+	nonDeterminismMetadata := NonDeterminismMetadata{
+		FailedCount:            1,
+		Attempt:                2,
+		LastFailedEventID:      8,
+		Cause:                  types.DecisionTaskFailedCauseWorkflowWorkerUnhandledFailure.String(),
+		Identity:               "worker-2",
+		FailingBinaryChecksum:  "v2",
+		LastGoodBinaryChecksum: "v1",
+		Details:                testDetails,
+	}
+	pendingMetadata := PendingDecisionMetadata{
+		ScheduledEventID: 9,
+		ScheduledTime:    time.Unix(0, testTimeStamp),
+		Attempt:          2,
+		Tasklist:         &types.TaskList{Name: testTasklist},
+	}
+	pendingResult := invariant.InvariantCheckResult{
+		IssueID:       0,
+		InvariantType: DecisionTaskPending.String(),
+		Reason:        "decision task scheduled at event 9 has not been started for 5m0s",
+		Metadata:      invariant.MarshalData(pendingMetadata),
+	}
+	testCases := []struct {
+		name             string
+		testData         *types.GetWorkflowExecutionHistoryResponse
+		now              time.Time
+		failureThreshold int
+		expectedResult   []invariant.InvariantCheckResult
+	}{
+		{
+			name:     "repeated non-deterministic failures with pending decision",
+			testData: nonDeterministicWfHistory(2, testDetails),
+			now:      time.Unix(0, testTimeStamp).Add(5 * time.Minute),
+			expectedResult: []invariant.InvariantCheckResult{
+				{
+					IssueID:       0,
+					InvariantType: DecisionTaskNonDeterminism.String(),
+					Reason:        types.DecisionTaskFailedCauseWorkflowWorkerUnhandledFailure.String(),
+					Metadata:      invariant.MarshalData(nonDeterminismMetadata),
+				},
+				{
+					IssueID:       1,
+					InvariantType: DecisionTaskPending.String(),
+					Reason:        "decision task scheduled at event 9 has not been started for 5m0s",
+					Metadata:      invariant.MarshalData(pendingMetadata),
+				},
+			},
+		},
+		{
+			name:     "recently scheduled decision after repeated failures",
+			testData: nonDeterministicWfHistory(2, testDetails),
+			now:      time.Unix(0, testTimeStamp).Add(10 * time.Second),
+			expectedResult: []invariant.InvariantCheckResult{
+				{
+					IssueID:       0,
+					InvariantType: DecisionTaskNonDeterminism.String(),
+					Reason:        types.DecisionTaskFailedCauseWorkflowWorkerUnhandledFailure.String(),
+					Metadata:      invariant.MarshalData(nonDeterminismMetadata),
+				},
+			},
+		},
+		{
+			name:           "single non-deterministic failure",
+			testData:       nonDeterministicWfHistory(1, testDetails),
+			now:            time.Unix(0, testTimeStamp).Add(5 * time.Minute),
+			expectedResult: []invariant.InvariantCheckResult{withMetadata(pendingResult, withAttempt(pendingMetadata, 1))},
+		},
+		{
+			name:             "single non-deterministic failure with threshold of one",
+			testData:         nonDeterministicWfHistory(1, testDetails),
+			now:              time.Unix(0, testTimeStamp),
+			failureThreshold: 1,
+			expectedResult: []invariant.InvariantCheckResult{
+				{
+					IssueID:       0,
+					InvariantType: DecisionTaskNonDeterminism.String(),
+					Reason:        types.DecisionTaskFailedCauseWorkflowWorkerUnhandledFailure.String(),
+					Metadata: invariant.MarshalData(NonDeterminismMetadata{
+						FailedCount:            1,
+						Attempt:                1,
+						LastFailedEventID:      8,
+						Cause:                  types.DecisionTaskFailedCauseWorkflowWorkerUnhandledFailure.String(),
+						Identity:               "worker-2",
+						FailingBinaryChecksum:  "v2",
+						LastGoodBinaryChecksum: "v1",
+						Details:                testDetails,
+					}),
+				},
+			},
+		},
+		{
+			name:           "repeated worker panics",
+			testData:       nonDeterministicWfHistory(2, "panic: runtime error: invalid memory address or nil pointer dereference"),
+			now:            time.Unix(0, testTimeStamp).Add(5 * time.Minute),
+			expectedResult: []invariant.InvariantCheckResult{pendingResult},
+		},
+		{
+			name:           "decision started and completed",
+			testData:       completedWfHistory(),
+			now:            time.Unix(0, testTimeStamp),
+			expectedResult: []invariant.InvariantCheckResult{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			params := Params{}
+			if tc.failureThreshold > 0 {
+				params.NonDeterministicFailureThreshold = dynamicproperties.GetIntPropertyFilteredByDomain(tc.failureThreshold)
+			}
+			inv := NewInvariant(params)
+			inv.(*decision).timeSource = clock.NewMockedTimeSourceAt(tc.now)
+			result, err := inv.Check(context.Background(), invariant.InvariantCheckInput{
+				WorkflowExecutionHistory: tc.testData,
+				Domain:                   testDomain,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func Test__RootCause(t *testing.T) {
+	pendingMetadataInBytes, err := json.Marshal(PendingDecisionMetadata{
+		ScheduledEventID: 9,
+		Tasklist:         &types.TaskList{Name: testTasklist},
+	})
+	require.NoError(t, err)
+	pollersMetadataInBytes := invariant.MarshalData(DecisionRootCauseMetadata{
+		PollersMetadata: &PollersMetadata{TaskListName: testTasklist, TaskListBacklog: testTaskListBacklog},
+	})
+	deploymentMetadata := NonDeterminismMetadata{FailedCount: 1, FailingBinaryChecksum: "v2", LastGoodBinaryChecksum: "v1"}
+	codeMetadata := NonDeterminismMetadata{FailedCount: 1, FailingBinaryChecksum: "v1", LastGoodBinaryChecksum: "v1"}
+	testCases := []struct {
+		name           string
+		input          []invariant.InvariantCheckResult
+		clientExpects  func(client *publicservicetest.MockClient)
+		expectedResult []invariant.InvariantRootCauseResult
+	}{
+		{
+			name: "pending decision without pollers",
+			input: []invariant.InvariantCheckResult{
+				{IssueID: 1, InvariantType: DecisionTaskPending.String(), Metadata: pendingMetadataInBytes},
+			},
+			clientExpects: func(client *publicservicetest.MockClient) {
+				client.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).Return(&shared.DescribeTaskListResponse{
+					TaskListStatus: &shared.TaskListStatus{
+						BacklogCountHint: common.Int64Ptr(testTaskListBacklog),
+					},
+				}, nil)
+			},
+			expectedResult: []invariant.InvariantRootCauseResult{
+				{IssueID: 1, RootCause: invariant.RootCauseTypeDecisionMissingPollers, Metadata: pollersMetadataInBytes},
+			},
+		},
+		{
+			name: "pending decision with pollers",
+			input: []invariant.InvariantCheckResult{
+				{IssueID: 1, InvariantType: DecisionTaskPending.String(), Metadata: pendingMetadataInBytes},
+			},
+			clientExpects: func(client *publicservicetest.MockClient) {
+				client.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).Return(&shared.DescribeTaskListResponse{
+					Pollers: []*shared.PollerInfo{{Identity: common.StringPtr("worker")}},
+					TaskListStatus: &shared.TaskListStatus{
+						BacklogCountHint: common.Int64Ptr(testTaskListBacklog),
+					},
+				}, nil)
+			},
+			expectedResult: []invariant.InvariantRootCauseResult{
+				{IssueID: 1, RootCause: invariant.RootCauseTypeDecisionPollersStatus, Metadata: pollersMetadataInBytes},
+			},
+		},
+		{
+			name: "tasklist cannot be described",
+			input: []invariant.InvariantCheckResult{
+				{IssueID: 0, InvariantType: DecisionTaskNonDeterminism.String(), Metadata: invariant.MarshalData(codeMetadata)},
+				{IssueID: 1, InvariantType: DecisionTaskPending.String(), Metadata: pendingMetadataInBytes},
+			},
+			clientExpects: func(client *publicservicetest.MockClient) {
+				client.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).Return(nil, &shared.InternalServiceError{Message: "unavailable"})
+			},
+			expectedResult: []invariant.InvariantRootCauseResult{
+				{
+					IssueID:   0,
+					RootCause: invariant.RootCauseTypeNonDeterministicWorkflowCode,
+					Metadata:  invariant.MarshalData(DecisionRootCauseMetadata{NonDeterminismMetadata: &codeMetadata}),
+				},
+			},
+		},
+		{
+			name: "non-determinism after deployment",
+			input: []invariant.InvariantCheckResult{
+				{IssueID: 0, InvariantType: DecisionTaskNonDeterminism.String(), Metadata: invariant.MarshalData(deploymentMetadata)},
+			},
+			clientExpects: func(client *publicservicetest.MockClient) {},
+			expectedResult: []invariant.InvariantRootCauseResult{
+				{
+					IssueID:   0,
+					RootCause: invariant.RootCauseTypeNonDeterministicDeployment,
+					Metadata:  invariant.MarshalData(DecisionRootCauseMetadata{NonDeterminismMetadata: &deploymentMetadata}),
+				},
+			},
+		},
+		{
+			name: "non-determinism without deployment",
+			input: []invariant.InvariantCheckResult{
+				{IssueID: 0, InvariantType: DecisionTaskNonDeterminism.String(), Metadata: invariant.MarshalData(codeMetadata)},
+			},
+			clientExpects: func(client *publicservicetest.MockClient) {},
+			expectedResult: []invariant.InvariantRootCauseResult{
+				{
+					IssueID:   0,
+					RootCause: invariant.RootCauseTypeNonDeterministicWorkflowCode,
+					Metadata:  invariant.MarshalData(DecisionRootCauseMetadata{NonDeterminismMetadata: &codeMetadata}),
+				},
+			},
+		},
+	}
+	ctrl := gomock.NewController(t)
+	mockClient := publicservicetest.NewMockClient(ctrl)
+	inv := NewInvariant(Params{
+		Client: mockClient,
+	})
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.clientExpects(mockClient)
+			result, err := inv.RootCause(context.Background(), invariant.InvariantRootCauseInput{
+				Domain: testDomain,
+				Issues: tc.input,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func withMetadata(result invariant.InvariantCheckResult, metadata interface{}) invariant.InvariantCheckResult {
+	result.Metadata = invariant.MarshalData(metadata)
+	return result
+}
+
+func withAttempt(metadata PendingDecisionMetadata, attempt int64) PendingDecisionMetadata {
+	metadata.Attempt = attempt
+	return metadata
+}
+
+// nonDeterministicWfHistory is the history of a workflow whose decision task failed with the details and is retried
+// at the attempt, the failures of the retries are not written to the history
+func nonDeterministicWfHistory(attempt int64, details string) *types.GetWorkflowExecutionHistoryResponse {
+	return &types.GetWorkflowExecutionHistoryResponse{
+		History: &types.History{
+			Events: []*types.HistoryEvent{
+				{
+					ID:                                      1,
+					EventType:                               types.EventTypeWorkflowExecutionStarted.Ptr(),
+					WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{},
+				},
+				decisionScheduled(2),
+				decisionStarted(3),
+				{
+					ID:        4,
+					EventType: types.EventTypeDecisionTaskCompleted.Ptr(),
+					DecisionTaskCompletedEventAttributes: &types.DecisionTaskCompletedEventAttributes{
+						BinaryChecksum: "v1",
+					},
+				},
+				{
+					ID:        5,
+					EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(),
+					WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{
+						SignalName: "signal",
+					},
+				},
+				decisionScheduled(6),
+				decisionStarted(7),
+				{
+					ID:        8,
+					EventType: types.EventTypeDecisionTaskFailed.Ptr(),
+					DecisionTaskFailedEventAttributes: &types.DecisionTaskFailedEventAttributes{
+						Cause:          types.DecisionTaskFailedCauseWorkflowWorkerUnhandledFailure.Ptr(),
+						Details:        []byte(details),
+						Identity:       "worker-2",
+						BinaryChecksum: "v2",
+					},
+				},
+				{
+					ID:        9,
+					Timestamp: common.Int64Ptr(testTimeStamp),
+					EventType: types.EventTypeDecisionTaskScheduled.Ptr(),
+					DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{
+						TaskList: &types.TaskList{Name: testTasklist},
+						Attempt:  attempt,
+					},
+				},
+			},
+		},
+	}
+}
+
+func completedWfHistory() *types.GetWorkflowExecutionHistoryResponse {
+	return &types.GetWorkflowExecutionHistoryResponse{
+		History: &types.History{
+			Events: []*types.HistoryEvent{
+				{
+					ID:                                      1,
+					EventType:                               types.EventTypeWorkflowExecutionStarted.Ptr(),
+					WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{},
+				},
+				decisionScheduled(2),
+				decisionStarted(3),
+				{
+					ID:        4,
+					EventType: types.EventTypeDecisionTaskFailed.Ptr(),
+					DecisionTaskFailedEventAttributes: &types.DecisionTaskFailedEventAttributes{
+						Cause: types.DecisionTaskFailedCauseResetStickyTasklist.Ptr(),
+					},
+				},
+				decisionScheduled(5),
+				decisionStarted(6),
+				{
+					ID:                                   7,
+					EventType:                            types.EventTypeDecisionTaskCompleted.Ptr(),
+					DecisionTaskCompletedEventAttributes: &types.DecisionTaskCompletedEventAttributes{},
+				},
+			},
+		},
+	}
+}
+
+func decisionScheduled(id int64) *types.HistoryEvent {
+	return &types.HistoryEvent{
+		ID:        id,
+		Timestamp: common.Int64Ptr(testTimeStamp),
+		EventType: types.EventTypeDecisionTaskScheduled.Ptr(),
+		DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{
+			TaskList: &types.TaskList{Name: testTasklist},
+			Attempt:  1,
+		},
+	}
+}
+
+func decisionStarted(id int64) *types.HistoryEvent {
+	return &types.HistoryEvent{
+		ID:                                 id,
+		EventType:                          types.EventTypeDecisionTaskStarted.Ptr(),
+		DecisionTaskStartedEventAttributes: &types.DecisionTaskStartedEventAttributes{ScheduledEventID: id - 1},
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package decision
+
+import (
+	"time"
+
+	"github.com/uber/cadence/common/types"
+)
+
+type DecisionIssueType string
+
+const (
+	DecisionTaskPending        DecisionIssueType = "Decision task is pending and has not been picked up by a worker"
+	DecisionTaskNonDeterminism DecisionIssueType = "Decision task failed due to non-deterministic workflow code"
+)
+
+func (d DecisionIssueType) String() string {
+	return string(d)
+}
+
+const (
+	PendingDecisionRemediation = "Make sure workers are running and polling the decision tasklist of the workflow. " +
+		"If workers are running, check that they are registered with the correct domain and tasklist name and that they are not overloaded."
+	NonDeterminismRemediation = "Roll back the worker deployment or guard the code change with workflow.GetVersion. " +
+		"Once fixed workers are deployed, stuck workflows can be reset with 'cadence workflow reset --reset_type LastDecisionCompleted' " +
+		"and the faulty binary can be blocked with 'cadence domain update --add_bad_binary'."
+)
+
+// Remediation returns the suggested action for an issue identified by this invariant
+func Remediation(issueType string) string {
+	switch issueType {
+	case DecisionTaskPending.String():
+		return PendingDecisionRemediation
+	case DecisionTaskNonDeterminism.String():
+		return NonDeterminismRemediation
+	}
+	return ""
+}
+
+type PendingDecisionMetadata struct {
+	ScheduledEventID int64
+	ScheduledTime    time.Time
+	Attempt          int64
+	Tasklist         *types.TaskList
+}
+
+type NonDeterminismMetadata struct {
+	FailedCount            int
+	Attempt                int64 // attempt of the decision task retried after the last failure
+	LastFailedEventID      int64
+	Cause                  string
+	Identity               string
+	FailingBinaryChecksum  string
+	LastGoodBinaryChecksum string
+	Details                string
+}
+
+type PollersMetadata struct {
+	TaskListName    string
+	TaskListBacklog int64
+}
+
+// consecutiveFailures is the number of failed attempts, including the retries whose failures are not in the history
+func (m NonDeterminismMetadata) consecutiveFailures() int {
+	if int(m.Attempt) > m.FailedCount {
+		return int(m.Attempt)
+	}
+	return m.FailedCount
+}
+
+type DecisionRootCauseMetadata struct {
+	PollersMetadata        *PollersMetadata
+	NonDeterminismMetadata *NonDeterminismMetadata
+}
+
+type DecisionIssuesMetadata struct {
+	PendingDecision *PendingDecisionMetadata
+	NonDeterminism  *NonDeterminismMetadata
+}
@@ -38,6 +38,39 @@ func (e ErrorType) String() string {
 	return string(e)
 }
 
+const (
+	CustomErrorRemediation  = "Check the reason and details of the custom error in the failure event and handle that case in the service code."
+	GenericErrorRemediation = "Check the logs of the worker identity in the failure event for the error and fix the service code. " +
+		"If the error is transient, configure a retry policy so that the failed operation is retried."
+	PanicErrorRemediation                  = "Fix the panic in the service code, the stack trace is in the details of the failure event."
+	TimeoutErrorRemediation                = "Make the failed operation complete faster, or increase its timeout if it legitimately needs more time."
+	HeartBeatBlobSizeLimitRemediation      = "Reduce the size of the heartbeat details recorded by the activity so that they stay within the blob size limit of the domain."
+	ActivityOutputBlobSizeLimitRemediation = "Reduce the size of the activity result, for example by storing large payloads outside of Cadence and returning a reference to them."
+	DecisionBlobSizeLimitRemediation       = "Reduce the size of the inputs the workflow passes to its activities, child workflows and signals, " +
+		"for example by storing large payloads outside of Cadence and passing a reference to them."
+)
+
+// Remediation returns the suggested action for an issue with the given reason, reasons are error types
+func Remediation(reason string) string {
+	switch reason {
+	case CustomError.String():
+		return CustomErrorRemediation
+	case GenericError.String():
+		return GenericErrorRemediation
+	case PanicError.String():
+		return PanicErrorRemediation
+	case TimeoutError.String():
+		return TimeoutErrorRemediation
+	case HeartBeatBlobSizeLimit.String():
+		return HeartBeatBlobSizeLimitRemediation
+	case ActivityOutputBlobSizeLimit.String():
+		return ActivityOutputBlobSizeLimitRemediation
+	case DecisionBlobSizeLimit.String():
+		return DecisionBlobSizeLimitRemediation
+	}
+	return ""
+}
+
 type FailureType string
 
 const (
@@ -0,0 +1,174 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package historygrowth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+const _signalStormWindow = time.Minute // sliding window used to detect bursts of signals
+
+// HistoryGrowthInvariant is an invariant that will be used to identify workflows whose history grows towards the history limits
+type HistoryGrowthInvariant invariant.Invariant
+
+type historyGrowth struct {
+	historyCountLimitWarn  dynamicproperties.IntPropertyFnWithDomainFilter
+	historyCountLimitError dynamicproperties.IntPropertyFnWithDomainFilter
+	historySizeLimitWarn   dynamicproperties.IntPropertyFnWithDomainFilter
+	historySizeLimitError  dynamicproperties.IntPropertyFnWithDomainFilter
+	signalStormThreshold   dynamicproperties.IntPropertyFnWithDomainFilter
+	serializer             persistence.PayloadSerializer
+}
+
+type Params struct {
+	HistoryCountLimitWarn  dynamicproperties.IntPropertyFnWithDomainFilter
+	HistoryCountLimitError dynamicproperties.IntPropertyFnWithDomainFilter
+	HistorySizeLimitWarn   dynamicproperties.IntPropertyFnWithDomainFilter
+	HistorySizeLimitError  dynamicproperties.IntPropertyFnWithDomainFilter
+	SignalStormThreshold   dynamicproperties.IntPropertyFnWithDomainFilter
+}
+
+func NewInvariant(p Params) HistoryGrowthInvariant {
+	return &historyGrowth{
+		historyCountLimitWarn:  p.HistoryCountLimitWarn,
+		historyCountLimitError: p.HistoryCountLimitError,
+		historySizeLimitWarn:   p.HistorySizeLimitWarn,
+		historySizeLimitError:  p.HistorySizeLimitError,
+		signalStormThreshold:   p.SignalStormThreshold,
+		serializer:             persistence.NewPayloadSerializer(),
+	}
+}
+
+func (h *historyGrowth) Check(ctx context.Context, params invariant.InvariantCheckInput) ([]invariant.InvariantCheckResult, error) {
+	result := make([]invariant.InvariantCheckResult, 0)
+	events := params.WorkflowExecutionHistory.GetHistory().GetEvents()
+	issueID := 0
+
+	stormMetadata := checkSignalStorm(events)
+	// a threshold of zero or less disables storm detection
+	if threshold := h.stormThreshold(params.Domain); threshold > 0 && stormMetadata.PeakSignalsPerWindow >= threshold {
+		result = append(result, invariant.InvariantCheckResult{
+			IssueID:       issueID,
+			InvariantType: SignalStorm.String(),
+			Reason:        fmt.Sprintf("%d signals received within %v", stormMetadata.PeakSignalsPerWindow, _signalStormWindow),
+			Metadata:      invariant.MarshalData(stormMetadata),
+		})
+		issueID++
+	}
+
+	if invariant.WorkflowClosed(events) {
+		return result, nil
+	}
+	metadata := HistoryGrowthMetadata{
+		HistoryCount:          len(events),
+		HistoryWarnLimit:      limit(h.historyCountLimitWarn, params.Domain),
+		HistoryErrorLimit:     limit(h.historyCountLimitError, params.Domain),
+		HistorySizeWarnLimit:  limit(h.historySizeLimitWarn, params.Domain),
+		HistorySizeErrorLimit: limit(h.historySizeLimitError, params.Domain),
+		SignalCount:           stormMetadata.SignalCount,
+	}
+	var reasons []string
+	if metadata.HistoryWarnLimit > 0 && metadata.HistoryCount >= metadata.HistoryWarnLimit {
+		reasons = append(reasons, fmt.Sprintf("history has %d events, the workflow will be terminated at %d events", metadata.HistoryCount, metadata.HistoryErrorLimit))
+	}
+	if metadata.HistorySizeWarnLimit > 0 {
+		// the history size limits apply to the serialized events, as they are stored by the history service
+		blob, err := h.serializer.SerializeBatchEvents(events, constants.EncodingTypeThriftRW)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize history: %w", err)
+		}
+		metadata.HistorySize = len(blob.Data)
+		if metadata.HistorySize >= metadata.HistorySizeWarnLimit {
+			reasons = append(reasons, fmt.Sprintf("history is %d bytes, the workflow will be terminated at %d bytes", metadata.HistorySize, metadata.HistorySizeErrorLimit))
+		}
+	}
+	if len(reasons) > 0 {
+		result = append(result, invariant.InvariantCheckResult{
+			IssueID:       issueID,
+			InvariantType: HistoryGrowth.String(),
+			Reason:        strings.Join(reasons, ", "),
+			Metadata:      invariant.MarshalData(metadata),
+		})
+		issueID++
+	}
+	return result, nil
+}
+
+func (h *historyGrowth) RootCause(ctx context.Context, params invariant.InvariantRootCauseInput) ([]invariant.InvariantRootCauseResult, error) {
+	// Not implemented since this invariant does not have any root cause.
+	// Issue identified in Check() are the root cause.
+	result := make([]invariant.InvariantRootCauseResult, 0)
+	return result, nil
+}
+
+func (h *historyGrowth) stormThreshold(domain string) int {
+	if h.signalStormThreshold == nil {
+		return dynamicproperties.DiagnosticsSignalStormThreshold.DefaultInt()
+	}
+	return h.signalStormThreshold(domain)
+}
+
+// limit returns the history limit of a domain, zero when the limit is not configured
+func limit(fn dynamicproperties.IntPropertyFnWithDomainFilter, domain string) int {
+	if fn == nil {
+		return 0
+	}
+	return fn(domain)
+}
+
+// checkSignalStorm finds the largest number of signals received within the storm window
+func checkSignalStorm(events []*types.HistoryEvent) SignalStormMetadata {
+	metadata := SignalStormMetadata{
+		Window:      _signalStormWindow,
+		SignalNames: make(map[string]int),
+	}
+	var signals []*types.HistoryEvent
+	for _, event := range events {
+		if attr := event.GetWorkflowExecutionSignaledEventAttributes(); attr != nil {
+			signals = append(signals, event)
+			metadata.SignalNames[attr.GetSignalName()]++
+		}
+	}
+	metadata.SignalCount = len(signals)
+
+	start := 0
+	for end := range signals {
+		endTime := common.Int64Default(signals[end].Timestamp)
+		for endTime-common.Int64Default(signals[start].Timestamp) > _signalStormWindow.Nanoseconds() {
+			start++
+		}
+		if count := end - start + 1; count > metadata.PeakSignalsPerWindow {
+			metadata.PeakSignalsPerWindow = count
+			metadata.PeakWindowStartEvent = signals[start].ID
+		}
+	}
+	return metadata
+}
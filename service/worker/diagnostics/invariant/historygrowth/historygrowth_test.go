@@ -0,0 +1,177 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package historygrowth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+const (
+	testTimeStamp = int64(2547596872371000000)
+	testDomain    = "test-domain"
+)
+
+func Test__Check(t *testing.T) {
+	testCases := []struct {
+		name           string
+		testData       *types.GetWorkflowExecutionHistoryResponse
+		warnLimit      int
+		sizeWarnLimit  int
+		stormThreshold int
+		expectedResult []invariant.InvariantCheckResult
+	}{
+		{
+			name:           "signal storm approaching history limit",
+			testData:       signalHistory(150, time.Millisecond),
+			warnLimit:      100,
+			stormThreshold: 100,
+			expectedResult: []invariant.InvariantCheckResult{
+				{
+					IssueID:       0,
+					InvariantType: SignalStorm.String(),
+					Reason:        "150 signals received within 1m0s",
+					Metadata: invariant.MarshalData(SignalStormMetadata{
+						SignalCount:          150,
+						PeakSignalsPerWindow: 150,
+						Window:               time.Minute,
+						PeakWindowStartEvent: 2,
+						SignalNames:          map[string]int{"signal": 150},
+					}),
+				},
+				{
+					IssueID:       1,
+					InvariantType: HistoryGrowth.String(),
+					Reason:        "history has 151 events, the workflow will be terminated at 1000 events",
+					Metadata: invariant.MarshalData(HistoryGrowthMetadata{
+						HistoryCount:          151,
+						HistoryWarnLimit:      100,
+						HistoryErrorLimit:     1000,
+						HistorySizeErrorLimit: 10000,
+						SignalCount:           150,
+					}),
+				},
+			},
+		},
+		{
+			name:           "storm detection disabled",
+			testData:       signalHistory(150, time.Millisecond),
+			warnLimit:      500,
+			stormThreshold: 0,
+			expectedResult: []invariant.InvariantCheckResult{},
+		},
+		{
+			name:           "history approaching the size limit",
+			testData:       signalHistory(10, time.Second),
+			warnLimit:      500,
+			sizeWarnLimit:  100,
+			stormThreshold: 100,
+			expectedResult: []invariant.InvariantCheckResult{
+				{
+					IssueID:       0,
+					InvariantType: HistoryGrowth.String(),
+					Reason:        fmt.Sprintf("history is %d bytes, the workflow will be terminated at 10000 bytes", historySize(t, signalHistory(10, time.Second))),
+					Metadata: invariant.MarshalData(HistoryGrowthMetadata{
+						HistoryCount:          11,
+						HistoryWarnLimit:      500,
+						HistoryErrorLimit:     1000,
+						HistorySize:           historySize(t, signalHistory(10, time.Second)),
+						HistorySizeWarnLimit:  100,
+						HistorySizeErrorLimit: 10000,
+						SignalCount:           10,
+					}),
+				},
+			},
+		},
+		{
+			name:           "signals spread over time",
+			testData:       signalHistory(150, time.Second),
+			warnLimit:      500,
+			stormThreshold: 100,
+			expectedResult: []invariant.InvariantCheckResult{},
+		},
+		{
+			name:           "signal burst below the domain threshold",
+			testData:       signalHistory(150, time.Millisecond),
+			warnLimit:      500,
+			stormThreshold: 200,
+			expectedResult: []invariant.InvariantCheckResult{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inv := NewInvariant(Params{
+				HistoryCountLimitWarn:  dynamicproperties.GetIntPropertyFilteredByDomain(tc.warnLimit),
+				HistoryCountLimitError: dynamicproperties.GetIntPropertyFilteredByDomain(1000),
+				HistorySizeLimitWarn:   dynamicproperties.GetIntPropertyFilteredByDomain(tc.sizeWarnLimit),
+				HistorySizeLimitError:  dynamicproperties.GetIntPropertyFilteredByDomain(10000),
+				SignalStormThreshold:   dynamicproperties.GetIntPropertyFilteredByDomain(tc.stormThreshold),
+			})
+			result, err := inv.Check(context.Background(), invariant.InvariantCheckInput{
+				WorkflowExecutionHistory: tc.testData,
+				Domain:                   testDomain,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func historySize(t *testing.T, history *types.GetWorkflowExecutionHistoryResponse) int {
+	blob, err := persistence.NewPayloadSerializer().SerializeBatchEvents(history.History.Events, constants.EncodingTypeThriftRW)
+	require.NoError(t, err)
+	return len(blob.Data)
+}
+
+func signalHistory(signals int, interval time.Duration) *types.GetWorkflowExecutionHistoryResponse {
+	events := []*types.HistoryEvent{
+		{
+			ID:                                      1,
+			Timestamp:                               common.Int64Ptr(testTimeStamp),
+			EventType:                               types.EventTypeWorkflowExecutionStarted.Ptr(),
+			WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{},
+		},
+	}
+	for i := 0; i < signals; i++ {
+		events = append(events, &types.HistoryEvent{
+			ID:        int64(i + 2),
+			Timestamp: common.Int64Ptr(testTimeStamp + int64(i)*interval.Nanoseconds()),
+			EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(),
+			WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{
+				SignalName: "signal",
+			},
+		})
+	}
+	return &types.GetWorkflowExecutionHistoryResponse{
+		History: &types.History{Events: events},
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package historygrowth
+
+import "time"
+
+type HistoryIssueType string
+
+const (
+	SignalStorm   HistoryIssueType = "Workflow is receiving a large number of signals in a short period of time"
+	HistoryGrowth HistoryIssueType = "Workflow history is approaching the history limits"
+)
+
+func (h HistoryIssueType) String() string {
+	return string(h)
+}
+
+const (
+	SignalStormRemediation = "Reduce the rate of signals sent to the workflow, batch several updates into a single signal " +
+		"or spread the signals across multiple workflows. Every signal is recorded in the history and counts towards the history limits."
+	HistoryGrowthRemediation = "Use ContinueAsNew to periodically start a new run with a fresh history. " +
+		"The workflow will be terminated once its history exceeds the error limit."
+)
+
+// Remediation returns the suggested action for an issue identified by this invariant
+func Remediation(issueType string) string {
+	switch issueType {
+	case SignalStorm.String():
+		return SignalStormRemediation
+	case HistoryGrowth.String():
+		return HistoryGrowthRemediation
+	}
+	return ""
+}
+
+type SignalStormMetadata struct {
+	SignalCount          int
+	PeakSignalsPerWindow int
+	Window               time.Duration
+	PeakWindowStartEvent int64
+	SignalNames          map[string]int
+}
+
+type HistoryGrowthMetadata struct {
+	HistoryCount          int
+	HistoryWarnLimit      int
+	HistoryErrorLimit     int
+	HistorySize           int
+	HistorySizeWarnLimit  int
+	HistorySizeErrorLimit int
+	SignalCount           int
+}
+
+type HistoryGrowthIssuesMetadata struct {
+	SignalStorm   *SignalStormMetadata
+	HistoryGrowth *HistoryGrowthMetadata
+}
@@ -66,6 +66,10 @@ const (
 	RootCauseTypeServiceSidePanic                      RootCause = "There is a panic in the activity/workflow that is causing a failure"
 	RootCauseTypeServiceSideCustomError                RootCause = "Customised error returned by the activity/workflow"
 	RootCauseTypeBlobSizeLimit                         RootCause = "Workflow has exceeded the blob size limits configured for the domain"
+	RootCauseTypeDecisionMissingPollers                RootCause = "There are no workers polling the decision tasklist"
+	RootCauseTypeDecisionPollersStatus                 RootCause = "There are workers polling the decision tasklist but the decision has not been picked up. Check worker health and backlog"
+	RootCauseTypeNonDeterministicDeployment            RootCause = "Decision tasks started failing after a new worker binary was deployed"
+	RootCauseTypeNonDeterministicWorkflowCode          RootCause = "Workflow code is not deterministic when replaying the existing history"
 )
 
 func (r RootCause) String() string {
@@ -82,3 +86,20 @@ func MarshalData(rc any) []byte {
 	data, _ := json.Marshal(rc)
 	return data
 }
+
+// WorkflowClosed returns true if the history ends with a workflow close event
+func WorkflowClosed(events []*types.HistoryEvent) bool {
+	if len(events) == 0 {
+		return false
+	}
+	switch events[len(events)-1].GetEventType() {
+	case types.EventTypeWorkflowExecutionCompleted,
+		types.EventTypeWorkflowExecutionFailed,
+		types.EventTypeWorkflowExecutionTimedOut,
+		types.EventTypeWorkflowExecutionCanceled,
+		types.EventTypeWorkflowExecutionTerminated,
+		types.EventTypeWorkflowExecutionContinuedAsNew:
+		return true
+	}
+	return false
+}
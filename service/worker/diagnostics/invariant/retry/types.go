@@ -48,6 +48,26 @@ func (i IssueType) String() string {
 	return string(i)
 }
 
+const (
+	MaxAttemptsRemediation      = "Set MaximumAttempts above 1 to allow retries, or to 0 to retry until the expiration interval."
+	ExpIntervalRemediation      = "Set ExpirationIntervalInSeconds greater than InitialIntervalInSeconds, or to 0 to retry until the maximum attempts are reached."
+	HeartBeatTimeoutRemediation = "Set the heartbeat timeout lower than the start to close timeout of the activity, " +
+		"so that a worker which stopped heartbeating is detected before the activity times out."
+)
+
+// Remediation returns the suggested action for an issue with the given reason, reasons are issue types
+func Remediation(reason string) string {
+	switch reason {
+	case RetryPolicyValidationMaxAttempts.String():
+		return MaxAttemptsRemediation
+	case RetryPolicyValidationExpInterval.String():
+		return ExpIntervalRemediation
+	case HeartBeatTimeoutEqualToStartToCloseTimeout.String():
+		return HeartBeatTimeoutRemediation
+	}
+	return ""
+}
+
 type RetryMetadata struct {
 	EventID     int64
 	RetryPolicy *types.RetryPolicy
@@ -41,6 +41,32 @@ func (tt TimeoutType) String() string {
 	return string(tt)
 }
 
+const (
+	ExecutionTimeoutRemediation = "Check the last ongoing event to see what the workflow was waiting for when it timed out. " +
+		"If the workflow legitimately needs more time, increase its execution start to close timeout or use ContinueAsNew to split the work across runs."
+	ActivityTimeoutRemediation = "Make sure workers are running and polling the activity tasklist. " +
+		"Long running activities should heartbeat with a heartbeat timeout configured, and have a retry policy so that timed out attempts are retried."
+	DecisionTimeoutRemediation = "Make sure decision tasks complete within their start to close timeout: avoid blocking calls and heavy computation in workflow code " +
+		"and check that workers are not overloaded."
+	ChildWorkflowTimeoutRemediation = "Diagnose the child workflow to find out why it did not complete in time. " +
+		"If it legitimately needs more time, increase the execution start to close timeout the parent sets for it."
+)
+
+// Remediation returns the suggested action for an issue identified by this invariant
+func Remediation(issueType string) string {
+	switch issueType {
+	case TimeoutTypeExecution.String():
+		return ExecutionTimeoutRemediation
+	case TimeoutTypeActivity.String():
+		return ActivityTimeoutRemediation
+	case TimeoutTypeDecision.String():
+		return DecisionTimeoutRemediation
+	case TimeoutTypeChildWorkflow.String():
+		return ChildWorkflowTimeoutRemediation
+	}
+	return ""
+}
+
 type ExecutionTimeoutMetadata struct {
 	ExecutionTime     time.Duration
 	ConfiguredTimeout time.Duration
@@ -35,6 +35,7 @@ import (
 	"github.com/uber/cadence/client"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
@@ -56,6 +57,8 @@ type dw struct {
 	worker          worker.Worker
 	invariants      []invariant.Invariant
 	clusterMetadata cluster.Metadata
+
+	historyEventLinkTemplate dynamicproperties.StringPropertyFnWithDomainFilter
 }
 
 type Params struct {
@@ -67,6 +70,8 @@ type Params struct {
 	TallyScope      tally.Scope
 	Invariants      []invariant.Invariant
 	ClusterMetadata cluster.Metadata
+
+	HistoryEventLinkTemplate dynamicproperties.StringPropertyFnWithDomainFilter
 }
 
 // New creates a new diagnostics workflow.
@@ -80,6 +85,8 @@ func New(params Params) DiagnosticsWorkflow {
 		logger:          params.Logger,
 		invariants:      params.Invariants,
 		clusterMetadata: params.ClusterMetadata,
+
+		historyEventLinkTemplate: params.HistoryEventLinkTemplate,
 	}
 }
 
@@ -40,6 +40,9 @@ const (
 	issueTypeTimeouts = "Timeout"
 	issueTypeFailures = "Failure"
 	issueTypeRetry    = "Retry"
+	issueTypeDecision = "Decision"
+	issueTypeHistory  = "HistoryGrowth"
+	issueTypeChild    = "ChildWorkflow"
 )
 
 type DiagnosticsStarterWorkflowInput struct {
@@ -65,6 +68,18 @@ func (w *dw) DiagnosticsStarterWorkflow(ctx workflow.Context, params Diagnostics
 	if err != nil {
 		return nil, err
 	}
+	err = workflow.SetQueryHandler(ctx, QueryDiagnosticsReportJSON, func() (*DiagnosticsReport, error) {
+		return newDiagnosticsReport(params, workflowResult, w.historyEventLinkTemplate(params.Domain)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = workflow.SetQueryHandler(ctx, QueryDiagnosticsReportMarkdown, func() (string, error) {
+		return RenderMarkdown(newDiagnosticsReport(params, workflowResult, w.historyEventLinkTemplate(params.Domain))), nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	future := workflow.ExecuteChildWorkflow(ctx, w.DiagnosticsWorkflow, DiagnosticsWorkflowInput{
 		Domain:     params.Domain,
@@ -126,5 +141,14 @@ func getIssueType(result DiagnosticsWorkflowResult) string {
 	if result.Retries != nil {
 		issueType = fmt.Sprintf("%s-%s", issueType, issueTypeRetry)
 	}
+	if result.Decisions != nil {
+		issueType = fmt.Sprintf("%s-%s", issueType, issueTypeDecision)
+	}
+	if result.HistoryGrowth != nil {
+		issueType = fmt.Sprintf("%s-%s", issueType, issueTypeHistory)
+	}
+	if result.ChildWorkflows != nil {
+		issueType = fmt.Sprintf("%s-%s", issueType, issueTypeChild)
+	}
 	return issueType
 }
@@ -0,0 +1,316 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package diagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+)
+
+// The diagnostics report is served by queries on the diagnostics starter workflow in the system domain,
+// fetched through the QueryWorkflow frontend API with the ID from DiagnosticsWorkflowID.
+// There is no dedicated frontend endpoint for it, as the frontend API is defined by the cadence-idl
+// module; these query types are the supported way to fetch a report until it has one.
+const (
+	// QueryDiagnosticsReportJSON is the query type returning the structured diagnostics report
+	QueryDiagnosticsReportJSON = "query-diagnostics-report-json"
+	// QueryDiagnosticsReportMarkdown is the query type returning the diagnostics report rendered as Markdown
+	QueryDiagnosticsReportMarkdown = "query-diagnostics-report-markdown"
+)
+
+// DiagnosticsWorkflowID returns the ID of the diagnostics workflow started for a workflow execution
+func DiagnosticsWorkflowID(domain, runID string) string {
+	return fmt.Sprintf("%s-%s", domain, runID)
+}
+
+// DiagnosticsReport is the structured report of a diagnosed workflow execution
+type DiagnosticsReport struct {
+	Domain     string         `json:"domain"`
+	WorkflowID string         `json:"workflowId"`
+	RunID      string         `json:"runId"`
+	Completed  bool           `json:"completed"`
+	Issues     []*ReportIssue `json:"issues"`
+}
+
+// ReportIssue is an issue identified by one of the invariants together with its root causes
+type ReportIssue struct {
+	Category      string             `json:"category"`
+	IssueID       int                `json:"issueId"`
+	InvariantType string             `json:"invariantType"`
+	Reason        string             `json:"reason"`
+	Events        []*ReportEvent     `json:"events,omitempty"`
+	RootCauses    []*ReportRootCause `json:"rootCauses,omitempty"`
+	Remediation   string             `json:"remediation,omitempty"`
+	Runbook       string             `json:"runbook,omitempty"`
+	Metadata      json.RawMessage    `json:"metadata,omitempty"`
+}
+
+// ReportEvent is a history event related to an issue
+type ReportEvent struct {
+	EventID int64 `json:"eventId"`
+	// Link opens the event in the workflow history, it is only set when a history event link template is configured
+	Link string `json:"link,omitempty"`
+}
+
+// ReportRootCause is a root cause of an issue
+type ReportRootCause struct {
+	RootCause string          `json:"rootCause"`
+	Metadata  json.RawMessage `json:"metadata,omitempty"`
+}
+
+// newDiagnosticsReport builds the report of a diagnosed workflow execution. Related history events are linked with
+// linkTemplate, where {domain}, {workflowID}, {runID} and {eventID} are replaced by the values of the event.
+func newDiagnosticsReport(params DiagnosticsStarterWorkflowInput, result DiagnosticsStarterWorkflowResult, linkTemplate string) *DiagnosticsReport {
+	report := &DiagnosticsReport{
+		Domain:     params.Domain,
+		WorkflowID: params.WorkflowID,
+		RunID:      params.RunID,
+		Completed:  result.DiagnosticsCompleted,
+		Issues:     make([]*ReportIssue, 0),
+	}
+	diag := result.DiagnosticsResult
+	if diag == nil {
+		return report
+	}
+	events := func(ids []int64) []*ReportEvent {
+		return historyEvents(linkTemplate, params, ids)
+	}
+
+	if diag.Timeouts != nil {
+		for _, issue := range diag.Timeouts.Issues {
+			var eventIDs []int64
+			if issue.Metadata != nil && issue.Metadata.ExecutionTimeout != nil && issue.Metadata.ExecutionTimeout.LastOngoingEvent != nil {
+				eventIDs = append(eventIDs, issue.Metadata.ExecutionTimeout.LastOngoingEvent.ID)
+			}
+			reportIssue := &ReportIssue{
+				Category:      issueTypeTimeouts,
+				IssueID:       issue.IssueID,
+				InvariantType: issue.InvariantType,
+				Reason:        issue.Reason,
+				Events:        events(eventIDs),
+				Remediation:   issue.Remediation,
+				Runbook:       diag.Timeouts.Runbook,
+				Metadata:      invariant.MarshalData(issue.Metadata),
+			}
+			for _, rc := range diag.Timeouts.RootCause {
+				if rc.IssueID == issue.IssueID {
+					reportIssue.RootCauses = append(reportIssue.RootCauses, &ReportRootCause{RootCause: rc.RootCauseType, Metadata: invariant.MarshalData(rc.Metadata)})
+				}
+			}
+			report.Issues = append(report.Issues, reportIssue)
+		}
+	}
+
+	if diag.Failures != nil {
+		for _, issue := range diag.Failures.Issues {
+			var eventIDs []int64
+			if issue.Metadata != nil {
+				eventIDs = nonZeroEventIDs(issue.Metadata.ActivityScheduledID, issue.Metadata.ActivityStartedID)
+			}
+			reportIssue := &ReportIssue{
+				Category:      issueTypeFailures,
+				IssueID:       issue.IssueID,
+				InvariantType: issue.InvariantType,
+				Reason:        issue.Reason,
+				Events:        events(eventIDs),
+				Remediation:   issue.Remediation,
+				Runbook:       diag.Failures.Runbook,
+				Metadata:      invariant.MarshalData(issue.Metadata),
+			}
+			for _, rc := range diag.Failures.RootCause {
+				if rc.IssueID != issue.IssueID {
+					continue
+				}
+				var metadata json.RawMessage
+				if rc.Metadata != nil {
+					metadata = invariant.MarshalData(rc.Metadata)
+				}
+				reportIssue.RootCauses = append(reportIssue.RootCauses, &ReportRootCause{RootCause: rc.RootCauseType, Metadata: metadata})
+			}
+			report.Issues = append(report.Issues, reportIssue)
+		}
+	}
+
+	if diag.Retries != nil {
+		for _, issue := range diag.Retries.Issues {
+			report.Issues = append(report.Issues, &ReportIssue{
+				Category:      issueTypeRetry,
+				IssueID:       issue.IssueID,
+				InvariantType: issue.InvariantType,
+				Reason:        issue.Reason,
+				Events:        events(nonZeroEventIDs(issue.Metadata.EventID)),
+				Remediation:   issue.Remediation,
+				Runbook:       diag.Retries.Runbook,
+				Metadata:      invariant.MarshalData(issue.Metadata),
+			})
+		}
+	}
+
+	if diag.Decisions != nil {
+		for _, issue := range diag.Decisions.Issues {
+			var eventIDs []int64
+			if issue.Metadata != nil && issue.Metadata.PendingDecision != nil {
+				eventIDs = nonZeroEventIDs(issue.Metadata.PendingDecision.ScheduledEventID)
+			}
+			if issue.Metadata != nil && issue.Metadata.NonDeterminism != nil {
+				eventIDs = nonZeroEventIDs(issue.Metadata.NonDeterminism.LastFailedEventID)
+			}
+			reportIssue := &ReportIssue{
+				Category:      issueTypeDecision,
+				IssueID:       issue.IssueID,
+				InvariantType: issue.InvariantType,
+				Reason:        issue.Reason,
+				Events:        events(eventIDs),
+				Remediation:   issue.Remediation,
+				Metadata:      invariant.MarshalData(issue.Metadata),
+			}
+			for _, rc := range diag.Decisions.RootCause {
+				if rc.IssueID == issue.IssueID {
+					reportIssue.RootCauses = append(reportIssue.RootCauses, &ReportRootCause{RootCause: rc.RootCauseType, Metadata: invariant.MarshalData(rc.Metadata)})
+				}
+			}
+			report.Issues = append(report.Issues, reportIssue)
+		}
+	}
+
+	if diag.HistoryGrowth != nil {
+		for _, issue := range diag.HistoryGrowth.Issues {
+			var eventIDs []int64
+			if issue.Metadata != nil && issue.Metadata.SignalStorm != nil {
+				eventIDs = nonZeroEventIDs(issue.Metadata.SignalStorm.PeakWindowStartEvent)
+			}
+			report.Issues = append(report.Issues, &ReportIssue{
+				Category:      issueTypeHistory,
+				IssueID:       issue.IssueID,
+				InvariantType: issue.InvariantType,
+				Reason:        issue.Reason,
+				Events:        events(eventIDs),
+				Remediation:   issue.Remediation,
+				Metadata:      invariant.MarshalData(issue.Metadata),
+			})
+		}
+	}
+
+	if diag.ChildWorkflows != nil {
+		for _, issue := range diag.ChildWorkflows.Issues {
+			var eventIDs []int64
+			if issue.Metadata != nil {
+				for _, child := range issue.Metadata.PendingChildren {
+					eventIDs = append(eventIDs, child.InitiatedEventID)
+				}
+			}
+			report.Issues = append(report.Issues, &ReportIssue{
+				Category:      issueTypeChild,
+				IssueID:       issue.IssueID,
+				InvariantType: issue.InvariantType,
+				Reason:        issue.Reason,
+				Events:        events(eventIDs),
+				Remediation:   issue.Remediation,
+				Metadata:      invariant.MarshalData(issue.Metadata),
+			})
+		}
+	}
+	return report
+}
+
+// RenderMarkdown renders the diagnostics report as Markdown so that it can be posted into tickets or chats
+func RenderMarkdown(report *DiagnosticsReport) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Diagnostics report\n\n")
+	fmt.Fprintf(&sb, "- **Domain:** `%s`\n", report.Domain)
+	fmt.Fprintf(&sb, "- **Workflow ID:** `%s`\n", report.WorkflowID)
+	fmt.Fprintf(&sb, "- **Run ID:** `%s`\n", report.RunID)
+
+	if !report.Completed {
+		sb.WriteString("\nDiagnostics is still running, the report below may be incomplete.\n")
+	}
+	if len(report.Issues) == 0 {
+		sb.WriteString("\nNo issues were identified.\n")
+		return sb.String()
+	}
+
+	for _, issue := range report.Issues {
+		fmt.Fprintf(&sb, "\n## %s: %s\n\n", issue.Category, issue.InvariantType)
+		if issue.Reason != "" {
+			fmt.Fprintf(&sb, "**Reason:** %s\n\n", issue.Reason)
+		}
+		if len(issue.Events) > 0 {
+			events := make([]string, 0, len(issue.Events))
+			for _, event := range issue.Events {
+				if event.Link != "" {
+					events = append(events, fmt.Sprintf("[#%d](%s)", event.EventID, event.Link))
+				} else {
+					events = append(events, fmt.Sprintf("#%d", event.EventID))
+				}
+			}
+			fmt.Fprintf(&sb, "**History events:** %s\n\n", strings.Join(events, ", "))
+		}
+		if len(issue.RootCauses) > 0 {
+			sb.WriteString("**Root cause:**\n\n")
+			for _, rc := range issue.RootCauses {
+				fmt.Fprintf(&sb, "- %s\n", rc.RootCause)
+			}
+			sb.WriteString("\n")
+		}
+		if issue.Remediation != "" {
+			fmt.Fprintf(&sb, "**Remediation:** %s\n\n", issue.Remediation)
+		}
+		if issue.Runbook != "" {
+			fmt.Fprintf(&sb, "**Runbook:** %s\n\n", issue.Runbook)
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+func nonZeroEventIDs(ids ...int64) []int64 {
+	var result []int64
+	for _, id := range ids {
+		if id != 0 {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// historyEvents links the history events of the diagnosed workflow, events are not linked when linkTemplate is empty
+func historyEvents(linkTemplate string, params DiagnosticsStarterWorkflowInput, ids []int64) []*ReportEvent {
+	if linkTemplate != "" {
+		linkTemplate = strings.NewReplacer(
+			"{domain}", url.PathEscape(params.Domain),
+			"{workflowID}", url.PathEscape(params.WorkflowID),
+			"{runID}", url.PathEscape(params.RunID),
+		).Replace(linkTemplate)
+	}
+	var events []*ReportEvent
+	for _, id := range ids {
+		event := &ReportEvent{EventID: id}
+		if linkTemplate != "" {
+			event.Link = strings.ReplaceAll(linkTemplate, "{eventID}", strconv.FormatInt(id, 10))
+		}
+		events = append(events, event)
+	}
+	return events
+}
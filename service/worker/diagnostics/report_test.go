@@ -0,0 +1,161 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/decision"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/failure"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/retry"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/timeout"
+)
+
+func Test__newDiagnosticsReport(t *testing.T) {
+	input := DiagnosticsStarterWorkflowInput{Domain: "test-domain", WorkflowID: "wid", RunID: "rid"}
+	result := DiagnosticsStarterWorkflowResult{
+		DiagnosticsCompleted: true,
+		DiagnosticsResult: &DiagnosticsWorkflowResult{
+			Timeouts: &timeoutDiagnostics{
+				Issues: []*timeoutIssuesResult{
+					{
+						IssueID:       0,
+						InvariantType: timeout.TimeoutTypeExecution.String(),
+						Reason:        "START_TO_CLOSE",
+						Remediation:   timeout.ExecutionTimeoutRemediation,
+						Metadata: &timeout.TimeoutIssuesMetadata{
+							ExecutionTimeout: &timeout.ExecutionTimeoutMetadata{LastOngoingEvent: &types.HistoryEvent{ID: 7}},
+						},
+					},
+				},
+				RootCause: []*timeoutRootCauseResult{
+					{IssueID: 0, RootCauseType: invariant.RootCauseTypeMissingPollers.String()},
+					{IssueID: 1, RootCauseType: invariant.RootCauseTypePollersStatus.String()},
+				},
+				Runbook: linkToTimeoutsRunbook,
+			},
+			Failures: &failureDiagnostics{
+				Issues: []*failureIssuesResult{
+					{
+						IssueID:       0,
+						InvariantType: failure.ActivityFailed.String(),
+						Reason:        failure.PanicError.String(),
+						Remediation:   failure.PanicErrorRemediation,
+						Metadata:      &failure.FailureIssuesMetadata{ActivityScheduledID: 5, ActivityStartedID: 6},
+					},
+				},
+				RootCause: []*failureRootCauseResult{
+					{IssueID: 0, RootCauseType: invariant.RootCauseTypeServiceSidePanic.String()},
+				},
+				Runbook: linkToFailuresRunbook,
+			},
+			Retries: &retryDiagnostics{
+				Issues: []*retryIssuesResult{
+					{
+						IssueID:       0,
+						InvariantType: retry.WorkflowRetryIssue.String(),
+						Reason:        retry.RetryPolicyValidationMaxAttempts.String(),
+						Remediation:   retry.MaxAttemptsRemediation,
+						Metadata:      retry.RetryMetadata{EventID: 1},
+					},
+				},
+				Runbook: linkToRetriesRunbook,
+			},
+			Decisions: &decisionDiagnostics{
+				Issues: []*decisionIssuesResult{
+					{
+						IssueID:       0,
+						InvariantType: decision.DecisionTaskPending.String(),
+						Reason:        "pending",
+						Remediation:   decision.PendingDecisionRemediation,
+						Metadata: &decision.DecisionIssuesMetadata{
+							PendingDecision: &decision.PendingDecisionMetadata{ScheduledEventID: 9},
+						},
+					},
+				},
+				RootCause: []*decisionRootCauseResult{
+					{IssueID: 0, RootCauseType: invariant.RootCauseTypeDecisionMissingPollers.String()},
+				},
+			},
+		},
+	}
+
+	report := newDiagnosticsReport(input, result, "")
+	require.Len(t, report.Issues, 4)
+	assert.Equal(t, "test-domain", report.Domain)
+	assert.True(t, report.Completed)
+
+	assert.Equal(t, issueTypeTimeouts, report.Issues[0].Category)
+	assert.Equal(t, []*ReportEvent{{EventID: 7}}, report.Issues[0].Events)
+	assert.Equal(t, timeout.ExecutionTimeoutRemediation, report.Issues[0].Remediation)
+	require.Len(t, report.Issues[0].RootCauses, 1)
+	assert.Equal(t, invariant.RootCauseTypeMissingPollers.String(), report.Issues[0].RootCauses[0].RootCause)
+	assert.Equal(t, linkToTimeoutsRunbook, report.Issues[0].Runbook)
+
+	assert.Equal(t, issueTypeFailures, report.Issues[1].Category)
+	assert.Equal(t, []*ReportEvent{{EventID: 5}, {EventID: 6}}, report.Issues[1].Events)
+	assert.Equal(t, failure.PanicErrorRemediation, report.Issues[1].Remediation)
+	require.Len(t, report.Issues[1].RootCauses, 1)
+	assert.Nil(t, report.Issues[1].RootCauses[0].Metadata)
+
+	assert.Equal(t, issueTypeRetry, report.Issues[2].Category)
+	assert.Equal(t, []*ReportEvent{{EventID: 1}}, report.Issues[2].Events)
+	assert.Equal(t, retry.MaxAttemptsRemediation, report.Issues[2].Remediation)
+
+	assert.Equal(t, issueTypeDecision, report.Issues[3].Category)
+	assert.Equal(t, []*ReportEvent{{EventID: 9}}, report.Issues[3].Events)
+	assert.Equal(t, decision.PendingDecisionRemediation, report.Issues[3].Remediation)
+
+	markdown := RenderMarkdown(report)
+	assert.Contains(t, markdown, "- **Workflow ID:** `wid`")
+	assert.Contains(t, markdown, "## Decision: "+decision.DecisionTaskPending.String())
+	assert.Contains(t, markdown, "**History events:** #5, #6")
+	assert.Contains(t, markdown, "- "+invariant.RootCauseTypeDecisionMissingPollers.String())
+	assert.Contains(t, markdown, "**Remediation:** "+decision.PendingDecisionRemediation)
+	assert.Contains(t, markdown, "**Runbook:** "+linkToRetriesRunbook)
+	assert.NotContains(t, markdown, "still running")
+}
+
+func Test__RenderMarkdown_NoIssues(t *testing.T) {
+	report := newDiagnosticsReport(DiagnosticsStarterWorkflowInput{Domain: "test-domain", WorkflowID: "wid", RunID: "rid"}, DiagnosticsStarterWorkflowResult{}, "")
+	assert.Equal(t, "# Diagnostics report\n\n"+
+		"- **Domain:** `test-domain`\n"+
+		"- **Workflow ID:** `wid`\n"+
+		"- **Run ID:** `rid`\n\n"+
+		"Diagnostics is still running, the report below may be incomplete.\n\n"+
+		"No issues were identified.\n", RenderMarkdown(report))
+}
+
+func Test__historyEvents(t *testing.T) {
+	input := DiagnosticsStarterWorkflowInput{Domain: "test-domain", WorkflowID: "order/42", RunID: "rid"}
+
+	assert.Nil(t, historyEvents("https://cadence.example.com/{eventID}", input, nil))
+	assert.Equal(t, []*ReportEvent{{EventID: 3}}, historyEvents("", input, []int64{3}))
+	assert.Equal(t, []*ReportEvent{
+		{EventID: 3, Link: "https://cadence.example.com/domains/test-domain/workflows/order%2F42/rid/history?eventId=3"},
+		{EventID: 12, Link: "https://cadence.example.com/domains/test-domain/workflows/order%2F42/rid/history?eventId=12"},
+	}, historyEvents("https://cadence.example.com/domains/{domain}/workflows/{workflowID}/{runID}/history?eventId={eventID}", input, []int64{3, 12}))
+}
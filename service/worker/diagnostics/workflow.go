@@ -32,7 +32,10 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/child"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/decision"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/failure"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/historygrowth"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/retry"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/timeout"
 )
@@ -55,6 +58,10 @@ type DiagnosticsWorkflowResult struct {
 	Timeouts *timeoutDiagnostics
 	Failures *failureDiagnostics
 	Retries  *retryDiagnostics
+
+	Decisions      *decisionDiagnostics
+	HistoryGrowth  *historyGrowthDiagnostics
+	ChildWorkflows *childWorkflowDiagnostics
 }
 
 type timeoutDiagnostics struct {
@@ -67,6 +74,7 @@ type timeoutIssuesResult struct {
 	IssueID       int
 	InvariantType string
 	Reason        string
+	Remediation   string
 	Metadata      *timeout.TimeoutIssuesMetadata
 }
 
@@ -86,6 +94,7 @@ type failureIssuesResult struct {
 	IssueID       int
 	InvariantType string
 	Reason        string
+	Remediation   string
 	Metadata      *failure.FailureIssuesMetadata
 }
 
@@ -104,9 +113,53 @@ type retryIssuesResult struct {
 	IssueID       int
 	InvariantType string
 	Reason        string
+	Remediation   string
 	Metadata      retry.RetryMetadata
 }
 
+type decisionDiagnostics struct {
+	Issues    []*decisionIssuesResult
+	RootCause []*decisionRootCauseResult
+}
+
+type decisionIssuesResult struct {
+	IssueID       int
+	InvariantType string
+	Reason        string
+	Remediation   string
+	Metadata      *decision.DecisionIssuesMetadata
+}
+
+type decisionRootCauseResult struct {
+	IssueID       int
+	RootCauseType string
+	Metadata      *decision.DecisionRootCauseMetadata
+}
+
+type historyGrowthDiagnostics struct {
+	Issues []*historyGrowthIssuesResult
+}
+
+type historyGrowthIssuesResult struct {
+	IssueID       int
+	InvariantType string
+	Reason        string
+	Remediation   string
+	Metadata      *historygrowth.HistoryGrowthIssuesMetadata
+}
+
+type childWorkflowDiagnostics struct {
+	Issues []*childWorkflowIssuesResult
+}
+
+type childWorkflowIssuesResult struct {
+	IssueID       int
+	InvariantType string
+	Reason        string
+	Remediation   string
+	Metadata      *child.ChildWorkflowMetadata
+}
+
 func (w *dw) DiagnosticsWorkflow(ctx workflow.Context, params DiagnosticsWorkflowInput) (*DiagnosticsWorkflowResult, error) {
 	scope := w.metricsClient.Scope(metrics.DiagnosticsWorkflowScope, metrics.DomainTag(params.Domain))
 	scope.IncCounter(metrics.DiagnosticsWorkflowStartedCount)
@@ -120,6 +173,9 @@ func (w *dw) DiagnosticsWorkflow(ctx workflow.Context, params DiagnosticsWorkflo
 	var timeoutsResult *timeoutDiagnostics
 	var failureResult *failureDiagnostics
 	var retryResult *retryDiagnostics
+	var decisionResult *decisionDiagnostics
+	var historyGrowthResult *historyGrowthDiagnostics
+	var childWorkflowResult *childWorkflowDiagnostics
 	var checkResult []invariant.InvariantCheckResult
 	var rootCauseResult []invariant.InvariantRootCauseResult
 
@@ -195,11 +251,52 @@ func (w *dw) DiagnosticsWorkflow(ctx workflow.Context, params DiagnosticsWorkflo
 		}
 	}
 
+	decisionIssues, err := retrieveDecisionIssues(checkResult)
+	if err != nil {
+		return nil, fmt.Errorf("RetrieveDecisionIssues: %w", err)
+	}
+
+	if len(decisionIssues) > 0 {
+		decisionRootCause, err := retrieveDecisionRootCause(rootCauseResult)
+		if err != nil {
+			return nil, fmt.Errorf("RetrieveDecisionRootCause: %w", err)
+		}
+		decisionResult = &decisionDiagnostics{
+			Issues:    decisionIssues,
+			RootCause: decisionRootCause,
+		}
+	}
+
+	historyGrowthIssues, err := retrieveHistoryGrowthIssues(checkResult)
+	if err != nil {
+		return nil, fmt.Errorf("RetrieveHistoryGrowthIssues: %w", err)
+	}
+
+	if len(historyGrowthIssues) > 0 {
+		historyGrowthResult = &historyGrowthDiagnostics{
+			Issues: historyGrowthIssues,
+		}
+	}
+
+	childWorkflowIssues, err := retrieveChildWorkflowIssues(checkResult)
+	if err != nil {
+		return nil, fmt.Errorf("RetrieveChildWorkflowIssues: %w", err)
+	}
+
+	if len(childWorkflowIssues) > 0 {
+		childWorkflowResult = &childWorkflowDiagnostics{
+			Issues: childWorkflowIssues,
+		}
+	}
+
 	scope.IncCounter(metrics.DiagnosticsWorkflowSuccess)
 	return &DiagnosticsWorkflowResult{
-		Timeouts: timeoutsResult,
-		Failures: failureResult,
-		Retries:  retryResult,
+		Timeouts:       timeoutsResult,
+		Failures:       failureResult,
+		Retries:        retryResult,
+		Decisions:      decisionResult,
+		HistoryGrowth:  historyGrowthResult,
+		ChildWorkflows: childWorkflowResult,
 	}, nil
 }
 
@@ -217,6 +314,7 @@ func retrieveTimeoutIssues(issues []invariant.InvariantCheckResult) ([]*timeoutI
 				IssueID:       issue.IssueID,
 				InvariantType: issue.InvariantType,
 				Reason:        issue.Reason,
+				Remediation:   timeout.Remediation(issue.InvariantType),
 				Metadata: &timeout.TimeoutIssuesMetadata{
 					ExecutionTimeout: &metadata,
 				},
@@ -231,6 +329,7 @@ func retrieveTimeoutIssues(issues []invariant.InvariantCheckResult) ([]*timeoutI
 				IssueID:       issue.IssueID,
 				InvariantType: issue.InvariantType,
 				Reason:        issue.Reason,
+				Remediation:   timeout.Remediation(issue.InvariantType),
 				Metadata: &timeout.TimeoutIssuesMetadata{
 					ActivityTimeout: &metadata,
 				},
@@ -245,6 +344,7 @@ func retrieveTimeoutIssues(issues []invariant.InvariantCheckResult) ([]*timeoutI
 				IssueID:       issue.IssueID,
 				InvariantType: issue.InvariantType,
 				Reason:        issue.Reason,
+				Remediation:   timeout.Remediation(issue.InvariantType),
 				Metadata: &timeout.TimeoutIssuesMetadata{
 					ChildWfTimeout: &metadata,
 				},
@@ -259,6 +359,7 @@ func retrieveTimeoutIssues(issues []invariant.InvariantCheckResult) ([]*timeoutI
 				IssueID:       issue.IssueID,
 				InvariantType: issue.InvariantType,
 				Reason:        issue.Reason,
+				Remediation:   timeout.Remediation(issue.InvariantType),
 				Metadata: &timeout.TimeoutIssuesMetadata{
 					DecisionTimeout: &metadata,
 				},
@@ -316,6 +417,7 @@ func retrieveFailureIssues(issues []invariant.InvariantCheckResult) ([]*failureI
 				IssueID:       issue.IssueID,
 				InvariantType: issue.InvariantType,
 				Reason:        issue.Reason,
+				Remediation:   failure.Remediation(issue.Reason),
 				Metadata:      &data,
 			})
 		}
@@ -363,6 +465,7 @@ func retrieveRetryIssues(issues []invariant.InvariantCheckResult) ([]*retryIssue
 				IssueID:       issue.IssueID,
 				InvariantType: issue.InvariantType,
 				Reason:        issue.Reason,
+				Remediation:   retry.Remediation(issue.Reason),
 				Metadata:      data,
 			})
 		}
@@ -370,6 +473,124 @@ func retrieveRetryIssues(issues []invariant.InvariantCheckResult) ([]*retryIssue
 	return result, nil
 }
 
+func retrieveDecisionIssues(issues []invariant.InvariantCheckResult) ([]*decisionIssuesResult, error) {
+	result := make([]*decisionIssuesResult, 0)
+	for _, issue := range issues {
+		var metadata decision.DecisionIssuesMetadata
+		switch issue.InvariantType {
+		case decision.DecisionTaskPending.String():
+			var data decision.PendingDecisionMetadata
+			err := json.Unmarshal(issue.Metadata, &data)
+			if err != nil {
+				return nil, err
+			}
+			metadata.PendingDecision = &data
+		case decision.DecisionTaskNonDeterminism.String():
+			var data decision.NonDeterminismMetadata
+			err := json.Unmarshal(issue.Metadata, &data)
+			if err != nil {
+				return nil, err
+			}
+			metadata.NonDeterminism = &data
+		default:
+			continue
+		}
+		result = append(result, &decisionIssuesResult{
+			IssueID:       issue.IssueID,
+			InvariantType: issue.InvariantType,
+			Reason:        issue.Reason,
+			Remediation:   decision.Remediation(issue.InvariantType),
+			Metadata:      &metadata,
+		})
+	}
+	return result, nil
+}
+
+func retrieveDecisionRootCause(rootCause []invariant.InvariantRootCauseResult) ([]*decisionRootCauseResult, error) {
+	result := make([]*decisionRootCauseResult, 0)
+	for _, rc := range rootCause {
+		if rootCauseDecisionRelated(rc.RootCause) {
+			var metadata decision.DecisionRootCauseMetadata
+			err := json.Unmarshal(rc.Metadata, &metadata)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, &decisionRootCauseResult{
+				IssueID:       rc.IssueID,
+				RootCauseType: rc.RootCause.String(),
+				Metadata:      &metadata,
+			})
+		}
+	}
+	return result, nil
+}
+
+func retrieveHistoryGrowthIssues(issues []invariant.InvariantCheckResult) ([]*historyGrowthIssuesResult, error) {
+	result := make([]*historyGrowthIssuesResult, 0)
+	for _, issue := range issues {
+		var metadata historygrowth.HistoryGrowthIssuesMetadata
+		switch issue.InvariantType {
+		case historygrowth.SignalStorm.String():
+			var data historygrowth.SignalStormMetadata
+			err := json.Unmarshal(issue.Metadata, &data)
+			if err != nil {
+				return nil, err
+			}
+			metadata.SignalStorm = &data
+		case historygrowth.HistoryGrowth.String():
+			var data historygrowth.HistoryGrowthMetadata
+			err := json.Unmarshal(issue.Metadata, &data)
+			if err != nil {
+				return nil, err
+			}
+			metadata.HistoryGrowth = &data
+		default:
+			continue
+		}
+		result = append(result, &historyGrowthIssuesResult{
+			IssueID:       issue.IssueID,
+			InvariantType: issue.InvariantType,
+			Reason:        issue.Reason,
+			Remediation:   historygrowth.Remediation(issue.InvariantType),
+			Metadata:      &metadata,
+		})
+	}
+	return result, nil
+}
+
+func retrieveChildWorkflowIssues(issues []invariant.InvariantCheckResult) ([]*childWorkflowIssuesResult, error) {
+	result := make([]*childWorkflowIssuesResult, 0)
+	for _, issue := range issues {
+		if issue.InvariantType == child.ChildWorkflowBlockingParent.String() {
+			var data child.ChildWorkflowMetadata
+			err := json.Unmarshal(issue.Metadata, &data)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, &childWorkflowIssuesResult{
+				IssueID:       issue.IssueID,
+				InvariantType: issue.InvariantType,
+				Reason:        issue.Reason,
+				Remediation:   child.Remediation(issue.InvariantType),
+				Metadata:      &data,
+			})
+		}
+	}
+	return result, nil
+}
+
+func rootCauseDecisionRelated(rootCause invariant.RootCause) bool {
+	for _, rc := range []invariant.RootCause{invariant.RootCauseTypeDecisionMissingPollers,
+		invariant.RootCauseTypeDecisionPollersStatus,
+		invariant.RootCauseTypeNonDeterministicDeployment,
+		invariant.RootCauseTypeNonDeterministicWorkflowCode} {
+		if rc == rootCause {
+			return true
+		}
+	}
+	return false
+}
+
 func rootCauseHeartBeatRelated(rootCause invariant.RootCause) bool {
 	for _, rc := range []invariant.RootCause{invariant.RootCauseTypeNoHeartBeatTimeoutNoRetryPolicy,
 		invariant.RootCauseTypeHeartBeatingNotEnabledWithRetryPolicy,
@@ -37,11 +37,15 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/resource"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/child"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/decision"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/failure"
+	"github.com/uber/cadence/service/worker/diagnostics/invariant/historygrowth"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/retry"
 	"github.com/uber/cadence/service/worker/diagnostics/invariant/timeout"
 )
@@ -68,6 +72,8 @@ func (s *diagnosticsWorkflowTestSuite) SetupTest() {
 		clientBean:    mockResource.ClientBean,
 		metricsClient: mockResource.GetMetricsClient(),
 		invariants:    []invariant.Invariant{timeout.NewInvariant(timeout.Params{Client: publicClient}), failure.NewInvariant(), retry.NewInvariant()},
+
+		historyEventLinkTemplate: dynamicproperties.GetStringPropertyFnFilteredByDomain("https://cadence.example.com/domains/{domain}/workflows/{workflowID}/{runID}/history#{eventID}"),
 	}
 
 	s.T().Cleanup(func() {
@@ -130,6 +136,7 @@ func (s *diagnosticsWorkflowTestSuite) TestWorkflow() {
 			IssueID:       1,
 			InvariantType: timeout.TimeoutTypeExecution.String(),
 			Reason:        "START_TO_CLOSE",
+			Remediation:   timeout.ExecutionTimeoutRemediation,
 			Metadata: &timeout.TimeoutIssuesMetadata{
 				ExecutionTimeout: &workflowTimeoutData,
 			},
@@ -193,6 +200,23 @@ func (s *diagnosticsWorkflowTestSuite) TestWorkflow() {
 	s.ElementsMatch(queriedResult.DiagnosticsResult.Timeouts.Issues, result.DiagnosticsResult.Timeouts.Issues)
 	s.ElementsMatch(queriedResult.DiagnosticsResult.Timeouts.RootCause, result.DiagnosticsResult.Timeouts.RootCause)
 	s.True(queriedResult.DiagnosticsCompleted)
+
+	queryFuture, err := s.workflowEnv.QueryWorkflow(QueryDiagnosticsReportJSON)
+	s.NoError(err)
+	var report DiagnosticsReport
+	s.NoError(queryFuture.Get(&report))
+	s.True(report.Completed)
+	s.Equal("123", report.WorkflowID)
+	s.Len(report.Issues, len(timeoutIssues)+1)
+	s.Equal([]*ReportEvent{{EventID: 1, Link: "https://cadence.example.com/domains/test/workflows/123/abc/history#1"}}, report.Issues[0].Events)
+
+	queryFuture, err = s.workflowEnv.QueryWorkflow(QueryDiagnosticsReportMarkdown)
+	s.NoError(err)
+	var markdown string
+	s.NoError(queryFuture.Get(&markdown))
+	s.Contains(markdown, "## Timeout: "+timeout.TimeoutTypeExecution.String())
+	s.Contains(markdown, "**History events:** [#1](https://cadence.example.com/domains/test/workflows/123/abc/history#1)")
+	s.Contains(markdown, "**Remediation:** "+timeout.ExecutionTimeoutRemediation)
 }
 
 func (s *diagnosticsWorkflowTestSuite) TestWorkflow_Error() {
@@ -299,6 +323,7 @@ func (s *diagnosticsWorkflowTestSuite) Test__retrieveTimeoutIssues() {
 			IssueID:       1,
 			InvariantType: timeout.TimeoutTypeExecution.String(),
 			Reason:        "START_TO_CLOSE",
+			Remediation:   timeout.ExecutionTimeoutRemediation,
 			Metadata: &timeout.TimeoutIssuesMetadata{
 				ExecutionTimeout: &workflowTimeoutData,
 			},
@@ -307,6 +332,7 @@ func (s *diagnosticsWorkflowTestSuite) Test__retrieveTimeoutIssues() {
 			IssueID:       2,
 			InvariantType: timeout.TimeoutTypeActivity.String(),
 			Reason:        "START_TO_CLOSE",
+			Remediation:   timeout.ActivityTimeoutRemediation,
 			Metadata: &timeout.TimeoutIssuesMetadata{
 				ActivityTimeout: &activityTimeoutData,
 			},
@@ -315,6 +341,7 @@ func (s *diagnosticsWorkflowTestSuite) Test__retrieveTimeoutIssues() {
 			IssueID:       3,
 			InvariantType: timeout.TimeoutTypeDecision.String(),
 			Reason:        "START_TO_CLOSE",
+			Remediation:   timeout.DecisionTimeoutRemediation,
 			Metadata: &timeout.TimeoutIssuesMetadata{
 				DecisionTimeout: &descTimeoutData,
 			},
@@ -323,6 +350,7 @@ func (s *diagnosticsWorkflowTestSuite) Test__retrieveTimeoutIssues() {
 			IssueID:       4,
 			InvariantType: timeout.TimeoutTypeChildWorkflow.String(),
 			Reason:        "START_TO_CLOSE",
+			Remediation:   timeout.ChildWorkflowTimeoutRemediation,
 			Metadata: &timeout.TimeoutIssuesMetadata{
 				ChildWfTimeout: &childWorkflowTimeoutData,
 			},
@@ -393,6 +421,7 @@ func (s *diagnosticsWorkflowTestSuite) Test__retrieveFailureIssues() {
 			IssueID:       1,
 			InvariantType: failure.ActivityFailed.String(),
 			Reason:        failure.CustomError.String(),
+			Remediation:   failure.CustomErrorRemediation,
 			Metadata:      &actMetadata,
 		},
 	}
@@ -469,12 +498,14 @@ func (s *diagnosticsWorkflowTestSuite) Test__retrieveRetryIssues() {
 			IssueID:       1,
 			InvariantType: retry.ActivityRetryIssue.String(),
 			Reason:        retry.RetryPolicyValidationMaxAttempts.String(),
+			Remediation:   retry.MaxAttemptsRemediation,
 			Metadata:      retryMetadata,
 		},
 		{
 			IssueID:       2,
 			InvariantType: retry.WorkflowRetryIssue.String(),
 			Reason:        retry.RetryPolicyValidationMaxAttempts.String(),
+			Remediation:   retry.MaxAttemptsRemediation,
 			Metadata:      retryMetadata,
 		},
 	}
@@ -482,3 +513,136 @@ func (s *diagnosticsWorkflowTestSuite) Test__retrieveRetryIssues() {
 	s.NoError(err)
 	s.ElementsMatch(retryIssues, result)
 }
+
+func (s *diagnosticsWorkflowTestSuite) Test__retrieveDecisionIssues() {
+	pendingMetadata := decision.PendingDecisionMetadata{
+		ScheduledEventID: 5,
+		Attempt:          2,
+		Tasklist:         &types.TaskList{Name: "test-tasklist"},
+	}
+	pendingMetadataInBytes, err := json.Marshal(pendingMetadata)
+	s.NoError(err)
+	nonDeterminismMetadata := decision.NonDeterminismMetadata{
+		FailedCount:           1,
+		LastFailedEventID:     8,
+		FailingBinaryChecksum: "v2",
+	}
+	nonDeterminismMetadataInBytes, err := json.Marshal(nonDeterminismMetadata)
+	s.NoError(err)
+	issues := []invariant.InvariantCheckResult{
+		{
+			IssueID:       0,
+			InvariantType: decision.DecisionTaskNonDeterminism.String(),
+			Reason:        types.DecisionTaskFailedCauseWorkflowWorkerUnhandledFailure.String(),
+			Metadata:      nonDeterminismMetadataInBytes,
+		},
+		{
+			IssueID:       1,
+			InvariantType: decision.DecisionTaskPending.String(),
+			Reason:        "pending",
+			Metadata:      pendingMetadataInBytes,
+		},
+		{
+			IssueID:       0,
+			InvariantType: retry.WorkflowRetryIssue.String(),
+			Reason:        retry.RetryPolicyValidationMaxAttempts.String(),
+		},
+	}
+	decisionIssues := []*decisionIssuesResult{
+		{
+			IssueID:       0,
+			InvariantType: decision.DecisionTaskNonDeterminism.String(),
+			Reason:        types.DecisionTaskFailedCauseWorkflowWorkerUnhandledFailure.String(),
+			Remediation:   decision.NonDeterminismRemediation,
+			Metadata:      &decision.DecisionIssuesMetadata{NonDeterminism: &nonDeterminismMetadata},
+		},
+		{
+			IssueID:       1,
+			InvariantType: decision.DecisionTaskPending.String(),
+			Reason:        "pending",
+			Remediation:   decision.PendingDecisionRemediation,
+			Metadata:      &decision.DecisionIssuesMetadata{PendingDecision: &pendingMetadata},
+		},
+	}
+	result, err := retrieveDecisionIssues(issues)
+	s.NoError(err)
+	s.Equal(decisionIssues, result)
+
+	rootCauseMetadata := decision.DecisionRootCauseMetadata{
+		PollersMetadata: &decision.PollersMetadata{TaskListName: "test-tasklist", TaskListBacklog: 5},
+	}
+	rootCauseMetadataInBytes, err := json.Marshal(rootCauseMetadata)
+	s.NoError(err)
+	rootCause, err := retrieveDecisionRootCause([]invariant.InvariantRootCauseResult{
+		{
+			IssueID:   1,
+			RootCause: invariant.RootCauseTypeDecisionMissingPollers,
+			Metadata:  rootCauseMetadataInBytes,
+		},
+		{
+			IssueID:   0,
+			RootCause: invariant.RootCauseTypeServiceSidePanic,
+		},
+	})
+	s.NoError(err)
+	s.Equal([]*decisionRootCauseResult{
+		{
+			IssueID:       1,
+			RootCauseType: invariant.RootCauseTypeDecisionMissingPollers.String(),
+			Metadata:      &rootCauseMetadata,
+		},
+	}, rootCause)
+}
+
+func (s *diagnosticsWorkflowTestSuite) Test__retrieveHistoryGrowthAndChildWorkflowIssues() {
+	growthMetadata := historygrowth.HistoryGrowthMetadata{
+		HistoryCount:      60000,
+		HistoryWarnLimit:  50000,
+		HistoryErrorLimit: 200000,
+	}
+	growthMetadataInBytes, err := json.Marshal(growthMetadata)
+	s.NoError(err)
+	childMetadata := child.ChildWorkflowMetadata{
+		PendingChildren: []child.PendingChild{{InitiatedEventID: 5, WorkflowID: "child-wid"}},
+	}
+	childMetadataInBytes, err := json.Marshal(childMetadata)
+	s.NoError(err)
+	issues := []invariant.InvariantCheckResult{
+		{
+			IssueID:       0,
+			InvariantType: historygrowth.HistoryGrowth.String(),
+			Reason:        "growth",
+			Metadata:      growthMetadataInBytes,
+		},
+		{
+			IssueID:       0,
+			InvariantType: child.ChildWorkflowBlockingParent.String(),
+			Reason:        "children",
+			Metadata:      childMetadataInBytes,
+		},
+	}
+
+	growthResult, err := retrieveHistoryGrowthIssues(issues)
+	s.NoError(err)
+	s.Equal([]*historyGrowthIssuesResult{
+		{
+			IssueID:       0,
+			InvariantType: historygrowth.HistoryGrowth.String(),
+			Reason:        "growth",
+			Remediation:   historygrowth.HistoryGrowthRemediation,
+			Metadata:      &historygrowth.HistoryGrowthIssuesMetadata{HistoryGrowth: &growthMetadata},
+		},
+	}, growthResult)
+
+	childResult, err := retrieveChildWorkflowIssues(issues)
+	s.NoError(err)
+	s.Equal([]*childWorkflowIssuesResult{
+		{
+			IssueID:       0,
+			InvariantType: child.ChildWorkflowBlockingParent.String(),
+			Reason:        "children",
+			Remediation:   child.ChildWorkflowBlockingParentRemediation,
+			Metadata:      &childMetadata,
+		},
+	}, childResult)
+}
@@ -25,6 +25,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/uber/cadence/.gen/go/indexer"
@@ -55,6 +57,15 @@ type (
 		logger        log.Logger
 		scope         metrics.Scope
 		msgEncoder    codec.BinaryEncoder
+
+		// requests rejected with a retryable status are queued here by bulkAfterAction and added back to the
+		// bulk processor by retryLoop, as bulkAfterAction runs on a bulk processor worker which Add would wait for
+		retryMu       sync.Mutex
+		retryRequests []*bulk.GenericBulkableAddRequest
+		retryNotifyCh chan struct{}
+		status        int32
+		shutdownCh    chan struct{}
+		shutdownWG    sync.WaitGroup
 	}
 
 	kafkaMessageWithMetrics struct { // value of ESProcessorImpl.mapToKafkaMsg
@@ -62,6 +73,8 @@ type (
 		swFromAddToAck *metrics.Stopwatch // metric from message add to process, to message ack/nack
 		processStart   time.Time
 		scope          metrics.Scope
+		retries        atomic.Int32                    // number of times ES rejected the request with a retryable status
+		request        *bulk.GenericBulkableAddRequest // re-added to the bulk processor on a retryable rejection
 	}
 )
 
@@ -78,6 +91,9 @@ func newESProcessor(
 		logger:     logger.WithTags(tag.ComponentIndexerESProcessor),
 		scope:      metricsClient.Scope(metrics.ESProcessorScope),
 		msgEncoder: defaultEncoder,
+
+		retryNotifyCh: make(chan struct{}, 1),
+		shutdownCh:    make(chan struct{}),
 	}
 
 	params := &bulk.BulkProcessorParameters{
@@ -89,6 +105,8 @@ func newESProcessor(
 		Backoff:       bulk.NewExponentialBackoff(esProcessorInitialRetryInterval, esProcessorMaxRetryInterval),
 		BeforeFunc:    p.bulkBeforeAction,
 		AfterFunc:     p.bulkAfterAction,
+		// requests rejected with a retryable status are re-added by retryLoop until they exhaust their retries
+		DisableItemRetries: true,
 	}
 	processor, err := client.RunBulkProcessor(context.Background(), params)
 	if err != nil {
@@ -103,9 +121,18 @@ func newESProcessor(
 func (p *ESProcessorImpl) Start() {
 	// current implementation (v6 and v7) allows to invoke Start() multiple times
 	p.bulkProcessor.Start(context.Background())
-
+	if atomic.CompareAndSwapInt32(&p.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		p.shutdownWG.Add(1)
+		go p.retryLoop()
+	}
 }
+
 func (p *ESProcessorImpl) Stop() {
+	if atomic.CompareAndSwapInt32(&p.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		// the retry loop must not add requests once the bulk processor is stopped
+		close(p.shutdownCh)
+		p.shutdownWG.Wait()
+	}
 	p.bulkProcessor.Stop() //nolint:errcheck
 	p.mapToKafkaMsg = nil
 }
@@ -118,6 +145,7 @@ func (p *ESProcessorImpl) Add(request *bulk.GenericBulkableAddRequest, key strin
 	esProcessStart := time.Now()
 	sw := p.scope.StartTimer(metrics.ESProcessorProcessMsgLatency)
 	mapVal := newKafkaMessageWithMetrics(kafkaMsg, &sw, esProcessStart, p.scope)
+	mapVal.request = request
 	_, isDup, _ := p.mapToKafkaMsg.PutOrDo(key, mapVal, actionWhenFoundDuplicates)
 	if isDup {
 		return
@@ -183,7 +211,7 @@ func (p *ESProcessorImpl) bulkAfterAction(id int64, requests []bulk.GenericBulka
 					tag.WorkflowID(wid),
 					tag.WorkflowRunID(rid),
 					tag.WorkflowDomainID(domainID))
-				p.nackKafkaMsg(key)
+				p.nackKafkaMsg(key, fmt.Sprintf("ES bulk request failed with status %d: %v", err.Status, err.Details))
 			}
 			p.scope.IncCounter(metrics.ESProcessorFailures)
 		}
@@ -206,31 +234,42 @@ func (p *ESProcessorImpl) bulkAfterAction(id int64, requests []bulk.GenericBulka
 				p.logger.Error("ES request failed.",
 					tag.ESResponseStatus(resp.Status), tag.ESResponseError(getErrorMsgFromESResp(resp)), tag.WorkflowID(wid), tag.WorkflowRunID(rid),
 					tag.WorkflowDomainID(domainID))
-				p.nackKafkaMsg(key)
-			default: // bulk bulkProcessor will retry
+				p.nackKafkaMsg(key, fmt.Sprintf("ES request failed with status %d: %s", resp.Status, getErrorMsgFromESResp(resp)))
+			case p.exhaustedRetries(key):
+				// the request is not added back to the bulk processor, so it is dropped along with its message
+				wid, rid, domainID := p.getMsgWithInfo(key)
+				p.logger.Error("ES request exhausted its retries.",
+					tag.ESResponseStatus(resp.Status), tag.ESResponseError(getErrorMsgFromESResp(resp)), tag.WorkflowID(wid), tag.WorkflowRunID(rid),
+					tag.WorkflowDomainID(domainID))
+				p.scope.IncCounter(metrics.ESProcessorFailures)
+				p.nackKafkaMsg(key, fmt.Sprintf("ES request still failing with retryable status %d after %d retries: %s",
+					resp.Status, p.config.ESProcessorMaxItemRetries(), getErrorMsgFromESResp(resp)))
+			default:
 				p.logger.Info("ES request retried.", tag.ESResponseStatus(resp.Status))
 				p.scope.IncCounter(metrics.ESProcessorRetries)
+				p.retryRequest(key)
 			}
 		}
 	}
 }
 
 func (p *ESProcessorImpl) ackKafkaMsg(key string) {
-	p.ackKafkaMsgHelper(key, false)
+	p.ackKafkaMsgHelper(key, false, "")
 }
 
-func (p *ESProcessorImpl) nackKafkaMsg(key string) {
-	p.ackKafkaMsgHelper(key, true)
+// nackKafkaMsg sends the message to the DLQ, along with the reason it could not be indexed
+func (p *ESProcessorImpl) nackKafkaMsg(key string, reason string) {
+	p.ackKafkaMsgHelper(key, true, reason)
 }
 
-func (p *ESProcessorImpl) ackKafkaMsgHelper(key string, nack bool) {
+func (p *ESProcessorImpl) ackKafkaMsgHelper(key string, nack bool, reason string) {
 	kafkaMsg, ok := p.getKafkaMsg(key)
 	if !ok {
 		return
 	}
 
 	if nack {
-		kafkaMsg.Nack()
+		kafkaMsg.Nack(reason)
 	} else {
 		kafkaMsg.Ack()
 	}
@@ -238,6 +277,61 @@ func (p *ESProcessorImpl) ackKafkaMsgHelper(key string, nack bool) {
 	p.mapToKafkaMsg.Remove(key)
 }
 
+// exhaustedRetries counts a retryable rejection of the request for key,
+// and reports whether it has now been retried more than ESProcessorMaxItemRetries times
+func (p *ESProcessorImpl) exhaustedRetries(key string) bool {
+	maxRetries := p.config.ESProcessorMaxItemRetries()
+	if maxRetries <= 0 {
+		return false
+	}
+	kafkaMsg, ok := p.getKafkaMsg(key)
+	if !ok {
+		return false
+	}
+	return int(kafkaMsg.retries.Add(1)) > maxRetries
+}
+
+// retryRequest queues the request for key to be added back to the bulk processor, which does not retry rejected items itself.
+// It never blocks, as it is called from bulkAfterAction on a bulk processor worker.
+func (p *ESProcessorImpl) retryRequest(key string) {
+	kafkaMsg, ok := p.getKafkaMsg(key)
+	if !ok || kafkaMsg.request == nil {
+		return
+	}
+	p.retryMu.Lock()
+	p.retryRequests = append(p.retryRequests, kafkaMsg.request)
+	p.retryMu.Unlock()
+	select {
+	case p.retryNotifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// retryLoop adds the requests queued by retryRequest back to the bulk processor until the processor is stopped
+func (p *ESProcessorImpl) retryLoop() {
+	defer p.shutdownWG.Done()
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case <-p.retryNotifyCh:
+		}
+		p.retryMu.Lock()
+		requests := p.retryRequests
+		p.retryRequests = nil
+		p.retryMu.Unlock()
+		for _, request := range requests {
+			select {
+			case <-p.shutdownCh:
+				// messages of the dropped requests are not acked, so they are redelivered
+				return
+			default:
+			}
+			p.bulkProcessor.Add(request)
+		}
+	}
+}
+
 func (p *ESProcessorImpl) getKafkaMsg(key string) (kafkaMsg *kafkaMessageWithMetrics, ok bool) {
 	msg, ok := p.mapToKafkaMsg.Get(key)
 	if !ok {
@@ -380,8 +474,8 @@ func (km *kafkaMessageWithMetrics) Ack() {
 	}
 }
 
-func (km *kafkaMessageWithMetrics) Nack() {
-	km.message.Nack() //nolint:errcheck
+func (km *kafkaMessageWithMetrics) Nack(reason string) {
+	km.message.NackWithReason(reason) //nolint:errcheck
 	if km.swFromAddToAck != nil {
 		km.swFromAddToAck.Stop()
 		km.scope.ExponentialHistogram(metrics.ESProcessorProcessMsgLatencyHistogram, time.Since(km.processStart))
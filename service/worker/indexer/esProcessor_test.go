@@ -22,16 +22,24 @@ package indexer
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/uber/cadence/.gen/go/indexer"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/collection"
+	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/definition"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/elasticsearch"
 	"github.com/uber/cadence/common/elasticsearch/bulk"
@@ -70,11 +78,12 @@ func (s *esProcessorSuite) SetupSuite() {
 
 func (s *esProcessorSuite) SetupTest() {
 	config := &Config{
-		IndexerConcurrency:       dynamicproperties.GetIntPropertyFn(32),
-		ESProcessorNumOfWorkers:  dynamicproperties.GetIntPropertyFn(1),
-		ESProcessorBulkActions:   dynamicproperties.GetIntPropertyFn(10),
-		ESProcessorBulkSize:      dynamicproperties.GetIntPropertyFn(2 << 20),
-		ESProcessorFlushInterval: dynamicproperties.GetDurationPropertyFn(1 * time.Minute),
+		IndexerConcurrency:        dynamicproperties.GetIntPropertyFn(32),
+		ESProcessorNumOfWorkers:   dynamicproperties.GetIntPropertyFn(1),
+		ESProcessorBulkActions:    dynamicproperties.GetIntPropertyFn(10),
+		ESProcessorBulkSize:       dynamicproperties.GetIntPropertyFn(2 << 20),
+		ESProcessorFlushInterval:  dynamicproperties.GetDurationPropertyFn(1 * time.Minute),
+		ESProcessorMaxItemRetries: dynamicproperties.GetIntPropertyFn(2),
 	}
 	s.mockBulkProcessor = &mocks2.GenericBulkProcessor{}
 	s.mockScope = &mocks.Scope{}
@@ -115,6 +124,7 @@ func (s *esProcessorSuite) TestNewESProcessorAndStart() {
 		s.Equal(config.ESProcessorFlushInterval(), input.FlushInterval)
 		s.NotNil(input.Backoff)
 		s.NotNil(input.AfterFunc)
+		s.True(input.DisableItemRetries)
 		return true
 	})).Return(&mocks2.GenericBulkProcessor{}, nil).Once()
 	processor, err := newESProcessor(processorName, config, s.mockESClient, s.esProcessor.logger, metrics.NewNoopMetricsClient())
@@ -235,7 +245,7 @@ func (s *esProcessorSuite) TestBulkAfterAction_Nack() {
 	mockKafkaMsg := &msgMocks.Message{}
 	mapVal := newKafkaMessageWithMetrics(mockKafkaMsg, &testStopWatch, time.Now(), s.esProcessor.scope)
 	s.esProcessor.mapToKafkaMsg.Put(testKey, mapVal)
-	mockKafkaMsg.On("Nack").Return(nil).Once()
+	mockKafkaMsg.On("NackWithReason", "ES request failed with status 400: ").Return(nil).Once()
 	mockKafkaMsg.On("Value").Return(payload).Once()
 	s.mockScope.On("ExponentialHistogram", metrics.ESProcessorProcessMsgLatencyHistogram, mock.AnythingOfType("time.Duration")).Once()
 	// s.mockBulkProcessor.On("RetrieveKafkaKey", request, mock.Anything, mock.Anything).Return(testKey)
@@ -243,6 +253,51 @@ func (s *esProcessorSuite) TestBulkAfterAction_Nack() {
 	mockKafkaMsg.AssertExpectations(s.T())
 }
 
+func (s *esProcessorSuite) TestBulkAfterAction_RetriesExhausted() {
+	testKey := "testKey"
+	request := &mocks2.GenericBulkableRequest{}
+	request.On("String").Return("")
+	request.On("Source").Return([]string{string(`{"delete":{"_id":"testKey"}}`)}, nil)
+	requests := []bulk.GenericBulkableRequest{request}
+
+	response := &bulk.GenericBulkResponse{
+		Took:   3,
+		Errors: false,
+		Items: []map[string]*bulk.GenericBulkResponseItem{{
+			"index": {
+				Index:  testIndex,
+				Type:   testType,
+				ID:     testID,
+				Status: 429,
+			},
+		}},
+	}
+
+	mockKafkaMsg := &msgMocks.Message{}
+	mapVal := newKafkaMessageWithMetrics(mockKafkaMsg, &testStopWatch, time.Now(), s.esProcessor.scope)
+	mapVal.request = &bulk.GenericBulkableAddRequest{ID: testID, RequestType: bulk.BulkableDeleteRequest}
+	s.esProcessor.mapToKafkaMsg.Put(testKey, mapVal)
+
+	// retried up to the limit of 2 while the message is held back
+	s.mockScope.On("IncCounter", metrics.ESProcessorRetries).Twice()
+	s.esProcessor.bulkAfterAction(0, requests, response, nil)
+	s.esProcessor.bulkAfterAction(1, requests, response, nil)
+	mockKafkaMsg.AssertExpectations(s.T())
+	s.Equal(1, s.esProcessor.mapToKafkaMsg.Len())
+	s.Equal([]*bulk.GenericBulkableAddRequest{mapVal.request, mapVal.request}, s.esProcessor.retryRequests)
+
+	// then dead-lettered without being added back to the bulk processor
+	mockKafkaMsg.On("Value").Return(s.getEncodedMsg("wid", "rid", "domainID")).Once()
+	mockKafkaMsg.On("NackWithReason", "ES request still failing with retryable status 429 after 2 retries: ").Return(nil).Once()
+	s.mockScope.On("IncCounter", metrics.ESProcessorFailures).Once()
+	s.mockScope.On("ExponentialHistogram", metrics.ESProcessorProcessMsgLatencyHistogram, mock.AnythingOfType("time.Duration")).Once()
+	s.esProcessor.bulkAfterAction(2, requests, response, nil)
+	s.Len(s.esProcessor.retryRequests, 2)
+	mockKafkaMsg.AssertExpectations(s.T())
+	s.mockScope.AssertExpectations(s.T())
+	s.Equal(0, s.esProcessor.mapToKafkaMsg.Len())
+}
+
 func (s *esProcessorSuite) TestBulkAfterAction_Error() {
 	version := int64(3)
 	testKey := "testKey"
@@ -274,7 +329,7 @@ func (s *esProcessorSuite) TestBulkAfterAction_Error() {
 	mockKafkaMsg := &msgMocks.Message{}
 	mapVal := newKafkaMessageWithMetrics(mockKafkaMsg, &testStopWatch, time.Now(), s.esProcessor.scope)
 	s.esProcessor.mapToKafkaMsg.Put(testKey, mapVal)
-	mockKafkaMsg.On("Nack").Return(nil).Once()
+	mockKafkaMsg.On("NackWithReason", "ES bulk request failed with status 0: some error").Return(nil).Once()
 	mockKafkaMsg.On("Value").Return(payload).Once()
 	s.mockScope.On("IncCounter", metrics.ESProcessorFailures).Once()
 	s.mockScope.On("ExponentialHistogram", metrics.ESProcessorProcessMsgLatencyHistogram, mock.AnythingOfType("time.Duration")).Once()
@@ -312,7 +367,7 @@ func (s *esProcessorSuite) TestBulkAfterAction_Error_Nack() {
 	mockKafkaMsg := &msgMocks.Message{}
 	mapVal := newKafkaMessageWithMetrics(mockKafkaMsg, &testStopWatch, time.Now(), s.esProcessor.scope)
 	s.esProcessor.mapToKafkaMsg.Put(testKey, mapVal)
-	mockKafkaMsg.On("Nack").Return(nil).Once()
+	mockKafkaMsg.On("NackWithReason", mock.AnythingOfType("string")).Return(nil).Once()
 	mockKafkaMsg.On("Ack").Return(nil).Once() // Expect Ack to be called
 	mockKafkaMsg.On("Value").Return(payload).Once()
 	s.mockScope.On("IncCounter", metrics.ESProcessorFailures).Once()
@@ -342,7 +397,7 @@ func (s *esProcessorSuite) TestAckKafkaMsg() {
 func (s *esProcessorSuite) TestNackKafkaMsg() {
 	key := "test-key-nack"
 	// no msg in map, nothing called
-	s.esProcessor.nackKafkaMsg(key)
+	s.esProcessor.nackKafkaMsg(key, "test reason")
 
 	request := &bulk.GenericBulkableAddRequest{}
 	mockKafkaMsg := &msgMocks.Message{}
@@ -351,9 +406,9 @@ func (s *esProcessorSuite) TestNackKafkaMsg() {
 	s.esProcessor.Add(request, key, mockKafkaMsg)
 	s.Equal(1, s.esProcessor.mapToKafkaMsg.Len())
 
-	mockKafkaMsg.On("Nack").Return(nil).Once()
+	mockKafkaMsg.On("NackWithReason", "test reason").Return(nil).Once()
 	s.mockScope.On("ExponentialHistogram", metrics.ESProcessorProcessMsgLatencyHistogram, mock.AnythingOfType("time.Duration")).Once()
-	s.esProcessor.nackKafkaMsg(key)
+	s.esProcessor.nackKafkaMsg(key, "test reason")
 	mockKafkaMsg.AssertExpectations(s.T())
 	s.Equal(0, s.esProcessor.mapToKafkaMsg.Len())
 }
@@ -533,7 +588,7 @@ func (s *esProcessorSuite) TestBulkAfterAction_Nack_Shadow_WithError() {
 	s.esProcessor.mapToKafkaMsg.Put(testKey, mapVal)
 
 	// Mock Kafka message Nack and Value
-	mockKafkaMsg.On("Nack").Return(nil).Once()
+	mockKafkaMsg.On("NackWithReason", "ES bulk request failed with status 500: Test error occurred").Return(nil).Once()
 	mockKafkaMsg.On("Value").Return(payload).Once()
 	s.mockScope.On("IncCounter", mock.AnythingOfType("metrics.MetricIdx")).Return()
 	s.mockScope.On("ExponentialHistogram", metrics.ESProcessorProcessMsgLatencyHistogram, mock.AnythingOfType("time.Duration")).Once()
@@ -574,10 +629,92 @@ func (s *esProcessorSuite) TestBulkAfterAction_Shadow_Fail_WithoutError() {
 	s.esProcessor.mapToKafkaMsg.Put(testKey, mapVal)
 
 	// Mock Kafka message Nack and Value
-	mockKafkaMsg.On("Nack").Return(nil).Once()
+	mockKafkaMsg.On("NackWithReason", "ES request failed with status 400: ").Return(nil).Once()
 	mockKafkaMsg.On("Value").Return(payload).Once()
 	s.mockScope.On("IncCounter", mock.AnythingOfType("int")).Return()
 	s.mockScope.On("ExponentialHistogram", metrics.ESProcessorProcessMsgLatencyHistogram, mock.AnythingOfType("time.Duration")).Once()
 	// Execute bulkAfterAction for primary processor with error
 	s.esProcessor.bulkAfterAction(0, requests, response, nil)
 }
+
+func TestESProcessorRetriesRejectedRequestsWithBulkProcessor(t *testing.T) {
+	tests := map[string]struct {
+		rejections    int32
+		expectAck     bool
+		expectedBulks int32
+	}{
+		"accepted after a rejection": {
+			rejections:    1,
+			expectAck:     true,
+			expectedBulks: 2,
+		},
+		"dropped after exhausting retries": {
+			rejections:    100,
+			expectAck:     false,
+			expectedBulks: 3, // the first attempt and two retries
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var bulks atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := 201
+				if bulks.Add(1) <= tc.rejections {
+					status = 429
+				}
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"took":1,"errors":%v,"items":[{"index":{"_index":%q,"_id":%q,"_version":1,"status":%d}}]}`,
+					status != 201, testIndex, testID, status)
+			}))
+			defer server.Close()
+
+			serverURL, err := url.Parse(server.URL)
+			require.NoError(t, err)
+			logger := testlogger.New(t)
+			client, err := elasticsearch.NewGenericClient(&config.ElasticSearchConfig{
+				URL:                *serverURL,
+				Version:            "v7",
+				DisableSniff:       true,
+				DisableHealthCheck: true,
+			}, logger)
+			require.NoError(t, err)
+
+			processor, err := newESProcessor("test-processor", &Config{
+				IndexerConcurrency:        dynamicproperties.GetIntPropertyFn(1),
+				ESProcessorNumOfWorkers:   dynamicproperties.GetIntPropertyFn(1),
+				ESProcessorBulkActions:    dynamicproperties.GetIntPropertyFn(1),
+				ESProcessorBulkSize:       dynamicproperties.GetIntPropertyFn(1 << 20),
+				ESProcessorFlushInterval:  dynamicproperties.GetDurationPropertyFn(10 * time.Millisecond),
+				ESProcessorMaxItemRetries: dynamicproperties.GetIntPropertyFn(2),
+			}, client, logger, metrics.NewNoopMetricsClient())
+			require.NoError(t, err)
+			processor.Start()
+			defer processor.Stop()
+
+			var done atomic.Bool
+			kafkaMsg := &msgMocks.Message{}
+			kafkaMsg.On("Value").Return([]byte{}).Maybe()
+			if tc.expectAck {
+				kafkaMsg.On("Ack").Run(func(mock.Arguments) { done.Store(true) }).Return(nil).Once()
+			} else {
+				kafkaMsg.On("NackWithReason", mock.Anything).Run(func(mock.Arguments) { done.Store(true) }).Return(nil).Once()
+			}
+
+			processor.Add(&bulk.GenericBulkableAddRequest{
+				Index:       testIndex,
+				ID:          testID,
+				VersionType: versionTypeExternal,
+				Version:     1,
+				RequestType: bulk.BulkableIndexRequest,
+				Doc:         map[string]interface{}{definition.KafkaKey: testID},
+			}, testID, kafkaMsg)
+
+			assert.Eventually(t, done.Load, 10*time.Second, 10*time.Millisecond)
+			// the request is not sent again once its message is acked or nacked
+			time.Sleep(100 * time.Millisecond)
+			assert.Equal(t, tc.expectedBulks, bulks.Load())
+			kafkaMsg.AssertExpectations(t)
+		})
+	}
+}
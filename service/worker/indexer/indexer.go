@@ -31,6 +31,7 @@ import (
 
 	"github.com/uber/cadence/.gen/go/indexer"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/codec"
 	"github.com/uber/cadence/common/constants"
 	"github.com/uber/cadence/common/definition"
@@ -42,6 +43,7 @@ import (
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/visibility"
 )
 
 const (
@@ -65,6 +67,7 @@ type (
 		esIndexName         string
 		consumer            messaging.Consumer
 		visibilityProcessor ESProcessor
+		domainCache         cache.DomainCache
 		config              *Config
 		logger              log.Logger
 		scope               metrics.Scope
@@ -88,7 +91,10 @@ type (
 		ESProcessorBulkActions         dynamicproperties.IntPropertyFn // max number of requests in bulk
 		ESProcessorBulkSize            dynamicproperties.IntPropertyFn // max total size of bytes in bulk
 		ESProcessorFlushInterval       dynamicproperties.DurationPropertyFn
+		ESProcessorMaxItemRetries      dynamicproperties.IntPropertyFn // retries of a rejected request before its message goes to the DLQ
 		ValidSearchAttributes          dynamicproperties.MapPropertyFn
+		DomainSearchAttributes         dynamicproperties.MapPropertyFnWithDomainFilter
+		SearchAttributeAliases         dynamicproperties.MapPropertyFn
 		EnableQueryAttributeValidation dynamicproperties.BoolPropertyFn
 	}
 )
@@ -98,6 +104,7 @@ func NewIndexer(
 	config *Config,
 	client messaging.Client,
 	visibilityClient es.GenericClient,
+	domainCache cache.DomainCache,
 	visibilityName string,
 	consumerName string,
 	logger log.Logger,
@@ -127,6 +134,7 @@ func NewIndexer(
 		scope:               metricsClient.Scope(metrics.IndexProcessorScope),
 		shutdownCh:          make(chan struct{}),
 		visibilityProcessor: visibilityProcessor,
+		domainCache:         domainCache,
 		msgEncoder:          defaultEncoder,
 	}
 }
@@ -201,7 +209,7 @@ func (i *Indexer) messageProcessLoop(workerWG *sync.WaitGroup) {
 		sw.Stop()
 		i.scope.ExponentialHistogram(metrics.IndexProcessorProcessMsgLatencyHistogram, time.Since(indexProcessStart))
 		if err != nil {
-			msg.Nack() //nolint:errcheck
+			msg.NackWithReason(err.Error()) //nolint:errcheck
 		}
 	}
 }
@@ -235,7 +243,7 @@ func (i *Indexer) addMessageToES(indexMsg *indexer.Message, kafkaMsg messaging.M
 			tag.WorkflowDomainID(indexMsg.GetDomainID()),
 			tag.WorkflowID(indexMsg.GetWorkflowID()),
 			tag.WorkflowRunID(indexMsg.GetRunID()))
-		kafkaMsg.Nack()
+		kafkaMsg.NackWithReason(fmt.Sprintf("document ID exceeds the size limit of %d", es.GetESDocIDSizeLimit()))
 		return nil
 	}
 
@@ -289,8 +297,10 @@ func (i *Indexer) decodeSearchAttrBinary(bytes []byte, key string) interface{} {
 func (i *Indexer) dumpFieldsToMap(fields map[string]*indexer.Field, domainID string) map[string]interface{} {
 	doc := make(map[string]interface{})
 	attr := make(map[string]interface{})
+	validAttr := i.config.ValidSearchAttributes()
+	aliases := i.config.SearchAttributeAliases()
 	for k, v := range fields {
-		if !i.isValidFieldToES(k) {
+		if !i.isValidFieldToES(k, domainID) {
 			i.logger.Error("Unregistered field.", tag.ESField(k), tag.WorkflowDomainID(domainID))
 			i.scope.IncCounter(metrics.IndexProcessorCorruptedData)
 			continue
@@ -311,8 +321,8 @@ func (i *Indexer) dumpFieldsToMap(fields map[string]*indexer.Field, domainID str
 		case indexer.FieldTypeBinary:
 			if k == definition.Memo {
 				doc[k] = v.GetBinaryData()
-			} else { // custom search attributes
-				attr[k] = i.decodeSearchAttrBinary(v.GetBinaryData(), k)
+			} else { // custom search attributes, values of a search attribute whose type changed go to the one it is aliased to
+				attr[visibility.IndexedSearchAttributeKey(validAttr, aliases, k, v.GetBinaryData())] = i.decodeSearchAttrBinary(v.GetBinaryData(), k)
 			}
 		default:
 			// there must be bug in code and bad deployment, check data sent from producer
@@ -323,7 +333,7 @@ func (i *Indexer) dumpFieldsToMap(fields map[string]*indexer.Field, domainID str
 	return doc
 }
 
-func (i *Indexer) isValidFieldToES(field string) bool {
+func (i *Indexer) isValidFieldToES(field, domainID string) bool {
 	if !i.config.EnableQueryAttributeValidation() {
 		return true
 	}
@@ -333,7 +343,18 @@ func (i *Indexer) isValidFieldToES(field string) bool {
 	if field == definition.Memo || field == definition.KafkaKey || field == definition.Encoding || field == es.VisibilityOperation {
 		return true
 	}
-	return false
+	return i.isDomainSearchAttribute(field, domainID)
+}
+
+// isDomainSearchAttribute returns true if field is a search attribute registered for the domain
+func (i *Indexer) isDomainSearchAttribute(field, domainID string) bool {
+	domainName, err := i.domainCache.GetDomainName(domainID)
+	if err != nil {
+		i.logger.Warn("Failed to get domain name to validate search attribute.", tag.ESField(field), tag.WorkflowDomainID(domainID), tag.Error(err))
+		return false
+	}
+	_, ok := i.config.DomainSearchAttributes(domainName)[field]
+	return ok
 }
 
 func fulfillDoc(doc map[string]interface{}, msg *indexer.Message, keyToKafkaMsg string) {
@@ -33,6 +33,7 @@ import (
 
 	"github.com/uber/cadence/.gen/go/indexer"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/definition"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/elasticsearch/bulk"
@@ -42,6 +43,7 @@ import (
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/types"
 )
 
 func TestNewDualIndexer(t *testing.T) {
@@ -64,7 +66,7 @@ func TestNewDualIndexer(t *testing.T) {
 	mockMessagingClient.EXPECT().NewConsumer("visibility", "test-bulkProcessor-consumer").Return(nil, nil).Times(1)
 	mockMessagingClient.EXPECT().NewConsumer("visibility", "test-bulkProcessor-os-consumer").Return(nil, nil).Times(1)
 
-	indexer := NewMigrationDualIndexer(config, mockMessagingClient, mockESClient, mockESClient, processorName, processorName, "", consumerName, testlogger.New(t), metrics.NewNoopMetricsClient())
+	indexer := NewMigrationDualIndexer(config, mockMessagingClient, mockESClient, mockESClient, cache.NewMockDomainCache(ctrl), processorName, processorName, "", consumerName, testlogger.New(t), metrics.NewNoopMetricsClient())
 	assert.NotNil(t, indexer)
 }
 
@@ -87,7 +89,7 @@ func TestNewIndexer(t *testing.T) {
 	mockMessagingClient := messaging.NewMockClient(ctrl)
 	mockMessagingClient.EXPECT().NewConsumer("visibility", "test-bulkProcessor-consumer").Return(nil, nil).Times(1)
 
-	indexer := NewIndexer(config, mockMessagingClient, mockESClient, processorName, "", testlogger.New(t), metrics.NewNoopMetricsClient())
+	indexer := NewIndexer(config, mockMessagingClient, mockESClient, cache.NewMockDomainCache(ctrl), processorName, "", testlogger.New(t), metrics.NewNoopMetricsClient())
 	assert.NotNil(t, indexer)
 }
 
@@ -224,55 +226,87 @@ func TestIndexerStop(t *testing.T) {
 }
 
 func TestIsValidFieldToES(t *testing.T) {
+	domainSearchAttributes := func(domain string) map[string]interface{} {
+		if domain == "team-domain" {
+			return map[string]interface{}{"teamField": "ok"}
+		}
+		return map[string]interface{}{}
+	}
 	tests := map[string]struct {
-		testIndexer *Indexer
+		config      *Config
 		field       string
+		domainID    string
 		expectedRes bool
 	}{
 		"not EnableQueryAttributeValidation": {
-			testIndexer: &Indexer{
-				config: &Config{
-					EnableQueryAttributeValidation: dynamicproperties.GetBoolPropertyFn(false),
-				},
+			config: &Config{
+				EnableQueryAttributeValidation: dynamicproperties.GetBoolPropertyFn(false),
 			},
 			field:       "someField",
 			expectedRes: true,
 		},
 		"field is valid": {
-			testIndexer: &Indexer{
-				config: &Config{
-					EnableQueryAttributeValidation: dynamicproperties.GetBoolPropertyFn(true),
-					ValidSearchAttributes:          dynamicproperties.GetMapPropertyFn(map[string]interface{}{"someField": "ok"}),
-				},
+			config: &Config{
+				EnableQueryAttributeValidation: dynamicproperties.GetBoolPropertyFn(true),
+				ValidSearchAttributes:          dynamicproperties.GetMapPropertyFn(map[string]interface{}{"someField": "ok"}),
 			},
 			field:       "someField",
 			expectedRes: true,
 		},
 		"field is not valid, but meet definition": {
-			testIndexer: &Indexer{
-				config: &Config{
-					EnableQueryAttributeValidation: dynamicproperties.GetBoolPropertyFn(true),
-					ValidSearchAttributes:          dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
-				},
+			config: &Config{
+				EnableQueryAttributeValidation: dynamicproperties.GetBoolPropertyFn(true),
+				ValidSearchAttributes:          dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
 			},
 			field:       definition.Memo,
 			expectedRes: true,
 		},
-		"false": {
-			testIndexer: &Indexer{
-				config: &Config{
-					EnableQueryAttributeValidation: dynamicproperties.GetBoolPropertyFn(true),
-					ValidSearchAttributes:          dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
-				},
+		"field is registered for domain": {
+			config: &Config{
+				EnableQueryAttributeValidation: dynamicproperties.GetBoolPropertyFn(true),
+				ValidSearchAttributes:          dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
+				DomainSearchAttributes:         domainSearchAttributes,
+			},
+			field:       "teamField",
+			domainID:    "team-domain-id",
+			expectedRes: true,
+		},
+		"field is registered for another domain": {
+			config: &Config{
+				EnableQueryAttributeValidation: dynamicproperties.GetBoolPropertyFn(true),
+				ValidSearchAttributes:          dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
+				DomainSearchAttributes:         domainSearchAttributes,
+			},
+			field:       "teamField",
+			domainID:    "other-domain-id",
+			expectedRes: false,
+		},
+		"domain not found": {
+			config: &Config{
+				EnableQueryAttributeValidation: dynamicproperties.GetBoolPropertyFn(true),
+				ValidSearchAttributes:          dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
+				DomainSearchAttributes:         domainSearchAttributes,
 			},
-			field:       "stuff",
+			field:       "teamField",
+			domainID:    "unknown-domain-id",
 			expectedRes: false,
 		},
 	}
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			res := tc.testIndexer.isValidFieldToES(tc.field)
+			ctrl := gomock.NewController(t)
+			domainCache := cache.NewMockDomainCache(ctrl)
+			domainCache.EXPECT().GetDomainName("team-domain-id").Return("team-domain", nil).AnyTimes()
+			domainCache.EXPECT().GetDomainName("other-domain-id").Return("other-domain", nil).AnyTimes()
+			domainCache.EXPECT().GetDomainName("unknown-domain-id").Return("", &types.EntityNotExistsError{}).AnyTimes()
+			testIndexer := &Indexer{
+				config:      tc.config,
+				domainCache: domainCache,
+				logger:      log.NewNoop(),
+			}
+
+			res := testIndexer.isValidFieldToES(tc.field, tc.domainID)
 			assert.Equal(t, tc.expectedRes, res)
 		})
 	}
@@ -303,16 +337,27 @@ func TestFulfillDoc_AllFieldsPresent(t *testing.T) {
 }
 
 func TestDumpFieldsToMap(t *testing.T) {
+	domainCache := cache.NewMockDomainCache(gomock.NewController(t))
+	domainCache.EXPECT().GetDomainName("id").Return("domain", nil).AnyTimes()
 	testIndexer := &Indexer{
 		config: &Config{
 			EnableQueryAttributeValidation: dynamicproperties.GetBoolPropertyFn(true),
-			ValidSearchAttributes:          dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
+			ValidSearchAttributes: dynamicproperties.GetMapPropertyFn(map[string]interface{}{
+				"CustomKeywordField":    float64(types.IndexedValueTypeKeyword),
+				"CustomKeywordFieldInt": float64(types.IndexedValueTypeInt),
+			}),
+			DomainSearchAttributes: dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
+			SearchAttributeAliases: dynamicproperties.GetMapPropertyFn(map[string]interface{}{"CustomKeywordField": "CustomKeywordFieldInt"}),
 		},
-		logger: log.NewNoop(),
-		scope:  metrics.NoopScope,
+		domainCache: domainCache,
+		logger:      log.NewNoop(),
+		scope:       metrics.NoopScope,
 	}
 
 	stringPtr := "string"
+	binaryField := func(data string) *indexer.Field {
+		return &indexer.Field{Type: indexer.FieldTypeBinary.Ptr(), BinaryData: []byte(data)}
+	}
 
 	tests := map[string]struct {
 		fields   map[string]*indexer.Field
@@ -334,6 +379,22 @@ func TestDumpFieldsToMap(t *testing.T) {
 				"Attr": map[string]interface{}{},
 			},
 		},
+		"value of the new type of a search attribute whose type changed": {
+			fields: map[string]*indexer.Field{
+				"CustomKeywordField": binaryField(`1`),
+			},
+			expected: map[string]interface{}{
+				"Attr": map[string]interface{}{"CustomKeywordFieldInt": float64(1)},
+			},
+		},
+		"value of the previous type of a search attribute whose type changed": {
+			fields: map[string]*indexer.Field{
+				"CustomKeywordField": binaryField(`"one"`),
+			},
+			expected: map[string]interface{}{
+				"Attr": map[string]interface{}{"CustomKeywordField": "one"},
+			},
+		},
 	}
 
 	for name, tc := range tests {
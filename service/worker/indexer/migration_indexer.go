@@ -21,6 +21,7 @@
 package indexer
 
 import (
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/constants"
 	es "github.com/uber/cadence/common/elasticsearch"
 	"github.com/uber/cadence/common/log"
@@ -35,6 +36,7 @@ func NewMigrationDualIndexer(config *Config,
 	client messaging.Client,
 	primaryClient es.GenericClient,
 	secondaryClient es.GenericClient,
+	domainCache cache.DomainCache,
 	primaryVisibilityName string,
 	secondaryVisibilityName string,
 	primaryConsumerName string,
@@ -65,6 +67,7 @@ func NewMigrationDualIndexer(config *Config,
 		scope:               metricsClient.Scope(metrics.IndexProcessorScope),
 		shutdownCh:          make(chan struct{}),
 		visibilityProcessor: visibilityProcessor,
+		domainCache:         domainCache,
 		msgEncoder:          defaultEncoder,
 	}
 
@@ -89,6 +92,7 @@ func NewMigrationDualIndexer(config *Config,
 		scope:               metricsClient.Scope(metrics.IndexProcessorScope),
 		shutdownCh:          make(chan struct{}),
 		visibilityProcessor: secondaryVisibilityProcessor,
+		domainCache:         domainCache,
 		msgEncoder:          defaultEncoder,
 	}
 
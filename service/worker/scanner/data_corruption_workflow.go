@@ -226,6 +226,7 @@ func NewDataCorruptionWorkflowWorker(
 	return &Scanner{
 		context: scannerContext{
 			resource: resource,
+			cfg:      params.Config,
 		},
 		tallyScope: params.TallyScope,
 		zapLogger:  zapLogger.Named("data-corruption-workflow"),
@@ -235,6 +236,8 @@ func NewDataCorruptionWorkflowWorker(
 
 func (s *Scanner) StartDataCorruptionWorkflowWorker() error {
 	ctx := context.WithValue(context.Background(), contextKey(reconciliation.CheckDataCorruptionWorkflowType), s.context)
+	// targeted scans are started on demand as well, and share the task list
+	ctx = context.WithValue(ctx, contextKey(reconciliation.TargetedScanWorkflowType), s.context)
 	workerOpts := worker.Options{
 		Logger:                                 s.zapLogger,
 		MetricsScope:                           s.tallyScope,
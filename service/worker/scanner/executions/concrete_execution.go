@@ -25,6 +25,7 @@ package executions
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"go.uber.org/cadence/workflow"
 	"go.uber.org/zap"
 
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/dynamicconfig"
@@ -40,6 +42,7 @@ import (
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/reconciliation/invariant"
 	"github.com/uber/cadence/common/reconciliation/store"
+	"github.com/uber/cadence/common/resource"
 	"github.com/uber/cadence/service/worker/scanner/shardscanner"
 )
 
@@ -120,7 +123,7 @@ func concreteExecutionScannerManager(
 	collections := ParseCollections(params.ScannerConfig)
 
 	var ivs []invariant.Invariant
-	for _, fn := range ConcreteExecutionType.ToInvariants(collections, zap.NewNop()) {
+	for _, fn := range ConcreteExecutionType.ToInvariants(collections, zap.NewNop(), scannerInvariantDeps(ctx, collections)) {
 		ivs = append(ivs, fn(pr, domainCache))
 	}
 
@@ -144,7 +147,7 @@ func concreteExecutionFixerIterator(ctx context.Context, client blobstore.Client
 }
 
 // concreteExecutionFixerManager provides invariant manager for concrete execution fixer.
-func concreteExecutionFixerManager(_ context.Context, pr persistence.Retryer, params shardscanner.FixShardActivityParams, domainCache cache.DomainCache) invariant.Manager {
+func concreteExecutionFixerManager(ctx context.Context, pr persistence.Retryer, params shardscanner.FixShardActivityParams, domainCache cache.DomainCache) invariant.Manager {
 	// convert to invariants.
 	// this may produce an empty list if it all fixers are intentionally disabled,
 	// or if the list came from a previous version of the server which lacked this config.
@@ -160,12 +163,55 @@ func concreteExecutionFixerManager(_ context.Context, pr persistence.Retryer, pa
 	}
 
 	var ivs []invariant.Invariant
-	for _, fn := range ConcreteExecutionType.ToInvariants(collections, zap.NewNop()) {
+	for _, fn := range ConcreteExecutionType.ToInvariants(collections, zap.NewNop(), fixerInvariantDeps(ctx, collections)) {
 		ivs = append(ivs, fn(pr, domainCache))
 	}
 	return invariant.NewInvariantManager(ivs)
 }
 
+// scannerInvariantDeps returns the dependencies of invariants which load executions of other shards.
+// They are only resolved when such invariants are enabled, as they require the scanner activity context.
+func scannerInvariantDeps(ctx context.Context, collections []invariant.Collection) InvariantDeps {
+	if !slices.Contains(collections, invariant.CollectionChildParent) {
+		return InvariantDeps{}
+	}
+	scannerContext, err := shardscanner.GetScannerContext(ctx)
+	if err != nil {
+		return InvariantDeps{}
+	}
+	return InvariantDeps{
+		WorkflowRetryer: resourceWorkflowRetryer(scannerContext.Resource, scannerContext.NumHistoryShards),
+	}
+}
+
+// fixerInvariantDeps returns the dependencies of fixes which repair executions through history,
+// and of invariants which load executions of other shards.
+// They are only resolved when such fixes are enabled, as they require the fixer activity context.
+func fixerInvariantDeps(ctx context.Context, collections []invariant.Collection) InvariantDeps {
+	if !slices.Contains(collections, invariant.CollectionChildParent) && !slices.Contains(collections, invariant.CollectionTimerTask) {
+		return InvariantDeps{}
+	}
+	fixerContext, err := shardscanner.GetFixerContext(ctx)
+	if err != nil {
+		return InvariantDeps{}
+	}
+	return InvariantDeps{
+		HistoryClient:   fixerContext.Resource.GetHistoryClient(),
+		WorkflowRetryer: resourceWorkflowRetryer(fixerContext.Resource, fixerContext.NumHistoryShards),
+	}
+}
+
+// resourceWorkflowRetryer loads executions through the execution manager of the shard which owns them
+func resourceWorkflowRetryer(res resource.Resource, numHistoryShards int) invariant.WorkflowRetryer {
+	return invariant.NewWorkflowRetryer(numHistoryShards, func(shardID int) (persistence.Retryer, error) {
+		execManager, err := res.GetExecutionManager(shardID)
+		if err != nil {
+			return nil, err
+		}
+		return persistence.NewPersistenceRetryerWithShardID(execManager, res.GetHistoryManager(), common.CreatePersistenceRetryPolicy(), shardID), nil
+	})
+}
+
 // concreteExecutionCustomScannerConfig resolves dynamic config for concrete executions scanner.
 func concreteExecutionCustomScannerConfig(ctx shardscanner.ScannerContext) shardscanner.CustomScannerConfig {
 	res := shardscanner.CustomScannerConfig{}
@@ -179,6 +225,12 @@ func concreteExecutionCustomScannerConfig(ctx shardscanner.ScannerContext) shard
 	if ctx.Config.DynamicCollection.GetBoolProperty(dynamicproperties.ConcreteExecutionsScannerInvariantCollectionStale)() {
 		res[invariant.CollectionStale.String()] = strconv.FormatBool(true)
 	}
+	if ctx.Config.DynamicCollection.GetBoolProperty(dynamicproperties.ConcreteExecutionsScannerInvariantCollectionChildParent)() {
+		res[invariant.CollectionChildParent.String()] = strconv.FormatBool(true)
+	}
+	if ctx.Config.DynamicCollection.GetBoolProperty(dynamicproperties.ConcreteExecutionsScannerInvariantCollectionTimerTask)() {
+		res[invariant.CollectionTimerTask.String()] = strconv.FormatBool(true)
+	}
 
 	return res
 }
@@ -197,6 +249,12 @@ func concreteExecutionCustomFixerConfig(ctx shardscanner.FixerContext) shardscan
 	res[invariant.CollectionStale.String()] = strconv.FormatBool(
 		ctx.Config.DynamicCollection.GetBoolProperty(dynamicproperties.ConcreteExecutionsFixerInvariantCollectionStale)(),
 	)
+	res[invariant.CollectionChildParent.String()] = strconv.FormatBool(
+		ctx.Config.DynamicCollection.GetBoolProperty(dynamicproperties.ConcreteExecutionsFixerInvariantCollectionChildParent)(),
+	)
+	res[invariant.CollectionTimerTask.String()] = strconv.FormatBool(
+		ctx.Config.DynamicCollection.GetBoolProperty(dynamicproperties.ConcreteExecutionsFixerInvariantCollectionTimerTask)(),
+	)
 
 	return res
 }
@@ -450,7 +450,7 @@ func Test_concreteExecutionCustomScannerConfig(t *testing.T) {
 
 	collection := dynamicconfig.NewCollection(mockClient, log.NewNoop())
 
-	mockClient.EXPECT().GetBoolValue(gomock.Any(), gomock.Any()).Return(true, nil).Times(3)
+	mockClient.EXPECT().GetBoolValue(gomock.Any(), gomock.Any()).Return(true, nil).Times(5)
 
 	ctx := shardscanner.ScannerContext{
 		Config: &shardscanner.ScannerConfig{
@@ -461,10 +461,12 @@ func Test_concreteExecutionCustomScannerConfig(t *testing.T) {
 	cfg := concreteExecutionCustomScannerConfig(ctx)
 
 	assert.NotNil(t, cfg)
-	assert.Len(t, cfg, 3)
+	assert.Len(t, cfg, 5)
 	assert.Equal(t, "true", cfg[invariant.CollectionHistory.String()])
 	assert.Equal(t, "true", cfg[invariant.CollectionMutableState.String()])
 	assert.Equal(t, "true", cfg[invariant.CollectionStale.String()])
+	assert.Equal(t, "true", cfg[invariant.CollectionChildParent.String()])
+	assert.Equal(t, "true", cfg[invariant.CollectionTimerTask.String()])
 }
 
 func Test_concreteExecutionCustomFixerConfig(t *testing.T) {
@@ -472,7 +474,7 @@ func Test_concreteExecutionCustomFixerConfig(t *testing.T) {
 
 	collection := dynamicconfig.NewCollection(mockClient, log.NewNoop())
 
-	mockClient.EXPECT().GetBoolValue(gomock.Any(), gomock.Any()).Return(true, nil).Times(3)
+	mockClient.EXPECT().GetBoolValue(gomock.Any(), gomock.Any()).Return(true, nil).Times(5)
 
 	ctx := shardscanner.FixerContext{
 		Config: &shardscanner.ScannerConfig{
@@ -483,10 +485,12 @@ func Test_concreteExecutionCustomFixerConfig(t *testing.T) {
 	cfg := concreteExecutionCustomFixerConfig(ctx)
 
 	assert.NotNil(t, cfg)
-	assert.Len(t, cfg, 3)
+	assert.Len(t, cfg, 5)
 	assert.Equal(t, "true", cfg[invariant.CollectionHistory.String()])
 	assert.Equal(t, "true", cfg[invariant.CollectionMutableState.String()])
 	assert.Equal(t, "true", cfg[invariant.CollectionStale.String()])
+	assert.Equal(t, "true", cfg[invariant.CollectionChildParent.String()])
+	assert.Equal(t, "true", cfg[invariant.CollectionTimerTask.String()])
 }
 
 func TestConcreteExecutionConfig(t *testing.T) {
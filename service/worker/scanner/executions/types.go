@@ -30,6 +30,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/pagination"
 	"github.com/uber/cadence/common/persistence"
@@ -55,6 +56,17 @@ type (
 
 	// ExecutionFetcher represents a function which returns specific execution entity
 	ExecutionFetcher func(ctx context.Context, retryer persistence.Retryer, request fetcher.ExecutionRequest) (entity.Entity, error)
+
+	// InvariantDeps are the dependencies shared by the invariants returned from ToInvariants, all of them are optional.
+	InvariantDeps struct {
+		// HistoryClient is used by invariants which repair executions through history,
+		// it may be nil when fixes are not going to be run.
+		HistoryClient history.Client
+		// WorkflowRetryer loads executions owned by other shards, such as parents and children.
+		WorkflowRetryer invariant.WorkflowRetryer
+		// TimerTaskIndexes shares the loaded timer queues of shards between invariants created per execution.
+		TimerTaskIndexes *invariant.TimerTaskIndexCache
+	}
 )
 
 // ToBlobstoreEntity picks struct depending on scanner type.
@@ -93,7 +105,7 @@ func (st ScanType) ToExecutionFetcher() ExecutionFetcher {
 }
 
 // ToInvariants returns list of invariants to be checked depending on scan type.
-func (st ScanType) ToInvariants(collections []invariant.Collection, logger *zap.Logger) []InvariantFactory {
+func (st ScanType) ToInvariants(collections []invariant.Collection, logger *zap.Logger, deps InvariantDeps) []InvariantFactory {
 	var fns []InvariantFactory
 	switch st {
 	case ConcreteExecutionType:
@@ -109,6 +121,14 @@ func (st ScanType) ToInvariants(collections []invariant.Collection, logger *zap.
 				})
 			case invariant.CollectionMutableState:
 				fns = append(fns, invariant.NewOpenCurrentExecution)
+			case invariant.CollectionChildParent:
+				fns = append(fns, func(pr persistence.Retryer, dc cache.DomainCache) invariant.Invariant {
+					return invariant.NewChildParentLinkage(pr, dc, deps.HistoryClient, deps.WorkflowRetryer)
+				})
+			case invariant.CollectionTimerTask:
+				fns = append(fns, func(pr persistence.Retryer, dc cache.DomainCache) invariant.Invariant {
+					return invariant.NewDanglingTimer(pr, dc, deps.HistoryClient, deps.TimerTaskIndexes)
+				})
 			}
 		}
 		return fns
@@ -191,7 +191,7 @@ func (s *Scanner) startShardScanner(
 		ctx = shardscanner.NewScannerContext(
 			ctx,
 			config.ScannerWFTypeName,
-			shardscanner.NewShardScannerContext(s.context.resource, config),
+			shardscanner.NewShardScannerContext(s.context.resource, config, s.context.cfg.Persistence.NumHistoryShards),
 		)
 		go s.startWorkflowWithRetryFn(
 			config.ScannerWFTypeName,
@@ -215,7 +215,7 @@ func (s *Scanner) startShardScanner(
 		ctx = shardscanner.NewFixerContext(
 			ctx,
 			config.FixerWFTypeName,
-			shardscanner.NewShardFixerContext(s.context.resource, config),
+			shardscanner.NewShardFixerContext(s.context.resource, config, s.context.cfg.Persistence.NumHistoryShards),
 		)
 		go s.startWorkflowWithRetryFn(
 			config.FixerWFTypeName,
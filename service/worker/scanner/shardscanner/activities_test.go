@@ -139,7 +139,7 @@ func (s *activitiesSuite) TestScanShardActivity() {
 		})
 		sc := NewShardScannerContext(s.mockResource, &ScannerConfig{
 			ScannerHooks: func() *ScannerHooks { return hooks },
-		})
+		}, 16)
 		ctx := NewScannerContext(context.Background(), tc.workflowName, sc)
 		env.SetWorkerOptions(worker.Options{
 			BackgroundActivityContext: ctx,
@@ -242,7 +242,7 @@ func (s *activitiesSuite) TestFixShardActivity() {
 					}
 				}
 			}
-			fc := NewShardFixerContext(s.mockResource, cfg)
+			fc := NewShardFixerContext(s.mockResource, cfg, 16)
 
 			env := s.NewTestActivityEnvironment()
 
@@ -372,7 +372,7 @@ func (s *activitiesSuite) TestScannerConfigActivity() {
 			BackgroundActivityContext: NewScannerContext(
 				context.Background(),
 				testWorkflowName,
-				NewShardScannerContext(s.mockResource, cfg),
+				NewShardScannerContext(s.mockResource, cfg, 16),
 			),
 		},
 		)
@@ -498,7 +498,7 @@ func (s *activitiesSuite) getFixerActivityEnvironment() *testsuite.TestActivityE
 			return &FixerHooks{}
 		},
 	}
-	fc := NewShardFixerContext(s.mockResource, cfg)
+	fc := NewShardFixerContext(s.mockResource, cfg, 16)
 	env.SetWorkerOptions(worker.Options{
 		BackgroundActivityContext: NewFixerContext(context.Background(), testWorkflowName, fc),
 	})
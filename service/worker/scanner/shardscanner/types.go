@@ -57,20 +57,22 @@ type (
 
 	// ScannerContext is the resource that is available in activities under ShardScanner context key
 	ScannerContext struct {
-		Resource resource.Resource
-		Hooks    *ScannerHooks
-		Scope    metrics.Scope
-		Config   *ScannerConfig
-		Logger   log.Logger
+		Resource         resource.Resource
+		Hooks            *ScannerHooks
+		Scope            metrics.Scope
+		Config           *ScannerConfig
+		Logger           log.Logger
+		NumHistoryShards int
 	}
 
 	// FixerContext is the resource that is available to activities under ShardFixer key
 	FixerContext struct {
-		Resource resource.Resource
-		Hooks    *FixerHooks
-		Scope    metrics.Scope
-		Config   *ScannerConfig
-		Logger   log.Logger
+		Resource         resource.Resource
+		Hooks            *FixerHooks
+		Scope            metrics.Scope
+		Config           *ScannerConfig
+		Logger           log.Logger
+		NumHistoryShards int
 	}
 
 	// ScannerEmitMetricsActivityParams is the parameter for scannerEmitMetricsActivity
@@ -448,13 +450,15 @@ func (s Shards) Flatten() ([]int, int, int) {
 func NewShardScannerContext(
 	res resource.Resource,
 	config *ScannerConfig,
+	numHistoryShards int,
 ) ScannerContext {
 	return ScannerContext{
-		Resource: res,
-		Scope:    res.GetMetricsClient().Scope(metrics.ExecutionsScannerScope),
-		Config:   config,
-		Logger:   res.GetLogger().WithTags(tag.ComponentShardScanner),
-		Hooks:    config.ScannerHooks(),
+		Resource:         res,
+		Scope:            res.GetMetricsClient().Scope(metrics.ExecutionsScannerScope),
+		Config:           config,
+		Logger:           res.GetLogger().WithTags(tag.ComponentShardScanner),
+		Hooks:            config.ScannerHooks(),
+		NumHistoryShards: numHistoryShards,
 	}
 }
 
@@ -462,13 +466,15 @@ func NewShardScannerContext(
 func NewShardFixerContext(
 	res resource.Resource,
 	config *ScannerConfig,
+	numHistoryShards int,
 ) FixerContext {
 	return FixerContext{
-		Resource: res,
-		Scope:    res.GetMetricsClient().Scope(metrics.ExecutionsFixerScope),
-		Config:   config,
-		Hooks:    config.FixerHooks(),
-		Logger:   res.GetLogger().WithTags(tag.ComponentShardFixer),
+		Resource:         res,
+		Scope:            res.GetMetricsClient().Scope(metrics.ExecutionsFixerScope),
+		Config:           config,
+		Hooks:            config.FixerHooks(),
+		Logger:           res.GetLogger().WithTags(tag.ComponentShardFixer),
+		NumHistoryShards: numHistoryShards,
 	}
 }
 
@@ -0,0 +1,320 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/workflow"
+	"go.uber.org/zap"
+
+	c "github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/reconciliation"
+	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/reconciliation/fetcher"
+	"github.com/uber/cadence/common/reconciliation/invariant"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/scanner/executions"
+)
+
+func init() {
+	workflow.RegisterWithOptions(
+		TargetedScanWorkflow,
+		workflow.RegisterOptions{Name: reconciliation.TargetedScanWorkflowType},
+	)
+	activity.Register(TargetedScanActivity)
+}
+
+const (
+	targetedScanPageSize = 100
+	// targetedScanPagesPerRun is the number of pages scanned before the workflow continues as new
+	targetedScanPagesPerRun     = 50
+	targetedScanActivityTimeout = 30 * time.Minute
+	targetedScanHeartbeat       = time.Minute
+
+	errTargetedScanInvalidParams = "invalid-targeted-scan-params"
+)
+
+type (
+	// targetedScanCursor is the position of a targeted scan in its selected executions
+	targetedScanCursor struct {
+		// NextPageToken is the visibility page token when executions are selected by query
+		NextPageToken []byte
+		// Offset is the index of the next workflow ID when executions are selected by ID
+		Offset int
+		Done   bool
+		// Selected and Reported are the totals of the report when the page starts,
+		// they bound the page by MaxExecutions and TargetedScanMaxReportedExecutions
+		Selected int
+		Reported int
+	}
+
+	// targetedScanPage is the result of scanning one page of executions.
+	// It only lists the executions which still fit in the report, so that activity results stay small.
+	targetedScanPage struct {
+		Report reconciliation.TargetedScanReport
+		Cursor targetedScanCursor
+	}
+)
+
+// TargetedScanWorkflow scans, and optionally fixes, the executions selected by params.
+// It is started on demand by operators, and its report can be queried while it runs.
+// The workflow continues as new every targetedScanPagesPerRun pages, carrying its report in params.Progress.
+func TargetedScanWorkflow(ctx workflow.Context, params reconciliation.TargetedScanParams) (*reconciliation.TargetedScanReport, error) {
+	var cursor targetedScanCursor
+	report := &reconciliation.TargetedScanReport{}
+	if progress := params.Progress; progress != nil {
+		*report = progress.Report
+		cursor.NextPageToken = progress.NextPageToken
+		cursor.Offset = progress.Offset
+		params.Progress = nil
+	}
+	report.Params = params
+	if err := workflow.SetQueryHandler(ctx, reconciliation.TargetedScanReportQuery, func() (*reconciliation.TargetedScanReport, error) {
+		return report, nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := validateTargetedScanParams(params); err != nil {
+		return nil, cadence.NewCustomError(errTargetedScanInvalidParams, err.Error())
+	}
+
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: time.Minute,
+		StartToCloseTimeout:    targetedScanActivityTimeout,
+		HeartbeatTimeout:       targetedScanHeartbeat,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          activityRetryPolicy.InitialInterval,
+			BackoffCoefficient:       activityRetryPolicy.BackoffCoefficient,
+			MaximumInterval:          activityRetryPolicy.MaximumInterval,
+			ExpirationInterval:       time.Hour,
+			NonRetriableErrorReasons: []string{errTargetedScanInvalidParams},
+		},
+	})
+	for pages := 0; !cursor.Done; pages++ {
+		if pages == targetedScanPagesPerRun {
+			progress := &reconciliation.TargetedScanProgress{
+				Report:        *report,
+				NextPageToken: cursor.NextPageToken,
+				Offset:        cursor.Offset,
+			}
+			progress.Report.Params = reconciliation.TargetedScanParams{}
+			params.Progress = progress
+			return nil, workflow.NewContinueAsNewError(ctx, reconciliation.TargetedScanWorkflowType, params)
+		}
+
+		cursor.Selected = report.Selected()
+		cursor.Reported = len(report.Executions)
+		var page targetedScanPage
+		if err := workflow.ExecuteActivity(activityCtx, TargetedScanActivity, params, cursor).Get(ctx, &page); err != nil {
+			workflow.GetLogger(ctx).Error("failed to run targeted scan activity", zap.Error(err))
+			return report, err
+		}
+		report.Merge(page.Report)
+		cursor = page.Cursor
+	}
+	report.Completed = true
+	return report, nil
+}
+
+// TargetedScanActivity scans one page of the executions selected by params, starting at cursor
+func TargetedScanActivity(
+	ctx context.Context,
+	params reconciliation.TargetedScanParams,
+	cursor targetedScanCursor,
+) (*targetedScanPage, error) {
+	sc, err := getScannerContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find key %v in context", reconciliation.TargetedScanWorkflowType)
+	}
+	res := sc.resource
+	domainID, err := res.GetDomainCache().GetDomainID(params.Domain)
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			return nil, cadence.NewCustomError(errTargetedScanInvalidParams, err.Error())
+		}
+		return nil, err
+	}
+
+	var (
+		workflowExecutions []types.WorkflowExecution
+		next               targetedScanCursor
+	)
+	if len(params.WorkflowIDs) > 0 {
+		end := cursor.Offset + targetedScanPageSize
+		if end >= len(params.WorkflowIDs) {
+			end = len(params.WorkflowIDs)
+			next.Done = true
+		}
+		for _, workflowID := range params.WorkflowIDs[cursor.Offset:end] {
+			workflowExecutions = append(workflowExecutions, types.WorkflowExecution{WorkflowID: workflowID})
+		}
+		next.Offset = end
+	} else {
+		resp, err := res.GetFrontendClient().ScanWorkflowExecutions(ctx, &types.ListWorkflowExecutionsRequest{
+			Domain:        params.Domain,
+			PageSize:      targetedScanPageSize,
+			NextPageToken: cursor.NextPageToken,
+			Query:         params.Query,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range resp.GetExecutions() {
+			workflowExecutions = append(workflowExecutions, *info.GetExecution())
+		}
+		next.NextPageToken = resp.GetNextPageToken()
+		next.Done = len(next.NextPageToken) == 0
+	}
+
+	collections, err := parseTargetedScanCollections(params.Collections)
+	if err != nil {
+		return nil, cadence.NewCustomError(errTargetedScanInvalidParams, err.Error())
+	}
+	numShards := sc.cfg.Persistence.NumHistoryShards
+	deps := executions.InvariantDeps{
+		HistoryClient: res.GetHistoryClient(),
+		WorkflowRetryer: invariant.NewWorkflowRetryer(numShards, func(shardID int) (persistence.Retryer, error) {
+			pr, _, err := getDefaultDAO(ctx, shardID)
+			return pr, err
+		}),
+		TimerTaskIndexes: invariant.NewTimerTaskIndexCache(),
+	}
+	page := &targetedScanPage{Cursor: next}
+	reportBudget := reconciliation.TargetedScanMaxReportedExecutions - cursor.Reported
+	for i, workflowExecution := range workflowExecutions {
+		if params.MaxExecutions > 0 && cursor.Selected+page.Report.Selected() >= params.MaxExecutions {
+			page.Cursor = targetedScanCursor{Done: true}
+			break
+		}
+		shardID := c.WorkflowIDToHistoryShard(workflowExecution.WorkflowID, numShards)
+		pr, domainCache, err := getDefaultDAO(ctx, shardID)
+		if err != nil {
+			return nil, err
+		}
+		result, err := scanExecution(ctx, pr, domainID, params, workflowExecution, func() invariant.Manager {
+			var ivs []invariant.Invariant
+			for _, fn := range executions.ConcreteExecutionType.ToInvariants(collections, zap.NewNop(), deps) {
+				ivs = append(ivs, fn(pr, domainCache))
+			}
+			return invariant.NewInvariantManager(ivs)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			page.Report.Missing++
+		} else {
+			page.Report.Add(*result)
+			if len(page.Report.Executions) > reportBudget {
+				page.Report.Executions = page.Report.Executions[:reportBudget]
+				page.Report.Truncated = true
+			}
+		}
+		activity.RecordHeartbeat(ctx, i)
+	}
+	if params.MaxExecutions > 0 && cursor.Selected+page.Report.Selected() >= params.MaxExecutions {
+		page.Cursor = targetedScanCursor{Done: true}
+	}
+	return page, nil
+}
+
+// scanExecution runs the checks, and fixes if requested, on a single execution.
+// It returns nil if the execution does not exist.
+func scanExecution(
+	ctx context.Context,
+	pr persistence.Retryer,
+	domainID string,
+	params reconciliation.TargetedScanParams,
+	workflowExecution types.WorkflowExecution,
+	newManager func() invariant.Manager,
+) (*reconciliation.TargetedScanExecution, error) {
+	runID := workflowExecution.RunID
+	if runID == "" {
+		current, err := pr.GetCurrentExecution(ctx, &persistence.GetCurrentExecutionRequest{
+			DomainID:   domainID,
+			WorkflowID: workflowExecution.WorkflowID,
+			DomainName: params.Domain,
+		})
+		if err != nil {
+			if _, ok := err.(*types.EntityNotExistsError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+		runID = current.RunID
+	}
+	concreteExecution, err := fetcher.ConcreteExecution(ctx, pr, fetcher.ExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: workflowExecution.WorkflowID,
+		RunID:      runID,
+		DomainName: params.Domain,
+	})
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	manager := newManager()
+	result := &reconciliation.TargetedScanExecution{
+		Execution:   concreteExecution.(*entity.ConcreteExecution).Execution,
+		CheckResult: manager.RunChecks(ctx, concreteExecution),
+	}
+	if params.Fix && result.CheckResult.CheckResultType == invariant.CheckResultTypeCorrupted {
+		fixResult := manager.RunFixes(ctx, concreteExecution)
+		result.FixResult = &fixResult
+	}
+	return result, nil
+}
+
+func validateTargetedScanParams(params reconciliation.TargetedScanParams) error {
+	if params.Domain == "" {
+		return errors.New("domain is required")
+	}
+	if params.Query != "" && len(params.WorkflowIDs) > 0 {
+		return errors.New("query and workflow IDs are mutually exclusive")
+	}
+	_, err := parseTargetedScanCollections(params.Collections)
+	return err
+}
+
+func parseTargetedScanCollections(names []string) ([]invariant.Collection, error) {
+	if len(names) == 0 {
+		return nil, errors.New("at least one invariant collection is required")
+	}
+	collections := make([]invariant.Collection, 0, len(names))
+	for _, name := range names {
+		collection, err := invariant.CollectionString(name)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, collection)
+	}
+	return collections, nil
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scanner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/worker"
+	"go.uber.org/cadence/workflow"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/metrics"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/reconciliation"
+	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/reconciliation/invariant"
+	"github.com/uber/cadence/common/resource"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestTargetedScanWorkflow(t *testing.T) {
+	params := reconciliation.TargetedScanParams{
+		Domain:      "test-domain",
+		Collections: []string{invariant.CollectionTimerTask.String()},
+	}
+	workflowIDParams := reconciliation.TargetedScanParams{
+		Domain:      "test-domain",
+		WorkflowIDs: []string{"wid1", "wid2"},
+		Collections: []string{invariant.CollectionTimerTask.String()},
+	}
+	corrupted := reconciliation.TargetedScanExecution{
+		Execution: entity.Execution{WorkflowID: "wid"},
+		CheckResult: invariant.ManagerCheckResult{
+			CheckResultType: invariant.CheckResultTypeCorrupted,
+		},
+	}
+
+	tests := map[string]struct {
+		params reconciliation.TargetedScanParams
+		setup  func(env *testsuite.TestWorkflowEnvironment)
+		// wantReport is the result and the queried report of a completed scan
+		wantReport *reconciliation.TargetedScanReport
+		// wantProgress is the progress carried over when the scan continues as new
+		wantProgress *reconciliation.TargetedScanProgress
+		wantErr      bool
+	}{
+		"pages are merged into the report": {
+			params: params,
+			setup: func(env *testsuite.TestWorkflowEnvironment) {
+				env.OnActivity(TargetedScanActivity, mock.Anything, params, targetedScanCursor{}).Return(&targetedScanPage{
+					Report: reconciliation.TargetedScanReport{Scanned: 2, Healthy: 1, Corrupted: 1, Executions: []reconciliation.TargetedScanExecution{corrupted}},
+					Cursor: targetedScanCursor{NextPageToken: []byte("next")},
+				}, nil).Once()
+				env.OnActivity(TargetedScanActivity, mock.Anything, params, targetedScanCursor{NextPageToken: []byte("next"), Selected: 2, Reported: 1}).Return(&targetedScanPage{
+					Report: reconciliation.TargetedScanReport{Scanned: 1, Healthy: 1, Missing: 1},
+					Cursor: targetedScanCursor{Done: true},
+				}, nil).Once()
+			},
+			wantReport: &reconciliation.TargetedScanReport{
+				Params:     params,
+				Completed:  true,
+				Scanned:    3,
+				Healthy:    2,
+				Corrupted:  1,
+				Missing:    1,
+				Executions: []reconciliation.TargetedScanExecution{corrupted},
+			},
+		},
+		"continues as new after a number of pages": {
+			params: params,
+			setup: func(env *testsuite.TestWorkflowEnvironment) {
+				env.OnActivity(TargetedScanActivity, mock.Anything, params, mock.Anything).Return(&targetedScanPage{
+					Report: reconciliation.TargetedScanReport{Scanned: 1, Healthy: 1},
+					Cursor: targetedScanCursor{NextPageToken: []byte("next")},
+				}, nil).Times(targetedScanPagesPerRun)
+			},
+			wantProgress: &reconciliation.TargetedScanProgress{
+				Report:        reconciliation.TargetedScanReport{Scanned: targetedScanPagesPerRun, Healthy: targetedScanPagesPerRun},
+				NextPageToken: []byte("next"),
+			},
+		},
+		"resumes the progress of the previous run": {
+			params: func() reconciliation.TargetedScanParams {
+				resumed := workflowIDParams
+				resumed.Progress = &reconciliation.TargetedScanProgress{
+					Report: reconciliation.TargetedScanReport{Scanned: 1, Healthy: 1},
+					Offset: 1,
+				}
+				return resumed
+			}(),
+			setup: func(env *testsuite.TestWorkflowEnvironment) {
+				env.OnActivity(TargetedScanActivity, mock.Anything, workflowIDParams, targetedScanCursor{Offset: 1, Selected: 1}).Return(&targetedScanPage{
+					Report: reconciliation.TargetedScanReport{Scanned: 1, Healthy: 1},
+					Cursor: targetedScanCursor{Offset: 2, Done: true},
+				}, nil).Once()
+			},
+			wantReport: &reconciliation.TargetedScanReport{
+				Params:    workflowIDParams,
+				Completed: true,
+				Scanned:   2,
+				Healthy:   2,
+			},
+		},
+		"invalid collection": {
+			params: reconciliation.TargetedScanParams{
+				Domain:      "test-domain",
+				Collections: []string{"NotACollection"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ts := &testsuite.WorkflowTestSuite{}
+			env := ts.NewTestWorkflowEnvironment()
+			if tc.setup != nil {
+				tc.setup(env)
+			}
+
+			env.ExecuteWorkflow(reconciliation.TargetedScanWorkflowType, tc.params)
+			require.True(t, env.IsWorkflowCompleted())
+			env.AssertExpectations(t)
+
+			switch {
+			case tc.wantErr:
+				assert.Error(t, env.GetWorkflowError())
+			case tc.wantProgress != nil:
+				var continueAsNew *workflow.ContinueAsNewError
+				require.ErrorAs(t, env.GetWorkflowError(), &continueAsNew)
+				next, ok := continueAsNew.Args()[0].(reconciliation.TargetedScanParams)
+				require.True(t, ok)
+				assert.Equal(t, tc.wantProgress, next.Progress)
+			default:
+				require.NoError(t, env.GetWorkflowError())
+				var report reconciliation.TargetedScanReport
+				require.NoError(t, env.GetWorkflowResult(&report))
+				assert.Equal(t, *tc.wantReport, report)
+
+				value, err := env.QueryWorkflow(reconciliation.TargetedScanReportQuery)
+				require.NoError(t, err)
+				var queried reconciliation.TargetedScanReport
+				require.NoError(t, value.Get(&queried))
+				assert.Equal(t, report, queried)
+			}
+		})
+	}
+}
+
+func TestTargetedScanActivity(t *testing.T) {
+	completedExecution := &p.GetWorkflowExecutionResponse{
+		State: &p.WorkflowMutableState{
+			ExecutionInfo: &p.WorkflowExecutionInfo{
+				DomainID:    "test-domain-id",
+				WorkflowID:  "wid",
+				RunID:       "rid",
+				State:       p.WorkflowStateCompleted,
+				BranchToken: validBranchToken,
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		params     reconciliation.TargetedScanParams
+		cursor     targetedScanCursor
+		setup      func(mockResource *resource.Test)
+		wantCursor targetedScanCursor
+		wantReport reconciliation.TargetedScanReport
+	}{
+		"workflow IDs which no longer exist are reported as missing": {
+			params: reconciliation.TargetedScanParams{
+				Domain:      "test-domain",
+				WorkflowIDs: []string{"wid", "wid-missing"},
+				Collections: []string{invariant.CollectionMutableState.String()},
+			},
+			setup: func(mockResource *resource.Test) {
+				mockResource.DomainCache.EXPECT().GetDomainName(gomock.Any()).Return("test-domain", nil).AnyTimes()
+				mockResource.ExecutionMgr.On("GetCurrentExecution", mock.Anything, mock.MatchedBy(func(req *p.GetCurrentExecutionRequest) bool {
+					return req.WorkflowID == "wid-missing"
+				})).Return(nil, &types.EntityNotExistsError{})
+				mockResource.ExecutionMgr.On("GetCurrentExecution", mock.Anything, mock.Anything).Return(&p.GetCurrentExecutionResponse{RunID: "rid"}, nil)
+				mockResource.ExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(completedExecution, nil)
+			},
+			wantCursor: targetedScanCursor{Offset: 2, Done: true},
+			wantReport: reconciliation.TargetedScanReport{Scanned: 1, Healthy: 1, Missing: 1},
+		},
+		"executions selected by earlier pages count toward the limit": {
+			params: reconciliation.TargetedScanParams{
+				Domain:        "test-domain",
+				WorkflowIDs:   []string{"wid1", "wid2", "wid3"},
+				Collections:   []string{invariant.CollectionMutableState.String()},
+				MaxExecutions: 3,
+			},
+			cursor: targetedScanCursor{Selected: 2},
+			setup: func(mockResource *resource.Test) {
+				mockResource.DomainCache.EXPECT().GetDomainName(gomock.Any()).Return("test-domain", nil).AnyTimes()
+				mockResource.ExecutionMgr.On("GetCurrentExecution", mock.Anything, mock.Anything).Return(&p.GetCurrentExecutionResponse{RunID: "rid"}, nil).Once()
+				mockResource.ExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(completedExecution, nil).Once()
+			},
+			wantCursor: targetedScanCursor{Done: true},
+			wantReport: reconciliation.TargetedScanReport{Scanned: 1, Healthy: 1},
+		},
+		"missing executions count toward the limit": {
+			params: reconciliation.TargetedScanParams{
+				Domain:        "test-domain",
+				WorkflowIDs:   []string{"wid1", "wid2", "wid3"},
+				Collections:   []string{invariant.CollectionMutableState.String()},
+				MaxExecutions: 2,
+			},
+			setup: func(mockResource *resource.Test) {
+				mockResource.ExecutionMgr.On("GetCurrentExecution", mock.Anything, mock.Anything).Return(nil, &types.EntityNotExistsError{}).Twice()
+			},
+			wantCursor: targetedScanCursor{Done: true},
+			wantReport: reconciliation.TargetedScanReport{Missing: 2},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			mockResource := resource.NewTest(t, controller, metrics.Worker)
+			defer mockResource.Finish(t)
+			mockResource.DomainCache.EXPECT().GetDomainID("test-domain").Return("test-domain-id", nil).AnyTimes()
+			tc.setup(mockResource)
+
+			ts := &testsuite.WorkflowTestSuite{}
+			env := ts.NewTestActivityEnvironment()
+			env.SetTestTimeout(time.Second * 5)
+			env.SetWorkerOptions(worker.Options{
+				BackgroundActivityContext: context.WithValue(context.Background(), contextKey(testWorkflowName), scannerContext{
+					resource: mockResource,
+					cfg:      Config{Persistence: &config.Persistence{NumHistoryShards: 4}},
+				}),
+			})
+
+			value, err := env.ExecuteActivity(TargetedScanActivity, tc.params, tc.cursor)
+			require.NoError(t, err)
+			var page targetedScanPage
+			require.NoError(t, value.Get(&page))
+			assert.Equal(t, tc.wantCursor, page.Cursor)
+			assert.Equal(t, tc.wantReport, page.Report)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package visibility
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/pagination"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/reconciliation/fetcher"
+	"github.com/uber/cadence/common/reconciliation/invariant"
+	"github.com/uber/cadence/common/reconciliation/store"
+	"github.com/uber/cadence/service/worker/scanner/shardscanner"
+)
+
+const (
+	// ScannerWFTypeName defines workflow type name for visibility scanner
+	ScannerWFTypeName   = "cadence-sys-visibility-scanner-workflow"
+	wfid                = "cadence-sys-visibility-scanner"
+	scannerTaskListName = "cadence-sys-visibility-scanner-tasklist-0"
+
+	// FixerWFTypeName defines workflow type name for visibility fixer
+	FixerWFTypeName   = "cadence-sys-visibility-fixer-workflow"
+	fixerTaskListName = "cadence-sys-visibility-fixer-tasklist-0"
+	fixerwfid         = "cadence-sys-visibility-fixer"
+	sampleRateKey     = "sample_rate"
+	storesKey         = "stores"
+)
+
+// ScannerWorkflow starts visibility scanner.
+func ScannerWorkflow(
+	ctx workflow.Context,
+	params shardscanner.ScannerWorkflowParams,
+) error {
+	wf, err := shardscanner.NewScannerWorkflow(ctx, ScannerWFTypeName, params)
+	if err != nil {
+		return err
+	}
+
+	return wf.Start(ctx)
+}
+
+// FixerWorkflow starts visibility fixer.
+func FixerWorkflow(
+	ctx workflow.Context,
+	params shardscanner.FixerWorkflowParams,
+) error {
+	wf, err := shardscanner.NewFixerWorkflow(ctx, FixerWFTypeName, params)
+	if err != nil {
+		return err
+	}
+
+	return wf.Start(ctx)
+}
+
+// ScannerHooks provides hooks for visibility scanner.
+func ScannerHooks() *shardscanner.ScannerHooks {
+	h, err := shardscanner.NewScannerHooks(Manager, Iterator, Config)
+	if err != nil {
+		return nil
+	}
+
+	return h
+}
+
+// FixerHooks provides hooks needed for visibility fixer.
+func FixerHooks() *shardscanner.FixerHooks {
+	h, err := shardscanner.NewFixerHooks(FixerManager, FixerIterator, visibilityCustomConfig)
+	if err != nil {
+		return nil
+	}
+	return h
+}
+
+func visibilityCustomConfig(_ shardscanner.FixerContext) shardscanner.CustomScannerConfig {
+	// must be non-empty to pass backwards-compat check,
+	// there is only one invariant, which is enabled with the fixer.
+	return map[string]string{
+		string(invariant.VisibilityConsistency): "true",
+	}
+}
+
+// Manager provides invariant manager for visibility scanner.
+// Visibility records are compared with the executions in the visibility stores resolved when the scan started.
+func Manager(
+	ctx context.Context,
+	pr persistence.Retryer,
+	params shardscanner.ScanShardActivityParams,
+	cache cache.DomainCache,
+) invariant.Manager {
+	var visibilityManager persistence.VisibilityManager
+	if scannerContext, err := shardscanner.GetScannerContext(ctx); err == nil {
+		visibilityManager = scannerContext.Resource.GetVisibilityManager()
+	}
+	stores := parseStores(params.ScannerConfig[storesKey])
+	return invariant.NewInvariantManager(getInvariants(pr, cache, visibilityManager, stores))
+}
+
+// Iterator provides iterator for visibility scanner, which samples the concrete executions of a shard.
+func Iterator(
+	ctx context.Context,
+	pr persistence.Retryer,
+	params shardscanner.ScanShardActivityParams,
+) pagination.Iterator {
+	sampleRate, err := strconv.ParseFloat(params.ScannerConfig[sampleRateKey], 64)
+	if err != nil {
+		return nil
+	}
+
+	return fetcher.SampledConcreteExecutionIterator(ctx, pr, params.PageSize, sampleRate)
+}
+
+// FixerIterator provides iterator for visibility fixer.
+func FixerIterator(
+	ctx context.Context,
+	client blobstore.Client,
+	keys store.Keys,
+	_ shardscanner.FixShardActivityParams,
+) store.ScanOutputIterator {
+	return store.NewBlobstoreIterator(ctx, client, keys, &entity.ConcreteExecution{})
+}
+
+// FixerManager provides invariant manager for visibility fixer.
+// Fixes republish the visibility records of corrupted executions from their mutable state through the visibility manager
+// of the worker, which writes to the stores in the WriteVisibilityStoreName dynamic config.
+func FixerManager(
+	ctx context.Context,
+	pr persistence.Retryer,
+	_ shardscanner.FixShardActivityParams,
+	cache cache.DomainCache,
+) invariant.Manager {
+	var (
+		visibilityManager persistence.VisibilityManager
+		stores            []string
+	)
+	if fixerContext, err := shardscanner.GetFixerContext(ctx); err == nil {
+		visibilityManager = fixerContext.Resource.GetVisibilityManager()
+		stores = checkedStores(fixerContext.Config.DynamicCollection)
+	}
+	return invariant.NewInvariantManager(getInvariants(pr, cache, visibilityManager, stores))
+}
+
+// Config resolves dynamic config for visibility scanner.
+func Config(ctx shardscanner.ScannerContext) shardscanner.CustomScannerConfig {
+	res := shardscanner.CustomScannerConfig{}
+	res[sampleRateKey] = strconv.FormatFloat(ctx.Config.DynamicCollection.GetFloat64Property(dynamicproperties.VisibilityScannerSampleRate)(), 'f', -1, 64)
+	res[storesKey] = strings.Join(checkedStores(ctx.Config.DynamicCollection), ",")
+	return res
+}
+
+// ScannerConfig configures visibility scanner
+func ScannerConfig(dc *dynamicconfig.Collection) *shardscanner.ScannerConfig {
+	return &shardscanner.ScannerConfig{
+		ScannerWFTypeName: ScannerWFTypeName,
+		FixerWFTypeName:   FixerWFTypeName,
+		DynamicParams: shardscanner.DynamicParams{
+			ScannerEnabled:          dc.GetBoolProperty(dynamicproperties.VisibilityScannerEnabled),
+			FixerEnabled:            dc.GetBoolProperty(dynamicproperties.VisibilityFixerEnabled),
+			Concurrency:             dc.GetIntProperty(dynamicproperties.VisibilityScannerConcurrency),
+			PageSize:                dc.GetIntProperty(dynamicproperties.VisibilityScannerPersistencePageSize),
+			BlobstoreFlushThreshold: dc.GetIntProperty(dynamicproperties.VisibilityScannerBlobstoreFlushThreshold),
+			ActivityBatchSize:       dc.GetIntProperty(dynamicproperties.VisibilityScannerActivityBatchSize),
+			AllowDomain:             dc.GetBoolPropertyFilteredByDomain(dynamicproperties.VisibilityFixerDomainAllow),
+		},
+		DynamicCollection: dc,
+		ScannerHooks:      ScannerHooks,
+		FixerHooks:        FixerHooks,
+
+		StartWorkflowOptions: client.StartWorkflowOptions{
+			ID:                           wfid,
+			TaskList:                     scannerTaskListName,
+			ExecutionStartToCloseTimeout: 20 * 365 * 24 * time.Hour,
+			WorkflowIDReusePolicy:        client.WorkflowIDReusePolicyAllowDuplicate,
+			CronSchedule:                 "0 */6 * * *",
+		},
+		StartFixerOptions: client.StartWorkflowOptions{
+			ID:                           fixerwfid,
+			TaskList:                     fixerTaskListName,
+			ExecutionStartToCloseTimeout: 20 * 365 * 24 * time.Hour,
+			WorkflowIDReusePolicy:        client.WorkflowIDReusePolicyAllowDuplicate,
+			CronSchedule:                 "0 */6 * * *",
+		},
+	}
+}
+
+// checkedStores returns the advanced visibility stores (e.g. es and pinot) which are currently written to.
+func checkedStores(dc *dynamicconfig.Collection) []string {
+	var stores []string
+	for _, store := range parseStores(dc.GetStringProperty(dynamicproperties.WriteVisibilityStoreName)()) {
+		if store != constants.VisibilityModeDB && store != constants.AdvancedVisibilityModeOff {
+			stores = append(stores, store)
+		}
+	}
+	return stores
+}
+
+func parseStores(value string) []string {
+	var stores []string
+	for _, store := range strings.Split(value, ",") {
+		if store = strings.ToLower(strings.TrimSpace(store)); store != "" {
+			stores = append(stores, store)
+		}
+	}
+	return stores
+}
+
+func getInvariants(
+	pr persistence.Retryer,
+	cache cache.DomainCache,
+	visibilityManager persistence.VisibilityManager,
+	stores []string,
+) []invariant.Invariant {
+	return []invariant.Invariant{
+		invariant.NewVisibilityConsistency(pr, cache, visibilityManager, stores),
+	}
+}
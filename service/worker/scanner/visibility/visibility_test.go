@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package visibility
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/service/worker/scanner/shardscanner"
+)
+
+func TestScannerConfig(t *testing.T) {
+	dc := dynamicconfig.NewCollection(dynamicconfig.NewInMemoryClient(), log.NewNoop())
+	cfg := ScannerConfig(dc)
+	assert.Equal(t, ScannerWFTypeName, cfg.ScannerWFTypeName)
+	assert.Equal(t, FixerWFTypeName, cfg.FixerWFTypeName)
+	assert.NotNil(t, cfg.ScannerHooks())
+	assert.NotNil(t, cfg.FixerHooks())
+}
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		writeStore string
+		want       shardscanner.CustomScannerConfig
+	}{
+		{
+			name:       "single store",
+			writeStore: "es",
+			want:       shardscanner.CustomScannerConfig{sampleRateKey: "0.05", storesKey: "es"},
+		},
+		{
+			name:       "dual writing",
+			writeStore: "db, ES, pinot",
+			want:       shardscanner.CustomScannerConfig{sampleRateKey: "0.05", storesKey: "es,pinot"},
+		},
+		{
+			name:       "advanced visibility off",
+			writeStore: "off",
+			want:       shardscanner.CustomScannerConfig{sampleRateKey: "0.05", storesKey: ""},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := dynamicconfig.NewInMemoryClient()
+			require.NoError(t, client.UpdateValue(dynamicproperties.WriteVisibilityStoreName, tc.writeStore))
+			require.NoError(t, client.UpdateValue(dynamicproperties.VisibilityScannerSampleRate, 0.05))
+			dc := dynamicconfig.NewCollection(client, log.NewNoop())
+
+			assert.Equal(t, tc.want, Config(shardscanner.ScannerContext{Config: ScannerConfig(dc)}))
+		})
+	}
+}
+
+func TestIterator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	pr := persistence.NewMockRetryer(ctrl)
+	pr.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).
+		Return(&persistence.ListConcreteExecutionsResponse{}, nil).Times(1)
+
+	assert.NotNil(t, Iterator(context.Background(), pr, shardscanner.ScanShardActivityParams{
+		PageSize:      10,
+		ScannerConfig: shardscanner.CustomScannerConfig{sampleRateKey: "0.5"},
+	}))
+	assert.Nil(t, Iterator(context.Background(), pr, shardscanner.ScanShardActivityParams{
+		PageSize:      10,
+		ScannerConfig: shardscanner.CustomScannerConfig{},
+	}))
+}
@@ -54,6 +54,7 @@ import (
 	"github.com/uber/cadence/service/worker/scanner/shardscanner"
 	"github.com/uber/cadence/service/worker/scanner/tasklist"
 	"github.com/uber/cadence/service/worker/scanner/timers"
+	"github.com/uber/cadence/service/worker/scanner/visibility"
 	"github.com/uber/cadence/service/worker/scheduler"
 )
 
@@ -95,6 +96,14 @@ type (
 		EnableESAnalyzer                    dynamicproperties.BoolPropertyFn
 		EnableAsyncWorkflowConsumption      dynamicproperties.BoolPropertyFn
 		EnableDomainAuditLogging            dynamicproperties.BoolPropertyFn
+		ReadVisibilityStoreName             dynamicproperties.StringPropertyFnWithDomainFilter
+		WriteVisibilityStoreName            dynamicproperties.StringPropertyFn
+		EnableReadFromClosedExecutionV2     dynamicproperties.BoolPropertyFn
+		ESIndexMaxResultWindow              dynamicproperties.IntPropertyFn
+		ValidSearchAttributes               dynamicproperties.MapPropertyFn
+		SearchAttributeAliases              dynamicproperties.MapPropertyFn
+		PinotOptimizedQueryColumns          dynamicproperties.MapPropertyFn
+		DiagnosticsHistoryEventLinkTemplate dynamicproperties.StringPropertyFnWithDomainFilter
 		HostName                            string
 	}
 )
@@ -110,7 +119,16 @@ func NewService(params *resource.Params) (resource.Resource, error) {
 			PersistenceGlobalMaxQPS:  serviceConfig.PersistenceGlobalMaxQPS,
 			ThrottledLoggerMaxRPS:    serviceConfig.ThrottledLogRPS,
 			IsErrorRetryableFunction: common.IsServiceTransientError,
-			// worker service doesn't need visibility config as it never call visibilityManager API
+
+			// worker service reads visibility to check the consistency of visibility stores,
+			// and writes it to republish the records of executions found inconsistent
+			WriteVisibilityStoreName:                    serviceConfig.WriteVisibilityStoreName,
+			ReadVisibilityStoreName:                     serviceConfig.ReadVisibilityStoreName,
+			EnableReadDBVisibilityFromClosedExecutionV2: serviceConfig.EnableReadFromClosedExecutionV2,
+			ESIndexMaxResultWindow:                      serviceConfig.ESIndexMaxResultWindow,
+			ValidSearchAttributes:                       serviceConfig.ValidSearchAttributes,
+			SearchAttributeAliases:                      serviceConfig.SearchAttributeAliases,
+			PinotOptimizedQueryColumns:                  serviceConfig.PinotOptimizedQueryColumns,
 		},
 	)
 	if err != nil {
@@ -157,6 +175,7 @@ func NewConfig(params *resource.Params) *Config {
 				executions.ConcreteExecutionConfig(dc),
 				executions.CurrentExecutionConfig(dc),
 				timers.ScannerConfig(dc),
+				visibility.ScannerConfig(dc),
 			},
 			MaxWorkflowRetentionInDays: dc.GetIntProperty(dynamicproperties.MaxRetentionDays),
 		},
@@ -198,6 +217,14 @@ func NewConfig(params *resource.Params) *Config {
 		DomainReplicationMaxRetryDuration:   dc.GetDurationProperty(dynamicproperties.WorkerReplicationTaskMaxRetryDuration),
 		EnableAsyncWorkflowConsumption:      dc.GetBoolProperty(dynamicproperties.EnableAsyncWorkflowConsumption),
 		EnableDomainAuditLogging:            dc.GetBoolProperty(dynamicproperties.EnableDomainAuditLogging),
+		ReadVisibilityStoreName:             dc.GetStringPropertyFilteredByDomain(dynamicproperties.ReadVisibilityStoreName),
+		WriteVisibilityStoreName:            dc.GetStringProperty(dynamicproperties.WriteVisibilityStoreName),
+		EnableReadFromClosedExecutionV2:     dc.GetBoolProperty(dynamicproperties.EnableReadFromClosedExecutionV2),
+		ESIndexMaxResultWindow:              dc.GetIntProperty(dynamicproperties.FrontendESIndexMaxResultWindow),
+		ValidSearchAttributes:               dc.GetMapProperty(dynamicproperties.ValidSearchAttributes),
+		SearchAttributeAliases:              dc.GetMapProperty(dynamicproperties.SearchAttributeAliases),
+		PinotOptimizedQueryColumns:          dc.GetMapProperty(dynamicproperties.PinotOptimizedQueryColumns),
+		DiagnosticsHistoryEventLinkTemplate: dc.GetStringPropertyFilteredByDomain(dynamicproperties.DiagnosticsHistoryEventLinkTemplate),
 		HostName:                            params.HostName,
 	}
 	advancedVisWritingMode := dc.GetStringProperty(
@@ -211,7 +238,10 @@ func NewConfig(params *resource.Params) *Config {
 			ESProcessorBulkActions:         dc.GetIntProperty(dynamicproperties.WorkerESProcessorBulkActions),
 			ESProcessorBulkSize:            dc.GetIntProperty(dynamicproperties.WorkerESProcessorBulkSize),
 			ESProcessorFlushInterval:       dc.GetDurationProperty(dynamicproperties.WorkerESProcessorFlushInterval),
+			ESProcessorMaxItemRetries:      dc.GetIntProperty(dynamicproperties.WorkerESProcessorMaxItemRetries),
 			ValidSearchAttributes:          dc.GetMapProperty(dynamicproperties.ValidSearchAttributes),
+			DomainSearchAttributes:         dc.GetMapPropertyFilteredByDomain(dynamicproperties.DomainSearchAttributes),
+			SearchAttributeAliases:         dc.GetMapProperty(dynamicproperties.SearchAttributeAliases),
 			EnableQueryAttributeValidation: dc.GetBoolProperty(dynamicproperties.EnableQueryAttributeValidation),
 		}
 	}
@@ -335,12 +365,13 @@ func (s *Service) startESAnalyzer() {
 
 func (s *Service) startBatcher() {
 	params := &batcher.BootstrapParams{
-		Config:        *s.config.BatcherCfg,
-		ServiceClient: s.params.PublicClient,
-		MetricsClient: s.GetMetricsClient(),
-		Logger:        s.GetLogger(),
-		TallyScope:    s.params.MetricScope,
-		ClientBean:    s.GetClientBean(),
+		Config:          *s.config.BatcherCfg,
+		ServiceClient:   s.params.PublicClient,
+		MetricsClient:   s.GetMetricsClient(),
+		Logger:          s.GetLogger(),
+		TallyScope:      s.params.MetricScope,
+		ClientBean:      s.GetClientBean(),
+		BlobstoreClient: s.GetBlobstoreClient(),
 	}
 	if err := batcher.New(params).Start(); err != nil {
 		s.GetLogger().Fatal("error starting batcher", tag.Error(err))
@@ -394,6 +425,8 @@ func (s *Service) startDiagnostics() {
 		Logger:          s.GetLogger(),
 		Invariants:      s.params.DiagnosticsInvariants,
 		ClusterMetadata: s.GetClusterMetadata(),
+
+		HistoryEventLinkTemplate: s.config.DiagnosticsHistoryEventLinkTemplate,
 	}
 	if err := diagnostics.New(params).Start(); err != nil {
 		s.Stop()
@@ -431,6 +464,7 @@ func (s *Service) startIndexer() {
 		s.config.IndexerCfg,
 		s.GetMessagingClient(),
 		s.params.ESClient,
+		s.GetDomainCache(),
 		s.params.ESConfig.Indices[constants.VisibilityAppName],
 		s.params.ESConfig.ConsumerName,
 		s.GetLogger(),
@@ -448,6 +482,7 @@ func (s *Service) startMigrationDualIndexer() {
 		s.GetMessagingClient(),
 		s.params.ESClient,
 		s.params.OSClient,
+		s.GetDomainCache(),
 		s.params.ESConfig.Indices[constants.VisibilityAppName],
 		s.params.OSConfig.Indices[constants.VisibilityAppName],
 		s.params.ESConfig.ConsumerName,
@@ -29,6 +29,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/uber/cadence/common/reconciliation/invariant"
+	"github.com/uber/cadence/service/worker/batcher"
 	"github.com/uber/cadence/service/worker/scanner/executions"
 )
 
@@ -681,6 +682,52 @@ func newAdminElasticSearchCommands() []*cli.Command {
 			},
 			Action: GenerateReport,
 		},
+		{
+			Name:  "reindex",
+			Usage: "Rebuild visibility docs of a domain or time range from the executions in the database, through the visibility Kafka topic and indexer",
+			Flags: append(getDBFlags(),
+				&cli.StringFlag{
+					Name:    FlagDomain,
+					Aliases: []string{"do"},
+					Usage:   "Only reindex workflows of the given domain",
+				},
+				&cli.StringFlag{
+					Name:  FlagSearchAttributesKey,
+					Usage: "Only reindex workflows with the given search attribute, e.g. after changing its type",
+				},
+				&cli.StringFlag{
+					Name:  FlagEarliestTime,
+					Usage: "Only reindex workflows started since this time. Supported formats are '2006-01-02T15:04:05+07:00', raw UnixNano and time range (N<duration>), where 0 < N < 1000000 and duration (full-notation/short-notation) can be second/s, minute/m, hour/h, day/d, week/w, month/M or year/y. For example, '15minute' or '15m' implies last 15 minutes.",
+				},
+				&cli.StringFlag{
+					Name:  FlagLatestTime,
+					Usage: "Only reindex workflows started before this time, used with --" + FlagEarliestTime + ". Defaults to now",
+				},
+				&cli.IntFlag{
+					Name:  FlagLowerShardBound,
+					Usage: "First shard to scan",
+					Value: 0,
+				},
+				&cli.IntFlag{
+					Name:     FlagUpperShardBound,
+					Usage:    "Last shard to scan (numHistoryShards - 1)",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  FlagPinot,
+					Usage: "Also publish the records to the Pinot visibility topic, while Pinot is a visibility store of the cluster",
+				},
+				&cli.BoolFlag{
+					Name:  FlagDryRun,
+					Usage: "Only count the workflows which would be reindexed",
+				},
+				&cli.BoolFlag{
+					Name:  FlagCompleteTypeChange,
+					Usage: "Complete the type change of the search attribute once all workflows with it are reindexed, queries by it resolve to the search attribute of the new type from then on",
+				},
+			),
+			Action: AdminReindex,
+		},
 	}
 }
 
@@ -771,6 +818,73 @@ func newAdminClusterCommands() []*cli.Command {
 			},
 			Action: AdminAddSearchAttribute,
 		},
+		{
+			Name:    "add-domain-search-attr",
+			Aliases: []string{"adsa"},
+			Usage:   "Register a search attribute for a domain, it is only valid in the domain. Requires the global --domain flag",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  FlagSearchAttributesKey,
+					Usage: "Search Attribute key to be registered",
+				},
+				&cli.IntFlag{
+					Name:  FlagSearchAttributesType,
+					Value: -1,
+					Usage: "Search Attribute value type. [0:String, 1:Keyword, 2:Int, 3:Double, 4:Bool, 5:Datetime]",
+				},
+			},
+			Action: AdminAddDomainSearchAttribute,
+		},
+		{
+			Name:    "remove-search-attr",
+			Aliases: []string{"rsa"},
+			Usage:   "Remove a search attribute: upserts of it are rejected and it is hidden from the search attribute list, existing documents can still be queried by it",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  FlagSearchAttributesKey,
+					Usage: "Search Attribute key to be removed",
+				},
+			},
+			Action: AdminRemoveSearchAttribute,
+		},
+		{
+			Name:    "rename-search-attr",
+			Aliases: []string{"rnsa"},
+			Usage:   "Rename a search attribute with an alias, which resolves to the search attribute in queries",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  FlagSearchAttributesKey,
+					Usage: "Search Attribute key or alias to be renamed",
+				},
+				&cli.StringFlag{
+					Name:  FlagSearchAttributesNewKey,
+					Usage: "New name of the Search Attribute",
+				},
+			},
+			Action: AdminRenameSearchAttribute,
+		},
+		{
+			Name:    "update-search-attr-type",
+			Aliases: []string{"usat"},
+			Usage:   "Change the type of a search attribute by aliasing it to a search attribute added with the new type, queries keep resolving to the old type until the workflows with it are reindexed with --" + FlagCompleteTypeChange,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  FlagSearchAttributesKey,
+					Usage: "Search Attribute key to be updated",
+				},
+				&cli.IntFlag{
+					Name:  FlagSearchAttributesType,
+					Value: -1,
+					Usage: "New Search Attribute value type. [0:String, 1:Keyword, 2:Int, 3:Double, 4:Bool, 5:Datetime]",
+				},
+				&cli.StringFlag{
+					Name:    FlagSecurityToken,
+					Aliases: []string{"st"},
+					Usage:   "Optional token for security check",
+				},
+			},
+			Action: AdminUpdateSearchAttributeType,
+		},
 		{
 			Name:    "describe",
 			Aliases: []string{"d"},
@@ -806,6 +920,103 @@ func newAdminClusterCommands() []*cli.Command {
 			Usage:       "Rebalance the domains active cluster",
 			Subcommands: newAdminRebalanceCommands(),
 		},
+		{
+			Name:        "re-replicate",
+			Aliases:     []string{"rr"},
+			Usage:       "Re-send replication tasks of a workflow, a visibility query or a time window of a domain to a remote cluster",
+			Subcommands: newAdminReReplicateCommands(),
+		},
+	}
+}
+
+func newAdminReReplicateCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "start",
+			Usage: "Start a throttled batch job re-replicating the selected workflows",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     FlagDomain,
+					Aliases:  []string{"do"},
+					Usage:    "Domain of the workflows",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     FlagSourceCluster,
+					Aliases:  []string{"sc"},
+					Usage:    "Cluster the replication tasks are sent from, the job must be started in this cluster",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     FlagTargetCluster,
+					Aliases:  []string{"tc"},
+					Usage:    "Cluster the replication tasks are sent to",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    FlagReason,
+					Aliases: []string{"re"},
+					Usage:   "Reason to run this re-replication job",
+				},
+				&cli.StringFlag{
+					Name:    FlagWorkflowID,
+					Aliases: []string{"w", "wid"},
+					Usage:   "Re-replicate a single workflow",
+				},
+				&cli.StringFlag{
+					Name:    FlagListQuery,
+					Aliases: []string{"q"},
+					Usage:   "Re-replicate workflows matching the visibility query",
+				},
+				&cli.StringFlag{
+					Name:  FlagEarliestTime,
+					Usage: "Re-replicate workflows changed since this time, or still open. Supported formats are '2006-01-02T15:04:05+07:00', raw UnixNano and time range (N<duration>), where 0 < N < 1000000 and duration (full-notation/short-notation) can be second/s, minute/m, hour/h, day/d, week/w, month/M or year/y. For example, '15minute' or '15m' implies last 15 minutes.",
+				},
+				&cli.StringFlag{
+					Name:  FlagLatestTime,
+					Usage: "End of the time window, used with --" + FlagEarliestTime + ". Defaults to now",
+				},
+				&cli.IntFlag{
+					Name:  FlagRPS,
+					Value: batcher.DefaultRPS,
+					Usage: "Workflows re-replicated per second",
+				},
+				&cli.IntFlag{
+					Name:  FlagConcurrency,
+					Value: batcher.DefaultConcurrency,
+					Usage: "Concurrency of the re-replication activity",
+				},
+				&cli.IntFlag{
+					Name:  FlagPageSize,
+					Value: batcher.DefaultPageSize,
+					Usage: "Number of workflows scanned per page",
+				},
+				&cli.IntFlag{
+					Name:  FlagRetryAttempts,
+					Value: batcher.DefaultAttemptsOnRetryableError,
+					Usage: "Retry attempts for retriable errors",
+				},
+				&cli.BoolFlag{
+					Name:  FlagYes,
+					Usage: "Optional flag to disable confirmation prompt",
+				},
+			},
+			Action: AdminStartReReplication,
+		},
+		{
+			Name:  "describe",
+			Usage: "Show the progress of a re-replication job",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     FlagJobID,
+					Aliases:  []string{"jid"},
+					Usage:    "Job ID returned by re-replicate start",
+					Required: true,
+				},
+				getFormatFlag(),
+			},
+			Action: AdminDescribeReReplication,
+		},
 	}
 }
 
@@ -925,6 +1136,67 @@ func newAdminAsyncQueueCommands() []*cli.Command {
 	}
 }
 
+func newAdminScannerCommands() []*cli.Command {
+	collections := invariant.CollectionStrings()
+	return []*cli.Command{
+		{
+			Name:    "start",
+			Aliases: []string{"s"},
+			Usage:   "Scan, and optionally fix, the executions of a domain selected by a visibility query or by workflow IDs",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagListQuery,
+					Aliases: []string{"q"},
+					Usage:   "Visibility query selecting the executions to scan, all executions of the domain are scanned if neither query nor workflow IDs are given",
+				},
+				&cli.StringSliceFlag{
+					Name:    FlagWorkflowID,
+					Aliases: []string{"w", "wid"},
+					Usage:   "Workflow ID whose current run is scanned, can be repeated",
+				},
+				&cli.StringFlag{
+					Name:    FlagInputFile,
+					Aliases: []string{"if"},
+					Usage:   "Input file of workflow IDs to scan, one per line",
+				},
+				&cli.StringSliceFlag{
+					Name:     FlagInvariantCollection,
+					Usage:    "Invariant collection to check: " + strings.Join(collections, ", "),
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  FlagFix,
+					Usage: "Fix the corrupted executions",
+				},
+				&cli.IntFlag{
+					Name:  FlagMaxExecutions,
+					Usage: "Maximum number of executions to scan, executions which no longer exist included, 0 means no limit",
+				},
+			},
+			Action: AdminStartTargetedScan,
+		},
+		{
+			Name:    "report",
+			Aliases: []string{"r"},
+			Usage:   "Show the corruption report of a scan started with the start command",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     FlagWorkflowID,
+					Aliases:  []string{"w", "wid"},
+					Usage:    "WorkflowID of the scan",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    FlagRunID,
+					Aliases: []string{"r", "rid"},
+					Usage:   "RunID of the scan",
+				},
+			},
+			Action: AdminTargetedScanReport,
+		},
+	}
+}
+
 func newDBCommands() []*cli.Command {
 	var collections cli.StringSlice = *cli.NewStringSlice(invariant.CollectionStrings()...)
 
@@ -1362,6 +1634,10 @@ func newAdminConfigStoreCommands() []*cli.Command {
 					Usage:    fmt.Sprintf(`Can be specified multiple times for multiple values. ex: --%s '{"Value":true,"Filters":[]}'`, FlagDynamicConfigValue),
 					Required: true,
 				},
+				&cli.DurationFlag{
+					Name:  FlagDynamicConfigTTL,
+					Usage: "Optional. Values without ExpiresAt or TTL expire after this duration and fall back to the remaining values, ex: 2h",
+				},
 			},
 			Action: AdminUpdateDynamicConfig,
 		},
@@ -1385,7 +1661,7 @@ func newAdminConfigStoreCommands() []*cli.Command {
 		{
 			Name:    "list",
 			Aliases: []string{"l"},
-			Usage:   "List Dynamic Config Value",
+			Usage:   "List Dynamic Config Value with the remaining TTL of expiring values",
 			Flags:   []cli.Flag{},
 			Action:  AdminListDynamicConfig,
 		},
@@ -1396,6 +1672,40 @@ func newAdminConfigStoreCommands() []*cli.Command {
 			Flags:   []cli.Flag{getFormatFlag()},
 			Action:  AdminListConfigKeys,
 		},
+		{
+			Name:  "schema",
+			Usage: "Print the JSON Schema of all dynamic config keys, or of the values of a single key",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  FlagDynamicConfigName,
+					Usage: "Optional. Name of Dynamic Config parameter to print the value schema of",
+				},
+			},
+			Action: AdminDynamicConfigSchema,
+		},
+		{
+			Name:    "diff",
+			Aliases: []string{"d"},
+			Usage:   "Preview how an update changes the effective Dynamic Config Value per filter combination",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     FlagDynamicConfigName,
+					Usage:    "Name of Dynamic Config parameter to preview the update of",
+					Required: true,
+				},
+				&cli.StringSliceFlag{
+					Name:     FlagDynamicConfigValue,
+					Usage:    fmt.Sprintf(`Can be specified multiple times for multiple values. ex: --%s '{"Value":true,"Filters":[]}'`, FlagDynamicConfigValue),
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  FlagDynamicConfigFilter,
+					Usage: fmt.Sprintf(`Optional. Additional filter combination to evaluate. ex: --%s '{"domainName":"global-samples-domain", "taskListName":"sample-tasklist"}'`, FlagDynamicConfigFilter),
+				},
+				getFormatFlag(),
+			},
+			Action: AdminDiffDynamicConfig,
+		},
 		{
 			Name:    "operational-get",
 			Aliases: []string{"og"},
@@ -1428,6 +1738,10 @@ func newAdminConfigStoreCommands() []*cli.Command {
 					Usage:    fmt.Sprintf(`Can be specified multiple times for multiple values. ex: --%s '{"Value":true,"Filters":[]}'`, FlagDynamicConfigValue),
 					Required: true,
 				},
+				&cli.DurationFlag{
+					Name:  FlagDynamicConfigTTL,
+					Usage: "Optional. Values without ExpiresAt or TTL expire after this duration and fall back to the remaining values, ex: 2h",
+				},
 			},
 			Action: AdminUpdateOperationalDynamicConfig,
 		},
@@ -1451,10 +1765,32 @@ func newAdminConfigStoreCommands() []*cli.Command {
 		{
 			Name:    "operational-list",
 			Aliases: []string{"ol"},
-			Usage:   "List Operational Dynamic Config Value (cassandra-backed store)",
+			Usage:   "List Operational Dynamic Config Value with the remaining TTL of expiring values (cassandra-backed store)",
 			Flags:   []cli.Flag{},
 			Action:  AdminListOperationalDynamicConfig,
 		},
+		{
+			Name:    "history",
+			Aliases: []string{"hist"},
+			Usage:   "Show when each Dynamic Config Value took effect, reading the config store directly",
+			Flags: append(getDBFlags(),
+				&cli.StringFlag{
+					Name:  FlagDynamicConfigName,
+					Usage: "Optional. Name of Dynamic Config parameter to show the history of, all parameters by default",
+				},
+				&cli.BoolFlag{
+					Name:  FlagDynamicConfigOperational,
+					Usage: "Optional. Show the history of the operational dynamic config instead",
+				},
+				&cli.IntFlag{
+					Name:  FlagLimit,
+					Usage: "Maximum number of changes to show, latest first",
+					Value: 20,
+				},
+				getFormatFlag(),
+			),
+			Action: AdminDynamicConfigHistory,
+		},
 	}
 }
 
@@ -1527,5 +1863,74 @@ func newAdminIsolationGroupCommands() []*cli.Command {
 			},
 			Action: AdminUpdateDomainIsolationGroups,
 		},
+		{
+			Name:  "drain-status",
+			Usage: "shows the task lists of a domain still pinned to drained isolation groups",
+			Flags: []cli.Flag{
+				&cli.StringSliceFlag{
+					Name:  FlagIsolationGroup,
+					Usage: "Isolation groups to report. Defaults to all groups drained globally or for the domain",
+				},
+				getFormatFlag(),
+			},
+			Action: AdminIsolationGroupDrainStatus,
+		},
+		{
+			Name:  "schedule",
+			Usage: "manages planned isolation-group drains which are applied and reverted automatically",
+			Subcommands: []*cli.Command{
+				{
+					Name:  "list",
+					Usage: "lists upcoming and active drain windows. Use --domain to only show global drains and the drains of that domain",
+					Flags: []cli.Flag{
+						&cli.BoolFlag{
+							Name:  FlagAll,
+							Usage: "Also show completed and cancelled drain windows",
+						},
+						getFormatFlag(),
+					},
+					Action: AdminListIsolationGroupDrainSchedules,
+				},
+				{
+					Name:  "add",
+					Usage: "schedules a drain of an isolation group, for all domains or for the domain given with --domain",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     FlagIsolationGroup,
+							Usage:    "Isolation group to drain",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     FlagStartTime,
+							Usage:    "Start of the drain window, in UTC format '2006-01-02T15:04:05Z' or raw UnixNano",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     FlagEndTime,
+							Usage:    "End of the drain window after which the drain is reverted, in UTC format '2006-01-02T15:04:05Z' or raw UnixNano",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     FlagReason,
+							Usage:    "Reason for the drain, e.g. the maintenance ticket",
+							Required: true,
+						},
+					},
+					Action: AdminAddIsolationGroupDrainSchedule,
+				},
+				{
+					Name:  "cancel",
+					Usage: "cancels a drain window, reverting the drain immediately if it is active",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     FlagDrainScheduleID,
+							Usage:    "ID of the drain schedule to cancel",
+							Required: true,
+						},
+					},
+					Action: AdminCancelIsolationGroupDrainSchedule,
+				},
+			},
+		},
 	}
 }
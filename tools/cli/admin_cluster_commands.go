@@ -21,15 +21,20 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/pborman/uuid"
 	"github.com/urfave/cli/v2"
 
+	"github.com/uber/cadence/client/admin"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/configstore"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/common/visibility"
 	"github.com/uber/cadence/service/worker/failovermanager"
@@ -86,6 +91,320 @@ func AdminAddSearchAttribute(c *cli.Context) error {
 	return nil
 }
 
+// AdminRemoveSearchAttribute removes a search attribute: new upserts of it are rejected and it is no longer listed,
+// existing documents can still be queried by it. The admin handler removes it on the update of the removed search attributes.
+func AdminRemoveSearchAttribute(c *cli.Context) error {
+	key, err := getRequiredOption(c, FlagSearchAttributesKey)
+	if err != nil {
+		return commoncli.Problem("Required flag not present:", err)
+	}
+	adminClient, err := getDeps(c).ServerAdminClient(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := newContext(c)
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	defer cancel()
+
+	valid, err := getSearchAttributeConfig(ctx, adminClient, dynamicproperties.ValidSearchAttributes)
+	if err != nil {
+		return commoncli.Problem("Failed to get valid search attributes", err)
+	}
+	deprecated, err := getSearchAttributeConfigForUpdate(ctx, adminClient, dynamicproperties.DeprecatedSearchAttributes)
+	if err != nil {
+		return commoncli.Problem("Failed to get removed search attributes", err)
+	}
+	deprecated, err = visibility.RemoveSearchAttribute(valid, deprecated, key)
+	if err != nil {
+		return commoncli.Problem("Cannot remove search attribute.", err)
+	}
+
+	promptFn(fmt.Sprintf("Are you trying to remove key [%s]? y/N", color.YellowString(key)))
+	if err := updateSearchAttributeConfig(ctx, adminClient, dynamicproperties.DeprecatedSearchAttributes, deprecated); err != nil {
+		return commoncli.Problem("Remove search attribute failed.", err)
+	}
+	fmt.Fprintf(getDeps(c).Output(), "Search attribute %s is removed.\n", key)
+	return nil
+}
+
+// AdminRenameSearchAttribute renames a search attribute with an alias, which resolves to the search attribute in queries.
+// The admin handler renames it on the update of the search attribute aliases.
+func AdminRenameSearchAttribute(c *cli.Context) error {
+	key, err := getRequiredOption(c, FlagSearchAttributesKey)
+	if err != nil {
+		return commoncli.Problem("Required flag not present:", err)
+	}
+	newName, err := getRequiredOption(c, FlagSearchAttributesNewKey)
+	if err != nil {
+		return commoncli.Problem("Required flag not present:", err)
+	}
+	adminClient, err := getDeps(c).ServerAdminClient(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := newContext(c)
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	defer cancel()
+
+	valid, err := getSearchAttributeConfig(ctx, adminClient, dynamicproperties.ValidSearchAttributes)
+	if err != nil {
+		return commoncli.Problem("Failed to get valid search attributes", err)
+	}
+	deprecated, err := getSearchAttributeConfig(ctx, adminClient, dynamicproperties.DeprecatedSearchAttributes)
+	if err != nil {
+		return commoncli.Problem("Failed to get removed search attributes", err)
+	}
+	aliases, err := getSearchAttributeConfigForUpdate(ctx, adminClient, dynamicproperties.SearchAttributeAliases)
+	if err != nil {
+		return commoncli.Problem("Failed to get search attribute aliases", err)
+	}
+	aliases, err = visibility.RenameSearchAttribute(valid, deprecated, aliases, key, newName)
+	if err != nil {
+		return commoncli.Problem("Cannot rename search attribute.", err)
+	}
+
+	promptFn(fmt.Sprintf("Are you trying to rename key [%s] to [%s]? y/N", color.YellowString(key), color.YellowString(newName)))
+	if err := updateSearchAttributeConfig(ctx, adminClient, dynamicproperties.SearchAttributeAliases, aliases); err != nil {
+		return commoncli.Problem("Rename search attribute failed.", err)
+	}
+	fmt.Fprintf(getDeps(c).Output(), "Search attribute %s is renamed to %s. Queries by %s resolve to %s.\n",
+		key, newName, newName, aliases[newName])
+	return nil
+}
+
+// AdminUpdateSearchAttributeType changes the type of a search attribute. ES and Pinot can't change the type of an
+// existing field, so a search attribute is added with the new type through the admin API, and the key is aliased to it
+// pending reindex: values of the new type are indexed under the added search attribute, while queries by the key keep
+// resolving to the key. Reindexing the workflows with the search attribute moves existing documents and completes the
+// type change, see AdminReindex.
+func AdminUpdateSearchAttributeType(c *cli.Context) error {
+	key, err := getRequiredOption(c, FlagSearchAttributesKey)
+	if err != nil {
+		return commoncli.Problem("Required flag not present:", err)
+	}
+	valType, err := getRequiredIntOption(c, FlagSearchAttributesType)
+	if err != nil {
+		return commoncli.Problem("Required flag not present:", err)
+	}
+	if !isValueTypeValid(valType) {
+		return commoncli.Problem("Unknown Search Attributes value type.", nil)
+	}
+	adminClient, err := getDeps(c).ServerAdminClient(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := newContext(c)
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	defer cancel()
+
+	valid, err := getSearchAttributeConfig(ctx, adminClient, dynamicproperties.ValidSearchAttributes)
+	if err != nil {
+		return commoncli.Problem("Failed to get valid search attributes", err)
+	}
+	deprecated, err := getSearchAttributeConfig(ctx, adminClient, dynamicproperties.DeprecatedSearchAttributes)
+	if err != nil {
+		return commoncli.Problem("Failed to get removed search attributes", err)
+	}
+	aliases, err := getSearchAttributeConfigForUpdate(ctx, adminClient, dynamicproperties.SearchAttributeAliases)
+	if err != nil {
+		return commoncli.Problem("Failed to get search attribute aliases", err)
+	}
+	newKey, aliases, err := visibility.ChangeSearchAttributeType(valid, deprecated, aliases, key, types.IndexedValueType(valType))
+	if err != nil {
+		return commoncli.Problem("Cannot change search attribute type.", err)
+	}
+
+	promptFn(fmt.Sprintf("Are you trying to change the type of key [%s] to [%s]? y/N",
+		color.YellowString(key), color.YellowString(intValTypeToString(valType))))
+	if newKey != key {
+		err := adminClient.AddSearchAttribute(ctx, &types.AddSearchAttributeRequest{
+			SearchAttribute: map[string]types.IndexedValueType{newKey: types.IndexedValueType(valType)},
+			SecurityToken:   c.String(FlagSecurityToken),
+		})
+		if err != nil {
+			return commoncli.Problem("Add search attribute failed.", err)
+		}
+	}
+	if err := updateSearchAttributeConfig(ctx, adminClient, dynamicproperties.SearchAttributeAliases, aliases); err != nil {
+		return commoncli.Problem("Change search attribute type failed.", err)
+	}
+	if newKey == key {
+		fmt.Fprintf(getDeps(c).Output(), "Search attribute %s is now of type %s, its values are indexed as %s. "+
+			"Reindex the workflows with the search attribute to move their documents:\n"+
+			"  cadence admin elasticsearch reindex --%s %s --%s <numHistoryShards - 1> [--%s]\n",
+			key, intValTypeToString(valType), newKey, FlagSearchAttributesKey, key, FlagUpperShardBound, FlagPinot)
+		return nil
+	}
+	fmt.Fprintf(getDeps(c).Output(), "Search attribute %s is now of type %s, its values are indexed as %s. "+
+		"Queries by %s keep resolving to %s until the workflows with the search attribute are reindexed, which completes the type change:\n"+
+		"  cadence admin elasticsearch reindex --%s %s --%s --%s <numHistoryShards - 1> [--%s]\n",
+		key, intValTypeToString(valType), newKey, key, key, FlagSearchAttributesKey, key, FlagCompleteTypeChange, FlagUpperShardBound, FlagPinot)
+	return nil
+}
+
+// AdminAddDomainSearchAttribute registers a custom search attribute for a domain, it is only valid in the domain.
+// The admin handler registers it on the update of the domain's value of the domain search attributes.
+func AdminAddDomainSearchAttribute(c *cli.Context) error {
+	domain, err := getRequiredOption(c, FlagDomain)
+	if err != nil {
+		return commoncli.Problem("Required flag not present:", err)
+	}
+	key, err := getRequiredOption(c, FlagSearchAttributesKey)
+	if err != nil {
+		return commoncli.Problem("Required flag not present:", err)
+	}
+	valType, err := getRequiredIntOption(c, FlagSearchAttributesType)
+	if err != nil {
+		return commoncli.Problem("Required flag not present:", err)
+	}
+	if !isValueTypeValid(valType) {
+		return commoncli.Problem("Unknown Search Attributes value type.", nil)
+	}
+	adminClient, err := getDeps(c).ServerAdminClient(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := newContext(c)
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	defer cancel()
+
+	valid, err := getSearchAttributeConfig(ctx, adminClient, dynamicproperties.ValidSearchAttributes)
+	if err != nil {
+		return commoncli.Problem("Failed to get valid search attributes", err)
+	}
+	values, err := listDynamicConfigValues(ctx, adminClient, dynamicproperties.DomainSearchAttributes)
+	if err != nil {
+		return commoncli.Problem("Failed to get domain search attributes", err)
+	}
+	var registered map[string]interface{}
+	var otherDomains []map[string]interface{}
+	for _, value := range values {
+		attributes, err := decodeSearchAttributeConfigValue(value)
+		if err != nil {
+			return commoncli.Problem("Failed to decode domain search attributes", err)
+		}
+		if isDomainConfigValue(value, domain) {
+			registered = attributes
+			continue
+		}
+		otherDomains = append(otherDomains, attributes)
+	}
+	registered, err = visibility.RegisterDomainSearchAttribute(valid, registered, otherDomains, key, types.IndexedValueType(valType))
+	if err != nil {
+		return commoncli.Problem("Cannot register search attribute.", err)
+	}
+
+	promptFn(fmt.Sprintf("Are you trying to register key [%s] with Type [%s] for domain [%s]? y/N",
+		color.YellowString(key), color.YellowString(intValTypeToString(valType)), color.YellowString(domain)))
+	domainData, err := json.Marshal(domain)
+	if err != nil {
+		return commoncli.Problem("Failed to encode domain filter", err)
+	}
+	err = updateSearchAttributeConfig(ctx, adminClient, dynamicproperties.DomainSearchAttributes, registered, &types.DynamicConfigFilter{
+		Name:  dynamicproperties.DomainName.String(),
+		Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: domainData},
+	})
+	if err != nil {
+		return commoncli.Problem("Register search attribute failed.", err)
+	}
+	fmt.Fprintf(getDeps(c).Output(), "Search attribute %s is registered for domain %s. "+
+		"The visibility index must map %s to type %s, all domains share its mapping. "+
+		"Only clients sending the domain list it, e.g. cadence --domain %s cluster get-search-attr.\n",
+		key, domain, key, intValTypeToString(valType), domain)
+	return nil
+}
+
+// isDomainConfigValue returns true if the only filter of value is the domain, overrides of the domain's value are not
+func isDomainConfigValue(value *types.DynamicConfigValue, domain string) bool {
+	_, lookupFilters, err := dynamicproperties.ParseOverride(value.Filters)
+	if err != nil || len(value.Filters) != 1 || len(lookupFilters) != 1 || lookupFilters[0] == nil {
+		return false
+	}
+	filter := lookupFilters[0]
+	if filter.Name != dynamicproperties.DomainName.String() || filter.Value == nil {
+		return false
+	}
+	var filterValue string
+	return json.Unmarshal(filter.Value.Data, &filterValue) == nil && filterValue == domain
+}
+
+// decodeSearchAttributeConfigValue returns the map of a search attribute dynamic config value
+func decodeSearchAttributeConfigValue(value *types.DynamicConfigValue) (map[string]interface{}, error) {
+	attributes := map[string]interface{}{}
+	if value.Value == nil {
+		return attributes, nil
+	}
+	if err := json.Unmarshal(value.Value.Data, &attributes); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+// getSearchAttributeConfig returns the value of a search attribute dynamic config map without filters, or its default
+func getSearchAttributeConfig(ctx context.Context, adminClient admin.Client, key dynamicproperties.MapKey) (map[string]interface{}, error) {
+	values, err := listDynamicConfigValues(ctx, adminClient, key)
+	if err != nil {
+		return nil, err
+	}
+	value, found, err := configstore.EffectiveValue(values, nil, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return key.DefaultMap(), nil
+	}
+	mapValue, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("value of %s is not a map", key.String())
+	}
+	return mapValue, nil
+}
+
+// getSearchAttributeConfigForUpdate returns the value of a search attribute dynamic config map stored without filters,
+// or its default, to pass on to updateSearchAttributeConfig after changing it
+func getSearchAttributeConfigForUpdate(ctx context.Context, adminClient admin.Client, key dynamicproperties.MapKey) (map[string]interface{}, error) {
+	values, err := listDynamicConfigValues(ctx, adminClient, key)
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		if len(value.Filters) == 0 {
+			return decodeSearchAttributeConfigValue(value)
+		}
+	}
+	return key.DefaultMap(), nil
+}
+
+// updateSearchAttributeConfig replaces the value of a search attribute dynamic config map stored with filters, or without
+// any, with value. The admin handler validates the update, and keeps the other values of the config.
+func updateSearchAttributeConfig(
+	ctx context.Context,
+	adminClient admin.Client,
+	key dynamicproperties.MapKey,
+	value map[string]interface{},
+	filters ...*types.DynamicConfigFilter,
+) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return adminClient.UpdateDynamicConfig(ctx, &types.UpdateDynamicConfigRequest{
+		ConfigName: key.String(),
+		ConfigValues: []*types.DynamicConfigValue{{
+			Value:   &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: data},
+			Filters: filters,
+		}},
+	})
+}
+
 // AdminDescribeCluster is used to dump information about the cluster
 func AdminDescribeCluster(c *cli.Context) error {
 	adminClient, err := getDeps(c).ServerAdminClient(c)
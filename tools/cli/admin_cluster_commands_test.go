@@ -23,14 +23,19 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/mock/gomock"
 
 	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/common/visibility"
 	"github.com/uber/cadence/service/worker/failovermanager"
@@ -435,3 +440,310 @@ func TestAdminAddSearchAttribute_errors(t *testing.T) {
 		})
 	}
 }
+
+func TestAdminSearchAttributeLifecycle(t *testing.T) {
+	defer func(fn func(string)) { promptFn = fn }(promptFn)
+	promptFn = func(string) {}
+
+	dynamicConfigResponse := func(key dynamicproperties.MapKey, value map[string]interface{}) *types.ListDynamicConfigResponse {
+		data, err := json.Marshal(value)
+		require.NoError(t, err)
+		return &types.ListDynamicConfigResponse{Entries: []*types.DynamicConfigEntry{{
+			Name:   key.String(),
+			Values: []*types.DynamicConfigValue{{Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: data}}},
+		}}}
+	}
+	expectList := func(td *cliTestData, key dynamicproperties.MapKey, value map[string]interface{}, filtered ...*types.DynamicConfigValue) {
+		resp := &types.ListDynamicConfigResponse{}
+		if value != nil {
+			resp = dynamicConfigResponse(key, value)
+		}
+		if len(filtered) > 0 {
+			if len(resp.Entries) == 0 {
+				resp.Entries = []*types.DynamicConfigEntry{{Name: key.String()}}
+			}
+			resp.Entries[0].Values = append(resp.Entries[0].Values, filtered...)
+		}
+		td.mockAdminClient.EXPECT().ListDynamicConfig(gomock.Any(), &types.ListDynamicConfigRequest{ConfigName: key.String()}).Return(resp, nil)
+	}
+	// expectUpdate expects only the value without filters to be sent, the admin handler keeps the filtered values
+	expectUpdate := func(td *cliTestData, key dynamicproperties.MapKey, value map[string]interface{}) {
+		td.mockAdminClient.EXPECT().UpdateDynamicConfig(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, req *types.UpdateDynamicConfigRequest, _ ...interface{}) error {
+				assert.Equal(t, key.String(), req.ConfigName)
+				require.Len(t, req.ConfigValues, 1)
+				assert.JSONEq(t, string(dynamicConfigResponse(key, value).Entries[0].Values[0].Value.Data), string(req.ConfigValues[0].Value.Data))
+				assert.Empty(t, req.ConfigValues[0].Filters)
+				return nil
+			})
+	}
+	valid := map[string]interface{}{
+		"CustomKeywordField": float64(types.IndexedValueTypeKeyword),
+		"CustomIntField":     float64(types.IndexedValueTypeInt),
+	}
+	domainData, err := json.Marshal(testDomain)
+	require.NoError(t, err)
+	domainDeprecated := &types.DynamicConfigValue{
+		Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: []byte(`{"CustomKeywordField":true}`)},
+		Filters: []*types.DynamicConfigFilter{{
+			Name:  dynamicproperties.DomainName.String(),
+			Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: domainData},
+		}},
+	}
+
+	tests := []struct {
+		name           string
+		action         cli.ActionFunc
+		args           []clitest.CliArgument
+		mockSetup      func(td *cliTestData)
+		errContains    string
+		expectedOutput string
+	}{
+		{
+			name:   "remove",
+			action: AdminRemoveSearchAttribute,
+			args:   []clitest.CliArgument{clitest.StringArgument(FlagSearchAttributesKey, "CustomIntField")},
+			mockSetup: func(td *cliTestData) {
+				expectList(td, dynamicproperties.ValidSearchAttributes, valid)
+				expectList(td, dynamicproperties.DeprecatedSearchAttributes, nil)
+				expectUpdate(td, dynamicproperties.DeprecatedSearchAttributes, map[string]interface{}{"CustomIntField": true})
+			},
+			expectedOutput: "Search attribute CustomIntField is removed.\n",
+		},
+		{
+			name:   "remove sends the value without filters",
+			action: AdminRemoveSearchAttribute,
+			args:   []clitest.CliArgument{clitest.StringArgument(FlagSearchAttributesKey, "CustomIntField")},
+			mockSetup: func(td *cliTestData) {
+				expectList(td, dynamicproperties.ValidSearchAttributes, valid)
+				expectList(td, dynamicproperties.DeprecatedSearchAttributes, nil, domainDeprecated)
+				expectUpdate(td, dynamicproperties.DeprecatedSearchAttributes, map[string]interface{}{"CustomIntField": true})
+			},
+			expectedOutput: "Search attribute CustomIntField is removed.\n",
+		},
+		{
+			name:   "remove unknown search attribute",
+			action: AdminRemoveSearchAttribute,
+			args:   []clitest.CliArgument{clitest.StringArgument(FlagSearchAttributesKey, "Unknown")},
+			mockSetup: func(td *cliTestData) {
+				expectList(td, dynamicproperties.ValidSearchAttributes, valid)
+				expectList(td, dynamicproperties.DeprecatedSearchAttributes, nil)
+			},
+			errContains: "Cannot remove search attribute.",
+		},
+		{
+			name:   "rename",
+			action: AdminRenameSearchAttribute,
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagSearchAttributesKey, "CustomKeywordField"),
+				clitest.StringArgument(FlagSearchAttributesNewKey, "CustomTag"),
+			},
+			mockSetup: func(td *cliTestData) {
+				expectList(td, dynamicproperties.ValidSearchAttributes, valid)
+				expectList(td, dynamicproperties.DeprecatedSearchAttributes, nil)
+				expectList(td, dynamicproperties.SearchAttributeAliases, map[string]interface{}{"CustomCount": "CustomIntField"})
+				expectUpdate(td, dynamicproperties.SearchAttributeAliases, map[string]interface{}{
+					"CustomCount": "CustomIntField",
+					"CustomTag":   "CustomKeywordField",
+				})
+			},
+			expectedOutput: "Search attribute CustomKeywordField is renamed to CustomTag. Queries by CustomTag resolve to CustomKeywordField.\n",
+		},
+		{
+			name:        "rename without new name",
+			action:      AdminRenameSearchAttribute,
+			args:        []clitest.CliArgument{clitest.StringArgument(FlagSearchAttributesKey, "CustomKeywordField")},
+			errContains: "Required flag not present:",
+		},
+		{
+			name:   "change type",
+			action: AdminUpdateSearchAttributeType,
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagSearchAttributesKey, "CustomKeywordField"),
+				clitest.IntArgument(FlagSearchAttributesType, int(types.IndexedValueTypeInt)),
+			},
+			mockSetup: func(td *cliTestData) {
+				expectList(td, dynamicproperties.ValidSearchAttributes, valid)
+				expectList(td, dynamicproperties.DeprecatedSearchAttributes, nil)
+				expectList(td, dynamicproperties.SearchAttributeAliases, nil)
+				td.mockAdminClient.EXPECT().AddSearchAttribute(gomock.Any(), &types.AddSearchAttributeRequest{
+					SearchAttribute: map[string]types.IndexedValueType{"CustomKeywordFieldInt": types.IndexedValueTypeInt},
+				}).Return(nil)
+				expectUpdate(td, dynamicproperties.SearchAttributeAliases, map[string]interface{}{
+					"CustomKeywordField": map[string]interface{}{"pendingReindex": "CustomKeywordFieldInt"},
+				})
+			},
+			expectedOutput: "Search attribute CustomKeywordField is now of type Int, its values are indexed as CustomKeywordFieldInt. " +
+				"Queries by CustomKeywordField keep resolving to CustomKeywordField until the workflows with the search attribute are reindexed, which completes the type change:\n" +
+				"  cadence admin elasticsearch reindex --search_attr_key CustomKeywordField --complete_type_change --upper_shard_bound <numHistoryShards - 1> [--pinot]\n",
+		},
+		{
+			name:   "change type add search attribute error",
+			action: AdminUpdateSearchAttributeType,
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagSearchAttributesKey, "CustomKeywordField"),
+				clitest.IntArgument(FlagSearchAttributesType, int(types.IndexedValueTypeInt)),
+			},
+			mockSetup: func(td *cliTestData) {
+				expectList(td, dynamicproperties.ValidSearchAttributes, valid)
+				expectList(td, dynamicproperties.DeprecatedSearchAttributes, nil)
+				expectList(td, dynamicproperties.SearchAttributeAliases, nil)
+				td.mockAdminClient.EXPECT().AddSearchAttribute(gomock.Any(), gomock.Any()).Return(errors.New("mapping error"))
+			},
+			errContains: "Add search attribute failed.",
+		},
+		{
+			name:   "change type back",
+			action: AdminUpdateSearchAttributeType,
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagSearchAttributesKey, "CustomKeywordField"),
+				clitest.IntArgument(FlagSearchAttributesType, int(types.IndexedValueTypeKeyword)),
+			},
+			mockSetup: func(td *cliTestData) {
+				expectList(td, dynamicproperties.ValidSearchAttributes, map[string]interface{}{
+					"CustomKeywordField":    float64(types.IndexedValueTypeKeyword),
+					"CustomKeywordFieldInt": float64(types.IndexedValueTypeInt),
+				})
+				expectList(td, dynamicproperties.DeprecatedSearchAttributes, nil)
+				expectList(td, dynamicproperties.SearchAttributeAliases, map[string]interface{}{"CustomKeywordField": "CustomKeywordFieldInt"})
+				expectUpdate(td, dynamicproperties.SearchAttributeAliases, map[string]interface{}{})
+			},
+			expectedOutput: "Search attribute CustomKeywordField is now of type Keyword, its values are indexed as CustomKeywordField. " +
+				"Reindex the workflows with the search attribute to move their documents:\n" +
+				"  cadence admin elasticsearch reindex --search_attr_key CustomKeywordField --upper_shard_bound <numHistoryShards - 1> [--pinot]\n",
+		},
+		{
+			name:   "change to the same type",
+			action: AdminUpdateSearchAttributeType,
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagSearchAttributesKey, "CustomIntField"),
+				clitest.IntArgument(FlagSearchAttributesType, int(types.IndexedValueTypeInt)),
+			},
+			mockSetup: func(td *cliTestData) {
+				expectList(td, dynamicproperties.ValidSearchAttributes, valid)
+				expectList(td, dynamicproperties.DeprecatedSearchAttributes, nil)
+				expectList(td, dynamicproperties.SearchAttributeAliases, nil)
+			},
+			errContains: "Cannot change search attribute type.",
+		},
+		{
+			name:        "change to unknown type",
+			action:      AdminUpdateSearchAttributeType,
+			args:        []clitest.CliArgument{clitest.StringArgument(FlagSearchAttributesKey, "CustomIntField"), clitest.IntArgument(FlagSearchAttributesType, 9)},
+			errContains: "Unknown Search Attributes value type.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			if tt.mockSetup != nil {
+				tt.mockSetup(td)
+			}
+			err := tt.action(clitest.NewCLIContext(t, td.app, tt.args...))
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, td.consoleOutput())
+		})
+	}
+}
+
+func TestAdminAddDomainSearchAttribute(t *testing.T) {
+	defer func(fn func(string)) { promptFn = fn }(promptFn)
+	promptFn = func(string) {}
+
+	jsonBlob := func(v interface{}) *types.DataBlob {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: data}
+	}
+	domainValue := func(domain string, attributes map[string]interface{}) *types.DynamicConfigValue {
+		return &types.DynamicConfigValue{
+			Value:   jsonBlob(attributes),
+			Filters: []*types.DynamicConfigFilter{{Name: dynamicproperties.DomainName.String(), Value: jsonBlob(domain)}},
+		}
+	}
+	expectList := func(td *cliTestData, key dynamicproperties.MapKey, values ...*types.DynamicConfigValue) {
+		td.mockAdminClient.EXPECT().ListDynamicConfig(gomock.Any(), &types.ListDynamicConfigRequest{ConfigName: key.String()}).
+			Return(&types.ListDynamicConfigResponse{Entries: []*types.DynamicConfigEntry{{Name: key.String(), Values: values}}}, nil)
+	}
+	valid := &types.DynamicConfigValue{Value: jsonBlob(map[string]interface{}{"CustomKeywordField": types.IndexedValueTypeKeyword})}
+	otherDomain := domainValue("other-domain", map[string]interface{}{"TeamKey": float64(types.IndexedValueTypeKeyword)})
+
+	tests := []struct {
+		name           string
+		args           []clitest.CliArgument
+		mockSetup      func(td *cliTestData)
+		errContains    string
+		expectedOutput string
+	}{
+		{
+			name: "register",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagDomain, testDomain),
+				clitest.StringArgument(FlagSearchAttributesKey, "TeamKey"),
+				clitest.IntArgument(FlagSearchAttributesType, int(types.IndexedValueTypeKeyword)),
+			},
+			mockSetup: func(td *cliTestData) {
+				expectList(td, dynamicproperties.ValidSearchAttributes, valid)
+				expectList(td, dynamicproperties.DomainSearchAttributes, otherDomain,
+					domainValue(testDomain, map[string]interface{}{"TeamCount": float64(types.IndexedValueTypeInt)}))
+				td.mockAdminClient.EXPECT().UpdateDynamicConfig(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *types.UpdateDynamicConfigRequest, _ ...interface{}) error {
+						assert.Equal(t, dynamicproperties.DomainSearchAttributes.String(), req.ConfigName)
+						require.Len(t, req.ConfigValues, 1)
+						registered := domainValue(testDomain, map[string]interface{}{
+							"TeamCount": float64(types.IndexedValueTypeInt),
+							"TeamKey":   float64(types.IndexedValueTypeKeyword),
+						})
+						assert.Equal(t, registered.Filters, req.ConfigValues[0].Filters)
+						assert.JSONEq(t, string(registered.Value.Data), string(req.ConfigValues[0].Value.Data))
+						return nil
+					})
+			},
+			expectedOutput: "Search attribute TeamKey is registered for domain " + testDomain + ". " +
+				"The visibility index must map TeamKey to type Keyword, all domains share its mapping. " +
+				"Only clients sending the domain list it, e.g. cadence --domain " + testDomain + " cluster get-search-attr.\n",
+		},
+		{
+			name: "type conflicts with another domain",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagDomain, testDomain),
+				clitest.StringArgument(FlagSearchAttributesKey, "TeamKey"),
+				clitest.IntArgument(FlagSearchAttributesType, int(types.IndexedValueTypeInt)),
+			},
+			mockSetup: func(td *cliTestData) {
+				expectList(td, dynamicproperties.ValidSearchAttributes, valid)
+				expectList(td, dynamicproperties.DomainSearchAttributes, otherDomain)
+			},
+			errContains: "Cannot register search attribute.",
+		},
+		{
+			name: "missing domain",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagSearchAttributesKey, "TeamKey"),
+				clitest.IntArgument(FlagSearchAttributesType, int(types.IndexedValueTypeKeyword)),
+			},
+			errContains: "Required flag not present:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			if tt.mockSetup != nil {
+				tt.mockSetup(td)
+			}
+			err := AdminAddDomainSearchAttribute(clitest.NewCLIContext(t, td.app, tt.args...))
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, td.consoleOutput())
+		})
+	}
+}
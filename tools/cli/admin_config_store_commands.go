@@ -21,15 +21,21 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli/v2"
 
+	"github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/common/dynamicconfig/configstore"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/tools/common/commoncli"
 )
@@ -43,6 +49,20 @@ type cliEntry struct {
 type cliValue struct {
 	Value   interface{}
 	Filters []*cliFilter
+	// ExpiresAt is the RFC3339 time the value stops applying. TTL is the remaining time to live: it sets ExpiresAt
+	// relative to now on updates and is reported by list.
+	ExpiresAt string             `json:",omitempty"`
+	TTL       string             `json:",omitempty"`
+	Rollout   []*cliRolloutStage `json:",omitempty"`
+}
+
+// cliRolloutStage is a rollout stage of a value, its start time is either given by StartTime (RFC3339)
+// or by Delay relative to now on updates.
+type cliRolloutStage struct {
+	StartTime        string `json:",omitempty"`
+	Delay            string `json:",omitempty"`
+	DomainPercentage int    `json:",omitempty"`
+	ShardIDs         []int  `json:",omitempty"`
 }
 
 type cliFilter struct {
@@ -111,47 +131,16 @@ func AdminUpdateDynamicConfig(c *cli.Context) error {
 	if err != nil {
 		return commoncli.Problem("Required flag not found", err)
 	}
-	dcValuesRaw := c.StringSlice(FlagDynamicConfigValue)
-
-	// WORKAROUND: urfave/cli v2 StringSliceFlag splits on commas by default.
-	// This breaks JSON values. Try reassembling the split pieces.
-	var dcValues []string
-	if len(dcValuesRaw) > 1 && strings.HasPrefix(dcValuesRaw[0], "{") {
-		assembled := strings.Join(dcValuesRaw, ",")
-		var test interface{}
-		if json.Unmarshal([]byte(assembled), &test) == nil {
-			dcValues = []string{assembled}
-		} else {
-			dcValues = dcValuesRaw
-		}
-	} else {
-		dcValues = dcValuesRaw
-	}
+	dcValues := getInputValues(c)
 
 	ctx, cancel, err := newContext(c)
 	defer cancel()
 	if err != nil {
 		return commoncli.Problem("Error in creating context: ", err)
 	}
-	var parsedValues []*types.DynamicConfigValue
-
-	if dcValues != nil {
-		parsedValues = make([]*types.DynamicConfigValue, 0, len(dcValues))
-
-		for _, valueString := range dcValues {
-			var parsedInputValue *cliValue
-			err := json.Unmarshal([]byte(valueString), &parsedInputValue)
-			if err != nil {
-				return commoncli.Problem("Unable to unmarshal value to inputValue", err)
-			}
-			parsedValue, err := convertFromInputValue(parsedInputValue)
-			if err != nil {
-				return commoncli.Problem("Unable to convert from inputValue to DynamicConfigValue", err)
-			}
-			parsedValues = append(parsedValues, parsedValue)
-		}
-	} else {
-		parsedValues = nil
+	parsedValues, err := parseInputValuesWithTTL(dcValues, c.Duration(FlagDynamicConfigTTL))
+	if err != nil {
+		return err
 	}
 
 	req := &types.UpdateDynamicConfigRequest{
@@ -205,6 +194,7 @@ func AdminRestoreDynamicConfig(c *cli.Context) error {
 }
 
 // AdminListDynamicConfig lists all values associated with specified dynamic config parameter or all values for all dc parameter if none is specified.
+// Values with an expiry show their remaining TTL.
 func AdminListDynamicConfig(c *cli.Context) error {
 	adminClient, err := getDeps(c).ServerAdminClient(c)
 	if err != nil {
@@ -229,8 +219,9 @@ func AdminListDynamicConfig(c *cli.Context) error {
 		fmt.Printf("No dynamic config values stored to list.\n")
 	} else {
 		cliEntries := make([]*cliEntry, 0, len(val.Entries))
+		now := time.Now()
 		for _, dcEntry := range val.Entries {
-			cliEntry, err := convertToInputEntry(dcEntry)
+			cliEntry, err := convertToInputEntry(dcEntry, now)
 			if err != nil {
 				fmt.Printf("Cannot parse list response.\n")
 			}
@@ -309,47 +300,16 @@ func AdminUpdateOperationalDynamicConfig(c *cli.Context) error {
 	if err != nil {
 		return commoncli.Problem("Required flag not found", err)
 	}
-	dcValuesRaw := c.StringSlice(FlagDynamicConfigValue)
-
-	// WORKAROUND: urfave/cli v2 StringSliceFlag splits on commas by default.
-	// This breaks JSON values. Try reassembling the split pieces.
-	var dcValues []string
-	if len(dcValuesRaw) > 1 && strings.HasPrefix(dcValuesRaw[0], "{") {
-		assembled := strings.Join(dcValuesRaw, ",")
-		var test interface{}
-		if json.Unmarshal([]byte(assembled), &test) == nil {
-			dcValues = []string{assembled}
-		} else {
-			dcValues = dcValuesRaw
-		}
-	} else {
-		dcValues = dcValuesRaw
-	}
+	dcValues := getInputValues(c)
 
 	ctx, cancel, err := newContext(c)
 	defer cancel()
 	if err != nil {
 		return commoncli.Problem("Error in creating context: ", err)
 	}
-	var parsedValues []*types.DynamicConfigValue
-
-	if dcValues != nil {
-		parsedValues = make([]*types.DynamicConfigValue, 0, len(dcValues))
-
-		for _, valueString := range dcValues {
-			var parsedInputValue *cliValue
-			err := json.Unmarshal([]byte(valueString), &parsedInputValue)
-			if err != nil {
-				return commoncli.Problem("Unable to unmarshal value to inputValue", err)
-			}
-			parsedValue, err := convertFromInputValue(parsedInputValue)
-			if err != nil {
-				return commoncli.Problem("Unable to convert from inputValue to DynamicConfigValue", err)
-			}
-			parsedValues = append(parsedValues, parsedValue)
-		}
-	} else {
-		parsedValues = nil
+	parsedValues, err := parseInputValuesWithTTL(dcValues, c.Duration(FlagDynamicConfigTTL))
+	if err != nil {
+		return err
 	}
 
 	req := &types.UpdateOperationalDynamicConfigRequest{
@@ -429,8 +389,9 @@ func AdminListOperationalDynamicConfig(c *cli.Context) error {
 		fmt.Printf("No operational dynamic config values stored to list.\n")
 	} else {
 		cliEntries := make([]*cliEntry, 0, len(val.Entries))
+		now := time.Now()
 		for _, dcEntry := range val.Entries {
-			cliEntry, err := convertToInputEntry(dcEntry)
+			cliEntry, err := convertToInputEntry(dcEntry, now)
 			if err != nil {
 				fmt.Printf("Cannot parse list response entry, skipping.\n")
 				continue
@@ -473,10 +434,306 @@ func AdminListConfigKeys(c *cli.Context) error {
 	)
 }
 
-func convertToInputEntry(dcEntry *types.DynamicConfigEntry) (*cliEntry, error) {
+// AdminDynamicConfigSchema prints the JSON Schema of all dynamic config keys, or the schema of the values of a single key
+func AdminDynamicConfigSchema(c *cli.Context) error {
+	if !c.IsSet(FlagDynamicConfigName) {
+		prettyPrintJSONObject(getDeps(c).Output(), dynamicproperties.JSONSchema())
+		return nil
+	}
+	key, err := dynamicproperties.GetKeyFromKeyName(c.String(FlagDynamicConfigName))
+	if err != nil {
+		return commoncli.Problem("Unknown dynamic config key", err)
+	}
+	prettyPrintJSONObject(getDeps(c).Output(), dynamicproperties.ValueSchema(key))
+	return nil
+}
+
+// AdminDiffDynamicConfig previews how updating a dynamic config parameter with the given values
+// changes its effective value for every filter combination the current or the new values apply to
+func AdminDiffDynamicConfig(c *cli.Context) error {
+	adminClient, err := getDeps(c).ServerAdminClient(c)
+	if err != nil {
+		return err
+	}
+
+	dcName, err := getRequiredOption(c, FlagDynamicConfigName)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+	key, err := dynamicproperties.GetKeyFromKeyName(dcName)
+	if err != nil {
+		return commoncli.Problem("Unknown dynamic config key", err)
+	}
+	newValues, err := parseInputValues(getInputValues(c))
+	if err != nil {
+		return err
+	}
+	if err := dynamicproperties.ValidateConfigValues(key, newValues); err != nil {
+		return commoncli.Problem("Invalid dynamic config value", err)
+	}
+	extraFilters, err := parseInputFilter(c.String(FlagDynamicConfigFilter))
+	if err != nil {
+		return commoncli.Problem("Failed to parse input filter", err)
+	}
+	if err := dynamicproperties.ValidateConfigFilters(key, extraFilters); err != nil {
+		return commoncli.Problem("Invalid dynamic config filter", err)
+	}
+
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	resp, err := adminClient.ListDynamicConfig(ctx, &types.ListDynamicConfigRequest{ConfigName: dcName})
+	if err != nil {
+		return commoncli.Problem("Failed to list dynamic config value(s)", err)
+	}
+	var currentValues []*types.DynamicConfigValue
+	if resp != nil {
+		for _, entry := range resp.Entries {
+			if entry != nil && entry.Name == key.String() {
+				currentValues = append(currentValues, entry.Values...)
+			}
+		}
+	}
+
+	rows, err := diffDynamicConfigValues(key, currentValues, newValues, extraFilters)
+	if err != nil {
+		return commoncli.Problem("Failed to evaluate dynamic config values", err)
+	}
+	return Render(c, rows, RenderOptions{DefaultTemplate: templateTable, Color: true, Border: true})
+}
+
+type dynamicConfigDiffRow struct {
+	Context string `header:"Context" json:"context"`
+	Before  string `header:"Before" json:"before"`
+	After   string `header:"After" json:"after"`
+	Changed bool   `header:"Changed" json:"changed"`
+}
+
+// diffDynamicConfigValues evaluates the effective value of key before and after replacing currentValues with newValues.
+// Evaluated contexts are the one without filters, the filter combination of every value and the extra filters if any.
+func diffDynamicConfigValues(
+	key dynamicproperties.Key,
+	currentValues []*types.DynamicConfigValue,
+	newValues []*types.DynamicConfigValue,
+	extraFilters []*types.DynamicConfigFilter,
+) ([]dynamicConfigDiffRow, error) {
+	filterSets := [][]*types.DynamicConfigFilter{nil}
+	for _, value := range append(append([]*types.DynamicConfigValue{}, currentValues...), newValues...) {
+		_, lookupFilters, err := dynamicproperties.ParseOverride(value.Filters)
+		if err != nil {
+			return nil, err
+		}
+		filterSets = append(filterSets, lookupFilters)
+	}
+	if len(extraFilters) > 0 {
+		filterSets = append(filterSets, extraFilters)
+	}
+
+	rows := make([]dynamicConfigDiffRow, 0, len(filterSets))
+	seen := make(map[string]bool, len(filterSets))
+	for _, filterSet := range filterSets {
+		filters, label, err := decodeFilterSet(filterSet)
+		if err != nil {
+			return nil, err
+		}
+		if seen[label] {
+			continue
+		}
+		seen[label] = true
+
+		before, err := effectiveValueString(key, currentValues, filters)
+		if err != nil {
+			return nil, err
+		}
+		after, err := effectiveValueString(key, newValues, filters)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, dynamicConfigDiffRow{
+			Context: label,
+			Before:  before,
+			After:   after,
+			Changed: before != after,
+		})
+	}
+	// keep the context without filters first, followed by the more specific ones
+	sort.SliceStable(rows[1:], func(i, j int) bool {
+		return rows[i+1].Context < rows[j+1].Context
+	})
+	return rows, nil
+}
+
+func decodeFilterSet(filterSet []*types.DynamicConfigFilter) (map[dynamicproperties.Filter]interface{}, string, error) {
+	if len(filterSet) == 0 {
+		return map[dynamicproperties.Filter]interface{}{}, "(no filters)", nil
+	}
+	filters := make(map[dynamicproperties.Filter]interface{}, len(filterSet))
+	parts := make([]string, 0, len(filterSet))
+	for _, filter := range filterSet {
+		var value interface{}
+		if err := json.Unmarshal(filter.Value.Data, &value); err != nil {
+			return nil, "", fmt.Errorf("filter %q: %w", filter.Name, err)
+		}
+		filters[dynamicproperties.ParseFilter(filter.Name)] = value
+		parts = append(parts, fmt.Sprintf("%s=%v", filter.Name, value))
+	}
+	sort.Strings(parts)
+	return filters, strings.Join(parts, ", "), nil
+}
+
+func effectiveValueString(key dynamicproperties.Key, values []*types.DynamicConfigValue, filters map[dynamicproperties.Filter]interface{}) (string, error) {
+	value, found, err := configstore.EffectiveValue(values, filters, time.Now())
+	if err != nil {
+		return "", err
+	}
+	suffix := ""
+	if !found {
+		value = key.DefaultValue()
+		suffix = " (default)"
+	}
+	if duration, ok := value.(time.Duration); ok {
+		return duration.String() + suffix, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded) + suffix, nil
+}
+
+// AdminDynamicConfigHistory lists the changes of dynamic config values and the time they took effect, latest first
+// The admin API has no endpoint for older config store snapshots, so the history is read from the database directly.
+func AdminDynamicConfigHistory(c *cli.Context) error {
+	var key dynamicproperties.Key
+	if dcName := c.String(FlagDynamicConfigName); dcName != "" {
+		var err error
+		key, err = dynamicproperties.GetKeyFromKeyName(dcName)
+		if err != nil {
+			return commoncli.Problem("Unknown dynamic config key", err)
+		}
+	}
+	configType := persistence.DynamicConfig
+	if c.Bool(FlagDynamicConfigOperational) {
+		configType = persistence.OperationalDynamicConfig
+	}
+
+	configStoreManager, err := getDeps(c).initializeConfigStoreManager(c)
+	if err != nil {
+		return commoncli.Problem("Error in dynamic config history: ", err)
+	}
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+
+	changes, err := configstore.ChangeHistory(ctx, configStoreManager, configType, key, c.Int(FlagLimit))
+	if err != nil {
+		return commoncli.Problem("Failed to read dynamic config history", err)
+	}
+	rows, err := dynamicConfigHistoryRows(changes)
+	if err != nil {
+		return commoncli.Problem("Failed to decode dynamic config values", err)
+	}
+	if len(rows) == 0 && c.String(FlagFormat) != formatJSON {
+		fmt.Fprintln(getDeps(c).Output(), "No dynamic config changes found.")
+		return nil
+	}
+	return Render(c, rows, RenderOptions{DefaultTemplate: templateTable, Color: true, Border: true})
+}
+
+type dynamicConfigHistoryRow struct {
+	Version int64  `header:"Version" json:"version"`
+	Time    string `header:"Time" json:"time"`
+	Name    string `header:"Name" json:"name"`
+	Before  string `header:"Before" json:"before"`
+	After   string `header:"After" json:"after"`
+}
+
+// dynamicConfigHistoryRows renders the values of each change the way they are given to update, "-" if there are none.
+// Expiring values only show their expiry time since their remaining TTL is not meaningful for past values.
+func dynamicConfigHistoryRows(changes []*configstore.ValueChange) ([]dynamicConfigHistoryRow, error) {
+	rows := make([]dynamicConfigHistoryRow, 0, len(changes))
+	for _, change := range changes {
+		before, err := historyValuesString(change.PreviousValues)
+		if err != nil {
+			return nil, err
+		}
+		after, err := historyValuesString(change.Values)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, dynamicConfigHistoryRow{
+			Version: change.Version,
+			Time:    change.Timestamp.UTC().Format(time.RFC3339),
+			Name:    change.Key,
+			Before:  before,
+			After:   after,
+		})
+	}
+	return rows, nil
+}
+
+func historyValuesString(values []*types.DynamicConfigValue) (string, error) {
+	if len(values) == 0 {
+		return "-", nil
+	}
+	entry, err := convertToInputEntry(&types.DynamicConfigEntry{Values: values}, time.Time{})
+	if err != nil {
+		return "", err
+	}
+	for _, value := range entry.Values {
+		value.TTL = ""
+	}
+	encoded, err := json.Marshal(entry.Values)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// listDynamicConfigValues returns all values stored for a dynamic config key
+func listDynamicConfigValues(ctx context.Context, adminClient admin.Client, key dynamicproperties.Key) ([]*types.DynamicConfigValue, error) {
+	resp, err := adminClient.ListDynamicConfig(ctx, &types.ListDynamicConfigRequest{ConfigName: key.String()})
+	if err != nil {
+		return nil, err
+	}
+	var values []*types.DynamicConfigValue
+	if resp != nil {
+		for _, entry := range resp.Entries {
+			if entry != nil && entry.Name == key.String() {
+				values = append(values, entry.Values...)
+			}
+		}
+	}
+	return values, nil
+}
+
+// compareAndUpdateDynamicConfig replaces the values of a dynamic config key, read earlier as expected, with values.
+// The update is rejected if the values stored for the key were changed by someone else in the meantime.
+func compareAndUpdateDynamicConfig(
+	ctx context.Context,
+	adminClient admin.Client,
+	key dynamicproperties.Key,
+	values []*types.DynamicConfigValue,
+	expected []*types.DynamicConfigValue,
+) error {
+	values, err := dynamicproperties.WithExpectedValues(values, expected)
+	if err != nil {
+		return err
+	}
+	return adminClient.UpdateDynamicConfig(ctx, &types.UpdateDynamicConfigRequest{
+		ConfigName:   key.String(),
+		ConfigValues: values,
+	})
+}
+
+func convertToInputEntry(dcEntry *types.DynamicConfigEntry, now time.Time) (*cliEntry, error) {
 	newValues := make([]*cliValue, 0, len(dcEntry.Values))
 	for _, value := range dcEntry.Values {
-		newValue, err := convertToInputValue(value)
+		newValue, err := convertToInputValue(value, now)
 		if err != nil {
 			return nil, err
 		}
@@ -488,9 +745,13 @@ func convertToInputEntry(dcEntry *types.DynamicConfigEntry) (*cliEntry, error) {
 	}, nil
 }
 
-func convertToInputValue(dcValue *types.DynamicConfigValue) (*cliValue, error) {
-	newFilters := make([]*cliFilter, 0, len(dcValue.Filters))
-	for _, filter := range dcValue.Filters {
+func convertToInputValue(dcValue *types.DynamicConfigValue, now time.Time) (*cliValue, error) {
+	override, lookupFilters, err := dynamicproperties.ParseOverride(dcValue.Filters)
+	if err != nil {
+		return nil, err
+	}
+	newFilters := make([]*cliFilter, 0, len(lookupFilters))
+	for _, filter := range lookupFilters {
 		newFilter, err := convertToInputFilter(filter)
 		if err != nil {
 			return nil, err
@@ -499,15 +760,30 @@ func convertToInputValue(dcValue *types.DynamicConfigValue) (*cliValue, error) {
 	}
 
 	var val interface{}
-	err := json.Unmarshal(dcValue.Value.Data, &val)
+	err = json.Unmarshal(dcValue.Value.Data, &val)
 	if err != nil {
 		return nil, err
 	}
 
-	return &cliValue{
+	newValue := &cliValue{
 		Value:   val,
 		Filters: newFilters,
-	}, nil
+	}
+	if !override.ExpiresAt.IsZero() {
+		newValue.ExpiresAt = override.ExpiresAt.Format(time.RFC3339)
+		newValue.TTL = "expired"
+		if ttl := override.TTL(now); ttl > 0 {
+			newValue.TTL = ttl.Round(time.Second).String()
+		}
+	}
+	for _, stage := range override.Rollout {
+		newValue.Rollout = append(newValue.Rollout, &cliRolloutStage{
+			StartTime:        stage.StartTime.Format(time.RFC3339),
+			DomainPercentage: stage.DomainPercentage,
+			ShardIDs:         stage.ShardIDs,
+		})
+	}
+	return newValue, nil
 }
 
 func convertToInputFilter(dcFilter *types.DynamicConfigFilter) (*cliFilter, error) {
@@ -523,7 +799,7 @@ func convertToInputFilter(dcFilter *types.DynamicConfigFilter) (*cliFilter, erro
 	}, nil
 }
 
-func convertFromInputValue(inputValue *cliValue) (*types.DynamicConfigValue, error) {
+func convertFromInputValue(inputValue *cliValue, now time.Time) (*types.DynamicConfigValue, error) {
 	encodedValue, err := json.Marshal(inputValue.Value)
 	if err != nil {
 		return nil, err
@@ -543,12 +819,70 @@ func convertFromInputValue(inputValue *cliValue) (*types.DynamicConfigValue, err
 		dcFilters = append(dcFilters, dcFilter)
 	}
 
+	override, err := convertFromInputOverride(inputValue, now)
+	if err != nil {
+		return nil, err
+	}
+	overrideFilters, err := dynamicproperties.OverrideFilters(override)
+	if err != nil {
+		return nil, err
+	}
+	dcFilters = append(dcFilters, overrideFilters...)
+
 	return &types.DynamicConfigValue{
 		Value:   blob,
 		Filters: dcFilters,
 	}, nil
 }
 
+func convertFromInputOverride(inputValue *cliValue, now time.Time) (dynamicproperties.Override, error) {
+	var override dynamicproperties.Override
+	switch {
+	case inputValue.ExpiresAt != "" && inputValue.TTL != "":
+		return override, errors.New("only one of ExpiresAt and TTL can be set")
+	case inputValue.ExpiresAt != "":
+		expiresAt, err := time.Parse(time.RFC3339, inputValue.ExpiresAt)
+		if err != nil {
+			return override, fmt.Errorf("invalid ExpiresAt: %w", err)
+		}
+		override.ExpiresAt = expiresAt
+	case inputValue.TTL != "":
+		ttl, err := time.ParseDuration(inputValue.TTL)
+		if err != nil || ttl <= 0 {
+			return override, fmt.Errorf("invalid TTL %q, expected a positive duration", inputValue.TTL)
+		}
+		override.ExpiresAt = now.Add(ttl)
+	}
+
+	for i, inputStage := range inputValue.Rollout {
+		stage := dynamicproperties.RolloutStage{
+			DomainPercentage: inputStage.DomainPercentage,
+			ShardIDs:         inputStage.ShardIDs,
+		}
+		switch {
+		case inputStage.StartTime != "" && inputStage.Delay != "":
+			return override, fmt.Errorf("rollout stage %d: only one of StartTime and Delay can be set", i)
+		case inputStage.StartTime != "":
+			startTime, err := time.Parse(time.RFC3339, inputStage.StartTime)
+			if err != nil {
+				return override, fmt.Errorf("rollout stage %d: invalid StartTime: %w", i, err)
+			}
+			stage.StartTime = startTime
+		default:
+			var delay time.Duration
+			if inputStage.Delay != "" {
+				var err error
+				if delay, err = time.ParseDuration(inputStage.Delay); err != nil || delay < 0 {
+					return override, fmt.Errorf("rollout stage %d: invalid Delay %q", i, inputStage.Delay)
+				}
+			}
+			stage.StartTime = now.Add(delay)
+		}
+		override.Rollout = append(override.Rollout, stage)
+	}
+	return override, nil
+}
+
 func convertFromInputFilter(inputFilter *cliFilter) (*types.DynamicConfigFilter, error) {
 	encodedValue, err := json.Marshal(inputFilter.Value)
 	if err != nil {
@@ -564,6 +898,63 @@ func convertFromInputFilter(inputFilter *cliFilter) (*types.DynamicConfigFilter,
 	}, nil
 }
 
+// getInputValues returns the raw values given with FlagDynamicConfigValue.
+func getInputValues(c *cli.Context) []string {
+	dcValuesRaw := c.StringSlice(FlagDynamicConfigValue)
+
+	// WORKAROUND: urfave/cli v2 StringSliceFlag splits on commas by default.
+	// This breaks JSON values. Try reassembling the split pieces, one value at a time.
+	if len(dcValuesRaw) <= 1 || !strings.HasPrefix(dcValuesRaw[0], "{") {
+		return dcValuesRaw
+	}
+	var dcValues []string
+	start := 0
+	for end := range dcValuesRaw {
+		assembled := strings.Join(dcValuesRaw[start:end+1], ",")
+		var test interface{}
+		if json.Unmarshal([]byte(assembled), &test) == nil {
+			dcValues = append(dcValues, assembled)
+			start = end + 1
+		}
+	}
+	if start < len(dcValuesRaw) {
+		return dcValuesRaw
+	}
+	return dcValues
+}
+
+func parseInputValues(dcValues []string) ([]*types.DynamicConfigValue, error) {
+	return parseInputValuesWithTTL(dcValues, 0)
+}
+
+// parseInputValuesWithTTL parses values given with FlagDynamicConfigValue, values without an expiry expire after ttl if it is set
+func parseInputValuesWithTTL(dcValues []string, ttl time.Duration) ([]*types.DynamicConfigValue, error) {
+	if dcValues == nil {
+		return nil, nil
+	}
+	if ttl < 0 {
+		return nil, commoncli.Problem("Invalid TTL", fmt.Errorf("TTL must be positive, got %v", ttl))
+	}
+	now := time.Now()
+	parsedValues := make([]*types.DynamicConfigValue, 0, len(dcValues))
+	for _, valueString := range dcValues {
+		var parsedInputValue *cliValue
+		err := json.Unmarshal([]byte(valueString), &parsedInputValue)
+		if err != nil {
+			return nil, commoncli.Problem("Unable to unmarshal value to inputValue", err)
+		}
+		if ttl > 0 && parsedInputValue.ExpiresAt == "" && parsedInputValue.TTL == "" {
+			parsedInputValue.TTL = ttl.String()
+		}
+		parsedValue, err := convertFromInputValue(parsedInputValue, now)
+		if err != nil {
+			return nil, commoncli.Problem("Unable to convert from inputValue to DynamicConfigValue", err)
+		}
+		parsedValues = append(parsedValues, parsedValue)
+	}
+	return parsedValues, nil
+}
+
 func parseInputFilter(inputFilter string) ([]*types.DynamicConfigFilter, error) {
 	if inputFilter == "" || inputFilter == "{}" {
 		return nil, nil
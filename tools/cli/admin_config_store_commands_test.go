@@ -26,11 +26,14 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/yarpc"
 
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/tools/cli/clitest"
 )
@@ -252,6 +255,35 @@ func TestAdminUpdateDynamicConfig(t *testing.T) {
 			},
 			errContains: "",
 		},
+		{
+			name:    "update with ttl and staged rollout",
+			cmdline: `cadence admin config update --name history.rps --ttl 2h --value ` + `'{"Value":100,"Filters":[],"Rollout":[{"ShardIDs":[1,2]},{"Delay":"30m","DomainPercentage":100}]}'`,
+			setupMocks: func(td *cliTestData) {
+				td.mockAdminClient.EXPECT().UpdateDynamicConfig(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, request *types.UpdateDynamicConfigRequest, _ ...yarpc.CallOption) error {
+						assert.Len(t, request.ConfigValues, 1)
+						override, lookupFilters, err := dynamicproperties.ParseOverride(request.ConfigValues[0].Filters)
+						assert.NoError(t, err)
+						assert.Empty(t, lookupFilters)
+						assert.WithinDuration(t, time.Now().Add(2*time.Hour), override.ExpiresAt, time.Minute)
+						if assert.Len(t, override.Rollout, 2) {
+							assert.Equal(t, []int{1, 2}, override.Rollout[0].ShardIDs)
+							assert.Equal(t, 30*time.Minute, override.Rollout[1].StartTime.Sub(override.Rollout[0].StartTime))
+							assert.Equal(t, 100, override.Rollout[1].DomainPercentage)
+						}
+						return nil
+					})
+			},
+			errContains: "",
+		},
+		{
+			name:    "update with both expiry and ttl",
+			cmdline: `cadence admin config update --name history.rps --value ` + `'{"Value":100,"ExpiresAt":"2026-01-01T00:00:00Z","TTL":"1h"}'`,
+			setupMocks: func(td *cliTestData) {
+				// empty since the value is rejected
+			},
+			errContains: "only one of ExpiresAt and TTL",
+		},
 	}
 
 	for _, tt := range tests {
@@ -383,6 +415,37 @@ func TestAdminListDynamicConfig(t *testing.T) {
 	}
 }
 
+func TestAdminListDynamicConfigTTL(t *testing.T) {
+	overrideFilters, err := dynamicproperties.OverrideFilters(dynamicproperties.Override{ExpiresAt: time.Now().Add(90 * time.Minute)})
+	assert.NoError(t, err)
+	expiredFilters, err := dynamicproperties.OverrideFilters(dynamicproperties.Override{ExpiresAt: time.Now().Add(-time.Minute)})
+	assert.NoError(t, err)
+	value := &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: []byte("100")}
+
+	td := newCLITestData(t)
+	td.mockAdminClient.EXPECT().ListDynamicConfig(gomock.Any(), gomock.Any()).Return(&types.ListDynamicConfigResponse{
+		Entries: []*types.DynamicConfigEntry{{
+			Name: "history.rps",
+			Values: []*types.DynamicConfigValue{
+				{Value: value, Filters: overrideFilters},
+				{Value: value, Filters: expiredFilters},
+				{Value: value},
+			},
+		}},
+	}, nil)
+
+	assert.NoError(t, clitest.RunCommandLine(t, td.app, "cadence admin config list"))
+	var entries []*cliEntry
+	assert.NoError(t, json.Unmarshal([]byte(td.consoleOutput()), &entries))
+	if assert.Len(t, entries, 1) && assert.Len(t, entries[0].Values, 3) {
+		assert.Contains(t, []string{"1h30m0s", "1h29m59s"}, entries[0].Values[0].TTL)
+		assert.Empty(t, entries[0].Values[0].Filters)
+		assert.Equal(t, "expired", entries[0].Values[1].TTL)
+		assert.Empty(t, entries[0].Values[2].TTL)
+		assert.Empty(t, entries[0].Values[2].ExpiresAt)
+	}
+}
+
 func TestAdminListConfigKeys(t *testing.T) {
 	t.Run("list config keys", func(t *testing.T) {
 		td := newCLITestData(t)
@@ -594,3 +657,169 @@ func TestAdminListOperationalDynamicConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestAdminDynamicConfigSchema(t *testing.T) {
+	t.Run("all keys", func(t *testing.T) {
+		td := newCLITestData(t)
+		assert.NoError(t, clitest.RunCommandLine(t, td.app, "cadence admin config schema"))
+		var schema map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(td.consoleOutput()), &schema))
+		assert.Contains(t, schema["properties"], "matching.minTaskThrottlingBurstSize")
+	})
+	t.Run("single key", func(t *testing.T) {
+		td := newCLITestData(t)
+		assert.NoError(t, clitest.RunCommandLine(t, td.app, "cadence admin config schema --name matching.minTaskThrottlingBurstSize"))
+		assert.JSONEq(t, `{"type":"integer"}`, td.consoleOutput())
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		td := newCLITestData(t)
+		assert.ErrorContains(t, clitest.RunCommandLine(t, td.app, "cadence admin config schema --name unknown.key"), "Unknown dynamic config key")
+	})
+}
+
+func TestAdminDiffDynamicConfig(t *testing.T) {
+	const keyName = "matching.minTaskThrottlingBurstSize"
+	currentEntries := &types.ListDynamicConfigResponse{
+		Entries: []*types.DynamicConfigEntry{{
+			Name: keyName,
+			Values: []*types.DynamicConfigValue{
+				{
+					Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: []byte(`5`)},
+				},
+				{
+					Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: []byte(`10`)},
+					Filters: []*types.DynamicConfigFilter{{
+						Name:  "domainName",
+						Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: []byte(`"domain-a"`)},
+					}},
+				},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name        string
+		cmdline     string
+		setupMock   func(td *cliTestData)
+		wantRows    []dynamicConfigDiffRow
+		errContains string
+	}{
+		{
+			name:        "unknown key",
+			cmdline:     `cadence admin config diff --name unknown.key --value '{"Value":1}'`,
+			setupMock:   func(td *cliTestData) {},
+			errContains: "Unknown dynamic config key",
+		},
+		{
+			name:        "invalid value",
+			cmdline:     `cadence admin config diff --name matching.minTaskThrottlingBurstSize --value '{"Value":"1"}'`,
+			setupMock:   func(td *cliTestData) {},
+			errContains: "Invalid dynamic config value",
+		},
+		{
+			name:        "unsupported filter",
+			cmdline:     `cadence admin config diff --name matching.minTaskThrottlingBurstSize --value '{"Value":1,"Filters":[{"Name":"shardID","Value":1}]}'`,
+			setupMock:   func(td *cliTestData) {},
+			errContains: "is not supported by",
+		},
+		{
+			name:    "server error",
+			cmdline: `cadence admin config diff --name matching.minTaskThrottlingBurstSize --value '{"Value":1}'`,
+			setupMock: func(td *cliTestData) {
+				td.mockAdminClient.EXPECT().ListDynamicConfig(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+			errContains: "Failed to list dynamic config value(s)",
+		},
+		{
+			name: "effective values per filter combination",
+			cmdline: `cadence admin config diff --name matching.minTaskThrottlingBurstSize --format json` +
+				` --value '{"Value":5}'` +
+				` --value '{"Value":20,"Filters":[{"Name":"domainName","Value":"domain-b"},{"Name":"taskListName","Value":"tl"}]}'` +
+				` --filter '{"domainName":"domain-c"}'`,
+			setupMock: func(td *cliTestData) {
+				td.mockAdminClient.EXPECT().ListDynamicConfig(gomock.Any(), &types.ListDynamicConfigRequest{ConfigName: keyName}).
+					Return(currentEntries, nil)
+			},
+			wantRows: []dynamicConfigDiffRow{
+				{Context: "(no filters)", Before: "5", After: "5"},
+				{Context: "domainName=domain-a", Before: "10", After: "5", Changed: true},
+				{Context: "domainName=domain-b, taskListName=tl", Before: "5", After: "20", Changed: true},
+				{Context: "domainName=domain-c", Before: "5", After: "5"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			tt.setupMock(td)
+			err := clitest.RunCommandLine(t, td.app, tt.cmdline)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NoError(t, err)
+			var rows []dynamicConfigDiffRow
+			assert.NoError(t, json.Unmarshal([]byte(td.consoleOutput()), &rows))
+			assert.Equal(t, tt.wantRows, rows)
+		})
+	}
+}
+
+func TestDiffDynamicConfigValuesDefault(t *testing.T) {
+	rows, err := diffDynamicConfigValues(dynamicproperties.MatchingMinTaskThrottlingBurstSize, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []dynamicConfigDiffRow{{Context: "(no filters)", Before: "1 (default)", After: "1 (default)"}}, rows)
+}
+
+func TestAdminDynamicConfigHistory(t *testing.T) {
+	key := dynamicproperties.MatchingMinTaskThrottlingBurstSize
+	writtenAt := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	snapshot := func(version int64, value string) *persistence.DynamicConfigSnapshot {
+		return &persistence.DynamicConfigSnapshot{
+			Version:   version,
+			Timestamp: writtenAt.Add(time.Duration(version) * time.Hour),
+			Values: &types.DynamicConfigBlob{Entries: []*types.DynamicConfigEntry{{
+				Name:   key.String(),
+				Values: []*types.DynamicConfigValue{{Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: []byte(value)}}},
+			}}},
+		}
+	}
+
+	t.Run("unknown key", func(t *testing.T) {
+		td := newCLITestData(t)
+		err := AdminDynamicConfigHistory(clitest.NewCLIContext(t, td.app, clitest.StringArgument(FlagDynamicConfigName, "unknown")))
+		assert.ErrorContains(t, err, "Unknown dynamic config key")
+	})
+
+	t.Run("operational history of a key", func(t *testing.T) {
+		td := newCLITestData(t)
+		manager := persistence.NewMockConfigStoreManager(td.ctrl)
+		manager.EXPECT().FetchDynamicConfigHistory(gomock.Any(), gomock.Any(), persistence.OperationalDynamicConfig).
+			Return(&persistence.FetchDynamicConfigHistoryResponse{Snapshots: []*persistence.DynamicConfigSnapshot{
+				snapshot(2, "5"),
+				snapshot(1, "3"),
+			}}, nil)
+		td.mockManagerFactory.EXPECT().initializeConfigStoreManager(gomock.Any()).Return(manager, nil)
+
+		err := AdminDynamicConfigHistory(clitest.NewCLIContext(t, td.app,
+			clitest.StringArgument(FlagDynamicConfigName, key.String()),
+			clitest.BoolArgument(FlagDynamicConfigOperational, true),
+			clitest.IntArgument(FlagLimit, 20),
+		))
+		assert.NoError(t, err)
+		for _, s := range []string{"2026-03-04T07:06:07Z", key.String(), `[{"Value":3,"Filters":[]}]`, `[{"Value":5,"Filters":[]}]`} {
+			assert.Contains(t, td.consoleOutput(), s)
+		}
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		td := newCLITestData(t)
+		manager := persistence.NewMockConfigStoreManager(td.ctrl)
+		manager.EXPECT().FetchDynamicConfigHistory(gomock.Any(), gomock.Any(), persistence.DynamicConfig).
+			Return(&persistence.FetchDynamicConfigHistoryResponse{}, nil)
+		td.mockManagerFactory.EXPECT().initializeConfigStoreManager(gomock.Any()).Return(manager, nil)
+
+		assert.NoError(t, AdminDynamicConfigHistory(clitest.NewCLIContext(t, td.app)))
+		assert.Contains(t, td.consoleOutput(), "No dynamic config changes found.")
+	})
+}
@@ -80,7 +80,14 @@ func AdminDBScan(c *cli.Context) error {
 		}
 	}
 
-	invariants := scanType.ToInvariants(collections, logger)
+	retryers := &dbScanRetryers{c: c}
+	defer retryers.close()
+	workflowRetryer := invariant.NewWorkflowRetryer(numberOfShards, retryers.newRetryer)
+
+	invariants := scanType.ToInvariants(collections, logger, executions.InvariantDeps{
+		WorkflowRetryer:  workflowRetryer,
+		TimerTaskIndexes: invariant.NewTimerTaskIndexCache(),
+	})
 	if len(invariants) < 1 {
 		return commoncli.Problem(
 			fmt.Sprintf("no invariants for scan type %q and collections %q",
@@ -117,7 +124,7 @@ func AdminDBScan(c *cli.Context) error {
 	}
 
 	for _, e := range data {
-		execution, result, err := checkExecution(c, numberOfShards, e, invariants, ef)
+		execution, result, err := checkExecution(c, workflowRetryer, e, invariants, ef)
 		if err != nil {
 			return commoncli.Problem("Execution check failed", err)
 		}
@@ -138,30 +145,15 @@ func AdminDBScan(c *cli.Context) error {
 
 func checkExecution(
 	c *cli.Context,
-	numberOfShards int,
+	workflowRetryer invariant.WorkflowRetryer,
 	req fetcher.ExecutionRequest,
 	invariants []executions.InvariantFactory,
 	fetcher executions.ExecutionFetcher,
 ) (interface{}, invariant.ManagerCheckResult, error) {
-	shardID := common.WorkflowIDToHistoryShard(req.WorkflowID, numberOfShards)
-	execManager, err := getDeps(c).initializeExecutionManager(c, shardID)
-	if err != nil {
-		return nil, invariant.ManagerCheckResult{}, fmt.Errorf("initialize execution manager: %w", err)
-	}
-	defer execManager.Close()
-
-	historyV2Mgr, err := getDeps(c).initializeHistoryManager(c)
+	pr, err := workflowRetryer(req.WorkflowID)
 	if err != nil {
-		return nil, invariant.ManagerCheckResult{}, fmt.Errorf("initialize history manager: %w", err)
+		return nil, invariant.ManagerCheckResult{}, err
 	}
-	defer historyV2Mgr.Close()
-
-	pr := persistence.NewPersistenceRetryerWithShardID(
-		execManager,
-		historyV2Mgr,
-		common.CreatePersistenceRetryPolicy(),
-		shardID,
-	)
 
 	ctx, cancel, err := newContext(c)
 	if err != nil {
@@ -183,6 +175,44 @@ func checkExecution(
 	return execution, invariant.NewInvariantManager(ivs).RunChecks(ctx, execution), nil
 }
 
+// dbScanRetryers creates the persistence retryer of a shard once for all executions of the scan
+type dbScanRetryers struct {
+	c              *cli.Context
+	historyManager persistence.HistoryManager
+	execManagers   []persistence.ExecutionManager
+}
+
+func (r *dbScanRetryers) newRetryer(shardID int) (persistence.Retryer, error) {
+	execManager, err := getDeps(r.c).initializeExecutionManager(r.c, shardID)
+	if err != nil {
+		return nil, fmt.Errorf("initialize execution manager: %w", err)
+	}
+	r.execManagers = append(r.execManagers, execManager)
+
+	if r.historyManager == nil {
+		historyManager, err := getDeps(r.c).initializeHistoryManager(r.c)
+		if err != nil {
+			return nil, fmt.Errorf("initialize history manager: %w", err)
+		}
+		r.historyManager = historyManager
+	}
+	return persistence.NewPersistenceRetryerWithShardID(
+		execManager,
+		r.historyManager,
+		common.CreatePersistenceRetryPolicy(),
+		shardID,
+	), nil
+}
+
+func (r *dbScanRetryers) close() {
+	for _, execManager := range r.execManagers {
+		execManager.Close()
+	}
+	if r.historyManager != nil {
+		r.historyManager.Close()
+	}
+}
+
 // AdminDBScanUnsupportedWorkflow is to scan DB for unsupported workflow for a new release
 func AdminDBScanUnsupportedWorkflow(c *cli.Context) error {
 	outputFile, err := getOutputFile(c.String(FlagOutputFilename))
@@ -213,6 +213,13 @@ func TestAdminDBScanErrorCases(t *testing.T) {
 func TestAdminDBScan(t *testing.T) {
 	td := newCLITestData(t)
 
+	// the history manager is shared by the retryers of all shards
+	mockHistoryManager := persistence.NewMockHistoryManager(td.ctrl)
+	mockHistoryManager.EXPECT().Close().Times(1)
+	td.mockManagerFactory.EXPECT().
+		initializeHistoryManager(gomock.Any()).
+		Return(mockHistoryManager, nil).
+		Times(1)
 	expectWorkFlow(td, "test-workflow-id1")
 	expectWorkFlow(td, "test-workflow-id2")
 	expectWorkFlow(td, "test-workflow-id3")
@@ -286,13 +293,6 @@ func expectWorkFlow(td *cliTestData, workflowID string) {
 		Return(mockExecutionManager, nil).
 		Times(1)
 
-	mockHistoryManager := persistence.NewMockHistoryManager(td.ctrl)
-	mockHistoryManager.EXPECT().Close().Times(1)
-	td.mockManagerFactory.EXPECT().
-		initializeHistoryManager(gomock.Any()).
-		Return(mockHistoryManager, nil).
-		Times(1)
-
 	mockExecutionManager.EXPECT().GetCurrentExecution(gomock.Any(), gomock.Any()).
 		Return(&persistence.GetCurrentExecutionResponse{
 			RunID: "test-run-id1",
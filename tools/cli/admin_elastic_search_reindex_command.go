@@ -0,0 +1,366 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/uber/cadence/common/collection"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/persistence"
+	esvisibility "github.com/uber/cadence/common/persistence/elasticsearch"
+	pinotvisibility "github.com/uber/cadence/common/persistence/pinot"
+	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/visibility"
+	"github.com/uber/cadence/tools/common/commoncli"
+)
+
+type visibilityReindexer struct {
+	domainManager     persistence.DomainManager
+	historyManager    persistence.HistoryManager
+	visibilityManager persistence.VisibilityManager // nil on dry runs
+	output            io.Writer
+
+	domainID           string // only reindex this domain, if set
+	searchAttributeKey string // only reindex workflows with this search attribute, if set
+	earliest           time.Time
+	latest             time.Time
+
+	domains   map[string]*persistence.GetDomainResponse
+	reindexed int
+	failed    int
+}
+
+// AdminReindex rebuilds the visibility documents of a domain or time range from the executions in the primary
+// persistence store. Records are published to the visibility Kafka topic, so the worker's indexers write them
+// the same way as records coming from history, including to both indices while migrating visibility stores.
+// With --pinot they are also published to the Pinot visibility topic, as history does while Pinot is written.
+// With --complete_type_change all workflows with the search attribute are reindexed, and its type change pending
+// reindex is completed once none of them failed, so queries by it resolve to the search attribute of the new type.
+func AdminReindex(c *cli.Context) error {
+	domainName := c.String(FlagDomain)
+	completeTypeChange := c.Bool(FlagCompleteTypeChange)
+	if completeTypeChange {
+		if err := validateCompleteTypeChange(c); err != nil {
+			return err
+		}
+	} else if domainName == "" && !c.IsSet(FlagEarliestTime) {
+		return commoncli.Problem(fmt.Sprintf("At least one of --%s or --%s must be provided", FlagDomain, FlagEarliestTime), nil)
+	}
+	earliest, err := parseTime(c.String(FlagEarliestTime), 0)
+	if err != nil {
+		return commoncli.Problem("Invalid earliest time", err)
+	}
+	latest, err := parseTime(c.String(FlagLatestTime), time.Now().UnixNano())
+	if err != nil {
+		return commoncli.Problem("Invalid latest time", err)
+	}
+	if earliest > latest {
+		return commoncli.Problem("Earliest time must not be after latest time", nil)
+	}
+	lowerShardID, upperShardID := c.Int(FlagLowerShardBound), c.Int(FlagUpperShardBound)
+	if lowerShardID > upperShardID {
+		return commoncli.Problem("Lower shard bound must not be greater than upper shard bound", nil)
+	}
+
+	domainManager, err := getDeps(c).initializeDomainManager(c)
+	if err != nil {
+		return commoncli.Problem("Error in initializing domain manager: ", err)
+	}
+	defer domainManager.Close()
+	historyManager, err := getDeps(c).initializeHistoryManager(c)
+	if err != nil {
+		return commoncli.Problem("Error in initializing history manager: ", err)
+	}
+	defer historyManager.Close()
+	r := &visibilityReindexer{
+		domainManager:      domainManager,
+		historyManager:     historyManager,
+		output:             getDeps(c).Output(),
+		searchAttributeKey: c.String(FlagSearchAttributesKey),
+		earliest:           time.Unix(0, earliest),
+		latest:             time.Unix(0, latest),
+		domains:            make(map[string]*persistence.GetDomainResponse),
+	}
+	if !c.Bool(FlagDryRun) {
+		r.visibilityManager, err = newReindexVisibilityManager(c)
+		if err != nil {
+			return err
+		}
+	}
+
+	if domainName != "" {
+		ctx, cancel := context.WithTimeout(c.Context, listContextTimeout)
+		domain, err := domainManager.GetDomain(ctx, &persistence.GetDomainRequest{Name: domainName})
+		cancel()
+		if err != nil {
+			return commoncli.Problem("GetDomain error", err)
+		}
+		r.domainID = domain.Info.ID
+		r.domains[domain.Info.ID] = domain
+	}
+
+	for shardID := lowerShardID; shardID <= upperShardID; shardID++ {
+		if err := r.reindexShard(c, shardID); err != nil {
+			return err
+		}
+	}
+	if r.visibilityManager == nil {
+		fmt.Fprintf(r.output, "Dry run: %d workflow executions would be reindexed\n", r.reindexed)
+		return nil
+	}
+	fmt.Fprintf(r.output, "Reindexed %d workflow executions, %d failed\n", r.reindexed, r.failed)
+	if !completeTypeChange {
+		return nil
+	}
+	if r.failed > 0 {
+		return commoncli.Problem(fmt.Sprintf("Type change of %s is not completed, reindex the failed workflow executions again", r.searchAttributeKey), nil)
+	}
+	return completeSearchAttributeTypeChange(c, r.searchAttributeKey, r.output)
+}
+
+// validateCompleteTypeChange checks the reindex covers all workflows with the search attribute,
+// and its type change is pending reindex
+func validateCompleteTypeChange(c *cli.Context) error {
+	key := c.String(FlagSearchAttributesKey)
+	if key == "" {
+		return commoncli.Problem(fmt.Sprintf("--%s requires --%s", FlagCompleteTypeChange, FlagSearchAttributesKey), nil)
+	}
+	for _, flag := range []string{FlagDomain, FlagEarliestTime, FlagLatestTime, FlagLowerShardBound, FlagDryRun} {
+		if c.IsSet(flag) {
+			return commoncli.Problem(fmt.Sprintf("--%s reindexes all workflows with the search attribute, it can't be used with --%s", FlagCompleteTypeChange, flag), nil)
+		}
+	}
+	adminClient, err := getDeps(c).ServerAdminClient(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := newContext(c)
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	defer cancel()
+	aliases, err := getSearchAttributeConfigForUpdate(ctx, adminClient, dynamicproperties.SearchAttributeAliases)
+	if err != nil {
+		return commoncli.Problem("Failed to get search attribute aliases", err)
+	}
+	if _, ok := visibility.PendingTypeChange(aliases, key); !ok {
+		return commoncli.Problem(fmt.Sprintf("Search attribute %s has no type change pending reindex", key), nil)
+	}
+	return nil
+}
+
+// completeSearchAttributeTypeChange completes the type change of key pending reindex
+func completeSearchAttributeTypeChange(c *cli.Context, key string, output io.Writer) error {
+	adminClient, err := getDeps(c).ServerAdminClient(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := newContext(c)
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	defer cancel()
+	aliases, err := getSearchAttributeConfigForUpdate(ctx, adminClient, dynamicproperties.SearchAttributeAliases)
+	if err != nil {
+		return commoncli.Problem("Failed to get search attribute aliases", err)
+	}
+	aliases, err = visibility.CompleteTypeChange(aliases, key)
+	if err != nil {
+		return commoncli.Problem("Cannot complete search attribute type change.", err)
+	}
+	if err := updateSearchAttributeConfig(ctx, adminClient, dynamicproperties.SearchAttributeAliases, aliases); err != nil {
+		return commoncli.Problem("Complete search attribute type change failed.", err)
+	}
+	fmt.Fprintf(output, "Type change of search attribute %s is completed. Queries by %s resolve to %s.\n", key, key, aliases[key])
+	return nil
+}
+
+// newReindexVisibilityManager returns a visibility manager publishing records to the ES visibility topic,
+// and to the Pinot visibility topic with --pinot
+func newReindexVisibilityManager(c *cli.Context) (persistence.VisibilityManager, error) {
+	visibilityConfig := &persistence.DynamicConfiguration{
+		SerializationEncoding: dynamicproperties.GetStringPropertyFn(string(constants.EncodingTypeThriftRW)),
+	}
+	producer, err := getDeps(c).initializeVisibilityProducer(c, constants.VisibilityAppName)
+	if err != nil {
+		return nil, commoncli.Problem("Error in initializing visibility producer: ", err)
+	}
+	managers := map[string]persistence.VisibilityManager{
+		constants.VisibilityModeES: persistence.NewVisibilityManagerImpl(
+			esvisibility.NewElasticSearchVisibilityStore(nil, "", producer, nil, log.NewNoop()),
+			log.NewNoop(),
+			visibilityConfig,
+		),
+	}
+	if !c.Bool(FlagPinot) {
+		return managers[constants.VisibilityModeES], nil
+	}
+
+	pinotProducer, err := getDeps(c).initializeVisibilityProducer(c, constants.PinotVisibilityAppName)
+	if err != nil {
+		return nil, commoncli.Problem("Error in initializing Pinot visibility producer: ", err)
+	}
+	// Pinot documents are built from the records, with the keys of search attributes whose type changed
+	// resolved from the server's dynamic config
+	adminClient, err := getDeps(c).ServerAdminClient(c)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel, err := newContext(c)
+	if err != nil {
+		return nil, commoncli.Problem("Error in creating context: ", err)
+	}
+	defer cancel()
+	valid, err := getSearchAttributeConfig(ctx, adminClient, dynamicproperties.ValidSearchAttributes)
+	if err != nil {
+		return nil, commoncli.Problem("Failed to get valid search attributes", err)
+	}
+	aliases, err := getSearchAttributeConfig(ctx, adminClient, dynamicproperties.SearchAttributeAliases)
+	if err != nil {
+		return nil, commoncli.Problem("Failed to get search attribute aliases", err)
+	}
+	managers[constants.VisibilityModePinot] = persistence.NewVisibilityManagerImpl(
+		pinotvisibility.NewPinotVisibilityStore(nil, &service.Config{
+			ValidSearchAttributes:      dynamicproperties.GetMapPropertyFn(valid),
+			DomainSearchAttributes:     dynamicproperties.GetMapPropertyFnFilteredByDomain(map[string]interface{}{}),
+			SearchAttributeAliases:     dynamicproperties.GetMapPropertyFn(aliases),
+			PinotOptimizedQueryColumns: dynamicproperties.GetMapPropertyFn(map[string]interface{}{}),
+		}, pinotProducer, log.NewNoop()),
+		log.NewNoop(),
+		visibilityConfig,
+	)
+	return persistence.NewVisibilityHybridManager(
+		managers,
+		nil,
+		dynamicproperties.GetStringPropertyFn(constants.VisibilityModeES+","+constants.VisibilityModePinot),
+		nil,
+		"reindex",
+		log.NewNoop(),
+	), nil
+}
+
+func (r *visibilityReindexer) reindexShard(c *cli.Context, shardID int) error {
+	executionManager, err := getDeps(c).initializeExecutionManager(c, shardID)
+	if err != nil {
+		return commoncli.Problem("Error in initializing execution manager: ", err)
+	}
+	defer executionManager.Close()
+
+	paginationFunc := func(paginationToken []byte) ([]interface{}, []byte, error) {
+		ctx, cancel := context.WithTimeout(c.Context, listContextTimeout)
+		defer cancel()
+
+		resp, err := executionManager.ListConcreteExecutions(ctx, &persistence.ListConcreteExecutionsRequest{
+			PageSize:  1000,
+			PageToken: paginationToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		var paginateItems []interface{}
+		for _, execution := range resp.Executions {
+			paginateItems = append(paginateItems, execution)
+		}
+		return paginateItems, resp.PageToken, nil
+	}
+
+	executionIterator := collection.NewPagingIterator(paginationFunc)
+	for executionIterator.HasNext() {
+		result, err := executionIterator.Next()
+		if err != nil {
+			return commoncli.Problem(fmt.Sprintf("Failed to list executions of shard %v", shardID), err)
+		}
+		execution := result.(*persistence.ListConcreteExecutionsEntity)
+		if !r.shouldReindex(execution.ExecutionInfo) {
+			continue
+		}
+		if err := r.reindexExecution(c.Context, shardID, execution); err != nil {
+			r.failed++
+			fmt.Fprintf(r.output, "Failed to reindex workflow %v, run %v: %v\n",
+				execution.ExecutionInfo.WorkflowID, execution.ExecutionInfo.RunID, err)
+			continue
+		}
+		r.reindexed++
+	}
+	return nil
+}
+
+func (r *visibilityReindexer) shouldReindex(info *persistence.WorkflowExecutionInfo) bool {
+	if info == nil {
+		return false
+	}
+	if r.domainID != "" && info.DomainID != r.domainID {
+		return false
+	}
+	if info.StartTimestamp.Before(r.earliest) || info.StartTimestamp.After(r.latest) {
+		return false
+	}
+	if _, ok := info.SearchAttributes[r.searchAttributeKey]; r.searchAttributeKey != "" && !ok {
+		return false
+	}
+	switch info.State {
+	case persistence.WorkflowStateCreated, persistence.WorkflowStateRunning, persistence.WorkflowStateCompleted:
+		return true
+	default: // zombie, void and corrupted workflows are not visible
+		return false
+	}
+}
+
+func (r *visibilityReindexer) reindexExecution(ctx context.Context, shardID int, execution *persistence.ListConcreteExecutionsEntity) error {
+	if r.visibilityManager == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, listContextTimeout)
+	defer cancel()
+
+	domain, err := r.getDomain(ctx, execution.ExecutionInfo.DomainID)
+	if err != nil {
+		return err
+	}
+	return visibility.Republish(ctx, r.visibilityManager, r.historyManager, &visibility.RepublishRequest{
+		DomainName:       domain.Info.Name,
+		NumClusters:      int16(len(domain.ReplicationConfig.Clusters)),
+		RetentionDays:    domain.Config.Retention,
+		ShardID:          shardID,
+		ExecutionInfo:    execution.ExecutionInfo,
+		VersionHistories: execution.VersionHistories,
+	})
+}
+
+func (r *visibilityReindexer) getDomain(ctx context.Context, domainID string) (*persistence.GetDomainResponse, error) {
+	if domain, ok := r.domains[domainID]; ok {
+		return domain, nil
+	}
+	domain, err := r.domainManager.GetDomain(ctx, &persistence.GetDomainRequest{ID: domainID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain %v: %w", domainID, err)
+	}
+	r.domains[domainID] = domain
+	return domain, nil
+}
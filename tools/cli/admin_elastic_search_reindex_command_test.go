@@ -0,0 +1,353 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/.gen/go/indexer"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/tools/cli/clitest"
+)
+
+func TestAdminReindex(t *testing.T) {
+	startTime := time.Now().Add(-time.Hour)
+	domain := &persistence.GetDomainResponse{
+		Info:              &persistence.DomainInfo{ID: "domain-id", Name: "test-domain"},
+		Config:            &persistence.DomainConfig{Retention: 1},
+		ReplicationConfig: &persistence.DomainReplicationConfig{Clusters: []*persistence.ClusterReplicationConfig{{ClusterName: "active"}}},
+	}
+	newExecution := func(domainID, workflowID string, state int) *persistence.ListConcreteExecutionsEntity {
+		execution := &persistence.ListConcreteExecutionsEntity{
+			ExecutionInfo: &persistence.WorkflowExecutionInfo{
+				DomainID:       domainID,
+				WorkflowID:     workflowID,
+				RunID:          workflowID + "-run",
+				State:          state,
+				StartTimestamp: startTime,
+				NextEventID:    5,
+				BranchToken:    []byte("branch-token"),
+			},
+		}
+		if state == persistence.WorkflowStateCompleted {
+			execution.ExecutionInfo.CloseStatus = persistence.WorkflowCloseStatusCompleted
+		}
+		return execution
+	}
+	executions := []*persistence.ListConcreteExecutionsEntity{
+		newExecution("domain-id", "running", persistence.WorkflowStateRunning),
+		newExecution("domain-id", "closed", persistence.WorkflowStateCompleted),
+		newExecution("domain-id", "zombie", persistence.WorkflowStateZombie),
+		newExecution("other-domain-id", "other", persistence.WorkflowStateRunning),
+	}
+	executions[0].ExecutionInfo.SearchAttributes = map[string][]byte{"CustomKeywordField": []byte(`"value"`)}
+	startEvent := &types.HistoryEvent{
+		ID:                                      constants.FirstEventID,
+		Timestamp:                               common.Int64Ptr(startTime.UnixNano()),
+		WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{},
+	}
+
+	expectAliases := func(td *cliTestData, aliases string) {
+		td.mockAdminClient.EXPECT().ListDynamicConfig(gomock.Any(), &types.ListDynamicConfigRequest{ConfigName: dynamicproperties.SearchAttributeAliases.String()}).
+			Return(&types.ListDynamicConfigResponse{Entries: []*types.DynamicConfigEntry{{
+				Name:   dynamicproperties.SearchAttributeAliases.String(),
+				Values: []*types.DynamicConfigValue{{Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: []byte(aliases)}}},
+			}}}, nil)
+	}
+	pendingAliases := `{"CustomKeywordField":{"pendingReindex":"CustomKeywordFieldInt"}}`
+
+	tests := []struct {
+		name           string
+		args           []clitest.CliArgument
+		mockSetup      func(td *cliTestData)
+		errContains    string
+		expectedOutput string
+	}{
+		{
+			name:        "neither domain nor time range",
+			errContains: "At least one of --domain or --earliest_time must be provided",
+		},
+		{
+			name: "invalid shard bounds",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagDomain, "test-domain"),
+				clitest.IntArgument(FlagLowerShardBound, 2),
+				clitest.IntArgument(FlagUpperShardBound, 1),
+			},
+			errContains: "Lower shard bound must not be greater than upper shard bound",
+		},
+		{
+			name: "domain manager error",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagDomain, "test-domain"),
+			},
+			mockSetup: func(td *cliTestData) {
+				td.mockManagerFactory.EXPECT().initializeDomainManager(gomock.Any()).Return(nil, errors.New("init error"))
+			},
+			errContains: "Error in initializing domain manager",
+		},
+		{
+			name: "dry run",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagDomain, "test-domain"),
+				clitest.BoolArgument(FlagDryRun, true),
+			},
+			mockSetup: func(td *cliTestData) {
+				domainManager := persistence.NewMockDomainManager(td.ctrl)
+				domainManager.EXPECT().GetDomain(gomock.Any(), &persistence.GetDomainRequest{Name: "test-domain"}).Return(domain, nil)
+				domainManager.EXPECT().Close()
+				historyManager := persistence.NewMockHistoryManager(td.ctrl)
+				historyManager.EXPECT().Close()
+				executionManager := persistence.NewMockExecutionManager(td.ctrl)
+				executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).
+					Return(&persistence.ListConcreteExecutionsResponse{Executions: executions}, nil)
+				executionManager.EXPECT().Close()
+
+				td.mockManagerFactory.EXPECT().initializeDomainManager(gomock.Any()).Return(domainManager, nil)
+				td.mockManagerFactory.EXPECT().initializeHistoryManager(gomock.Any()).Return(historyManager, nil)
+				td.mockManagerFactory.EXPECT().initializeExecutionManager(gomock.Any(), 0).Return(executionManager, nil)
+			},
+			expectedOutput: "Dry run: 2 workflow executions would be reindexed\n",
+		},
+		{
+			name: "dry run of workflows with search attribute",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagDomain, "test-domain"),
+				clitest.StringArgument(FlagSearchAttributesKey, "CustomKeywordField"),
+				clitest.BoolArgument(FlagDryRun, true),
+			},
+			mockSetup: func(td *cliTestData) {
+				domainManager := persistence.NewMockDomainManager(td.ctrl)
+				domainManager.EXPECT().GetDomain(gomock.Any(), &persistence.GetDomainRequest{Name: "test-domain"}).Return(domain, nil)
+				domainManager.EXPECT().Close()
+				historyManager := persistence.NewMockHistoryManager(td.ctrl)
+				historyManager.EXPECT().Close()
+				executionManager := persistence.NewMockExecutionManager(td.ctrl)
+				executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).
+					Return(&persistence.ListConcreteExecutionsResponse{Executions: executions}, nil)
+				executionManager.EXPECT().Close()
+
+				td.mockManagerFactory.EXPECT().initializeDomainManager(gomock.Any()).Return(domainManager, nil)
+				td.mockManagerFactory.EXPECT().initializeHistoryManager(gomock.Any()).Return(historyManager, nil)
+				td.mockManagerFactory.EXPECT().initializeExecutionManager(gomock.Any(), 0).Return(executionManager, nil)
+			},
+			expectedOutput: "Dry run: 1 workflow executions would be reindexed\n",
+		},
+		{
+			name: "reindex domain",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagDomain, "test-domain"),
+			},
+			mockSetup: func(td *cliTestData) {
+				domainManager := persistence.NewMockDomainManager(td.ctrl)
+				domainManager.EXPECT().GetDomain(gomock.Any(), &persistence.GetDomainRequest{Name: "test-domain"}).Return(domain, nil)
+				domainManager.EXPECT().Close()
+				historyManager := persistence.NewMockHistoryManager(td.ctrl)
+				historyManager.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).
+					Return(&persistence.ReadHistoryBranchResponse{HistoryEvents: []*types.HistoryEvent{startEvent}}, nil)
+				historyManager.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("history read error"))
+				historyManager.EXPECT().Close()
+				executionManager := persistence.NewMockExecutionManager(td.ctrl)
+				executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).
+					Return(&persistence.ListConcreteExecutionsResponse{Executions: executions}, nil)
+				executionManager.EXPECT().Close()
+				producer := messaging.NewMockProducer(td.ctrl)
+				producer.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+				td.mockManagerFactory.EXPECT().initializeDomainManager(gomock.Any()).Return(domainManager, nil)
+				td.mockManagerFactory.EXPECT().initializeHistoryManager(gomock.Any()).Return(historyManager, nil)
+				td.mockManagerFactory.EXPECT().initializeExecutionManager(gomock.Any(), 0).Return(executionManager, nil)
+				td.mockManagerFactory.EXPECT().initializeVisibilityProducer(gomock.Any(), constants.VisibilityAppName).Return(producer, nil)
+			},
+			expectedOutput: "Failed to reindex workflow closed, run closed-run: failed to read start event: history read error\n" +
+				"Reindexed 1 workflow executions, 1 failed\n",
+		},
+		{
+			name: "reindex to ES and Pinot after a type change",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagDomain, "test-domain"),
+				clitest.StringArgument(FlagSearchAttributesKey, "CustomKeywordField"),
+				clitest.BoolArgument(FlagPinot, true),
+			},
+			mockSetup: func(td *cliTestData) {
+				changed := newExecution("domain-id", "changed", persistence.WorkflowStateRunning)
+				changed.ExecutionInfo.SearchAttributes = map[string][]byte{"CustomKeywordField": []byte(`1`)}
+				domainManager := persistence.NewMockDomainManager(td.ctrl)
+				domainManager.EXPECT().GetDomain(gomock.Any(), &persistence.GetDomainRequest{Name: "test-domain"}).Return(domain, nil)
+				domainManager.EXPECT().Close()
+				historyManager := persistence.NewMockHistoryManager(td.ctrl)
+				historyManager.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).
+					Return(&persistence.ReadHistoryBranchResponse{HistoryEvents: []*types.HistoryEvent{startEvent}}, nil)
+				historyManager.EXPECT().Close()
+				executionManager := persistence.NewMockExecutionManager(td.ctrl)
+				executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).
+					Return(&persistence.ListConcreteExecutionsResponse{Executions: []*persistence.ListConcreteExecutionsEntity{changed}}, nil)
+				executionManager.EXPECT().Close()
+				producer := messaging.NewMockProducer(td.ctrl)
+				producer.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+				pinotProducer := messaging.NewMockProducer(td.ctrl)
+				pinotProducer.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, msg interface{}) error {
+					var payload map[string]interface{}
+					require.NoError(t, json.Unmarshal(msg.(*indexer.PinotMessage).GetPayload(), &payload))
+					assert.Equal(t, map[string]interface{}{"CustomKeywordFieldInt": float64(1)}, payload["Attr"])
+					return nil
+				})
+				for key, value := range map[dynamicproperties.MapKey]string{
+					dynamicproperties.ValidSearchAttributes:  `{"CustomKeywordField":1,"CustomKeywordFieldInt":2}`,
+					dynamicproperties.SearchAttributeAliases: `{"CustomKeywordField":"CustomKeywordFieldInt"}`,
+				} {
+					td.mockAdminClient.EXPECT().ListDynamicConfig(gomock.Any(), &types.ListDynamicConfigRequest{ConfigName: key.String()}).
+						Return(&types.ListDynamicConfigResponse{Entries: []*types.DynamicConfigEntry{{
+							Name:   key.String(),
+							Values: []*types.DynamicConfigValue{{Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: []byte(value)}}},
+						}}}, nil)
+				}
+
+				td.mockManagerFactory.EXPECT().initializeDomainManager(gomock.Any()).Return(domainManager, nil)
+				td.mockManagerFactory.EXPECT().initializeHistoryManager(gomock.Any()).Return(historyManager, nil)
+				td.mockManagerFactory.EXPECT().initializeExecutionManager(gomock.Any(), 0).Return(executionManager, nil)
+				td.mockManagerFactory.EXPECT().initializeVisibilityProducer(gomock.Any(), constants.VisibilityAppName).Return(producer, nil)
+				td.mockManagerFactory.EXPECT().initializeVisibilityProducer(gomock.Any(), constants.PinotVisibilityAppName).Return(pinotProducer, nil)
+			},
+			expectedOutput: "Reindexed 1 workflow executions, 0 failed\n",
+		},
+		{
+			name:        "complete type change without search attribute",
+			args:        []clitest.CliArgument{clitest.BoolArgument(FlagCompleteTypeChange, true)},
+			errContains: "--complete_type_change requires --search_attr_key",
+		},
+		{
+			name: "complete type change of a domain",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagSearchAttributesKey, "CustomKeywordField"),
+				clitest.StringArgument(FlagDomain, "test-domain"),
+				clitest.BoolArgument(FlagCompleteTypeChange, true),
+			},
+			errContains: "it can't be used with --domain",
+		},
+		{
+			name: "complete type change not pending reindex",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagSearchAttributesKey, "CustomKeywordField"),
+				clitest.BoolArgument(FlagCompleteTypeChange, true),
+			},
+			mockSetup: func(td *cliTestData) {
+				expectAliases(td, `{"CustomKeywordField":"CustomKeywordFieldInt"}`)
+			},
+			errContains: "Search attribute CustomKeywordField has no type change pending reindex",
+		},
+		{
+			name: "complete type change after failed reindex",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagSearchAttributesKey, "CustomKeywordField"),
+				clitest.BoolArgument(FlagCompleteTypeChange, true),
+			},
+			mockSetup: func(td *cliTestData) {
+				expectAliases(td, pendingAliases)
+				domainManager := persistence.NewMockDomainManager(td.ctrl)
+				domainManager.EXPECT().GetDomain(gomock.Any(), &persistence.GetDomainRequest{ID: "domain-id"}).Return(domain, nil)
+				domainManager.EXPECT().Close()
+				historyManager := persistence.NewMockHistoryManager(td.ctrl)
+				historyManager.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("history read error"))
+				historyManager.EXPECT().Close()
+				executionManager := persistence.NewMockExecutionManager(td.ctrl)
+				executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).
+					Return(&persistence.ListConcreteExecutionsResponse{Executions: executions}, nil)
+				executionManager.EXPECT().Close()
+				producer := messaging.NewMockProducer(td.ctrl)
+
+				td.mockManagerFactory.EXPECT().initializeDomainManager(gomock.Any()).Return(domainManager, nil)
+				td.mockManagerFactory.EXPECT().initializeHistoryManager(gomock.Any()).Return(historyManager, nil)
+				td.mockManagerFactory.EXPECT().initializeExecutionManager(gomock.Any(), 0).Return(executionManager, nil)
+				td.mockManagerFactory.EXPECT().initializeVisibilityProducer(gomock.Any(), constants.VisibilityAppName).Return(producer, nil)
+			},
+			errContains: "Type change of CustomKeywordField is not completed, reindex the failed workflow executions again",
+		},
+		{
+			name: "complete type change",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagSearchAttributesKey, "CustomKeywordField"),
+				clitest.BoolArgument(FlagCompleteTypeChange, true),
+			},
+			mockSetup: func(td *cliTestData) {
+				expectAliases(td, pendingAliases)
+				domainManager := persistence.NewMockDomainManager(td.ctrl)
+				domainManager.EXPECT().GetDomain(gomock.Any(), &persistence.GetDomainRequest{ID: "domain-id"}).Return(domain, nil)
+				domainManager.EXPECT().Close()
+				historyManager := persistence.NewMockHistoryManager(td.ctrl)
+				historyManager.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).
+					Return(&persistence.ReadHistoryBranchResponse{HistoryEvents: []*types.HistoryEvent{startEvent}}, nil)
+				historyManager.EXPECT().Close()
+				executionManager := persistence.NewMockExecutionManager(td.ctrl)
+				executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).
+					Return(&persistence.ListConcreteExecutionsResponse{Executions: executions}, nil)
+				executionManager.EXPECT().Close()
+				producer := messaging.NewMockProducer(td.ctrl)
+				producer.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+				expectAliases(td, pendingAliases)
+				td.mockAdminClient.EXPECT().UpdateDynamicConfig(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *types.UpdateDynamicConfigRequest, _ ...interface{}) error {
+						assert.Equal(t, dynamicproperties.SearchAttributeAliases.String(), req.ConfigName)
+						require.Len(t, req.ConfigValues, 1)
+						assert.JSONEq(t, `{"CustomKeywordField":"CustomKeywordFieldInt"}`, string(req.ConfigValues[0].Value.Data))
+						return nil
+					})
+
+				td.mockManagerFactory.EXPECT().initializeDomainManager(gomock.Any()).Return(domainManager, nil)
+				td.mockManagerFactory.EXPECT().initializeHistoryManager(gomock.Any()).Return(historyManager, nil)
+				td.mockManagerFactory.EXPECT().initializeExecutionManager(gomock.Any(), 0).Return(executionManager, nil)
+				td.mockManagerFactory.EXPECT().initializeVisibilityProducer(gomock.Any(), constants.VisibilityAppName).Return(producer, nil)
+			},
+			expectedOutput: "Reindexed 1 workflow executions, 0 failed\n" +
+				"Type change of search attribute CustomKeywordField is completed. Queries by CustomKeywordField resolve to CustomKeywordFieldInt.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			if tt.mockSetup != nil {
+				tt.mockSetup(td)
+			}
+			err := AdminReindex(clitest.NewCLIContext(t, td.app, tt.args...))
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, td.consoleOutput())
+		})
+	}
+}
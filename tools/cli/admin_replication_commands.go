@@ -0,0 +1,202 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/batcher"
+	"github.com/uber/cadence/tools/common/commoncli"
+)
+
+// ReReplicationJobRow is the progress report of a re-replication batch job
+type ReReplicationJobRow struct {
+	JobID           string        `header:"Job ID" json:"jobID"`
+	Status          string        `header:"Status" json:"status"`
+	Processed       int           `header:"Processed" json:"processed"`
+	Succeeded       int           `header:"Succeeded" json:"succeeded"`
+	Failed          int           `header:"Failed" json:"failed"`
+	TotalEstimate   int64         `header:"Total Estimate" json:"totalEstimate"`
+	PercentComplete string        `header:"Complete" json:"percentComplete"`
+	Elapsed         time.Duration `header:"Elapsed" json:"elapsed"`
+	Remaining       time.Duration `header:"Estimated Remaining" json:"remaining"`
+}
+
+// AdminStartReReplication starts a batch job re-sending replication tasks of the selected workflows to a remote cluster
+func AdminStartReReplication(c *cli.Context) error {
+	domain, err := getRequiredOption(c, FlagDomain)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	sourceCluster, err := getRequiredOption(c, FlagSourceCluster)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	targetCluster, err := getRequiredOption(c, FlagTargetCluster)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	reason, err := getRequiredOption(c, FlagReason)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	query, err := getReReplicationQuery(c)
+	if err != nil {
+		return err
+	}
+
+	params := batcher.BatchParams{
+		DomainName: domain,
+		Query:      query,
+		Reason:     reason,
+		BatchType:  batcher.BatchTypeReplicate,
+		ReplicateParams: batcher.ReplicateParams{
+			SourceCluster: sourceCluster,
+			TargetCluster: targetCluster,
+		},
+		RPS:                      c.Int(FlagRPS),
+		Concurrency:              c.Int(FlagConcurrency),
+		PageSize:                 c.Int(FlagPageSize),
+		AttemptsOnRetryableError: c.Int(FlagRetryAttempts),
+		ActivityHeartBeatTimeout: batcher.DefaultActivityHeartBeatTimeout,
+		MaxActivityRetries:       batcher.DefaultMaxActivityRetries,
+	}
+	// V2 carries progress across activity restarts and can be re-throttled while running
+	return startBatchWorkflow(c, params, batcher.BatchWFV2TypeName)
+}
+
+// getReReplicationQuery builds the visibility query selecting workflows to re-replicate.
+// Exactly one of a workflow ID, a query or a time window must be given.
+func getReReplicationQuery(c *cli.Context) (string, error) {
+	selectors := 0
+	for _, flag := range []string{FlagWorkflowID, FlagListQuery, FlagEarliestTime} {
+		if c.IsSet(flag) {
+			selectors++
+		}
+	}
+	if selectors != 1 {
+		return "", commoncli.Problem(fmt.Sprintf("Exactly one of --%s, --%s or --%s must be provided", FlagWorkflowID, FlagListQuery, FlagEarliestTime), nil)
+	}
+
+	switch {
+	case c.IsSet(FlagWorkflowID):
+		return fmt.Sprintf("WorkflowID = %q", c.String(FlagWorkflowID)), nil
+	case c.IsSet(FlagListQuery):
+		return c.String(FlagListQuery), nil
+	}
+	earliest, err := parseTime(c.String(FlagEarliestTime), 0)
+	if err != nil {
+		return "", commoncli.Problem("Invalid earliest time", err)
+	}
+	latest, err := parseTime(c.String(FlagLatestTime), time.Now().UnixNano())
+	if err != nil {
+		return "", commoncli.Problem("Invalid latest time", err)
+	}
+	if earliest > latest {
+		return "", commoncli.Problem("Earliest time must not be after latest time", nil)
+	}
+	return batcher.ReplicationWindowQuery(time.Unix(0, earliest), time.Unix(0, latest)), nil
+}
+
+// AdminDescribeReReplication reports the progress of a re-replication batch job
+func AdminDescribeReReplication(c *cli.Context) error {
+	jobID, err := getRequiredOption(c, FlagJobID)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	svcClient, err := getDeps(c).ServerFrontendClient(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context:", err)
+	}
+
+	execution := &types.WorkflowExecution{WorkflowID: jobID}
+	wf, err := svcClient.DescribeWorkflowExecution(ctx, &types.DescribeWorkflowExecutionRequest{
+		Domain:    constants.BatcherLocalDomainName,
+		Execution: execution,
+	})
+	if err != nil {
+		return commoncli.Problem("Failed to describe re-replication job", err)
+	}
+
+	info := wf.WorkflowExecutionInfo
+	hbd := batcher.HeartBeatDetails{}
+	status := "RUNNING"
+	endTime := time.Now()
+	if info.CloseStatus != nil {
+		status = info.GetCloseStatus().String()
+		endTime = time.Unix(0, info.GetCloseTime())
+		if info.GetCloseStatus() == types.WorkflowExecutionCloseStatusCompleted {
+			if hbd, err = getBatchJobResult(ctx, svcClient, execution); err != nil {
+				return commoncli.Problem("Failed to get re-replication job result", err)
+			}
+		}
+	} else if len(wf.PendingActivities) > 0 && len(wf.PendingActivities[0].HeartbeatDetails) > 0 {
+		if err := json.Unmarshal(wf.PendingActivities[0].HeartbeatDetails, &hbd); err != nil {
+			return commoncli.Problem("Failed to decode re-replication job progress", err)
+		}
+	}
+
+	progress := hbd.Progress(endTime.Sub(time.Unix(0, info.GetStartTime())))
+	row := ReReplicationJobRow{
+		JobID:           jobID,
+		Status:          status,
+		Processed:       progress.Processed,
+		Succeeded:       progress.Succeeded,
+		Failed:          progress.Failed,
+		TotalEstimate:   progress.TotalEstimate,
+		PercentComplete: fmt.Sprintf("%.1f%%", progress.PercentComplete),
+		Elapsed:         progress.Elapsed.Round(time.Second),
+		Remaining:       progress.Remaining.Round(time.Second),
+	}
+	return Render(c, []ReReplicationJobRow{row}, RenderOptions{Color: true, DefaultTemplate: templateTable})
+}
+
+// getBatchJobResult returns the heartbeat details a completed batch workflow returned as its result
+func getBatchJobResult(ctx context.Context, svcClient frontend.Client, execution *types.WorkflowExecution) (batcher.HeartBeatDetails, error) {
+	hbd := batcher.HeartBeatDetails{}
+	resp, err := svcClient.GetWorkflowExecutionHistory(ctx, &types.GetWorkflowExecutionHistoryRequest{
+		Domain:                 constants.BatcherLocalDomainName,
+		Execution:              execution,
+		HistoryEventFilterType: types.HistoryEventFilterTypeCloseEvent.Ptr(),
+	})
+	if err != nil {
+		return hbd, err
+	}
+	for _, event := range resp.GetHistory().GetEvents() {
+		if attributes := event.WorkflowExecutionCompletedEventAttributes; attributes != nil && len(attributes.Result) > 0 {
+			err = json.Unmarshal(attributes.Result, &hbd)
+		}
+	}
+	return hbd, err
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/yarpc"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/batcher"
+	"github.com/uber/cadence/tools/cli/clitest"
+)
+
+func TestAdminStartReReplication(t *testing.T) {
+	requiredArgs := []clitest.CliArgument{
+		clitest.StringArgument(FlagDomain, testDomain),
+		clitest.StringArgument(FlagSourceCluster, "cluster0"),
+		clitest.StringArgument(FlagTargetCluster, "cluster1"),
+		clitest.StringArgument(FlagReason, "replication incident"),
+		clitest.BoolArgument(FlagYes, true),
+	}
+	expectStart := func(td *cliTestData, expectedQuery string) {
+		td.mockFrontendClient.EXPECT().CountWorkflowExecutions(gomock.Any(), &types.CountWorkflowExecutionsRequest{
+			Domain: testDomain,
+			Query:  expectedQuery,
+		}).Return(&types.CountWorkflowExecutionsResponse{Count: 10}, nil)
+		td.mockFrontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, request *types.StartWorkflowExecutionRequest, _ ...yarpc.CallOption) (*types.StartWorkflowExecutionResponse, error) {
+				assert.Equal(t, constants.BatcherLocalDomainName, request.Domain)
+				assert.Equal(t, batcher.BatchWFV2TypeName, request.WorkflowType.Name)
+				var params batcher.BatchParams
+				assert.NoError(t, json.Unmarshal(request.Input, &params))
+				assert.Equal(t, batcher.BatchTypeReplicate, params.BatchType)
+				assert.Equal(t, expectedQuery, params.Query)
+				assert.Equal(t, batcher.ReplicateParams{SourceCluster: "cluster0", TargetCluster: "cluster1"}, params.ReplicateParams)
+				return &types.StartWorkflowExecutionResponse{RunID: "run-id"}, nil
+			})
+	}
+
+	tests := []struct {
+		name        string
+		args        []clitest.CliArgument
+		setup       func(td *cliTestData)
+		errContains string
+	}{
+		{
+			name:        "missing target cluster",
+			args:        []clitest.CliArgument{clitest.StringArgument(FlagDomain, testDomain), clitest.StringArgument(FlagSourceCluster, "cluster0")},
+			errContains: "option target_cluster is required",
+		},
+		{
+			name:        "no workflow selector",
+			args:        requiredArgs,
+			errContains: "Exactly one of --workflow_id, --query or --earliest_time must be provided",
+		},
+		{
+			name:        "multiple workflow selectors",
+			args:        append(requiredArgs, clitest.StringArgument(FlagWorkflowID, "wid"), clitest.StringArgument(FlagListQuery, "WorkflowType = 'x'")),
+			errContains: "Exactly one of",
+		},
+		{
+			name:        "time window out of order",
+			args:        append(requiredArgs, clitest.StringArgument(FlagEarliestTime, "2000"), clitest.StringArgument(FlagLatestTime, "1000")),
+			errContains: "Earliest time must not be after latest time",
+		},
+		{
+			name: "single workflow",
+			args: append(requiredArgs, clitest.StringArgument(FlagWorkflowID, "wid")),
+			setup: func(td *cliTestData) {
+				expectStart(td, `WorkflowID = "wid"`)
+			},
+		},
+		{
+			name: "time window",
+			args: append(requiredArgs, clitest.StringArgument(FlagEarliestTime, "1000"), clitest.StringArgument(FlagLatestTime, "2000")),
+			setup: func(td *cliTestData) {
+				expectStart(td, batcher.ReplicationWindowQuery(time.Unix(0, 1000), time.Unix(0, 2000)))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			if tt.setup != nil {
+				tt.setup(td)
+			}
+
+			err := AdminStartReReplication(clitest.NewCLIContext(t, td.app, tt.args...))
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+				assert.Contains(t, td.consoleOutput(), "batch job is started")
+			} else {
+				assert.ErrorContains(t, err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestAdminDescribeReReplication(t *testing.T) {
+	startTime := time.Now().Add(-time.Hour)
+	hbd := batcher.HeartBeatDetails{TotalEstimate: 100, SuccessCount: 40, ErrorCount: 10}
+	encodedHBD, err := json.Marshal(hbd)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		setup          func(td *cliTestData)
+		errContains    string
+		outputContains []string
+	}{
+		{
+			name: "running",
+			setup: func(td *cliTestData) {
+				td.mockFrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&types.DescribeWorkflowExecutionResponse{
+					WorkflowExecutionInfo: &types.WorkflowExecutionInfo{StartTime: common.Int64Ptr(startTime.UnixNano())},
+					PendingActivities:     []*types.PendingActivityInfo{{HeartbeatDetails: encodedHBD}},
+				}, nil)
+			},
+			outputContains: []string{`"status": "RUNNING"`, `"processed": 50`, `"percentComplete": "50.0%"`},
+		},
+		{
+			name: "completed",
+			setup: func(td *cliTestData) {
+				td.mockFrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&types.DescribeWorkflowExecutionResponse{
+					WorkflowExecutionInfo: &types.WorkflowExecutionInfo{
+						StartTime:   common.Int64Ptr(startTime.UnixNano()),
+						CloseTime:   common.Int64Ptr(startTime.Add(time.Minute).UnixNano()),
+						CloseStatus: types.WorkflowExecutionCloseStatusCompleted.Ptr(),
+					},
+				}, nil)
+				td.mockFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(&types.GetWorkflowExecutionHistoryResponse{
+					History: &types.History{Events: []*types.HistoryEvent{{
+						WorkflowExecutionCompletedEventAttributes: &types.WorkflowExecutionCompletedEventAttributes{Result: encodedHBD},
+					}}},
+				}, nil)
+			},
+			outputContains: []string{`"status": "COMPLETED"`, `"succeeded": 40`, `"elapsed": 60000000000`},
+		},
+		{
+			name: "describe error",
+			setup: func(td *cliTestData) {
+				td.mockFrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, errors.New("critical error"))
+			},
+			errContains: "Failed to describe re-replication job",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			tt.setup(td)
+
+			err := AdminDescribeReReplication(clitest.NewCLIContext(t, td.app,
+				clitest.StringArgument(FlagJobID, "job-id"),
+				clitest.StringArgument(FlagFormat, formatJSON),
+			))
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errContains)
+			}
+			for _, expected := range tt.outputContains {
+				assert.Contains(t, td.consoleOutput(), expected)
+			}
+		})
+	}
+}
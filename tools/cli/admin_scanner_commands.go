@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/reconciliation"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/tools/common/commoncli"
+)
+
+// AdminStartTargetedScan starts a scan of the executions of a domain, selected by a visibility query or by
+// workflow IDs, and prints the workflow ID of the scan which is used to fetch its report.
+// There is no admin API for targeted scans yet, so the scan workflow is started directly in the
+// system domain and its report is fetched with a workflow query.
+func AdminStartTargetedScan(c *cli.Context) error {
+	domain, err := getRequiredOption(c, FlagDomain)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	collections := c.StringSlice(FlagInvariantCollection)
+	if len(collections) == 0 {
+		return commoncli.Problem("Required flag not found: ", fmt.Errorf("option %s is required", FlagInvariantCollection))
+	}
+	workflowIDs := c.StringSlice(FlagWorkflowID)
+	if c.IsSet(FlagInputFile) {
+		ids, err := readWorkflowIDs(c.String(FlagInputFile))
+		if err != nil {
+			return commoncli.Problem("Failed to read workflow IDs: ", err)
+		}
+		workflowIDs = append(workflowIDs, ids...)
+	}
+	query := c.String(FlagListQuery)
+	if query != "" && len(workflowIDs) > 0 {
+		return commoncli.Problem(fmt.Sprintf("Only one of --%s and --%s can be used", FlagListQuery, FlagWorkflowID), nil)
+	}
+
+	params := reconciliation.TargetedScanParams{
+		Domain:        domain,
+		Query:         query,
+		WorkflowIDs:   workflowIDs,
+		Collections:   collections,
+		Fix:           c.Bool(FlagFix),
+		MaxExecutions: c.Int(FlagMaxExecutions),
+	}
+	input, err := json.Marshal(params)
+	if err != nil {
+		return commoncli.Problem("Failed to serialize targeted scan params", err)
+	}
+
+	client, err := getCadenceClient(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	workflowID := reconciliation.TargetedScanWorkflowIDPrefix + uuidFn()
+	resp, err := client.StartWorkflowExecution(ctx, &types.StartWorkflowExecutionRequest{
+		Domain:                              constants.SystemLocalDomainName,
+		RequestID:                           uuidFn(),
+		WorkflowID:                          workflowID,
+		WorkflowIDReusePolicy:               types.WorkflowIDReusePolicyRejectDuplicate.Ptr(),
+		TaskList:                            &types.TaskList{Name: reconciliation.TargetedScanWorkflowTaskList},
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(reconciliation.TargetedScanWorkflowTimeoutInSeconds),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(reconciliation.TargetedScanWorkflowTaskTimeoutInSeconds),
+		WorkflowType:                        &types.WorkflowType{Name: reconciliation.TargetedScanWorkflowType},
+		Input:                               input,
+	})
+	if err != nil {
+		return commoncli.Problem("Failed to start targeted scan", err)
+	}
+	output := getDeps(c).Output()
+	fmt.Fprintln(output, "Targeted scan started")
+	fmt.Fprintln(output, "wid: "+workflowID)
+	fmt.Fprintln(output, "rid: "+resp.GetRunID())
+	return nil
+}
+
+// AdminTargetedScanReport prints the corruption report of a targeted scan, the report is partial until the scan completes.
+func AdminTargetedScanReport(c *cli.Context) error {
+	workflowID, err := getRequiredOption(c, FlagWorkflowID)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	client, err := getCadenceClient(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	resp, err := client.QueryWorkflow(ctx, &types.QueryWorkflowRequest{
+		Domain: constants.SystemLocalDomainName,
+		Execution: &types.WorkflowExecution{
+			WorkflowID: workflowID,
+			RunID:      c.String(FlagRunID),
+		},
+		Query: &types.WorkflowQuery{
+			QueryType: reconciliation.TargetedScanReportQuery,
+		},
+	})
+	if err != nil {
+		return commoncli.Problem("Failed to fetch targeted scan report", err)
+	}
+	var report reconciliation.TargetedScanReport
+	if err := json.Unmarshal(resp.GetQueryResult(), &report); err != nil {
+		return commoncli.Problem("Failed to decode targeted scan report", err)
+	}
+	prettyPrintJSONObject(getDeps(c).Output(), report)
+	return nil
+}
+
+// readWorkflowIDs reads one workflow ID per line, ignoring empty lines
+func readWorkflowIDs(fileName string) ([]string, error) {
+	// This code is executed from the CLI. All user input is from a CLI user.
+	// #nosec
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var workflowIDs []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if id := strings.TrimSpace(scanner.Text()); id != "" {
+			workflowIDs = append(workflowIDs, id)
+		}
+	}
+	return workflowIDs, scanner.Err()
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/mock/gomock"
+	"go.uber.org/yarpc"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/reconciliation"
+	"github.com/uber/cadence/common/types"
+)
+
+func (s *cliAppSuite) TestAdminStartTargetedScan() {
+	inputFile := filepath.Join(s.T().TempDir(), "workflow_ids")
+	s.NoError(os.WriteFile(inputFile, []byte("wid-2\n\nwid-3\n"), 0600))
+
+	s.serverFrontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *types.StartWorkflowExecutionRequest, _ ...yarpc.CallOption) (*types.StartWorkflowExecutionResponse, error) {
+			s.Equal(constants.SystemLocalDomainName, request.Domain)
+			s.Equal(reconciliation.TargetedScanWorkflowType, request.WorkflowType.Name)
+			s.Equal(reconciliation.TargetedScanWorkflowTaskList, request.TaskList.Name)
+			var params reconciliation.TargetedScanParams
+			s.NoError(json.Unmarshal(request.Input, &params))
+			s.Equal(reconciliation.TargetedScanParams{
+				Domain:        domainName,
+				WorkflowIDs:   []string{"wid-1", "wid-2", "wid-3"},
+				Collections:   []string{"CollectionChildParent", "CollectionTimerTask"},
+				Fix:           true,
+				MaxExecutions: 10,
+			}, params)
+			return &types.StartWorkflowExecutionResponse{RunID: "rid"}, nil
+		})
+	s.NoError(s.app.Run([]string{"", "--do", domainName, "admin", "scanner", "start",
+		"--invariant_collection", "CollectionChildParent", "--invariant_collection", "CollectionTimerTask",
+		"-w", "wid-1", "--if", inputFile, "--fix", "--max_executions", "10"}))
+}
+
+func (s *cliAppSuite) TestAdminStartTargetedScan_Failed() {
+	// query and workflow IDs are mutually exclusive
+	s.Error(s.app.Run([]string{"", "--do", domainName, "admin", "scanner", "start",
+		"--invariant_collection", "CollectionHistory", "-w", "wid", "-q", "WorkflowType = 'x'"}))
+
+	s.serverFrontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, &types.BadRequestError{Message: "faked error"})
+	s.Error(s.app.Run([]string{"", "--do", domainName, "admin", "scanner", "start", "--invariant_collection", "CollectionHistory"}))
+}
+
+func (s *cliAppSuite) TestAdminTargetedScanReport() {
+	report := reconciliation.TargetedScanReport{Completed: true, Scanned: 3, Healthy: 3}
+	reportInBytes, err := json.Marshal(report)
+	s.NoError(err)
+	s.serverFrontendClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *types.QueryWorkflowRequest, _ ...yarpc.CallOption) (*types.QueryWorkflowResponse, error) {
+			s.Equal(constants.SystemLocalDomainName, request.Domain)
+			s.Equal("targeted-scan-1", request.Execution.WorkflowID)
+			s.Equal(reconciliation.TargetedScanReportQuery, request.Query.QueryType)
+			return &types.QueryWorkflowResponse{QueryResult: reportInBytes}, nil
+		})
+	s.NoError(s.app.Run([]string{"", "admin", "scanner", "report", "-w", "targeted-scan-1"}))
+
+	s.serverFrontendClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).Return(nil, &types.EntityNotExistsError{})
+	s.Error(s.app.Run([]string{"", "admin", "scanner", "report", "-w", "targeted-scan-1"}))
+}
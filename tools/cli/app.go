@@ -221,6 +221,12 @@ func NewCliApp(cf ClientFactory, opts ...CLIAppOptions) *cli.App {
 					Usage:       "Run admin operations on database",
 					Subcommands: newDBCommands(),
 				},
+				{
+					Name:        "scanner",
+					Aliases:     []string{"scan"},
+					Usage:       "Run on demand scans of workflow executions",
+					Subcommands: newAdminScannerCommands(),
+				},
 				{
 					Name:        "queue",
 					Aliases:     []string{"q"},
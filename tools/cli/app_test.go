@@ -22,6 +22,8 @@ package cli
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -36,12 +38,15 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/mock/gomock"
+	"go.uber.org/yarpc"
 
 	"github.com/uber/cadence/client/admin"
 	"github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/constants"
 	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics"
 	"github.com/uber/cadence/tools/cli/clitest"
 )
 
@@ -412,6 +417,36 @@ func (s *cliAppSuite) TestDiagnoseWorkflow_Failed() {
 	s.Error(s.app.Run([]string{"", "--do", domainName, "workflow", "diagnose", "-w", "wid", "-r", "rid"}))
 }
 
+func (s *cliAppSuite) TestDiagnoseWorkflowReport() {
+	report := diagnostics.DiagnosticsReport{Domain: domainName, WorkflowID: "wid", RunID: "rid", Completed: true}
+	reportInBytes, err := json.Marshal(report)
+	s.NoError(err)
+	s.serverFrontendClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *types.QueryWorkflowRequest, _ ...yarpc.CallOption) (*types.QueryWorkflowResponse, error) {
+			s.Equal(constants.SystemLocalDomainName, request.Domain)
+			s.Equal(diagnostics.DiagnosticsWorkflowID(domainName, "rid"), request.Execution.WorkflowID)
+			s.Equal(diagnostics.QueryDiagnosticsReportJSON, request.Query.QueryType)
+			return &types.QueryWorkflowResponse{QueryResult: reportInBytes}, nil
+		})
+	s.NoError(s.app.Run([]string{"", "--do", domainName, "workflow", "diagnose-report", "-w", "wid", "-r", "rid"}))
+
+	markdownInBytes, err := json.Marshal("# Diagnostics report\n")
+	s.NoError(err)
+	s.serverFrontendClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *types.QueryWorkflowRequest, _ ...yarpc.CallOption) (*types.QueryWorkflowResponse, error) {
+			s.Equal(diagnostics.QueryDiagnosticsReportMarkdown, request.Query.QueryType)
+			return &types.QueryWorkflowResponse{QueryResult: markdownInBytes}, nil
+		})
+	s.NoError(s.app.Run([]string{"", "--do", domainName, "workflow", "diagnose-report", "-w", "wid", "-r", "rid", "--format", "markdown"}))
+}
+
+func (s *cliAppSuite) TestDiagnoseWorkflowReport_Failed() {
+	s.Error(s.app.Run([]string{"", "--do", domainName, "workflow", "diagnose-report", "-w", "wid", "-r", "rid", "--format", "table"}))
+
+	s.serverFrontendClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).Return(nil, &types.EntityNotExistsError{})
+	s.Error(s.app.Run([]string{"", "--do", domainName, "workflow", "diagnose-report", "-w", "wid", "-r", "rid"}))
+}
+
 func (s *cliAppSuite) TestStartWorkflow() {
 	resp := &types.StartWorkflowExecutionResponse{RunID: uuid.New()}
 	s.serverFrontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).Return(resp, nil).Times(2)
@@ -878,7 +913,8 @@ func (s *cliAppSuite) TestGetWorkflowIdReusePolicy_Failed_Negative() {
 
 func (s *cliAppSuite) TestGetSearchAttributes() {
 	resp := &types.GetSearchAttributesResponse{}
-	s.serverFrontendClient.EXPECT().GetSearchAttributes(gomock.Any()).Return(resp, nil).Times(2)
+	s.serverFrontendClient.EXPECT().GetSearchAttributes(gomock.Any()).Return(resp, nil).Times(1)
+	s.serverFrontendClient.EXPECT().GetSearchAttributes(gomock.Any(), yarpc.WithHeader(common.DomainHeaderName, domainName)).Return(resp, nil).Times(1)
 	err := s.app.Run([]string{"", "cluster", "get-search-attr"})
 	s.Nil(err)
 	err = s.app.Run([]string{"", "--do", domainName, "cluster", "get-search-attr"})
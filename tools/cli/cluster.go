@@ -26,7 +26,7 @@ func newClusterCommands() []*cli.Command {
 	return []*cli.Command{
 		{
 			Name:   "get-search-attr",
-			Usage:  "get list of legal search attributes that can be used in list workflow query.",
+			Usage:  "get list of legal search attributes that can be used in list workflow query. With the global --domain flag, the search attributes registered for the domain are included",
 			Action: GetSearchAttributes,
 		},
 	}
@@ -21,11 +21,16 @@
 package cli
 
 import (
+	"context"
 	"os"
 	"sort"
 
 	"github.com/urfave/cli/v2"
+	"go.uber.org/yarpc"
 
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/tools/common/commoncli"
 )
 
@@ -47,7 +52,7 @@ func (s SearchAttributesTable) Less(i, j int) bool {
 	return s[i].Key < s[j].Key
 }
 
-// GetSearchAttributes get valid search attributes
+// GetSearchAttributes get valid search attributes, including the ones registered for the domain if it is set
 func GetSearchAttributes(c *cli.Context) error {
 	wfClient, err := getWorkflowClient(c)
 	if err != nil {
@@ -59,7 +64,7 @@ func GetSearchAttributes(c *cli.Context) error {
 		return commoncli.Problem("Error in creating context:", err)
 	}
 
-	resp, err := wfClient.GetSearchAttributes(ctx)
+	resp, err := getSearchAttributes(ctx, c, wfClient)
 	if err != nil {
 		return commoncli.Problem("Failed to get search attributes.", err)
 	}
@@ -71,3 +76,12 @@ func GetSearchAttributes(c *cli.Context) error {
 	sort.Sort(table)
 	return RenderTable(os.Stdout, table, RenderOptions{Color: true, Border: true})
 }
+
+// getSearchAttributes returns the valid search attributes, including the ones registered for the domain if it is set
+func getSearchAttributes(ctx context.Context, c *cli.Context, wfClient frontend.Client) (*types.GetSearchAttributesResponse, error) {
+	var opts []yarpc.CallOption
+	if domain := c.String(FlagDomain); domain != "" {
+		opts = append(opts, yarpc.WithHeader(common.DomainHeaderName, domain))
+	}
+	return wfClient.GetSearchAttributes(ctx, opts...)
+}
@@ -26,12 +26,15 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/uber-go/tally"
 	"github.com/urfave/cli/v2"
 
 	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/constants"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/messaging/kafka"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/client"
@@ -158,8 +161,11 @@ type ManagerFactory interface {
 	initializeHistoryManager(c *cli.Context) (persistence.HistoryManager, error)
 	initializeShardManager(c *cli.Context) (persistence.ShardManager, error)
 	initializeDomainManager(c *cli.Context) (persistence.DomainManager, error)
+	initializeDomainAuditManager(c *cli.Context) (persistence.DomainAuditManager, error)
+	initializeConfigStoreManager(c *cli.Context) (persistence.ConfigStoreManager, error)
 	initPersistenceFactory(c *cli.Context) (client.Factory, error)
 	initializeInvariantManager(ivs []invariant.Invariant) (invariant.Manager, error)
+	initializeVisibilityProducer(c *cli.Context, appName string) (messaging.Producer, error)
 }
 
 type defaultManagerFactory struct {
@@ -214,6 +220,30 @@ func (f *defaultManagerFactory) initializeDomainManager(c *cli.Context) (persist
 	return domainManager, nil
 }
 
+func (f *defaultManagerFactory) initializeDomainAuditManager(c *cli.Context) (persistence.DomainAuditManager, error) {
+	factory, err := f.getPersistenceFactory(c)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get persistence factory: %w", err)
+	}
+	domainAuditManager, err := factory.NewDomainAuditManager()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to initialize domain audit manager: %w", err)
+	}
+	return domainAuditManager, nil
+}
+
+func (f *defaultManagerFactory) initializeConfigStoreManager(c *cli.Context) (persistence.ConfigStoreManager, error) {
+	factory, err := f.getPersistenceFactory(c)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get persistence factory: %w", err)
+	}
+	configStoreManager, err := factory.NewConfigStoreManager()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to initialize config store manager: %w", err)
+	}
+	return configStoreManager, nil
+}
+
 func (f *defaultManagerFactory) getPersistenceFactory(c *cli.Context) (client.Factory, error) {
 	var err error
 	if f.persistenceFactory == nil {
@@ -271,6 +301,20 @@ func (f *defaultManagerFactory) initializeInvariantManager(ivs []invariant.Invar
 	return invariant.NewInvariantManager(ivs), nil
 }
 
+// initializeVisibilityProducer creates a producer writing to the visibility topic of an application in the server's
+// Kafka config, i.e. the topic consumed by the worker's visibility indexers or by Pinot
+func (f *defaultManagerFactory) initializeVisibilityProducer(c *cli.Context, appName string) (messaging.Producer, error) {
+	cfg, err := getDeps(c).ServerConfig(c)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load server config: %w", err)
+	}
+	if _, ok := cfg.Kafka.Applications[appName]; !ok {
+		return nil, fmt.Errorf("Kafka config has no %q application", appName)
+	}
+	client := kafka.NewKafkaClient(&cfg.Kafka, metrics.NewNoopMetricsClient(), log.NewNoop(), tally.NoopScope, true)
+	return client.NewProducer(appName)
+}
+
 func overrideDataStore(c *cli.Context, ds config.DataStore) (config.DataStore, error) {
 	if c.IsSet(FlagDBType) {
 		// overriding DBType will wipe out all settings, everything will be set from flags only
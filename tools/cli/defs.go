@@ -72,6 +72,8 @@ const (
 	searchAttrInputSeparator = "|"
 
 	defaultGracefulFailoverTimeoutInSeconds = 60
+
+	batchReportTimeout = defaultContextTimeoutForLongPoll
 )
 
 var envKeysForUserName = []string{
@@ -181,5 +181,20 @@ func newDomainCommands() []*cli.Command {
 				})
 			},
 		},
+		{
+			Name:    "audit",
+			Aliases: []string{"au"},
+			Usage:   "Show who changed a domain and when, with a field level diff of each change. Reads the domain audit log from the database, deleted or renamed domains are looked up with --domain_id",
+			Flags:   domainAuditFlags,
+			Action: func(c *cli.Context) error {
+				err := checkNoAdditionalArgsPassed(c)
+				if err != nil {
+					return err
+				}
+				return withDomainClient(c, true, func(dc *domainCLIImpl) error {
+					return dc.DomainAudit(c)
+				})
+			},
+		},
 	}
 }
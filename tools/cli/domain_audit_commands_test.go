@@ -0,0 +1,166 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/domain"
+	"github.com/uber/cadence/common/domain/audit"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/tools/cli/clitest"
+)
+
+func TestDomainAudit(t *testing.T) {
+	createdTime := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	entry := audit.NewEntry(&persistence.DomainAuditLog{
+		EventID:       "event-id",
+		DomainID:      testDomainID,
+		OperationType: persistence.DomainAuditOperationTypeUpdate,
+		CreatedTime:   createdTime,
+		Identity:      "alice",
+		StateBefore: &persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{Name: testDomain},
+			Config: &persistence.DomainConfig{Retention: 3},
+		},
+		StateAfter: &persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{Name: testDomain},
+			Config: &persistence.DomainConfig{Retention: 7},
+		},
+	})
+
+	tests := []struct {
+		name           string
+		testSetup      func(td *cliTestData, handler *domain.MockHandler) *cli.Context
+		errContains    string
+		outputContains []string
+	}{
+		{
+			name: "missing domain",
+			testSetup: func(td *cliTestData, handler *domain.MockHandler) *cli.Context {
+				return clitest.NewCLIContext(t, td.app)
+			},
+			errContains: "Required flag not found",
+		},
+		{
+			name: "invalid operation type",
+			testSetup: func(td *cliTestData, handler *domain.MockHandler) *cli.Context {
+				return clitest.NewCLIContext(t, td.app,
+					clitest.StringArgument(FlagDomain, testDomain),
+					clitest.StringSliceArgument(FlagOperationType, "rename"),
+				)
+			},
+			errContains: "Invalid --operation_type",
+		},
+		{
+			name: "filters by operation type and identity and renders the diff",
+			testSetup: func(td *cliTestData, handler *domain.MockHandler) *cli.Context {
+				handler.EXPECT().ListDomainAuditLogs(gomock.Any(), testDomain, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, filter audit.Filter) ([]*audit.Entry, error) {
+						assert.Equal(t, []persistence.DomainAuditOperationType{persistence.DomainAuditOperationTypeUpdate}, filter.OperationTypes)
+						assert.Equal(t, "alice", filter.Identity)
+						assert.Equal(t, 20, filter.Limit)
+						assert.True(t, createdTime.Add(-time.Hour).Equal(filter.StartTime))
+						return []*audit.Entry{entry}, nil
+					})
+
+				return clitest.NewCLIContext(t, td.app,
+					clitest.StringArgument(FlagDomain, testDomain),
+					clitest.StringSliceArgument(FlagOperationType, "update"),
+					clitest.StringArgument(FlagIdentity, "alice"),
+					clitest.IntArgument(FlagLimit, 20),
+					clitest.StringArgument(FlagEarliestTime, createdTime.Add(-time.Hour).Format(time.RFC3339)),
+				)
+			},
+			outputContains: []string{"2025-03-04T05:06:07Z", "Update", "alice", "config.retentionDays", "3", "7"},
+		},
+		{
+			name: "deleted domain by its ID",
+			testSetup: func(td *cliTestData, handler *domain.MockHandler) *cli.Context {
+				handler.EXPECT().ListDomainAuditLogs(gomock.Any(), "", gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, filter audit.Filter) ([]*audit.Entry, error) {
+						assert.Equal(t, testDomainID, filter.DomainID)
+						return nil, nil
+					})
+
+				return clitest.NewCLIContext(t, td.app, clitest.StringArgument(FlagDomainID, testDomainID))
+			},
+			outputContains: []string{"No audit log entries found for domain: " + testDomainID},
+		},
+		{
+			name: "json output",
+			testSetup: func(td *cliTestData, handler *domain.MockHandler) *cli.Context {
+				handler.EXPECT().ListDomainAuditLogs(gomock.Any(), testDomain, gomock.Any()).Return([]*audit.Entry{entry}, nil)
+
+				return clitest.NewCLIContext(t, td.app,
+					clitest.StringArgument(FlagDomain, testDomain),
+					clitest.StringArgument(FlagFormat, formatJSON),
+				)
+			},
+			outputContains: []string{`"eventID": "event-id"`, `"field": "config.retentionDays"`, `"before": "3"`, `"after": "7"`},
+		},
+		{
+			name: "no entries",
+			testSetup: func(td *cliTestData, handler *domain.MockHandler) *cli.Context {
+				handler.EXPECT().ListDomainAuditLogs(gomock.Any(), testDomain, gomock.Any()).Return(nil, nil)
+
+				return clitest.NewCLIContext(t, td.app, clitest.StringArgument(FlagDomain, testDomain))
+			},
+			outputContains: []string{"No audit log entries found for domain: " + testDomain},
+		},
+		{
+			name: "query fails",
+			testSetup: func(td *cliTestData, handler *domain.MockHandler) *cli.Context {
+				handler.EXPECT().ListDomainAuditLogs(gomock.Any(), testDomain, gomock.Any()).
+					Return(nil, &types.EntityNotExistsError{Message: "domain not found"})
+
+				return clitest.NewCLIContext(t, td.app, clitest.StringArgument(FlagDomain, testDomain))
+			},
+			errContains: "Failed to query domain audit log.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			handler := domain.NewMockHandler(td.ctrl)
+			cliCtx := tt.testSetup(td, handler)
+
+			dc := &domainCLIImpl{domainHandler: handler}
+			err := dc.DomainAudit(cliCtx)
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errContains)
+			}
+			for _, s := range tt.outputContains {
+				assert.Contains(t, td.consoleOutput(), s)
+			}
+		})
+	}
+}
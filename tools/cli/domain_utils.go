@@ -319,6 +319,40 @@ var (
 		getFormatFlag(),
 	}
 
+	domainAuditFlags = append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  FlagDomainID,
+			Usage: "Domain UUID, used instead of the domain name to show changes of deleted or renamed domains",
+		},
+		&cli.StringFlag{
+			Name:    FlagEarliestTime,
+			Aliases: []string{"et"},
+			Usage: "Only show changes made after this time, supported formats are '2006-01-02T15:04:05+07:00', raw UnixNano and " +
+				"time range (N<duration>), where 0 < N < 1000000 and duration (full-notation/short-notation) can be second/s, " +
+				"minute/m, hour/h, day/d, week/w, month/M or year/y. For example, '15minute' or '15m' implies last 15 minutes.",
+		},
+		&cli.StringFlag{
+			Name:    FlagLatestTime,
+			Aliases: []string{"lt"},
+			Usage:   "Only show changes made before this time, same formats as --" + FlagEarliestTime + ". Defaults to now",
+		},
+		&cli.StringSliceFlag{
+			Name:    FlagOperationType,
+			Aliases: []string{"op"},
+			Usage:   "Only show these operations, any of create, update, failover, deprecate or delete. Defaults to all",
+		},
+		&cli.StringFlag{
+			Name:  FlagIdentity,
+			Usage: "Only show changes made by this identity",
+		},
+		&cli.IntFlag{
+			Name:  FlagLimit,
+			Value: 20,
+			Usage: "Maximum number of audit log entries to show, 0 shows all of them",
+		},
+		getFormatFlag(),
+	}, getDBFlags()...)
+
 	adminDomainCommonFlags = getDBFlags()
 
 	adminRegisterDomainFlags = append(
@@ -375,6 +409,10 @@ func initializeAdminDomainHandler(c *cli.Context) (domain.Handler, error) {
 	if err != nil {
 		return nil, fmt.Errorf("Error in init admin domain handler: %w", err)
 	}
+	auditMgr, err := getDeps(c).initializeDomainAuditManager(c)
+	if err != nil {
+		return nil, fmt.Errorf("Error in init admin domain handler: %w", err)
+	}
 	archivalprovider, err := initializeArchivalProvider(configuration, clusterMetadata, metricsClient, logger)
 	if err != nil {
 		return nil, fmt.Errorf("Error in init admin domain handler: %w", err)
@@ -382,6 +420,7 @@ func initializeAdminDomainHandler(c *cli.Context) (domain.Handler, error) {
 	domainhandler := initializeDomainHandler(
 		logger,
 		metadataMgr,
+		auditMgr,
 		clusterMetadata,
 		initializeArchivalMetadata(configuration, dynamicConfig),
 		archivalprovider,
@@ -409,6 +448,7 @@ func loadConfig(
 func initializeDomainHandler(
 	logger log.Logger,
 	domainManager persistence.DomainManager,
+	domainAuditManager persistence.DomainAuditManager,
 	clusterMetadata cluster.Metadata,
 	archivalMetadata archiver.ArchivalMetadata,
 	archiverProvider provider.ArchiverProvider,
@@ -418,12 +458,14 @@ func initializeDomainHandler(
 		MinRetentionDays:  dynamicproperties.GetIntPropertyFn(dynamicproperties.MinRetentionDays.DefaultInt()),
 		MaxBadBinaryCount: dynamicproperties.GetIntPropertyFilteredByDomain(dynamicproperties.FrontendMaxBadBinaries.DefaultInt()),
 		FailoverCoolDown:  dynamicproperties.GetDurationPropertyFnFilteredByDomain(dynamicproperties.FrontendFailoverCoolDown.DefaultDuration()),
+		// the CLI only reads the audit log, changes made through it are not audited
+		EnableDomainAuditLogging: dynamicproperties.GetBoolPropertyFn(false),
 	}
 	return domain.NewHandler(
 		domainConfig,
 		logger,
 		domainManager,
-		nil, // domainAuditManager not needed for CLI tools
+		domainAuditManager,
 		clusterMetadata,
 		initializeDomainReplicator(logger),
 		archivalMetadata,
@@ -87,6 +87,8 @@ const (
 	FlagSkipCurrentCompleted           = "skip_current_completed"
 	FlagSkipBaseIsNotCurrent           = "skip_base_is_not_current"
 	FlagDryRun                         = "dry_run"
+	FlagPinot                          = "pinot"
+	FlagCompleteTypeChange             = "complete_type_change"
 	FlagNonDeterministicOnly           = "only_non_deterministic"
 	FlagInputTopic                     = "input_topic"
 	FlagHostFile                       = "host_file"
@@ -159,6 +161,7 @@ const (
 	FlagSearchAttributesKey            = "search_attr_key"
 	FlagSearchAttributesVal            = "search_attr_value"
 	FlagSearchAttributesType           = "search_attr_type"
+	FlagSearchAttributesNewKey         = "search_attr_new_key"
 	FlagAddBadBinary                   = "add_bad_binary"
 	FlagRemoveBadBinary                = "remove_bad_binary"
 	FlagResetType                      = "reset_type"
@@ -229,11 +232,15 @@ const (
 	FlagDynamicConfigName              = "name"
 	FlagDynamicConfigFilter            = "filter"
 	FlagDynamicConfigValue             = "value"
+	FlagDynamicConfigTTL               = "ttl"
+	FlagDynamicConfigOperational       = "operational"
 	FlagTransport                      = "transport"
 	FlagFormat                         = "format"
 	FlagJSON                           = "json"
 	FlagIsolationGroupSetDrains        = "set-drains"
 	FlagIsolationGroupsRemoveAllDrains = "remove-all-drains"
+	FlagIsolationGroup                 = "isolation-group"
+	FlagDrainScheduleID                = "drain-schedule-id"
 	FlagSearchAttribute                = "search_attr"
 	FlagNumReadPartitions              = "num_read_partitions"
 	FlagNumWritePartitions             = "num_write_partitions"
@@ -242,6 +249,15 @@ const (
 	FlagClusterAttributeName           = "cluster_attribute_name"
 	FlagClusterAttributesJSON          = "cluster_attributes_json"
 	FlagBatchV2                        = "v2"
+	FlagFailuresOnly                   = "failures_only"
+	FlagFix                            = "fix"
+	FlagMaxExecutions                  = "max_executions"
+	FlagOperationType                  = "operation_type"
+	FlagWorkflowID2                    = "workflow_id2"
+	FlagRunID2                         = "run_id2"
+	FlagSampleSize                     = "sample_size"
+	FlagScanLimit                      = "scan_limit"
+	FlagOutputDirectory                = "output_directory"
 
 	FlagClustersUsage = "Clusters (example: --clusters clusterA,clusterB or --cl clusterA --cl clusterB)"
 )
@@ -800,6 +816,55 @@ func getFlagsForDescribeID() []cli.Flag {
 	}
 }
 
+func getFlagsForDiagnoseReport() []cli.Flag {
+	return append(flagsForExecution, &cli.StringFlag{
+		Name:  FlagFormat,
+		Usage: "Format of the report [json|markdown]",
+		Value: formatJSON,
+	})
+}
+
+func getFlagsForDiff() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    FlagWorkflowID,
+			Aliases: []string{"w", "wid"},
+			Usage:   "WorkflowID of the left side of the diff",
+		},
+		&cli.StringFlag{
+			Name:    FlagRunID,
+			Aliases: []string{"r", "rid"},
+			Usage:   "RunID of the left side of the diff, defaults to the current run",
+		},
+		&cli.StringFlag{
+			Name:    FlagWorkflowID2,
+			Aliases: []string{"w2", "wid2"},
+			Usage:   "WorkflowID of the right side of the diff, defaults to the left WorkflowID",
+		},
+		&cli.StringFlag{
+			Name:    FlagRunID2,
+			Aliases: []string{"r2", "rid2"},
+			Usage:   "RunID of the right side of the diff, defaults to the current run. Use the base and the new run of a reset to compare both branches",
+		},
+		&cli.BoolFlag{
+			Name:    FlagAll,
+			Aliases: []string{"a"},
+			Usage:   "Show identical events as well, by default only diverging events are shown",
+		},
+		&cli.IntFlag{
+			Name:    FlagMaxFieldLength,
+			Aliases: []string{"maxl"},
+			Usage:   "Maximum length for each attribute field in table output",
+			Value:   defaultMaxFieldLength,
+		},
+		&cli.StringFlag{
+			Name:  FlagFormat,
+			Usage: "Output format [table|json]",
+			Value: formatTable,
+		},
+	}
+}
+
 func getFlagsForObserve() []cli.Flag {
 	return append(flagsForExecution, getFlagsForObserveID()...)
 }
@@ -22,12 +22,24 @@ package cli
 
 import (
 	"bytes"
+	"cmp"
+	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/pborman/uuid"
 	"github.com/urfave/cli/v2"
 
+	"github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common/dynamicconfig/configstore"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/isolationgroup/isolationgroupapi"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/tools/common/commoncli"
 )
@@ -266,3 +278,388 @@ func convertIsolationGroupStateToString(state types.IsolationGroupState) string
 		return fmt.Sprintf("Unknown state: %d", state)
 	}
 }
+
+// IsolationGroupDrainStatusRow is the part of a task list still pinned to a drained isolation group
+type IsolationGroupDrainStatusRow struct {
+	IsolationGroup   string `header:"Isolation Group" json:"isolationGroup"`
+	TaskList         string `header:"Task List" json:"taskList"`
+	Type             string `header:"Type" json:"type"`
+	PinnedPartitions int    `header:"Pinned Partitions" json:"pinnedPartitions"`
+	PinnedTasks      int64  `header:"Pinned Tasks" json:"pinnedTasks"`
+	Pollers          int64  `header:"Pollers" json:"pollers"`
+}
+
+// AdminIsolationGroupDrainStatus shows how much work of a domain is still pinned to drained isolation groups.
+// Pinned tasks are the backlog of task list partitions still assigned to the group, which drops to zero
+// once matching reroutes the partitions to healthy groups.
+func AdminIsolationGroupDrainStatus(c *cli.Context) error {
+	domain, err := getRequiredOption(c, FlagDomain)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	adminClient, err := getDeps(c).ServerAdminClient(c)
+	if err != nil {
+		return err
+	}
+	frontendClient, err := getDeps(c).ServerFrontendClient(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error creating context:", err)
+	}
+
+	groups := c.StringSlice(FlagIsolationGroup)
+	if len(groups) == 0 {
+		groups, err = getDrainedIsolationGroups(ctx, adminClient, domain)
+		if err != nil {
+			return commoncli.Problem("failed to get isolation-groups:", err)
+		}
+	}
+	if len(groups) == 0 {
+		getDeps(c).Output().Write([]byte("-- No drained isolation groups found --\n"))
+		return nil
+	}
+
+	taskLists, err := frontendClient.GetTaskListsByDomain(ctx, &types.GetTaskListsByDomainRequest{Domain: domain})
+	if err != nil {
+		return commoncli.Problem("Operation GetTaskListByDomain failed.", err)
+	}
+	var rows []IsolationGroupDrainStatusRow
+	for tlType, names := range map[types.TaskListType][]string{
+		types.TaskListTypeDecision: slices.Collect(maps.Keys(taskLists.GetDecisionTaskListMap())),
+		types.TaskListTypeActivity: slices.Collect(maps.Keys(taskLists.GetActivityTaskListMap())),
+	} {
+		for _, name := range names {
+			taskListRows, err := getIsolationGroupDrainStatus(ctx, frontendClient, domain, name, tlType, groups)
+			if err != nil {
+				return commoncli.Problem("Operation DescribeTaskList failed for task list: "+name, err)
+			}
+			rows = append(rows, taskListRows...)
+		}
+	}
+	slices.SortFunc(rows, func(a, b IsolationGroupDrainStatusRow) int {
+		if a.PinnedTasks != b.PinnedTasks {
+			return cmp.Compare(b.PinnedTasks, a.PinnedTasks)
+		}
+		return cmp.Or(
+			strings.Compare(a.IsolationGroup, b.IsolationGroup),
+			strings.Compare(a.TaskList, b.TaskList),
+			strings.Compare(a.Type, b.Type),
+		)
+	})
+	if len(rows) == 0 && c.String(FlagFormat) != formatJSON {
+		getDeps(c).Output().Write([]byte("-- No tasks pinned to drained isolation groups --\n"))
+		return nil
+	}
+	return Render(c, rows, RenderOptions{Color: true, DefaultTemplate: templateTable})
+}
+
+func getDrainedIsolationGroups(ctx context.Context, adminClient admin.Client, domain string) ([]string, error) {
+	global, err := adminClient.GetGlobalIsolationGroups(ctx, &types.GetGlobalIsolationGroupsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	domainGroups, err := adminClient.GetDomainIsolationGroups(ctx, &types.GetDomainIsolationGroupsRequest{Domain: domain})
+	if err != nil {
+		return nil, err
+	}
+	drained := make(map[string]bool)
+	for _, igs := range []types.IsolationGroupConfiguration{global.IsolationGroups, domainGroups.IsolationGroups} {
+		for name, group := range igs {
+			if group.State == types.IsolationGroupStateDrained {
+				drained[name] = true
+			}
+		}
+	}
+	groups := slices.Collect(maps.Keys(drained))
+	slices.Sort(groups)
+	return groups, nil
+}
+
+func getIsolationGroupDrainStatus(
+	ctx context.Context,
+	frontendClient frontend.Client,
+	domain string,
+	taskList string,
+	tlType types.TaskListType,
+	groups []string,
+) ([]IsolationGroupDrainStatusRow, error) {
+	describe := func(name string) (*types.DescribeTaskListResponse, error) {
+		return frontendClient.DescribeTaskList(ctx, &types.DescribeTaskListRequest{
+			Domain:                domain,
+			TaskList:              &types.TaskList{Name: name, Kind: types.TaskListKindNormal.Ptr()},
+			TaskListType:          tlType.Ptr(),
+			IncludeTaskListStatus: true,
+		})
+	}
+	root, err := describe(taskList)
+	if err != nil {
+		return nil, err
+	}
+
+	var readPartitions map[int]*types.TaskListPartition
+	if root.PartitionConfig != nil {
+		readPartitions = root.PartitionConfig.ReadPartitions
+	}
+	var groupMetrics map[string]*types.IsolationGroupMetrics
+	if root.TaskListStatus != nil {
+		groupMetrics = root.TaskListStatus.IsolationGroupMetrics
+	}
+
+	var rows []IsolationGroupDrainStatusRow
+	backlogByPartition := make(map[int]int64)
+	for _, group := range groups {
+		row := IsolationGroupDrainStatusRow{
+			IsolationGroup: group,
+			TaskList:       taskList,
+			Type:           tlType.String(),
+		}
+		if metrics, ok := groupMetrics[group]; ok {
+			row.Pollers = metrics.PollerCount
+		}
+		for id, partition := range readPartitions {
+			if !slices.Contains(partition.IsolationGroups, group) {
+				continue
+			}
+			backlog, ok := backlogByPartition[id]
+			if !ok {
+				partitionResp := root
+				if id != 0 {
+					if partitionResp, err = describe(getPartitionTaskListName(taskList, id)); err != nil {
+						return nil, err
+					}
+				}
+				backlog = partitionResp.GetTaskListStatus().GetBacklogCountHint()
+				backlogByPartition[id] = backlog
+			}
+			row.PinnedPartitions++
+			row.PinnedTasks += backlog
+		}
+		if row.PinnedPartitions > 0 || row.Pollers > 0 {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// drainScheduleRetention is how long finished drain schedules are kept as an audit trail
+const drainScheduleRetention = 90 * 24 * time.Hour
+
+// IsolationGroupDrainScheduleRow is a planned isolation-group drain window
+type IsolationGroupDrainScheduleRow struct {
+	ID             string    `header:"ID" json:"id"`
+	IsolationGroup string    `header:"Isolation Group" json:"isolationGroup"`
+	Domain         string    `header:"Domain" json:"domain"`
+	Status         string    `header:"Status" json:"status"`
+	StartTime      time.Time `header:"Start Time" json:"startTime"`
+	EndTime        time.Time `header:"End Time" json:"endTime"`
+	Reason         string    `header:"Reason" json:"reason"`
+	CreatedBy      string    `header:"Created By" json:"createdBy"`
+	CancelledBy    string    `header:"Cancelled By" json:"cancelledBy,omitempty"`
+}
+
+// AdminListIsolationGroupDrainSchedules lists the upcoming and active isolation-group drain windows,
+// and with --all also the completed and cancelled ones
+func AdminListIsolationGroupDrainSchedules(c *cli.Context) error {
+	adminClient, err := getDeps(c).ServerAdminClient(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error creating context:", err)
+	}
+	schedules, _, err := getIsolationGroupDrainSchedules(ctx, adminClient)
+	if err != nil {
+		return commoncli.Problem("failed to get isolation-group drain schedules:", err)
+	}
+
+	domain := c.String(FlagDomain)
+	now := time.Now()
+	var rows []IsolationGroupDrainScheduleRow
+	for _, s := range schedules {
+		status := s.Status(now)
+		if !c.Bool(FlagAll) && status != isolationgroupapi.DrainScheduleStatusUpcoming && status != isolationgroupapi.DrainScheduleStatusActive {
+			continue
+		}
+		if domain != "" && s.Domain != "" && s.Domain != domain {
+			continue
+		}
+		rows = append(rows, IsolationGroupDrainScheduleRow{
+			ID:             s.ID,
+			IsolationGroup: s.IsolationGroup,
+			Domain:         cmp.Or(s.Domain, "*"),
+			Status:         string(status),
+			StartTime:      s.StartTime,
+			EndTime:        s.EndTime,
+			Reason:         s.Reason,
+			CreatedBy:      s.CreatedBy,
+			CancelledBy:    s.CancelledBy,
+		})
+	}
+	if len(rows) == 0 && c.String(FlagFormat) != formatJSON {
+		fmt.Fprintln(getDeps(c).Output(), "-- No drain schedules found --")
+		return nil
+	}
+	slices.SortFunc(rows, func(a, b IsolationGroupDrainScheduleRow) int {
+		return cmp.Or(a.StartTime.Compare(b.StartTime), cmp.Compare(a.ID, b.ID))
+	})
+	return Render(c, rows, RenderOptions{Color: true, DefaultTemplate: templateTable})
+}
+
+// AdminAddIsolationGroupDrainSchedule schedules a drain of an isolation-group for all domains, or for the
+// domain given with --domain. The drain is applied at the start time and reverted at the end time.
+func AdminAddIsolationGroupDrainSchedule(c *cli.Context) error {
+	isolationGroup, err := getRequiredOption(c, FlagIsolationGroup)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	reason, err := getRequiredOption(c, FlagReason)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	startTime, err := parseDrainScheduleTime(c, FlagStartTime)
+	if err != nil {
+		return err
+	}
+	endTime, err := parseDrainScheduleTime(c, FlagEndTime)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if !endTime.After(now) {
+		return commoncli.Problem("invalid args:", fmt.Errorf("end time %v is in the past", endTime))
+	}
+
+	adminClient, err := getDeps(c).ServerAdminClient(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error creating context:", err)
+	}
+	schedules, values, err := getIsolationGroupDrainSchedules(ctx, adminClient)
+	if err != nil {
+		return commoncli.Problem("failed to get isolation-group drain schedules:", err)
+	}
+
+	schedule := isolationgroupapi.DrainSchedule{
+		ID:             uuid.New(),
+		IsolationGroup: isolationGroup,
+		Domain:         c.String(FlagDomain),
+		StartTime:      startTime,
+		EndTime:        endTime,
+		Reason:         reason,
+		CreatedBy:      getCurrentUserFromEnv(),
+		CreatedTime:    now,
+	}
+	if err := schedule.Validate(); err != nil {
+		return commoncli.Problem("invalid args:", err)
+	}
+	if err := updateIsolationGroupDrainSchedules(ctx, adminClient, append(schedules, schedule), values, now); err != nil {
+		return commoncli.Problem("failed to update isolation-group drain schedules:", err)
+	}
+	fmt.Fprintf(getDeps(c).Output(), "Scheduled drain %s of isolation group %s from %v to %v\n", schedule.ID, isolationGroup, startTime, endTime)
+	return nil
+}
+
+// AdminCancelIsolationGroupDrainSchedule cancels a drain window. Cancelling an active window reverts the drain
+// immediately. The schedule is kept, marked as cancelled, as part of the audit trail.
+func AdminCancelIsolationGroupDrainSchedule(c *cli.Context) error {
+	id, err := getRequiredOption(c, FlagDrainScheduleID)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	adminClient, err := getDeps(c).ServerAdminClient(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error creating context:", err)
+	}
+	schedules, values, err := getIsolationGroupDrainSchedules(ctx, adminClient)
+	if err != nil {
+		return commoncli.Problem("failed to get isolation-group drain schedules:", err)
+	}
+
+	now := time.Now()
+	i := slices.IndexFunc(schedules, func(s isolationgroupapi.DrainSchedule) bool { return s.ID == id })
+	if i < 0 {
+		return commoncli.Problem("invalid args:", fmt.Errorf("drain schedule %q not found", id))
+	}
+	switch schedules[i].Status(now) {
+	case isolationgroupapi.DrainScheduleStatusCancelled, isolationgroupapi.DrainScheduleStatusCompleted:
+		return commoncli.Problem("invalid args:", fmt.Errorf("drain schedule %q is already %s", id, schedules[i].Status(now)))
+	}
+	schedules[i].CancelledBy = getCurrentUserFromEnv()
+	schedules[i].CancelledTime = &now
+	if err := updateIsolationGroupDrainSchedules(ctx, adminClient, schedules, values, now); err != nil {
+		return commoncli.Problem("failed to update isolation-group drain schedules:", err)
+	}
+	fmt.Fprintf(getDeps(c).Output(), "Cancelled drain %s of isolation group %s\n", id, schedules[i].IsolationGroup)
+	return nil
+}
+
+func parseDrainScheduleTime(c *cli.Context, flag string) (time.Time, error) {
+	value, err := getRequiredOption(c, flag)
+	if err != nil {
+		return time.Time{}, commoncli.Problem("Required flag not found: ", err)
+	}
+	nanos, err := parseTime(value, 0)
+	if err != nil {
+		return time.Time{}, commoncli.Problem(fmt.Sprintf("invalid %s:", flag), err)
+	}
+	return time.Unix(0, nanos).UTC(), nil
+}
+
+// getIsolationGroupDrainSchedules returns the drain schedules, along with the stored values they were read from
+func getIsolationGroupDrainSchedules(ctx context.Context, adminClient admin.Client) ([]isolationgroupapi.DrainSchedule, []*types.DynamicConfigValue, error) {
+	values, err := listDynamicConfigValues(ctx, adminClient, dynamicproperties.IsolationGroupDrainSchedules)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, found, err := configstore.EffectiveValue(values, nil, time.Now())
+	if err != nil || !found {
+		return nil, values, err
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("value of %s is not a list", dynamicproperties.IsolationGroupDrainSchedules.String())
+	}
+	schedules, err := isolationgroupapi.MapDrainSchedulesResponse(list)
+	if err != nil {
+		return nil, nil, err
+	}
+	return schedules, values, nil
+}
+
+// updateIsolationGroupDrainSchedules stores the schedules, dropping the ones which finished longer ago than the retention.
+// It fails if the schedules were changed since they were read from the expected values.
+func updateIsolationGroupDrainSchedules(
+	ctx context.Context,
+	adminClient admin.Client,
+	schedules []isolationgroupapi.DrainSchedule,
+	expected []*types.DynamicConfigValue,
+	now time.Time,
+) error {
+	schedules = slices.DeleteFunc(schedules, func(s isolationgroupapi.DrainSchedule) bool {
+		finished := s.EndTime
+		if s.CancelledTime != nil && s.CancelledTime.Before(finished) {
+			finished = *s.CancelledTime
+		}
+		return now.Sub(finished) > drainScheduleRetention
+	})
+	values, err := isolationgroupapi.MapUpdateDrainSchedulesRequest(schedules)
+	if err != nil {
+		return err
+	}
+	return compareAndUpdateDynamicConfig(ctx, adminClient, dynamicproperties.IsolationGroupDrainSchedules, values, expected)
+}
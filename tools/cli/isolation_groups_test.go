@@ -23,17 +23,24 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/mock/gomock"
+	"go.uber.org/yarpc"
 
 	"github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/isolationgroup/isolationgroupapi"
 	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/tools/cli/clitest"
 )
 
 func TestValidateIsolationGroupArgs(t *testing.T) {
@@ -544,3 +551,299 @@ func TestAdminUpdateDomainIsolationGroups(t *testing.T) {
 		})
 	}
 }
+
+func TestAdminIsolationGroupDrainStatus(t *testing.T) {
+	describeResponse := func(backlog int64, partitions map[int]*types.TaskListPartition, groupMetrics map[string]*types.IsolationGroupMetrics) *types.DescribeTaskListResponse {
+		return &types.DescribeTaskListResponse{
+			TaskListStatus: &types.TaskListStatus{
+				BacklogCountHint:      backlog,
+				IsolationGroupMetrics: groupMetrics,
+			},
+			PartitionConfig: &types.TaskListPartitionConfig{
+				ReadPartitions:  partitions,
+				WritePartitions: partitions,
+			},
+		}
+	}
+	expectTaskList := func(td *cliTestData) {
+		td.mockFrontendClient.EXPECT().GetTaskListsByDomain(gomock.Any(), &types.GetTaskListsByDomainRequest{Domain: testDomain}).
+			Return(&types.GetTaskListsByDomainResponse{
+				DecisionTaskListMap: map[string]*types.DescribeTaskListResponse{"tl": {}},
+			}, nil)
+		td.mockFrontendClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, request *types.DescribeTaskListRequest, _ ...yarpc.CallOption) (*types.DescribeTaskListResponse, error) {
+				switch request.TaskList.Name {
+				case "tl":
+					return describeResponse(5, map[int]*types.TaskListPartition{
+						0: {IsolationGroups: []string{"zone-1"}},
+						1: {IsolationGroups: []string{"zone-1", "zone-2"}},
+						2: {IsolationGroups: []string{"zone-2"}},
+					}, map[string]*types.IsolationGroupMetrics{"zone-2": {PollerCount: 3}}), nil
+				case getPartitionTaskListName("tl", 1):
+					return describeResponse(7, nil, nil), nil
+				case getPartitionTaskListName("tl", 2):
+					return describeResponse(11, nil, nil), nil
+				}
+				return nil, errors.New("unexpected task list")
+			}).AnyTimes()
+	}
+
+	tests := []struct {
+		name           string
+		args           []clitest.CliArgument
+		setup          func(td *cliTestData)
+		errContains    string
+		outputContains []string
+	}{
+		{
+			name: "drained groups from domain and global config",
+			setup: func(td *cliTestData) {
+				td.mockAdminClient.EXPECT().GetGlobalIsolationGroups(gomock.Any(), gomock.Any()).Return(&types.GetGlobalIsolationGroupsResponse{
+					IsolationGroups: types.IsolationGroupConfiguration{"zone-1": {Name: "zone-1", State: types.IsolationGroupStateHealthy}},
+				}, nil)
+				td.mockAdminClient.EXPECT().GetDomainIsolationGroups(gomock.Any(), gomock.Any()).Return(&types.GetDomainIsolationGroupsResponse{
+					IsolationGroups: types.IsolationGroupConfiguration{"zone-2": {Name: "zone-2", State: types.IsolationGroupStateDrained}},
+				}, nil)
+				expectTaskList(td)
+			},
+			outputContains: []string{
+				`"isolationGroup": "zone-2"`,
+				`"pinnedPartitions": 2`,
+				`"pinnedTasks": 18`,
+				`"pollers": 3`,
+			},
+		},
+		{
+			name: "explicit groups",
+			args: []clitest.CliArgument{clitest.StringSliceArgument(FlagIsolationGroup, "zone-1")},
+			setup: func(td *cliTestData) {
+				expectTaskList(td)
+			},
+			outputContains: []string{
+				`"isolationGroup": "zone-1"`,
+				`"pinnedTasks": 12`,
+			},
+		},
+		{
+			name: "no drained groups",
+			setup: func(td *cliTestData) {
+				td.mockAdminClient.EXPECT().GetGlobalIsolationGroups(gomock.Any(), gomock.Any()).Return(&types.GetGlobalIsolationGroupsResponse{}, nil)
+				td.mockAdminClient.EXPECT().GetDomainIsolationGroups(gomock.Any(), gomock.Any()).Return(&types.GetDomainIsolationGroupsResponse{}, nil)
+			},
+			outputContains: []string{"No drained isolation groups found"},
+		},
+		{
+			name: "describe task list error",
+			args: []clitest.CliArgument{clitest.StringSliceArgument(FlagIsolationGroup, "zone-1")},
+			setup: func(td *cliTestData) {
+				td.mockFrontendClient.EXPECT().GetTaskListsByDomain(gomock.Any(), gomock.Any()).Return(&types.GetTaskListsByDomainResponse{
+					ActivityTaskListMap: map[string]*types.DescribeTaskListResponse{"tl": {}},
+				}, nil)
+				td.mockFrontendClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).Return(nil, errors.New("critical error"))
+			},
+			errContains: "Operation DescribeTaskList failed for task list: tl",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			tt.setup(td)
+
+			args := append([]clitest.CliArgument{
+				clitest.StringArgument(FlagDomain, testDomain),
+				clitest.StringArgument(FlagFormat, formatJSON),
+			}, tt.args...)
+			err := AdminIsolationGroupDrainStatus(clitest.NewCLIContext(t, td.app, args...))
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errContains)
+			}
+			for _, expected := range tt.outputContains {
+				assert.Contains(t, td.consoleOutput(), expected)
+			}
+		})
+	}
+}
+
+func TestAdminIsolationGroupDrainSchedules(t *testing.T) {
+	now := time.Now().UTC()
+	cancelled := now.Add(-time.Minute)
+	stored := []isolationgroupapi.DrainSchedule{
+		{ID: "active", IsolationGroup: "zone-1", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour), Reason: "maintenance", CreatedBy: "alice"},
+		{ID: "upcoming", IsolationGroup: "zone-2", Domain: testDomain, StartTime: now.Add(time.Hour), EndTime: now.Add(2 * time.Hour), Reason: "upgrade"},
+		{ID: "other-domain", IsolationGroup: "zone-3", Domain: "other", StartTime: now.Add(time.Hour), EndTime: now.Add(2 * time.Hour), Reason: "upgrade"},
+		{ID: "completed", IsolationGroup: "zone-1", StartTime: now.Add(-3 * time.Hour), EndTime: now.Add(-2 * time.Hour), Reason: "old"},
+		{ID: "cancelled", IsolationGroup: "zone-1", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour), Reason: "mistake", CancelledTime: &cancelled},
+		{ID: "expired", IsolationGroup: "zone-1", StartTime: now.Add(-100 * 24 * time.Hour), EndTime: now.Add(-99 * 24 * time.Hour), Reason: "ancient"},
+	}
+	storedData, err := json.Marshal(stored)
+	assert.NoError(t, err)
+	storedValues := []*types.DynamicConfigValue{{Value: &types.DataBlob{EncodingType: types.EncodingTypeJSON.Ptr(), Data: storedData}}}
+	expectGet := func(td *cliTestData) {
+		td.mockAdminClient.EXPECT().ListDynamicConfig(gomock.Any(), &types.ListDynamicConfigRequest{ConfigName: "system.isolationGroupDrainSchedules"}).
+			Return(&types.ListDynamicConfigResponse{Entries: []*types.DynamicConfigEntry{{Name: "system.isolationGroupDrainSchedules", Values: storedValues}}}, nil)
+	}
+	expectUpdate := func(td *cliTestData, validate func(t *testing.T, schedules []isolationgroupapi.DrainSchedule)) {
+		td.mockAdminClient.EXPECT().UpdateDynamicConfig(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, request *types.UpdateDynamicConfigRequest, _ ...yarpc.CallOption) error {
+				assert.Equal(t, "system.isolationGroupDrainSchedules", request.ConfigName)
+				values, expectedDigest, hasPrecondition, err := dynamicproperties.SplitPrecondition(request.ConfigValues)
+				assert.NoError(t, err)
+				assert.True(t, hasPrecondition, "the update must be conditional on the schedules it was based on")
+				storedDigest, err := dynamicproperties.ValuesDigest(storedValues)
+				assert.NoError(t, err)
+				assert.Equal(t, storedDigest, expectedDigest)
+				var schedules []isolationgroupapi.DrainSchedule
+				assert.NoError(t, json.Unmarshal(values[0].Value.Data, &schedules))
+				validate(t, schedules)
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name              string
+		action            func(c *cli.Context) error
+		args              []clitest.CliArgument
+		setup             func(td *cliTestData)
+		errContains       string
+		outputContains    []string
+		outputNotContains []string
+	}{
+		{
+			name:   "list upcoming and active",
+			action: AdminListIsolationGroupDrainSchedules,
+			args:   []clitest.CliArgument{clitest.StringArgument(FlagFormat, formatJSON)},
+			setup:  expectGet,
+			outputContains: []string{
+				`"id": "active"`,
+				`"status": "active"`,
+				`"id": "upcoming"`,
+				`"status": "upcoming"`,
+				`"id": "other-domain"`,
+			},
+			outputNotContains: []string{`"id": "completed"`, `"id": "cancelled"`},
+		},
+		{
+			name:   "list all for a domain",
+			action: AdminListIsolationGroupDrainSchedules,
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagFormat, formatJSON),
+				clitest.StringArgument(FlagDomain, testDomain),
+				clitest.BoolArgument(FlagAll, true),
+			},
+			setup: expectGet,
+			outputContains: []string{
+				`"status": "completed"`,
+				`"status": "cancelled"`,
+				`"domain": "test-domain"`,
+				`"domain": "*"`,
+			},
+			outputNotContains: []string{`"id": "other-domain"`},
+		},
+		{
+			name:   "list nothing scheduled",
+			action: AdminListIsolationGroupDrainSchedules,
+			setup: func(td *cliTestData) {
+				td.mockAdminClient.EXPECT().ListDynamicConfig(gomock.Any(), gomock.Any()).
+					Return(&types.ListDynamicConfigResponse{}, nil)
+			},
+			outputContains: []string{"No drain schedules found"},
+		},
+		{
+			name:   "add",
+			action: AdminAddIsolationGroupDrainSchedule,
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagDomain, testDomain),
+				clitest.StringArgument(FlagIsolationGroup, "zone-4"),
+				clitest.StringArgument(FlagStartTime, now.Add(time.Hour).Format(time.RFC3339)),
+				clitest.StringArgument(FlagEndTime, now.Add(2*time.Hour).Format(time.RFC3339)),
+				clitest.StringArgument(FlagReason, "rack replacement"),
+			},
+			setup: func(td *cliTestData) {
+				expectGet(td)
+				expectUpdate(td, func(t *testing.T, schedules []isolationgroupapi.DrainSchedule) {
+					assert.Len(t, schedules, len(stored)) // the expired schedule is dropped
+					added := schedules[len(schedules)-1]
+					assert.Equal(t, "zone-4", added.IsolationGroup)
+					assert.Equal(t, testDomain, added.Domain)
+					assert.Equal(t, "rack replacement", added.Reason)
+					assert.NotEmpty(t, added.ID)
+				})
+			},
+			outputContains: []string{"Scheduled drain", "zone-4"},
+		},
+		{
+			name:   "add with end before start",
+			action: AdminAddIsolationGroupDrainSchedule,
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagIsolationGroup, "zone-4"),
+				clitest.StringArgument(FlagStartTime, now.Add(2*time.Hour).Format(time.RFC3339)),
+				clitest.StringArgument(FlagEndTime, now.Add(time.Hour).Format(time.RFC3339)),
+				clitest.StringArgument(FlagReason, "rack replacement"),
+			},
+			setup:       expectGet,
+			errContains: "end time must be after start time",
+		},
+		{
+			name:   "add with end in the past",
+			action: AdminAddIsolationGroupDrainSchedule,
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagIsolationGroup, "zone-4"),
+				clitest.StringArgument(FlagStartTime, now.Add(-2*time.Hour).Format(time.RFC3339)),
+				clitest.StringArgument(FlagEndTime, now.Add(-time.Hour).Format(time.RFC3339)),
+				clitest.StringArgument(FlagReason, "rack replacement"),
+			},
+			setup:       func(td *cliTestData) {},
+			errContains: "is in the past",
+		},
+		{
+			name:   "cancel active",
+			action: AdminCancelIsolationGroupDrainSchedule,
+			args:   []clitest.CliArgument{clitest.StringArgument(FlagDrainScheduleID, "active")},
+			setup: func(td *cliTestData) {
+				expectGet(td)
+				expectUpdate(td, func(t *testing.T, schedules []isolationgroupapi.DrainSchedule) {
+					assert.Equal(t, "active", schedules[0].ID)
+					assert.NotNil(t, schedules[0].CancelledTime)
+				})
+			},
+			outputContains: []string{"Cancelled drain active"},
+		},
+		{
+			name:        "cancel completed",
+			action:      AdminCancelIsolationGroupDrainSchedule,
+			args:        []clitest.CliArgument{clitest.StringArgument(FlagDrainScheduleID, "completed")},
+			setup:       expectGet,
+			errContains: "is already completed",
+		},
+		{
+			name:        "cancel unknown",
+			action:      AdminCancelIsolationGroupDrainSchedule,
+			args:        []clitest.CliArgument{clitest.StringArgument(FlagDrainScheduleID, "unknown")},
+			setup:       expectGet,
+			errContains: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			tt.setup(td)
+
+			err := tt.action(clitest.NewCLIContext(t, td.app, tt.args...))
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errContains)
+			}
+			for _, expected := range tt.outputContains {
+				assert.Contains(t, td.consoleOutput(), expected)
+			}
+			for _, unexpected := range tt.outputNotContains {
+				assert.NotContains(t, td.consoleOutput(), unexpected)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	cli "github.com/urfave/cli/v2"
 	gomock "go.uber.org/mock/gomock"
 
+	messaging "github.com/uber/cadence/common/messaging"
 	persistence "github.com/uber/cadence/common/persistence"
 	client "github.com/uber/cadence/common/persistence/client"
 	invariant "github.com/uber/cadence/common/reconciliation/invariant"
@@ -59,6 +60,36 @@ func (mr *MockManagerFactoryMockRecorder) initPersistenceFactory(c any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "initPersistenceFactory", reflect.TypeOf((*MockManagerFactory)(nil).initPersistenceFactory), c)
 }
 
+// initializeConfigStoreManager mocks base method.
+func (m *MockManagerFactory) initializeConfigStoreManager(c *cli.Context) (persistence.ConfigStoreManager, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "initializeConfigStoreManager", c)
+	ret0, _ := ret[0].(persistence.ConfigStoreManager)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// initializeConfigStoreManager indicates an expected call of initializeConfigStoreManager.
+func (mr *MockManagerFactoryMockRecorder) initializeConfigStoreManager(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "initializeConfigStoreManager", reflect.TypeOf((*MockManagerFactory)(nil).initializeConfigStoreManager), c)
+}
+
+// initializeDomainAuditManager mocks base method.
+func (m *MockManagerFactory) initializeDomainAuditManager(c *cli.Context) (persistence.DomainAuditManager, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "initializeDomainAuditManager", c)
+	ret0, _ := ret[0].(persistence.DomainAuditManager)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// initializeDomainAuditManager indicates an expected call of initializeDomainAuditManager.
+func (mr *MockManagerFactoryMockRecorder) initializeDomainAuditManager(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "initializeDomainAuditManager", reflect.TypeOf((*MockManagerFactory)(nil).initializeDomainAuditManager), c)
+}
+
 // initializeDomainManager mocks base method.
 func (m *MockManagerFactory) initializeDomainManager(c *cli.Context) (persistence.DomainManager, error) {
 	m.ctrl.T.Helper()
@@ -133,3 +164,18 @@ func (mr *MockManagerFactoryMockRecorder) initializeShardManager(c any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "initializeShardManager", reflect.TypeOf((*MockManagerFactory)(nil).initializeShardManager), c)
 }
+
+// initializeVisibilityProducer mocks base method.
+func (m *MockManagerFactory) initializeVisibilityProducer(c *cli.Context, appName string) (messaging.Producer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "initializeVisibilityProducer", c, appName)
+	ret0, _ := ret[0].(messaging.Producer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// initializeVisibilityProducer indicates an expected call of initializeVisibilityProducer.
+func (mr *MockManagerFactoryMockRecorder) initializeVisibilityProducer(c, appName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "initializeVisibilityProducer", reflect.TypeOf((*MockManagerFactory)(nil).initializeVisibilityProducer), c, appName)
+}
@@ -36,8 +36,9 @@ import (
 )
 
 const (
-	formatTable = "table"
-	formatJSON  = "json"
+	formatTable    = "table"
+	formatJSON     = "json"
+	formatMarkdown = "markdown"
 
 	templateTable = "{{table .}}\n"
 	templateJSON  = "{{json .}}\n"
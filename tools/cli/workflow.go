@@ -21,6 +21,7 @@
 package cli
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -45,6 +46,13 @@ func newWorkflowCommands() []*cli.Command {
 			Flags:   flagsForExecution,
 			Action:  DiagnoseWorkflow,
 		},
+		{
+			Name:    "diagnose-report",
+			Aliases: []string{"diagr"},
+			Usage:   "fetches the report of a diagnosed workflow execution as JSON or Markdown by querying its diagnostics workflow in the cadence-system domain",
+			Flags:   getFlagsForDiagnoseReport(),
+			Action:  DiagnoseWorkflowReport,
+		},
 		{
 			Name:    "refresh-tasks",
 			Aliases: []string{"rt"},
@@ -64,6 +72,13 @@ func newWorkflowCommands() []*cli.Command {
 			Flags:  getFlagsForShow(),
 			Action: ShowHistory,
 		},
+		{
+			Name:        "diff",
+			Usage:       "compare the histories of two workflow runs",
+			Description: "Aligns the histories of two runs, e.g. a run and its reset or retry, and shows diverging events, attribute differences and timing deltas",
+			Flags:       getFlagsForDiff(),
+			Action:      DiffHistory,
+		},
 		{
 			Name:        "showid",
 			Usage:       "show workflow history with given workflow_id and run_id (a shortcut of `show -w <wid> -r <rid>`). run_id is only required for archived history",
@@ -362,6 +377,12 @@ func newWorkflowCommands() []*cli.Command {
 			},
 			Action: ResetInBatch,
 		},
+		{
+			Name:        "replay-bundle",
+			Aliases:     []string{"rb"},
+			Usage:       "sample closed workflow histories for replay tests",
+			Subcommands: newReplayBundleCommands(),
+		},
 		{
 			Name:        "batch",
 			Usage:       "batch operation on a list of workflows from query.",
@@ -444,6 +465,50 @@ func newActivityCommands() []*cli.Command {
 	}
 }
 
+func newReplayBundleCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "create",
+			Usage: "Start a job sampling closed workflows matching a query into a replay bundle",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagListQuery,
+					Aliases: []string{"q"},
+					Usage:   "Visibility query selecting the workflows to sample, only closed workflows are included",
+				},
+				&cli.IntFlag{
+					Name:  FlagSampleSize,
+					Value: batcher.DefaultReplayBundleSampleSize,
+					Usage: fmt.Sprintf("Number of histories in the bundle, at most %d", batcher.MaxReplayBundleSampleSize),
+				},
+				&cli.IntFlag{
+					Name:  FlagScanLimit,
+					Value: batcher.DefaultReplayBundleScanLimit,
+					Usage: "Number of visibility records the sample is drawn from",
+				},
+			},
+			Action: CreateReplayBundle,
+		},
+		{
+			Name:  "fetch",
+			Usage: "Download the histories of a replay bundle, one JSON file per history which can be replayed by the Go and Java client replayers. Workflows past the retention of their domain are skipped",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagJobID,
+					Aliases: []string{"jid"},
+					Usage:   "Replay bundle job ID",
+				},
+				&cli.StringFlag{
+					Name:    FlagOutputDirectory,
+					Aliases: []string{"od"},
+					Usage:   "Directory to write the manifest and histories of the bundle to",
+				},
+			},
+			Action: FetchReplayBundle,
+		},
+	}
+}
+
 func newBatchCommands() []*cli.Command {
 	return []*cli.Command{
 		{
@@ -476,6 +541,23 @@ func newBatchCommands() []*cli.Command {
 			},
 			Action: TerminateBatchJob,
 		},
+		{
+			Name:  "report",
+			Usage: "Download the per-workflow results of a batch operation job",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagJobID,
+					Aliases: []string{"jid"},
+					Usage:   "Batch Job ID",
+				},
+				&cli.BoolFlag{
+					Name:  FlagFailuresOnly,
+					Usage: "Only report the workflows which failed to be processed",
+				},
+				getFormatFlag(),
+			},
+			Action: ReportBatchJob,
+		},
 		{
 			Name:    "list",
 			Aliases: []string{"l"},
@@ -530,6 +612,28 @@ func newBatchCommands() []*cli.Command {
 					Aliases: []string{"tc"},
 					Usage:   "Required for batch replicate",
 				},
+				&cli.StringFlag{
+					Name:  FlagResetType,
+					Usage: "Required for batch reset. Types supported: " + strings.Join(batcher.AllResetTypes, ","),
+				},
+				&cli.IntFlag{
+					Name: FlagDecisionOffset,
+					Usage: "Optional for batch reset, moves the reset point of LastDecisionCompleted or LastDecisionScheduled back by this many decisions. " +
+						"Only negative numbers are supported.",
+				},
+				&cli.StringFlag{
+					Name:  FlagResetBadBinaryChecksum,
+					Usage: "Required for batch reset with BadBinary",
+				},
+				&cli.BoolFlag{
+					Name:  FlagSkipSignalReapply,
+					Usage: "Optional for batch reset, skips reapplying the signals after the reset point",
+				},
+				&cli.BoolFlag{
+					Name:    FlagSkipErrorMode,
+					Aliases: []string{"serr"},
+					Usage:   "Optional for batch delete, skips errors and deletes as much as possible of each workflow",
+				},
 				&cli.IntFlag{
 					Name:  FlagRPS,
 					Value: batcher.DefaultRPS,
@@ -570,6 +674,10 @@ func newBatchCommands() []*cli.Command {
 					Name:  FlagBatchV2,
 					Usage: "Use V2 batch workflow with runtime signal-based tuning support",
 				},
+				&cli.BoolFlag{
+					Name:  FlagDryRun,
+					Usage: "Only record the workflows the query matches in the job report, without processing them",
+				},
 			},
 			Action: StartBatchJob,
 		},
@@ -31,6 +31,7 @@ import (
 	"github.com/pborman/uuid"
 	"github.com/urfave/cli/v2"
 
+	"github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/constants"
 	"github.com/uber/cadence/common/types"
@@ -205,7 +206,6 @@ func StartBatchJob(c *cli.Context) error {
 	if !validateBatchType(batchType) {
 		return commoncli.Problem("batchType is not valid, supported:"+strings.Join(batcher.AllBatchTypes, ","), nil)
 	}
-	operator := getCurrentUserFromEnv()
 	var sigName, sigVal string
 	if batchType == batcher.BatchTypeSignal {
 		sigName, err = getRequiredOption(c, FlagSignalName)
@@ -228,13 +228,52 @@ func StartBatchJob(c *cli.Context) error {
 			return commoncli.Problem("Required flag not found: ", err)
 		}
 	}
-	rps := c.Int(FlagRPS)
-	pageSize := c.Int(FlagPageSize)
-	concurrency := c.Int(FlagConcurrency)
-	retryAttempt := c.Int(FlagRetryAttempts)
-	heartBeatTimeout := time.Duration(c.Int(FlagActivityHeartBeatTimeout)) * time.Second
-	maxActivityRetries := c.Int(FlagMaxActivityRetries)
+	var resetType string
+	if batchType == batcher.BatchTypeReset {
+		resetType, err = getRequiredOption(c, FlagResetType)
+		if err != nil {
+			return commoncli.Problem("Required flag not found: ", err)
+		}
+	}
+	params := batcher.BatchParams{
+		DomainName: domain,
+		Query:      query,
+		Reason:     reason,
+		BatchType:  batchType,
+		SignalParams: batcher.SignalParams{
+			SignalName: sigName,
+			Input:      sigVal,
+		},
+		ReplicateParams: batcher.ReplicateParams{
+			SourceCluster: sourceCluster,
+			TargetCluster: targetCluster,
+		},
+		ResetParams: batcher.ResetParams{
+			ResetType:         resetType,
+			DecisionOffset:    c.Int(FlagDecisionOffset),
+			BadBinaryChecksum: c.String(FlagResetBadBinaryChecksum),
+			SkipSignalReapply: c.Bool(FlagSkipSignalReapply),
+		},
+		DeleteParams: batcher.DeleteParams{
+			SkipErrors: c.Bool(FlagSkipErrorMode),
+		},
+		DryRun:                   c.Bool(FlagDryRun),
+		RPS:                      c.Int(FlagRPS),
+		Concurrency:              c.Int(FlagConcurrency),
+		PageSize:                 c.Int(FlagPageSize),
+		AttemptsOnRetryableError: c.Int(FlagRetryAttempts),
+		ActivityHeartBeatTimeout: time.Duration(c.Int(FlagActivityHeartBeatTimeout)) * time.Second,
+		MaxActivityRetries:       c.Int(FlagMaxActivityRetries),
+	}
+	wfTypeName := batcher.BatchWFTypeName
+	if c.Bool(FlagBatchV2) {
+		wfTypeName = batcher.BatchWFV2TypeName
+	}
+	return startBatchWorkflow(c, params, wfTypeName)
+}
 
+// startBatchWorkflow asks for confirmation and starts a batcher workflow of the given type with params
+func startBatchWorkflow(c *cli.Context, params batcher.BatchParams, wfTypeName string) error {
 	svcClient, err := getDeps(c).ServerFrontendClient(c)
 	if err != nil {
 		return err
@@ -248,15 +287,15 @@ func StartBatchJob(c *cli.Context) error {
 	resp, err := svcClient.CountWorkflowExecutions(
 		tcCtx,
 		&types.CountWorkflowExecutionsRequest{
-			Domain: domain,
-			Query:  query,
+			Domain: params.DomainName,
+			Query:  params.Query,
 		},
 	)
 	if err != nil {
 		return commoncli.Problem("Failed to count impacting workflows for starting a batch job", err)
 	}
 	fmt.Printf("This batch job will be operating on %v workflows.\n", resp.GetCount())
-	if !c.Bool(FlagYes) {
+	if !c.Bool(FlagYes) && !params.DryRun {
 		reader := bufio.NewReader(os.Stdin)
 		for {
 			fmt.Print("Please confirm[Yes/No]:")
@@ -279,47 +318,23 @@ func StartBatchJob(c *cli.Context) error {
 		return commoncli.Problem("Error in creating context:", err)
 	}
 
-	params := batcher.BatchParams{
-		DomainName: domain,
-		Query:      query,
-		Reason:     reason,
-		BatchType:  batchType,
-		SignalParams: batcher.SignalParams{
-			SignalName: sigName,
-			Input:      sigVal,
-		},
-		ReplicateParams: batcher.ReplicateParams{
-			SourceCluster: sourceCluster,
-			TargetCluster: targetCluster,
-		},
-		RPS:                      rps,
-		Concurrency:              concurrency,
-		PageSize:                 pageSize,
-		AttemptsOnRetryableError: retryAttempt,
-		ActivityHeartBeatTimeout: heartBeatTimeout,
-		MaxActivityRetries:       maxActivityRetries,
-	}
 	input, err := json.Marshal(params)
 	if err != nil {
 		return commoncli.Problem("Failed to encode batch job parameters", err)
 	}
 	memo, err := getWorkflowMemo(map[string]interface{}{
-		"Reason": reason,
+		"Reason": params.Reason,
 	})
 	if err != nil {
 		return commoncli.Problem("Failed to encode batch job memo", err)
 	}
 	searchAttributes, err := serializeSearchAttributes(map[string]interface{}{
-		"CustomDomain": domain,
-		"Operator":     operator,
+		"CustomDomain": params.DomainName,
+		"Operator":     getCurrentUserFromEnv(),
 	})
 	if err != nil {
 		return commoncli.Problem("Failed to encode batch job search attributes", err)
 	}
-	wfTypeName := batcher.BatchWFTypeName
-	if c.Bool(FlagBatchV2) {
-		wfTypeName = batcher.BatchWFV2TypeName
-	}
 
 	workflowID := uuid.NewRandom().String()
 	request := &types.StartWorkflowExecutionRequest{
@@ -347,6 +362,105 @@ func StartBatchJob(c *cli.Context) error {
 	return nil
 }
 
+// BatchResultRow is the result of a workflow in the report of a batch job
+type BatchResultRow struct {
+	WorkflowID string `header:"Workflow ID" json:"workflowID"`
+	RunID      string `header:"Run ID" json:"runID"`
+	Outcome    string `header:"Outcome" json:"outcome"`
+	Error      string `header:"Error" json:"error,omitempty"`
+}
+
+// ReportBatchJob downloads the result log of a batch job
+func ReportBatchJob(c *cli.Context) error {
+	jobID, err := getRequiredOption(c, FlagJobID)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	svcClient, err := getDeps(c).ServerFrontendClient(c)
+	if err != nil {
+		return err
+	}
+
+	params := batcher.BatchReportParams{
+		JobID:        jobID,
+		FailuresOnly: c.Bool(FlagFailuresOnly),
+	}
+	rows := []BatchResultRow{}
+	for {
+		report, err := runBatchReport(c, svcClient, params)
+		if err != nil {
+			return commoncli.Problem("Failed to get batch job report", err)
+		}
+		for _, result := range report.Results {
+			rows = append(rows, BatchResultRow(result))
+		}
+		if !report.HasMore {
+			break
+		}
+		params.FromPage = report.NextPage
+	}
+	return Render(c, rows, RenderOptions{Color: true, DefaultTemplate: templateTable})
+}
+
+// runBatchReport runs a batch report workflow, which reads the result log from the blobstore of the batcher, and waits for its result
+func runBatchReport(c *cli.Context, svcClient frontend.Client, params batcher.BatchReportParams) (batcher.BatchReport, error) {
+	report := batcher.BatchReport{}
+	err := runBatcherWorkflow(c, svcClient, params.JobID+"-report-"+uuid.New(), batcher.BatchReportWFTypeName, params, &report)
+	return report, err
+}
+
+// runBatcherWorkflow runs a short workflow of the given type on the batcher task list and decodes its result into result
+func runBatcherWorkflow(c *cli.Context, svcClient frontend.Client, workflowID, wfTypeName string, params, result interface{}) error {
+	ctx, cancel, err := newContextForLongPoll(c)
+	defer cancel()
+	if err != nil {
+		return err
+	}
+	input, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	execution := &types.WorkflowExecution{WorkflowID: workflowID}
+	resp, err := svcClient.StartWorkflowExecution(ctx, &types.StartWorkflowExecutionRequest{
+		Domain:                              constants.BatcherLocalDomainName,
+		RequestID:                           uuid.New(),
+		WorkflowID:                          execution.WorkflowID,
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(int32(batchReportTimeout.Seconds())),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(int32(defaultDecisionTimeoutInSeconds)),
+		TaskList:                            &types.TaskList{Name: batcher.BatcherTaskListName},
+		WorkflowType:                        &types.WorkflowType{Name: wfTypeName},
+		Input:                               input,
+	})
+	if err != nil {
+		return err
+	}
+	execution.RunID = resp.GetRunID()
+
+	request := &types.GetWorkflowExecutionHistoryRequest{
+		Domain:                 constants.BatcherLocalDomainName,
+		Execution:              execution,
+		WaitForNewEvent:        true,
+		HistoryEventFilterType: types.HistoryEventFilterTypeCloseEvent.Ptr(),
+	}
+	for {
+		history, err := svcClient.GetWorkflowExecutionHistory(ctx, request)
+		if err != nil {
+			return err
+		}
+		for _, event := range history.GetHistory().GetEvents() {
+			if attributes := event.WorkflowExecutionCompletedEventAttributes; attributes != nil {
+				return json.Unmarshal(attributes.Result, result)
+			}
+			if attributes := event.WorkflowExecutionFailedEventAttributes; attributes != nil {
+				return fmt.Errorf("%s: %s", attributes.GetReason(), attributes.Details)
+			}
+			return fmt.Errorf("%s workflow closed with %v", wfTypeName, event.GetEventType())
+		}
+		request.NextPageToken = history.NextPageToken
+	}
+}
+
 func copyRetryPolicyFromWorkflow() *types.RetryPolicy {
 	return &types.RetryPolicy{
 		InitialIntervalInSeconds:    int32(batcher.BatchActivityRetryPolicy.InitialInterval.Seconds()),
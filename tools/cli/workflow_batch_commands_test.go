@@ -21,6 +21,7 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"flag"
@@ -29,6 +30,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/mock/gomock"
+	"go.uber.org/yarpc"
 
 	"github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/common"
@@ -109,6 +111,67 @@ func TestStartBatchJob(t *testing.T) {
 			},
 			expectedError: "Required flag not found: : option input is required",
 		},
+		{
+			name: "Valid Start Batch Reset",
+			setup: func(mockClient *frontend.MockClient) {
+				mockClient.EXPECT().CountWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&types.CountWorkflowExecutionsResponse{
+					Count: 100,
+				}, nil)
+				mockClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, request *types.StartWorkflowExecutionRequest, _ ...yarpc.CallOption) (*types.StartWorkflowExecutionResponse, error) {
+						var params batcher.BatchParams
+						assert.NoError(t, json.Unmarshal(request.Input, &params))
+						assert.Equal(t, batcher.ResetParams{ResetType: batcher.ResetTypeLastDecisionCompleted, DecisionOffset: -1}, params.ResetParams)
+						return &types.StartWorkflowExecutionResponse{RunID: "run-id-example"}, nil
+					})
+			},
+			flags: map[string]interface{}{
+				FlagDomain:         "test-domain",
+				FlagListQuery:      "workflowType='batch'",
+				FlagReason:         "Testing batch job",
+				FlagBatchType:      batcher.BatchTypeReset,
+				FlagResetType:      batcher.ResetTypeLastDecisionCompleted,
+				FlagDecisionOffset: -1,
+				FlagYes:            true,
+			},
+			expectedError:  "",
+			expectedOutput: "batch job is started",
+		},
+		{
+			name: "Dry Run Without Confirmation",
+			setup: func(mockClient *frontend.MockClient) {
+				mockClient.EXPECT().CountWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&types.CountWorkflowExecutionsResponse{
+					Count: 100,
+				}, nil)
+				mockClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, request *types.StartWorkflowExecutionRequest, _ ...yarpc.CallOption) (*types.StartWorkflowExecutionResponse, error) {
+						var params batcher.BatchParams
+						assert.NoError(t, json.Unmarshal(request.Input, &params))
+						assert.True(t, params.DryRun)
+						return &types.StartWorkflowExecutionResponse{RunID: "run-id-example"}, nil
+					})
+			},
+			flags: map[string]interface{}{
+				FlagDomain:    "test-domain",
+				FlagListQuery: "workflowType='batch'",
+				FlagReason:    "Testing batch job",
+				FlagBatchType: batcher.BatchTypeTerminate,
+				FlagDryRun:    true,
+			},
+			expectedError:  "",
+			expectedOutput: "batch job is started",
+		},
+		{
+			name:  "Missing Reset Type",
+			setup: func(mockClient *frontend.MockClient) {},
+			flags: map[string]interface{}{
+				FlagDomain:    "test-domain",
+				FlagListQuery: "workflowType='batch'",
+				FlagReason:    "Testing batch job",
+				FlagBatchType: batcher.BatchTypeReset,
+			},
+			expectedError: "Required flag not found: : option reset_type is required",
+		},
 		{
 			name:  "Missing Reason",
 			setup: func(mockClient *frontend.MockClient) {},
@@ -128,7 +191,7 @@ func TestStartBatchJob(t *testing.T) {
 				FlagReason:    "Testing batch job",
 				FlagBatchType: "invalidBatchType",
 			},
-			expectedError: "batchType is not valid, supported:terminate,cancel,signal,replicate",
+			expectedError: "batchType is not valid, supported:terminate,cancel,signal,replicate,reset,delete",
 		},
 		{
 			name: "Count Workflow Executions Failure",
@@ -554,3 +617,116 @@ func TestValidateBatchType(t *testing.T) {
 		})
 	}
 }
+
+func TestReportBatchJob(t *testing.T) {
+	expectReport := func(mockClient *frontend.MockClient, params batcher.BatchReportParams, closeEvent *types.HistoryEvent) {
+		mockClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, request *types.StartWorkflowExecutionRequest, _ ...yarpc.CallOption) (*types.StartWorkflowExecutionResponse, error) {
+				assert.Equal(t, batcher.BatchReportWFTypeName, request.WorkflowType.Name)
+				var actual batcher.BatchReportParams
+				assert.NoError(t, json.Unmarshal(request.Input, &actual))
+				assert.Equal(t, params, actual)
+				return &types.StartWorkflowExecutionResponse{RunID: "report-run-id"}, nil
+			})
+		// the first long poll times out without the close event
+		mockClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).
+			Return(&types.GetWorkflowExecutionHistoryResponse{History: &types.History{}, NextPageToken: []byte("token")}, nil)
+		mockClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).
+			Return(&types.GetWorkflowExecutionHistoryResponse{History: &types.History{Events: []*types.HistoryEvent{closeEvent}}}, nil)
+	}
+	completed := func(report batcher.BatchReport) *types.HistoryEvent {
+		result, err := json.Marshal(report)
+		assert.NoError(t, err)
+		return &types.HistoryEvent{
+			EventType: types.EventTypeWorkflowExecutionCompleted.Ptr(),
+			WorkflowExecutionCompletedEventAttributes: &types.WorkflowExecutionCompletedEventAttributes{Result: result},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		setup          func(*frontend.MockClient)
+		flags          map[string]interface{}
+		expectedError  string
+		expectedOutput []BatchResultRow
+	}{
+		{
+			name: "Report across pages",
+			setup: func(mockClient *frontend.MockClient) {
+				expectReport(mockClient, batcher.BatchReportParams{JobID: "job-id", FailuresOnly: true}, completed(batcher.BatchReport{
+					Results:  []batcher.WorkflowResult{{WorkflowID: "wid1", RunID: "rid1", Outcome: batcher.OutcomeFailed, Error: "error"}},
+					NextPage: 3,
+					HasMore:  true,
+				}))
+				expectReport(mockClient, batcher.BatchReportParams{JobID: "job-id", FromPage: 3, FailuresOnly: true}, completed(batcher.BatchReport{
+					Results: []batcher.WorkflowResult{{WorkflowID: "wid2", RunID: "rid2", Outcome: batcher.OutcomeFailed, Error: "error"}},
+				}))
+			},
+			flags: map[string]interface{}{
+				FlagJobID:        "job-id",
+				FlagFailuresOnly: true,
+				FlagFormat:       formatJSON,
+			},
+			expectedOutput: []BatchResultRow{
+				{WorkflowID: "wid1", RunID: "rid1", Outcome: batcher.OutcomeFailed, Error: "error"},
+				{WorkflowID: "wid2", RunID: "rid2", Outcome: batcher.OutcomeFailed, Error: "error"},
+			},
+		},
+		{
+			name: "Report workflow failed",
+			setup: func(mockClient *frontend.MockClient) {
+				expectReport(mockClient, batcher.BatchReportParams{JobID: "job-id"}, &types.HistoryEvent{
+					EventType: types.EventTypeWorkflowExecutionFailed.Ptr(),
+					WorkflowExecutionFailedEventAttributes: &types.WorkflowExecutionFailedEventAttributes{
+						Reason:  common.StringPtr("non-retriable-error"),
+						Details: []byte("blobstore is not configured"),
+					},
+				})
+			},
+			flags: map[string]interface{}{
+				FlagJobID: "job-id",
+			},
+			expectedError: "Failed to get batch job report: non-retriable-error: blobstore is not configured",
+		},
+		{
+			name:          "Missing Job ID",
+			setup:         func(mockClient *frontend.MockClient) {},
+			flags:         map[string]interface{}{},
+			expectedError: "Required flag not found: : option job_id is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockClient := frontend.NewMockClient(mockCtrl)
+			ioHandler := &testIOHandler{}
+			app := NewCliApp(&clientFactoryMock{
+				serverFrontendClient: mockClient,
+			}, WithIOHandler(ioHandler))
+
+			set := flag.NewFlagSet("test", 0)
+			for k, v := range tt.flags {
+				switch val := v.(type) {
+				case string:
+					_ = set.String(k, val, "")
+				case bool:
+					_ = set.Bool(k, val, "")
+				}
+			}
+			c := cli.NewContext(app, set, nil)
+			tt.setup(mockClient)
+
+			err := ReportBatchJob(c)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				var actualOutput []BatchResultRow
+				assert.NoError(t, json.Unmarshal(ioHandler.outputBytes.Bytes(), &actualOutput))
+				assert.Equal(t, tt.expectedOutput, actualOutput)
+			}
+		})
+	}
+}
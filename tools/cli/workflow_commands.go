@@ -44,8 +44,10 @@ import (
 	"github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/constants"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/execution"
+	"github.com/uber/cadence/service/worker/diagnostics"
 	"github.com/uber/cadence/tools/common/commoncli"
 )
 
@@ -128,12 +130,74 @@ func DiagnoseWorkflow(c *cli.Context) error {
 	if err != nil {
 		return commoncli.Problem("Diagnose workflow failed.", err)
 	}
-	fmt.Println("Workflow diagnosis started. Query the diagnostic workflow to get diagnostics report.")
+	fmt.Println("Workflow diagnosis started. Run 'cadence workflow diagnose-report' with the same workflow and run ID to get the diagnostics report.")
 	fmt.Println("============Diagnostic Workflow details============")
 	fmt.Printf("Domain: %s, Workflow Id: %s, Run Id: %s\n", resp.GetDomain(), resp.GetDiagnosticWorkflowExecution().GetWorkflowID(), resp.GetDiagnosticWorkflowExecution().GetRunID())
 	return nil
 }
 
+// DiagnoseWorkflowReport fetches the report of a diagnosed workflow execution
+func DiagnoseWorkflowReport(c *cli.Context) error {
+	wfClient, err := getWorkflowClient(c)
+	if err != nil {
+		return err
+	}
+
+	domain, err := getRequiredOption(c, FlagDomain)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	if _, err := getRequiredOption(c, FlagWorkflowID); err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	rid, err := getRequiredOption(c, FlagRunID)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	var queryType string
+	switch format := c.String(FlagFormat); format {
+	case "", formatJSON:
+		queryType = diagnostics.QueryDiagnosticsReportJSON
+	case formatMarkdown:
+		queryType = diagnostics.QueryDiagnosticsReportMarkdown
+	default:
+		return commoncli.Problem(fmt.Sprintf("Invalid format %q, valid formats are %s and %s", format, formatJSON, formatMarkdown), nil)
+	}
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error creating context: ", err)
+	}
+	resp, err := wfClient.QueryWorkflow(ctx, &types.QueryWorkflowRequest{
+		Domain: constants.SystemLocalDomainName,
+		Execution: &types.WorkflowExecution{
+			WorkflowID: diagnostics.DiagnosticsWorkflowID(domain, rid),
+		},
+		Query: &types.WorkflowQuery{
+			QueryType: queryType,
+		},
+	})
+	if err != nil {
+		return commoncli.Problem("Failed to fetch diagnostics report. Make sure the workflow was diagnosed first.", err)
+	}
+
+	output := getDeps(c).Output()
+	if queryType == diagnostics.QueryDiagnosticsReportMarkdown {
+		var markdown string
+		if err := json.Unmarshal(resp.GetQueryResult(), &markdown); err != nil {
+			return commoncli.Problem("Failed to decode diagnostics report: ", err)
+		}
+		output.Write([]byte(markdown))
+		return nil
+	}
+	var report diagnostics.DiagnosticsReport
+	if err := json.Unmarshal(resp.GetQueryResult(), &report); err != nil {
+		return commoncli.Problem("Failed to decode diagnostics report: ", err)
+	}
+	prettyPrintJSONObject(output, report)
+	return nil
+}
+
 // RefreshWorkflowTasks refreshes all the tasks of a workflow
 func RefreshWorkflowTasks(c *cli.Context) error {
 	wfClient, err := getWorkflowClient(c)
@@ -1328,7 +1392,7 @@ func convertSearchAttributesToMapOfInterface(searchAttributes *types.SearchAttri
 	if err != nil {
 		return nil, commoncli.Problem("Error creating context: ", err)
 	}
-	validSearchAttributes, err := wfClient.GetSearchAttributes(ctx)
+	validSearchAttributes, err := getSearchAttributes(ctx, c, wfClient)
 	if err != nil {
 		return nil, commoncli.Problem("Error when get search attributes", err)
 	}
@@ -218,7 +218,7 @@ func Test_DescribeWorkflowWithID_Error(t *testing.T) {
 			},
 		},
 	}, nil).Times(1)
-	serverFrontendClient.EXPECT().GetSearchAttributes(gomock.Any()).Return(nil, errors.New("test-error")).Times(1)
+	serverFrontendClient.EXPECT().GetSearchAttributes(gomock.Any(), gomock.Any()).Return(nil, errors.New("test-error")).Times(1)
 
 	c := getMockContext(t, nil, app)
 	err = DescribeWorkflowWithID(c)
@@ -327,7 +327,7 @@ func Test_ConvertSearchAttributesToMapOfInterface(t *testing.T) {
 				serverAdminClient:    serverAdminClient,
 			})
 			c := getMockContext(t, nil, app)
-			serverFrontendClient.EXPECT().GetSearchAttributes(gomock.Any()).Return(tt.mockResponse, tt.mockError).AnyTimes()
+			serverFrontendClient.EXPECT().GetSearchAttributes(gomock.Any(), gomock.Any()).Return(tt.mockResponse, tt.mockError).AnyTimes()
 			out, err := convertSearchAttributesToMapOfInterface(tt.in, serverFrontendClient, c)
 			if tt.expectedError {
 				assert.Error(t, err)
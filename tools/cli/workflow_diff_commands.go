@@ -0,0 +1,554 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/tools/common/commoncli"
+)
+
+const (
+	historyDiffSame      = "same"
+	historyDiffChanged   = "changed"
+	historyDiffLeftOnly  = "left only"
+	historyDiffRightOnly = "right only"
+
+	// maxHistoryDiffCells bounds the size of the table used to align the diverging part of two histories,
+	// larger histories are aligned by position instead
+	maxHistoryDiffCells = 4 * 1024 * 1024
+)
+
+// historyDiffIgnoredFields are attributes that differ between any two runs and say nothing about the workflow logic
+var historyDiffIgnoredFields = map[string]bool{
+	"Identity":            true,
+	"RequestID":           true,
+	"FirstScheduleTime":   true,
+	"PrevAutoResetPoints": true,
+	"ForkEventVersion":    true,
+}
+
+type historyDiff struct {
+	Left            historyDiffRun     `json:"left"`
+	Right           historyDiffRun     `json:"right"`
+	FirstDivergence *historyEventDiff  `json:"firstDivergence,omitempty"`
+	Same            int                `json:"same"`
+	Changed         int                `json:"changed"`
+	LeftOnly        int                `json:"leftOnly"`
+	RightOnly       int                `json:"rightOnly"`
+	MaxTimingDelta  string             `json:"maxTimingDelta,omitempty"`
+	Events          []historyEventDiff `json:"events"`
+}
+
+type historyDiffRun struct {
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId"`
+	EventCount int    `json:"eventCount"`
+	Duration   string `json:"duration"`
+}
+
+type historyEventDiff struct {
+	Status       string               `json:"status"`
+	EventType    string               `json:"eventType"`
+	Key          string               `json:"key,omitempty"`
+	LeftEventID  int64                `json:"leftEventId,omitempty"`
+	RightEventID int64                `json:"rightEventId,omitempty"`
+	LeftOffset   string               `json:"leftOffset,omitempty"`
+	RightOffset  string               `json:"rightOffset,omitempty"`
+	TimingDelta  string               `json:"timingDelta,omitempty"`
+	Changes      []historyFieldChange `json:"changes,omitempty"`
+
+	timingDelta time.Duration
+}
+
+type historyFieldChange struct {
+	Field string `json:"field"`
+	Left  string `json:"left"`
+	Right string `json:"right"`
+}
+
+type historyDiffRow struct {
+	Status    string `header:"Status"`
+	LeftID    string `header:"Left ID"`
+	RightID   string `header:"Right ID"`
+	EventType string `header:"Event Type"`
+	Key       string `header:"Key"`
+	Field     string `header:"Field"`
+	Left      string `header:"Left"`
+	Right     string `header:"Right"`
+	Delta     string `header:"Timing Delta"`
+}
+
+// DiffHistory aligns the histories of two workflow executions and shows where they diverge
+func DiffHistory(c *cli.Context) error {
+	wfClient, err := getWorkflowClient(c)
+	if err != nil {
+		return err
+	}
+	domain, err := getRequiredOption(c, FlagDomain)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	wid, err := getRequiredOption(c, FlagWorkflowID)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	rid := c.String(FlagRunID)
+	otherWid := c.String(FlagWorkflowID2)
+	if otherWid == "" {
+		otherWid = wid
+	}
+	otherRid := c.String(FlagRunID2)
+	if wid == otherWid && rid == otherRid {
+		return commoncli.Problem(fmt.Sprintf("Both sides of the diff point to the same execution, set a different --%s or --%s.", FlagRunID2, FlagWorkflowID2), nil)
+	}
+
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error creating context: ", err)
+	}
+	left, err := GetHistory(ctx, wfClient, domain, wid, rid, nil)
+	if err != nil {
+		return commoncli.Problem(fmt.Sprintf("Failed to get history on workflow id: %s, run id: %s.", wid, rid), err)
+	}
+	right, err := GetHistory(ctx, wfClient, domain, otherWid, otherRid, nil)
+	if err != nil {
+		return commoncli.Problem(fmt.Sprintf("Failed to get history on workflow id: %s, run id: %s.", otherWid, otherRid), err)
+	}
+
+	diff := diffHistories(left.GetEvents(), right.GetEvents())
+	diff.Left.WorkflowID, diff.Left.RunID = wid, rid
+	diff.Right.WorkflowID, diff.Right.RunID = otherWid, otherRid
+	if !c.Bool(FlagAll) {
+		diff.Events = divergingEvents(diff.Events)
+	}
+
+	if c.String(FlagFormat) == formatJSON {
+		return Render(c, diff, RenderOptions{DefaultTemplate: templateJSON})
+	}
+
+	output := getDeps(c).Output()
+	fmt.Fprintf(output, "Left:  %s/%s, %d events, duration %s\n", wid, displayRunID(rid), diff.Left.EventCount, diff.Left.Duration)
+	fmt.Fprintf(output, "Right: %s/%s, %d events, duration %s\n", otherWid, displayRunID(otherRid), diff.Right.EventCount, diff.Right.Duration)
+	fmt.Fprintf(output, "Same: %d, changed: %d, left only: %d, right only: %d\n", diff.Same, diff.Changed, diff.LeftOnly, diff.RightOnly)
+	if diff.MaxTimingDelta != "" {
+		fmt.Fprintf(output, "Largest timing delta: %s\n", diff.MaxTimingDelta)
+	}
+	if diff.FirstDivergence == nil {
+		fmt.Fprintln(output, "Histories do not diverge.")
+		if len(diff.Events) == 0 {
+			return nil
+		}
+	} else {
+		fmt.Fprintf(output, "First divergence: left event %d, right event %d (%s)\n", diff.FirstDivergence.LeftEventID, diff.FirstDivergence.RightEventID, diff.FirstDivergence.EventType)
+	}
+	maxFieldLength := c.Int(FlagMaxFieldLength)
+	if maxFieldLength == 0 {
+		maxFieldLength = defaultMaxFieldLength
+	}
+	return Render(c, historyDiffRows(diff.Events, maxFieldLength), RenderOptions{DefaultTemplate: templateTable, Color: true, Border: true})
+}
+
+func displayRunID(runID string) string {
+	if runID == "" {
+		return "<current run>"
+	}
+	return runID
+}
+
+// diffHistories aligns two histories on the logical identity of their events (event type plus activity, timer,
+// signal or child workflow identity) and compares the attributes of every aligned pair
+func diffHistories(left, right []*types.HistoryEvent) *historyDiff {
+	diff := &historyDiff{
+		Left:  historyDiffRun{EventCount: len(left), Duration: historyDuration(left).String()},
+		Right: historyDiffRun{EventCount: len(right), Duration: historyDuration(right).String()},
+	}
+	leftKeys, rightKeys := historyEventKeys(left), historyEventKeys(right)
+	leftStart, rightStart := historyStartTime(left), historyStartTime(right)
+
+	var maxDelta time.Duration
+	for _, pair := range alignHistories(leftKeys, rightKeys) {
+		var d historyEventDiff
+		switch {
+		case pair.left >= 0 && pair.right >= 0:
+			l, r := left[pair.left], right[pair.right]
+			d = historyEventDiff{
+				Status:       historyDiffSame,
+				EventType:    l.GetEventType().String(),
+				Key:          leftKeys[pair.left].id,
+				LeftEventID:  l.ID,
+				RightEventID: r.ID,
+				Changes:      diffEventAttributes(l, r),
+			}
+			if len(d.Changes) > 0 {
+				d.Status = historyDiffChanged
+				diff.Changed++
+			} else {
+				diff.Same++
+			}
+			leftOffset, rightOffset := eventOffset(l, leftStart), eventOffset(r, rightStart)
+			d.LeftOffset, d.RightOffset = leftOffset.String(), rightOffset.String()
+			d.timingDelta = rightOffset - leftOffset
+			d.TimingDelta = formatTimingDelta(d.timingDelta)
+			if abs := d.timingDelta.Abs(); abs > maxDelta {
+				maxDelta = abs
+				diff.MaxTimingDelta = fmt.Sprintf("%s (left event %d, right event %d)", d.TimingDelta, l.ID, r.ID)
+			}
+		case pair.left >= 0:
+			l := left[pair.left]
+			d = historyEventDiff{
+				Status:      historyDiffLeftOnly,
+				EventType:   l.GetEventType().String(),
+				Key:         leftKeys[pair.left].id,
+				LeftEventID: l.ID,
+				LeftOffset:  eventOffset(l, leftStart).String(),
+			}
+			diff.LeftOnly++
+		default:
+			r := right[pair.right]
+			d = historyEventDiff{
+				Status:       historyDiffRightOnly,
+				EventType:    r.GetEventType().String(),
+				Key:          rightKeys[pair.right].id,
+				RightEventID: r.ID,
+				RightOffset:  eventOffset(r, rightStart).String(),
+			}
+			diff.RightOnly++
+		}
+		if d.Status != historyDiffSame && diff.FirstDivergence == nil {
+			first := d
+			diff.FirstDivergence = &first
+		}
+		diff.Events = append(diff.Events, d)
+	}
+	return diff
+}
+
+func divergingEvents(events []historyEventDiff) []historyEventDiff {
+	var result []historyEventDiff
+	for _, e := range events {
+		if e.Status != historyDiffSame {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func historyDiffRows(events []historyEventDiff, maxFieldLength int) []historyDiffRow {
+	var rows []historyDiffRow
+	for _, e := range events {
+		row := historyDiffRow{
+			Status:    e.Status,
+			EventType: e.EventType,
+			Key:       e.Key,
+			Delta:     e.TimingDelta,
+		}
+		if e.LeftEventID != 0 {
+			row.LeftID = fmt.Sprint(e.LeftEventID)
+		}
+		if e.RightEventID != 0 {
+			row.RightID = fmt.Sprint(e.RightEventID)
+		}
+		if len(e.Changes) == 0 {
+			rows = append(rows, row)
+			continue
+		}
+		for _, change := range e.Changes {
+			row.Field = change.Field
+			row.Left = trimText(change.Left, maxFieldLength)
+			row.Right = trimText(change.Right, maxFieldLength)
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+type historyEventKey struct {
+	eventType types.EventType
+	id        string
+}
+
+// historyEventKeys computes the logical identity of every event, events referring to a scheduled or initiated
+// event (activity started/completed, child workflow started/completed, ...) inherit the identity of that event
+func historyEventKeys(events []*types.HistoryEvent) []historyEventKey {
+	keys := make([]historyEventKey, len(events))
+	idByEventID := make(map[int64]string, len(events))
+	for i, e := range events {
+		id := historyEventIdentity(e)
+		if id == "" {
+			if ref, ok := referencedEventID(e); ok {
+				id = idByEventID[ref]
+			}
+		}
+		idByEventID[e.ID] = id
+		keys[i] = historyEventKey{eventType: e.GetEventType(), id: id}
+	}
+	return keys
+}
+
+func historyEventIdentity(e *types.HistoryEvent) string {
+	switch e.GetEventType() {
+	case types.EventTypeActivityTaskScheduled:
+		attr := e.ActivityTaskScheduledEventAttributes
+		return fmt.Sprintf("%s:%s", attr.GetActivityType().GetName(), attr.GetActivityID())
+	case types.EventTypeTimerStarted:
+		return e.TimerStartedEventAttributes.GetTimerID()
+	case types.EventTypeTimerFired:
+		return e.TimerFiredEventAttributes.GetTimerID()
+	case types.EventTypeTimerCanceled:
+		return e.TimerCanceledEventAttributes.GetTimerID()
+	case types.EventTypeCancelTimerFailed:
+		if attr := e.CancelTimerFailedEventAttributes; attr != nil {
+			return attr.TimerID
+		}
+	case types.EventTypeActivityTaskCancelRequested:
+		return e.ActivityTaskCancelRequestedEventAttributes.GetActivityID()
+	case types.EventTypeRequestCancelActivityTaskFailed:
+		if attr := e.RequestCancelActivityTaskFailedEventAttributes; attr != nil {
+			return attr.ActivityID
+		}
+	case types.EventTypeWorkflowExecutionSignaled:
+		return e.WorkflowExecutionSignaledEventAttributes.GetSignalName()
+	case types.EventTypeMarkerRecorded:
+		return e.MarkerRecordedEventAttributes.GetMarkerName()
+	case types.EventTypeStartChildWorkflowExecutionInitiated:
+		attr := e.StartChildWorkflowExecutionInitiatedEventAttributes
+		return fmt.Sprintf("%s:%s", attr.GetWorkflowType().GetName(), attr.GetWorkflowID())
+	case types.EventTypeSignalExternalWorkflowExecutionInitiated:
+		attr := e.SignalExternalWorkflowExecutionInitiatedEventAttributes
+		return fmt.Sprintf("%s:%s", attr.GetSignalName(), attr.GetWorkflowExecution().GetWorkflowID())
+	case types.EventTypeRequestCancelExternalWorkflowExecutionInitiated:
+		return e.RequestCancelExternalWorkflowExecutionInitiatedEventAttributes.GetWorkflowExecution().GetWorkflowID()
+	}
+	return ""
+}
+
+// referencedEventID returns the scheduled or initiated event an event belongs to
+func referencedEventID(e *types.HistoryEvent) (int64, bool) {
+	attr := reflect.ValueOf(getEventAttributes(e))
+	if attr.Kind() != reflect.Ptr || attr.IsNil() {
+		return 0, false
+	}
+	for _, name := range []string{"ScheduledEventID", "InitiatedEventID"} {
+		if f := attr.Elem().FieldByName(name); f.IsValid() && f.Kind() == reflect.Int64 {
+			return f.Int(), true
+		}
+	}
+	return 0, false
+}
+
+type historyEventPair struct {
+	left, right int
+}
+
+// alignHistories matches the events of two histories, identical prefixes and suffixes are matched directly and the
+// part in between is aligned using the longest common subsequence of event keys. Unmatched events get an index of -1
+// on the other side.
+func alignHistories(left, right []historyEventKey) []historyEventPair {
+	prefix := 0
+	for prefix < len(left) && prefix < len(right) && left[prefix] == right[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(left)-prefix && suffix < len(right)-prefix && left[len(left)-1-suffix] == right[len(right)-1-suffix] {
+		suffix++
+	}
+
+	pairs := make([]historyEventPair, 0, len(left)+len(right))
+	for i := 0; i < prefix; i++ {
+		pairs = append(pairs, historyEventPair{left: i, right: i})
+	}
+	pairs = append(pairs, alignMiddle(left[prefix:len(left)-suffix], right[prefix:len(right)-suffix], prefix, prefix)...)
+	for i := suffix; i > 0; i-- {
+		pairs = append(pairs, historyEventPair{left: len(left) - i, right: len(right) - i})
+	}
+	return pairs
+}
+
+func alignMiddle(left, right []historyEventKey, leftOffset, rightOffset int) []historyEventPair {
+	n, m := len(left), len(right)
+	var pairs []historyEventPair
+	if (n+1)*(m+1) > maxHistoryDiffCells {
+		for i := 0; i < n || i < m; i++ {
+			switch {
+			case i < n && i < m && left[i] == right[i]:
+				pairs = append(pairs, historyEventPair{left: leftOffset + i, right: rightOffset + i})
+			case i < n && i < m:
+				pairs = append(pairs, historyEventPair{left: leftOffset + i, right: -1}, historyEventPair{left: -1, right: rightOffset + i})
+			case i < n:
+				pairs = append(pairs, historyEventPair{left: leftOffset + i, right: -1})
+			default:
+				pairs = append(pairs, historyEventPair{left: -1, right: rightOffset + i})
+			}
+		}
+		return pairs
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of left[i:] and right[j:]
+	lcs := make([][]int32, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if left[i] == right[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && left[i] == right[j]:
+			pairs = append(pairs, historyEventPair{left: leftOffset + i, right: rightOffset + j})
+			i++
+			j++
+		case j == m || (i < n && lcs[i+1][j] >= lcs[i][j+1]):
+			pairs = append(pairs, historyEventPair{left: leftOffset + i, right: -1})
+			i++
+		default:
+			pairs = append(pairs, historyEventPair{left: -1, right: rightOffset + j})
+			j++
+		}
+	}
+	return pairs
+}
+
+// diffEventAttributes compares the attributes of two events of the same type field by field
+func diffEventAttributes(left, right *types.HistoryEvent) []historyFieldChange {
+	leftFields, rightFields := map[string]string{}, map[string]string{}
+	flattenEventAttributes("", reflect.ValueOf(getEventAttributes(left)), leftFields)
+	flattenEventAttributes("", reflect.ValueOf(getEventAttributes(right)), rightFields)
+
+	var changes []historyFieldChange
+	for field, l := range leftFields {
+		if r := rightFields[field]; l != r {
+			changes = append(changes, historyFieldChange{Field: field, Left: l, Right: r})
+		}
+	}
+	for field, r := range rightFields {
+		if _, ok := leftFields[field]; !ok {
+			changes = append(changes, historyFieldChange{Field: field, Right: r})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+func flattenEventAttributes(path string, v reflect.Value, fields map[string]string) {
+	if !v.IsValid() {
+		return
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		fields[path] = t.UTC().Format(time.RFC3339Nano)
+		return
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		if s, ok := v.Interface().(fmt.Stringer); ok && v.Elem().Kind() != reflect.Struct {
+			fields[path] = s.String()
+			return
+		}
+		flattenEventAttributes(path, v.Elem(), fields)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			name := v.Type().Field(i).Name
+			if isIgnoredHistoryDiffField(name) {
+				continue
+			}
+			flattenEventAttributes(joinFieldPath(path, name), v.Field(i), fields)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if v.Len() > 0 {
+				fields[path] = string(v.Bytes())
+			}
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			flattenEventAttributes(fmt.Sprintf("%s[%d]", path, i), v.Index(i), fields)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			flattenEventAttributes(joinFieldPath(path, fmt.Sprint(key.Interface())), v.MapIndex(key), fields)
+		}
+	default:
+		if v.IsZero() {
+			return
+		}
+		fields[path] = fmt.Sprint(v.Interface())
+	}
+}
+
+func isIgnoredHistoryDiffField(name string) bool {
+	// event IDs and run IDs are expected to differ between runs, timestamps are reported as timing deltas instead
+	return historyDiffIgnoredFields[name] ||
+		strings.HasSuffix(name, "EventID") ||
+		strings.HasSuffix(name, "RunID") ||
+		strings.HasSuffix(name, "Timestamp")
+}
+
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func historyStartTime(events []*types.HistoryEvent) int64 {
+	if len(events) == 0 {
+		return 0
+	}
+	return events[0].GetTimestamp()
+}
+
+func historyDuration(events []*types.HistoryEvent) time.Duration {
+	if len(events) == 0 {
+		return 0
+	}
+	return time.Duration(events[len(events)-1].GetTimestamp() - events[0].GetTimestamp())
+}
+
+func eventOffset(e *types.HistoryEvent, start int64) time.Duration {
+	return time.Duration(e.GetTimestamp() - start)
+}
+
+func formatTimingDelta(d time.Duration) string {
+	if d > 0 {
+		return "+" + d.String()
+	}
+	return d.String()
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/yarpc"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+)
+
+type testHistoryBuilder struct {
+	start  time.Time
+	events []*types.HistoryEvent
+}
+
+func (b *testHistoryBuilder) add(offset time.Duration, eventType types.EventType, set func(*types.HistoryEvent)) *testHistoryBuilder {
+	e := &types.HistoryEvent{
+		ID:        int64(len(b.events) + 1),
+		Timestamp: common.Int64Ptr(b.start.Add(offset).UnixNano()),
+		EventType: eventType.Ptr(),
+	}
+	if set != nil {
+		set(e)
+	}
+	b.events = append(b.events, e)
+	return b
+}
+
+func (b *testHistoryBuilder) started(input string) *testHistoryBuilder {
+	return b.add(0, types.EventTypeWorkflowExecutionStarted, func(e *types.HistoryEvent) {
+		e.WorkflowExecutionStartedEventAttributes = &types.WorkflowExecutionStartedEventAttributes{
+			WorkflowType: &types.WorkflowType{Name: "OrderWorkflow"},
+			Input:        []byte(input),
+			Identity:     "worker-" + input,
+		}
+	})
+}
+
+func (b *testHistoryBuilder) decision(offset time.Duration) *testHistoryBuilder {
+	scheduled := int64(len(b.events) + 1)
+	return b.add(offset, types.EventTypeDecisionTaskScheduled, func(e *types.HistoryEvent) {
+		e.DecisionTaskScheduledEventAttributes = &types.DecisionTaskScheduledEventAttributes{}
+	}).add(offset, types.EventTypeDecisionTaskStarted, func(e *types.HistoryEvent) {
+		e.DecisionTaskStartedEventAttributes = &types.DecisionTaskStartedEventAttributes{ScheduledEventID: scheduled}
+	}).add(offset, types.EventTypeDecisionTaskCompleted, func(e *types.HistoryEvent) {
+		e.DecisionTaskCompletedEventAttributes = &types.DecisionTaskCompletedEventAttributes{ScheduledEventID: scheduled, StartedEventID: scheduled + 1}
+	})
+}
+
+func (b *testHistoryBuilder) activity(offset time.Duration, activityType, input string) *testHistoryBuilder {
+	return b.add(offset, types.EventTypeActivityTaskScheduled, func(e *types.HistoryEvent) {
+		e.ActivityTaskScheduledEventAttributes = &types.ActivityTaskScheduledEventAttributes{
+			ActivityID:   activityType,
+			ActivityType: &types.ActivityType{Name: activityType},
+			Input:        []byte(input),
+		}
+	})
+}
+
+func (b *testHistoryBuilder) activityCompleted(offset time.Duration, scheduledEventID int64, result string) *testHistoryBuilder {
+	return b.add(offset, types.EventTypeActivityTaskCompleted, func(e *types.HistoryEvent) {
+		e.ActivityTaskCompletedEventAttributes = &types.ActivityTaskCompletedEventAttributes{ScheduledEventID: scheduledEventID, Result: []byte(result)}
+	})
+}
+
+func (b *testHistoryBuilder) signaled(offset time.Duration, name string) *testHistoryBuilder {
+	return b.add(offset, types.EventTypeWorkflowExecutionSignaled, func(e *types.HistoryEvent) {
+		e.WorkflowExecutionSignaledEventAttributes = &types.WorkflowExecutionSignaledEventAttributes{SignalName: name}
+	})
+}
+
+// baseAndResetHistories returns a run and a run reset after its first decision that charges a different amount,
+// gets a signal the base run never received and completes slower
+func baseAndResetHistories() ([]*types.HistoryEvent, []*types.HistoryEvent) {
+	start := time.Unix(1700000000, 0)
+	base := (&testHistoryBuilder{start: start}).
+		started("order-1").
+		decision(time.Second).
+		activity(time.Second, "Charge", "10").
+		activityCompleted(2*time.Second, 5, "charged").
+		decision(3*time.Second).
+		activity(3*time.Second, "Ship", "order-1").
+		activityCompleted(4*time.Second, 10, "shipped")
+
+	reset := (&testHistoryBuilder{start: start.Add(time.Hour)}).
+		started("order-1").
+		decision(time.Second).
+		activity(time.Second, "Charge", "12").
+		signaled(2*time.Second, "discount").
+		activityCompleted(5*time.Second, 5, "charged").
+		decision(6*time.Second).
+		activity(6*time.Second, "Ship", "order-1").
+		activityCompleted(7*time.Second, 11, "shipped")
+	return base.events, reset.events
+}
+
+func TestDiffHistories(t *testing.T) {
+	base, reset := baseAndResetHistories()
+	diff := diffHistories(base, reset)
+
+	assert.Equal(t, historyDiffRun{EventCount: 11, Duration: "4s"}, diff.Left)
+	assert.Equal(t, historyDiffRun{EventCount: 12, Duration: "7s"}, diff.Right)
+	assert.Equal(t, 10, diff.Same)
+	assert.Equal(t, 1, diff.Changed)
+	assert.Equal(t, 0, diff.LeftOnly)
+	assert.Equal(t, 1, diff.RightOnly)
+	assert.Equal(t, "+3s (left event 6, right event 7)", diff.MaxTimingDelta)
+	require.Len(t, diff.Events, 12)
+
+	require.NotNil(t, diff.FirstDivergence)
+	assert.Equal(t, historyEventDiff{
+		Status:       historyDiffChanged,
+		EventType:    types.EventTypeActivityTaskScheduled.String(),
+		Key:          "Charge:Charge",
+		LeftEventID:  5,
+		RightEventID: 5,
+		LeftOffset:   "1s",
+		RightOffset:  "1s",
+		TimingDelta:  "0s",
+		Changes:      []historyFieldChange{{Field: "Input", Left: "10", Right: "12"}},
+	}, *diff.FirstDivergence)
+
+	signal := diff.Events[5]
+	assert.Equal(t, historyDiffRightOnly, signal.Status)
+	assert.Equal(t, "discount", signal.Key)
+	assert.Equal(t, int64(6), signal.RightEventID)
+
+	completed := diff.Events[6]
+	assert.Equal(t, historyDiffSame, completed.Status)
+	assert.Equal(t, "Charge:Charge", completed.Key)
+	assert.Equal(t, int64(6), completed.LeftEventID)
+	assert.Equal(t, int64(7), completed.RightEventID)
+	assert.Equal(t, "+3s", completed.TimingDelta)
+}
+
+func TestDiffHistories_Identical(t *testing.T) {
+	base, _ := baseAndResetHistories()
+	diff := diffHistories(base, base)
+	assert.Nil(t, diff.FirstDivergence)
+	assert.Equal(t, len(base), diff.Same)
+	assert.Empty(t, divergingEvents(diff.Events))
+	assert.Empty(t, diff.MaxTimingDelta)
+}
+
+func TestAlignHistories(t *testing.T) {
+	key := func(ids ...string) []historyEventKey {
+		keys := make([]historyEventKey, len(ids))
+		for i, id := range ids {
+			keys[i] = historyEventKey{eventType: types.EventTypeMarkerRecorded, id: id}
+		}
+		return keys
+	}
+	tests := map[string]struct {
+		left, right []historyEventKey
+		want        []historyEventPair
+	}{
+		"identical": {
+			left:  key("a", "b"),
+			right: key("a", "b"),
+			want:  []historyEventPair{{0, 0}, {1, 1}},
+		},
+		"insertion and removal": {
+			left:  key("a", "b", "c", "e"),
+			right: key("a", "c", "d", "e"),
+			want:  []historyEventPair{{0, 0}, {1, -1}, {2, 1}, {-1, 2}, {3, 3}},
+		},
+		"empty side": {
+			left:  key("a"),
+			right: nil,
+			want:  []historyEventPair{{0, -1}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, alignHistories(tc.left, tc.right))
+		})
+	}
+}
+
+func TestDiffHistory(t *testing.T) {
+	base, reset := baseAndResetHistories()
+	historyByRunID := map[string][]*types.HistoryEvent{"base-run": base, "": reset}
+
+	newTestData := func(t *testing.T) *cliTestData {
+		td := newCLITestData(t)
+		td.mockFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, request *types.GetWorkflowExecutionHistoryRequest, _ ...yarpc.CallOption) (*types.GetWorkflowExecutionHistoryResponse, error) {
+				assert.Equal(t, "wid", request.Execution.WorkflowID)
+				return &types.GetWorkflowExecutionHistoryResponse{History: &types.History{Events: historyByRunID[request.Execution.RunID]}}, nil
+			}).Times(2)
+		return td
+	}
+
+	t.Run("table", func(t *testing.T) {
+		td := newTestData(t)
+		require.NoError(t, td.app.Run([]string{"", "--do", "test-domain", "workflow", "diff", "-w", "wid", "-r", "base-run"}))
+		output := td.consoleOutput()
+		assert.Contains(t, output, "Same: 10, changed: 1, left only: 0, right only: 1")
+		assert.Contains(t, output, "First divergence: left event 5, right event 5 (ActivityTaskScheduled)")
+		assert.Contains(t, output, "discount")
+		assert.NotContains(t, output, "DecisionTaskStarted")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		td := newTestData(t)
+		require.NoError(t, td.app.Run([]string{"", "--do", "test-domain", "workflow", "diff", "-w", "wid", "-r", "base-run", "--all", "--format", "json"}))
+		var diff historyDiff
+		require.NoError(t, json.Unmarshal([]byte(td.consoleOutput()), &diff))
+		assert.Equal(t, "base-run", diff.Left.RunID)
+		assert.Equal(t, "wid", diff.Right.WorkflowID)
+		assert.Len(t, diff.Events, 12)
+		assert.Equal(t, "Ship:Ship", diff.Events[11].Key)
+	})
+
+	t.Run("same execution", func(t *testing.T) {
+		td := newCLITestData(t)
+		err := td.app.Run([]string{"", "--do", "test-domain", "workflow", "diff", "-w", "wid", "-r", "base-run", "--r2", "base-run"})
+		assert.ErrorContains(t, err, "same execution")
+	})
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/urfave/cli/v2"
+
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/batcher"
+	"github.com/uber/cadence/tools/common/commoncli"
+)
+
+const (
+	replayBundleTimeout      = 24 * time.Hour
+	replayBundleManifestFile = "manifest.json"
+)
+
+// CreateReplayBundle starts a job sampling closed workflow histories matching a query into a replay bundle
+func CreateReplayBundle(c *cli.Context) error {
+	domain, err := getRequiredOption(c, FlagDomain)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	query, err := getRequiredOption(c, FlagListQuery)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	sampleSize := c.Int(FlagSampleSize)
+	if sampleSize > batcher.MaxReplayBundleSampleSize {
+		return commoncli.Problem(fmt.Sprintf("--%s must not exceed %d", FlagSampleSize, batcher.MaxReplayBundleSampleSize), nil)
+	}
+	svcClient, err := getDeps(c).ServerFrontendClient(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context:", err)
+	}
+
+	input, err := json.Marshal(batcher.ReplayBundleParams{
+		DomainName: domain,
+		Query:      query,
+		SampleSize: sampleSize,
+		ScanLimit:  c.Int(FlagScanLimit),
+	})
+	if err != nil {
+		return commoncli.Problem("Failed to encode replay bundle parameters", err)
+	}
+	workflowID := "replay-bundle-" + uuid.New()
+	_, err = svcClient.StartWorkflowExecution(ctx, &types.StartWorkflowExecutionRequest{
+		Domain:                              constants.BatcherLocalDomainName,
+		RequestID:                           uuid.New(),
+		WorkflowID:                          workflowID,
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(int32(replayBundleTimeout.Seconds())),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(int32(defaultDecisionTimeoutInSeconds)),
+		TaskList:                            &types.TaskList{Name: batcher.BatcherTaskListName},
+		WorkflowType:                        &types.WorkflowType{Name: batcher.ReplayBundleWFTypeName},
+		Input:                               input,
+	})
+	if err != nil {
+		return commoncli.Problem("Failed to start replay bundle job", err)
+	}
+	prettyPrintJSONObject(getDeps(c).Output(), map[string]interface{}{
+		"msg":   "replay bundle job is started, fetch it with `cadence workflow replay-bundle fetch` once completed",
+		"jobID": workflowID,
+	})
+	return nil
+}
+
+// FetchReplayBundle copies the histories of a completed replay bundle job into a directory, one JSON file per history
+// in the format the client replayers read, along with a manifest describing them.
+// The manifest is the result of the job and the histories are read from the sampled domain, both through the
+// frontend. Fetching is therefore bounded by the retention of the sampled domain, workflows which are no longer
+// retained are skipped and left out of the written manifest.
+func FetchReplayBundle(c *cli.Context) error {
+	jobID, err := getRequiredOption(c, FlagJobID)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	outputDirectory, err := getRequiredOption(c, FlagOutputDirectory)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	svcClient, err := getDeps(c).ServerFrontendClient(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context:", err)
+	}
+	job, err := svcClient.DescribeWorkflowExecution(ctx, &types.DescribeWorkflowExecutionRequest{
+		Domain:    constants.BatcherLocalDomainName,
+		Execution: &types.WorkflowExecution{WorkflowID: jobID},
+	})
+	if err != nil {
+		return commoncli.Problem("Failed to describe replay bundle job", err)
+	}
+	switch status := job.GetWorkflowExecutionInfo().CloseStatus; {
+	case status == nil:
+		return commoncli.Problem(fmt.Sprintf("Replay bundle job %s is still running, try again once it completes.", jobID), nil)
+	case *status != types.WorkflowExecutionCloseStatusCompleted:
+		return commoncli.Problem(fmt.Sprintf("Replay bundle job %s did not complete, it closed as %v.", jobID, status), nil)
+	}
+
+	manifest, err := getReplayBundleManifest(ctx, svcClient, job.GetWorkflowExecutionInfo().GetExecution())
+	if err != nil {
+		return commoncli.Problem("Failed to read replay bundle manifest", err)
+	}
+
+	if err := os.MkdirAll(outputDirectory, 0755); err != nil {
+		return commoncli.Problem("Failed to create output directory", err)
+	}
+	fetched := make([]batcher.ReplayBundleEntry, 0, len(manifest.Histories))
+	for _, entry := range manifest.Histories {
+		events, err := getReplayBundleHistory(ctx, svcClient, manifest.DomainName, entry)
+		var notExists *types.EntityNotExistsError
+		if errors.As(err, &notExists) {
+			fmt.Fprintf(getDeps(c).Output(), "Skipping workflow %s, run %s which is no longer retained\n", entry.WorkflowID, entry.RunID)
+			continue
+		}
+		if err != nil {
+			return commoncli.Problem(fmt.Sprintf("Failed to read history of workflow %s, run %s", entry.WorkflowID, entry.RunID), err)
+		}
+		// same encoding as `cadence workflow show --output_filename`
+		body, err := json.Marshal(events)
+		if err != nil {
+			return commoncli.Problem("Failed to encode replay bundle history", err)
+		}
+		if err := os.WriteFile(filepath.Join(outputDirectory, replayBundleHistoryFile(entry)), body, 0644); err != nil {
+			return commoncli.Problem("Failed to write replay bundle history", err)
+		}
+		entry.EventCount = len(events)
+		entry.SizeBytes = len(body)
+		fetched = append(fetched, entry)
+	}
+	manifest.Histories = fetched
+	body, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return commoncli.Problem("Failed to encode replay bundle manifest", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDirectory, replayBundleManifestFile), body, 0644); err != nil {
+		return commoncli.Problem("Failed to write replay bundle manifest", err)
+	}
+	fmt.Fprintf(getDeps(c).Output(), "Fetched %d histories of replay bundle %s into %s\n", len(manifest.Histories), jobID, outputDirectory)
+	return nil
+}
+
+// getReplayBundleManifest returns the manifest a completed replay bundle job returned as its result
+func getReplayBundleManifest(ctx context.Context, svcClient frontend.Client, execution *types.WorkflowExecution) (*batcher.ReplayBundleManifest, error) {
+	resp, err := svcClient.GetWorkflowExecutionHistory(ctx, &types.GetWorkflowExecutionHistoryRequest{
+		Domain:                 constants.BatcherLocalDomainName,
+		Execution:              execution,
+		HistoryEventFilterType: types.HistoryEventFilterTypeCloseEvent.Ptr(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range resp.GetHistory().GetEvents() {
+		if attributes := event.WorkflowExecutionCompletedEventAttributes; attributes != nil {
+			var manifest batcher.ReplayBundleManifest
+			if err := json.Unmarshal(attributes.Result, &manifest); err != nil {
+				return nil, err
+			}
+			return &manifest, nil
+		}
+	}
+	return nil, fmt.Errorf("replay bundle job %s has no completion event", execution.GetWorkflowID())
+}
+
+// getReplayBundleHistory returns all events of a sampled workflow
+func getReplayBundleHistory(ctx context.Context, svcClient frontend.Client, domain string, entry batcher.ReplayBundleEntry) ([]*types.HistoryEvent, error) {
+	request := &types.GetWorkflowExecutionHistoryRequest{
+		Domain:    domain,
+		Execution: &types.WorkflowExecution{WorkflowID: entry.WorkflowID, RunID: entry.RunID},
+	}
+	var events []*types.HistoryEvent
+	for {
+		resp, err := svcClient.GetWorkflowExecutionHistory(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, resp.GetHistory().GetEvents()...)
+		if len(resp.NextPageToken) == 0 {
+			return events, nil
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+}
+
+func replayBundleHistoryFile(entry batcher.ReplayBundleEntry) string {
+	return fmt.Sprintf("history_%d.json", entry.Index)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/yarpc"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/batcher"
+)
+
+func TestCreateReplayBundle(t *testing.T) {
+	td := newCLITestData(t)
+	td.mockFrontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *types.StartWorkflowExecutionRequest, _ ...yarpc.CallOption) (*types.StartWorkflowExecutionResponse, error) {
+			assert.Equal(t, constants.BatcherLocalDomainName, request.Domain)
+			assert.Equal(t, batcher.BatcherTaskListName, request.TaskList.Name)
+			assert.Equal(t, batcher.ReplayBundleWFTypeName, request.WorkflowType.Name)
+			var params batcher.ReplayBundleParams
+			assert.NoError(t, json.Unmarshal(request.Input, &params))
+			assert.Equal(t, batcher.ReplayBundleParams{
+				DomainName: "test-domain",
+				Query:      "WorkflowType = 'wf'",
+				SampleSize: 50,
+				ScanLimit:  batcher.DefaultReplayBundleScanLimit,
+			}, params)
+			return &types.StartWorkflowExecutionResponse{RunID: "run-id"}, nil
+		})
+
+	require.NoError(t, td.app.Run([]string{"", "--do", "test-domain", "workflow", "replay-bundle", "create", "-q", "WorkflowType = 'wf'", "--sample_size", "50"}))
+	assert.Contains(t, td.consoleOutput(), `"jobID": "replay-bundle-`)
+}
+
+func TestCreateReplayBundle_InvalidFlags(t *testing.T) {
+	td := newCLITestData(t)
+	assert.ErrorContains(t, td.app.Run([]string{"", "--do", "test-domain", "workflow", "replay-bundle", "create"}), "option query is required")
+	assert.ErrorContains(t, td.app.Run([]string{"", "--do", "test-domain", "workflow", "replay-bundle", "create", "-q", "q", "--sample_size", "5000"}), "must not exceed")
+}
+
+func TestFetchReplayBundle(t *testing.T) {
+	expectJob := func(td *cliTestData, status *types.WorkflowExecutionCloseStatus) {
+		td.mockFrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, request *types.DescribeWorkflowExecutionRequest, _ ...yarpc.CallOption) (*types.DescribeWorkflowExecutionResponse, error) {
+				assert.Equal(t, constants.BatcherLocalDomainName, request.Domain)
+				assert.Equal(t, "job-id", request.Execution.WorkflowID)
+				return &types.DescribeWorkflowExecutionResponse{WorkflowExecutionInfo: &types.WorkflowExecutionInfo{
+					Execution:   &types.WorkflowExecution{WorkflowID: "job-id", RunID: "job-run-id"},
+					CloseStatus: status,
+				}}, nil
+			})
+	}
+	expectResult := func(td *cliTestData, closeEvent *types.HistoryEvent) {
+		td.mockFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, request *types.GetWorkflowExecutionHistoryRequest, _ ...yarpc.CallOption) (*types.GetWorkflowExecutionHistoryResponse, error) {
+				assert.Equal(t, constants.BatcherLocalDomainName, request.Domain)
+				assert.Equal(t, "job-run-id", request.Execution.RunID)
+				assert.Equal(t, types.HistoryEventFilterTypeCloseEvent, request.GetHistoryEventFilterType())
+				return &types.GetWorkflowExecutionHistoryResponse{History: &types.History{Events: []*types.HistoryEvent{closeEvent}}}, nil
+			})
+	}
+	manifest := batcher.ReplayBundleManifest{
+		JobID:      "job-id",
+		DomainName: "test-domain",
+		Histories:  []batcher.ReplayBundleEntry{{Index: 0, WorkflowID: "wid0", RunID: "rid0"}, {Index: 1, WorkflowID: "wid1", RunID: "rid1"}},
+	}
+	manifestBody, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	completed := &types.HistoryEvent{
+		EventType: types.EventTypeWorkflowExecutionCompleted.Ptr(),
+		WorkflowExecutionCompletedEventAttributes: &types.WorkflowExecutionCompletedEventAttributes{Result: manifestBody},
+	}
+
+	t.Run("fetch through the frontend", func(t *testing.T) {
+		td := newCLITestData(t)
+		dir := filepath.Join(t.TempDir(), "bundle")
+		expectJob(td, types.WorkflowExecutionCloseStatusCompleted.Ptr())
+		expectResult(td, completed)
+		td.mockFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, request *types.GetWorkflowExecutionHistoryRequest, _ ...yarpc.CallOption) (*types.GetWorkflowExecutionHistoryResponse, error) {
+				assert.Equal(t, "test-domain", request.Domain)
+				id := int64(1)
+				if request.Execution.WorkflowID == "wid1" {
+					id = 2
+				}
+				return &types.GetWorkflowExecutionHistoryResponse{History: &types.History{Events: []*types.HistoryEvent{{ID: id}}}}, nil
+			}).Times(2)
+
+		require.NoError(t, td.app.Run([]string{"", "workflow", "replay-bundle", "fetch", "--job_id", "job-id", "--output_directory", dir}))
+		assert.Equal(t, "Fetched 2 histories of replay bundle job-id into "+dir+"\n", td.consoleOutput())
+
+		history, err := os.ReadFile(filepath.Join(dir, "history_1.json"))
+		require.NoError(t, err)
+		serializer := &JSONHistorySerializer{}
+		replayable, err := serializer.Deserialize(history)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), replayable.Events[0].ID)
+
+		firstHistory, err := os.ReadFile(filepath.Join(dir, "history_0.json"))
+		require.NoError(t, err)
+		manifestFile, err := os.ReadFile(filepath.Join(dir, replayBundleManifestFile))
+		require.NoError(t, err)
+		var written batcher.ReplayBundleManifest
+		require.NoError(t, json.Unmarshal(manifestFile, &written))
+		assert.Equal(t, batcher.ReplayBundleManifest{
+			JobID:      "job-id",
+			DomainName: "test-domain",
+			Histories: []batcher.ReplayBundleEntry{
+				{Index: 0, WorkflowID: "wid0", RunID: "rid0", EventCount: 1, SizeBytes: len(firstHistory)},
+				{Index: 1, WorkflowID: "wid1", RunID: "rid1", EventCount: 1, SizeBytes: len(history)},
+			},
+		}, written)
+	})
+
+	t.Run("sampled workflow past retention", func(t *testing.T) {
+		td := newCLITestData(t)
+		dir := t.TempDir()
+		expectJob(td, types.WorkflowExecutionCloseStatusCompleted.Ptr())
+		expectResult(td, completed)
+		td.mockFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, request *types.GetWorkflowExecutionHistoryRequest, _ ...yarpc.CallOption) (*types.GetWorkflowExecutionHistoryResponse, error) {
+				if request.Execution.WorkflowID == "wid0" {
+					return nil, &types.EntityNotExistsError{Message: "workflow not found"}
+				}
+				return &types.GetWorkflowExecutionHistoryResponse{History: &types.History{Events: []*types.HistoryEvent{{ID: 1}}}}, nil
+			}).Times(2)
+
+		require.NoError(t, td.app.Run([]string{"", "workflow", "replay-bundle", "fetch", "--job_id", "job-id", "--output_directory", dir}))
+		assert.Equal(t, "Skipping workflow wid0, run rid0 which is no longer retained\n"+
+			"Fetched 1 histories of replay bundle job-id into "+dir+"\n", td.consoleOutput())
+		_, err := os.Stat(filepath.Join(dir, "history_0.json"))
+		assert.True(t, os.IsNotExist(err))
+
+		manifestFile, err := os.ReadFile(filepath.Join(dir, replayBundleManifestFile))
+		require.NoError(t, err)
+		var written batcher.ReplayBundleManifest
+		require.NoError(t, json.Unmarshal(manifestFile, &written))
+		require.Len(t, written.Histories, 1)
+		assert.Equal(t, "wid1", written.Histories[0].WorkflowID)
+	})
+
+	t.Run("history read error", func(t *testing.T) {
+		td := newCLITestData(t)
+		expectJob(td, types.WorkflowExecutionCloseStatusCompleted.Ptr())
+		expectResult(td, completed)
+		td.mockFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(nil, &types.InternalServiceError{Message: "unavailable"})
+		err := td.app.Run([]string{"", "workflow", "replay-bundle", "fetch", "--job_id", "job-id", "--output_directory", t.TempDir()})
+		assert.ErrorContains(t, err, "Failed to read history of workflow wid0, run rid0")
+	})
+
+	t.Run("job without a result", func(t *testing.T) {
+		td := newCLITestData(t)
+		expectJob(td, types.WorkflowExecutionCloseStatusCompleted.Ptr())
+		expectResult(td, &types.HistoryEvent{EventType: types.EventTypeWorkflowExecutionTerminated.Ptr()})
+		err := td.app.Run([]string{"", "workflow", "replay-bundle", "fetch", "--job_id", "job-id", "--output_directory", t.TempDir()})
+		assert.ErrorContains(t, err, "replay bundle job job-id has no completion event")
+	})
+
+	t.Run("job still running", func(t *testing.T) {
+		td := newCLITestData(t)
+		expectJob(td, nil)
+		err := td.app.Run([]string{"", "workflow", "replay-bundle", "fetch", "--job_id", "job-id", "--output_directory", t.TempDir()})
+		assert.ErrorContains(t, err, "still running")
+	})
+
+	t.Run("job failed", func(t *testing.T) {
+		td := newCLITestData(t)
+		expectJob(td, types.WorkflowExecutionCloseStatusFailed.Ptr())
+		err := td.app.Run([]string{"", "workflow", "replay-bundle", "fetch", "--job_id", "job-id", "--output_directory", t.TempDir()})
+		assert.ErrorContains(t, err, "closed as FAILED")
+	})
+
+	t.Run("missing output directory", func(t *testing.T) {
+		td := newCLITestData(t)
+		err := td.app.Run([]string{"", "workflow", "replay-bundle", "fetch", "--job_id", "job-id"})
+		assert.ErrorContains(t, err, "option output_directory is required")
+	})
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	apiv1 "github.com/uber/cadence-idl/go/proto/api/v1"
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/transport/grpc"
+
+	"github.com/uber/cadence/client/frontend"
+	grpcClient "github.com/uber/cadence/client/wrappers/grpc"
+	"github.com/uber/cadence/common"
+	cc "github.com/uber/cadence/common/client"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	mcpClientName          = "cadence-mcp"
+	cadenceFrontendService = "cadence-frontend"
+)
+
+// clientProvider returns clients talking to the frontend at the given gRPC endpoint,
+// an empty endpoint means the frontend the server was started with
+type clientProvider interface {
+	Frontend(endpoint string) (frontend.Client, error)
+}
+
+// grpcClients lazily builds one dispatcher per endpoint and keeps it for the lifetime of the server
+type grpcClients struct {
+	defaultEndpoint string
+
+	sync.Mutex
+	dispatchers map[string]*yarpc.Dispatcher
+}
+
+func newGRPCClients(defaultEndpoint string) *grpcClients {
+	return &grpcClients{
+		defaultEndpoint: defaultEndpoint,
+		dispatchers:     make(map[string]*yarpc.Dispatcher),
+	}
+}
+
+func (c *grpcClients) Frontend(endpoint string) (frontend.Client, error) {
+	clientConfig, err := c.clientConfig(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return grpcClient.NewFrontendClient(
+		apiv1.NewDomainAPIYARPCClient(clientConfig),
+		apiv1.NewWorkflowAPIYARPCClient(clientConfig),
+		apiv1.NewWorkerAPIYARPCClient(clientConfig),
+		apiv1.NewVisibilityAPIYARPCClient(clientConfig),
+		apiv1.NewScheduleAPIYARPCClient(clientConfig),
+	), nil
+}
+
+func (c *grpcClients) clientConfig(endpoint string) (transport.ClientConfig, error) {
+	if endpoint == "" {
+		endpoint = c.defaultEndpoint
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if dispatcher, ok := c.dispatchers[endpoint]; ok {
+		return dispatcher.ClientConfig(cadenceFrontendService), nil
+	}
+	dispatcher := yarpc.NewDispatcher(yarpc.Config{
+		Name: mcpClientName,
+		Outbounds: yarpc.Outbounds{
+			cadenceFrontendService: {Unary: grpc.NewTransport().NewSingleOutbound(endpoint)},
+		},
+		OutboundMiddleware: yarpc.OutboundMiddleware{
+			Unary: &headersMiddleware{},
+		},
+	})
+	if err := dispatcher.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start dispatcher for %s: %w", endpoint, err)
+	}
+	c.dispatchers[endpoint] = dispatcher
+	return dispatcher.ClientConfig(cadenceFrontendService), nil
+}
+
+func (c *grpcClients) Stop() {
+	c.Lock()
+	defer c.Unlock()
+
+	for endpoint, dispatcher := range c.dispatchers {
+		if err := dispatcher.Stop(); err != nil {
+			debugLog("Failed to stop dispatcher for %s: %v", endpoint, err)
+		}
+	}
+}
+
+// headersMiddleware identifies requests the same way the CLI does, the server applies the same version checks to both
+type headersMiddleware struct{}
+
+func (m *headersMiddleware) Call(ctx context.Context, request *transport.Request, out transport.UnaryOutbound) (*transport.Response, error) {
+	request.Headers = request.Headers.
+		With(common.ClientImplHeaderName, cc.CLI).
+		With(common.FeatureVersionHeaderName, cc.SupportedCLIVersion).
+		With(common.ClientFeatureFlagsHeaderName, cc.FeatureFlagsHeader(cc.DefaultCLIFeatureFlags)).
+		With(common.CallerTypeHeaderName, types.CallerTypeCLI.String())
+	return out.Call(ctx, request)
+}
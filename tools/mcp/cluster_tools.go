@@ -0,0 +1,536 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/diagnostics"
+)
+
+const (
+	defaultRPCTimeout          = 30 * time.Second
+	defaultMaxHistoryEvents    = 10000
+	defaultFailedWorkflows     = 20
+	defaultDiagnosticsWait     = 60 * time.Second
+	diagnosticsPollInterval    = 2 * time.Second
+	stalePollerThreshold       = time.Minute
+	historyPageSize            = 1000
+	maxListWorkflowsPageSize   = 100
+	failedWorkflowsQueryFilter = `CloseStatus = "FAILED"`
+)
+
+// inspector implements the tools which inspect a live cluster through its frontend
+type inspector struct {
+	clients clientProvider
+}
+
+func (i *inspector) describeWorkflowHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	domain, execution, err := executionArgs(request)
+	if err != nil {
+		return nil, err
+	}
+	client, err := i.clients.Frontend(optionalString(request, "grpc_endpoint"))
+	if err != nil {
+		return mcp.NewToolResultError("Error creating frontend client: " + err.Error()), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultRPCTimeout)
+	defer cancel()
+
+	resp, err := client.DescribeWorkflowExecution(ctx, &types.DescribeWorkflowExecutionRequest{
+		Domain:    domain,
+		Execution: execution,
+	})
+	if err != nil {
+		return mcp.NewToolResultError("Error describing workflow: " + err.Error()), nil
+	}
+	return jsonResult(newWorkflowDescription(resp))
+}
+
+func (i *inspector) workflowHistoryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	domain, execution, err := executionArgs(request)
+	if err != nil {
+		return nil, err
+	}
+	maxEvents := optionalInt(request, "max_events", defaultMaxHistoryEvents)
+	client, err := i.clients.Frontend(optionalString(request, "grpc_endpoint"))
+	if err != nil {
+		return mcp.NewToolResultError("Error creating frontend client: " + err.Error()), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultRPCTimeout)
+	defer cancel()
+
+	var (
+		events        []*types.HistoryEvent
+		nextPageToken []byte
+	)
+	for {
+		resp, err := client.GetWorkflowExecutionHistory(ctx, &types.GetWorkflowExecutionHistoryRequest{
+			Domain:          domain,
+			Execution:       execution,
+			MaximumPageSize: historyPageSize,
+			NextPageToken:   nextPageToken,
+		})
+		if err != nil {
+			return mcp.NewToolResultError("Error fetching workflow history: " + err.Error()), nil
+		}
+		events = append(events, resp.GetHistory().GetEvents()...)
+		nextPageToken = resp.NextPageToken
+		if len(nextPageToken) == 0 || len(events) >= maxEvents {
+			break
+		}
+	}
+	truncated := len(nextPageToken) != 0
+	if len(events) > maxEvents {
+		events, truncated = events[:maxEvents], true
+	}
+	return jsonResult(summarizeHistory(events, truncated))
+}
+
+func (i *inspector) diagnoseWorkflowHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	domain, execution, err := executionArgs(request)
+	if err != nil {
+		return nil, err
+	}
+	wait := time.Duration(optionalInt(request, "wait_seconds", int(defaultDiagnosticsWait/time.Second))) * time.Second
+	client, err := i.clients.Frontend(optionalString(request, "grpc_endpoint"))
+	if err != nil {
+		return mcp.NewToolResultError("Error creating frontend client: " + err.Error()), nil
+	}
+
+	rpcCtx, cancel := context.WithTimeout(ctx, defaultRPCTimeout)
+	defer cancel()
+	if execution.RunID == "" {
+		// diagnostics are keyed by run ID, so resolve the current run first
+		resp, err := client.DescribeWorkflowExecution(rpcCtx, &types.DescribeWorkflowExecutionRequest{Domain: domain, Execution: execution})
+		if err != nil {
+			return mcp.NewToolResultError("Error describing workflow: " + err.Error()), nil
+		}
+		execution = resp.GetWorkflowExecutionInfo().GetExecution()
+		if execution == nil {
+			return mcp.NewToolResultError("Error describing workflow: no execution returned"), nil
+		}
+	}
+	resp, err := client.DiagnoseWorkflowExecution(rpcCtx, &types.DiagnoseWorkflowExecutionRequest{
+		Domain:            domain,
+		WorkflowExecution: execution,
+		Identity:          mcpClientName,
+	})
+	if err != nil {
+		return mcp.NewToolResultError("Error starting diagnostics: " + err.Error()), nil
+	}
+	diagnosticsExecution := resp.GetDiagnosticWorkflowExecution()
+
+	waitCtx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+	for {
+		describeResp, err := client.DescribeWorkflowExecution(waitCtx, &types.DescribeWorkflowExecutionRequest{
+			Domain:    resp.GetDomain(),
+			Execution: diagnosticsExecution,
+		})
+		if err == nil && describeResp.GetWorkflowExecutionInfo().CloseStatus != nil {
+			break
+		}
+		select {
+		case <-waitCtx.Done():
+			return mcp.NewToolResultText(fmt.Sprintf(
+				"Diagnostics are still running in domain %s, workflow ID %s, run ID %s. Call this tool again later to fetch the report.",
+				resp.GetDomain(), diagnosticsExecution.GetWorkflowID(), diagnosticsExecution.GetRunID(),
+			)), nil
+		case <-time.After(diagnosticsPollInterval):
+		}
+	}
+
+	queryResp, err := client.QueryWorkflow(rpcCtx, &types.QueryWorkflowRequest{
+		Domain:    resp.GetDomain(),
+		Execution: &types.WorkflowExecution{WorkflowID: diagnosticsExecution.GetWorkflowID()},
+		Query:     &types.WorkflowQuery{QueryType: diagnostics.QueryDiagnosticsReportMarkdown},
+	})
+	if err != nil {
+		return mcp.NewToolResultError("Error fetching diagnostics report: " + err.Error()), nil
+	}
+	var report string
+	if err := json.Unmarshal(queryResp.GetQueryResult(), &report); err != nil {
+		return mcp.NewToolResultError("Error decoding diagnostics report: " + err.Error()), nil
+	}
+	return mcp.NewToolResultText(report), nil
+}
+
+func (i *inspector) describeTaskListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	domain, err := requiredString(request, "domain")
+	if err != nil {
+		return nil, err
+	}
+	taskList, err := requiredString(request, "task_list")
+	if err != nil {
+		return nil, err
+	}
+	var taskListTypes []types.TaskListType
+	switch taskListType := strings.ToLower(optionalString(request, "task_list_type")); taskListType {
+	case "", "both":
+		taskListTypes = []types.TaskListType{types.TaskListTypeDecision, types.TaskListTypeActivity}
+	case "decision":
+		taskListTypes = []types.TaskListType{types.TaskListTypeDecision}
+	case "activity":
+		taskListTypes = []types.TaskListType{types.TaskListTypeActivity}
+	default:
+		return nil, fmt.Errorf("task_list_type must be decision, activity or both, got %q", taskListType)
+	}
+	client, err := i.clients.Frontend(optionalString(request, "grpc_endpoint"))
+	if err != nil {
+		return mcp.NewToolResultError("Error creating frontend client: " + err.Error()), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultRPCTimeout)
+	defer cancel()
+
+	now := time.Now()
+	var descriptions []*taskListDescription
+	for _, taskListType := range taskListTypes {
+		resp, err := client.DescribeTaskList(ctx, &types.DescribeTaskListRequest{
+			Domain:                domain,
+			TaskList:              &types.TaskList{Name: taskList, Kind: types.TaskListKindNormal.Ptr()},
+			TaskListType:          taskListType.Ptr(),
+			IncludeTaskListStatus: true,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error describing %s task list: %v", taskListType, err)), nil
+		}
+		descriptions = append(descriptions, newTaskListDescription(taskListType, resp, now))
+	}
+	return jsonResult(descriptions)
+}
+
+func (i *inspector) listFailedWorkflowsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	domain, err := requiredString(request, "domain")
+	if err != nil {
+		return nil, err
+	}
+	query := failedWorkflowsQueryFilter
+	if extra := strings.TrimSpace(optionalString(request, "query")); extra != "" {
+		query = fmt.Sprintf("%s AND (%s)", failedWorkflowsQueryFilter, extra)
+	}
+	limit := optionalInt(request, "limit", defaultFailedWorkflows)
+	client, err := i.clients.Frontend(optionalString(request, "grpc_endpoint"))
+	if err != nil {
+		return mcp.NewToolResultError("Error creating frontend client: " + err.Error()), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultRPCTimeout)
+	defer cancel()
+
+	result := &failedWorkflows{Query: query, CountsByType: make(map[string]int)}
+	var nextPageToken []byte
+	for {
+		resp, err := client.ListWorkflowExecutions(ctx, &types.ListWorkflowExecutionsRequest{
+			Domain:        domain,
+			PageSize:      int32(min(limit, maxListWorkflowsPageSize)),
+			NextPageToken: nextPageToken,
+			Query:         query,
+		})
+		if err != nil {
+			return mcp.NewToolResultError("Error listing failed workflows: " + err.Error()), nil
+		}
+		for _, info := range resp.GetExecutions() {
+			if len(result.Workflows) >= limit {
+				result.Truncated = true
+				break
+			}
+			result.CountsByType[info.GetType().GetName()]++
+			result.Workflows = append(result.Workflows, failedWorkflow{
+				WorkflowID:    info.GetExecution().GetWorkflowID(),
+				RunID:         info.GetExecution().GetRunID(),
+				WorkflowType:  info.GetType().GetName(),
+				StartTime:     formatUnixNano(info.GetStartTime()),
+				CloseTime:     formatUnixNano(info.GetCloseTime()),
+				HistoryLength: info.HistoryLength,
+			})
+		}
+		nextPageToken = resp.NextPageToken
+		if len(nextPageToken) == 0 {
+			break
+		}
+		if len(result.Workflows) >= limit {
+			result.Truncated = true
+			break
+		}
+	}
+	return jsonResult(result)
+}
+
+func (i *inspector) domainResilienceHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	domainName, err := requiredString(request, "domain")
+	if err != nil {
+		return nil, err
+	}
+	endpoint := optionalString(request, "grpc_endpoint")
+	client, err := i.clients.Frontend(endpoint)
+	if err != nil {
+		return mcp.NewToolResultError("Error creating frontend client: " + err.Error()), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultRPCTimeout)
+	defer cancel()
+
+	domain, err := client.DescribeDomain(ctx, &types.DescribeDomainRequest{Name: &domainName})
+	if err != nil {
+		return mcp.NewToolResultError("Error describing domain: " + err.Error()), nil
+	}
+
+	return jsonResult(evaluateResilience(domain))
+}
+
+type (
+	workflowDescription struct {
+		WorkflowID        string                   `json:"workflowID"`
+		RunID             string                   `json:"runID"`
+		WorkflowType      string                   `json:"workflowType"`
+		TaskList          string                   `json:"taskList,omitempty"`
+		Status            string                   `json:"status"`
+		StartTime         string                   `json:"startTime,omitempty"`
+		CloseTime         string                   `json:"closeTime,omitempty"`
+		LastUpdateTime    string                   `json:"lastUpdateTime,omitempty"`
+		HistoryLength     int64                    `json:"historyLength"`
+		IsCron            bool                     `json:"isCron,omitempty"`
+		Parent            *types.WorkflowExecution `json:"parent,omitempty"`
+		PendingDecision   *pendingDecision         `json:"pendingDecision,omitempty"`
+		PendingActivities []pendingActivity        `json:"pendingActivities,omitempty"`
+		PendingChildren   []pendingChild           `json:"pendingChildren,omitempty"`
+	}
+
+	pendingDecision struct {
+		State         string `json:"state"`
+		Attempt       int64  `json:"attempt"`
+		ScheduledTime string `json:"scheduledTime,omitempty"`
+		StartedTime   string `json:"startedTime,omitempty"`
+	}
+
+	pendingActivity struct {
+		ActivityID        string `json:"activityID"`
+		ActivityType      string `json:"activityType"`
+		State             string `json:"state"`
+		Attempt           int32  `json:"attempt"`
+		MaximumAttempts   int32  `json:"maximumAttempts,omitempty"`
+		ScheduledTime     string `json:"scheduledTime,omitempty"`
+		LastStartedTime   string `json:"lastStartedTime,omitempty"`
+		LastHeartbeatTime string `json:"lastHeartbeatTime,omitempty"`
+		LastFailureReason string `json:"lastFailureReason,omitempty"`
+		LastWorker        string `json:"lastWorker,omitempty"`
+	}
+
+	pendingChild struct {
+		WorkflowID   string `json:"workflowID"`
+		RunID        string `json:"runID,omitempty"`
+		WorkflowType string `json:"workflowType"`
+		Domain       string `json:"domain,omitempty"`
+	}
+
+	taskListDescription struct {
+		Type              string       `json:"type"`
+		BacklogCountHint  int64        `json:"backlogCountHint"`
+		RatePerSecond     float64      `json:"ratePerSecond"`
+		NewTasksPerSecond float64      `json:"newTasksPerSecond"`
+		ReadPartitions    int          `json:"readPartitions,omitempty"`
+		WritePartitions   int          `json:"writePartitions,omitempty"`
+		Pollers           []taskPoller `json:"pollers"`
+		Findings          []string     `json:"findings,omitempty"`
+	}
+
+	taskPoller struct {
+		Identity       string  `json:"identity"`
+		LastAccessTime string  `json:"lastAccessTime"`
+		RatePerSecond  float64 `json:"ratePerSecond,omitempty"`
+	}
+
+	failedWorkflows struct {
+		Query        string           `json:"query"`
+		CountsByType map[string]int   `json:"countsByType"`
+		Workflows    []failedWorkflow `json:"workflows"`
+		Truncated    bool             `json:"truncated,omitempty"`
+	}
+
+	failedWorkflow struct {
+		WorkflowID    string `json:"workflowID"`
+		RunID         string `json:"runID"`
+		WorkflowType  string `json:"workflowType"`
+		StartTime     string `json:"startTime"`
+		CloseTime     string `json:"closeTime"`
+		HistoryLength int64  `json:"historyLength"`
+	}
+)
+
+func newWorkflowDescription(resp *types.DescribeWorkflowExecutionResponse) *workflowDescription {
+	info := resp.GetWorkflowExecutionInfo()
+	if info == nil {
+		info = &types.WorkflowExecutionInfo{}
+	}
+	d := &workflowDescription{
+		WorkflowID:     info.GetExecution().GetWorkflowID(),
+		RunID:          info.GetExecution().GetRunID(),
+		WorkflowType:   info.GetType().GetName(),
+		TaskList:       info.TaskList.GetName(),
+		Status:         "RUNNING",
+		StartTime:      formatUnixNano(info.GetStartTime()),
+		CloseTime:      formatUnixNano(info.GetCloseTime()),
+		LastUpdateTime: formatUnixNano(info.GetUpdateTime()),
+		HistoryLength:  info.HistoryLength,
+		IsCron:         info.IsCron,
+		Parent:         info.ParentExecution,
+	}
+	if info.CloseStatus != nil {
+		d.Status = info.CloseStatus.String()
+	}
+	if decision := resp.PendingDecision; decision != nil {
+		d.PendingDecision = &pendingDecision{
+			State:         enumString(decision.State),
+			Attempt:       decision.Attempt,
+			ScheduledTime: formatUnixNano(common.Int64Default(decision.ScheduledTimestamp)),
+			StartedTime:   formatUnixNano(common.Int64Default(decision.StartedTimestamp)),
+		}
+	}
+	for _, activity := range resp.GetPendingActivities() {
+		d.PendingActivities = append(d.PendingActivities, pendingActivity{
+			ActivityID:        activity.GetActivityID(),
+			ActivityType:      activity.ActivityType.GetName(),
+			State:             enumString(activity.State),
+			Attempt:           activity.GetAttempt(),
+			MaximumAttempts:   activity.GetMaximumAttempts(),
+			ScheduledTime:     formatUnixNano(common.Int64Default(activity.ScheduledTimestamp)),
+			LastStartedTime:   formatUnixNano(common.Int64Default(activity.LastStartedTimestamp)),
+			LastHeartbeatTime: formatUnixNano(activity.GetLastHeartbeatTimestamp()),
+			LastFailureReason: activity.GetLastFailureReason(),
+			LastWorker:        activity.GetLastWorkerIdentity(),
+		})
+	}
+	for _, child := range resp.PendingChildren {
+		d.PendingChildren = append(d.PendingChildren, pendingChild{
+			WorkflowID:   child.WorkflowID,
+			RunID:        child.RunID,
+			WorkflowType: child.WorkflowTypeName,
+			Domain:       child.Domain,
+		})
+	}
+	return d
+}
+
+func newTaskListDescription(taskListType types.TaskListType, resp *types.DescribeTaskListResponse, now time.Time) *taskListDescription {
+	status := resp.GetTaskListStatus()
+	if status == nil {
+		status = &types.TaskListStatus{}
+	}
+	d := &taskListDescription{
+		Type:              taskListType.String(),
+		BacklogCountHint:  status.BacklogCountHint,
+		RatePerSecond:     status.RatePerSecond,
+		NewTasksPerSecond: status.NewTasksPerSecond,
+		Pollers:           []taskPoller{},
+	}
+	if partitions := resp.PartitionConfig; partitions != nil {
+		d.ReadPartitions = len(partitions.ReadPartitions)
+		d.WritePartitions = len(partitions.WritePartitions)
+	}
+
+	var lastPoll time.Time
+	pollers := resp.GetPollers()
+	sort.Slice(pollers, func(i, j int) bool {
+		return pollers[i].GetLastAccessTime() > pollers[j].GetLastAccessTime()
+	})
+	for _, poller := range pollers {
+		accessTime := time.Unix(0, poller.GetLastAccessTime())
+		if accessTime.After(lastPoll) {
+			lastPoll = accessTime
+		}
+		d.Pollers = append(d.Pollers, taskPoller{
+			Identity:       poller.GetIdentity(),
+			LastAccessTime: formatUnixNano(poller.GetLastAccessTime()),
+			RatePerSecond:  poller.GetRatePerSecond(),
+		})
+	}
+
+	switch {
+	case len(pollers) == 0:
+		d.Findings = append(d.Findings, "No worker is polling this task list, tasks will not be processed until workers are started.")
+	case now.Sub(lastPoll) > stalePollerThreshold:
+		d.Findings = append(d.Findings, fmt.Sprintf("No worker has polled this task list for %v, workers may be stuck or gone.", now.Sub(lastPoll).Round(time.Second)))
+	}
+	if d.BacklogCountHint > 0 && d.NewTasksPerSecond > 0 && d.RatePerSecond > 0 && d.NewTasksPerSecond > d.RatePerSecond {
+		d.Findings = append(d.Findings, fmt.Sprintf("Tasks are added at %.1f/s but dispatched at most at %.1f/s, the backlog will keep growing.", d.NewTasksPerSecond, d.RatePerSecond))
+	}
+	return d
+}
+
+func executionArgs(request mcp.CallToolRequest) (string, *types.WorkflowExecution, error) {
+	domain, err := requiredString(request, "domain")
+	if err != nil {
+		return "", nil, err
+	}
+	workflowID, err := requiredString(request, "workflow_id")
+	if err != nil {
+		return "", nil, err
+	}
+	return domain, &types.WorkflowExecution{
+		WorkflowID: workflowID,
+		RunID:      optionalString(request, "run_id"),
+	}, nil
+}
+
+func requiredString(request mcp.CallToolRequest, name string) (string, error) {
+	value, ok := request.Params.Arguments[name].(string)
+	if !ok || value == "" {
+		return "", errors.New(name + " must be a non-empty string")
+	}
+	return value, nil
+}
+
+func optionalString(request mcp.CallToolRequest, name string) string {
+	value, _ := request.Params.Arguments[name].(string)
+	return value
+}
+
+// optionalInt reads a number argument, JSON numbers are decoded as float64
+func optionalInt(request mcp.CallToolRequest, name string, defaultValue int) int {
+	if value, ok := request.Params.Arguments[name].(float64); ok && value > 0 {
+		return int(value)
+	}
+	return defaultValue
+}
+
+func optionalBool(request mcp.CallToolRequest, name string, defaultValue bool) bool {
+	if value, ok := request.Params.Arguments[name].(bool); ok {
+		return value
+	}
+	return defaultValue
+}
+
+func jsonResult(v interface{}) (*mcp.CallToolResult, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError("Error encoding result: " + err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(data)), nil
+}
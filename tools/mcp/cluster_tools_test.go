@@ -0,0 +1,262 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+)
+
+type fakeClients struct {
+	frontend frontend.Client
+}
+
+func (f *fakeClients) Frontend(string) (frontend.Client, error) { return f.frontend, nil }
+
+func callTool(t *testing.T, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]interface{}) string {
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = args
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	text, ok := result.Content[0].(mcp.TextContent)
+	require.True(t, ok)
+	require.False(t, result.IsError, text.Text)
+	return text.Text
+}
+
+func TestSummarizeHistory(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	at := func(d time.Duration) *int64 { return common.Int64Ptr(start.Add(d).UnixNano()) }
+	events := []*types.HistoryEvent{
+		{ID: 1, Timestamp: at(0), EventType: types.EventTypeWorkflowExecutionStarted.Ptr(), WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{
+			WorkflowType: &types.WorkflowType{Name: "OrderWorkflow"},
+			TaskList:     &types.TaskList{Name: "orders"},
+		}},
+		{ID: 2, Timestamp: at(time.Second), EventType: types.EventTypeActivityTaskScheduled.Ptr(), ActivityTaskScheduledEventAttributes: &types.ActivityTaskScheduledEventAttributes{
+			ActivityType: &types.ActivityType{Name: "Charge"},
+		}},
+		{ID: 3, Timestamp: at(time.Hour), EventType: types.EventTypeActivityTaskFailed.Ptr(), ActivityTaskFailedEventAttributes: &types.ActivityTaskFailedEventAttributes{
+			ScheduledEventID: 2,
+			Reason:           common.StringPtr("card declined"),
+			Details:          []byte("insufficient funds"),
+		}},
+		{ID: 4, Timestamp: at(time.Hour + time.Second), EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(), WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{
+			SignalName: "retry",
+		}},
+		{ID: 5, Timestamp: at(time.Hour + 2*time.Second), EventType: types.EventTypeWorkflowExecutionFailed.Ptr(), WorkflowExecutionFailedEventAttributes: &types.WorkflowExecutionFailedEventAttributes{
+			Reason: common.StringPtr("payment failed"),
+		}},
+	}
+
+	s := summarizeHistory(events, true)
+	assert.Equal(t, 5, s.EventCount)
+	assert.True(t, s.Truncated)
+	assert.Equal(t, "OrderWorkflow", s.WorkflowType)
+	assert.Equal(t, "orders", s.TaskList)
+	assert.Equal(t, "WorkflowExecutionFailed", s.CloseEvent)
+	assert.Equal(t, &activityStats{Scheduled: 1, Failed: 1}, s.Activities["Charge"])
+	assert.Equal(t, map[string]int{"retry": 1}, s.Signals)
+	require.Len(t, s.Failures, 2)
+	assert.Equal(t, historyFailure{
+		EventID:   3,
+		EventType: "ActivityTaskFailed",
+		Time:      formatUnixNano(*at(time.Hour)),
+		Name:      "Charge",
+		Reason:    "card declined",
+		Details:   "insufficient funds",
+	}, s.Failures[0])
+	assert.Equal(t, "payment failed", s.Failures[1].Reason)
+	require.NotEmpty(t, s.LongestGaps)
+	assert.Equal(t, historyGap{AfterEventID: 2, Duration: time.Hour - time.Second}, s.LongestGaps[0])
+	assert.Len(t, s.RecentEvents, 5)
+}
+
+func TestEvaluateResilience(t *testing.T) {
+	domain := func() *types.DescribeDomainResponse {
+		return &types.DescribeDomainResponse{
+			DomainInfo:     &types.DomainInfo{Name: "orders", UUID: "domain-id"},
+			IsGlobalDomain: true,
+			ReplicationConfiguration: &types.DomainReplicationConfiguration{
+				ActiveClusterName: "cluster-a",
+				Clusters: []*types.ClusterReplicationConfiguration{
+					{ClusterName: "cluster-a"},
+					{ClusterName: "cluster-b"},
+				},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		domain    func() *types.DescribeDomainResponse
+		resilient bool
+		findings  int
+	}{
+		"healthy global domain": {
+			domain:    domain,
+			resilient: true,
+		},
+		"local domain": {
+			domain: func() *types.DescribeDomainResponse {
+				d := domain()
+				d.IsGlobalDomain = false
+				return d
+			},
+			findings: 1,
+		},
+		"single cluster": {
+			domain: func() *types.DescribeDomainResponse {
+				d := domain()
+				d.ReplicationConfiguration.Clusters = d.ReplicationConfiguration.Clusters[:1]
+				return d
+			},
+			findings: 1,
+		},
+		"active-active with every attribute in one cluster and an unknown cluster": {
+			domain: func() *types.DescribeDomainResponse {
+				d := domain()
+				d.ReplicationConfiguration.ActiveClusters = &types.ActiveClusters{
+					AttributeScopes: map[string]types.ClusterAttributeScope{
+						"region": {ClusterAttributes: map[string]types.ActiveClusterInfo{
+							"us-east": {ActiveClusterName: "cluster-c", FailoverVersion: 1},
+							"us-west": {ActiveClusterName: "cluster-c", FailoverVersion: 2},
+						}},
+					},
+				}
+				return d
+			},
+			findings: 3,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			report := evaluateResilience(tc.domain())
+			assert.Equal(t, tc.resilient, report.Resilient)
+			assert.Len(t, report.Findings, tc.findings, report.Findings)
+		})
+	}
+}
+
+func TestDomainResilienceHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	frontendClient := frontend.NewMockClient(ctrl)
+	i := &inspector{clients: &fakeClients{frontend: frontendClient}}
+
+	frontendClient.EXPECT().DescribeDomain(gomock.Any(), &types.DescribeDomainRequest{Name: common.StringPtr("orders")}).Return(&types.DescribeDomainResponse{
+		DomainInfo:     &types.DomainInfo{Name: "orders", UUID: "domain-id"},
+		IsGlobalDomain: true,
+		ReplicationConfiguration: &types.DomainReplicationConfiguration{
+			ActiveClusterName: "cluster-a",
+			Clusters:          []*types.ClusterReplicationConfiguration{{ClusterName: "cluster-a"}, {ClusterName: "cluster-b"}},
+		},
+	}, nil)
+
+	text := callTool(t, i.domainResilienceHandler, map[string]interface{}{"domain": "orders"})
+	var report resilienceReport
+	require.NoError(t, json.Unmarshal([]byte(text), &report))
+	assert.True(t, report.Resilient)
+	assert.Equal(t, []string{"cluster-a", "cluster-b"}, report.Clusters)
+	assert.Equal(t, []string{replicationLagNotChecked}, report.NotChecked)
+}
+
+func TestWithPanicRecovery(t *testing.T) {
+	handler := withPanicRecovery(func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var resp *types.DescribeDomainResponse
+		return mcp.NewToolResultText(resp.DomainInfo.Name), nil
+	})
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "domain_rr"
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	require.Len(t, result.Content, 1)
+	text, ok := result.Content[0].(mcp.TextContent)
+	require.True(t, ok)
+	assert.Contains(t, text.Text, "Internal error in domain_rr")
+}
+
+func TestDescribeTaskListHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	frontendClient := frontend.NewMockClient(ctrl)
+	i := &inspector{clients: &fakeClients{frontend: frontendClient}}
+
+	frontendClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).Return(&types.DescribeTaskListResponse{
+		TaskListStatus: &types.TaskListStatus{BacklogCountHint: 500, RatePerSecond: 10, NewTasksPerSecond: 50},
+		Pollers: []*types.PollerInfo{
+			{Identity: "worker-1", LastAccessTime: common.Int64Ptr(time.Now().UnixNano())},
+		},
+	}, nil)
+
+	text := callTool(t, i.describeTaskListHandler, map[string]interface{}{"domain": "orders", "task_list": "orders-tl", "task_list_type": "activity"})
+	var descriptions []taskListDescription
+	require.NoError(t, json.Unmarshal([]byte(text), &descriptions))
+	require.Len(t, descriptions, 1)
+	assert.Equal(t, types.TaskListTypeActivity.String(), descriptions[0].Type)
+	assert.Equal(t, int64(500), descriptions[0].BacklogCountHint)
+	assert.Len(t, descriptions[0].Pollers, 1)
+	assert.Len(t, descriptions[0].Findings, 1)
+}
+
+func TestDescribeTaskListHandler_NoPollers(t *testing.T) {
+	d := newTaskListDescription(types.TaskListTypeDecision, &types.DescribeTaskListResponse{}, time.Now())
+	assert.Equal(t, []string{"No worker is polling this task list, tasks will not be processed until workers are started."}, d.Findings)
+}
+
+func TestListFailedWorkflowsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	frontendClient := frontend.NewMockClient(ctrl)
+	i := &inspector{clients: &fakeClients{frontend: frontendClient}}
+
+	frontendClient.EXPECT().ListWorkflowExecutions(gomock.Any(), &types.ListWorkflowExecutionsRequest{
+		Domain:   "orders",
+		PageSize: 2,
+		Query:    `CloseStatus = "FAILED" AND (WorkflowType = 'OrderWorkflow')`,
+	}).Return(&types.ListWorkflowExecutionsResponse{
+		Executions: []*types.WorkflowExecutionInfo{
+			{Execution: &types.WorkflowExecution{WorkflowID: "wf-1", RunID: "run-1"}, Type: &types.WorkflowType{Name: "OrderWorkflow"}},
+			{Execution: &types.WorkflowExecution{WorkflowID: "wf-2", RunID: "run-2"}, Type: &types.WorkflowType{Name: "OrderWorkflow"}},
+		},
+		NextPageToken: []byte("next"),
+	}, nil)
+
+	text := callTool(t, i.listFailedWorkflowsHandler, map[string]interface{}{
+		"domain": "orders",
+		"query":  "WorkflowType = 'OrderWorkflow'",
+		"limit":  float64(2),
+	})
+	var result failedWorkflows
+	require.NoError(t, json.Unmarshal([]byte(text), &result))
+	assert.True(t, result.Truncated)
+	assert.Equal(t, map[string]int{"OrderWorkflow": 2}, result.CountsByType)
+	assert.Len(t, result.Workflows, 2)
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	maxSummaryFailures     = 20
+	maxSummaryRecentEvents = 10
+	maxSummaryGaps         = 5
+	maxFailureDetailsSize  = 512
+)
+
+type (
+	// historySummary condenses a workflow history into what matters when triaging it
+	historySummary struct {
+		EventCount       int                       `json:"eventCount"`
+		Truncated        bool                      `json:"truncated,omitempty"`
+		WorkflowType     string                    `json:"workflowType,omitempty"`
+		TaskList         string                    `json:"taskList,omitempty"`
+		ContinuedFrom    string                    `json:"continuedFromRunID,omitempty"`
+		FirstEventTime   string                    `json:"firstEventTime,omitempty"`
+		LastEventTime    string                    `json:"lastEventTime,omitempty"`
+		CloseEvent       string                    `json:"closeEvent,omitempty"`
+		EventTypeCounts  map[string]int            `json:"eventTypeCounts"`
+		Activities       map[string]*activityStats `json:"activities,omitempty"`
+		ChildWorkflows   map[string]*activityStats `json:"childWorkflows,omitempty"`
+		Signals          map[string]int            `json:"signals,omitempty"`
+		DecisionFailures int                       `json:"decisionFailures,omitempty"`
+		DecisionTimeouts int                       `json:"decisionTimeouts,omitempty"`
+		Failures         []historyFailure          `json:"failures,omitempty"`
+		LongestGaps      []historyGap              `json:"longestGaps,omitempty"`
+		RecentEvents     []historyEventLine        `json:"recentEvents"`
+	}
+
+	// activityStats counts the outcomes of an activity or child workflow type
+	activityStats struct {
+		Scheduled int `json:"scheduled"`
+		Completed int `json:"completed"`
+		Failed    int `json:"failed,omitempty"`
+		TimedOut  int `json:"timedOut,omitempty"`
+		Canceled  int `json:"canceled,omitempty"`
+	}
+
+	historyFailure struct {
+		EventID   int64  `json:"eventID"`
+		EventType string `json:"eventType"`
+		Time      string `json:"time"`
+		Name      string `json:"name,omitempty"`
+		Reason    string `json:"reason,omitempty"`
+		Details   string `json:"details,omitempty"`
+	}
+
+	historyEventLine struct {
+		EventID   int64  `json:"eventID"`
+		EventType string `json:"eventType"`
+		Time      string `json:"time"`
+	}
+
+	// historyGap is a period without any event, usually a timer, a slow activity or a stuck worker
+	historyGap struct {
+		AfterEventID int64         `json:"afterEventID"`
+		Duration     time.Duration `json:"duration"`
+	}
+)
+
+// summarizeHistory computes the summary of the given events, truncated marks a history that was not read to the end
+func summarizeHistory(events []*types.HistoryEvent, truncated bool) *historySummary {
+	s := &historySummary{
+		EventCount:      len(events),
+		Truncated:       truncated,
+		EventTypeCounts: make(map[string]int),
+		Activities:      make(map[string]*activityStats),
+		ChildWorkflows:  make(map[string]*activityStats),
+		Signals:         make(map[string]int),
+	}
+	if len(events) == 0 {
+		return s
+	}
+	s.FirstEventTime = formatUnixNano(events[0].GetTimestamp())
+	s.LastEventTime = formatUnixNano(events[len(events)-1].GetTimestamp())
+
+	activityTypes := make(map[int64]string)
+	childTypes := make(map[int64]string)
+	var gaps []historyGap
+	for i, event := range events {
+		s.EventTypeCounts[event.GetEventType().String()]++
+		if i > 0 {
+			if gap := time.Duration(event.GetTimestamp() - events[i-1].GetTimestamp()); gap > 0 {
+				gaps = append(gaps, historyGap{AfterEventID: events[i-1].ID, Duration: gap})
+			}
+		}
+
+		switch event.GetEventType() {
+		case types.EventTypeWorkflowExecutionStarted:
+			attr := event.WorkflowExecutionStartedEventAttributes
+			s.WorkflowType = attr.WorkflowType.GetName()
+			s.TaskList = attr.TaskList.GetName()
+			s.ContinuedFrom = attr.GetContinuedExecutionRunID()
+		case types.EventTypeActivityTaskScheduled:
+			name := event.ActivityTaskScheduledEventAttributes.GetActivityType().GetName()
+			activityTypes[event.ID] = name
+			s.activity(name).Scheduled++
+		case types.EventTypeActivityTaskCompleted:
+			s.activity(activityTypes[event.ActivityTaskCompletedEventAttributes.GetScheduledEventID()]).Completed++
+		case types.EventTypeActivityTaskFailed:
+			attr := event.ActivityTaskFailedEventAttributes
+			name := activityTypes[attr.GetScheduledEventID()]
+			s.activity(name).Failed++
+			s.addFailure(event, name, common.StringDefault(attr.Reason), attr.Details)
+		case types.EventTypeActivityTaskTimedOut:
+			attr := event.ActivityTaskTimedOutEventAttributes
+			name := activityTypes[attr.GetScheduledEventID()]
+			s.activity(name).TimedOut++
+			s.addFailure(event, name, attr.GetTimeoutType().String(), attr.LastFailureDetails)
+		case types.EventTypeActivityTaskCanceled:
+			s.activity(activityTypes[event.ActivityTaskCanceledEventAttributes.GetScheduledEventID()]).Canceled++
+		case types.EventTypeStartChildWorkflowExecutionInitiated:
+			name := event.StartChildWorkflowExecutionInitiatedEventAttributes.GetWorkflowType().GetName()
+			childTypes[event.ID] = name
+			s.child(name).Scheduled++
+		case types.EventTypeStartChildWorkflowExecutionFailed:
+			attr := event.StartChildWorkflowExecutionFailedEventAttributes
+			name := childTypes[attr.GetInitiatedEventID()]
+			s.child(name).Failed++
+			s.addFailure(event, name, enumString(attr.Cause), nil)
+		case types.EventTypeChildWorkflowExecutionCompleted:
+			s.child(childTypes[event.ChildWorkflowExecutionCompletedEventAttributes.GetInitiatedEventID()]).Completed++
+		case types.EventTypeChildWorkflowExecutionFailed:
+			attr := event.ChildWorkflowExecutionFailedEventAttributes
+			name := childTypes[attr.GetInitiatedEventID()]
+			s.child(name).Failed++
+			s.addFailure(event, name, common.StringDefault(attr.Reason), attr.Details)
+		case types.EventTypeChildWorkflowExecutionTimedOut:
+			attr := event.ChildWorkflowExecutionTimedOutEventAttributes
+			name := childTypes[attr.GetInitiatedEventID()]
+			s.child(name).TimedOut++
+			s.addFailure(event, name, enumString(attr.TimeoutType), nil)
+		case types.EventTypeChildWorkflowExecutionCanceled:
+			s.child(childTypes[event.ChildWorkflowExecutionCanceledEventAttributes.GetInitiatedEventID()]).Canceled++
+		case types.EventTypeWorkflowExecutionSignaled:
+			s.Signals[event.WorkflowExecutionSignaledEventAttributes.GetSignalName()]++
+		case types.EventTypeDecisionTaskFailed:
+			attr := event.DecisionTaskFailedEventAttributes
+			s.DecisionFailures++
+			s.addFailure(event, attr.GetCause().String(), common.StringDefault(attr.Reason), attr.Details)
+		case types.EventTypeDecisionTaskTimedOut:
+			attr := event.DecisionTaskTimedOutEventAttributes
+			s.DecisionTimeouts++
+			s.addFailure(event, attr.GetCause().String(), attr.GetTimeoutType().String(), nil)
+		case types.EventTypeWorkflowExecutionFailed:
+			attr := event.WorkflowExecutionFailedEventAttributes
+			s.CloseEvent = event.GetEventType().String()
+			s.addFailure(event, s.WorkflowType, attr.GetReason(), attr.Details)
+		case types.EventTypeWorkflowExecutionTimedOut:
+			s.CloseEvent = event.GetEventType().String()
+			s.addFailure(event, s.WorkflowType, event.WorkflowExecutionTimedOutEventAttributes.GetTimeoutType().String(), nil)
+		case types.EventTypeWorkflowExecutionCompleted,
+			types.EventTypeWorkflowExecutionCanceled,
+			types.EventTypeWorkflowExecutionTerminated,
+			types.EventTypeWorkflowExecutionContinuedAsNew:
+			s.CloseEvent = event.GetEventType().String()
+		}
+	}
+
+	sort.SliceStable(gaps, func(i, j int) bool {
+		return gaps[i].Duration > gaps[j].Duration
+	})
+	if len(gaps) > maxSummaryGaps {
+		gaps = gaps[:maxSummaryGaps]
+	}
+	s.LongestGaps = gaps
+
+	recent := events
+	if len(recent) > maxSummaryRecentEvents {
+		recent = recent[len(recent)-maxSummaryRecentEvents:]
+	}
+	for _, event := range recent {
+		s.RecentEvents = append(s.RecentEvents, historyEventLine{
+			EventID:   event.ID,
+			EventType: event.GetEventType().String(),
+			Time:      formatUnixNano(event.GetTimestamp()),
+		})
+	}
+	return s
+}
+
+func (s *historySummary) activity(name string) *activityStats {
+	if _, ok := s.Activities[name]; !ok {
+		s.Activities[name] = &activityStats{}
+	}
+	return s.Activities[name]
+}
+
+func (s *historySummary) child(name string) *activityStats {
+	if _, ok := s.ChildWorkflows[name]; !ok {
+		s.ChildWorkflows[name] = &activityStats{}
+	}
+	return s.ChildWorkflows[name]
+}
+
+// addFailure keeps the latest failures, older ones are the least useful when looking at what went wrong
+func (s *historySummary) addFailure(event *types.HistoryEvent, name, reason string, details []byte) {
+	if len(details) > maxFailureDetailsSize {
+		details = append(details[:maxFailureDetailsSize:maxFailureDetailsSize], "..."...)
+	}
+	s.Failures = append(s.Failures, historyFailure{
+		EventID:   event.ID,
+		EventType: event.GetEventType().String(),
+		Time:      formatUnixNano(event.GetTimestamp()),
+		Name:      name,
+		Reason:    reason,
+		Details:   string(details),
+	})
+	if len(s.Failures) > maxSummaryFailures {
+		s.Failures = s.Failures[1:]
+	}
+}
+
+func formatUnixNano(unixNano int64) string {
+	if unixNano == 0 {
+		return ""
+	}
+	return time.Unix(0, unixNano).UTC().Format(time.RFC3339Nano)
+}
+
+func enumString[T fmt.Stringer](v *T) string {
+	if v == nil {
+		return ""
+	}
+	return (*v).String()
+}
@@ -26,6 +26,7 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"os/exec"
@@ -37,7 +38,19 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
+const frontendAddressEnv = "CADENCE_MCP_FRONTEND_ADDRESS"
+
 func main() {
+	defaultAddress := os.Getenv(frontendAddressEnv)
+	if defaultAddress == "" {
+		defaultAddress = "localhost:7833"
+	}
+	address := flag.String("address", defaultAddress, "gRPC endpoint of the cadence frontend to inspect, can also be set with "+frontendAddressEnv)
+	flag.Parse()
+
+	clients := newGRPCClients(*address)
+	defer clients.Stop()
+	inspector := &inspector{clients: clients}
 
 	// Create MCP server
 	s := server.NewMCPServer(
@@ -48,16 +61,75 @@ func main() {
 
 	// Add tool handlers
 	s.AddTool(mcp.NewTool("domain_rr",
-		mcp.WithDescription("Check if a cadence domain is resilient to regional outages"),
+		mcp.WithDescription("Evaluate whether a cadence domain is resilient to regional outages: checks it is global and replicated to several clusters, "+
+			"validates its active-active cluster attributes and reports in-progress failovers. "+
+			"Replication lag is not checked, no API reports it per domain, it is only available from the history service metrics"),
 		mcp.WithString("domain",
 			mcp.Required(),
 			mcp.Description("Name of the cadence domain to check"),
 		),
-		mcp.WithString("grpc_endpoint",
-			mcp.DefaultString("localhost:7833"),
-			mcp.Description("gRPC endpoint of the cadence domain"),
+		endpointArgument(),
+	), withPanicRecovery(inspector.domainResilienceHandler))
+
+	s.AddTool(mcp.NewTool("describe_workflow",
+		mcp.WithDescription("Describe a workflow execution: status, timestamps, history length, pending decision, pending activities with their attempts and last failures, and pending child workflows"),
+		workflowArguments(),
+		endpointArgument(),
+	), withPanicRecovery(inspector.describeWorkflowHandler))
+
+	s.AddTool(mcp.NewTool("workflow_history",
+		mcp.WithDescription("Fetch the history of a workflow execution and summarize it: event counts, per activity and child workflow outcomes, signals, "+
+			"decision failures, the latest failures with their reasons, the longest gaps between events and the most recent events"),
+		workflowArguments(),
+		mcp.WithNumber("max_events",
+			mcp.DefaultNumber(defaultMaxHistoryEvents),
+			mcp.Description("Maximum number of events to read, the summary is marked as truncated beyond it"),
+		),
+		endpointArgument(),
+	), withPanicRecovery(inspector.workflowHistoryHandler))
+
+	s.AddTool(mcp.NewTool("diagnose_workflow",
+		mcp.WithDescription("Run Cadence diagnostics on a workflow execution, which looks for timeouts, failures and retry issues, and return the report as Markdown"),
+		workflowArguments(),
+		mcp.WithNumber("wait_seconds",
+			mcp.DefaultNumber(defaultDiagnosticsWait.Seconds()),
+			mcp.Description("How long to wait for the diagnostics to complete before returning"),
+		),
+		endpointArgument(),
+	), withPanicRecovery(inspector.diagnoseWorkflowHandler))
+
+	s.AddTool(mcp.NewTool("describe_task_list",
+		mcp.WithDescription("Describe a task list: backlog, add and dispatch rates, partitions and pollers, with findings such as missing or stale workers"),
+		mcp.WithString("domain",
+			mcp.Required(),
+			mcp.Description("Name of the cadence domain"),
+		),
+		mcp.WithString("task_list",
+			mcp.Required(),
+			mcp.Description("Name of the task list"),
 		),
-	), domainRRHandler)
+		mcp.WithString("task_list_type",
+			mcp.DefaultString("both"),
+			mcp.Description("decision, activity or both"),
+		),
+		endpointArgument(),
+	), withPanicRecovery(inspector.describeTaskListHandler))
+
+	s.AddTool(mcp.NewTool("list_failed_workflows",
+		mcp.WithDescription("List failed workflow executions of a domain, optionally narrowed down by a visibility query, with counts per workflow type"),
+		mcp.WithString("domain",
+			mcp.Required(),
+			mcp.Description("Name of the cadence domain"),
+		),
+		mcp.WithString("query",
+			mcp.Description("Additional visibility query, e.g. WorkflowType = 'MyWorkflow' AND CloseTime > '2024-01-01T00:00:00Z'"),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(defaultFailedWorkflows),
+			mcp.Description("Maximum number of workflows to return"),
+		),
+		endpointArgument(),
+	), withPanicRecovery(inspector.listFailedWorkflowsHandler))
 
 	s.AddTool(mcp.NewTool("payload_decoder",
 		mcp.WithDescription("Decode a payload that is encoded by hex or base64. The payload is from Cadence database."),
@@ -83,7 +155,7 @@ func main() {
 		),
 	), cadenceCommandGeneratorHandler)
 
-	debugLog("Cadence MCP started")
+	debugLog("Cadence MCP started, inspecting %s", *address)
 
 	// Start the stdio server
 	if err := server.ServeStdio(s); err != nil {
@@ -93,47 +165,42 @@ func main() {
 	debugLog("Cadence MCP stopped")
 }
 
-func domainRRHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	defer func() {
-		// recover from panic
-		if r := recover(); r != nil {
-			// include the stack trace
-			debugLog("Panic: %v\n", r)
-			debugLog("Stack trace: %s\n", string(debug.Stack()))
-		}
-	}()
-
-	domain, ok := request.Params.Arguments["domain"].(string)
-	if !ok {
-		return nil, errors.New("domain must be a string")
-	}
-
-	endpoint, ok := request.Params.Arguments["grpc_endpoint"].(string)
-	if !ok {
-		endpoint = "localhost:7833"
-	}
-
-	// run cadence CLI to check if it's a global domain or not
-	cmd := exec.Command("docker", "run", "-t", "--rm", "--network", "host", "ubercadence/cli:master",
-		"--transport", "grpc",
-		"--address", endpoint,
-		"--domain", domain,
-		"domain", "describe")
-	// run the cmd and capture both stdout and stderr
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		debugLog("Error checking domain resilience: %v, %s\n", err, string(output))
-		return mcp.NewToolResultError("Error checking domain resilience: " + err.Error() + "\n" + string(output)), nil
+// withPanicRecovery turns a panic of a tool handler into an error result, so that
+// a bad response from the cluster does not bring the whole MCP server down
+func withPanicRecovery(handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				// include the stack trace
+				debugLog("Panic in %s: %v\n", request.Params.Name, r)
+				debugLog("Stack trace: %s\n", string(debug.Stack()))
+				result, err = mcp.NewToolResultError(fmt.Sprintf("Internal error in %s: %v", request.Params.Name, r)), nil
+			}
+		}()
+		return handler(ctx, request)
 	}
+}
 
-	// parse the output of the cadence CLI
-	// if it contains "IsGlobal(XDC)Domain: true" then it's a global domain
-	// otherwise it's not
-	if strings.Contains(string(output), "IsGlobal(XDC)Domain: true") {
-		return mcp.NewToolResultText("Yes, this domain is resilient to regional outages"), nil
+func workflowArguments() mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		mcp.WithString("domain",
+			mcp.Required(),
+			mcp.Description("Name of the cadence domain"),
+		)(t)
+		mcp.WithString("workflow_id",
+			mcp.Required(),
+			mcp.Description("Workflow ID"),
+		)(t)
+		mcp.WithString("run_id",
+			mcp.Description("Run ID, defaults to the current run"),
+		)(t)
 	}
+}
 
-	return mcp.NewToolResultText("No, this domain is not resilient to regional outages. Consider making it a global domain."), nil
+func endpointArgument() mcp.ToolOption {
+	return mcp.WithString("grpc_endpoint",
+		mcp.Description("gRPC endpoint of the cadence frontend, defaults to the one the server was started with"),
+	)
 }
 
 func payloadDecoderHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/uber/cadence/common/types"
+)
+
+type (
+	// resilienceReport explains whether a domain survives losing a region and what stands in the way
+	resilienceReport struct {
+		Domain            string                    `json:"domain"`
+		Resilient         bool                      `json:"resilient"`
+		IsGlobalDomain    bool                      `json:"isGlobalDomain"`
+		ActiveCluster     string                    `json:"activeCluster,omitempty"`
+		Clusters          []string                  `json:"clusters,omitempty"`
+		ActiveActive      bool                      `json:"activeActive"`
+		ClusterAttributes []clusterAttributeSummary `json:"clusterAttributes,omitempty"`
+		FailoverVersion   int64                     `json:"failoverVersion"`
+		Findings          []string                  `json:"findings,omitempty"`
+		NotChecked        []string                  `json:"notChecked,omitempty"`
+	}
+
+	clusterAttributeSummary struct {
+		Scope           string `json:"scope"`
+		Name            string `json:"name"`
+		ActiveCluster   string `json:"activeCluster"`
+		FailoverVersion int64  `json:"failoverVersion"`
+	}
+)
+
+// replicationLagNotChecked explains why a global domain can be reported resilient while its replication lags behind.
+// No admin or frontend API reports replication lag per domain, history only emits it as metrics.
+const replicationLagNotChecked = "Replication lag is not checked, a domain lagging behind loses its latest updates on failover. " +
+	"Check the replication lag metrics of the history service before relying on a failover."
+
+// evaluateResilience checks the replication config of a domain. Replication lag is not checked, see replicationLagNotChecked.
+func evaluateResilience(domain *types.DescribeDomainResponse) *resilienceReport {
+	replicationConfig := domain.ReplicationConfiguration
+	report := &resilienceReport{
+		Domain:          domain.GetDomainInfo().GetName(),
+		IsGlobalDomain:  domain.GetIsGlobalDomain(),
+		ActiveCluster:   replicationConfig.GetActiveClusterName(),
+		ActiveActive:    replicationConfig.IsActiveActive(),
+		FailoverVersion: domain.GetFailoverVersion(),
+	}
+	clusters := make(map[string]bool)
+	for _, cluster := range replicationConfig.GetClusters() {
+		clusters[cluster.GetClusterName()] = true
+		report.Clusters = append(report.Clusters, cluster.GetClusterName())
+	}
+	sort.Strings(report.Clusters)
+
+	if !report.IsGlobalDomain {
+		report.Findings = append(report.Findings, "Domain is not global, it only exists in a single cluster. Consider making it a global domain.")
+		return report
+	}
+	report.NotChecked = append(report.NotChecked, replicationLagNotChecked)
+	if len(report.Clusters) < 2 {
+		report.Findings = append(report.Findings, "Domain is global but only replicated to a single cluster. Add at least one more cluster to fail over to.")
+	}
+	if !clusters[report.ActiveCluster] {
+		report.Findings = append(report.Findings, fmt.Sprintf("Active cluster %q is not part of the domain's clusters.", report.ActiveCluster))
+	}
+
+	if report.ActiveActive {
+		activeClusters := make(map[string]bool)
+		for scope, attributes := range replicationConfig.GetActiveClusters().GetAttributeScopes() {
+			for name, info := range attributes.ClusterAttributes {
+				report.ClusterAttributes = append(report.ClusterAttributes, clusterAttributeSummary{
+					Scope:           scope,
+					Name:            name,
+					ActiveCluster:   info.ActiveClusterName,
+					FailoverVersion: info.FailoverVersion,
+				})
+				activeClusters[info.ActiveClusterName] = true
+				if !clusters[info.ActiveClusterName] {
+					report.Findings = append(report.Findings, fmt.Sprintf("Cluster attribute %s.%s is active in %q which is not part of the domain's clusters.", scope, name, info.ActiveClusterName))
+				}
+			}
+		}
+		sort.Slice(report.ClusterAttributes, func(i, j int) bool {
+			a, b := report.ClusterAttributes[i], report.ClusterAttributes[j]
+			if a.Scope != b.Scope {
+				return a.Scope < b.Scope
+			}
+			return a.Name < b.Name
+		})
+		if len(activeClusters) == 1 {
+			report.Findings = append(report.Findings, "Domain is active-active but every cluster attribute is active in the same cluster, a regional outage affects all of its workflows.")
+		}
+	}
+
+	if info := domain.GetFailoverInfo(); info != nil && len(info.PendingShards) > 0 {
+		report.Findings = append(report.Findings, fmt.Sprintf("A graceful failover to version %d is in progress with %d shards pending.", info.FailoverVersion, len(info.PendingShards)))
+	}
+
+	report.Resilient = len(report.Findings) == 0
+	return report
+}